@@ -76,6 +76,20 @@ func (s APIHostPortsSetter) SetAPIHostPorts(servers [][]network.HostPort) error
 	})
 }
 
+// CACertSetter trivially wraps an Agent to implement
+// worker/cacertupdater/CACertSetter.
+type CACertSetter struct {
+	Agent
+}
+
+// SetCACert is the CACertSetter interface.
+func (s CACertSetter) SetCACert(caCert string) error {
+	return s.ChangeConfig(func(c ConfigSetter) error {
+		c.SetCACert(caCert)
+		return nil
+	})
+}
+
 // StateServingInfoSetter trivially wraps an Agent to implement
 // worker/certupdater/SetStateServingInfo.
 type StateServingInfoSetter struct {
@@ -248,12 +248,17 @@ func connectWebsocket(info *Info, opts DialOpts) (*websocket.Conn, *tls.Config,
 		return nil, nil, errors.New("no API addresses to connect to")
 	}
 	tlsConfig := utils.SecureTLSConfig()
-	// We want to be specific here (rather than just using "anything".
-	// See commit 7fc118f015d8480dfad7831788e4b8c0432205e8 (PR 899).
-	tlsConfig.ServerName = "juju-apiserver"
 	tlsConfig.InsecureSkipVerify = opts.InsecureSkipVerify
 
-	if !tlsConfig.InsecureSkipVerify {
+	// When trusting the system's CAs, the controller must present a
+	// certificate that the system trusts for the name we dial, so the
+	// server name is left for the TLS client to fill in.
+	if !info.TrustSystemCA {
+		// We want to be specific here (rather than just using "anything".
+		// See commit 7fc118f015d8480dfad7831788e4b8c0432205e8 (PR 899).
+		tlsConfig.ServerName = "juju-apiserver"
+	}
+	if !tlsConfig.InsecureSkipVerify && !info.TrustSystemCA {
 		certPool, err := CreateCertPool(info.CACert)
 		if err != nil {
 			return nil, nil, errors.Annotate(err, "cert pool creation failed")
@@ -8,6 +8,8 @@
 package application
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -146,6 +148,12 @@ type SetCharmConfig struct {
 	// ResourceIDs is a map of resource names to resource IDs to activate during
 	// the upgrade.
 	ResourceIDs map[string]string
+	// BatchSize, if non-zero, upgrades at most this many units at a
+	// time, waiting for each batch to settle before the next.
+	BatchSize int
+	// BatchTimeout, if non-zero, is how long each batch of units may
+	// take to settle before the upgrade is halted.
+	BatchTimeout time.Duration
 }
 
 // SetCharm sets the charm for a given service.
@@ -157,10 +165,43 @@ func (c *Client) SetCharm(cfg SetCharmConfig) error {
 		ForceSeries:     cfg.ForceSeries,
 		ForceUnits:      cfg.ForceUnits,
 		ResourceIDs:     cfg.ResourceIDs,
+		BatchSize:       cfg.BatchSize,
+		BatchTimeout:    cfg.BatchTimeout,
 	}
 	return c.facade.FacadeCall("SetCharm", args, nil)
 }
 
+// RollbackCharm changes the application's charm back to the one it ran
+// before its last upgrade, and returns the URL of that charm. If
+// forceUnits is true, units in an error state are rolled back too.
+func (c *Client) RollbackCharm(application string, forceUnits bool) (*charm.URL, error) {
+	args := params.ApplicationRollbackCharm{
+		ApplicationName: application,
+		ForceUnits:      forceUnits,
+	}
+	var result params.StringResult
+	if err := c.facade.FacadeCall("RollbackCharm", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return charm.ParseURL(result.Result)
+}
+
+// SetPlacementPolicy sets how the application's units are placed. spread
+// is the scope, one of "machine", "host" or "zone", within which no two
+// units may be placed; colocate names an application whose machines are
+// preferred. Empty values clear that part of the policy.
+func (c *Client) SetPlacementPolicy(application, spread, colocate string) error {
+	args := params.ApplicationPlacementPolicy{
+		ApplicationName: application,
+		Spread:          spread,
+		Colocate:        colocate,
+	}
+	return c.facade.FacadeCall("SetPlacementPolicy", args, nil)
+}
+
 // Update updates the application attributes, including charm URL,
 // minimum number of units, settings and constraints.
 func (c *Client) Update(args params.ApplicationUpdate) error {
@@ -212,11 +253,21 @@ func (c *Client) SetConstraints(application string, constraints constraints.Valu
 
 // Expose changes the juju-managed firewall to expose any ports that
 // were also explicitly marked by units as open.
-func (c *Client) Expose(application string) error {
-	params := params.ApplicationExpose{ApplicationName: application}
+//
+// If any source CIDRs are given, access to the ports is restricted to
+// them; otherwise the model's default ingress CIDRs apply.
+func (c *Client) Expose(application string, cidrs ...string) error {
+	params := params.ApplicationExpose{ApplicationName: application, CIDRs: cidrs}
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
+// SetTrust sets whether the application's units may access the model's
+// cloud credential.
+func (c *Client) SetTrust(application string, trust bool) error {
+	params := params.ApplicationTrust{ApplicationName: application, Trust: trust}
+	return c.facade.FacadeCall("SetTrust", params, nil)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(application string) error {
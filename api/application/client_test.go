@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -163,3 +165,73 @@ func (s *serviceSuite) TestServiceSetCharm(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *serviceSuite) TestServiceSetCharmRolling(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetCharm")
+		args, ok := a.(params.ApplicationSetCharm)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args.BatchSize, gc.Equals, 2)
+		c.Assert(args.BatchTimeout, gc.Equals, 5*time.Minute)
+		return nil
+	})
+	cfg := application.SetCharmConfig{
+		ApplicationName: "application",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/application-1"),
+		},
+		BatchSize:    2,
+		BatchTimeout: 5 * time.Minute,
+	}
+	err := s.client.SetCharm(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestServiceRollbackCharm(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "RollbackCharm")
+		c.Assert(a, jc.DeepEquals, params.ApplicationRollbackCharm{
+			ApplicationName: "application",
+			ForceUnits:      true,
+		})
+		result := response.(*params.StringResult)
+		result.Result = "cs:trusty/application-1"
+		return nil
+	})
+	curl, err := s.client.RollbackCharm("application", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl, gc.DeepEquals, charm.MustParseURL("cs:trusty/application-1"))
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestServiceSetPlacementPolicy(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetPlacementPolicy")
+		c.Assert(a, jc.DeepEquals, params.ApplicationPlacementPolicy{
+			ApplicationName: "wordpress",
+			Spread:          "zone",
+			Colocate:        "mysql",
+		})
+		return nil
+	})
+	err := s.client.SetPlacementPolicy("wordpress", "zone", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestServiceRollbackCharmError(c *gc.C) {
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		result := response.(*params.StringResult)
+		result.Error = &params.Error{Message: "previous charm not found"}
+		return nil
+	})
+	_, err := s.client.RollbackCharm("application", false)
+	c.Assert(err, gc.ErrorMatches, "previous charm not found")
+}
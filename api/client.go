@@ -17,6 +17,7 @@ import (
 	"github.com/juju/version"
 	"golang.org/x/net/websocket"
 	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
@@ -25,6 +26,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/downloader"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/tools"
@@ -267,6 +269,24 @@ func (c *Client) ModelGet() (map[string]interface{}, error) {
 	return result.Config, err
 }
 
+// ModelGetWithSource returns all model settings, annotated
+// with the source of each value.
+func (c *Client) ModelGetWithSource() (config.ConfigValues, error) {
+	result := params.ModelConfigSourceResults{}
+	err := c.facade.FacadeCall("ModelGetWithSource", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	values := make(config.ConfigValues)
+	for name, val := range result.Config {
+		values[name] = config.ConfigValue{
+			Value:  val.Value,
+			Source: val.Source,
+		}
+	}
+	return values, nil
+}
+
 // ModelSet sets the given key-value pairs in the model.
 func (c *Client) ModelSet(config map[string]interface{}) error {
 	args := params.ModelSet{Config: config}
@@ -292,6 +312,22 @@ func (c *Client) AbortCurrentUpgrade() error {
 	return c.facade.FacadeCall("AbortCurrentUpgrade", nil, nil)
 }
 
+// SetModelAgentCanary upgrades the agents on the given machines to the
+// given version ahead of the rest of the model.
+func (c *Client) SetModelAgentCanary(version version.Number, machineIds []string) error {
+	args := params.SetModelAgentCanary{
+		Version:    version,
+		MachineIds: machineIds,
+	}
+	return c.facade.FacadeCall("SetModelAgentCanary", args, nil)
+}
+
+// AbortModelAgentCanary abandons the model's canary upgrade, returning
+// the canary machines to the model agent version.
+func (c *Client) AbortModelAgentCanary() error {
+	return c.facade.FacadeCall("AbortModelAgentCanary", nil, nil)
+}
+
 // FindTools returns a List containing all tools matching the specified parameters.
 func (c *Client) FindTools(majorVersion, minorVersion int, series, arch string) (result params.FindToolsResult, err error) {
 	args := params.FindToolsParams{
@@ -373,6 +409,56 @@ func (c *Client) UploadCharm(curl *charm.URL, content io.ReadSeeker) (*charm.URL
 	return curl, nil
 }
 
+// UploadMirroredCharm uploads the archive of a charm store charm to
+// the controller's charm mirror. The controller must have
+// charmstore-mirror enabled, and the user must be a controller
+// administrator. The given URL must include the charm's series and
+// its revision in the charm store, and hash must be the archive's
+// SHA384 hash as recorded by the charm store.
+func (c *Client) UploadMirroredCharm(curl *charm.URL, hash string, content io.ReadSeeker) error {
+	if curl.Schema != "cs" || curl.Series == "" || curl.Revision < 0 {
+		return errors.Errorf("expected fully qualified charm store URL, got %q", curl)
+	}
+	v := url.Values{}
+	v.Set("series", curl.Series)
+	v.Set("schema", curl.Schema)
+	v.Set("user", curl.User)
+	v.Set("revision", fmt.Sprint(curl.Revision))
+	v.Set("sha384", hash)
+	var resp params.CharmsResponse
+	if err := c.httpPost(content, "/charms?"+v.Encode(), "application/zip", &resp); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// UploadMirroredResource uploads the content of a charm store
+// resource of the given charm, which must already have been uploaded
+// with UploadMirroredCharm, to the controller's charm mirror.
+func (c *Client) UploadMirroredResource(curl *charm.URL, res charmresource.Resource, content io.ReadSeeker) error {
+	v := url.Values{}
+	v.Set("url", curl.String())
+	v.Set("name", res.Name)
+	v.Set("revision", fmt.Sprint(res.Revision))
+	v.Set("fingerprint", res.Fingerprint.String())
+	req, err := http.NewRequest("POST", "/mirror-resources?"+v.Encode(), nil)
+	if err != nil {
+		return errors.Annotate(err, "cannot create upload request")
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.ContentLength = res.Size
+
+	httpClient, err := c.st.HTTPClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var resp params.ErrorResult
+	if err := httpClient.Do(req, content, &resp); err != nil {
+		return errors.Annotatef(err, "cannot upload resource %q", res.Name)
+	}
+	return nil
+}
+
 type minJujuVersionErr struct {
 	*errors.Err
 }
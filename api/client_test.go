@@ -28,6 +28,7 @@ import (
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
 	jujunames "github.com/juju/juju/juju/names"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/rpc"
@@ -514,6 +515,43 @@ func (s *clientSuite) TestAbortCurrentUpgrade(c *gc.C) {
 	c.Assert(err, gc.Equals, someErr) // Confirms that the correct facade was called
 }
 
+func (s *clientSuite) TestSetModelAgentCanary(c *gc.C) {
+	client := s.APIState.Client()
+	someErr := errors.New("random")
+	cleanup := api.PatchClientFacadeCall(client,
+		func(request string, args interface{}, response interface{}) error {
+			c.Assert(request, gc.Equals, "SetModelAgentCanary")
+			c.Assert(args, jc.DeepEquals, params.SetModelAgentCanary{
+				Version:    version.MustParse("9.8.7"),
+				MachineIds: []string{"1", "2"},
+			})
+			c.Assert(response, gc.IsNil)
+			return someErr
+		},
+	)
+	defer cleanup()
+
+	err := client.SetModelAgentCanary(version.MustParse("9.8.7"), []string{"1", "2"})
+	c.Assert(err, gc.Equals, someErr)
+}
+
+func (s *clientSuite) TestAbortModelAgentCanary(c *gc.C) {
+	client := s.APIState.Client()
+	someErr := errors.New("random")
+	cleanup := api.PatchClientFacadeCall(client,
+		func(request string, args interface{}, response interface{}) error {
+			c.Assert(request, gc.Equals, "AbortModelAgentCanary")
+			c.Assert(args, gc.IsNil)
+			c.Assert(response, gc.IsNil)
+			return someErr
+		},
+	)
+	defer cleanup()
+
+	err := client.AbortModelAgentCanary()
+	c.Assert(err, gc.Equals, someErr)
+}
+
 func (s *clientSuite) TestEnvironmentGet(c *gc.C) {
 	client := s.APIState.Client()
 	env, err := client.ModelGet()
@@ -522,6 +560,17 @@ func (s *clientSuite) TestEnvironmentGet(c *gc.C) {
 	c.Assert(env["type"], gc.Equals, "dummy")
 }
 
+func (s *clientSuite) TestModelGetWithSource(c *gc.C) {
+	client := s.APIState.Client()
+	err := client.ModelSet(map[string]interface{}{"some-name": "value"})
+	c.Assert(err, jc.ErrorIsNil)
+	values, err := client.ModelGetWithSource()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["some-name"], jc.DeepEquals, config.ConfigValue{
+		Value: "value", Source: "model",
+	})
+}
+
 func (s *clientSuite) TestEnvironmentSet(c *gc.C) {
 	client := s.APIState.Client()
 	err := client.ModelSet(map[string]interface{}{
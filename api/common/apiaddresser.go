@@ -79,3 +79,29 @@ func (a *APIAddresser) WatchAPIHostPorts() (watcher.NotifyWatcher, error) {
 	}
 	return apiwatcher.NewNotifyWatcher(a.facade.RawAPICaller(), result), nil
 }
+
+// TrustedCACert returns the CA certificates that should be trusted when
+// validating the API connection. While the controller CA is being
+// rotated, both the current and the new CA certificates are returned.
+func (a *APIAddresser) TrustedCACert() (string, error) {
+	var result params.StringResult
+	err := a.facade.FacadeCall("TrustedCACert", nil, &result)
+	if err != nil {
+		return "", err
+	}
+	if err := result.Error; err != nil {
+		return "", err
+	}
+	return result.Result, nil
+}
+
+// WatchCACert watches the CA certificates that should be trusted when
+// validating the API connection.
+func (a *APIAddresser) WatchCACert() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	err := a.facade.FacadeCall("WatchCACert", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return apiwatcher.NewNotifyWatcher(a.facade.RawAPICaller(), result), nil
+}
@@ -75,6 +75,32 @@ func (c *Client) DestroyController(destroyModels bool) error {
 	return c.facade.FacadeCall("DestroyController", args, nil)
 }
 
+// SetAPICertificate sets the custom certificate chain and private key
+// presented to clients of the controller API. Empty values remove any
+// custom certificate.
+func (c *Client) SetAPICertificate(certPEM, keyPEM string) error {
+	args := params.SetAPICertificateArgs{
+		Cert:       certPEM,
+		PrivateKey: keyPEM,
+	}
+	return errors.Trace(c.facade.FacadeCall("SetAPICertificate", args, nil))
+}
+
+// RotateCertificates starts a rotation of the controller CA, or
+// finishes the rotation in progress if finish is true. It returns the
+// PEM-encoded CA certificates that should now be trusted when
+// connecting to the controller, and whether the rotation is complete.
+func (c *Client) RotateCertificates(finish bool) (string, bool, error) {
+	args := params.RotateCertificatesArgs{
+		Finish: finish,
+	}
+	var result params.RotateCertificatesResult
+	if err := c.facade.FacadeCall("RotateCertificates", args, &result); err != nil {
+		return "", false, errors.Trace(err)
+	}
+	return result.CACert, result.Complete, nil
+}
+
 // ListBlockedModels returns a list of all models within the controller
 // which have at least one block in place.
 func (c *Client) ListBlockedModels() ([]params.ModelBlockInfo, error) {
@@ -171,6 +197,21 @@ func (s *ModelMigrationSpec) Validate() error {
 	return nil
 }
 
+func (s *ModelMigrationSpec) initiateArgs() params.InitiateModelMigrationArgs {
+	return params.InitiateModelMigrationArgs{
+		Specs: []params.ModelMigrationSpec{{
+			ModelTag: names.NewModelTag(s.ModelUUID).String(),
+			TargetInfo: params.ModelMigrationTargetInfo{
+				ControllerTag: names.NewModelTag(s.TargetControllerUUID).String(),
+				Addrs:         s.TargetAddrs,
+				CACert:        s.TargetCACert,
+				AuthTag:       names.NewUserTag(s.TargetUser).String(),
+				Password:      s.TargetPassword,
+			},
+		}},
+	}
+}
+
 // InitiateModelMigration attempts to start a migration for the
 // specified model, returning the migration's ID.
 //
@@ -181,18 +222,7 @@ func (c *Client) InitiateModelMigration(spec ModelMigrationSpec) (string, error)
 	if err := spec.Validate(); err != nil {
 		return "", errors.Trace(err)
 	}
-	args := params.InitiateModelMigrationArgs{
-		Specs: []params.ModelMigrationSpec{{
-			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
-			TargetInfo: params.ModelMigrationTargetInfo{
-				ControllerTag: names.NewModelTag(spec.TargetControllerUUID).String(),
-				Addrs:         spec.TargetAddrs,
-				CACert:        spec.TargetCACert,
-				AuthTag:       names.NewUserTag(spec.TargetUser).String(),
-				Password:      spec.TargetPassword,
-			},
-		}},
-	}
+	args := spec.initiateArgs()
 	response := params.InitiateModelMigrationResults{}
 	if err := c.facade.FacadeCall("InitiateModelMigration", args, &response); err != nil {
 		return "", errors.Trace(err)
@@ -206,3 +236,25 @@ func (c *Client) InitiateModelMigration(spec ModelMigrationSpec) (string, error)
 	}
 	return result.Id, nil
 }
+
+// PrecheckModelMigration runs the source and target prechecks for
+// the specified model migration without starting it, returning every
+// problem that would prevent the model from being migrated.
+func (c *Client) PrecheckModelMigration(spec ModelMigrationSpec) ([]string, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := spec.initiateArgs()
+	response := params.ModelMigrationPrecheckResults{}
+	if err := c.facade.FacadeCall("PrecheckModelMigration", args, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return nil, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Problems, nil
+}
@@ -91,6 +91,34 @@ func (s *controllerSuite) TestDestroyController(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `failed to destroy model: hosting 1 other models \(controller has hosted models\)`)
 }
 
+func (s *controllerSuite) TestRotateCertificates(c *gc.C) {
+	sysManager := s.OpenAPI(c)
+	caCert, complete, err := sysManager.RotateCertificates(false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(complete, jc.IsFalse)
+	trusted, err := s.State.TrustedCACert()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(caCert, gc.Equals, trusted)
+
+	caCert, complete, err = sysManager.RotateCertificates(true)
+	c.Assert(err, jc.ErrorIsNil)
+	trusted, err = s.State.TrustedCACert()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(caCert, gc.Equals, trusted)
+	_, err = s.State.CARotation()
+	c.Assert(complete, gc.Equals, errors.IsNotFound(err))
+}
+
+func (s *controllerSuite) TestSetAPICertificate(c *gc.C) {
+	sysManager := s.OpenAPI(c)
+	err := sysManager.SetAPICertificate(testing.ServerCert, testing.ServerKey)
+	c.Assert(err, jc.ErrorIsNil)
+	certPEM, keyPEM, err := s.State.APICertificate()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(certPEM, gc.Equals, testing.ServerCert)
+	c.Assert(keyPEM, gc.Equals, testing.ServerKey)
+}
+
 func (s *controllerSuite) TestListBlockedModels(c *gc.C) {
 	err := s.State.SwitchBlockOn(state.ChangeBlock, "change block for controller")
 	err = s.State.SwitchBlockOn(state.DestroyBlock, "destroy block for controller")
@@ -220,6 +248,22 @@ func (s *controllerSuite) TestInitiateModelMigrationError(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "unable to read model: .+")
 }
 
+func (s *controllerSuite) TestPrecheckModelMigrationError(c *gc.C) {
+	spec := controller.ModelMigrationSpec{
+		ModelUUID:            randomUUID(), // Model doesn't exist.
+		TargetControllerUUID: randomUUID(),
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "someone",
+		TargetPassword:       "secret",
+	}
+
+	controller := s.OpenAPI(c)
+	problems, err := controller.PrecheckModelMigration(spec)
+	c.Check(problems, gc.HasLen, 0)
+	c.Check(err, gc.ErrorMatches, "unable to read model: .+")
+}
+
 func randomUUID() string {
 	return utils.MustNewUUID().String()
 }
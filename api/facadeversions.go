@@ -21,14 +21,14 @@ var facadeVersions = map[string]int{
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  1,
+	"Application":                  2,
 	"ApplicationScaler":            1,
 	"Backups":                      1,
 	"Block":                        2,
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
-	"Client":                       1,
+	"Client":                       2,
 	"Cloud":                        1,
 	"Controller":                   3,
 	"Deployer":                     1,
@@ -48,18 +48,18 @@ var facadeVersions = map[string]int{
 	"LifeFlag":                     1,
 	"Logger":                       1,
 	"MachineActions":               1,
-	"MachineManager":               2,
+	"MachineManager":               3,
 	"Machiner":                     1,
 	"MeterStatus":                  1,
 	"MetricsAdder":                 2,
-	"MetricsDebug":                 2,
+	"MetricsDebug":                 3,
 	"MetricsManager":               1,
 	"MigrationFlag":                1,
 	"MigrationMaster":              1,
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
 	"MigrationTarget":              1,
-	"ModelManager":                 2,
+	"ModelManager":                 3,
 	"NotifyWatcher":                1,
 	"Pinger":                       1,
 	"Provisioner":                  3,
@@ -68,6 +68,7 @@ var facadeVersions = map[string]int{
 	"RelationUnitsWatcher":         1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
+	"RollingUpgrader":              1,
 	"Singular":                     1,
 	"Spaces":                       2,
 	"SSHClient":                    1,
@@ -79,6 +80,7 @@ var facadeVersions = map[string]int{
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
 	"Uniter":                       4,
+	"UpgradeSeries":                1,
 	"Upgrader":                     1,
 	"UserManager":                  1,
 	"VolumeAttachmentsWatcher":     2,
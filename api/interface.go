@@ -42,6 +42,11 @@ type Info struct {
 	// to validate the controller's certificate, in PEM format.
 	CACert string
 
+	// TrustSystemCA specifies that the controller's certificate
+	// is validated against the system's trusted CAs rather than
+	// CACert, for controllers presenting a custom certificate.
+	TrustSystemCA bool
+
 	// ModelTag holds the model tag for the model we are
 	// trying to connect to.
 	ModelTag names.ModelTag
@@ -93,7 +98,7 @@ func (info *Info) Validate() error {
 	if _, err := network.ParseHostPorts(info.Addrs...); err != nil {
 		return errors.NotValidf("host addresses")
 	}
-	if info.CACert == "" {
+	if info.CACert == "" && !info.TrustSystemCA {
 		return errors.NotValidf("missing CA certificate")
 	}
 	if info.SkipLogin {
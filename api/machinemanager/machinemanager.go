@@ -5,6 +5,7 @@ package machinemanager
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
@@ -36,3 +37,51 @@ func (client *Client) AddMachines(machineParams []params.AddMachineParams) ([]pa
 	}
 	return results.Machines, err
 }
+
+// UpgradeSeriesPrepare locks the machine for an in-place upgrade to the
+// given series, and requests that the units on it run their
+// pre-series-upgrade hooks.
+func (client *Client) UpgradeSeriesPrepare(machineName, series string, force bool) error {
+	args := params.UpgradeSeriesPrepareArg{
+		Entity: params.Entity{Tag: names.NewMachineTag(machineName).String()},
+		Series: series,
+		Force:  force,
+	}
+	var result params.ErrorResult
+	if err := client.facade.FacadeCall("UpgradeSeriesPrepare", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// UpgradeSeriesComplete requests that the units on a machine whose OS
+// release has been upgraded run their post-series-upgrade hooks.
+func (client *Client) UpgradeSeriesComplete(machineName string) error {
+	args := params.Entity{Tag: names.NewMachineTag(machineName).String()}
+	var result params.ErrorResult
+	if err := client.facade.FacadeCall("UpgradeSeriesComplete", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// UpgradeSeriesAbort abandons the unfinished series upgrade of a
+// machine: the units on it run their post-series-upgrade hooks, and the
+// machine is unlocked without changing its series.
+func (client *Client) UpgradeSeriesAbort(machineName string) error {
+	args := params.Entity{Tag: names.NewMachineTag(machineName).String()}
+	var result params.ErrorResult
+	if err := client.facade.FacadeCall("UpgradeSeriesAbort", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
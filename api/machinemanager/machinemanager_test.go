@@ -116,3 +116,70 @@ func (s *MachinemanagerSuite) TestAddMachinesResultCountInvalid(c *gc.C) {
 		c.Check(err, gc.ErrorMatches, fmt.Sprintf("expected 1 result, got %d", n))
 	}
 }
+
+func (s *MachinemanagerSuite) TestUpgradeSeriesPrepare(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "MachineManager")
+		c.Check(request, gc.Equals, "UpgradeSeriesPrepare")
+		c.Check(arg, gc.DeepEquals, params.UpgradeSeriesPrepareArg{
+			Entity: params.Entity{Tag: "machine-1"},
+			Series: "xenial",
+			Force:  true,
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResult{})
+		callCount++
+		return nil
+	})
+	st := machinemanager.NewClient(apiCaller)
+	err := st.UpgradeSeriesPrepare("1", "xenial", true)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *MachinemanagerSuite) TestUpgradeSeriesPrepareServerError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ErrorResult)) = params.ErrorResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	st := machinemanager.NewClient(apiCaller)
+	err := st.UpgradeSeriesPrepare("1", "xenial", false)
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *MachinemanagerSuite) TestUpgradeSeriesComplete(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "MachineManager")
+		c.Check(request, gc.Equals, "UpgradeSeriesComplete")
+		c.Check(arg, gc.DeepEquals, params.Entity{Tag: "machine-1"})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResult{})
+		callCount++
+		return nil
+	})
+	st := machinemanager.NewClient(apiCaller)
+	err := st.UpgradeSeriesComplete("1")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *MachinemanagerSuite) TestUpgradeSeriesAbort(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "MachineManager")
+		c.Check(request, gc.Equals, "UpgradeSeriesAbort")
+		c.Check(arg, gc.DeepEquals, params.Entity{Tag: "machine-1"})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResult{})
+		*(result.(*params.ErrorResult)) = params.ErrorResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		callCount++
+		return nil
+	})
+	st := machinemanager.NewClient(apiCaller)
+	err := st.UpgradeSeriesAbort("1")
+	c.Check(err, gc.ErrorMatches, "boom")
+	c.Check(callCount, gc.Equals, 1)
+}
@@ -32,8 +32,15 @@ type MeterStatusClient interface {
 	SetMeterStatus(tag, code, info string) error
 }
 
+// MetricsQueryClient defines methods on the metricsdebug API end point.
+type MetricsQueryClient interface {
+	// AggregateMetrics will aggregate the metrics selected by the query.
+	AggregateMetrics(query params.MetricsQuery) ([]params.AggregatedMetric, error)
+}
+
 var _ MetricsDebugClient = (*Client)(nil)
 var _ MeterStatusClient = (*Client)(nil)
+var _ MetricsQueryClient = (*Client)(nil)
 
 // NewClient creates a new client for accessing the metricsdebug api
 func NewClient(st base.APICallCloser) *Client {
@@ -79,3 +86,15 @@ func (c *Client) SetMeterStatus(tag, code, info string) error {
 	}
 	return nil
 }
+
+// AggregateMetrics will aggregate the metrics selected by the query.
+func (c *Client) AggregateMetrics(query params.MetricsQuery) ([]params.AggregatedMetric, error) {
+	var result params.AggregatedMetricsResult
+	if err := c.facade.FacadeCall("AggregateMetrics", query, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Metrics, nil
+}
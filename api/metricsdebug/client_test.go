@@ -175,6 +175,63 @@ func (s *metricsdebugSuiteMock) TestSetMeterStatusFacadeCallError(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *metricsdebugSuiteMock) TestAggregateMetrics(c *gc.C) {
+	var called bool
+	now := time.Now()
+	query := params.MetricsQuery{
+		Entities:    []params.Entity{{"application-metered"}},
+		Interval:    time.Hour,
+		Aggregation: "sum",
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			c.Assert(request, gc.Equals, "AggregateMetrics")
+			c.Assert(a, jc.DeepEquals, query)
+			result := response.(*params.AggregatedMetricsResult)
+			result.Metrics = []params.AggregatedMetric{{
+				Unit:  "metered/0",
+				Key:   "pings",
+				Time:  now,
+				Value: 5,
+				Count: 2,
+			}}
+			called = true
+			return nil
+		})
+	client := metricsdebug.NewClient(apiCaller)
+	metrics, err := client.AggregateMetrics(query)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(metrics, jc.DeepEquals, []params.AggregatedMetric{{
+		Unit:  "metered/0",
+		Key:   "pings",
+		Time:  now,
+		Value: 5,
+		Count: 2,
+	}})
+}
+
+func (s *metricsdebugSuiteMock) TestAggregateMetricsFails(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			result := response.(*params.AggregatedMetricsResult)
+			result.Error = common.ServerError(errors.New("an error"))
+			return nil
+		})
+	client := metricsdebug.NewClient(apiCaller)
+	metrics, err := client.AggregateMetrics(params.MetricsQuery{Aggregation: "sum"})
+	c.Assert(err, gc.ErrorMatches, "an error")
+	c.Assert(metrics, gc.IsNil)
+}
+
 type metricsdebugSuite struct {
 	jujutesting.JujuConnSuite
 	manager *metricsdebug.Client
@@ -4,7 +4,14 @@
 package migrationmaster
 
 import (
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
@@ -30,27 +37,68 @@ type Client interface {
 	SetPhase(migration.Phase) error
 
 	// Export returns a serialized representation of the model
-	// associated with the API connection.
-	Export() ([]byte, error)
+	// associated with the API connection, along with the charms and
+	// agent binaries it uses.
+	Export() (migration.SerializedModel, error)
+
+	// OpenCharm streams out the archive of the identified charm from
+	// the controller.
+	OpenCharm(*charm.URL) (io.ReadCloser, error)
+
+	// OpenTools streams out the identified agent binaries from the
+	// controller.
+	OpenTools(version.Binary) (io.ReadCloser, error)
+
+	// Prechecks checks that the model associated with the API
+	// connection is in a fit state to be migrated, returning every
+	// problem found.
+	Prechecks() ([]string, error)
+
+	// ModelInfo returns the details of the model associated with the
+	// API connection that the target controller needs to run its
+	// prechecks.
+	ModelInfo() (migration.ModelInfo, error)
+
+	// WatchMinionReports returns a watcher which reports when a
+	// migration minion has made a report for the current migration
+	// phase.
+	WatchMinionReports() (watcher.NotifyWatcher, error)
+
+	// MinionReports returns details of the reports made by migration
+	// minions to the controller for the current migration phase.
+	MinionReports() (migration.MinionReports, error)
+
+	// ModelLogs returns approximately limit of the model's log
+	// records which were logged strictly after the time given.
+	ModelLogs(after time.Time, limit int) ([]migration.LogRecord, error)
+
+	// Reap removes all documents for the model associated with the
+	// API connection once it has been migrated.
+	Reap() error
 }
 
 // MigrationStatus returns the details for a migration as needed by
 // the migration master worker.
 type MigrationStatus struct {
-	ModelUUID  string
-	Attempt    int
-	Phase      migration.Phase
-	TargetInfo migration.TargetInfo
+	MigrationId string
+	ModelUUID   string
+	Attempt     int
+	Phase       migration.Phase
+	TargetInfo  migration.TargetInfo
 }
 
 // NewClient returns a new Client based on an existing API connection.
 func NewClient(caller base.APICaller) Client {
-	return &client{base.NewFacadeCaller(caller, "MigrationMaster")}
+	return &client{
+		caller:     base.NewFacadeCaller(caller, "MigrationMaster"),
+		httpCaller: caller,
+	}
 }
 
 // client implements Client.
 type client struct {
-	caller base.FacadeCaller
+	caller     base.FacadeCaller
+	httpCaller base.APICaller
 }
 
 // Watch implements Client.
@@ -98,9 +146,10 @@ func (c *client) GetMigrationStatus() (MigrationStatus, error) {
 	}
 
 	return MigrationStatus{
-		ModelUUID: modelTag.Id(),
-		Attempt:   status.Attempt,
-		Phase:     phase,
+		MigrationId: status.MigrationId,
+		ModelUUID:   modelTag.Id(),
+		Attempt:     status.Attempt,
+		Phase:       phase,
 		TargetInfo: migration.TargetInfo{
 			ControllerTag: controllerTag,
 			Addrs:         target.Addrs,
@@ -120,11 +169,176 @@ func (c *client) SetPhase(phase migration.Phase) error {
 }
 
 // Export implements Client.
-func (c *client) Export() ([]byte, error) {
+func (c *client) Export() (migration.SerializedModel, error) {
 	var serialized params.SerializedModel
 	err := c.caller.FacadeCall("Export", nil, &serialized)
 	if err != nil {
-		return nil, err
+		return migration.SerializedModel{}, err
+	}
+
+	tools := make(map[version.Binary]string)
+	for _, toolsInfo := range serialized.Tools {
+		v, err := version.ParseBinary(toolsInfo.Version)
+		if err != nil {
+			return migration.SerializedModel{}, errors.Annotate(err, "error parsing agent binary version")
+		}
+		tools[v] = toolsInfo.SHA256
+	}
+
+	return migration.SerializedModel{
+		Bytes:  serialized.Bytes,
+		Charms: serialized.Charms,
+		Tools:  tools,
+	}, nil
+}
+
+// OpenCharm implements Client.
+func (c *client) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	query := url.Values{}
+	query.Set("url", curl.String())
+	query.Set("file", "*")
+	return c.openResource("/charms?" + query.Encode())
+}
+
+// OpenTools implements Client.
+func (c *client) OpenTools(v version.Binary) (io.ReadCloser, error) {
+	return c.openResource("/tools/" + v.String())
+}
+
+func (c *client) openResource(uri string) (io.ReadCloser, error) {
+	req, err := http.NewRequest("GET", uri, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create HTTP request")
+	}
+	// The returned httpClient sets the base url to /model/<uuid>.
+	httpClient, err := c.httpCaller.HTTPClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var resp *http.Response
+	if err := httpClient.Do(req, nil, &resp); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return resp.Body, nil
+}
+
+// Prechecks implements Client.
+func (c *client) Prechecks() ([]string, error) {
+	var result params.MigrationPrecheckResult
+	err := c.caller.FacadeCall("Prechecks", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Problems, nil
+}
+
+// ModelInfo implements Client.
+func (c *client) ModelInfo() (migration.ModelInfo, error) {
+	var info params.MigrationModelInfo
+	err := c.caller.FacadeCall("ModelInfo", nil, &info)
+	if err != nil {
+		return migration.ModelInfo{}, errors.Trace(err)
+	}
+	owner, err := names.ParseUserTag(info.OwnerTag)
+	if err != nil {
+		return migration.ModelInfo{}, errors.Annotate(err, "parsing owner tag")
+	}
+	return migration.ModelInfo{
+		UUID:            info.UUID,
+		Name:            info.Name,
+		Owner:           owner,
+		AgentVersion:    info.AgentVersion,
+		CloudType:       info.CloudType,
+		CloudRegion:     info.CloudRegion,
+		CloudCredential: info.CloudCredential,
+	}, nil
+}
+
+// WatchMinionReports implements Client.
+func (c *client) WatchMinionReports() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	err := c.caller.FacadeCall("WatchMinionReports", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(c.caller.RawAPICaller(), result)
+	return w, nil
+}
+
+// MinionReports implements Client.
+func (c *client) MinionReports() (migration.MinionReports, error) {
+	var in params.MinionReports
+	var out migration.MinionReports
+
+	err := c.caller.FacadeCall("MinionReports", nil, &in)
+	if err != nil {
+		return out, errors.Trace(err)
+	}
+
+	out.MigrationId = in.MigrationId
+
+	phase, ok := migration.ParsePhase(in.Phase)
+	if !ok {
+		return out, errors.Errorf("invalid phase: %q", in.Phase)
+	}
+	out.Phase = phase
+
+	out.SuccessCount = in.SuccessCount
+	out.UnknownCount = in.UnknownCount
+
+	out.SomeUnknown, err = convertTags(in.UnknownSample)
+	if err != nil {
+		return out, errors.Annotate(err, "processing unknown agents")
+	}
+
+	out.Failed, err = convertTags(in.Failed)
+	if err != nil {
+		return out, errors.Annotate(err, "processing failed agents")
+	}
+
+	return out, nil
+}
+
+// ModelLogs implements Client.
+func (c *client) ModelLogs(after time.Time, limit int) ([]migration.LogRecord, error) {
+	args := params.ModelLogsArgs{
+		After: after,
+		Limit: limit,
+	}
+	var result params.ModelLogs
+	if err := c.caller.FacadeCall("ModelLogs", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	records := make([]migration.LogRecord, len(result.Records))
+	for i, rec := range result.Records {
+		records[i] = migration.LogRecord{
+			Time:     rec.Time,
+			Entity:   rec.Entity,
+			Module:   rec.Module,
+			Location: rec.Location,
+			Level:    rec.Level,
+			Message:  rec.Message,
+		}
+	}
+	return records, nil
+}
+
+// Reap implements Client.
+func (c *client) Reap() error {
+	return c.caller.FacadeCall("Reap", nil, nil)
+}
+
+func convertTags(tagStrs []string) ([]names.Tag, error) {
+	out := make([]names.Tag, 0, len(tagStrs))
+	for _, tagStr := range tagStrs {
+		tag, err := names.ParseTag(tagStr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		out = append(out, tag)
 	}
-	return serialized.Bytes, nil
+	return out, nil
 }
@@ -4,13 +4,20 @@
 package migrationmaster_test
 
 import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/httprequest"
+	"github.com/juju/loggo"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
 	apitesting "github.com/juju/juju/api/base/testing"
@@ -101,8 +108,9 @@ func (s *ClientSuite) TestGetMigrationStatus(c *gc.C) {
 					Password:      "secret",
 				},
 			},
-			Attempt: 3,
-			Phase:   "READONLY",
+			MigrationId: "id",
+			Attempt:     3,
+			Phase:       "READONLY",
 		}
 		return nil
 	})
@@ -111,9 +119,10 @@ func (s *ClientSuite) TestGetMigrationStatus(c *gc.C) {
 	status, err := client.GetMigrationStatus()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(status, gc.DeepEquals, migrationmaster.MigrationStatus{
-		ModelUUID: modelUUID,
-		Attempt:   3,
-		Phase:     migration.READONLY,
+		MigrationId: "id",
+		ModelUUID:   modelUUID,
+		Attempt:     3,
+		Phase:       migration.READONLY,
 		TargetInfo: migration.TargetInfo{
 			ControllerTag: names.NewModelTag(controllerUUID),
 			Addrs:         []string{"2.2.2.2:2"},
@@ -153,16 +162,55 @@ func (s *ClientSuite) TestExport(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		stub.AddCall(objType+"."+request, id, arg)
 		out := result.(*params.SerializedModel)
-		*out = params.SerializedModel{Bytes: []byte("foo")}
+		*out = params.SerializedModel{
+			Bytes:  []byte("foo"),
+			Charms: []string{"cs:foo-1"},
+			Tools: []params.SerializedModelTools{{
+				Version: "2.0.0-trusty-amd64",
+				SHA256:  "abcd",
+			}},
+		}
 		return nil
 	})
 	client := migrationmaster.NewClient(apiCaller)
-	bytes, err := client.Export()
+	out, err := client.Export()
 	c.Assert(err, jc.ErrorIsNil)
 	stub.CheckCalls(c, []jujutesting.StubCall{
 		{"MigrationMaster.Export", []interface{}{"", nil}},
 	})
-	c.Assert(string(bytes), gc.Equals, "foo")
+	c.Assert(out, gc.DeepEquals, migration.SerializedModel{
+		Bytes:  []byte("foo"),
+		Charms: []string{"cs:foo-1"},
+		Tools: map[version.Binary]string{
+			version.MustParseBinary("2.0.0-trusty-amd64"): "abcd",
+		},
+	})
+}
+
+func (s *ClientSuite) TestOpenCharm(c *gc.C) {
+	doer := &fakeDoer{response: "charm"}
+	client := migrationmaster.NewClient(&fakeHTTPCaller{doer: doer})
+	r, err := client.OpenCharm(charm.MustParseURL("cs:foo-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "charm")
+	c.Assert(doer.method, gc.Equals, "GET")
+	c.Assert(doer.url, gc.Equals, "https://api.example.com/model/uuid/charms?file=%2A&url=cs%3Afoo-1")
+}
+
+func (s *ClientSuite) TestOpenTools(c *gc.C) {
+	doer := &fakeDoer{response: "tools"}
+	client := migrationmaster.NewClient(&fakeHTTPCaller{doer: doer})
+	r, err := client.OpenTools(version.MustParseBinary("2.0.0-trusty-amd64"))
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "tools")
+	c.Assert(doer.method, gc.Equals, "GET")
+	c.Assert(doer.url, gc.Equals, "https://api.example.com/model/uuid/tools/2.0.0-trusty-amd64")
 }
 
 func (s *ClientSuite) TestExportError(c *gc.C) {
@@ -173,3 +221,185 @@ func (s *ClientSuite) TestExportError(c *gc.C) {
 	_, err := client.Export()
 	c.Assert(err, gc.ErrorMatches, "blam")
 }
+
+func (s *ClientSuite) TestPrechecks(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		out := result.(*params.MigrationPrecheckResult)
+		*out = params.MigrationPrecheckResult{Problems: []string{"model is dying"}}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	problems, err := client.Prechecks()
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.Prechecks", []interface{}{"", nil}},
+	})
+	c.Assert(problems, jc.DeepEquals, []string{"model is dying"})
+}
+
+func (s *ClientSuite) TestModelInfo(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, v int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		out := result.(*params.MigrationModelInfo)
+		*out = params.MigrationModelInfo{
+			UUID:         "uuid",
+			Name:         "name",
+			OwnerTag:     names.NewUserTag("owner").String(),
+			AgentVersion: version.MustParse("2.0.0"),
+			CloudType:    "ec2",
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	info, err := client.ModelInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.ModelInfo", []interface{}{"", nil}},
+	})
+	c.Assert(info, gc.DeepEquals, migration.ModelInfo{
+		UUID:         "uuid",
+		Name:         "name",
+		Owner:        names.NewUserTag("owner"),
+		AgentVersion: version.MustParse("2.0.0"),
+		CloudType:    "ec2",
+	})
+}
+
+func (s *ClientSuite) TestMinionReports(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, v int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		out := result.(*params.MinionReports)
+		*out = params.MinionReports{
+			MigrationId:   "id",
+			Phase:         "IMPORT",
+			SuccessCount:  4,
+			UnknownCount:  3,
+			UnknownSample: []string{"machine-0", "unit-foo-0"},
+			Failed:        []string{"machine-1"},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	reports, err := client.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.MinionReports", []interface{}{"", nil}},
+	})
+	c.Assert(reports, gc.DeepEquals, migration.MinionReports{
+		MigrationId:  "id",
+		Phase:        migration.IMPORT,
+		SuccessCount: 4,
+		UnknownCount: 3,
+		SomeUnknown:  []names.Tag{names.NewMachineTag("0"), names.NewUnitTag("foo/0")},
+		Failed:       []names.Tag{names.NewMachineTag("1")},
+	})
+}
+
+func (s *ClientSuite) TestMinionReportsBadPhase(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(_ string, _ int, _, _ string, _, result interface{}) error {
+		out := result.(*params.MinionReports)
+		*out = params.MinionReports{Phase: "BLARGH"}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	_, err := client.MinionReports()
+	c.Assert(err, gc.ErrorMatches, `invalid phase: "BLARGH"`)
+}
+
+func (s *ClientSuite) TestMinionReportsBadFailedTag(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(_ string, _ int, _, _ string, _, result interface{}) error {
+		out := result.(*params.MinionReports)
+		*out = params.MinionReports{
+			Phase:  "IMPORT",
+			Failed: []string{"dave"},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	_, err := client.MinionReports()
+	c.Assert(err, gc.ErrorMatches, `processing failed agents: "dave" is not a valid tag`)
+}
+
+func (s *ClientSuite) TestModelLogs(c *gc.C) {
+	t0 := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		out := result.(*params.ModelLogs)
+		*out = params.ModelLogs{
+			Records: []params.MigrationLogRecord{{
+				Time:     t0,
+				Entity:   "machine-0",
+				Module:   "juju.worker",
+				Location: "worker.go:42",
+				Level:    loggo.INFO,
+				Message:  "hello",
+			}},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	records, err := client.ModelLogs(t0.Add(-time.Hour), 100)
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.ModelLogs", []interface{}{"", params.ModelLogsArgs{
+			After: t0.Add(-time.Hour),
+			Limit: 100,
+		}}},
+	})
+	c.Assert(records, jc.DeepEquals, []migration.LogRecord{{
+		Time:     t0,
+		Entity:   "machine-0",
+		Module:   "juju.worker",
+		Location: "worker.go:42",
+		Level:    loggo.INFO,
+		Message:  "hello",
+	}})
+}
+
+func (s *ClientSuite) TestReap(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		return errors.New("boom")
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	err := client.Reap()
+	c.Assert(err, gc.ErrorMatches, "boom")
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.Reap", []interface{}{"", nil}},
+	})
+}
+
+type fakeHTTPCaller struct {
+	apitesting.APICallerFunc
+	doer *fakeDoer
+}
+
+func (f *fakeHTTPCaller) HTTPClient() (*httprequest.Client, error) {
+	return &httprequest.Client{
+		BaseURL: "https://api.example.com/model/uuid",
+		Doer:    f.doer,
+	}, nil
+}
+
+type fakeDoer struct {
+	response string
+
+	method string
+	url    string
+}
+
+func (d *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	d.method = req.Method
+	d.url = req.URL.String()
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       ioutil.NopCloser(bytes.NewBufferString(d.response)),
+	}, nil
+}
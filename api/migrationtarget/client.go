@@ -4,16 +4,31 @@
 package migrationtarget
 
 import (
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/httprequest"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/tools"
 )
 
 // Client describes the client side API for the MigrationTarget
 // facade. It is called by the migration master worker to talk to the
 // target controller during a migration.
 type Client interface {
+	// Prechecks checks that the target controller is able to accept
+	// the described model, returning every problem found.
+	Prechecks(migration.ModelInfo) ([]string, error)
+
 	// Import takes a serialized model and imports it into the target
 	// controller.
 	Import([]byte) error
@@ -24,16 +39,63 @@ type Client interface {
 
 	// Activate marks a migrated model as being ready to use.
 	Activate(string) error
+
+	// UploadCharm sends the content of a charm used by the model
+	// being imported with the given UUID to the target controller.
+	UploadCharm(string, *charm.URL, io.ReadSeeker) (*charm.URL, error)
+
+	// UploadTools sends agent binaries used by the model being
+	// imported with the given UUID to the target controller.
+	UploadTools(string, io.ReadSeeker, version.Binary) (tools.List, error)
+
+	// AddLogs adds historical log records to the model with the
+	// given UUID.
+	AddLogs(string, []migration.LogRecord) error
+
+	// LatestLogTime returns the time of the most recent log record
+	// added to the model with the given UUID by AddLogs. The zero
+	// time is returned if no records have been added.
+	LatestLogTime(string) (time.Time, error)
 }
 
 // NewClient returns a new Client based on an existing API connection.
 func NewClient(caller base.APICaller) Client {
-	return &client{base.NewFacadeCaller(caller, "MigrationTarget")}
+	return &client{
+		caller:     base.NewFacadeCaller(caller, "MigrationTarget"),
+		httpCaller: caller,
+	}
 }
 
 // client implements Client.
 type client struct {
-	caller base.FacadeCaller
+	caller     base.FacadeCaller
+	httpCaller base.APICaller
+}
+
+// rootHTTPClienter is implemented by API connections which can make
+// HTTP requests relative to the root of the API server rather than a
+// model. The migration master connects to the target controller
+// without a model, so the binary uploads must use the root endpoints.
+type rootHTTPClienter interface {
+	RootHTTPClient() (*httprequest.Client, error)
+}
+
+// Prechecks implements Client.
+func (c *client) Prechecks(model migration.ModelInfo) ([]string, error) {
+	args := params.MigrationModelInfo{
+		UUID:            model.UUID,
+		Name:            model.Name,
+		OwnerTag:        model.Owner.String(),
+		AgentVersion:    model.AgentVersion,
+		CloudType:       model.CloudType,
+		CloudRegion:     model.CloudRegion,
+		CloudCredential: model.CloudCredential,
+	}
+	var result params.MigrationPrecheckResult
+	if err := c.caller.FacadeCall("Prechecks", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Problems, nil
 }
 
 // Import implements Client.
@@ -53,3 +115,80 @@ func (c *client) Activate(modelUUID string) error {
 	args := params.ModelArgs{ModelTag: names.NewModelTag(modelUUID).String()}
 	return c.caller.FacadeCall("Activate", args, nil)
 }
+
+// AddLogs implements Client.
+func (c *client) AddLogs(modelUUID string, records []migration.LogRecord) error {
+	args := params.MigrationLogs{
+		ModelTag: names.NewModelTag(modelUUID).String(),
+		Records:  make([]params.MigrationLogRecord, len(records)),
+	}
+	for i, rec := range records {
+		args.Records[i] = params.MigrationLogRecord{
+			Time:     rec.Time,
+			Entity:   rec.Entity,
+			Module:   rec.Module,
+			Location: rec.Location,
+			Level:    rec.Level,
+			Message:  rec.Message,
+		}
+	}
+	return c.caller.FacadeCall("AddLogs", args, nil)
+}
+
+// LatestLogTime implements Client.
+func (c *client) LatestLogTime(modelUUID string) (time.Time, error) {
+	args := params.ModelArgs{ModelTag: names.NewModelTag(modelUUID).String()}
+	var result params.LatestLogTimeResult
+	if err := c.caller.FacadeCall("LatestLogTime", args, &result); err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	return result.Time, nil
+}
+
+// UploadCharm implements Client.
+func (c *client) UploadCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	args := url.Values{}
+	args.Set("curl", curl.String())
+	var resp params.CharmsResponse
+	if err := c.httpPost(modelUUID, content, "/migrate/charms?"+args.Encode(), "application/zip", &resp); err != nil {
+		return nil, errors.Trace(err)
+	}
+	curl, err := charm.ParseURL(resp.CharmURL)
+	if err != nil {
+		return nil, errors.Annotatef(err, "bad charm URL in response")
+	}
+	return curl, nil
+}
+
+// UploadTools implements Client.
+func (c *client) UploadTools(modelUUID string, content io.ReadSeeker, vers version.Binary) (tools.List, error) {
+	args := url.Values{}
+	args.Set("binaryVersion", vers.String())
+	var resp params.ToolsResult
+	if err := c.httpPost(modelUUID, content, "/migrate/tools?"+args.Encode(), "application/x-tar-gz", &resp); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return resp.ToolsList, nil
+}
+
+func (c *client) httpPost(modelUUID string, content io.ReadSeeker, endpoint, contentType string, response interface{}) error {
+	req, err := http.NewRequest("POST", endpoint, nil)
+	if err != nil {
+		return errors.Annotate(err, "cannot create upload request")
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set(params.MigrationModelHTTPHeader, modelUUID)
+
+	rooter, ok := c.httpCaller.(rootHTTPClienter)
+	if !ok {
+		return errors.NotSupportedf("uploads over %T", c.httpCaller)
+	}
+	httpClient, err := rooter.RootHTTPClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := httpClient.Do(req, content, response); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
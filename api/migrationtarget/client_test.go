@@ -4,14 +4,26 @@
 package migrationtarget_test
 
 import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/httprequest"
+	"github.com/juju/loggo"
 	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
 	apitesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/migrationtarget"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/migration"
 )
 
 type ClientSuite struct {
@@ -30,6 +42,32 @@ func (s *ClientSuite) getClientAndStub(c *gc.C) (migrationtarget.Client, *jujute
 	return client, &stub
 }
 
+func (s *ClientSuite) TestPrechecks(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+
+	ownerTag := names.NewUserTag("owner")
+	vers := version.MustParse("1.2.3")
+	_, err := client.Prechecks(migration.ModelInfo{
+		UUID:         "uuid",
+		Name:         "name",
+		Owner:        ownerTag,
+		AgentVersion: vers,
+		CloudType:    "ec2",
+	})
+
+	expectedArg := params.MigrationModelInfo{
+		UUID:         "uuid",
+		Name:         "name",
+		OwnerTag:     ownerTag.String(),
+		AgentVersion: vers,
+		CloudType:    "ec2",
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.Prechecks", []interface{}{"", expectedArg}},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -58,6 +96,56 @@ func (s *ClientSuite) TestActivate(c *gc.C) {
 	s.AssertModelCall(c, stub, names.NewModelTag(uuid), "Activate", err)
 }
 
+func (s *ClientSuite) TestAddLogs(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+
+	t0 := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	err := client.AddLogs("fake", []migration.LogRecord{{
+		Time:     t0,
+		Entity:   "machine-0",
+		Module:   "juju.worker",
+		Location: "worker.go:42",
+		Level:    loggo.INFO,
+		Message:  "hello",
+	}})
+
+	expectedArg := params.MigrationLogs{
+		ModelTag: names.NewModelTag("fake").String(),
+		Records: []params.MigrationLogRecord{{
+			Time:     t0,
+			Entity:   "machine-0",
+			Module:   "juju.worker",
+			Location: "worker.go:42",
+			Level:    loggo.INFO,
+			Message:  "hello",
+		}},
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.AddLogs", []interface{}{"", expectedArg}},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ClientSuite) TestLatestLogTime(c *gc.C) {
+	t0 := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.LatestLogTimeResult)) = params.LatestLogTimeResult{Time: t0}
+		return nil
+	})
+	client := migrationtarget.NewClient(apiCaller)
+
+	t, err := client.LatestLogTime("fake")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(t, gc.Equals, t0)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.LatestLogTime", []interface{}{"", params.ModelArgs{
+			ModelTag: names.NewModelTag("fake").String(),
+		}}},
+	})
+}
+
 func (s *ClientSuite) AssertModelCall(c *gc.C, stub *jujutesting.Stub, tag names.ModelTag, call string, err error) {
 	expectedArg := params.ModelArgs{ModelTag: tag.String()}
 	stub.CheckCalls(c, []jujutesting.StubCall{
@@ -65,3 +153,86 @@ func (s *ClientSuite) AssertModelCall(c *gc.C, stub *jujutesting.Stub, tag names
 	})
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
+
+func (s *ClientSuite) TestUploadCharm(c *gc.C) {
+	doer := &fakeDoer{response: `{"CharmURL":"local:trusty/magic-10"}`}
+	client := migrationtarget.NewClient(&fakeHTTPCaller{doer: doer})
+
+	curl := charm.MustParseURL("local:trusty/magic-10")
+	outCurl, err := client.UploadCharm("model-uuid", curl, bytes.NewReader([]byte("charm")))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(outCurl, gc.DeepEquals, curl)
+
+	c.Assert(doer.method, gc.Equals, "POST")
+	c.Assert(doer.url, gc.Equals, "https://api.example.com/migrate/charms?curl=local%3Atrusty%2Fmagic-10")
+	c.Assert(doer.header.Get("Content-Type"), gc.Equals, "application/zip")
+	c.Assert(doer.header.Get(params.MigrationModelHTTPHeader), gc.Equals, "model-uuid")
+	c.Assert(doer.body, gc.Equals, "charm")
+}
+
+func (s *ClientSuite) TestUploadTools(c *gc.C) {
+	doer := &fakeDoer{response: `{"ToolsList":[{"version":"2.0.1-trusty-amd64"}]}`}
+	client := migrationtarget.NewClient(&fakeHTTPCaller{doer: doer})
+
+	vers := version.MustParseBinary("2.0.1-trusty-amd64")
+	toolsList, err := client.UploadTools("model-uuid", bytes.NewReader([]byte("tools")), vers)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(toolsList, gc.HasLen, 1)
+	c.Assert(toolsList[0].Version, gc.Equals, vers)
+
+	c.Assert(doer.method, gc.Equals, "POST")
+	c.Assert(doer.url, gc.Equals, "https://api.example.com/migrate/tools?binaryVersion=2.0.1-trusty-amd64")
+	c.Assert(doer.header.Get("Content-Type"), gc.Equals, "application/x-tar-gz")
+	c.Assert(doer.header.Get(params.MigrationModelHTTPHeader), gc.Equals, "model-uuid")
+	c.Assert(doer.body, gc.Equals, "tools")
+}
+
+func (s *ClientSuite) TestUploadRequiresRootHTTPClient(c *gc.C) {
+	client, _ := s.getClientAndStub(c)
+	curl := charm.MustParseURL("local:trusty/magic-10")
+	_, err := client.UploadCharm("model-uuid", curl, bytes.NewReader(nil))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+type fakeHTTPCaller struct {
+	apitesting.APICallerFunc
+	doer *fakeDoer
+}
+
+func (f *fakeHTTPCaller) RootHTTPClient() (*httprequest.Client, error) {
+	return &httprequest.Client{
+		BaseURL: "https://api.example.com",
+		Doer:    f.doer,
+	}, nil
+}
+
+type fakeDoer struct {
+	response string
+
+	method string
+	url    string
+	header http.Header
+	body   string
+}
+
+func (d *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	return d.DoWithBody(req, nil)
+}
+
+func (d *fakeDoer) DoWithBody(req *http.Request, body io.ReadSeeker) (*http.Response, error) {
+	d.method = req.Method
+	d.url = req.URL.String()
+	d.header = req.Header
+	if body != nil {
+		data, err := ioutil.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		d.body = string(data)
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(d.response)),
+	}, nil
+}
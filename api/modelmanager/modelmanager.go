@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/permission"
 )
 
@@ -105,6 +106,65 @@ func (c *Client) ModelInfo(tags []names.ModelTag) ([]params.ModelInfoResult, err
 	return results.Results, nil
 }
 
+// ModelDefaults returns the default values for model config
+// attributes, at each level from which models inherit them.
+func (c *Client) ModelDefaults() (config.ModelDefaultAttributes, error) {
+	result := params.ModelDefaultsResult{}
+	err := c.facade.FacadeCall("ModelDefaults", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	values := make(config.ModelDefaultAttributes)
+	for name, val := range result.Config {
+		setting := config.AttributeDefaultValues{
+			Default:    val.Default,
+			Controller: val.Controller,
+		}
+		for _, region := range val.Regions {
+			setting.Regions = append(setting.Regions, config.RegionDefaultValue{
+				Name:  region.RegionName,
+				Value: region.Value,
+			})
+		}
+		values[name] = setting
+	}
+	return values, nil
+}
+
+// SetModelDefaults sets the given key-value pairs as model config
+// defaults for the controller, or for the cloud region if specified.
+func (c *Client) SetModelDefaults(cloudRegion string, config map[string]interface{}) error {
+	args := params.SetModelDefaults{
+		Config: []params.ModelDefaultValues{{
+			CloudRegion: cloudRegion,
+			Config:      config,
+		}},
+	}
+	var result params.ErrorResults
+	err := c.facade.FacadeCall("SetModelDefaults", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// UnsetModelDefaults removes the given keys from the model config
+// defaults for the controller, or for the cloud region if specified.
+func (c *Client) UnsetModelDefaults(cloudRegion string, keys ...string) error {
+	args := params.UnsetModelDefaults{
+		Keys: []params.ModelUnsetKeys{{
+			CloudRegion: cloudRegion,
+			Keys:        keys,
+		}},
+	}
+	var result params.ErrorResults
+	err := c.facade.FacadeCall("UnsetModelDefaults", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
 // ParseModelAccess parses an access permission argument into
 // a type suitable for making an API facade call.
 func ParseModelAccess(access string) (params.ModelAccessPermission, error) {
@@ -10,6 +10,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/environs/config"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
 )
@@ -73,3 +74,45 @@ func (s *modelmanagerSuite) TestListModels(c *gc.C) {
 	ownerNames := []string{models[0].Owner, models[1].Owner}
 	c.Assert(ownerNames, jc.DeepEquals, []string{"user@remote", "user@remote"})
 }
+
+func (s *modelmanagerSuite) TestModelDefaults(c *gc.C) {
+	err := s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"apt-mirror": "http://mirror",
+	}, nil, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelManager := s.OpenAPI(c)
+	defaults, err := modelManager.ModelDefaults()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(defaults["apt-mirror"], jc.DeepEquals, config.AttributeDefaultValues{
+		Controller: "http://mirror",
+	})
+}
+
+func (s *modelmanagerSuite) TestSetModelDefaults(c *gc.C) {
+	modelManager := s.OpenAPI(c)
+	err := modelManager.SetModelDefaults("", map[string]interface{}{
+		"apt-mirror": "http://mirror",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	defaults, err := s.State.ModelConfigDefaults()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(defaults["apt-mirror"], gc.Equals, "http://mirror")
+}
+
+func (s *modelmanagerSuite) TestUnsetModelDefaults(c *gc.C) {
+	err := s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"apt-mirror": "http://mirror",
+	}, nil, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelManager := s.OpenAPI(c)
+	err = modelManager.UnsetModelDefaults("", "apt-mirror")
+	c.Assert(err, jc.ErrorIsNil)
+
+	defaults, err := s.State.ModelConfigDefaults()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := defaults["apt-mirror"]
+	c.Assert(ok, jc.IsFalse)
+}
@@ -521,6 +521,27 @@ func (s *provisionerSuite) TestWatchModelMachines(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *provisionerSuite) TestWatchMachineCharmProfiles(c *gc.C) {
+	w, err := s.provisioner.WatchMachineCharmProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewStringsWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertChange()
+
+	// Assigning a unit to a machine reports the machine.
+	application := s.AddTestingService(c, "lxd-profile", s.AddTestingCharm(c, "lxd-profile"))
+	unit, err := application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(machine.Id())
+	wc.AssertNoChange()
+}
+
 func (s *provisionerSuite) TestStateAddresses(c *gc.C) {
 	err := s.machine.SetProviderAddresses(network.NewAddress("0.1.2.3"))
 	c.Assert(err, jc.ErrorIsNil)
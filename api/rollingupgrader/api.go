@@ -0,0 +1,72 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+var logger = loggo.GetLogger("juju.api.rollingupgrader")
+
+// API makes calls to the RollingUpgrader facade.
+type API struct {
+	caller base.FacadeCaller
+}
+
+// NewAPI returns a new API using the supplied caller.
+func NewAPI(caller base.APICaller) *API {
+	return &API{
+		caller: base.NewFacadeCaller(caller, "RollingUpgrader"),
+	}
+}
+
+// RollingUpgrades returns the names of the applications with a rolling
+// charm upgrade in progress.
+func (api *API) RollingUpgrades() ([]string, error) {
+	var result params.StringsResult
+	err := api.caller.FacadeCall("RollingUpgrades", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
+
+// Advance requests that the rolling charm upgrades of all supplied
+// application names be moved along. It returns the first error it
+// encounters.
+func (api *API) Advance(applications []string) error {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(applications)),
+	}
+	for i, application := range applications {
+		if !names.IsValidApplication(application) {
+			return errors.NotValidf("application name %q", application)
+		}
+		tag := names.NewApplicationTag(application)
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.ErrorResults
+	err := api.caller.FacadeCall("Advance", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, result := range results.Results {
+		if result.Error != nil {
+			if err == nil {
+				err = result.Error
+			} else {
+				logger.Errorf("additional rolling upgrade error: %v", result.Error)
+			}
+		}
+	}
+	return errors.Trace(err)
+}
@@ -0,0 +1,134 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/rollingupgrader"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type APISuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&APISuite{})
+
+func (s *APISuite) TestRollingUpgradesMethodName(c *gc.C) {
+	var called bool
+	caller := apiCaller(c, func(request string, _, _ interface{}) error {
+		called = true
+		c.Check(request, gc.Equals, "RollingUpgrades")
+		return nil
+	})
+	api := rollingupgrader.NewAPI(caller)
+
+	api.RollingUpgrades()
+	c.Check(called, jc.IsTrue)
+}
+
+func (s *APISuite) TestRollingUpgradesCallError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, _ interface{}) error {
+		return errors.New("snorble flip")
+	})
+	api := rollingupgrader.NewAPI(caller)
+
+	applications, err := api.RollingUpgrades()
+	c.Check(err, gc.ErrorMatches, "snorble flip")
+	c.Check(applications, gc.IsNil)
+}
+
+func (s *APISuite) TestRollingUpgradesResultError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		resultPtr, ok := result.(*params.StringsResult)
+		c.Assert(ok, jc.IsTrue)
+		resultPtr.Error = &params.Error{Message: "blam pow"}
+		return nil
+	})
+	api := rollingupgrader.NewAPI(caller)
+
+	applications, err := api.RollingUpgrades()
+	c.Check(err, gc.ErrorMatches, "blam pow")
+	c.Check(applications, gc.IsNil)
+}
+
+func (s *APISuite) TestRollingUpgradesSuccess(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		resultPtr, ok := result.(*params.StringsResult)
+		c.Assert(ok, jc.IsTrue)
+		resultPtr.Result = []string{"foo", "bar"}
+		return nil
+	})
+	api := rollingupgrader.NewAPI(caller)
+
+	applications, err := api.RollingUpgrades()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(applications, jc.DeepEquals, []string{"foo", "bar"})
+}
+
+func (s *APISuite) TestAdvanceBadArgs(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, _ interface{}) error {
+		panic("should not be called")
+	})
+	api := rollingupgrader.NewAPI(caller)
+
+	err := api.Advance([]string{"good-name", "bad/name"})
+	c.Check(err, gc.ErrorMatches, `application name "bad/name" not valid`)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *APISuite) TestAdvanceConvertArgs(c *gc.C) {
+	var called bool
+	caller := apiCaller(c, func(request string, arg, _ interface{}) error {
+		called = true
+		c.Check(request, gc.Equals, "Advance")
+		c.Check(arg, gc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{
+				"application-foo",
+			}, {
+				"application-bar-baz",
+			}},
+		})
+		return nil
+	})
+	api := rollingupgrader.NewAPI(caller)
+
+	err := api.Advance([]string{"foo", "bar-baz"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(called, jc.IsTrue)
+}
+
+func (s *APISuite) TestAdvanceFirstError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		resultPtr, ok := result.(*params.ErrorResults)
+		c.Assert(ok, jc.IsTrue)
+		*resultPtr = params.ErrorResults{Results: []params.ErrorResult{{
+			nil,
+		}, {
+			&params.Error{Message: "expect this error"},
+		}, {
+			&params.Error{Message: "not this one"},
+		}}}
+		return nil
+	})
+	api := rollingupgrader.NewAPI(caller)
+
+	err := api.Advance(nil)
+	c.Check(err, gc.ErrorMatches, "expect this error")
+}
+
+func apiCaller(c *gc.C, check func(request string, arg, result interface{}) error) base.APICaller {
+	return apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "RollingUpgrader")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		return check(request, arg, result)
+	})
+}
@@ -4,6 +4,7 @@
 package api
 
 import (
+	"fmt"
 	"net"
 	"strconv"
 
@@ -64,7 +65,9 @@ func (st *state) loginForVersion(tag names.Tag, password, nonce string, macaroon
 		)
 	}
 	err := st.APICall("Admin", vers, "", "Login", request, &result)
-	if err != nil {
+	if params.IsCodeRedirect(err) {
+		return errors.Trace(st.redirectInfo(vers))
+	} else if err != nil {
 		return errors.Trace(err)
 	}
 	if result.DischargeRequired != nil {
@@ -89,7 +92,9 @@ func (st *state) loginForVersion(tag names.Tag, password, nonce string, macaroon
 		request.Macaroons = httpbakery.MacaroonsForURL(st.bakeryClient.Client.Jar, st.cookieURL)
 		result = params.LoginResultV1{} // zero result
 		err = st.APICall("Admin", vers, "", "Login", request, &result)
-		if err != nil {
+		if params.IsCodeRedirect(err) {
+			return errors.Trace(st.redirectInfo(vers))
+		} else if err != nil {
 			return errors.Trace(err)
 		}
 		if result.DischargeRequired != nil {
@@ -116,6 +121,52 @@ func (st *state) loginForVersion(tag names.Tag, password, nonce string, macaroon
 	return nil
 }
 
+// RedirectError is returned from Open and Login when the model being
+// connected to has been migrated to another controller.
+type RedirectError struct {
+	// ControllerTag holds the tag of the controller the model was
+	// migrated to.
+	ControllerTag names.ModelTag
+
+	// Servers holds the host:port addresses of the API servers of
+	// the controller the model was migrated to.
+	Servers []string
+
+	// CACert holds the CA certificate used to validate the API
+	// servers' certificates, in PEM format.
+	CACert string
+}
+
+// Error implements the error interface.
+func (e *RedirectError) Error() string {
+	return fmt.Sprintf("model has been migrated to controller %s", e.ControllerTag.Id())
+}
+
+// ErrorCode returns params.CodeRedirect so that a RedirectError is
+// treated like any other error returned by the API server.
+func (e *RedirectError) ErrorCode() string {
+	return params.CodeRedirect
+}
+
+// redirectInfo retrieves the details of the controller that a model
+// has been migrated to after a login attempt has been redirected,
+// returning them as a *RedirectError.
+func (st *state) redirectInfo(vers int) error {
+	var result params.RedirectInfoResult
+	if err := st.APICall("Admin", vers, "", "RedirectInfo", nil, &result); err != nil {
+		return errors.Annotate(err, "cannot get redirect details")
+	}
+	controllerTag, err := names.ParseModelTag(result.ControllerTag)
+	if err != nil {
+		return errors.Annotate(err, "invalid controller tag in redirect details")
+	}
+	return &RedirectError{
+		ControllerTag: controllerTag,
+		Servers:       result.Servers,
+		CACert:        result.CACert,
+	}
+}
+
 func (st *state) setLoginResult(tag names.Tag, modelTag, controllerTag string, servers [][]network.HostPort, facades []params.FacadeVersions) error {
 	st.authTag = tag
 	st.modelTag = modelTag
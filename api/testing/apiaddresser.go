@@ -4,11 +4,14 @@
 package testing
 
 import (
+	"strings"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/watcher/watchertest"
 )
@@ -30,6 +33,8 @@ type APIAddresserFacade interface {
 	CACert() (string, error)
 	APIHostPorts() ([][]network.HostPort, error)
 	WatchAPIHostPorts() (watcher.NotifyWatcher, error)
+	TrustedCACert() (string, error)
+	WatchCACert() (watcher.NotifyWatcher, error)
 }
 
 func (s *APIAddresserTests) TestAPIAddresses(c *gc.C) {
@@ -92,3 +97,37 @@ func (s *APIAddresserTests) TestWatchAPIHostPorts(c *gc.C) {
 
 	wc.AssertOneChange()
 }
+
+func (s *APIAddresserTests) TestTrustedCACert(c *gc.C) {
+	cfg, err := s.state.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	controllerCACert, _ := cfg.CACert()
+
+	caCert, err := s.facade.TrustedCACert()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(caCert, gc.Equals, controllerCACert)
+
+	err = s.state.StartCARotation(coretesting.OtherCACert, coretesting.OtherCAKey)
+	c.Assert(err, jc.ErrorIsNil)
+
+	caCert, err = s.facade.TrustedCACert()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.HasPrefix(caCert, controllerCACert), jc.IsTrue)
+	c.Assert(strings.HasSuffix(caCert, coretesting.OtherCACert), jc.IsTrue)
+}
+
+func (s *APIAddresserTests) TestWatchCACert(c *gc.C) {
+	w, err := s.facade.WatchCACert()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.state.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	// Start rotating the CA and check that we get a notification.
+	err = s.state.StartCARotation(coretesting.OtherCACert, coretesting.OtherCAKey)
+	c.Assert(err, jc.ErrorIsNil)
+
+	wc.AssertOneChange()
+}
@@ -36,6 +36,27 @@ func (s *stateSuite) TestProviderType(c *gc.C) {
 	c.Assert(providerType, gc.DeepEquals, cfg.Type())
 }
 
+func (s *stateSuite) TestCloudSpec(c *gc.C) {
+	_, err := s.uniter.CloudSpec()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(err, jc.Satisfies, params.IsCodeUnauthorized)
+
+	err = s.wordpressService.SetTrust(true)
+	c.Assert(err, jc.ErrorIsNil)
+	spec, err := s.uniter.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Type, gc.Equals, "dummy")
+}
+
+func (s *stateSuite) TestGoalState(c *gc.C) {
+	goalState, err := s.uniter.GoalState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(goalState.Relations, gc.HasLen, 0)
+	c.Assert(goalState.Units, gc.HasLen, 1)
+	_, ok := goalState.Units["wordpress/0"]
+	c.Assert(ok, jc.IsTrue)
+}
+
 func (s *stateSuite) TestAllMachinePorts(c *gc.C) {
 	// Verify no ports are opened yet on the machine or unit.
 	machinePorts, err := s.wordpressMachine.AllPorts()
@@ -548,6 +548,66 @@ func (u *Unit) WatchConfigSettings() (watcher.NotifyWatcher, error) {
 	return w, nil
 }
 
+// UpgradeSeriesStatus returns the unit's progress through a series
+// upgrade of its machine.
+func (u *Unit) UpgradeSeriesStatus() (params.UpgradeSeriesStatus, error) {
+	var results params.UpgradeSeriesStatusResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("UpgradeSeriesUnitStatus", args, &results)
+	if err != nil {
+		return "", err
+	}
+	if len(results.Results) != 1 {
+		return "", fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Status, nil
+}
+
+// SetUpgradeSeriesStatus records the unit's progress through a series
+// upgrade of its machine.
+func (u *Unit) SetUpgradeSeriesStatus(status params.UpgradeSeriesStatus) error {
+	var result params.ErrorResults
+	args := params.UpgradeSeriesStatusParams{
+		Params: []params.UpgradeSeriesStatusParam{{
+			Entity: params.Entity{Tag: u.tag.String()},
+			Status: status,
+		}},
+	}
+	err := u.st.facade.FacadeCall("SetUpgradeSeriesUnitStatus", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+// WatchUpgradeSeriesNotifications returns a watcher that fires whenever
+// a series upgrade of the unit's machine is started or progresses.
+func (u *Unit) WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error) {
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("WatchUpgradeSeriesNotifications", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(u.st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // WatchAddresses returns a watcher for observing changes to the
 // unit's addresses. The unit must be assigned to a machine before
 // this method is called, and the returned watcher will be valid only
@@ -185,6 +185,35 @@ func (st *State) ProviderType() (string, error) {
 	return result.Result, nil
 }
 
+// CloudSpec returns the cloud, region and credential of the model.
+// It fails with a permission error unless the unit's application has
+// been trusted to access them.
+func (st *State) CloudSpec() (*params.CloudSpec, error) {
+	var result params.CloudSpecResult
+	err := st.facade.FacadeCall("CloudSpec", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+	return result.Result, nil
+}
+
+// GoalState returns the units that are expected to exist for the
+// unit's application and for each application related to it.
+func (st *State) GoalState() (*params.GoalState, error) {
+	var result params.GoalStateResult
+	err := st.facade.FacadeCall("GoalState", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+	return result.Result, nil
+}
+
 // Charm returns the charm with the given URL.
 func (st *State) Charm(curl *charm.URL) (*Charm, error) {
 	if curl == nil {
@@ -0,0 +1,134 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+// Client provides access to the UpgradeSeries API facade on behalf of
+// a single machine agent.
+type Client struct {
+	facade     base.FacadeCaller
+	machineTag names.MachineTag
+}
+
+// NewClient returns a new Client for the given machine.
+func NewClient(caller base.APICaller, machineTag names.MachineTag) *Client {
+	return &Client{
+		facade:     base.NewFacadeCaller(caller, "UpgradeSeries"),
+		machineTag: machineTag,
+	}
+}
+
+func (c *Client) entities() params.Entities {
+	return params.Entities{
+		Entities: []params.Entity{{Tag: c.machineTag.String()}},
+	}
+}
+
+// WatchUpgradeSeriesNotifications returns a NotifyWatcher that fires
+// whenever the machine's upgrade-series lock changes.
+func (c *Client) WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error) {
+	var results params.NotifyWatchResults
+	if err := c.facade.FacadeCall("WatchUpgradeSeriesNotifications", c.entities(), &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// MachineStatus returns the machine's progress through a series
+// upgrade.
+func (c *Client) MachineStatus() (params.UpgradeSeriesStatus, error) {
+	var results params.UpgradeSeriesStatusResults
+	if err := c.facade.FacadeCall("MachineStatus", c.entities(), &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Status, nil
+}
+
+// SetMachineStatus records the machine's progress through a series
+// upgrade.
+func (c *Client) SetMachineStatus(status params.UpgradeSeriesStatus) error {
+	var results params.ErrorResults
+	args := params.UpgradeSeriesStatusParams{
+		Params: []params.UpgradeSeriesStatusParam{{
+			Entity: params.Entity{Tag: c.machineTag.String()},
+			Status: status,
+		}},
+	}
+	if err := c.facade.FacadeCall("SetMachineStatus", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// TargetSeries returns the series the machine is being upgraded to.
+func (c *Client) TargetSeries() (string, error) {
+	var results params.StringResults
+	if err := c.facade.FacadeCall("TargetSeries", c.entities(), &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
+// UnitsStatuses returns the progress through a series upgrade of each
+// unit on the machine, keyed by unit name.
+func (c *Client) UnitsStatuses() (map[string]params.UpgradeSeriesStatus, error) {
+	var results params.UpgradeSeriesUnitStatusesResults
+	if err := c.facade.FacadeCall("UnitsStatuses", c.entities(), &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Statuses, nil
+}
+
+// FinishUpgradeSeries records the series the machine is now running,
+// and releases its upgrade-series lock.
+func (c *Client) FinishUpgradeSeries(hostSeries string) error {
+	var results params.ErrorResults
+	args := params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{{
+			Entity: params.Entity{Tag: c.machineTag.String()},
+			Series: hostSeries,
+		}},
+	}
+	if err := c.facade.FacadeCall("FinishUpgradeSeries", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
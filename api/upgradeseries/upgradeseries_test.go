@@ -0,0 +1,97 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/upgradeseries"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type upgradeSeriesSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&upgradeSeriesSuite{})
+
+var machineEntities = params.Entities{
+	Entities: []params.Entity{{Tag: "machine-0"}},
+}
+
+func (s *upgradeSeriesSuite) TestMachineStatus(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "UpgradeSeries")
+		c.Check(request, gc.Equals, "MachineStatus")
+		c.Check(arg, jc.DeepEquals, machineEntities)
+		*(result.(*params.UpgradeSeriesStatusResults)) = params.UpgradeSeriesStatusResults{
+			Results: []params.UpgradeSeriesStatusResult{{Status: params.UpgradeSeriesPrepareStarted}},
+		}
+		return nil
+	})
+	client := upgradeseries.NewClient(apiCaller, names.NewMachineTag("0"))
+	status, err := client.MachineStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, params.UpgradeSeriesPrepareStarted)
+}
+
+func (s *upgradeSeriesSuite) TestSetMachineStatus(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "SetMachineStatus")
+		c.Check(arg, jc.DeepEquals, params.UpgradeSeriesStatusParams{
+			Params: []params.UpgradeSeriesStatusParam{{
+				Entity: params.Entity{Tag: "machine-0"},
+				Status: params.UpgradeSeriesPrepareCompleted,
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+	client := upgradeseries.NewClient(apiCaller, names.NewMachineTag("0"))
+	err := client.SetMachineStatus(params.UpgradeSeriesPrepareCompleted)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *upgradeSeriesSuite) TestUnitsStatuses(c *gc.C) {
+	statuses := map[string]params.UpgradeSeriesStatus{
+		"mysql/0": params.UpgradeSeriesPrepareCompleted,
+	}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "UnitsStatuses")
+		c.Check(arg, jc.DeepEquals, machineEntities)
+		*(result.(*params.UpgradeSeriesUnitStatusesResults)) = params.UpgradeSeriesUnitStatusesResults{
+			Results: []params.UpgradeSeriesUnitStatusesResult{{Statuses: statuses}},
+		}
+		return nil
+	})
+	client := upgradeseries.NewClient(apiCaller, names.NewMachineTag("0"))
+	result, err := client.UnitsStatuses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, statuses)
+}
+
+func (s *upgradeSeriesSuite) TestFinishUpgradeSeries(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "FinishUpgradeSeries")
+		c.Check(arg, jc.DeepEquals, params.UpdateSeriesArgs{
+			Args: []params.UpdateSeriesArg{{
+				Entity: params.Entity{Tag: "machine-0"},
+				Series: "xenial",
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	client := upgradeseries.NewClient(apiCaller, names.NewMachineTag("0"))
+	err := client.FinishUpgradeSeries("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+}
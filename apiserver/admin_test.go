@@ -22,6 +22,7 @@ import (
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/migration"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/rpc"
@@ -657,6 +658,48 @@ func (s *loginSuite) TestNonExistentEnvironment(c *gc.C) {
 	})
 }
 
+func (s *loginSuite) TestMigratedModelRedirects(c *gc.C) {
+	info, cleanup := s.setupServerWithValidator(c, nil)
+	defer cleanup()
+
+	modelState := s.Factory.MakeModel(c, nil)
+	defer modelState.Close()
+	targetInfo := migration.TargetInfo{
+		ControllerTag: names.NewModelTag(utils.MustNewUUID().String()),
+		Addrs:         []string{"1.2.3.4:5555", "4.3.2.1:6666"},
+		CACert:        "cert",
+		AuthTag:       names.NewUserTag("admin"),
+		Password:      "secret",
+	}
+	_, err := modelState.CreateModelMigration(state.ModelMigrationSpec{
+		InitiatedBy: s.AdminUserTag(c),
+		TargetInfo:  targetInfo,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := modelState.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetMigrationMode(state.MigrationModeExporting)
+	c.Assert(err, jc.ErrorIsNil)
+	err = modelState.RemoveExportingModelDocs()
+	c.Assert(err, jc.ErrorIsNil)
+
+	info.ModelTag = model.ModelTag()
+	st := s.openAPIWithoutLogin(c, info)
+	defer st.Close()
+
+	// Redirect details are only revealed to authenticated users.
+	var result params.RedirectInfoResult
+	err = st.APICall("Admin", 3, "", "RedirectInfo", nil, &result)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	err = st.Login(s.AdminUserTag(c), "dummy-secret", "", nil)
+	c.Assert(errors.Cause(err), jc.DeepEquals, &api.RedirectError{
+		ControllerTag: targetInfo.ControllerTag,
+		Servers:       targetInfo.Addrs,
+		CACert:        targetInfo.CACert,
+	})
+}
+
 func (s *loginSuite) TestInvalidEnvironment(c *gc.C) {
 	info, cleanup := s.setupServerWithValidator(c, nil)
 	defer cleanup()
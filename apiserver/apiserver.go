@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
 )
 
 var logger = loggo.GetLogger("juju.apiserver")
@@ -35,6 +36,10 @@ var logger = loggo.GetLogger("juju.apiserver")
 // accept
 const loginRateLimit = 10
 
+// controllerServerName is the TLS server name requested by clients
+// that trust the controller CA rather than a custom API certificate.
+const controllerServerName = "juju-apiserver"
+
 // Server holds the server side of the API.
 type Server struct {
 	tomb              tomb.Tomb
@@ -42,6 +47,7 @@ type Server struct {
 	state             *state.State
 	statePool         *state.StatePool
 	lis               net.Listener
+	certListener      *changeCertListener
 	tag               names.Tag
 	dataDir           string
 	logDir            string
@@ -87,6 +93,10 @@ type changeCertListener struct {
 
 	// The config to update with any new certificate.
 	config *tls.Config
+
+	// clientCert, if not nil, is the custom certificate presented
+	// to clients that do not ask for the controller's own.
+	clientCert *tls.Certificate
 }
 
 func newChangeCertListener(lis net.Listener, certChanged <-chan params.StateServingInfo, config *tls.Config) *changeCertListener {
@@ -114,6 +124,17 @@ func (cl *changeCertListener) Accept() (net.Conn, error) {
 	// make a copy of cl.config so that update certificate does not mutate
 	// the config passed to the tls.Server for this conn.
 	config := *cl.config
+	if clientCert := cl.clientCert; clientCert != nil {
+		config.GetCertificate = func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			// Agents, and clients that trust the controller CA, ask
+			// for the controller's own certificate by name; everyone
+			// else is given the custom certificate.
+			if hello.ServerName == controllerServerName {
+				return nil, nil
+			}
+			return clientCert, nil
+		}
+	}
 	return tls.Server(conn, &config), nil
 }
 
@@ -159,6 +180,25 @@ func (cl *changeCertListener) updateCertificate(cert, key []byte) {
 	}
 }
 
+// updateClientCertificate sets the custom certificate presented to
+// clients, or removes it if the certificate and key are empty.
+func (cl *changeCertListener) updateClientCertificate(cert, key []byte) {
+	cl.m.Lock()
+	defer cl.m.Unlock()
+	if len(cert) == 0 && len(key) == 0 {
+		logger.Infof("removing custom api server certificate")
+		cl.clientCert = nil
+		return
+	}
+	tlsCert, err := tls.X509KeyPair(cert, key)
+	if err != nil {
+		logger.Errorf("cannot create custom TLS certificate: %v", err)
+		return
+	}
+	logger.Infof("updating custom api server certificate")
+	cl.clientCert = &tlsCert
+}
+
 // NewServer serves the given state by accepting requests on the given
 // listener, using the given certificate and key (in PEM format) for
 // authentication.
@@ -198,15 +238,17 @@ func newServer(s *state.State, lis *net.TCPListener, cfg ServerConfig) (_ *Serve
 		stPool = state.NewStatePool(s)
 	}
 
+	certListener := newChangeCertListener(lis, cfg.CertChanged, tlsConfig)
 	srv := &Server{
-		state:     s,
-		statePool: stPool,
-		lis:       newChangeCertListener(lis, cfg.CertChanged, tlsConfig),
-		tag:       cfg.Tag,
-		dataDir:   cfg.DataDir,
-		logDir:    cfg.LogDir,
-		limiter:   utils.NewLimiter(loginRateLimit),
-		validator: cfg.Validator,
+		state:        s,
+		statePool:    stPool,
+		lis:          certListener,
+		certListener: certListener,
+		tag:          cfg.Tag,
+		dataDir:      cfg.DataDir,
+		logDir:       cfg.LogDir,
+		limiter:      utils.NewLimiter(loginRateLimit),
+		validator:    cfg.Validator,
 		adminApiFactories: map[int]adminApiFactory{
 			3: newAdminApiV3,
 		},
@@ -343,6 +385,12 @@ func (srv *Server) run() {
 		srv.tomb.Kill(srv.mongoPinger())
 	}()
 
+	srv.wg.Add(1)
+	go func() {
+		defer srv.wg.Done()
+		srv.tomb.Kill(srv.watchAPICertificate())
+	}()
+
 	// for pat based handlers, they are matched in-order of being
 	// registered, first match wins. So more specific ones have to be
 	// registered first.
@@ -395,12 +443,20 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	add("/model/:modeluuid/log", debugLogHandler)
 	add("/model/:modeluuid/charms",
 		&charmsHandler{
-			ctxt:    httpCtxt,
-			dataDir: srv.dataDir},
+			ctxt:          httpCtxt,
+			dataDir:       srv.dataDir,
+			stateAuthFunc: httpCtxt.stateForRequestAuthenticatedUser,
+		},
+	)
+	add("/model/:modeluuid/mirror-resources",
+		&mirrorResourcesHandler{
+			ctxt: httpCtxt,
+		},
 	)
 	add("/model/:modeluuid/tools",
 		&toolsUploadHandler{
-			ctxt: httpCtxt,
+			ctxt:          httpCtxt,
+			stateAuthFunc: httpCtxt.stateForRequestAuthenticatedUser,
 		},
 	)
 	add("/model/:modeluuid/tools/:version",
@@ -431,13 +487,15 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 
 	add("/charms",
 		&charmsHandler{
-			ctxt:    httpCtxt,
-			dataDir: srv.dataDir,
+			ctxt:          httpCtxt,
+			dataDir:       srv.dataDir,
+			stateAuthFunc: httpCtxt.stateForRequestAuthenticatedUser,
 		},
 	)
 	add("/tools",
 		&toolsUploadHandler{
-			ctxt: httpCtxt,
+			ctxt:          httpCtxt,
+			stateAuthFunc: httpCtxt.stateForRequestAuthenticatedUser,
 		},
 	)
 	add("/tools/:version",
@@ -445,6 +503,22 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 			ctxt: httpCtxt,
 		},
 	)
+	// The migrate endpoints are used by the migration master worker of
+	// another controller to upload binaries for a model being
+	// imported. The model is identified by a request header.
+	add("/migrate/charms",
+		&charmsHandler{
+			ctxt:          httpCtxt,
+			dataDir:       srv.dataDir,
+			stateAuthFunc: httpCtxt.stateForMigrationImporting,
+		},
+	)
+	add("/migrate/tools",
+		&toolsUploadHandler{
+			ctxt:          httpCtxt,
+			stateAuthFunc: httpCtxt.stateForMigrationImporting,
+		},
+	)
 	add("/register",
 		&registerUserHandler{
 			httpCtxt,
@@ -567,7 +641,9 @@ func (srv *Server) serveConn(wsConn *websocket.Conn, reqNotifier *requestNotifie
 	conn := rpc.NewConn(codec, notifier)
 
 	h, err := srv.newAPIHandler(conn, reqNotifier, modelUUID)
-	if err != nil {
+	if redirect, ok := errors.Cause(err).(*common.RedirectError); ok {
+		conn.ServeFinder(newRedirectRoot(srv, redirect), serverError)
+	} else if err != nil {
 		conn.ServeFinder(&errRoot{err}, serverError)
 	} else {
 		adminApis := make(map[int]interface{})
@@ -621,6 +697,28 @@ func (srv *Server) mongoPinger() error {
 	}
 }
 
+// watchAPICertificate keeps the custom certificate presented to
+// clients in step with the one recorded in state.
+func (srv *Server) watchAPICertificate() error {
+	w := srv.state.WatchAPICertificate()
+	defer w.Stop()
+	for {
+		select {
+		case <-srv.tomb.Dying():
+			return tomb.ErrDying
+		case _, ok := <-w.Changes():
+			if !ok {
+				return watcher.EnsureErr(w)
+			}
+		}
+		certPEM, keyPEM, err := srv.state.APICertificate()
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Annotate(err, "cannot get custom api server certificate")
+		}
+		srv.certListener.updateClientCertificate([]byte(certPEM), []byte(keyPEM))
+	}
+}
+
 func serverError(err error) error {
 	if err := common.ServerError(err); err != nil {
 		return err
@@ -28,9 +28,58 @@ var (
 )
 
 func init() {
-	common.RegisterStandardFacade("Application", 1, NewAPI)
+	common.RegisterStandardFacade("Application", 1, NewAPIV1)
+	common.RegisterStandardFacade("Application", 2, NewAPI)
 }
 
+// APIV1 implements version 1 of the application API end point. It
+// differs from version 2 in not restricting exposed applications to
+// source CIDRs, in not upgrading charms in batches, and in not
+// rolling back charms or setting placement policies.
+type APIV1 struct {
+	*API
+}
+
+// NewAPIV1 returns a new version 1 application API facade.
+func NewAPIV1(
+	st *state.State,
+	resources *common.Resources,
+	authorizer common.Authorizer,
+) (*APIV1, error) {
+	api, err := NewAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV1{api}, nil
+}
+
+// Expose changes the juju-managed firewall to expose any ports that
+// were also explicitly marked by units as open. Source CIDRs are not
+// supported by this version of the facade.
+func (api *APIV1) Expose(args params.ApplicationExpose) error {
+	if len(args.CIDRs) > 0 {
+		return errors.NotSupportedf("exposing to source CIDRs")
+	}
+	return api.API.Expose(args)
+}
+
+// SetCharm sets the charm for a given for the application. Batched
+// upgrades are not supported by this version of the facade.
+func (api *APIV1) SetCharm(args params.ApplicationSetCharm) error {
+	if args.BatchSize != 0 || args.BatchTimeout != 0 {
+		return errors.NotSupportedf("upgrading charms in batches")
+	}
+	return api.API.SetCharm(args)
+}
+
+// RollbackCharm is not available in version 1 of the facade; the
+// method signature hides it from the RPC layer.
+func (api *APIV1) RollbackCharm(_, _ struct{}) {}
+
+// SetPlacementPolicy is not available in version 1 of the facade; the
+// method signature hides it from the RPC layer.
+func (api *APIV1) SetPlacementPolicy(_, _ struct{}) {}
+
 // Application defines the methods on the application API end point.
 type Application interface {
 	SetMetricCredentials(args params.ApplicationMetricCredentials) (params.ErrorResults, error)
@@ -227,8 +276,12 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 	if args.CharmUrl != "" {
 		// For now we do not support changing the channel through Update().
 		// TODO(ericsnow) Support it?
-		channel := svc.Channel()
-		if err = api.applicationSetCharm(svc, args.CharmUrl, channel, args.ForceSeries, args.ForceCharmUrl, nil); err != nil {
+		cfg := state.SetCharmConfig{
+			Channel:     svc.Channel(),
+			ForceSeries: args.ForceSeries,
+			ForceUnits:  args.ForceCharmUrl,
+		}
+		if err = api.applicationSetCharm(svc, args.CharmUrl, cfg); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -267,12 +320,40 @@ func (api *API) SetCharm(args params.ApplicationSetCharm) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	channel := csparams.Channel(args.Channel)
-	return api.applicationSetCharm(application, args.CharmUrl, channel, args.ForceSeries, args.ForceUnits, args.ResourceIDs)
+	cfg := state.SetCharmConfig{
+		Channel:      csparams.Channel(args.Channel),
+		ForceSeries:  args.ForceSeries,
+		ForceUnits:   args.ForceUnits,
+		ResourceIDs:  args.ResourceIDs,
+		BatchSize:    args.BatchSize,
+		BatchTimeout: args.BatchTimeout,
+	}
+	return api.applicationSetCharm(application, args.CharmUrl, cfg)
+}
+
+// RollbackCharm changes an application's charm back to the one it ran
+// before its last upgrade, and returns the URL of that charm.
+func (api *API) RollbackCharm(args params.ApplicationRollbackCharm) (params.StringResult, error) {
+	// when forced units in error, don't block
+	if !args.ForceUnits {
+		if err := api.check.ChangeAllowed(); err != nil {
+			return params.StringResult{}, errors.Trace(err)
+		}
+	}
+	application, err := api.state.Application(args.ApplicationName)
+	if err != nil {
+		return params.StringResult{}, errors.Trace(err)
+	}
+	if err := application.RollbackCharm(args.ForceUnits); err != nil {
+		return params.StringResult{Error: common.ServerError(err)}, nil
+	}
+	curl, _ := application.CharmURL()
+	return params.StringResult{Result: curl.String()}, nil
 }
 
-// applicationSetCharm sets the charm for the given for the application.
-func (api *API) applicationSetCharm(application *state.Application, url string, channel csparams.Channel, forceSeries, forceUnits bool, resourceIDs map[string]string) error {
+// applicationSetCharm sets the charm identified by url for the given
+// application, using the other options in the supplied config.
+func (api *API) applicationSetCharm(application *state.Application, url string, cfg state.SetCharmConfig) error {
 	curl, err := charm.ParseURL(url)
 	if err != nil {
 		return errors.Trace(err)
@@ -281,13 +362,7 @@ func (api *API) applicationSetCharm(application *state.Application, url string,
 	if err != nil {
 		return errors.Trace(err)
 	}
-	cfg := state.SetCharmConfig{
-		Charm:       sch,
-		Channel:     channel,
-		ForceSeries: forceSeries,
-		ForceUnits:  forceUnits,
-		ResourceIDs: resourceIDs,
-	}
+	cfg.Charm = sch
 	return application.SetCharm(cfg)
 }
 
@@ -419,7 +494,8 @@ func (api *API) CharmRelations(p params.ApplicationCharmRelations) (params.Appli
 }
 
 // Expose changes the juju-managed firewall to expose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open, optionally restricting
+// them to the given source CIDRs.
 func (api *API) Expose(args params.ApplicationExpose) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
@@ -428,7 +504,36 @@ func (api *API) Expose(args params.ApplicationExpose) error {
 	if err != nil {
 		return err
 	}
-	return svc.SetExposed()
+	return svc.SetExposedCIDRs(args.CIDRs)
+}
+
+// SetTrust sets whether the application's units may access the model's
+// cloud credential.
+func (api *API) SetTrust(args params.ApplicationTrust) error {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	svc, err := api.state.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	return svc.SetTrust(args.Trust)
+}
+
+// SetPlacementPolicy sets how the application's units are placed
+// relative to each other and to other applications.
+func (api *API) SetPlacementPolicy(args params.ApplicationPlacementPolicy) error {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	svc, err := api.state.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	return svc.SetPlacementPolicy(state.PlacementPolicy{
+		Spread:   state.PlacementScope(args.Spread),
+		Colocate: args.Colocate,
+	})
 }
 
 // Unexpose changes the juju-managed firewall to unexpose any ports that
@@ -6,6 +6,7 @@ package application_test
 import (
 	"fmt"
 	"io"
+	"reflect"
 	"regexp"
 	"sync"
 	"time"
@@ -29,6 +30,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/rpc/rpcreflect"
 	"github.com/juju/juju/state"
 	statestorage "github.com/juju/juju/state/storage"
 	"github.com/juju/juju/status"
@@ -681,6 +683,19 @@ func (s *serviceSuite) TestServiceSetCharm(c *gc.C) {
 	c.Assert(force, jc.IsFalse)
 }
 
+func (s *serviceSuite) TestServiceSetCharmBatchesV1(c *gc.C) {
+	s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	apiV1, err := application.NewAPIV1(s.State, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = apiV1.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "dummy-service",
+		CharmUrl:        "local:quantal/dummy-1",
+		BatchSize:       2,
+	})
+	c.Assert(err, gc.ErrorMatches, "upgrading charms in batches not supported")
+}
+
 func (s *serviceSuite) setupServiceSetCharm(c *gc.C) {
 	curl, _ := s.UploadCharm(c, "precise/dummy-0", "dummy")
 	err := application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
@@ -780,6 +795,56 @@ func (s *serviceSuite) TestServiceSetCharmForceUnits(c *gc.C) {
 	c.Assert(force, jc.IsTrue)
 }
 
+func (s *serviceSuite) TestServiceRollbackCharm(c *gc.C) {
+	s.setupServiceSetCharm(c)
+	result, err := s.applicationApi.RollbackCharm(params.ApplicationRollbackCharm{
+		ApplicationName: "application",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `cannot roll back charm of application "application": previous charm not found`)
+
+	application, err := s.State.Application("application")
+	c.Assert(err, jc.ErrorIsNil)
+	original, _ := application.CharmURL()
+	s.assertServiceSetCharm(c, false)
+
+	result, err = s.applicationApi.RollbackCharm(params.ApplicationRollbackCharm{
+		ApplicationName: "application",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, gc.Equals, original.String())
+
+	err = application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	charm, force, err := application.Charm()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charm.URL(), gc.DeepEquals, original)
+	c.Assert(force, jc.IsFalse)
+}
+
+func (s *serviceSuite) TestRollbackCharmNotInV1(c *gc.C) {
+	assertOnlyInV2(c, "RollbackCharm")
+}
+
+func assertOnlyInV2(c *gc.C, method string) {
+	objType := rpcreflect.ObjTypeOf(reflect.TypeOf(&application.APIV1{}))
+	_, err := objType.Method(method)
+	c.Assert(err, gc.Equals, rpcreflect.ErrMethodNotFound)
+	objType = rpcreflect.ObjTypeOf(reflect.TypeOf(&application.API{}))
+	_, err = objType.Method(method)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *serviceSuite) TestBlockServiceRollbackCharm(c *gc.C) {
+	s.setupServiceSetCharm(c)
+	s.BlockAllChanges(c, "TestBlockServiceRollbackCharm")
+	_, err := s.applicationApi.RollbackCharm(params.ApplicationRollbackCharm{
+		ApplicationName: "application",
+	})
+	s.AssertBlocked(c, err, "TestBlockServiceRollbackCharm")
+}
+
 func (s *serviceSuite) TestBlockServiceSetCharmForce(c *gc.C) {
 	s.setupServiceSetCharm(c)
 
@@ -1878,6 +1943,110 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	}
 }
 
+func (s *serviceSuite) TestServiceExposeCIDRs(c *gc.C) {
+	application := s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+
+	err := s.applicationApi.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-service",
+		CIDRs:           []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.IsExposed(), jc.IsTrue)
+	c.Assert(application.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	err = s.applicationApi.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-service",
+		CIDRs:           []string{"bad"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "dummy-service": CIDR "bad" not valid`)
+}
+
+func (s *serviceSuite) TestServiceExposeCIDRsV1(c *gc.C) {
+	svc := s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	apiV1, err := application.NewAPIV1(s.State, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = apiV1.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-service",
+		CIDRs:           []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, gc.ErrorMatches, "exposing to source CIDRs not supported")
+	err = svc.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.IsExposed(), jc.IsFalse)
+
+	err = apiV1.Expose(params.ApplicationExpose{ApplicationName: "dummy-service"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = svc.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.IsExposed(), jc.IsTrue)
+}
+
+func (s *serviceSuite) TestServiceSetTrust(c *gc.C) {
+	application := s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	c.Assert(application.IsTrusted(), jc.IsFalse)
+
+	err := s.applicationApi.SetTrust(params.ApplicationTrust{"dummy-service", true})
+	c.Assert(err, jc.ErrorIsNil)
+	err = application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.IsTrusted(), jc.IsTrue)
+
+	err = s.applicationApi.SetTrust(params.ApplicationTrust{"dummy-service", false})
+	c.Assert(err, jc.ErrorIsNil)
+	err = application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.IsTrusted(), jc.IsFalse)
+
+	err = s.applicationApi.SetTrust(params.ApplicationTrust{"unknown-service", true})
+	c.Assert(err, gc.ErrorMatches, `application "unknown-service" not found`)
+}
+
+func (s *serviceSuite) TestServiceSetPlacementPolicy(c *gc.C) {
+	application := s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	s.AddTestingService(c, "other-service", s.AddTestingCharm(c, "dummy"))
+
+	err := s.applicationApi.SetPlacementPolicy(params.ApplicationPlacementPolicy{
+		ApplicationName: "dummy-service",
+		Spread:          "zone",
+		Colocate:        "other-service",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.PlacementPolicy(), gc.Equals, state.PlacementPolicy{
+		Spread:   state.PlacementScopeZone,
+		Colocate: "other-service",
+	})
+
+	err = s.applicationApi.SetPlacementPolicy(params.ApplicationPlacementPolicy{
+		ApplicationName: "dummy-service",
+		Spread:          "rack",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set placement policy for application "dummy-service": placement scope "rack" not valid`)
+
+	err = s.applicationApi.SetPlacementPolicy(params.ApplicationPlacementPolicy{
+		ApplicationName: "unknown-service",
+	})
+	c.Assert(err, gc.ErrorMatches, `application "unknown-service" not found`)
+}
+
+func (s *serviceSuite) TestSetPlacementPolicyNotInV1(c *gc.C) {
+	assertOnlyInV2(c, "SetPlacementPolicy")
+}
+
+func (s *serviceSuite) TestBlockServiceSetPlacementPolicy(c *gc.C) {
+	s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	s.BlockAllChanges(c, "TestBlockServiceSetPlacementPolicy")
+	err := s.applicationApi.SetPlacementPolicy(params.ApplicationPlacementPolicy{
+		ApplicationName: "dummy-service",
+		Spread:          "machine",
+	})
+	s.AssertBlocked(c, err, "TestBlockServiceSetPlacementPolicy")
+}
+
 func (s *serviceSuite) setupServiceExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	serviceNames := []string{"dummy-service", "exposed-service"}
@@ -6,6 +6,7 @@ package application
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/url"
 	"os"
 
@@ -20,6 +21,7 @@ import (
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	jujuversion "github.com/juju/juju/version"
@@ -52,6 +54,17 @@ func AddCharmWithAuthorization(st *state.State, args params.AddCharmWithAuthoriz
 	if charmURL.Revision < 0 {
 		return fmt.Errorf("charm URL must include revision")
 	}
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return err
+	}
+	if controllerConfig.CharmStoreMirror() {
+		// The charm store is not reachable from the controller,
+		// so the charm must have been imported into its mirror.
+		if _, err := st.ResolveMirroredCharm(charmURL); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	// First, check if a pending or a real charm exists in state.
 	stateCharm, err := st.PrepareStoreCharmUpload(charmURL)
@@ -62,6 +75,9 @@ func AddCharmWithAuthorization(st *state.State, args params.AddCharmWithAuthoriz
 		// Charm already in state (it was uploaded already).
 		return nil
 	}
+	if controllerConfig.CharmStoreMirror() {
+		return errors.Trace(addMirroredCharm(st, charmURL))
+	}
 
 	// Open a charm store client.
 	repo, err := openCSRepo(args)
@@ -121,6 +137,49 @@ func AddCharmWithAuthorization(st *state.State, args params.AddCharmWithAuthoriz
 	return StoreCharmArchive(st, ca)
 }
 
+// addMirroredCharm copies the charm with the given URL from the
+// controller's charm mirror into the model.
+func addMirroredCharm(st *state.State, curl *charm.URL) error {
+	reader, _, err := st.OpenMirroredCharm(curl)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer reader.Close()
+	archive, err := ioutil.TempFile("", "charm")
+	if err != nil {
+		return errors.Annotate(err, "cannot create temp file")
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+	if _, err := io.Copy(archive, reader); err != nil {
+		return errors.Annotate(err, "cannot read mirrored charm")
+	}
+	ch, err := charm.ReadCharmArchive(archive.Name())
+	if err != nil {
+		return errors.Annotate(err, "invalid mirrored charm archive")
+	}
+	if err := checkMinVersion(ch); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := archive.Seek(0, 0); err != nil {
+		return errors.Annotate(err, "cannot rewind charm archive")
+	}
+	bundleSHA256, size, err := utils.ReadSHA256(archive)
+	if err != nil {
+		return errors.Annotate(err, "cannot calculate SHA256 hash of charm")
+	}
+	if _, err := archive.Seek(0, 0); err != nil {
+		return errors.Annotate(err, "cannot rewind charm archive")
+	}
+	return StoreCharmArchive(st, CharmArchive{
+		ID:     curl,
+		Charm:  ch,
+		Data:   archive,
+		Size:   size,
+		SHA256: bundleSHA256,
+	})
+}
+
 func openCSRepo(args params.AddCharmWithAuthorization) (charmrepo.Interface, error) {
 	csClient, err := openCSClient(args)
 	if err != nil {
@@ -198,6 +257,16 @@ type CharmArchive struct {
 
 // StoreCharmArchive stores a charm archive in environment storage.
 func StoreCharmArchive(st *state.State, archive CharmArchive) error {
+	profile, err := lxdprofile.ReadCharmProfile(archive.Charm)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if profile != nil {
+		if err := profile.Validate(); err != nil {
+			return errors.NewBadRequest(err, fmt.Sprintf("invalid %s", lxdprofile.ProfileFile))
+		}
+	}
+
 	storage := newStateStorage(st.ModelUUID(), st.MongoSession())
 	storagePath, err := charmArchiveStoragePath(archive.ID)
 	if err != nil {
@@ -213,6 +282,7 @@ func StoreCharmArchive(st *state.State, archive CharmArchive) error {
 		StoragePath: storagePath,
 		SHA256:      archive.SHA256,
 		Macaroon:    archive.Macaroon,
+		LXDProfile:  profile,
 	}
 
 	// Now update the charm data in state and mark it as no longer pending.
@@ -249,7 +319,8 @@ func charmArchiveStoragePath(curl *charm.URL) (string, error) {
 }
 
 // ResolveCharm resolves the best available charm URLs with series, for charm
-// locations without a series specified.
+// locations without a series specified. For controllers with a charm mirror,
+// the charms are resolved against the mirror, including their revisions.
 func ResolveCharms(st *state.State, args params.ResolveCharms) (params.ResolveCharmResults, error) {
 	var results params.ResolveCharmResults
 
@@ -257,6 +328,23 @@ func ResolveCharms(st *state.State, args params.ResolveCharms) (params.ResolveCh
 	if err != nil {
 		return params.ResolveCharmResults{}, err
 	}
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return params.ResolveCharmResults{}, err
+	}
+	if controllerConfig.CharmStoreMirror() {
+		for _, ref := range args.References {
+			result := params.ResolveCharmResult{}
+			curl, err := st.ResolveMirroredCharm(&ref)
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.URL = curl
+			}
+			results.URLs = append(results.URLs, result)
+		}
+		return results, nil
+	}
 	repo := config.SpecializeCharmRepo(
 		NewCharmStoreRepo(csclient.New(csclient.Params{})),
 		envConfig)
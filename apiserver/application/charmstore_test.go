@@ -0,0 +1,69 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type charmMirrorSuite struct {
+	jujutesting.JujuConnSuite
+}
+
+var _ = gc.Suite(&charmMirrorSuite{})
+
+func (s *charmMirrorSuite) SetUpTest(c *gc.C) {
+	s.ConfigAttrs = map[string]interface{}{
+		controller.CharmStoreMirror: true,
+	}
+	s.JujuConnSuite.SetUpTest(c)
+}
+
+func (s *charmMirrorSuite) TestAddCharmWithAuthorization(c *gc.C) {
+	curl := s.Factory.MakeMirroredCharm(c, &factory.CharmParams{Name: "wordpress", URL: "cs:quantal/wordpress-3"})
+
+	err := application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
+		URL: curl.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The charm is copied from the controller's mirror into the model.
+	sch, err := s.State.Charm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sch.IsUploaded(), jc.IsTrue)
+	c.Assert(sch.Meta().Name, gc.Equals, "wordpress")
+}
+
+func (s *charmMirrorSuite) TestAddCharmWithAuthorizationOtherModel(c *gc.C) {
+	curl := s.Factory.MakeMirroredCharm(c, &factory.CharmParams{Name: "wordpress", URL: "cs:quantal/wordpress-3"})
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	err := application.AddCharmWithAuthorization(st, params.AddCharmWithAuthorization{
+		URL: curl.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	sch, err := st.Charm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sch.IsUploaded(), jc.IsTrue)
+}
+
+func (s *charmMirrorSuite) TestAddCharmWithAuthorizationNotMirrored(c *gc.C) {
+	// Charms not in the mirror are not fetched from the charm store.
+	err := application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
+		URL: "cs:quantal/mysql-1",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.Charm(charm.MustParseURL("cs:quantal/mysql-1"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
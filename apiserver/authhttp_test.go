@@ -24,6 +24,7 @@ import (
 
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/apiserver/params"
+	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
@@ -171,6 +172,10 @@ type httpRequestParams struct {
 
 	// nonce holds the machine nonce to provide in the header.
 	nonce string
+
+	// extraHeaders holds any additional headers to set on the
+	// request.
+	extraHeaders map[string]string
 }
 
 func (s *authHttpSuite) sendRequest(c *gc.C, p httpRequestParams) *http.Response {
@@ -192,6 +197,9 @@ func (s *authHttpSuite) sendRequest(c *gc.C, p httpRequestParams) *http.Response
 	if p.nonce != "" {
 		hp.Header.Set(params.MachineNonceHeader, p.nonce)
 	}
+	for key, value := range p.extraHeaders {
+		hp.Header.Set(key, value)
+	}
 	if hp.Do == nil {
 		hp.Do = utils.GetNonValidatingHTTPClient().Do
 	}
@@ -244,6 +252,36 @@ func (s *authHttpSuite) setupOtherModel(c *gc.C) *state.State {
 	return envState
 }
 
+// setupImportingModel creates a model which is being imported by a
+// model migration.
+func (s *authHttpSuite) setupImportingModel(c *gc.C) *state.State {
+	st := s.Factory.MakeModel(c, nil)
+	s.AddCleanup(func(*gc.C) { st.Close() })
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetMigrationMode(state.MigrationModeImporting)
+	c.Assert(err, jc.ErrorIsNil)
+	return st
+}
+
+// migrateRequest uploads the file at path to a migrate endpoint as the
+// controller administrator, naming the given model as the one being
+// imported.
+func (s *authHttpSuite) migrateRequest(c *gc.C, uri, contentType, path, modelUUID string) *http.Response {
+	file, err := os.Open(path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer file.Close()
+	return s.sendRequest(c, httpRequestParams{
+		method:       "POST",
+		url:          uri,
+		contentType:  contentType,
+		body:         file,
+		tag:          s.AdminUserTag(c).String(),
+		password:     jujutesting.AdminSecret,
+		extraHeaders: map[string]string{params.MigrationModelHTTPHeader: modelUUID},
+	})
+}
+
 func (s *authHttpSuite) uploadRequest(c *gc.C, uri string, contentType, path string) *http.Response {
 	if path == "" {
 		return s.authRequest(c, httpRequestParams{
@@ -4,6 +4,8 @@
 package charmrevisionupdater
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
@@ -114,11 +116,6 @@ func retrieveLatestCharmInfo(st *state.State) ([]latestCharmInfo, error) {
 		return nil, err
 	}
 
-	client, err := NewCharmStoreClient(st)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-
 	var charms []charmstore.CharmID
 	var resultsIndexedServices []*state.Application
 	for _, service := range services {
@@ -138,9 +135,22 @@ func retrieveLatestCharmInfo(st *state.State) ([]latestCharmInfo, error) {
 		resultsIndexedServices = append(resultsIndexedServices, service)
 	}
 
-	results, err := charmstore.LatestCharmInfo(client, charms, env.UUID())
+	controllerConfig, err := st.ControllerConfig()
 	if err != nil {
-		return nil, err
+		return nil, errors.Trace(err)
+	}
+	var results []charmstore.CharmInfoResult
+	if controllerConfig.CharmStoreMirror() {
+		results = latestMirroredCharmInfo(st, charms)
+	} else {
+		client, err := NewCharmStoreClient(st)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		results, err = charmstore.LatestCharmInfo(client, charms, env.UUID())
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var latest []latestCharmInfo
@@ -157,3 +167,22 @@ func retrieveLatestCharmInfo(st *state.State) ([]latestCharmInfo, error) {
 	}
 	return latest, nil
 }
+
+// latestMirroredCharmInfo returns the latest revisions of the given
+// charms in the controller's charm mirror, for controllers that cannot
+// reach the charm store.
+func latestMirroredCharmInfo(st *state.State, charms []charmstore.CharmID) []charmstore.CharmInfoResult {
+	now := time.Now().UTC()
+	results := make([]charmstore.CharmInfoResult, len(charms))
+	for i, cid := range charms {
+		results[i].OriginalURL = cid.URL
+		results[i].Timestamp = now
+		latest, err := st.LatestMirroredCharm(cid.URL)
+		if err != nil {
+			results[i].Error = errors.Trace(err)
+			continue
+		}
+		results[i].LatestRevision = latest.Revision
+	}
+	return results
+}
@@ -19,11 +19,15 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/controller"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
 )
 
-type charmVersionSuite struct {
+// baseCharmVersionSuite sets up a CharmRevisionUpdaterAPI against a
+// controller whose config is taken from ConfigAttrs.
+type baseCharmVersionSuite struct {
 	testing.CharmSuite
 	jujutesting.JujuConnSuite
 
@@ -32,19 +36,17 @@ type charmVersionSuite struct {
 	authoriser           apiservertesting.FakeAuthorizer
 }
 
-var _ = gc.Suite(&charmVersionSuite{})
-
-func (s *charmVersionSuite) SetUpSuite(c *gc.C) {
+func (s *baseCharmVersionSuite) SetUpSuite(c *gc.C) {
 	s.JujuConnSuite.SetUpSuite(c)
 	s.CharmSuite.SetUpSuite(c, &s.JujuConnSuite)
 }
 
-func (s *charmVersionSuite) TearDownSuite(c *gc.C) {
+func (s *baseCharmVersionSuite) TearDownSuite(c *gc.C) {
 	s.CharmSuite.TearDownSuite(c)
 	s.JujuConnSuite.TearDownSuite(c)
 }
 
-func (s *charmVersionSuite) SetUpTest(c *gc.C) {
+func (s *baseCharmVersionSuite) SetUpTest(c *gc.C) {
 	s.JujuConnSuite.SetUpTest(c)
 	s.CharmSuite.SetUpTest(c)
 	s.resources = common.NewResources()
@@ -57,11 +59,17 @@ func (s *charmVersionSuite) SetUpTest(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
-func (s *charmVersionSuite) TearDownTest(c *gc.C) {
+func (s *baseCharmVersionSuite) TearDownTest(c *gc.C) {
 	s.CharmSuite.TearDownTest(c)
 	s.JujuConnSuite.TearDownTest(c)
 }
 
+type charmVersionSuite struct {
+	baseCharmVersionSuite
+}
+
+var _ = gc.Suite(&charmVersionSuite{})
+
 func (s *charmVersionSuite) TestNewCharmRevisionUpdaterAPIAcceptsStateManager(c *gc.C) {
 	endPoint, err := charmrevisionupdater.NewCharmRevisionUpdaterAPI(s.State, s.resources, s.authoriser)
 	c.Assert(err, jc.ErrorIsNil)
@@ -185,3 +193,37 @@ func (s *charmVersionSuite) TestEnvironmentUUIDUsed(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(header.Get(charmrepo.JujuMetadataHTTPHeader), gc.Equals, "environment_uuid="+env.UUID())
 }
+
+type charmVersionMirrorSuite struct {
+	baseCharmVersionSuite
+}
+
+var _ = gc.Suite(&charmVersionMirrorSuite{})
+
+func (s *charmVersionMirrorSuite) SetUpTest(c *gc.C) {
+	s.ConfigAttrs = map[string]interface{}{
+		controller.CharmStoreMirror: true,
+	}
+	s.baseCharmVersionSuite.SetUpTest(c)
+}
+
+func (s *charmVersionMirrorSuite) TestUpdateRevisions(c *gc.C) {
+	s.AddMachine(c, "0", state.JobManageModel)
+	s.SetupScenario(c)
+	for _, name := range []string{"cs:quantal/mysql-22", "cs:quantal/mysql-24"} {
+		s.Factory.MakeMirroredCharm(c, &factory.CharmParams{Name: "mysql", URL: name})
+	}
+
+	result, err := s.charmrevisionupdater.UpdateLatestRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	// The charm store is not consulted: the latest mirrored revision
+	// is recorded as pending, and charms that are not in the mirror
+	// have none.
+	pending, err := s.State.LatestPlaceholderCharm(charm.MustParseURL("cs:quantal/mysql"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending.String(), gc.Equals, "cs:quantal/mysql-24")
+	_, err = s.State.LatestPlaceholderCharm(charm.MustParseURL("cs:quantal/wordpress"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
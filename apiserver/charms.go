@@ -33,8 +33,9 @@ import (
 
 // charmsHandler handles charm upload through HTTPS in the API server.
 type charmsHandler struct {
-	ctxt    httpContext
-	dataDir string
+	ctxt          httpContext
+	dataDir       string
+	stateAuthFunc func(*http.Request) (*state.State, state.Entity, error)
 }
 
 // bundleContentSenderFunc functions are responsible for sending a
@@ -57,10 +58,17 @@ func (h *charmsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *charmsHandler) servePost(w http.ResponseWriter, r *http.Request) error {
-	st, _, err := h.ctxt.stateForRequestAuthenticatedUser(r)
+	st, entity, err := h.stateAuthFunc(r)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if r.URL.Query().Get("schema") == "cs" {
+		// Charm store charms are added to the controller's
+		// charm mirror rather than to the model.
+		if err := checkCharmMirrorUpload(st, entity); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	// Add a local charm to the store provider.
 	// Requires a "series" query specifying the series to use for the charm.
 	charmURL, err := h.processPost(r, st)
@@ -211,6 +219,14 @@ func (h *charmsHandler) archiveSender(w http.ResponseWriter, r *http.Request, bu
 func (h *charmsHandler) processPost(r *http.Request, st *state.State) (*charm.URL, error) {
 	query := r.URL.Query()
 	series := query.Get("series")
+	var migratedURL *charm.URL
+	if curlStr := query.Get("curl"); curlStr != "" {
+		curl, err := charm.ParseURL(curlStr)
+		if err != nil {
+			return nil, errors.Annotate(err, "invalid curl argument")
+		}
+		migratedURL, series = curl, curl.Series
+	}
 	if series == "" {
 		return nil, fmt.Errorf("expected series=URL argument")
 	}
@@ -236,6 +252,16 @@ func (h *charmsHandler) processPost(r *http.Request, st *state.State) (*charm.UR
 	if err != nil {
 		return nil, fmt.Errorf("invalid charm archive: %v", err)
 	}
+	if migratedURL != nil {
+		return h.processMigratedCharmPost(st, archive, migratedURL)
+	}
+	switch schema := query.Get("schema"); schema {
+	case "", "local":
+	case "cs":
+		return h.processStoreCharmPost(r, st, tempFile.Name(), archive, series)
+	default:
+		return nil, errors.Errorf("unsupported charm URL schema %q", schema)
+	}
 	// We got it, now let's reserve a charm URL for it in state.
 	archiveURL := &charm.URL{
 		Schema:   "local",
@@ -257,6 +283,81 @@ func (h *charmsHandler) processPost(r *http.Request, st *state.State) (*charm.UR
 	return preparedURL, nil
 }
 
+// processStoreCharmPost adds an uploaded charm store charm to the
+// controller's charm mirror. The charm's URL is taken from the "user"
+// and "revision" query arguments, as the revision recorded in a charm
+// store charm's archive need not match its revision in the store. The
+// "sha384" argument must hold the archive's hash as recorded by the
+// charm store.
+func (h *charmsHandler) processStoreCharmPost(r *http.Request, st *state.State, archivePath string, archive *charm.CharmArchive, series string) (*charm.URL, error) {
+	query := r.URL.Query()
+	revision, err := strconv.Atoi(query.Get("revision"))
+	if err != nil {
+		return nil, errors.Errorf("expected revision=N argument for charm store charm")
+	}
+	hash := query.Get("sha384")
+	if hash == "" {
+		return nil, errors.Errorf("expected sha384=HASH argument for charm store charm")
+	}
+	curl := &charm.URL{
+		Schema:   "cs",
+		User:     query.Get("user"),
+		Name:     archive.Meta().Name,
+		Revision: revision,
+		Series:   series,
+	}
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := st.AddMirroredCharm(curl, hash, f, info.Size()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return curl, nil
+}
+
+// processMigratedCharmPost adds a charm uploaded by a model migration
+// to the model being imported, keeping the charm's URL as it was on
+// the source controller.
+func (h *charmsHandler) processMigratedCharmPost(st *state.State, archive *charm.CharmArchive, curl *charm.URL) (*charm.URL, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if model.MigrationMode() != state.MigrationModeImporting {
+		return nil, errors.New("charm URLs may only be specified for models being imported")
+	}
+	switch curl.Schema {
+	case "local":
+		preparedURL, err := st.PrepareLocalCharmUpload(curl)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if preparedURL.String() != curl.String() {
+			return nil, errors.Errorf("charm %q already exists in model", curl)
+		}
+	case "cs":
+		stateCharm, err := st.PrepareStoreCharmUpload(curl)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if stateCharm.IsUploaded() {
+			return curl, nil
+		}
+	default:
+		return nil, errors.Errorf("unsupported charm URL schema %q", curl.Schema)
+	}
+	if err := h.repackageAndUploadCharm(st, archive, curl); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return curl, nil
+}
+
 // processUploadedArchive opens the given charm archive from path,
 // inspects it to see if it has all files at the root of the archive
 // or it has subdirs. It repackages the archive so it has all the
@@ -5,6 +5,8 @@ package apiserver_test
 
 import (
 	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -22,9 +24,12 @@ import (
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
 	"github.com/juju/juju/testcharms"
+	"github.com/juju/juju/testing/factory"
 )
 
 // charmsCommonSuite wraps authHttpSuite and adds
@@ -166,6 +171,41 @@ func (s *charmsSuite) TestUploadFailsWithInvalidZip(c *gc.C) {
 	s.assertErrorResponse(c, resp, http.StatusBadRequest, "expected Content-Type: application/zip, got: application/octet-stream")
 }
 
+func (s *charmsSuite) TestUploadStoresLXDProfile(c *gc.C) {
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "lxd-profile")
+	resp := s.uploadRequest(c, s.charmsURI(c, "?series=quantal"), "application/zip", ch.Path)
+	expectedURL := charm.MustParseURL("local:quantal/lxd-profile-1")
+	s.assertUploadResponse(c, resp, expectedURL.String())
+
+	sch, err := s.State.Charm(expectedURL)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sch.LXDProfile(), jc.DeepEquals, &lxdprofile.Profile{
+		Description: "lxd profile for testing",
+		Config: map[string]string{
+			"security.nesting":     "true",
+			"linux.kernel_modules": "openvswitch,nbd,ip_tables",
+		},
+		Devices: map[string]map[string]string{
+			"tun": {"path": "/dev/net/tun", "type": "unix-char"},
+		},
+	})
+}
+
+func (s *charmsSuite) TestUploadRejectsInvalidLXDProfile(c *gc.C) {
+	dir := testcharms.Repo.ClonedDir(c.MkDir(), "lxd-profile")
+	profile := "config:\n  raw.lxc: lxc.aa_profile=unconfined\n"
+	err := ioutil.WriteFile(filepath.Join(dir.Path, lxdprofile.ProfileFile), []byte(profile), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	tempFile, err := ioutil.TempFile(c.MkDir(), "charm")
+	c.Assert(err, jc.ErrorIsNil)
+	defer tempFile.Close()
+	err = dir.ArchiveTo(tempFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	resp := s.uploadRequest(c, s.charmsURI(c, "?series=quantal"), "application/zip", tempFile.Name())
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `invalid lxd-profile.yaml: config key "raw.lxc" not valid`)
+}
+
 func (s *charmsSuite) TestUploadBumpsRevision(c *gc.C) {
 	// Add the dummy charm with revision 1.
 	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
@@ -237,6 +277,163 @@ func (s *charmsSuite) TestUploadRespectsLocalRevision(c *gc.C) {
 	c.Assert(downloadedSHA256, gc.Equals, expectedSHA256)
 }
 
+func (s *charmsSuite) TestUploadStoreCharmRequiresMirror(c *gc.C) {
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	uri := s.charmsURI(c, "?series=quantal&schema=cs&revision=7&sha384="+fileSHA384(c, ch.Path))
+	resp := s.uploadRequest(c, uri, "application/zip", ch.Path)
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, "charm store charms and resources may only be uploaded to controllers with charmstore-mirror enabled")
+}
+
+func fileSHA384(c *gc.C, path string) string {
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	hash := sha512.Sum384(data)
+	return hex.EncodeToString(hash[:])
+}
+
+type charmsMirrorSuite struct {
+	charmsCommonSuite
+}
+
+var _ = gc.Suite(&charmsMirrorSuite{})
+
+func (s *charmsMirrorSuite) SetUpTest(c *gc.C) {
+	s.ConfigAttrs = map[string]interface{}{
+		controller.CharmStoreMirror: true,
+	}
+	s.charmsCommonSuite.SetUpTest(c)
+}
+
+func (s *charmsMirrorSuite) TestUploadStoreCharm(c *gc.C) {
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+
+	uri := s.charmsURI(c, "?series=quantal&schema=cs&revision=7&user=bob&sha384="+fileSHA384(c, ch.Path))
+	resp := s.uploadRequest(c, uri, "application/zip", ch.Path)
+	expectedURL := charm.MustParseURL("cs:~bob/quantal/dummy-7")
+	s.assertUploadResponse(c, resp, expectedURL.String())
+
+	// The charm is added to the controller's mirror, not the model.
+	_, err := s.State.Charm(expectedURL)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	curl, err := s.State.ResolveMirroredCharm(charm.MustParseURL("cs:~bob/dummy"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl, gc.DeepEquals, expectedURL)
+	reader, hash, err := s.State.OpenMirroredCharm(expectedURL)
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+	c.Assert(hash, gc.Equals, fileSHA384(c, ch.Path))
+}
+
+func (s *charmsMirrorSuite) TestUploadStoreCharmRequiresRevision(c *gc.C) {
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	resp := s.uploadRequest(c, s.charmsURI(c, "?series=quantal&schema=cs"), "application/zip", ch.Path)
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, "expected revision=N argument for charm store charm")
+}
+
+func (s *charmsMirrorSuite) TestUploadStoreCharmRequiresHash(c *gc.C) {
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	resp := s.uploadRequest(c, s.charmsURI(c, "?series=quantal&schema=cs&revision=7"), "application/zip", ch.Path)
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, "expected sha384=HASH argument for charm store charm")
+}
+
+func (s *charmsMirrorSuite) TestUploadStoreCharmHashMismatch(c *gc.C) {
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	other := testcharms.Repo.CharmArchive(c.MkDir(), "mysql")
+	uri := s.charmsURI(c, "?series=quantal&schema=cs&revision=7&sha384="+fileSHA384(c, other.Path))
+	resp := s.uploadRequest(c, uri, "application/zip", ch.Path)
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `charm "cs:quantal/dummy-7" archive with SHA384 .* not valid`)
+	_, err := s.State.ResolveMirroredCharm(charm.MustParseURL("cs:dummy"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *charmsMirrorSuite) TestUploadStoreCharmRequiresControllerAdmin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "hunter2", Access: state.ModelReadAccess})
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	f, err := os.Open(ch.Path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	resp := s.sendRequest(c, httpRequestParams{
+		tag:         user.Tag().String(),
+		password:    "hunter2",
+		method:      "POST",
+		url:         s.charmsURI(c, "?series=quantal&schema=cs&revision=7&sha384="+fileSHA384(c, ch.Path)),
+		contentType: "application/zip",
+		body:        f,
+	})
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
+func (s *charmsSuite) migrateCharmsURI(c *gc.C, query string) string {
+	uri := s.baseURL(c)
+	uri.Path = "/migrate/charms"
+	uri.RawQuery = query
+	return uri.String()
+}
+
+func (s *charmsSuite) TestMigrateLocalCharm(c *gc.C) {
+	importingSt := s.setupImportingModel(c)
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+
+	uri := s.migrateCharmsURI(c, "curl=local:quantal/dummy-5")
+	resp := s.migrateRequest(c, uri, "application/zip", ch.Path, importingSt.ModelUUID())
+	expectedURL := charm.MustParseURL("local:quantal/dummy-5")
+	s.assertUploadResponse(c, resp, expectedURL.String())
+	sch, err := importingSt.Charm(expectedURL)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sch.IsUploaded(), jc.IsTrue)
+
+	// The charm must not have been added to the controller model.
+	_, err = s.State.Charm(expectedURL)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *charmsSuite) TestMigrateStoreCharm(c *gc.C) {
+	importingSt := s.setupImportingModel(c)
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+
+	uri := s.migrateCharmsURI(c, "curl=cs:~bob/quantal/dummy-7")
+	resp := s.migrateRequest(c, uri, "application/zip", ch.Path, importingSt.ModelUUID())
+	expectedURL := charm.MustParseURL("cs:~bob/quantal/dummy-7")
+	s.assertUploadResponse(c, resp, expectedURL.String())
+	sch, err := importingSt.Charm(expectedURL)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sch.IsUploaded(), jc.IsTrue)
+}
+
+func (s *charmsSuite) TestMigrateCharmRequiresImportingModel(c *gc.C) {
+	otherSt := s.Factory.MakeModel(c, nil)
+	defer otherSt.Close()
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+
+	uri := s.migrateCharmsURI(c, "curl=local:quantal/dummy-5")
+	resp := s.migrateRequest(c, uri, "application/zip", ch.Path, otherSt.ModelUUID())
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, "model is not being imported")
+}
+
+func (s *charmsSuite) TestMigrateCharmRequiresControllerAdmin(c *gc.C) {
+	importingSt := s.setupImportingModel(c)
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+
+	uri := s.migrateCharmsURI(c, "curl=local:quantal/dummy-5")
+	file, err := os.Open(ch.Path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer file.Close()
+	resp := s.authRequest(c, httpRequestParams{
+		method:       "POST",
+		url:          uri,
+		contentType:  "application/zip",
+		body:         file,
+		extraHeaders: map[string]string{params.MigrationModelHTTPHeader: importingSt.ModelUUID()},
+	})
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
+func (s *charmsSuite) TestUploadRejectsCharmURLForNormalModel(c *gc.C) {
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	resp := s.uploadRequest(c, s.charmsURI(c, "?curl=local:quantal/dummy-5"), "application/zip", ch.Path)
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, "charm URLs may only be specified for models being imported")
+}
+
 func (s *charmsSuite) TestUploadAllowsTopLevelPath(c *gc.C) {
 	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
 	// Backwards compatibility check, that we can upload charms to
@@ -25,7 +25,8 @@ import (
 )
 
 func init() {
-	common.RegisterStandardFacade("Client", 1, NewClient)
+	common.RegisterStandardFacade("Client", 1, NewClientV1)
+	common.RegisterStandardFacade("Client", 2, NewClient)
 }
 
 var logger = loggo.GetLogger("juju.apiserver.client")
@@ -77,6 +78,26 @@ func NewClient(st *state.State, resources *common.Resources, authorizer common.A
 	return client, nil
 }
 
+// ClientV1 implements version 1 of the client API end point. It
+// differs from version 2 in not reporting the source of model config
+// values.
+type ClientV1 struct {
+	*Client
+}
+
+// NewClientV1 returns a new version 1 client API facade.
+func NewClientV1(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*ClientV1, error) {
+	client, err := NewClient(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientV1{client}, nil
+}
+
+// ModelGetWithSource is not available in version 1 of the facade; the
+// method signature hides it from the RPC layer.
+func (c *ClientV1) ModelGetWithSource(_, _ struct{}) {}
+
 func (c *Client) WatchAll() (params.AllWatcherId, error) {
 	w := c.api.stateAccessor.Watch()
 	return params.AllWatcherId{
@@ -349,6 +370,10 @@ func (c *Client) ModelInfo() (params.ModelInfo, error) {
 	if err != nil {
 		return params.ModelInfo{}, err
 	}
+	controllerConfig, err := state.ControllerConfig()
+	if err != nil {
+		return params.ModelInfo{}, err
+	}
 
 	info := params.ModelInfo{
 		DefaultSeries:   config.PreferredSeries(conf),
@@ -358,6 +383,8 @@ func (c *Client) ModelInfo() (params.ModelInfo, error) {
 		Name:            conf.Name(),
 		UUID:            model.UUID(),
 		ControllerUUID:  model.ControllerUUID(),
+
+		CharmStoreMirror: controllerConfig.CharmStoreMirror(),
 	}
 	return info, nil
 }
@@ -405,6 +432,24 @@ func (c *Client) ModelGet() (params.ModelConfigResults, error) {
 	return result, nil
 }
 
+// ModelGetWithSource implements the server-side part of the
+// get-model-config CLI command, reporting the source of each value.
+func (c *Client) ModelGetWithSource() (params.ModelConfigSourceResults, error) {
+	result := params.ModelConfigSourceResults{}
+	values, err := c.api.stateAccessor.ModelConfigValues()
+	if err != nil {
+		return result, err
+	}
+	result.Config = make(map[string]params.ConfigValue)
+	for attr, val := range values {
+		result.Config[attr] = params.ConfigValue{
+			Value:  val.Value,
+			Source: val.Source,
+		}
+	}
+	return result, nil
+}
+
 // ModelSet implements the server-side part of the
 // set-model-config CLI command.
 func (c *Client) ModelSet(args params.ModelSet) error {
@@ -462,6 +507,26 @@ func (c *Client) SetModelAgentVersion(args params.SetModelAgentVersion) error {
 	return c.api.stateAccessor.SetModelAgentVersion(args.Version)
 }
 
+// SetModelAgentCanary upgrades the agents on the given machines, and
+// the units they host, ahead of the rest of the model. The upgrade is
+// completed by setting the model agent version to the same version, or
+// abandoned with AbortModelAgentCanary.
+func (c *Client) SetModelAgentCanary(args params.SetModelAgentCanary) error {
+	if err := c.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	return c.api.stateAccessor.SetModelAgentCanary(args.Version, args.MachineIds)
+}
+
+// AbortModelAgentCanary abandons the model's canary upgrade, returning
+// the canary machines to the model agent version.
+func (c *Client) AbortModelAgentCanary() error {
+	if err := c.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	return c.api.stateAccessor.AbortModelAgentCanary()
+}
+
 var getEnvironment = func(cfg *config.Config) (environs.Environ, error) {
 	env, err := environs.New(cfg)
 	if err != nil {
@@ -5,6 +5,7 @@ package client_test
 
 import (
 	"fmt"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
@@ -25,6 +26,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/manual"
@@ -32,6 +34,7 @@ import (
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/state/presence"
@@ -280,6 +283,44 @@ func (s *serverSuite) TestAbortCurrentUpgrade(c *gc.C) {
 	c.Assert(isUpgrading, jc.IsFalse)
 }
 
+func (s *serverSuite) TestSetModelAgentCanary(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	newer := jujuversion.Current
+	newer.Patch++
+
+	err = s.client.SetModelAgentCanary(params.SetModelAgentCanary{
+		Version:    newer,
+		MachineIds: []string{machine.Id()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	canary, ok := model.UpgradeCanary()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(canary.Version, gc.Equals, newer)
+	c.Assert(canary.MachineIds, jc.DeepEquals, []string{machine.Id()})
+
+	err = s.client.AbortModelAgentCanary()
+	c.Assert(err, jc.ErrorIsNil)
+	model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = model.UpgradeCanary()
+	c.Assert(ok, jc.IsFalse)
+
+	err = s.client.AbortModelAgentCanary()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *serverSuite) TestBlockChangesSetModelAgentCanary(c *gc.C) {
+	s.BlockAllChanges(c, "TestBlockChangesSetModelAgentCanary")
+	err := s.client.SetModelAgentCanary(params.SetModelAgentCanary{
+		Version:    version.MustParse("9.8.7"),
+		MachineIds: []string{"0"},
+	})
+	s.AssertBlocked(c, err, "TestBlockChangesSetModelAgentCanary")
+}
+
 func (s *serverSuite) assertAbortCurrentUpgradeBlocked(c *gc.C, msg string) {
 	err := s.client.AbortCurrentUpgrade()
 	s.AssertBlocked(c, err, msg)
@@ -470,6 +511,7 @@ func (s *clientSuite) TestClientModelInfo(c *gc.C) {
 	c.Assert(info.Name, gc.Equals, conf.Name())
 	c.Assert(info.UUID, gc.Equals, model.UUID())
 	c.Assert(info.ControllerUUID, gc.Equals, model.ControllerUUID())
+	c.Assert(info.CharmStoreMirror, jc.IsFalse)
 }
 
 func assertLife(c *gc.C, entity state.Living, life state.Life) {
@@ -826,6 +868,33 @@ func (s *serverSuite) TestClientModelGet(c *gc.C) {
 	c.Assert(result.Config, gc.DeepEquals, modelConfig.AllAttrs())
 }
 
+func (s *serverSuite) TestClientModelGetWithSource(c *gc.C) {
+	err := s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"apt-mirror": "http://mirror",
+	}, nil, "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfig(map[string]interface{}{"some-key": "value"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.client.ModelGetWithSource()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Config["apt-mirror"], jc.DeepEquals, params.ConfigValue{
+		Value: "http://mirror", Source: "controller",
+	})
+	c.Assert(result.Config["some-key"], jc.DeepEquals, params.ConfigValue{
+		Value: "value", Source: "model",
+	})
+}
+
+func (s *serverSuite) TestModelGetWithSourceOnlyInV2(c *gc.C) {
+	objType := rpcreflect.ObjTypeOf(reflect.TypeOf(&client.ClientV1{}))
+	_, err := objType.Method("ModelGetWithSource")
+	c.Assert(err, gc.Equals, rpcreflect.ErrMethodNotFound)
+	objType = rpcreflect.ObjTypeOf(reflect.TypeOf(&client.Client{}))
+	_, err = objType.Method("ModelGetWithSource")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *serverSuite) assertEnvValue(c *gc.C, key string, expected interface{}) {
 	modelConfig, err := s.State.ModelConfig()
 	c.Assert(err, jc.ErrorIsNil)
@@ -1389,6 +1458,40 @@ func (s *clientRepoSuite) TestResolveCharm(c *gc.C) {
 	}
 }
 
+type clientMirrorSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&clientMirrorSuite{})
+
+func (s *clientMirrorSuite) SetUpTest(c *gc.C) {
+	s.ConfigAttrs = map[string]interface{}{
+		controller.CharmStoreMirror: true,
+	}
+	s.baseSuite.SetUpTest(c)
+}
+
+func (s *clientMirrorSuite) TestModelInfo(c *gc.C) {
+	info, err := s.APIState.Client().ModelInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.CharmStoreMirror, jc.IsTrue)
+}
+
+func (s *clientMirrorSuite) TestResolveCharm(c *gc.C) {
+	for _, url := range []string{"cs:precise/wordpress-1", "cs:precise/wordpress-4"} {
+		s.Factory.MakeMirroredCharm(c, &factory.CharmParams{Name: "wordpress", URL: url})
+	}
+
+	// The charm store is not consulted: only mirrored charms resolve,
+	// to their latest mirrored revision.
+	client := s.APIState.Client()
+	curl, err := client.ResolveCharm(charm.MustParseURL("cs:wordpress"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl.String(), gc.Equals, "cs:precise/wordpress-4")
+	_, err = client.ResolveCharm(charm.MustParseURL("cs:mysql"))
+	c.Assert(err, gc.ErrorMatches, `charm "cs:mysql" in the charm mirror not found`)
+}
+
 func (s *clientSuite) TestRetryProvisioning(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -9,6 +9,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/constraints"
+	jujucontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -43,6 +44,8 @@ type stateInterface interface {
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
 	ModelConstraints() (constraints.Value, error)
 	ModelConfig() (*config.Config, error)
+	ControllerConfig() (jujucontroller.Config, error)
+	ModelConfigValues() (config.ConfigValues, error)
 	UpdateModelConfig(map[string]interface{}, []string, state.ValidateConfigFunc) error
 	SetModelConstraints(constraints.Value) error
 	ModelUUID() string
@@ -50,6 +53,8 @@ type stateInterface interface {
 	Model() (*state.Model, error)
 	ForModel(tag names.ModelTag) (*state.State, error)
 	SetModelAgentVersion(version.Number) error
+	SetModelAgentCanary(version.Number, []string) error
+	AbortModelAgentCanary() error
 	SetAnnotations(state.GlobalEntity, map[string]string) error
 	Annotations(state.GlobalEntity) (map[string]string, error)
 	InferEndpoints(...string) ([]state.Endpoint, error)
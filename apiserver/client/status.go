@@ -465,6 +465,11 @@ func makeMachineStatus(machine *state.Machine) (status params.MachineStatus) {
 	status.Jobs = paramsJobsFromJobs(machine.Jobs())
 	status.WantsVote = machine.WantsVote()
 	status.HasVote = machine.HasVote()
+	if upgradeSeries, err := machine.UpgradeSeriesStatus(); err != nil {
+		logger.Debugf("error fetching upgrade series status: %v", err)
+	} else if upgradeSeries != state.UpgradeSeriesNotStarted {
+		status.UpgradeSeries = string(upgradeSeries)
+	}
 	sInfo, err := machine.InstanceStatus()
 	populateStatusFromStatusInfoAndErr(&status.InstanceStatus, sInfo, err)
 	instid, err := machine.InstanceId()
@@ -580,6 +585,15 @@ func (context *statusContext) processApplication(service *state.Application) par
 		Exposed: service.IsExposed(),
 		Life:    processLife(service),
 	}
+	if rolling, ok := service.RollingUpgrade(); ok {
+		processedStatus.RollingUpgrade = &params.RollingUpgradeStatus{
+			PreviousCharm: rolling.PreviousCharmURL.String(),
+			BatchSize:     rolling.BatchSize,
+			Released:      rolling.Released,
+			Halted:        rolling.Halted,
+			Message:       rolling.Message,
+		}
+	}
 
 	if latestCharm, ok := context.latestCharms[*serviceCharmURL.WithRevision(-1)]; ok && latestCharm != nil {
 		if latestCharm.Revision() > serviceCharmURL.Revision {
@@ -588,6 +602,11 @@ func (context *statusContext) processApplication(service *state.Application) par
 	}
 
 	var err error
+	processedStatus.PlacementViolations, err = service.PlacementPolicyViolations()
+	if err != nil {
+		processedStatus.Err = err
+		return processedStatus
+	}
 	processedStatus.Relations, processedStatus.SubordinateTo, err = context.processServiceRelations(service)
 	if err != nil {
 		processedStatus.Err = err
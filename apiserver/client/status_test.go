@@ -4,6 +4,8 @@
 package client_test
 
 import (
+	"fmt"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -77,6 +79,19 @@ func (s *statusUnitTestSuite) TestProcessMachinesWithOneMachineAndOneContainer(c
 	c.Check(statuses[host.Id()].Containers[container.Id()].Id, gc.Equals, containerStatus.Id)
 }
 
+func (s *statusUnitTestSuite) TestMachineUpgradeSeriesStatus(c *gc.C) {
+	machine := s.MakeMachine(c, nil)
+	status := client.MakeMachineStatus(machine)
+	c.Check(status.UpgradeSeries, gc.Equals, "")
+
+	err := machine.CreateUpgradeSeriesLock(nil, "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetUpgradeSeriesStatus(state.UpgradeSeriesPrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	status = client.MakeMachineStatus(machine)
+	c.Check(status.UpgradeSeries, gc.Equals, "prepare completed")
+}
+
 func (s *statusUnitTestSuite) TestProcessMachinesWithEmbeddedContainers(c *gc.C) {
 	host := s.MakeMachine(c, &factory.MachineParams{InstanceId: instance.Id("1")})
 	lxdHost := s.MakeMachineNested(c, host.Id(), nil)
@@ -153,6 +168,25 @@ func (s *statusUnitTestSuite) TestMeterStatus(c *gc.C) {
 	}
 }
 
+func (s *statusUnitTestSuite) TestPlacementViolations(c *gc.C) {
+	service := s.MakeApplication(c, nil)
+	machine := s.MakeMachine(c, nil)
+	for i := 0; i < 2; i++ {
+		s.MakeUnit(c, &factory.UnitParams{Application: service, Machine: machine})
+	}
+	err := service.SetPlacementPolicy(state.PlacementPolicy{Spread: state.PlacementScopeMachine})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	serviceStatus, ok := status.Applications[service.Name()]
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(serviceStatus.PlacementViolations, jc.DeepEquals, []string{
+		fmt.Sprintf("units %s/0 and %s/1 share machine %s", service.Name(), service.Name(), machine.Id()),
+	})
+}
+
 type statusUpgradeUnitSuite struct {
 	testing.CharmSuite
 	jujutesting.JujuConnSuite
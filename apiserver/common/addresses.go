@@ -19,6 +19,8 @@ type AddressAndCertGetter interface {
 	ModelUUID() string
 	APIHostPorts() ([][]network.HostPort, error)
 	WatchAPIHostPorts() state.NotifyWatcher
+	TrustedCACert() (string, error)
+	WatchCACert() state.NotifyWatcher
 }
 
 // APIAddresser implements the APIAddresses method
@@ -93,6 +95,29 @@ func (a *APIAddresser) CACert() params.BytesResult {
 	}
 }
 
+// TrustedCACert returns the CA certificates that should be trusted
+// when validating the API connection. While the controller CA is being
+// rotated, both the current and the new CA certificates are returned.
+func (a *APIAddresser) TrustedCACert() (params.StringResult, error) {
+	caCert, err := a.getter.TrustedCACert()
+	if err != nil {
+		return params.StringResult{}, err
+	}
+	return params.StringResult{Result: caCert}, nil
+}
+
+// WatchCACert watches the CA certificates that should be trusted when
+// validating the API connection.
+func (a *APIAddresser) WatchCACert() (params.NotifyWatchResult, error) {
+	watch := a.getter.WatchCACert()
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: a.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{}, watcher.EnsureErr(watch)
+}
+
 // ModelUUID returns the model UUID to connect to the environment
 // that the current connection is for.
 func (a *APIAddresser) ModelUUID() params.StringResult {
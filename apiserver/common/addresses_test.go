@@ -93,6 +93,12 @@ func (s *apiAddresserSuite) TestCACert(c *gc.C) {
 	c.Assert(string(result.Result), gc.Equals, "a cert")
 }
 
+func (s *apiAddresserSuite) TestTrustedCACert(c *gc.C) {
+	result, err := s.addresser.TrustedCACert()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, gc.Equals, "a cert\nanother cert")
+}
+
 func (s *apiAddresserSuite) TestEnvironUUID(c *gc.C) {
 	result := s.addresser.ModelUUID()
 	c.Assert(string(result.Result), gc.Equals, "the environ uuid")
@@ -127,3 +133,11 @@ func (f fakeAddresses) APIHostPorts() ([][]network.HostPort, error) {
 func (fakeAddresses) WatchAPIHostPorts() state.NotifyWatcher {
 	panic("should never be called")
 }
+
+func (fakeAddresses) TrustedCACert() (string, error) {
+	return "a cert\nanother cert", nil
+}
+
+func (fakeAddresses) WatchCACert() state.NotifyWatcher {
+	panic("should never be called")
+}
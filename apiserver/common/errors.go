@@ -77,6 +77,34 @@ func IsDischargeRequiredError(err error) bool {
 	return ok
 }
 
+// RedirectError is the error returned when a client attempts to
+// connect to a model which has been migrated to another controller.
+type RedirectError struct {
+	// ControllerTag holds the tag of the controller the model was
+	// migrated to.
+	ControllerTag names.ModelTag
+
+	// Servers holds the host:port addresses of the API servers of
+	// the controller the model was migrated to.
+	Servers []string
+
+	// CACert holds the CA certificate used to validate the API
+	// servers' certificates, in PEM format.
+	CACert string
+}
+
+// Error implements the error interface.
+func (e *RedirectError) Error() string {
+	return fmt.Sprintf("model has been migrated to controller %s", e.ControllerTag.Id())
+}
+
+// IsRedirectError reports whether the cause of the error is a
+// *RedirectError.
+func IsRedirectError(err error) bool {
+	_, ok := errors.Cause(err).(*RedirectError)
+	return ok
+}
+
 // IsUpgradeInProgress returns true if this error is caused
 // by an upgrade in progress.
 func IsUpgradeInProgressError(err error) bool {
@@ -225,6 +253,8 @@ func ServerError(err error) *params.Error {
 		code = params.CodeBadRequest
 	case errors.IsMethodNotAllowed(err):
 		code = params.CodeMethodNotAllowed
+	case IsRedirectError(err):
+		code = params.CodeRedirect
 	default:
 		if err, ok := err.(*DischargeRequiredError); ok {
 			code = params.CodeDischargeRequired
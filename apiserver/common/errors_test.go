@@ -176,6 +176,15 @@ var errorTransformTests = []struct {
 		}
 		return true
 	},
+}, {
+	err: &common.RedirectError{
+		ControllerTag: names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d"),
+		Servers:       []string{"1.2.3.4:17070"},
+		CACert:        "cert",
+	},
+	code:       params.CodeRedirect,
+	status:     http.StatusInternalServerError,
+	helperFunc: params.IsCodeRedirect,
 }, {
 	err:    unhashableError{"foo"},
 	status: http.StatusInternalServerError,
@@ -232,7 +241,8 @@ func (s *errorsSuite) TestErrorTransform(c *gc.C) {
 			params.CodeNoAddressSet,
 			params.CodeUpgradeInProgress,
 			params.CodeMachineHasAttachedStorage,
-			params.CodeDischargeRequired:
+			params.CodeDischargeRequired,
+			params.CodeRedirect:
 			continue
 		case params.CodeNotFound:
 			if common.IsUnknownModelError(t.err) {
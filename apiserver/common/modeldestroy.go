@@ -12,7 +12,15 @@ import (
 )
 
 var sendMetrics = func(st *state.State) error {
-	err := metricsender.SendMetrics(st, metricsender.DefaultMetricSender(), metricsender.DefaultMaxBatchesPerSend())
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sender, err := metricsender.NewSender(cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = metricsender.SendMetrics(st, sender, metricsender.DefaultMaxBatchesPerSend())
 	return errors.Trace(err)
 }
 
@@ -7,15 +7,20 @@ package controller
 
 import (
 	"sort"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/migrationtarget"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cert"
 	"github.com/juju/juju/core/migration"
+	modelmigration "github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 )
 
@@ -36,6 +41,9 @@ type Controller interface {
 	WatchAllModels() (params.AllWatcherId, error)
 	ModelStatus(req params.Entities) (params.ModelStatusResults, error)
 	InitiateModelMigration(params.InitiateModelMigrationArgs) (params.InitiateModelMigrationResults, error)
+	PrecheckModelMigration(params.InitiateModelMigrationArgs) (params.ModelMigrationPrecheckResults, error)
+	RotateCertificates(params.RotateCertificatesArgs) (params.RotateCertificatesResult, error)
+	SetAPICertificate(params.SetAPICertificateArgs) error
 }
 
 // ControllerAPI implements the environment manager interface and is
@@ -209,6 +217,59 @@ func (s *ControllerAPI) RemoveBlocks(args params.RemoveBlocksArgs) error {
 	return errors.Trace(s.state.RemoveAllBlocksForController())
 }
 
+// SetAPICertificate sets the custom certificate chain and key that the
+// API servers present to clients, in place of the certificate signed by
+// the controller CA. Agents are unaffected. An empty certificate and
+// key remove any custom certificate.
+func (s *ControllerAPI) SetAPICertificate(args params.SetAPICertificateArgs) error {
+	return errors.Trace(s.state.SetAPICertificate(args.Cert, args.PrivateKey))
+}
+
+// RotateCertificates starts or finishes a rotation of the controller
+// CA. Starting a rotation generates a new CA, which is trusted
+// alongside the current one while agents learn of it; finishing the
+// rotation makes the new CA the controller CA, causing the API server
+// and MongoDB certificates to be reissued with it, and finishing it
+// again once every controller has done so retires the old CA. The
+// result holds the CA certificates that clients should now trust.
+func (s *ControllerAPI) RotateCertificates(args params.RotateCertificatesArgs) (params.RotateCertificatesResult, error) {
+	var result params.RotateCertificatesResult
+	if args.Finish {
+		if err := s.state.FinishCARotation(); err != nil {
+			return result, errors.Trace(err)
+		}
+		_, err := s.state.CARotation()
+		if errors.IsNotFound(err) {
+			result.Complete = true
+		} else if err != nil {
+			return result, errors.Trace(err)
+		}
+	} else {
+		controllerModel, err := s.state.ControllerModel()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		cfg, err := s.state.ControllerConfig()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		expiry := time.Now().UTC().AddDate(10, 0, 0)
+		caCert, caKey, err := cert.NewCAWithKeyType(controllerModel.Name(), controllerModel.UUID(), expiry, cfg.CAKeyType())
+		if err != nil {
+			return result, errors.Annotate(err, "cannot generate CA certificate")
+		}
+		if err := s.state.StartCARotation(caCert, caKey); err != nil {
+			return result, errors.Trace(err)
+		}
+	}
+	caCert, err := s.state.TrustedCACert()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.CACert = caCert
+	return result, nil
+}
+
 // WatchAllModels starts watching events for all models in the
 // controller. The returned AllWatcherId should be used with Next on the
 // AllModelWatcher endpoint to receive deltas.
@@ -302,26 +363,14 @@ func (c *ControllerAPI) initiateOneModelMigration(spec params.ModelMigrationSpec
 	defer hostedState.Close()
 
 	// Start the migration.
-	targetInfo := spec.TargetInfo
-
-	controllerTag, err := names.ParseModelTag(targetInfo.ControllerTag)
-	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
-	}
-	authTag, err := names.ParseUserTag(targetInfo.AuthTag)
+	targetInfo, err := targetInfoFromParams(spec.TargetInfo)
 	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
+		return "", errors.Trace(err)
 	}
 
 	args := state.ModelMigrationSpec{
 		InitiatedBy: c.apiUser,
-		TargetInfo: migration.TargetInfo{
-			ControllerTag: controllerTag,
-			Addrs:         targetInfo.Addrs,
-			CACert:        targetInfo.CACert,
-			AuthTag:       authTag,
-			Password:      targetInfo.Password,
-		},
+		TargetInfo:  targetInfo,
 	}
 	mig, err := hostedState.CreateModelMigration(args)
 	if err != nil {
@@ -330,6 +379,100 @@ func (c *ControllerAPI) initiateOneModelMigration(spec params.ModelMigrationSpec
 	return mig.Id(), nil
 }
 
+// PrecheckModelMigration runs the source and target prechecks for one
+// or more model migrations without starting them, reporting every
+// problem that would prevent each model from being migrated.
+func (c *ControllerAPI) PrecheckModelMigration(reqArgs params.InitiateModelMigrationArgs) (
+	params.ModelMigrationPrecheckResults, error,
+) {
+	out := params.ModelMigrationPrecheckResults{
+		Results: make([]params.ModelMigrationPrecheckResult, len(reqArgs.Specs)),
+	}
+	for i, spec := range reqArgs.Specs {
+		result := &out.Results[i]
+		result.ModelTag = spec.ModelTag
+		problems, err := c.precheckOneModelMigration(spec)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Problems = problems
+		}
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) precheckOneModelMigration(spec params.ModelMigrationSpec) ([]string, error) {
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	if err != nil {
+		return nil, errors.Annotate(err, "model tag")
+	}
+	if _, err := c.state.GetModel(modelTag); err != nil {
+		return nil, errors.Annotate(err, "unable to read model")
+	}
+	targetInfo, err := targetInfoFromParams(spec.TargetInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := targetInfo.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	hostedState, err := c.state.ForModel(modelTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer hostedState.Close()
+
+	backend := modelmigration.PrecheckShim(hostedState)
+	problems, err := modelmigration.SourcePrecheck(backend)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	model, err := backend.ModelInfo()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	targetProblems, err := targetPrechecks(targetInfo, model)
+	if err != nil {
+		return nil, errors.Annotate(err, "target prechecks")
+	}
+	return append(problems, targetProblems...), nil
+}
+
+// targetPrechecks connects to the target controller and runs its
+// prechecks for the described model.
+var targetPrechecks = func(targetInfo migration.TargetInfo, model migration.ModelInfo) ([]string, error) {
+	conn, err := api.Open(&api.Info{
+		Addrs:    targetInfo.Addrs,
+		CACert:   targetInfo.CACert,
+		Tag:      targetInfo.AuthTag,
+		Password: targetInfo.Password,
+	}, api.DialOpts{})
+	if err != nil {
+		return nil, errors.Annotate(err, "connecting to target controller")
+	}
+	defer conn.Close()
+	return migrationtarget.NewClient(conn).Prechecks(model)
+}
+
+func targetInfoFromParams(targetInfo params.ModelMigrationTargetInfo) (migration.TargetInfo, error) {
+	controllerTag, err := names.ParseModelTag(targetInfo.ControllerTag)
+	if err != nil {
+		return migration.TargetInfo{}, errors.Annotate(err, "controller tag")
+	}
+	authTag, err := names.ParseUserTag(targetInfo.AuthTag)
+	if err != nil {
+		return migration.TargetInfo{}, errors.Annotate(err, "auth tag")
+	}
+	return migration.TargetInfo{
+		ControllerTag: controllerTag,
+		Addrs:         targetInfo.Addrs,
+		CACert:        targetInfo.CACert,
+		AuthTag:       authTag,
+		Password:      targetInfo.Password,
+	}, nil
+}
+
 func (c *ControllerAPI) environStatus(tag string) (params.ModelStatus, error) {
 	var status params.ModelStatus
 	modelTag, err := names.ParseModelTag(tag)
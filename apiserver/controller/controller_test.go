@@ -4,8 +4,10 @@
 package controller_test
 
 import (
+	"strings"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -17,6 +19,7 @@ import (
 	"github.com/juju/juju/apiserver/controller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coremigration "github.com/juju/juju/core/migration"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
@@ -194,6 +197,66 @@ func (s *controllerSuite) TestControllerConfigFromNonController(c *gc.C) {
 	c.Assert(cfg.Config["api-port"], gc.Equals, cfgFromDB.APIPort())
 }
 
+func (s *controllerSuite) TestRotateCertificates(c *gc.C) {
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	oldCACert, _ := cfg.CACert()
+
+	result, err := s.controller.RotateCertificates(params.RotateCertificatesArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	rotation, err := s.State.CARotation()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.HasPrefix(result.CACert, oldCACert), jc.IsTrue)
+	c.Assert(strings.HasSuffix(result.CACert, rotation.NewCACert), jc.IsTrue)
+
+	// A second rotation cannot be started until the first is finished.
+	_, err = s.controller.RotateCertificates(params.RotateCertificatesArgs{})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	// The old CA remains trusted until the controllers have
+	// reissued their certificates with the new one.
+	result, err = s.controller.RotateCertificates(params.RotateCertificatesArgs{Finish: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.HasPrefix(result.CACert, rotation.NewCACert), jc.IsTrue)
+	_, err = s.State.CARotation()
+	c.Assert(result.Complete, gc.Equals, errors.IsNotFound(err))
+
+	cfg, err = s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	newCACert, _ := cfg.CACert()
+	c.Assert(newCACert, gc.Equals, rotation.NewCACert)
+}
+
+func (s *controllerSuite) TestFinishRotateCertificatesNotStarted(c *gc.C) {
+	_, err := s.controller.RotateCertificates(params.RotateCertificatesArgs{Finish: true})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *controllerSuite) TestSetAPICertificate(c *gc.C) {
+	err := s.controller.SetAPICertificate(params.SetAPICertificateArgs{
+		Cert:       testing.ServerCert,
+		PrivateKey: testing.ServerKey,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	certPEM, keyPEM, err := s.State.APICertificate()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(certPEM, gc.Equals, testing.ServerCert)
+	c.Assert(keyPEM, gc.Equals, testing.ServerKey)
+
+	err = s.controller.SetAPICertificate(params.SetAPICertificateArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, _, err = s.State.APICertificate()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *controllerSuite) TestSetAPICertificateInvalid(c *gc.C) {
+	err := s.controller.SetAPICertificate(params.SetAPICertificateArgs{
+		Cert:       testing.ServerCert,
+		PrivateKey: testing.CAKey,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
 func (s *controllerSuite) TestRemoveBlocks(c *gc.C) {
 	st := s.Factory.MakeModel(c, &factory.ModelParams{
 		Name: "test"})
@@ -378,6 +441,61 @@ func (s *controllerSuite) TestInitiateModelMigrationValidationError(c *gc.C) {
 	c.Check(result.Error, gc.ErrorMatches, "controller tag: .+ is not a valid tag")
 }
 
+func (s *controllerSuite) TestPrecheckModelMigration(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	factory.NewFactory(st).MakeMachine(c, nil)
+
+	var targetModel coremigration.ModelInfo
+	s.PatchValue(controller.TargetPrechecks, func(targetInfo coremigration.TargetInfo, model coremigration.ModelInfo) ([]string, error) {
+		c.Check(targetInfo.Addrs, jc.DeepEquals, []string{"1.1.1.1:1111"})
+		targetModel = model
+		return []string{"target controller upgrade is in progress"}, nil
+	})
+
+	spec := params.ModelMigrationSpec{
+		ModelTag: st.ModelTag().String(),
+		TargetInfo: params.ModelMigrationTargetInfo{
+			ControllerTag: randomModelTag(),
+			Addrs:         []string{"1.1.1.1:1111"},
+			CACert:        "cert1",
+			AuthTag:       names.NewUserTag("admin1").String(),
+			Password:      "secret1",
+		},
+	}
+	out, err := s.controller.PrecheckModelMigration(params.InitiateModelMigrationArgs{
+		Specs: []params.ModelMigrationSpec{spec},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	result := out.Results[0]
+	c.Check(result.Error, gc.IsNil)
+	c.Check(result.ModelTag, gc.Equals, spec.ModelTag)
+	c.Check(result.Problems, jc.DeepEquals, []string{
+		"machine 0 agent is not running",
+		"target controller upgrade is in progress",
+	})
+	c.Check(targetModel.UUID, gc.Equals, st.ModelUUID())
+
+	// No migration was started.
+	_, err = st.GetModelMigration()
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *controllerSuite) TestPrecheckModelMigrationValidationError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	out, err := s.controller.PrecheckModelMigration(params.InitiateModelMigrationArgs{
+		Specs: []params.ModelMigrationSpec{{
+			ModelTag: st.ModelTag().String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "controller tag: .+ is not a valid tag")
+}
+
 func (s *controllerSuite) TestInitiateModelMigrationPartialFailure(c *gc.C) {
 	st := s.Factory.MakeModel(c, nil)
 	defer st.Close()
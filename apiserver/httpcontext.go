@@ -100,6 +100,41 @@ func (ctxt *httpContext) stateForRequestAuthenticatedUser(r *http.Request) (*sta
 	return st, entity, nil
 }
 
+// stateForMigrationImporting returns a state instance for the model
+// identified by the migration model header of the given request. The
+// request must be authenticated as a controller administrator and the
+// model must be in the process of being imported by a migration.
+func (ctxt *httpContext) stateForMigrationImporting(r *http.Request) (*state.State, state.Entity, error) {
+	st, entity, err := ctxt.stateForRequestAuthenticatedUser(r)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	isAdmin, err := st.IsControllerAdministrator(entity.Tag().(names.UserTag))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, nil, common.ErrPerm
+	}
+
+	modelUUID := r.Header.Get(params.MigrationModelHTTPHeader)
+	if modelUUID == "" {
+		return nil, nil, errors.BadRequestf("missing %s header", params.MigrationModelHTTPHeader)
+	}
+	migrationSt, err := ctxt.srv.statePool.Get(modelUUID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	model, err := migrationSt.Model()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if model.MigrationMode() != state.MigrationModeImporting {
+		return nil, nil, errors.BadRequestf("model is not being imported")
+	}
+	return migrationSt, entity, nil
+}
+
 // stateForRequestAuthenticatedUser is like stateForRequestAuthenticated
 // except that it also verifies that the authenticated entity is a user.
 func (ctxt *httpContext) stateForRequestAuthenticatedAgent(r *http.Request) (*state.State, state.Entity, error) {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
@@ -16,7 +17,8 @@ import (
 )
 
 func init() {
-	common.RegisterStandardFacade("MachineManager", 2, NewMachineManagerAPI)
+	common.RegisterStandardFacade("MachineManager", 2, NewMachineManagerAPIV2)
+	common.RegisterStandardFacade("MachineManager", 3, NewMachineManagerAPI)
 }
 
 // MachineManagerAPI provides access to the MachineManager API facade.
@@ -49,6 +51,39 @@ func NewMachineManagerAPI(
 	}, nil
 }
 
+// MachineManagerAPIV2 provides access to version 2 of the MachineManager
+// API facade. It differs from version 3 in not supporting in-place
+// series upgrades.
+type MachineManagerAPIV2 struct {
+	*MachineManagerAPI
+}
+
+// NewMachineManagerAPIV2 creates a new server-side version 2
+// MachineManager API facade.
+func NewMachineManagerAPIV2(
+	st *state.State,
+	resources *common.Resources,
+	authorizer common.Authorizer,
+) (*MachineManagerAPIV2, error) {
+	api, err := NewMachineManagerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &MachineManagerAPIV2{api}, nil
+}
+
+// UpgradeSeriesPrepare is not available in version 2 of the facade; the
+// method signature hides it from the RPC layer.
+func (*MachineManagerAPIV2) UpgradeSeriesPrepare(_, _ struct{}) {}
+
+// UpgradeSeriesComplete is not available in version 2 of the facade;
+// the method signature hides it from the RPC layer.
+func (*MachineManagerAPIV2) UpgradeSeriesComplete(_, _ struct{}) {}
+
+// UpgradeSeriesAbort is not available in version 2 of the facade; the
+// method signature hides it from the RPC layer.
+func (*MachineManagerAPIV2) UpgradeSeriesAbort(_, _ struct{}) {}
+
 // AddMachines adds new machines with the supplied parameters.
 func (mm *MachineManagerAPI) AddMachines(args params.AddMachines) (params.AddMachinesResults, error) {
 	results := params.AddMachinesResults{
@@ -157,3 +192,142 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 	}
 	return mm.st.AddMachineInsideNewMachine(template, template, p.ContainerType)
 }
+
+// UpgradeSeriesPrepare locks a machine for an in-place series upgrade,
+// and requests that each unit on the machine run its pre-series-upgrade
+// hook. Unless the Force flag is set, every unit's charm must support
+// the target series.
+func (mm *MachineManagerAPI) UpgradeSeriesPrepare(arg params.UpgradeSeriesPrepareArg) (params.ErrorResult, error) {
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return params.ErrorResult{}, errors.Trace(err)
+	}
+	err := mm.upgradeSeriesPrepare(arg)
+	return params.ErrorResult{Error: common.ServerError(err)}, nil
+}
+
+func (mm *MachineManagerAPI) upgradeSeriesPrepare(arg params.UpgradeSeriesPrepareArg) error {
+	if arg.Series == "" {
+		return errors.BadRequestf("series missing from args")
+	}
+	m, err := mm.machineFromTag(arg.Entity.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if m.IsManager() {
+		return errors.NotSupportedf("upgrading the series of controller machine %s", m.Id())
+	}
+	units, err := m.Units()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	unitNames := make([]string, len(units))
+	for i, u := range units {
+		unitNames[i] = u.Name()
+		if arg.Force {
+			continue
+		}
+		supported, err := u.CharmSeries()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(supported) > 0 && !containsString(supported, arg.Series) {
+			return errors.Errorf(
+				"series %q is not supported by the charm of unit %s; use --force to upgrade anyway",
+				arg.Series, u.Name(),
+			)
+		}
+	}
+	return errors.Trace(m.CreateUpgradeSeriesLock(unitNames, arg.Series))
+}
+
+// UpgradeSeriesComplete requests that each unit on a machine whose
+// series upgrade has been prepared run its post-series-upgrade hook.
+// The machine agent records the new series once the hooks are done.
+func (mm *MachineManagerAPI) UpgradeSeriesComplete(arg params.Entity) (params.ErrorResult, error) {
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return params.ErrorResult{}, errors.Trace(err)
+	}
+	err := mm.upgradeSeriesComplete(arg)
+	return params.ErrorResult{Error: common.ServerError(err)}, nil
+}
+
+func (mm *MachineManagerAPI) upgradeSeriesComplete(arg params.Entity) error {
+	m, err := mm.machineFromTag(arg.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	status, err := m.UpgradeSeriesStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if status != state.UpgradeSeriesPrepareCompleted {
+		return errors.Errorf("machine %s is not ready to complete a series upgrade (status %q)", m.Id(), status)
+	}
+	return errors.Trace(mm.startUpgradeSeriesCompletion(m))
+}
+
+// UpgradeSeriesAbort abandons the series upgrade of a machine that
+// has not yet been completed, typically after it has failed. Each unit
+// on the machine runs its post-series-upgrade hook so that it can
+// restart its workload, after which the machine agent releases the
+// upgrade lock, recording the series the machine is actually running.
+func (mm *MachineManagerAPI) UpgradeSeriesAbort(arg params.Entity) (params.ErrorResult, error) {
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return params.ErrorResult{}, errors.Trace(err)
+	}
+	err := mm.upgradeSeriesAbort(arg)
+	return params.ErrorResult{Error: common.ServerError(err)}, nil
+}
+
+func (mm *MachineManagerAPI) upgradeSeriesAbort(arg params.Entity) error {
+	m, err := mm.machineFromTag(arg.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	status, err := m.UpgradeSeriesStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch status {
+	case state.UpgradeSeriesPrepareStarted,
+		state.UpgradeSeriesPrepareCompleted,
+		state.UpgradeSeriesError:
+	case state.UpgradeSeriesNotStarted:
+		return errors.Errorf("machine %s is not upgrading its series", m.Id())
+	default:
+		return errors.Errorf("machine %s is already completing its series upgrade (status %q)", m.Id(), status)
+	}
+	return errors.Trace(mm.startUpgradeSeriesCompletion(m))
+}
+
+// startUpgradeSeriesCompletion requests that each unit on the machine
+// run its post-series-upgrade hook.
+func (mm *MachineManagerAPI) startUpgradeSeriesCompletion(m Machine) error {
+	unitStatuses, err := m.UpgradeSeriesUnitStatuses()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for unitName := range unitStatuses {
+		if err := m.SetUpgradeSeriesUnitStatus(unitName, state.UpgradeSeriesCompleteStarted); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(m.SetUpgradeSeriesStatus(state.UpgradeSeriesCompleteStarted))
+}
+
+func (mm *MachineManagerAPI) machineFromTag(tag string) (Machine, error) {
+	machineTag, err := names.ParseMachineTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return mm.st.Machine(machineTag.Id())
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -5,6 +5,7 @@ package machinemanager_test
 
 import (
 	"errors"
+	"reflect"
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -16,6 +17,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/rpc/rpcreflect"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/storage"
@@ -95,6 +97,17 @@ func (s *MachineManagerSuite) TestAddMachines(c *gc.C) {
 	})
 }
 
+func (s *MachineManagerSuite) TestUpgradeSeriesOnlyInV3(c *gc.C) {
+	for _, method := range []string{"UpgradeSeriesPrepare", "UpgradeSeriesComplete", "UpgradeSeriesAbort"} {
+		objType := rpcreflect.ObjTypeOf(reflect.TypeOf(&machinemanager.MachineManagerAPIV2{}))
+		_, err := objType.Method(method)
+		c.Check(err, gc.Equals, rpcreflect.ErrMethodNotFound, gc.Commentf("method %s", method))
+		objType = rpcreflect.ObjTypeOf(reflect.TypeOf(&machinemanager.MachineManagerAPI{}))
+		_, err = objType.Method(method)
+		c.Check(err, jc.ErrorIsNil, gc.Commentf("method %s", method))
+	}
+}
+
 func (s *MachineManagerSuite) TestNewMachineManagerAPINonClient(c *gc.C) {
 	tag := names.NewUnitTag("mysql/0")
 	s.authorizer = &apiservertesting.FakeAuthorizer{Tag: tag}
@@ -118,12 +131,149 @@ func (s *MachineManagerSuite) TestAddMachinesStateError(c *gc.C) {
 	c.Assert(s.st.calls, gc.Equals, 1)
 }
 
+func (s *MachineManagerSuite) setupUpgradeSeries(c *gc.C) *mockMachine {
+	m := &mockMachine{
+		id:     "0",
+		series: "trusty",
+		units: []machinemanager.Unit{
+			&mockUnit{name: "foo/0", series: []string{"trusty", "xenial"}},
+			&mockUnit{name: "bar/0"},
+		},
+	}
+	s.st.machine = m
+	return m
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesPrepare(c *gc.C) {
+	m := s.setupUpgradeSeries(c)
+	result, err := s.api.UpgradeSeriesPrepare(params.UpgradeSeriesPrepareArg{
+		Entity: params.Entity{Tag: "machine-0"},
+		Series: "xenial",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(m.lockedUnits, jc.DeepEquals, []string{"foo/0", "bar/0"})
+	c.Assert(m.lockedSeries, gc.Equals, "xenial")
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesPrepareUnsupportedSeries(c *gc.C) {
+	m := s.setupUpgradeSeries(c)
+	result, err := s.api.UpgradeSeriesPrepare(params.UpgradeSeriesPrepareArg{
+		Entity: params.Entity{Tag: "machine-0"},
+		Series: "yakkety",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `series "yakkety" is not supported by the charm of unit foo/0; use --force to upgrade anyway`)
+	c.Assert(m.lockedUnits, gc.IsNil)
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesPrepareForce(c *gc.C) {
+	m := s.setupUpgradeSeries(c)
+	result, err := s.api.UpgradeSeriesPrepare(params.UpgradeSeriesPrepareArg{
+		Entity: params.Entity{Tag: "machine-0"},
+		Series: "yakkety",
+		Force:  true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(m.lockedSeries, gc.Equals, "yakkety")
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesPrepareController(c *gc.C) {
+	m := s.setupUpgradeSeries(c)
+	m.manager = true
+	result, err := s.api.UpgradeSeriesPrepare(params.UpgradeSeriesPrepareArg{
+		Entity: params.Entity{Tag: "machine-0"},
+		Series: "xenial",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "upgrading the series of controller machine 0 not supported")
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesPrepareBadTag(c *gc.C) {
+	s.setupUpgradeSeries(c)
+	result, err := s.api.UpgradeSeriesPrepare(params.UpgradeSeriesPrepareArg{
+		Entity: params.Entity{Tag: "unit-foo-0"},
+		Series: "xenial",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `"unit-foo-0" is not a valid machine tag`)
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesComplete(c *gc.C) {
+	m := s.setupUpgradeSeries(c)
+	m.status = state.UpgradeSeriesPrepareCompleted
+	m.unitStatuses = map[string]state.UpgradeSeriesStatus{
+		"foo/0": state.UpgradeSeriesPrepareCompleted,
+		"bar/0": state.UpgradeSeriesPrepareCompleted,
+	}
+	result, err := s.api.UpgradeSeriesComplete(params.Entity{Tag: "machine-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(m.status, gc.Equals, state.UpgradeSeriesCompleteStarted)
+	c.Assert(m.unitStatuses, jc.DeepEquals, map[string]state.UpgradeSeriesStatus{
+		"foo/0": state.UpgradeSeriesCompleteStarted,
+		"bar/0": state.UpgradeSeriesCompleteStarted,
+	})
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesCompleteNotPrepared(c *gc.C) {
+	m := s.setupUpgradeSeries(c)
+	m.status = state.UpgradeSeriesPrepareStarted
+	result, err := s.api.UpgradeSeriesComplete(params.Entity{Tag: "machine-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `machine 0 is not ready to complete a series upgrade \(status "prepare started"\)`)
+	c.Assert(m.status, gc.Equals, state.UpgradeSeriesPrepareStarted)
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesAbort(c *gc.C) {
+	m := s.setupUpgradeSeries(c)
+	m.status = state.UpgradeSeriesError
+	m.unitStatuses = map[string]state.UpgradeSeriesStatus{
+		"foo/0": state.UpgradeSeriesPrepareCompleted,
+		"bar/0": state.UpgradeSeriesPrepareCompleted,
+	}
+	result, err := s.api.UpgradeSeriesAbort(params.Entity{Tag: "machine-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(m.status, gc.Equals, state.UpgradeSeriesCompleteStarted)
+	c.Assert(m.unitStatuses, jc.DeepEquals, map[string]state.UpgradeSeriesStatus{
+		"foo/0": state.UpgradeSeriesCompleteStarted,
+		"bar/0": state.UpgradeSeriesCompleteStarted,
+	})
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesAbortNotStarted(c *gc.C) {
+	m := s.setupUpgradeSeries(c)
+	m.status = state.UpgradeSeriesNotStarted
+	result, err := s.api.UpgradeSeriesAbort(params.Entity{Tag: "machine-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `machine 0 is not upgrading its series`)
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesAbortCompleting(c *gc.C) {
+	m := s.setupUpgradeSeries(c)
+	m.status = state.UpgradeSeriesCompleteStarted
+	result, err := s.api.UpgradeSeriesAbort(params.Entity{Tag: "machine-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `machine 0 is already completing its series upgrade \(status "complete started"\)`)
+	c.Assert(m.status, gc.Equals, state.UpgradeSeriesCompleteStarted)
+}
+
 type mockState struct {
 	calls    int
 	machines []state.MachineTemplate
+	machine  *mockMachine
 	err      error
 }
 
+func (st *mockState) Machine(id string) (machinemanager.Machine, error) {
+	if st.machine == nil || st.machine.id != id {
+		return nil, errors.New("machine " + id + " not found")
+	}
+	return st.machine, nil
+}
+
 func (st *mockState) AddOneMachine(template state.MachineTemplate) (*state.Machine, error) {
 	st.calls++
 	st.machines = append(st.machines, template)
@@ -151,6 +301,70 @@ func (st *mockState) AddMachineInsideMachine(template state.MachineTemplate, par
 	panic("not implemented")
 }
 
+type mockMachine struct {
+	id           string
+	series       string
+	manager      bool
+	units        []machinemanager.Unit
+	lockedUnits  []string
+	lockedSeries string
+	status       state.UpgradeSeriesStatus
+	unitStatuses map[string]state.UpgradeSeriesStatus
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) Series() string {
+	return m.series
+}
+
+func (m *mockMachine) IsManager() bool {
+	return m.manager
+}
+
+func (m *mockMachine) Units() ([]machinemanager.Unit, error) {
+	return m.units, nil
+}
+
+func (m *mockMachine) CreateUpgradeSeriesLock(unitNames []string, toSeries string) error {
+	m.lockedUnits = unitNames
+	m.lockedSeries = toSeries
+	return nil
+}
+
+func (m *mockMachine) UpgradeSeriesStatus() (state.UpgradeSeriesStatus, error) {
+	return m.status, nil
+}
+
+func (m *mockMachine) SetUpgradeSeriesStatus(status state.UpgradeSeriesStatus) error {
+	m.status = status
+	return nil
+}
+
+func (m *mockMachine) UpgradeSeriesUnitStatuses() (map[string]state.UpgradeSeriesStatus, error) {
+	return m.unitStatuses, nil
+}
+
+func (m *mockMachine) SetUpgradeSeriesUnitStatus(unitName string, status state.UpgradeSeriesStatus) error {
+	m.unitStatuses[unitName] = status
+	return nil
+}
+
+type mockUnit struct {
+	name   string
+	series []string
+}
+
+func (u *mockUnit) Name() string {
+	return u.name
+}
+
+func (u *mockUnit) CharmSeries() ([]string, error) {
+	return u.series, nil
+}
+
 type mockBlock struct {
 	state.Block
 }
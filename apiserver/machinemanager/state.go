@@ -16,6 +16,29 @@ type stateInterface interface {
 	AddOneMachine(template state.MachineTemplate) (*state.Machine, error)
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
 	AddMachineInsideMachine(template state.MachineTemplate, parentId string, containerType instance.ContainerType) (*state.Machine, error)
+	Machine(id string) (Machine, error)
+}
+
+// Machine describes the methods of a state machine needed to upgrade
+// its series in place.
+type Machine interface {
+	Id() string
+	Series() string
+	IsManager() bool
+	Units() ([]Unit, error)
+	CreateUpgradeSeriesLock(unitNames []string, toSeries string) error
+	UpgradeSeriesStatus() (state.UpgradeSeriesStatus, error)
+	SetUpgradeSeriesStatus(status state.UpgradeSeriesStatus) error
+	UpgradeSeriesUnitStatuses() (map[string]state.UpgradeSeriesStatus, error)
+	SetUpgradeSeriesUnitStatus(unitName string, status state.UpgradeSeriesStatus) error
+}
+
+// Unit describes a unit deployed to a machine being upgraded.
+type Unit interface {
+	Name() string
+	// CharmSeries returns the series supported by the unit's charm.
+	// It returns no series if the charm does not declare any.
+	CharmSeries() ([]string, error)
 }
 
 type stateShim struct {
@@ -45,3 +68,43 @@ func (s stateShim) AddMachineInsideNewMachine(template, parentTemplate state.Mac
 func (s stateShim) AddMachineInsideMachine(template state.MachineTemplate, parentId string, containerType instance.ContainerType) (*state.Machine, error) {
 	return s.State.AddMachineInsideMachine(template, parentId, containerType)
 }
+
+func (s stateShim) Machine(id string) (Machine, error) {
+	m, err := s.State.Machine(id)
+	if err != nil {
+		return nil, err
+	}
+	return machineShim{m}, nil
+}
+
+type machineShim struct {
+	*state.Machine
+}
+
+func (m machineShim) Units() ([]Unit, error) {
+	units, err := m.Machine.Units()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Unit, len(units))
+	for i, u := range units {
+		result[i] = unitShim{u}
+	}
+	return result, nil
+}
+
+type unitShim struct {
+	*state.Unit
+}
+
+func (u unitShim) CharmSeries() ([]string, error) {
+	app, err := u.Unit.Application()
+	if err != nil {
+		return nil, err
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return nil, err
+	}
+	return ch.Meta().Series, nil
+}
@@ -15,7 +15,8 @@ import (
 )
 
 func init() {
-	common.RegisterStandardFacade("MetricsDebug", 2, NewMetricsDebugAPI)
+	common.RegisterStandardFacade("MetricsDebug", 2, NewMetricsDebugAPIV2)
+	common.RegisterStandardFacade("MetricsDebug", 3, NewMetricsDebugAPI)
 }
 
 type metricsDebug interface {
@@ -30,6 +31,10 @@ type metricsDebug interface {
 
 	// Application returns the application based on its name.
 	Application(string) (*state.Application, error)
+
+	// AggregateMetrics returns the aggregated values of the metrics
+	// selected by the query.
+	AggregateMetrics(query state.MetricsQuery) ([]state.AggregatedMetric, error)
 }
 
 // MetricsDebug defines the methods on the metricsdebug API end point.
@@ -39,6 +44,10 @@ type MetricsDebug interface {
 
 	// SetMeterStatus will set the meter status on the given entity tag.
 	SetMeterStatus(params.MeterStatusParams) (params.ErrorResults, error)
+
+	// AggregateMetrics returns the aggregated values of the metrics
+	// stored by the state server.
+	AggregateMetrics(params.MetricsQuery) (params.AggregatedMetricsResult, error)
 }
 
 // MetricsDebugAPI implements the metricsdebug interface and is the concrete
@@ -64,6 +73,30 @@ func NewMetricsDebugAPI(
 	}, nil
 }
 
+// MetricsDebugAPIV2 implements version 2 of the metricsdebug API end
+// point. It differs from version 3 in not aggregating metrics.
+type MetricsDebugAPIV2 struct {
+	*MetricsDebugAPI
+}
+
+// NewMetricsDebugAPIV2 creates a new version 2 API endpoint for calling
+// metrics debug functions.
+func NewMetricsDebugAPIV2(
+	st *state.State,
+	resources *common.Resources,
+	authorizer common.Authorizer,
+) (*MetricsDebugAPIV2, error) {
+	api, err := NewMetricsDebugAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricsDebugAPIV2{api}, nil
+}
+
+// AggregateMetrics is not available in version 2 of the facade; the
+// method signature hides it from the RPC layer.
+func (*MetricsDebugAPIV2) AggregateMetrics(_, _ struct{}) {}
+
 // GetMetrics returns all metrics stored by the state server.
 func (api *MetricsDebugAPI) GetMetrics(args params.Entities) (params.MetricResults, error) {
 	results := params.MetricResults{
@@ -119,6 +152,75 @@ func (api *MetricsDebugAPI) GetMetrics(args params.Entities) (params.MetricResul
 	return results, nil
 }
 
+// AggregateMetrics returns the aggregated values of the metrics
+// recorded by the given units and applications, or by all units in
+// the model if none are given.
+func (api *MetricsDebugAPI) AggregateMetrics(args params.MetricsQuery) (params.AggregatedMetricsResult, error) {
+	var result params.AggregatedMetricsResult
+	units, err := api.unitNames(args.Entities)
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result, nil
+	}
+	if len(args.Entities) > 0 && len(units) == 0 {
+		return result, nil
+	}
+	aggregated, err := api.state.AggregateMetrics(state.MetricsQuery{
+		Units:       units,
+		Keys:        args.Keys,
+		Start:       args.Start,
+		End:         args.End,
+		Interval:    args.Interval,
+		Aggregation: state.MetricAggregation(args.Aggregation),
+	})
+	if err != nil {
+		result.Error = common.ServerError(errors.Annotate(err, "failed to aggregate metrics"))
+		return result, nil
+	}
+	result.Metrics = make([]params.AggregatedMetric, len(aggregated))
+	for i, m := range aggregated {
+		result.Metrics[i] = params.AggregatedMetric{
+			Unit:  m.Unit,
+			Key:   m.Key,
+			Time:  m.Time,
+			Value: m.Value,
+			Count: m.Count,
+		}
+	}
+	return result, nil
+}
+
+// unitNames returns the names of the units identified by the given
+// unit and application tags.
+func (api *MetricsDebugAPI) unitNames(entities []params.Entity) ([]string, error) {
+	var units []string
+	for _, entity := range entities {
+		tag, err := names.ParseTag(entity.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch tag := tag.(type) {
+		case names.UnitTag:
+			units = append(units, tag.Id())
+		case names.ApplicationTag:
+			application, err := api.state.Application(tag.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			applicationUnits, err := application.AllUnits()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, unit := range applicationUnits {
+				units = append(units, unit.Name())
+			}
+		default:
+			return nil, errors.Errorf("expected application or unit tag, got %T", tag)
+		}
+	}
+	return units, nil
+}
+
 // SetMeterStatus sets meter statuses for entities.
 func (api *MetricsDebugAPI) SetMeterStatus(args params.MeterStatusParams) (params.ErrorResults, error) {
 	results := params.ErrorResults{
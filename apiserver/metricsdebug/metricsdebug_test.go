@@ -4,6 +4,7 @@
 package metricsdebug_test
 
 import (
+	"reflect"
 	"time"
 
 	jc "github.com/juju/testing/checkers"
@@ -13,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/rpc/rpcreflect"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing/factory"
 )
@@ -275,3 +277,65 @@ func (s *metricsDebugSuite) TestGetMultipleMetricsNoMocksWithService(c *gc.C) {
 	c.Assert(metrics.Results[0].Metrics[1].Value, gc.Equals, metricUnit1.Metrics()[0].Value)
 	c.Assert(metrics.Results[0].Metrics[1].Time, jc.TimeBetween(metricUnit1.Metrics()[0].Time, metricUnit1.Metrics()[0].Time))
 }
+
+func (s *metricsDebugSuite) TestAggregateMetricsOnlyInV3(c *gc.C) {
+	objType := rpcreflect.ObjTypeOf(reflect.TypeOf(&metricsdebug.MetricsDebugAPIV2{}))
+	_, err := objType.Method("AggregateMetrics")
+	c.Assert(err, gc.Equals, rpcreflect.ErrMethodNotFound)
+	objType = rpcreflect.ObjTypeOf(reflect.TypeOf(&metricsdebug.MetricsDebugAPI{}))
+	_, err = objType.Method("AggregateMetrics")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *metricsDebugSuite) TestAggregateMetrics(c *gc.C) {
+	meteredCharm := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "metered", URL: "cs:quantal/metered"})
+	meteredService := s.Factory.MakeApplication(c, &factory.ApplicationParams{Charm: meteredCharm})
+	unit0 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: meteredService, SetCharmURL: true})
+	unit1 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: meteredService, SetCharmURL: true})
+	now := time.Now().UTC().Round(time.Second)
+	s.Factory.MakeMetric(c, &factory.MetricParams{Unit: unit0, Metrics: []state.Metric{
+		{"pings", "5", now},
+		{"pings", "10.5", now},
+	}})
+	s.Factory.MakeMetric(c, &factory.MetricParams{Unit: unit1, Metrics: []state.Metric{
+		{"pings", "3", now},
+	}})
+
+	result, err := s.metricsdebug.AggregateMetrics(params.MetricsQuery{
+		Entities:    []params.Entity{{"application-metered"}},
+		Aggregation: "sum",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AggregatedMetricsResult{
+		Metrics: []params.AggregatedMetric{
+			{Unit: "metered/0", Key: "pings", Value: 15.5, Count: 2},
+			{Unit: "metered/1", Key: "pings", Value: 3, Count: 1},
+		},
+	})
+
+	result, err = s.metricsdebug.AggregateMetrics(params.MetricsQuery{
+		Entities:    []params.Entity{{"unit-metered-1"}},
+		Aggregation: "max",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AggregatedMetricsResult{
+		Metrics: []params.AggregatedMetric{
+			{Unit: "metered/1", Key: "pings", Value: 3, Count: 1},
+		},
+	})
+}
+
+func (s *metricsDebugSuite) TestAggregateMetricsErrors(c *gc.C) {
+	result, err := s.metricsdebug.AggregateMetrics(params.MetricsQuery{
+		Entities:    []params.Entity{{"machine-0"}},
+		Aggregation: "sum",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "expected application or unit tag, got names.MachineTag")
+
+	result, err = s.metricsdebug.AggregateMetrics(params.MetricsQuery{
+		Aggregation: "median",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `failed to aggregate metrics: metric aggregation "median" not valid`)
+}
@@ -10,3 +10,7 @@ func PatchHost(host string) func() {
 		restoreHost()
 	}
 }
+
+func PatchMetricsDir(dir string) func() {
+	return testing.PatchValue(&metricsDir, dir)
+}
@@ -0,0 +1,56 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsender
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	wireformat "github.com/juju/romulus/wireformat/metrics"
+	"github.com/juju/utils/series"
+
+	"github.com/juju/juju/juju/paths"
+)
+
+// metricsDir is the directory on the controller that the file metric
+// sender writes to. Model config cannot choose the file's location, so
+// that model admins cannot have the controller write to arbitrary paths.
+var metricsDir = filepath.Join(paths.MustSucceed(paths.DataDir(series.HostSeries())), "metrics")
+
+// metricsFilePath returns the path of the file that the metrics of the
+// given model are appended to.
+func metricsFilePath(modelUUID string) string {
+	return filepath.Join(metricsDir, modelUUID+".log")
+}
+
+// FileSender appends metrics to a file on the controller, one JSON
+// encoded batch per line.
+type FileSender struct {
+	// Path is the path of the file the metrics are appended to.
+	Path string
+}
+
+// Send implements MetricSender.
+func (s *FileSender) Send(batches []*wireformat.MetricBatch) (*wireformat.Response, error) {
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+		return nil, errors.Trace(err)
+	}
+	f, err := os.OpenFile(s.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+	encoder := json.NewEncoder(f)
+	for _, batch := range batches {
+		if err := encoder.Encode(batch); err != nil {
+			return nil, errors.Annotate(err, "cannot write metrics")
+		}
+	}
+	if err := f.Close(); err != nil {
+		return nil, errors.Annotate(err, "cannot write metrics")
+	}
+	return acknowledge(batches)
+}
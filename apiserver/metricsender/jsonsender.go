@@ -0,0 +1,44 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsender
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	wireformat "github.com/juju/romulus/wireformat/metrics"
+)
+
+// senderHTTPClient is used by the senders that post metrics to an
+// endpoint of the operator's choosing, so that an unreachable endpoint
+// cannot block metric sending indefinitely.
+var senderHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// JSONSender posts metrics as JSON to an HTTP endpoint of the
+// operator's choosing. Batches are considered sent once the endpoint
+// accepts them.
+type JSONSender struct {
+	// URL is the endpoint the metrics are posted to.
+	URL string
+}
+
+// Send implements MetricSender.
+func (s *JSONSender) Send(batches []*wireformat.MetricBatch) (*wireformat.Response, error) {
+	b, err := json.Marshal(batches)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resp, err := senderHTTPClient.Post(s.URL, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, errors.Errorf("failed to send metrics http %v", resp.StatusCode)
+	}
+	return acknowledge(batches)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/loggo"
 	wireformat "github.com/juju/romulus/wireformat/metrics"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
 
@@ -90,6 +91,7 @@ func SendMetrics(st *state.State, sender MetricSender, batchSize int) error {
 			}
 			return errors.Trace(err)
 		}
+		acknowledged := 0
 		if response != nil {
 			// TODO (mattyw) We are currently ignoring errors during response handling.
 			handleResponse(metricsManager, st, *response)
@@ -99,6 +101,16 @@ func SendMetrics(st *state.State, sender MetricSender, batchSize int) error {
 				logger.Warningf("%v", err)
 				return errors.Trace(err)
 			}
+			for _, envResp := range response.EnvResponses {
+				acknowledged += len(envResp.AcknowledgedBatches)
+			}
+		}
+		if acknowledged == 0 {
+			// Unacknowledged batches stay unsent, so they would be
+			// selected again on the next round; stop rather than
+			// resend them until the next send.
+			logger.Warningf("none of %d metric batches were acknowledged", lenM)
+			break
 		}
 		sent += lenM
 	}
@@ -126,6 +138,22 @@ func DefaultMetricSender() MetricSender {
 	return defaultSender
 }
 
+// NewSender returns the metric sender selected by the given model
+// config.
+func NewSender(cfg *config.Config) (MetricSender, error) {
+	switch cfg.MetricsSender() {
+	case config.MetricsSenderCollector:
+		return defaultSender, nil
+	case config.MetricsSenderHTTP:
+		return &JSONSender{URL: cfg.MetricsSenderURL()}, nil
+	case config.MetricsSenderPushgateway:
+		return &PushgatewaySender{URL: cfg.MetricsSenderURL()}, nil
+	case config.MetricsSenderFile:
+		return &FileSender{Path: metricsFilePath(cfg.UUID())}, nil
+	}
+	return nil, errors.NotValidf("metrics sender %q", cfg.MetricsSender())
+}
+
 // ToWire converts the state.MetricBatch into a type
 // that can be sent over the wire to the collector.
 func ToWire(mb *state.MetricBatch) *wireformat.MetricBatch {
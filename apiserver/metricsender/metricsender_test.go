@@ -116,6 +116,31 @@ func (s *MetricSenderSuite) TestDontSendWithNopSender(c *gc.C) {
 	c.Assert(sent, gc.Equals, 3)
 }
 
+type unacknowledgingSender struct {
+	sends int
+}
+
+func (s *unacknowledgingSender) Send([]*wireformat.MetricBatch) (*wireformat.Response, error) {
+	s.sends++
+	return &wireformat.Response{}, nil
+}
+
+// TestStopsWhenNothingAcknowledged checks that SendMetrics does not
+// keep resending batches that the sender never acknowledges.
+func (s *MetricSenderSuite) TestStopsWhenNothingAcknowledged(c *gc.C) {
+	var sender unacknowledgingSender
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		s.Factory.MakeMetric(c, &factory.MetricParams{Unit: s.unit, Sent: false, Time: &now})
+	}
+	err := metricsender.SendMetrics(s.State, &sender, 10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sender.sends, gc.Equals, 1)
+	unsent, err := s.State.CountOfUnsentMetrics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsent, gc.Equals, 3)
+}
+
 func (s *MetricSenderSuite) TestFailureIncrementsConsecutiveFailures(c *gc.C) {
 	sender := &testing.ErrorSender{Err: errors.New("something went wrong")}
 	now := time.Now()
@@ -15,6 +15,12 @@ type NopSender struct {
 
 // Implement the send interface, act like everything is fine.
 func (n NopSender) Send(batches []*wireformat.MetricBatch) (*wireformat.Response, error) {
+	return acknowledge(batches)
+}
+
+// acknowledge returns a response acknowledging all the given batches,
+// for senders whose sinks do not respond as the collector does.
+func acknowledge(batches []*wireformat.MetricBatch) (*wireformat.Response, error) {
 	var resp = make(wireformat.EnvironmentResponses)
 	for _, batch := range batches {
 		resp.Ack(batch.ModelUUID, batch.UUID)
@@ -0,0 +1,109 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsender
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	wireformat "github.com/juju/romulus/wireformat/metrics"
+)
+
+// PushgatewaySender pushes metrics to a Prometheus pushgateway, in
+// the Prometheus text exposition format. The metrics of each unit are
+// pushed to their own group, labelled with the model UUID and the
+// unit name, and each charm metric key becomes a metric named after
+// it with a "juju_" prefix. Only the most recent value of each metric
+// is pushed. Batches holding metrics that the pushgateway cannot
+// represent, because their values are not numeric, are logged and
+// dropped: they are acknowledged without being pushed, as no later
+// attempt could push them either.
+type PushgatewaySender struct {
+	// URL is the base URL of the pushgateway.
+	URL string
+}
+
+// invalidMetricNameChars matches the characters that may not appear
+// in Prometheus metric names.
+var invalidMetricNameChars = regexp.MustCompile("[^a-zA-Z0-9_:]")
+
+type pushgatewayGroup struct {
+	modelUUID string
+	unitName  string
+}
+
+// Send implements MetricSender.
+func (s *PushgatewaySender) Send(batches []*wireformat.MetricBatch) (*wireformat.Response, error) {
+	groups := make(map[pushgatewayGroup]map[string]wireformat.Metric)
+	for _, batch := range batches {
+		var invalid []string
+		for _, m := range batch.Metrics {
+			if _, err := strconv.ParseFloat(m.Value, 64); err != nil {
+				invalid = append(invalid, fmt.Sprintf("%s=%q", m.Key, m.Value))
+			}
+		}
+		if len(invalid) > 0 {
+			logger.Errorf(
+				"dropping metric batch %q of unit %q: non-numeric metric values %s",
+				batch.UUID, batch.UnitName, strings.Join(invalid, ", "),
+			)
+			continue
+		}
+
+		group := pushgatewayGroup{batch.ModelUUID, batch.UnitName}
+		metrics, ok := groups[group]
+		if !ok {
+			metrics = make(map[string]wireformat.Metric)
+			groups[group] = metrics
+		}
+		for _, m := range batch.Metrics {
+			name := "juju_" + invalidMetricNameChars.ReplaceAllString(m.Key, "_")
+			if existing, ok := metrics[name]; !ok || m.Time.After(existing.Time) {
+				metrics[name] = m
+			}
+		}
+	}
+	for group, metrics := range groups {
+		if err := s.push(group, metrics); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return acknowledge(batches)
+}
+
+// push pushes the given metrics, keyed by metric name, to the
+// pushgateway group for a unit.
+func (s *PushgatewaySender) push(group pushgatewayGroup, metrics map[string]wireformat.Metric) error {
+	names := make([]string, 0, len(metrics))
+	for name := range metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var body bytes.Buffer
+	for _, name := range names {
+		fmt.Fprintf(&body, "%s %s\n", name, metrics[name].Value)
+	}
+
+	// Grouping label values may not contain slashes, so the unit
+	// name's slash is replaced with a dash.
+	pushURL := fmt.Sprintf("%s/metrics/job/juju/model/%s/unit/%s",
+		strings.TrimSuffix(s.URL, "/"),
+		group.modelUUID,
+		strings.Replace(group.unitName, "/", "-", -1),
+	)
+	resp, err := senderHTTPClient.Post(pushURL, "text/plain; version=0.0.4", &body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("failed to push metrics of unit %q http %v", group.unitName, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsender_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	wireformat "github.com/juju/romulus/wireformat/metrics"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/metricsender"
+	"github.com/juju/juju/testing"
+)
+
+type SendersSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&SendersSuite{})
+
+var _ metricsender.MetricSender = (*metricsender.JSONSender)(nil)
+var _ metricsender.MetricSender = (*metricsender.PushgatewaySender)(nil)
+var _ metricsender.MetricSender = (*metricsender.FileSender)(nil)
+
+func testBatches() []*wireformat.MetricBatch {
+	now := time.Now().UTC().Round(time.Second)
+	return []*wireformat.MetricBatch{{
+		UUID:      "batch-1",
+		ModelUUID: "model-uuid",
+		UnitName:  "metered/0",
+		CharmUrl:  "cs:quantal/metered",
+		Created:   now,
+		Metrics: []wireformat.Metric{
+			{Key: "pings", Value: "5", Time: now.Add(-time.Minute)},
+			{Key: "juju-units", Value: "1", Time: now},
+		},
+	}, {
+		UUID:      "batch-2",
+		ModelUUID: "model-uuid",
+		UnitName:  "metered/0",
+		CharmUrl:  "cs:quantal/metered",
+		Created:   now,
+		Metrics: []wireformat.Metric{
+			{Key: "pings", Value: "7", Time: now},
+			{Key: "state", Value: "busy", Time: now},
+		},
+	}}
+}
+
+func assertAcknowledged(c *gc.C, resp *wireformat.Response, batches []*wireformat.MetricBatch) {
+	var acked []string
+	for _, envResp := range resp.EnvResponses {
+		acked = append(acked, envResp.AcknowledgedBatches...)
+	}
+	var expected []string
+	for _, batch := range batches {
+		expected = append(expected, batch.UUID)
+	}
+	c.Assert(acked, jc.SameContents, expected)
+}
+
+func (s *SendersSuite) TestJSONSender(c *gc.C) {
+	var received []*wireformat.MetricBatch
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, gc.Equals, "POST")
+		c.Check(r.Header.Get("Content-Type"), gc.Equals, "application/json")
+		err := json.NewDecoder(r.Body).Decode(&received)
+		c.Check(err, jc.ErrorIsNil)
+	}))
+	defer server.Close()
+
+	batches := testBatches()
+	sender := &metricsender.JSONSender{URL: server.URL}
+	resp, err := sender.Send(batches)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(received, gc.HasLen, 2)
+	c.Assert(received[0].UUID, gc.Equals, "batch-1")
+	c.Assert(received[1].UUID, gc.Equals, "batch-2")
+	assertAcknowledged(c, resp, batches)
+}
+
+func (s *SendersSuite) TestJSONSenderError(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sender := &metricsender.JSONSender{URL: server.URL}
+	_, err := sender.Send(testBatches())
+	c.Assert(err, gc.ErrorMatches, "failed to send metrics http 503")
+}
+
+func (s *SendersSuite) TestPushgatewaySender(c *gc.C) {
+	var path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		data, err := ioutil.ReadAll(r.Body)
+		c.Check(err, jc.ErrorIsNil)
+		body = string(data)
+	}))
+	defer server.Close()
+
+	batches := testBatches()
+	sender := &metricsender.PushgatewaySender{URL: server.URL + "/"}
+	resp, err := sender.Send(batches)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(path, gc.Equals, "/metrics/job/juju/model/model-uuid/unit/metered-0")
+	// The second batch holds a non-numeric metric, so it is
+	// dropped: acknowledged but not pushed.
+	c.Assert(body, gc.Equals, "juju_juju_units 1\njuju_pings 5\n")
+	assertAcknowledged(c, resp, batches)
+}
+
+func (s *SendersSuite) TestPushgatewaySenderError(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sender := &metricsender.PushgatewaySender{URL: server.URL}
+	_, err := sender.Send(testBatches())
+	c.Assert(err, gc.ErrorMatches, `failed to push metrics of unit "metered/0" http 500`)
+}
+
+func (s *SendersSuite) TestFileSender(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "metrics.log")
+	batches := testBatches()
+	sender := &metricsender.FileSender{Path: path}
+	resp, err := sender.Send(batches[:1])
+	c.Assert(err, jc.ErrorIsNil)
+	assertAcknowledged(c, resp, batches[:1])
+	resp, err = sender.Send(batches[1:])
+	c.Assert(err, jc.ErrorIsNil)
+	assertAcknowledged(c, resp, batches[1:])
+
+	f, err := os.Open(path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	var uuids []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var batch wireformat.MetricBatch
+		err := json.Unmarshal(scanner.Bytes(), &batch)
+		c.Assert(err, jc.ErrorIsNil)
+		uuids = append(uuids, batch.UUID)
+	}
+	c.Assert(scanner.Err(), jc.ErrorIsNil)
+	c.Assert(uuids, jc.DeepEquals, []string{"batch-1", "batch-2"})
+}
+
+func (s *SendersSuite) TestFileSenderCreatesMetricsDir(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "metrics", "model-uuid.log")
+	sender := &metricsender.FileSender{Path: path}
+	_, err := sender.Send(testBatches())
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := os.Stat(filepath.Dir(path))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Mode().Perm(), gc.Equals, os.FileMode(0700))
+}
+
+func (s *SendersSuite) TestNewSender(c *gc.C) {
+	for i, test := range []struct {
+		attrs    testing.Attrs
+		expected metricsender.MetricSender
+	}{{
+		attrs:    testing.Attrs{},
+		expected: metricsender.DefaultMetricSender(),
+	}, {
+		attrs: testing.Attrs{
+			"metrics-sender":     "http",
+			"metrics-sender-url": "https://metrics.example.com/juju",
+		},
+		expected: &metricsender.JSONSender{URL: "https://metrics.example.com/juju"},
+	}, {
+		attrs: testing.Attrs{
+			"metrics-sender":     "pushgateway",
+			"metrics-sender-url": "http://pushgateway.example.com:9091",
+		},
+		expected: &metricsender.PushgatewaySender{URL: "http://pushgateway.example.com:9091"},
+	}} {
+		c.Logf("test %d: %v", i, test.attrs)
+		cfg := testing.CustomModelConfig(c, test.attrs)
+		sender, err := metricsender.NewSender(cfg)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(sender, jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *SendersSuite) TestNewFileSender(c *gc.C) {
+	metricsDir := c.MkDir()
+	restore := metricsender.PatchMetricsDir(metricsDir)
+	defer restore()
+	cfg := testing.CustomModelConfig(c, testing.Attrs{"metrics-sender": "file"})
+	sender, err := metricsender.NewSender(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sender, jc.DeepEquals, &metricsender.FileSender{
+		Path: filepath.Join(metricsDir, cfg.UUID()+".log"),
+	})
+}
@@ -20,7 +20,7 @@ var (
 	logger            = loggo.GetLogger("juju.apiserver.metricsmanager")
 	maxBatchesPerSend = metricsender.DefaultMaxBatchesPerSend()
 
-	sender = metricsender.DefaultMetricSender()
+	newSender = metricsender.NewSender
 )
 
 func init() {
@@ -103,6 +103,20 @@ func (api *MetricsManagerAPI) CleanupOldMetrics(args params.Entities) (params.Er
 	return result, nil
 }
 
+// sendMetrics sends any unsent metrics to the metric sender selected
+// by the model config.
+func (api *MetricsManagerAPI) sendMetrics() error {
+	cfg, err := api.state.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sender, err := newSender(cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return metricsender.SendMetrics(api.state, sender, maxBatchesPerSend)
+}
+
 // SendMetrics will send any unsent metrics onto the metric collection service.
 func (api *MetricsManagerAPI) SendMetrics(args params.Entities) (params.ErrorResults, error) {
 	result := params.ErrorResults{
@@ -125,7 +139,7 @@ func (api *MetricsManagerAPI) SendMetrics(args params.Entities) (params.ErrorRes
 			result.Results[i].Error = common.ServerError(common.ErrPerm)
 			continue
 		}
-		err = metricsender.SendMetrics(api.state, sender, maxBatchesPerSend)
+		err = api.sendMetrics()
 		if err != nil {
 			err = errors.Annotate(err, "failed to send metrics")
 			logger.Warningf("%v", err)
@@ -14,8 +14,10 @@ func PatchState(p Patcher, st Backend) {
 	})
 }
 
-func PatchExportModel(p Patcher, f func(migration.StateExporter) ([]byte, error)) {
-	p.PatchValue(&exportModel, f)
+func PatchPrecheckBackend(p Patcher, backend migration.PrecheckBackend) {
+	p.PatchValue(&getPrecheckBackend, func(*state.State) migration.PrecheckBackend {
+		return backend
+	})
 }
 
 type Patcher interface {
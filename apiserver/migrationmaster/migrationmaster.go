@@ -4,11 +4,14 @@
 package migrationmaster
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
@@ -22,9 +25,10 @@ func init() {
 // API implements the API required for the model migration
 // master worker.
 type API struct {
-	backend    Backend
-	authorizer common.Authorizer
-	resources  *common.Resources
+	backend         Backend
+	precheckBackend migration.PrecheckBackend
+	authorizer      common.Authorizer
+	resources       *common.Resources
 }
 
 // NewAPI creates a new API server endpoint for the model migration
@@ -38,9 +42,10 @@ func NewAPI(
 		return nil, common.ErrPerm
 	}
 	return &API{
-		backend:    getBackend(st),
-		authorizer: authorizer,
-		resources:  resources,
+		backend:         getBackend(st),
+		precheckBackend: getPrecheckBackend(st),
+		authorizer:      authorizer,
+		resources:       resources,
 	}, nil
 }
 
@@ -95,8 +100,9 @@ func (api *API) GetMigrationStatus() (params.FullMigrationStatus, error) {
 				Password:      target.Password,
 			},
 		},
-		Attempt: attempt,
-		Phase:   phase.String(),
+		MigrationId: mig.Id(),
+		Attempt:     attempt,
+		Phase:       phase.String(),
 	}, nil
 }
 
@@ -118,17 +124,175 @@ func (api *API) SetPhase(args params.SetMigrationPhaseArgs) error {
 	return errors.Annotate(err, "failed to set phase")
 }
 
-var exportModel = migration.ExportModel
+// WatchMinionReports sets up a watcher which reports when a report
+// for a migration minion has arrived.
+func (api *API) WatchMinionReports() params.NotifyWatchResult {
+	mig, err := api.backend.GetModelMigration()
+	if err != nil {
+		return params.NotifyWatchResult{Error: common.ServerError(err)}
+	}
+
+	watch, err := mig.WatchMinionReports()
+	if err != nil {
+		return params.NotifyWatchResult{Error: common.ServerError(err)}
+	}
+
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: api.resources.Register(watch),
+		}
+	}
+	return params.NotifyWatchResult{
+		Error: common.ServerError(watcher.EnsureErr(watch)),
+	}
+}
+
+// maxUnknownSample limits the number of unreported agents included
+// in MinionReports results.
+const maxUnknownSample = 10
 
-// Export serializes the model associated with the API connection.
+// MinionReports returns details of the reports made by migration
+// minions to the controller for the current migration phase.
+func (api *API) MinionReports() (params.MinionReports, error) {
+	var out params.MinionReports
+
+	mig, err := api.backend.GetModelMigration()
+	if err != nil {
+		return out, errors.Trace(err)
+	}
+
+	reports, err := mig.MinionReports()
+	if err != nil {
+		return out, errors.Trace(err)
+	}
+
+	out.MigrationId = mig.Id()
+	phase, err := mig.Phase()
+	if err != nil {
+		return out, errors.Trace(err)
+	}
+	out.Phase = phase.String()
+
+	out.SuccessCount = len(reports.Succeeded)
+
+	out.Failed = make([]string, len(reports.Failed))
+	for i, tag := range reports.Failed {
+		out.Failed[i] = tag.String()
+	}
+
+	out.UnknownCount = len(reports.Unknown)
+	unknown := reports.Unknown
+	if len(unknown) > maxUnknownSample {
+		unknown = unknown[:maxUnknownSample]
+	}
+	out.UnknownSample = make([]string, len(unknown))
+	for i, tag := range unknown {
+		out.UnknownSample[i] = tag.String()
+	}
+	return out, nil
+}
+
+// Export serializes the model associated with the API connection,
+// listing the charms and agent binaries it uses so they can be
+// transferred to the target controller.
 func (api *API) Export() (params.SerializedModel, error) {
 	var serialized params.SerializedModel
 
-	bytes, err := exportModel(api.backend)
+	model, err := api.backend.Export()
 	if err != nil {
-		return serialized, err
+		return serialized, errors.Trace(err)
+	}
+	bytes, err := description.Serialize(model)
+	if err != nil {
+		return serialized, errors.Trace(err)
 	}
-
 	serialized.Bytes = bytes
+	serialized.Charms = migration.ModelCharms(model)
+
+	tools := migration.ModelTools(model)
+	versions := make([]string, 0, len(tools))
+	hashes := make(map[string]string)
+	for v, hash := range tools {
+		versions = append(versions, v.String())
+		hashes[v.String()] = hash
+	}
+	sort.Strings(versions)
+	for _, v := range versions {
+		serialized.Tools = append(serialized.Tools, params.SerializedModelTools{
+			Version: v,
+			SHA256:  hashes[v],
+		})
+	}
 	return serialized, nil
 }
+
+// ModelLogs returns a page of the log history of the model associated
+// with the API connection so that it can be transferred to the target
+// controller.
+func (api *API) ModelLogs(args params.ModelLogsArgs) (params.ModelLogs, error) {
+	var out params.ModelLogs
+
+	records, err := api.backend.ModelLogs(args.After, args.Limit)
+	if err != nil {
+		return out, errors.Trace(err)
+	}
+	out.Records = make([]params.MigrationLogRecord, len(records))
+	for i, rec := range records {
+		out.Records[i] = params.MigrationLogRecord{
+			Time:     rec.Time,
+			Entity:   rec.Entity,
+			Module:   rec.Module,
+			Location: rec.Location,
+			Level:    rec.Level,
+			Message:  rec.Message,
+		}
+	}
+	return out, nil
+}
+
+// Reap removes all documents for the model associated with the API
+// connection once it has been successfully migrated, leaving a
+// redirect to the target controller in its place.
+func (api *API) Reap() error {
+	mig, err := api.backend.GetModelMigration()
+	if err != nil {
+		return errors.Annotate(err, "could not get migration")
+	}
+	phase, err := mig.Phase()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if phase != coremigration.REAP {
+		return errors.Errorf("migration is in %s phase, not REAP", phase)
+	}
+	err = api.backend.RemoveExportingModelDocs()
+	return errors.Annotate(err, "failed to remove model")
+}
+
+// Prechecks checks that the model associated with the API connection
+// is in a fit state to be migrated, returning every problem found.
+func (api *API) Prechecks() (params.MigrationPrecheckResult, error) {
+	problems, err := migration.SourcePrecheck(api.precheckBackend)
+	if err != nil {
+		return params.MigrationPrecheckResult{}, errors.Trace(err)
+	}
+	return params.MigrationPrecheckResult{Problems: problems}, nil
+}
+
+// ModelInfo returns the details of the model associated with the API
+// connection that the target controller needs to run its prechecks.
+func (api *API) ModelInfo() (params.MigrationModelInfo, error) {
+	info, err := api.precheckBackend.ModelInfo()
+	if err != nil {
+		return params.MigrationModelInfo{}, errors.Trace(err)
+	}
+	return params.MigrationModelInfo{
+		UUID:            info.UUID,
+		Name:            info.Name,
+		OwnerTag:        info.Owner.String(),
+		AgentVersion:    info.AgentVersion,
+		CloudType:       info.CloudType,
+		CloudRegion:     info.CloudRegion,
+		CloudCredential: info.CloudCredential,
+	}, nil
+}
@@ -4,11 +4,14 @@
 package migrationmaster_test
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
@@ -16,21 +19,20 @@ import (
 	"github.com/juju/juju/apiserver/migrationmaster"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/description"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 )
 
-// Ensure that Backend remains compatible with *state.State
-var _ migrationmaster.Backend = (*state.State)(nil)
-
 type Suite struct {
 	testing.BaseSuite
 
-	backend    *stubBackend
-	resources  *common.Resources
-	authorizer apiservertesting.FakeAuthorizer
+	backend         *stubBackend
+	precheckBackend *stubPrecheckBackend
+	resources       *common.Resources
+	authorizer      apiservertesting.FakeAuthorizer
 }
 
 var _ = gc.Suite(&Suite{})
@@ -39,9 +41,11 @@ func (s *Suite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 
 	s.backend = &stubBackend{
-		migration: new(stubMigration),
+		migration: &stubMigration{phase: coremigration.READONLY},
 	}
 	migrationmaster.PatchState(s, s.backend)
+	s.precheckBackend = &stubPrecheckBackend{life: state.Alive}
+	migrationmaster.PatchPrecheckBackend(s, s.precheckBackend)
 
 	s.resources = common.NewResources()
 	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
@@ -92,9 +96,67 @@ func (s *Suite) TestGetMigrationStatus(c *gc.C) {
 				Password:      "secret",
 			},
 		},
-		Attempt: 1,
-		Phase:   "READONLY",
+		MigrationId: "id",
+		Attempt:     1,
+		Phase:       "READONLY",
+	})
+}
+
+func (s *Suite) TestWatchMinionReports(c *gc.C) {
+	api := s.mustMakeAPI(c)
+
+	result := api.WatchMinionReports()
+	c.Assert(result.Error, gc.IsNil)
+
+	resource := s.resources.Get(result.NotifyWatcherId)
+	watcher, _ := resource.(state.NotifyWatcher)
+	c.Assert(watcher, gc.NotNil)
+
+	select {
+	case <-watcher.Changes():
+		c.Fatalf("initial event not consumed")
+	case <-time.After(testing.ShortWait):
+	}
+}
+
+func (s *Suite) TestMinionReports(c *gc.C) {
+	// Create 16 unknowns to ensure the sample is limited to 10.
+	var unknown []names.Tag
+	for i := 0; i < 16; i++ {
+		unknown = append(unknown, names.NewMachineTag(fmt.Sprint(i)))
+	}
+	s.backend.migration.minionReports = &state.MinionReports{
+		Succeeded: []names.Tag{
+			names.NewMachineTag("42"),
+			names.NewUnitTag("foo/2"),
+		},
+		Failed: []names.Tag{
+			names.NewUnitTag("foo/3"),
+		},
+		Unknown: unknown,
+	}
+
+	api := s.mustMakeAPI(c)
+	reports, err := api.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(reports.UnknownSample, gc.HasLen, 10)
+	c.Assert(reports, gc.DeepEquals, params.MinionReports{
+		MigrationId:   "id",
+		Phase:         "READONLY",
+		SuccessCount:  2,
+		UnknownCount:  16,
+		UnknownSample: reports.UnknownSample,
+		Failed:        []string{"unit-foo-3"},
 	})
+	c.Assert(reports.UnknownSample[0], gc.Equals, "machine-0")
+}
+
+func (s *Suite) TestMinionReportsError(c *gc.C) {
+	s.backend.migration.minionReportsErr = errors.New("boom")
+	api := s.mustMakeAPI(c)
+	_, err := api.MinionReports()
+	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
 func (s *Suite) TestSetPhase(c *gc.C) {
@@ -130,17 +192,139 @@ func (s *Suite) TestSetPhaseError(c *gc.C) {
 }
 
 func (s *Suite) TestExport(c *gc.C) {
-	exportModel := func(migration.StateExporter) ([]byte, error) {
-		return []byte("foo"), nil
-	}
-	migrationmaster.PatchExportModel(s, exportModel)
+	model := description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("admin"),
+	})
+	machine := model.AddMachine(description.MachineArgs{
+		Id: names.NewMachineTag("0"),
+	})
+	machine.SetTools(description.AgentToolsArgs{
+		Version: version.MustParseBinary("2.0.1-trusty-amd64"),
+		SHA256:  "machine-sha",
+	})
+	application := model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("foo"),
+		CharmURL: "cs:foo-0",
+	})
+	unit := application.AddUnit(description.UnitArgs{
+		Tag: names.NewUnitTag("foo/0"),
+	})
+	unit.SetTools(description.AgentToolsArgs{
+		Version: version.MustParseBinary("2.0.0-xenial-amd64"),
+		SHA256:  "unit-sha",
+	})
+	s.backend.model = model
 	api := s.mustMakeAPI(c)
 
 	serialized, err := api.Export()
+	c.Assert(err, jc.ErrorIsNil)
 
+	// We don't want to tie this test to the serialisation output
+	// (that's tested elsewhere). Just check that the model
+	// deserialises to what was exported.
+	out, err := description.Deserialize(serialized.Bytes)
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(serialized, gc.DeepEquals, params.SerializedModel{
-		Bytes: []byte("foo"),
+	c.Check(out.Applications(), gc.HasLen, 1)
+	c.Check(serialized.Charms, gc.DeepEquals, []string{"cs:foo-0"})
+	c.Check(serialized.Tools, gc.DeepEquals, []params.SerializedModelTools{
+		{"2.0.0-xenial-amd64", "unit-sha"},
+		{"2.0.1-trusty-amd64", "machine-sha"},
+	})
+}
+
+func (s *Suite) TestExportError(c *gc.C) {
+	s.backend.exportErr = errors.New("boom")
+	api := s.mustMakeAPI(c)
+
+	_, err := api.Export()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestModelLogs(c *gc.C) {
+	t0 := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	s.backend.logs = []*state.LogRecord{{
+		Time:      t0,
+		Entity:    "machine-0",
+		Module:    "juju.worker",
+		Location:  "worker.go:42",
+		Level:     loggo.INFO,
+		Message:   "hello",
+		ModelUUID: modelUUID,
+	}}
+	api := s.mustMakeAPI(c)
+
+	after := t0.Add(-time.Hour)
+	logs, err := api.ModelLogs(params.ModelLogsArgs{After: after, Limit: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.backend.logsArgs, jc.DeepEquals, []interface{}{after, 10})
+	c.Check(logs, jc.DeepEquals, params.ModelLogs{
+		Records: []params.MigrationLogRecord{{
+			Time:     t0,
+			Entity:   "machine-0",
+			Module:   "juju.worker",
+			Location: "worker.go:42",
+			Level:    loggo.INFO,
+			Message:  "hello",
+		}},
+	})
+}
+
+func (s *Suite) TestReap(c *gc.C) {
+	s.backend.migration.phase = coremigration.REAP
+	api := s.mustMakeAPI(c)
+
+	err := api.Reap()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.backend.reaped, jc.IsTrue)
+}
+
+func (s *Suite) TestReapWrongPhase(c *gc.C) {
+	api := s.mustMakeAPI(c)
+
+	err := api.Reap()
+	c.Assert(err, gc.ErrorMatches, "migration is in READONLY phase, not REAP")
+	c.Assert(s.backend.reaped, jc.IsFalse)
+}
+
+func (s *Suite) TestReapError(c *gc.C) {
+	s.backend.migration.phase = coremigration.REAP
+	s.backend.reapErr = errors.New("boom")
+	api := s.mustMakeAPI(c)
+
+	err := api.Reap()
+	c.Assert(err, gc.ErrorMatches, "failed to remove model: boom")
+}
+
+func (s *Suite) TestPrechecks(c *gc.C) {
+	api := s.mustMakeAPI(c)
+
+	result, err := api.Prechecks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Problems, gc.HasLen, 0)
+
+	s.precheckBackend.life = state.Dying
+	s.precheckBackend.upgrading = true
+	result, err = api.Prechecks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Problems, jc.DeepEquals, []string{
+		"model is dying",
+		"controller upgrade is in progress",
+	})
+}
+
+func (s *Suite) TestModelInfo(c *gc.C) {
+	api := s.mustMakeAPI(c)
+
+	info, err := api.ModelInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, gc.DeepEquals, params.MigrationModelInfo{
+		UUID:            modelUUID,
+		Name:            "model",
+		OwnerTag:        "user-owner",
+		AgentVersion:    version.MustParse("2.0.0"),
+		CloudType:       "ec2",
+		CloudRegion:     "us-east-1",
+		CloudCredential: "default",
 	})
 }
 
@@ -159,6 +343,32 @@ type stubBackend struct {
 
 	getErr    error
 	migration *stubMigration
+	model     description.Model
+	exportErr error
+	logs      []*state.LogRecord
+	logsArgs  []interface{}
+	reaped    bool
+	reapErr   error
+}
+
+func (b *stubBackend) Export() (description.Model, error) {
+	if b.exportErr != nil {
+		return nil, b.exportErr
+	}
+	return b.model, nil
+}
+
+func (b *stubBackend) ModelLogs(after time.Time, limit int) ([]*state.LogRecord, error) {
+	b.logsArgs = []interface{}{after, limit}
+	return b.logs, nil
+}
+
+func (b *stubBackend) RemoveExportingModelDocs() error {
+	if b.reapErr != nil {
+		return b.reapErr
+	}
+	b.reaped = true
+	return nil
 }
 
 func (b *stubBackend) WatchForModelMigration() state.NotifyWatcher {
@@ -172,14 +382,57 @@ func (b *stubBackend) GetModelMigration() (state.ModelMigration, error) {
 	return b.migration, nil
 }
 
+type stubPrecheckBackend struct {
+	migration.PrecheckBackend
+
+	life      state.Life
+	upgrading bool
+}
+
+func (b *stubPrecheckBackend) ModelInfo() (coremigration.ModelInfo, error) {
+	return coremigration.ModelInfo{
+		UUID:            modelUUID,
+		Name:            "model",
+		Owner:           names.NewUserTag("owner"),
+		AgentVersion:    version.MustParse("2.0.0"),
+		CloudType:       "ec2",
+		CloudRegion:     "us-east-1",
+		CloudCredential: "default",
+	}, nil
+}
+
+func (b *stubPrecheckBackend) ModelLife() (state.Life, error) {
+	return b.life, nil
+}
+
+func (b *stubPrecheckBackend) IsUpgrading() (bool, error) {
+	return b.upgrading, nil
+}
+
+func (b *stubPrecheckBackend) AllMachines() ([]migration.PrecheckMachine, error) {
+	return nil, nil
+}
+
+func (b *stubPrecheckBackend) AllApplications() ([]migration.PrecheckApplication, error) {
+	return nil, nil
+}
+
 type stubMigration struct {
 	state.ModelMigration
-	setPhaseErr error
-	phaseSet    coremigration.Phase
+
+	phase            coremigration.Phase
+	setPhaseErr      error
+	phaseSet         coremigration.Phase
+	minionReports    *state.MinionReports
+	minionReportsErr error
+}
+
+func (m *stubMigration) Id() string {
+	return "id"
 }
 
 func (m *stubMigration) Phase() (coremigration.Phase, error) {
-	return coremigration.READONLY, nil
+	return m.phase, nil
 }
 
 func (m *stubMigration) Attempt() (int, error) {
@@ -208,6 +461,20 @@ func (m *stubMigration) SetPhase(phase coremigration.Phase) error {
 	return nil
 }
 
+func (m *stubMigration) WatchMinionReports() (state.NotifyWatcher, error) {
+	return apiservertesting.NewFakeNotifyWatcher(), nil
+}
+
+func (m *stubMigration) MinionReports() (*state.MinionReports, error) {
+	if m.minionReportsErr != nil {
+		return nil, m.minionReportsErr
+	}
+	if m.minionReports == nil {
+		return new(state.MinionReports), nil
+	}
+	return m.minionReports, nil
+}
+
 var modelUUID string
 var controllerUUID string
 
@@ -4,6 +4,8 @@
 package migrationmaster
 
 import (
+	"time"
+
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 )
@@ -15,8 +17,25 @@ type Backend interface {
 
 	WatchForModelMigration() state.NotifyWatcher
 	GetModelMigration() (state.ModelMigration, error)
+	ModelLogs(after time.Time, limit int) ([]*state.LogRecord, error)
+	RemoveExportingModelDocs() error
 }
 
 var getBackend = func(st *state.State) Backend {
-	return st
+	return &backendShim{st}
+}
+
+// backendShim adds the log retrieval functionality of the state
+// package to *state.State so that it satisfies Backend.
+type backendShim struct {
+	*state.State
+}
+
+// ModelLogs implements Backend.
+func (s *backendShim) ModelLogs(after time.Time, limit int) ([]*state.LogRecord, error) {
+	return state.ModelLogs(s.State, after, limit)
+}
+
+var getPrecheckBackend = func(st *state.State) migration.PrecheckBackend {
+	return migration.PrecheckShim(st)
 }
@@ -5,13 +5,16 @@ package migrationminion_test
 
 import (
 	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/migrationminion"
+	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 )
@@ -32,7 +35,7 @@ var _ = gc.Suite(&Suite{})
 func (s *Suite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 
-	s.backend = &stubBackend{}
+	s.backend = &stubBackend{mig: &stubMigration{}}
 	migrationminion.PatchState(s, s.backend)
 
 	s.resources = common.NewResources()
@@ -74,6 +77,47 @@ func (s *Suite) TestWatch(c *gc.C) {
 	c.Assert(s.resources.Get(result.NotifyWatcherId), gc.NotNil)
 }
 
+func (s *Suite) TestReport(c *gc.C) {
+	api := s.mustMakeAPI(c)
+	err := api.Report(params.MinionReport{
+		MigrationId: "id",
+		Phase:       "IMPORT",
+		Success:     true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.mig.CheckCall(c, 0, "SubmitMinionReport",
+		names.NewMachineTag("99"), coremigration.IMPORT, true)
+}
+
+func (s *Suite) TestReportInvalidPhase(c *gc.C) {
+	api := s.mustMakeAPI(c)
+	err := api.Report(params.MinionReport{
+		MigrationId: "id",
+		Phase:       "WTF",
+	})
+	c.Assert(err, gc.ErrorMatches, "unable to parse phase")
+}
+
+func (s *Suite) TestReportWrongMigration(c *gc.C) {
+	api := s.mustMakeAPI(c)
+	err := api.Report(params.MinionReport{
+		MigrationId: "other",
+		Phase:       "IMPORT",
+	})
+	c.Assert(err, gc.ErrorMatches, `migration "other" is not the current migration`)
+	s.backend.mig.CheckNoCalls(c)
+}
+
+func (s *Suite) TestReportNoMigration(c *gc.C) {
+	s.backend.getErr = errors.NotFoundf("migration")
+	api := s.mustMakeAPI(c)
+	err := api.Report(params.MinionReport{
+		MigrationId: "id",
+		Phase:       "IMPORT",
+	})
+	c.Assert(err, gc.ErrorMatches, "unable to find migration: migration not found")
+}
+
 func (s *Suite) makeAPI() (*migrationminion.API, error) {
 	return migrationminion.NewAPI(nil, s.resources, s.authorizer)
 }
@@ -87,6 +131,15 @@ func (s *Suite) mustMakeAPI(c *gc.C) *migrationminion.API {
 type stubBackend struct {
 	migrationminion.Backend
 	watchError error
+	getErr     error
+	mig        *stubMigration
+}
+
+func (b *stubBackend) GetModelMigration() (state.ModelMigration, error) {
+	if b.getErr != nil {
+		return nil, b.getErr
+	}
+	return b.mig, nil
 }
 
 func (b *stubBackend) WatchMigrationStatus() (state.NotifyWatcher, error) {
@@ -95,3 +148,17 @@ func (b *stubBackend) WatchMigrationStatus() (state.NotifyWatcher, error) {
 	}
 	return apiservertesting.NewFakeNotifyWatcher(), nil
 }
+
+type stubMigration struct {
+	state.ModelMigration
+	jujutesting.Stub
+}
+
+func (m *stubMigration) Id() string {
+	return "id"
+}
+
+func (m *stubMigration) SubmitMinionReport(tag names.Tag, phase coremigration.Phase, success bool) error {
+	m.MethodCall(m, "SubmitMinionReport", tag, phase, success)
+	return m.NextErr()
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 )
@@ -57,6 +58,31 @@ func checkAuth(authorizer common.Authorizer, st *state.State) error {
 	return nil
 }
 
+// Prechecks checks that the target controller is able to accept the
+// described model, returning every problem found.
+func (api *API) Prechecks(model params.MigrationModelInfo) (params.MigrationPrecheckResult, error) {
+	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	if err != nil {
+		return params.MigrationPrecheckResult{}, errors.Trace(err)
+	}
+	problems, err := migration.TargetPrecheck(
+		migration.TargetPrecheckShim(api.state),
+		coremigration.ModelInfo{
+			UUID:            model.UUID,
+			Name:            model.Name,
+			Owner:           ownerTag,
+			AgentVersion:    model.AgentVersion,
+			CloudType:       model.CloudType,
+			CloudRegion:     model.CloudRegion,
+			CloudCredential: model.CloudCredential,
+		},
+	)
+	if err != nil {
+		return params.MigrationPrecheckResult{}, errors.Trace(err)
+	}
+	return params.MigrationPrecheckResult{Problems: problems}, nil
+}
+
 // Import takes a serialized Juju model, deserializes it, and
 // recreates it in the receiving controller.
 func (api *API) Import(serialized params.SerializedModel) error {
@@ -111,3 +137,80 @@ func (api *API) Activate(args params.ModelArgs) error {
 
 	return model.SetMigrationMode(state.MigrationModeActive)
 }
+
+// logTransferSink is the name under which the time of the most recent
+// log record received for a migrating model is recorded.
+const logTransferSink = "migration"
+
+// getModelState returns a State for the specified model. Unlike
+// getModel, the model's migration mode is not checked, as log
+// transfer happens after the model has been activated.
+func (api *API) getModelState(modelTag string) (*state.State, error) {
+	tag, err := names.ParseModelTag(modelTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := api.state.GetModel(tag); err != nil {
+		return nil, errors.Trace(err)
+	}
+	st, err := api.state.ForModel(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return st, nil
+}
+
+// AddLogs adds historical log records transferred from the source
+// controller to the specified model. The time of the last record is
+// recorded so that an interrupted transfer can be resumed.
+func (api *API) AddLogs(args params.MigrationLogs) error {
+	st, err := api.getModelState(args.ModelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Close()
+
+	if len(args.Records) == 0 {
+		return nil
+	}
+	records := make([]*state.LogRecord, len(args.Records))
+	for i, rec := range args.Records {
+		records[i] = &state.LogRecord{
+			Time:      rec.Time,
+			Entity:    rec.Entity,
+			Module:    rec.Module,
+			Location:  rec.Location,
+			Level:     rec.Level,
+			Message:   rec.Message,
+			ModelUUID: st.ModelUUID(),
+		}
+	}
+	if err := state.ImportLogs(st, records); err != nil {
+		return errors.Trace(err)
+	}
+	lastTime := records[len(records)-1].Time
+	err = state.NewLastSentLogger(st, logTransferSink).Set(lastTime)
+	return errors.Annotate(err, "cannot record log transfer progress")
+}
+
+// LatestLogTime returns the time of the most recent log record
+// received for the specified model by AddLogs. The zero time is
+// returned if no records have been received.
+func (api *API) LatestLogTime(args params.ModelArgs) (params.LatestLogTimeResult, error) {
+	var result params.LatestLogTimeResult
+
+	st, err := api.getModelState(args.ModelTag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	defer st.Close()
+
+	t, err := state.NewLastSentLogger(st, logTransferSink).Get()
+	if errors.Cause(err) == state.ErrNeverForwarded {
+		return result, nil
+	} else if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Time = t
+	return result, nil
+}
@@ -4,9 +4,13 @@
 package migrationtarget_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
@@ -97,6 +101,43 @@ func (s *Suite) TestImport(c *gc.C) {
 	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeImporting)
 }
 
+func (s *Suite) TestPrechecks(c *gc.C) {
+	api := s.mustNewAPI(c)
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	agentVersion, ok := cfg.AgentVersion()
+	c.Assert(ok, jc.IsTrue)
+
+	result, err := api.Prechecks(params.MigrationModelInfo{
+		UUID:         utils.MustNewUUID().String(),
+		Name:         "some-model",
+		OwnerTag:     s.Owner.String(),
+		AgentVersion: agentVersion,
+		CloudType:    "dummy",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Problems, gc.HasLen, 0)
+}
+
+func (s *Suite) TestPrechecksProblems(c *gc.C) {
+	api := s.mustNewAPI(c)
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.Prechecks(params.MigrationModelInfo{
+		UUID:         model.UUID(),
+		Name:         "some-model",
+		OwnerTag:     s.Owner.String(),
+		AgentVersion: version.MustParse("99.0.0"),
+		CloudType:    "ec2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Problems, gc.HasLen, 3)
+	c.Check(result.Problems[0], gc.Matches, `target controller is at version .*, model is at 99.0.0`)
+	c.Check(result.Problems[1], gc.Equals, `target controller cloud type is "dummy", model cloud type is "ec2"`)
+	c.Check(result.Problems[2], gc.Equals, "target controller already has model "+model.UUID())
+}
+
 func (s *Suite) TestAbort(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
@@ -170,6 +211,65 @@ func (s *Suite) TestActivateNotImportingEnv(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `migration mode for the model is not importing`)
 }
 
+func (s *Suite) TestAddLogs(c *gc.C) {
+	api := s.mustNewAPI(c)
+	tag := s.importModel(c, api)
+
+	result, err := api.LatestLogTime(params.ModelArgs{ModelTag: tag.String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Time.IsZero(), jc.IsTrue)
+
+	t0 := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Second)
+	err = api.AddLogs(params.MigrationLogs{
+		ModelTag: tag.String(),
+		Records: []params.MigrationLogRecord{{
+			Time:     t0,
+			Entity:   "machine-0",
+			Module:   "juju.worker",
+			Location: "worker.go:1",
+			Level:    loggo.INFO,
+			Message:  "first",
+		}, {
+			Time:     t1,
+			Entity:   "unit-foo-0",
+			Module:   "juju.worker.uniter",
+			Location: "uniter.go:2",
+			Level:    loggo.ERROR,
+			Message:  "second",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	st, err := s.State.ForModel(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	records, err := state.ModelLogs(st, time.Time{}, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 2)
+	c.Check(records[0].Message, gc.Equals, "first")
+	c.Check(records[1].Entity, gc.Equals, "unit-foo-0")
+	c.Check(records[1].Level, gc.Equals, loggo.ERROR)
+	c.Check(records[1].ModelUUID, gc.Equals, tag.Id())
+
+	result, err = api.LatestLogTime(params.ModelArgs{ModelTag: tag.String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Time.Equal(t1), jc.IsTrue)
+}
+
+func (s *Suite) TestAddLogsMissingModel(c *gc.C) {
+	api := s.mustNewAPI(c)
+	newUUID := utils.MustNewUUID().String()
+	err := api.AddLogs(params.MigrationLogs{ModelTag: names.NewModelTag(newUUID).String()})
+	c.Assert(err, gc.ErrorMatches, `model not found`)
+}
+
+func (s *Suite) TestLatestLogTimeNotATag(c *gc.C) {
+	api := s.mustNewAPI(c)
+	_, err := api.LatestLogTime(params.ModelArgs{ModelTag: "not-a-tag"})
+	c.Assert(err, gc.ErrorMatches, `"not-a-tag" is not a valid tag`)
+}
+
 func (s *Suite) newAPI() (*migrationtarget.API, error) {
 	return migrationtarget.NewAPI(s.State, s.resources, s.authorizer)
 }
@@ -0,0 +1,137 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+)
+
+// mirrorResourcesHandler handles the upload of charm store resources
+// into the controller's charm mirror.
+type mirrorResourcesHandler struct {
+	ctxt httpContext
+}
+
+func (h *mirrorResourcesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	st, entity, err := h.ctxt.stateForRequestAuthenticatedUser(r)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	switch r.Method {
+	case "POST":
+		if err := checkCharmMirrorUpload(st, entity); err != nil {
+			sendError(w, err)
+			return
+		}
+		if err := h.processPost(r, st); err != nil {
+			sendError(w, err)
+			return
+		}
+		sendStatusAndJSON(w, http.StatusOK, &params.ErrorResult{})
+	default:
+		sendError(w, errors.MethodNotAllowedf("unsupported method: %q", r.Method))
+	}
+}
+
+// checkCharmMirrorUpload checks that the controller hosts a charm
+// mirror and that the given entity may upload to it. Everything in
+// the mirror is used by every model on the controller, so only
+// controller administrators may upload to it.
+func checkCharmMirrorUpload(st *state.State, entity state.Entity) error {
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !controllerConfig.CharmStoreMirror() {
+		return errors.NewBadRequest(nil, "charm store charms and resources may only be uploaded to controllers with "+controller.CharmStoreMirror+" enabled")
+	}
+	userTag, ok := entity.Tag().(names.UserTag)
+	if !ok {
+		return common.ErrPerm
+	}
+	isAdmin, err := st.IsControllerAdministrator(userTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// processPost stores the uploaded content of a revision of a resource
+// of a mirrored charm. The charm is identified by the "url" query
+// argument, and the resource by the "name", "revision" and
+// "fingerprint" arguments, where the fingerprint is the resource's
+// SHA384 hash as recorded by the charm store. The resource's other
+// details are taken from the charm's metadata.
+func (h *mirrorResourcesHandler) processPost(r *http.Request, st *state.State) error {
+	query := r.URL.Query()
+	curl, err := charm.ParseURL(query.Get("url"))
+	if err != nil {
+		return errors.NewBadRequest(err, "invalid charm URL")
+	}
+	ch, err := openMirroredCharm(st, curl)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	name := query.Get("name")
+	meta, ok := ch.Meta().Resources[name]
+	if !ok {
+		return errors.NotFoundf("resource %q of charm %q", name, curl)
+	}
+	revision, err := strconv.Atoi(query.Get("revision"))
+	if err != nil {
+		return errors.NewBadRequest(err, "invalid resource revision")
+	}
+	fp, err := charmresource.ParseFingerprint(query.Get("fingerprint"))
+	if err != nil {
+		return errors.NewBadRequest(err, "invalid resource fingerprint")
+	}
+	if r.ContentLength < 0 {
+		return errors.NewBadRequest(nil, "missing Content-Length")
+	}
+	res := charmresource.Resource{
+		Meta:        meta,
+		Origin:      charmresource.OriginStore,
+		Revision:    revision,
+		Fingerprint: fp,
+		Size:        r.ContentLength,
+	}
+	if err := st.AddMirroredResource(curl, res, r.Body); err != nil {
+		if errors.IsNotValid(err) || errors.IsAlreadyExists(err) {
+			return errors.NewBadRequest(err, "")
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// openMirroredCharm reads the archive of the given charm in the
+// controller's charm mirror.
+func openMirroredCharm(st *state.State, curl *charm.URL) (*charm.CharmArchive, error) {
+	reader, _, err := st.OpenMirroredCharm(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer reader.Close()
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read mirrored charm %q", curl)
+	}
+	return charm.ReadCharmArchiveBytes(data)
+}
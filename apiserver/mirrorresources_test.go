@@ -0,0 +1,126 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type mirrorResourcesSuite struct {
+	authHttpSuite
+}
+
+var _ = gc.Suite(&mirrorResourcesSuite{})
+
+func (s *mirrorResourcesSuite) SetUpTest(c *gc.C) {
+	s.ConfigAttrs = map[string]interface{}{
+		controller.CharmStoreMirror: true,
+	}
+	s.authHttpSuite.SetUpTest(c)
+	s.Factory.MakeMirroredCharm(c, &factory.CharmParams{Name: "starsay", URL: "cs:quantal/starsay-3"})
+}
+
+func mirrorResourcesURI(c *gc.C, base *url.URL, modelUUID, name, content string, revision int) string {
+	fp, err := charmresource.GenerateFingerprint(strings.NewReader(content))
+	c.Assert(err, jc.ErrorIsNil)
+	base.Path = fmt.Sprintf("/model/%s/mirror-resources", modelUUID)
+	base.RawQuery = url.Values{
+		"url":         {"cs:quantal/starsay-3"},
+		"name":        {name},
+		"revision":    {fmt.Sprint(revision)},
+		"fingerprint": {fp.String()},
+	}.Encode()
+	return base.String()
+}
+
+func (s *mirrorResourcesSuite) mirrorResourcesURI(c *gc.C, name, content string, revision int) string {
+	return mirrorResourcesURI(c, s.baseURL(c), s.modelUUID, name, content, revision)
+}
+
+func assertMirrorErrorResponse(c *gc.C, resp *http.Response, expCode int, expError string) {
+	body := assertResponse(c, resp, expCode, params.ContentTypeJSON)
+	var result params.ErrorResult
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Message, gc.Matches, expError)
+}
+
+func (s *mirrorResourcesSuite) TestUpload(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method: "POST",
+		url:    s.mirrorResourcesURI(c, "store-resource", "spam", 2),
+		body:   strings.NewReader("spam"),
+	})
+	assertResponse(c, resp, http.StatusOK, params.ContentTypeJSON)
+
+	res, reader, err := s.State.OpenMirroredResource(charm.MustParseURL("cs:quantal/starsay"), "store-resource", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+	c.Assert(res.Path, gc.Equals, "filename.tgz")
+	c.Assert(res.Size, gc.Equals, int64(4))
+	data, err := ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "spam")
+}
+
+func (s *mirrorResourcesSuite) TestUploadRequiresControllerAdmin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "hunter2", Access: state.ModelReadAccess})
+	resp := s.sendRequest(c, httpRequestParams{
+		tag:      user.Tag().String(),
+		password: "hunter2",
+		method:   "POST",
+		url:      s.mirrorResourcesURI(c, "store-resource", "spam", 2),
+		body:     strings.NewReader("spam"),
+	})
+	assertMirrorErrorResponse(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
+func (s *mirrorResourcesSuite) TestUploadBadContent(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method: "POST",
+		url:    s.mirrorResourcesURI(c, "store-resource", "spam", 2),
+		body:   strings.NewReader("eggs"),
+	})
+	assertMirrorErrorResponse(c, resp, http.StatusBadRequest, `resource "store-resource" content with fingerprint .* not valid`)
+}
+
+func (s *mirrorResourcesSuite) TestUnknownResource(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method: "POST",
+		url:    s.mirrorResourcesURI(c, "no-such", "spam", 2),
+		body:   strings.NewReader("spam"),
+	})
+	assertMirrorErrorResponse(c, resp, http.StatusNotFound, `resource "no-such" of charm "cs:quantal/starsay-3" not found`)
+}
+
+type noMirrorResourcesSuite struct {
+	authHttpSuite
+}
+
+var _ = gc.Suite(&noMirrorResourcesSuite{})
+
+func (s *noMirrorResourcesSuite) TestRequiresMirror(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method: "POST",
+		url:    mirrorResourcesURI(c, s.baseURL(c), s.modelUUID, "store-resource", "spam", 2),
+		body:   strings.NewReader("spam"),
+	})
+	assertMirrorErrorResponse(c, resp, http.StatusBadRequest, "charm store charms and resources may only be uploaded to controllers with charmstore-mirror enabled")
+}
@@ -212,6 +212,7 @@ type mockState struct {
 	controllerModel *mockModel
 	users           []*state.ModelUser
 	creds           map[string]cloud.Credential
+	cfgDefaults     config.ModelDefaultAttributes
 }
 
 func (st *mockState) ModelUUID() string {
@@ -287,6 +288,16 @@ func (st *mockState) ModelUser(tag names.UserTag) (*state.ModelUser, error) {
 	return nil, st.NextErr()
 }
 
+func (st *mockState) ModelConfigDefaultValues() (config.ModelDefaultAttributes, error) {
+	st.MethodCall(st, "ModelConfigDefaultValues")
+	return st.cfgDefaults, st.NextErr()
+}
+
+func (st *mockState) UpdateModelConfigDefaultValues(update map[string]interface{}, remove []string, regionName string) error {
+	st.MethodCall(st, "UpdateModelConfigDefaultValues", update, remove, regionName)
+	return st.NextErr()
+}
+
 type mockModel struct {
 	gitjujutesting.Stub
 	owner  names.UserTag
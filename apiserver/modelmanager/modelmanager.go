@@ -30,7 +30,8 @@ import (
 var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
 func init() {
-	common.RegisterStandardFacade("ModelManager", 2, newFacade)
+	common.RegisterStandardFacade("ModelManager", 2, newFacadeV2)
+	common.RegisterStandardFacade("ModelManager", 3, newFacade)
 }
 
 // ModelManager defines the methods on the modelmanager API endpoint.
@@ -55,6 +56,33 @@ func newFacade(st *state.State, _ *common.Resources, auth common.Authorizer) (*M
 	return NewModelManagerAPI(NewStateBackend(st), auth)
 }
 
+// ModelManagerAPIV2 implements version 2 of the model manager API end
+// point. It differs from version 3 in not managing model config
+// defaults.
+type ModelManagerAPIV2 struct {
+	*ModelManagerAPI
+}
+
+func newFacadeV2(st *state.State, resources *common.Resources, auth common.Authorizer) (*ModelManagerAPIV2, error) {
+	api, err := newFacade(st, resources, auth)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV2{api}, nil
+}
+
+// ModelDefaults is not available in version 2 of the facade; the
+// method signature hides it from the RPC layer.
+func (*ModelManagerAPIV2) ModelDefaults(_, _ struct{}) {}
+
+// SetModelDefaults is not available in version 2 of the facade; the
+// method signature hides it from the RPC layer.
+func (*ModelManagerAPIV2) SetModelDefaults(_, _ struct{}) {}
+
+// UnsetModelDefaults is not available in version 2 of the facade; the
+// method signature hides it from the RPC layer.
+func (*ModelManagerAPIV2) UnsetModelDefaults(_, _ struct{}) {}
+
 // NewModelManagerAPI creates a new api server endpoint for managing
 // models.
 func NewModelManagerAPI(st Backend, authorizer common.Authorizer) (*ModelManagerAPI, error) {
@@ -104,12 +132,18 @@ type ConfigSource interface {
 }
 
 func (mm *ModelManagerAPI) newModelConfig(
-	args params.ModelCreateArgs, source ConfigSource, credential *cloud.Credential,
+	args params.ModelCreateArgs,
+	source ConfigSource,
+	credential *cloud.Credential,
+	defaults map[string]interface{},
 ) (*config.Config, error) {
 	// For now, we just smash to the two maps together as we store
 	// the account values and the model config together in the
 	// *config.Config instance.
 	joint := make(map[string]interface{})
+	for key, value := range defaults {
+		joint[key] = value
+	}
 	for key, value := range args.Config {
 		joint[key] = value
 	}
@@ -221,7 +255,11 @@ func (mm *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Mode
 		credential = &elem
 	}
 
-	newConfig, err := mm.newModelConfig(args, controllerModel, credential)
+	defaults, err := mm.regionDefaults(cloudRegion)
+	if err != nil {
+		return result, errors.Annotate(err, "getting config defaults")
+	}
+	newConfig, err := mm.newModelConfig(args, controllerModel, credential, defaults)
 	if err != nil {
 		return result, errors.Annotate(err, "failed to create config")
 	}
@@ -243,6 +281,87 @@ func (mm *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Mode
 	return mm.getModelInfo(model.ModelTag())
 }
 
+// regionDefaults returns the model config defaults inherited by
+// models created in the given cloud region.
+func (mm *ModelManagerAPI) regionDefaults(regionName string) (map[string]interface{}, error) {
+	values, err := mm.state.ModelConfigDefaultValues()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]interface{})
+	for attr, ds := range values {
+		if ds.Controller != nil {
+			result[attr] = ds.Controller
+		}
+		for _, region := range ds.Regions {
+			if region.Name == regionName {
+				result[attr] = region.Value
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// ModelDefaults returns the default config values inherited by
+// models, at each level of inheritance.
+func (mm *ModelManagerAPI) ModelDefaults() (params.ModelDefaultsResult, error) {
+	result := params.ModelDefaultsResult{}
+	values, err := mm.state.ModelConfigDefaultValues()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Config = make(map[string]params.ModelDefaults)
+	for attr, ds := range values {
+		settings := params.ModelDefaults{
+			Default:    ds.Default,
+			Controller: ds.Controller,
+		}
+		for _, region := range ds.Regions {
+			settings.Regions = append(settings.Regions, params.RegionDefaults{
+				RegionName: region.Name,
+				Value:      region.Value,
+			})
+		}
+		result.Config[attr] = settings
+	}
+	return result, nil
+}
+
+// SetModelDefaults sets the default config values inherited by
+// models, for the whole controller or for individual cloud regions.
+func (mm *ModelManagerAPI) SetModelDefaults(args params.SetModelDefaults) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Config)),
+	}
+	if !mm.isAdmin {
+		return results, common.ErrPerm
+	}
+	for i, arg := range args.Config {
+		// Replace any deprecated attributes with their new values.
+		attrs := config.ProcessDeprecatedAttributes(arg.Config)
+		err := mm.state.UpdateModelConfigDefaultValues(attrs, nil, arg.CloudRegion)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// UnsetModelDefaults removes default config values inherited by
+// models, for the whole controller or for individual cloud regions.
+func (mm *ModelManagerAPI) UnsetModelDefaults(args params.UnsetModelDefaults) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Keys)),
+	}
+	if !mm.isAdmin {
+		return results, common.ErrPerm
+	}
+	for i, arg := range args.Keys {
+		err := mm.state.UpdateModelConfigDefaultValues(nil, arg.Keys, arg.CloudRegion)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
 // ListModels returns the models that the specified user
 // has access to in the current server.  Only that controller owner
 // can list models for any user (at this stage).  Other users
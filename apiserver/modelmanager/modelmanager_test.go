@@ -4,6 +4,7 @@
 package modelmanager_test
 
 import (
+	"reflect"
 	"regexp"
 	"time"
 
@@ -30,6 +31,7 @@ import (
 	_ "github.com/juju/juju/provider/joyent"
 	_ "github.com/juju/juju/provider/maas"
 	_ "github.com/juju/juju/provider/openstack"
+	"github.com/juju/juju/rpc/rpcreflect"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
@@ -90,6 +92,24 @@ func (s *modelManagerSuite) SetUpTest(c *gc.C) {
 		creds: map[string]cloud.Credential{
 			"some-credential": cloud.NewEmptyCredential(),
 		},
+		cfgDefaults: config.ModelDefaultAttributes{
+			"attr": config.AttributeDefaultValues{
+				Default:    "",
+				Controller: "val",
+				Regions: []config.RegionDefaultValue{{
+					Name:  "qux",
+					Value: "val++",
+				}},
+			},
+			"attr2": config.AttributeDefaultValues{
+				Controller: "val3",
+				Default:    "val2",
+				Regions: []config.RegionDefaultValue{{
+					Name:  "dummy",
+					Value: "val++",
+				}},
+			},
+		},
 	}
 	s.authoriser = apiservertesting.FakeAuthorizer{
 		Tag: names.NewUserTag("admin@local"),
@@ -116,6 +136,7 @@ func (s *modelManagerSuite) TestCreateModelArgs(c *gc.C) {
 		"ModelUUID",
 		"ControllerModel",
 		"CloudCredentials",
+		"ModelConfigDefaultValues",
 		"NewModel",
 		"ForModel",
 		"Model",
@@ -127,7 +148,7 @@ func (s *modelManagerSuite) TestCreateModelArgs(c *gc.C) {
 	// We cannot predict the UUID, because it's generated,
 	// so we just extract it and ensure that it's not the
 	// same as the controller UUID.
-	newModelArgs := s.st.Calls()[4].Args[0].(state.ModelArgs)
+	newModelArgs := s.st.Calls()[5].Args[0].(state.ModelArgs)
 	uuid := newModelArgs.Config.UUID()
 	c.Assert(uuid, gc.Not(gc.Equals), s.st.controllerModel.cfg.UUID())
 
@@ -138,6 +159,8 @@ func (s *modelManagerSuite) TestCreateModelArgs(c *gc.C) {
 		"uuid":            uuid,
 		"agent-version":   jujuversion.Current.String(),
 		"bar":             "baz",
+		"attr":            "val++",
+		"attr2":           "val3",
 		"controller":      false,
 		"broken":          "",
 		"secret":          "pork",
@@ -160,7 +183,7 @@ func (s *modelManagerSuite) TestCreateModelDefaultRegion(c *gc.C) {
 	_, err := s.api.CreateModel(args)
 	c.Assert(err, jc.ErrorIsNil)
 
-	newModelArgs := s.st.Calls()[4].Args[0].(state.ModelArgs)
+	newModelArgs := s.st.Calls()[5].Args[0].(state.ModelArgs)
 	c.Assert(newModelArgs.CloudRegion, gc.Equals, "some-region")
 }
 
@@ -172,7 +195,7 @@ func (s *modelManagerSuite) TestCreateModelDefaultCredentialAdmin(c *gc.C) {
 	_, err := s.api.CreateModel(args)
 	c.Assert(err, jc.ErrorIsNil)
 
-	newModelArgs := s.st.Calls()[4].Args[0].(state.ModelArgs)
+	newModelArgs := s.st.Calls()[5].Args[0].(state.ModelArgs)
 	c.Assert(newModelArgs.CloudCredential, gc.Equals, "some-credential")
 }
 
@@ -184,7 +207,7 @@ func (s *modelManagerSuite) TestCreateModelEmptyCredentialNonAdmin(c *gc.C) {
 	_, err := s.api.CreateModel(args)
 	c.Assert(err, jc.ErrorIsNil)
 
-	newModelArgs := s.st.Calls()[4].Args[0].(state.ModelArgs)
+	newModelArgs := s.st.Calls()[5].Args[0].(state.ModelArgs)
 	c.Assert(newModelArgs.CloudCredential, gc.Equals, "")
 }
 
@@ -208,6 +231,85 @@ func (s *modelManagerSuite) TestCreateModelUnknownCredential(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `no such credential "bar"`)
 }
 
+func (s *modelManagerSuite) TestModelDefaultsOnlyInV3(c *gc.C) {
+	for _, method := range []string{"ModelDefaults", "SetModelDefaults", "UnsetModelDefaults"} {
+		objType := rpcreflect.ObjTypeOf(reflect.TypeOf(&modelmanager.ModelManagerAPIV2{}))
+		_, err := objType.Method(method)
+		c.Check(err, gc.Equals, rpcreflect.ErrMethodNotFound, gc.Commentf("method %s", method))
+		objType = rpcreflect.ObjTypeOf(reflect.TypeOf(&modelmanager.ModelManagerAPI{}))
+		_, err = objType.Method(method)
+		c.Check(err, jc.ErrorIsNil, gc.Commentf("method %s", method))
+	}
+}
+
+func (s *modelManagerSuite) TestModelDefaults(c *gc.C) {
+	result, err := s.api.ModelDefaults()
+	c.Assert(err, jc.ErrorIsNil)
+	expectedValues := map[string]params.ModelDefaults{
+		"attr": {
+			Controller: "val",
+			Default:    "",
+			Regions: []params.RegionDefaults{{
+				RegionName: "qux",
+				Value:      "val++",
+			}},
+		},
+		"attr2": {
+			Controller: "val3",
+			Default:    "val2",
+			Regions: []params.RegionDefaults{{
+				RegionName: "dummy",
+				Value:      "val++",
+			}},
+		},
+	}
+	c.Assert(result.Config, jc.DeepEquals, expectedValues)
+}
+
+func (s *modelManagerSuite) TestSetModelDefaults(c *gc.C) {
+	args := params.SetModelDefaults{
+		Config: []params.ModelDefaultValues{{
+			Config: map[string]interface{}{"attr3": "val3"},
+		}, {
+			CloudRegion: "qux",
+			Config:      map[string]interface{}{"attr": "val4"},
+		}},
+	}
+	result, err := s.api.SetModelDefaults(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Combine(), jc.ErrorIsNil)
+	s.st.CheckCall(c, 1, "UpdateModelConfigDefaultValues",
+		map[string]interface{}{"attr3": "val3"}, []string(nil), "")
+	s.st.CheckCall(c, 2, "UpdateModelConfigDefaultValues",
+		map[string]interface{}{"attr": "val4"}, []string(nil), "qux")
+}
+
+func (s *modelManagerSuite) TestUnsetModelDefaults(c *gc.C) {
+	args := params.UnsetModelDefaults{
+		Keys: []params.ModelUnsetKeys{{
+			CloudRegion: "qux",
+			Keys:        []string{"attr"},
+		}},
+	}
+	result, err := s.api.UnsetModelDefaults(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	s.st.CheckCall(c, 1, "UpdateModelConfigDefaultValues",
+		map[string]interface{}(nil), []string{"attr"}, "qux")
+}
+
+func (s *modelManagerSuite) TestSetModelDefaultsNonAdmin(c *gc.C) {
+	s.authoriser.Tag = names.NewUserTag("bob@local")
+	api, err := modelmanager.NewModelManagerAPI(&s.st, s.authoriser)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.SetModelDefaults(params.SetModelDefaults{
+		Config: []params.ModelDefaultValues{{
+			Config: map[string]interface{}{"attr3": "val3"},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 // modelManagerStateSuite contains end-to-end tests.
 // Prefer adding tests to modelManagerSuite above.
 type modelManagerStateSuite struct {
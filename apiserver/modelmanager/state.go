@@ -33,6 +33,8 @@ type Backend interface {
 	AddModelUser(state.ModelUserSpec) (*state.ModelUser, error)
 	RemoveModelUser(names.UserTag) error
 	ModelUser(names.UserTag) (*state.ModelUser, error)
+	ModelConfigDefaultValues() (config.ModelDefaultAttributes, error)
+	UpdateModelConfigDefaultValues(update map[string]interface{}, remove []string, regionName string) error
 	Close() error
 }
 
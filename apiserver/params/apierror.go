@@ -85,6 +85,7 @@ const (
 	CodeMethodNotAllowed          = "method not allowed"
 	CodeForbidden                 = "forbidden"
 	CodeDischargeRequired         = "macaroon discharge required"
+	CodeRedirect                  = "redirection required"
 )
 
 // ErrCode returns the error code associated with
@@ -214,3 +215,9 @@ func IsBadRequest(err error) bool {
 func IsMethodNotAllowed(err error) bool {
 	return ErrCode(err) == CodeMethodNotAllowed
 }
+
+// IsCodeRedirect returns true if err is caused by a login for a model
+// which has been migrated to another controller.
+func IsCodeRedirect(err error) bool {
+	return ErrCode(err) == CodeRedirect
+}
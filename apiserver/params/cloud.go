@@ -42,3 +42,26 @@ type UserCloudCredentials struct {
 type UsersCloudCredentials struct {
 	Users []UserCloudCredentials `json:"users"`
 }
+
+// CloudSpec holds a model's cloud, region and credential, as
+// exposed to trusted charms.
+type CloudSpec struct {
+	Type            string           `json:"type"`
+	Region          string           `json:"region,omitempty"`
+	Endpoint        string           `json:"endpoint,omitempty"`
+	StorageEndpoint string           `json:"storage-endpoint,omitempty"`
+	Credential      *CloudCredential `json:"credential,omitempty"`
+}
+
+// CloudSpecResult holds a CloudSpec, or an error.
+type CloudSpecResult struct {
+	Result *CloudSpec `json:"result,omitempty"`
+	Error  *Error     `json:"error,omitempty"`
+}
+
+// ApplicationTrust holds the parameters for the application SetTrust
+// call.
+type ApplicationTrust struct {
+	ApplicationName string `json:"application"`
+	Trust           bool   `json:"trust"`
+}
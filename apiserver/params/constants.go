@@ -31,4 +31,22 @@ const (
 	ResolvedNoHooks    ResolvedMode = "no-hooks"
 )
 
+// UpgradeSeriesStatus describes how far a machine, or a unit deployed
+// to it, has progressed through an in-place series upgrade.
+type UpgradeSeriesStatus string
+
+const (
+	UpgradeSeriesNotStarted       UpgradeSeriesStatus = "not started"
+	UpgradeSeriesPrepareStarted   UpgradeSeriesStatus = "prepare started"
+	UpgradeSeriesPrepareCompleted UpgradeSeriesStatus = "prepare completed"
+	UpgradeSeriesCompleteStarted  UpgradeSeriesStatus = "complete started"
+	UpgradeSeriesCompleted        UpgradeSeriesStatus = "completed"
+	UpgradeSeriesError            UpgradeSeriesStatus = "error"
+)
+
 const MachineNonceHeader = "X-Juju-Nonce"
+
+// MigrationModelHTTPHeader is the key for the HTTP header value
+// that is used to indicate the model being imported when binaries
+// are uploaded to a migration target controller.
+const MigrationModelHTTPHeader = "X-Juju-Migration-Model-UUID"
@@ -11,6 +11,41 @@ type DestroyControllerArgs struct {
 	DestroyModels bool `json:"destroy-models"`
 }
 
+// RotateCertificatesArgs holds the arguments for rotating the
+// controller CA and API server certificates.
+type RotateCertificatesArgs struct {
+	// Finish specifies whether to finish a rotation previously
+	// started, making the new CA the controller CA, or, once the
+	// controllers have reissued their certificates with it, retiring
+	// the old CA. If false, a new rotation is started.
+	Finish bool `json:"finish"`
+}
+
+// SetAPICertificateArgs holds the custom certificate for the
+// controller API servers to present to clients.
+type SetAPICertificateArgs struct {
+	// Cert holds the PEM-encoded certificate chain, leaf first.
+	// If Cert and PrivateKey are both empty, any custom certificate
+	// is removed.
+	Cert string `json:"cert"`
+
+	// PrivateKey holds the PEM-encoded private key for the leaf
+	// certificate.
+	PrivateKey string `json:"private-key"`
+}
+
+// RotateCertificatesResult holds the result of rotating the controller
+// CA and API server certificates.
+type RotateCertificatesResult struct {
+	// CACert holds the PEM-encoded CA certificates that clients
+	// should now trust when connecting to the controller.
+	CACert string `json:"ca-cert"`
+
+	// Complete records whether the rotation is complete, so that the
+	// old CA is no longer trusted.
+	Complete bool `json:"complete"`
+}
+
 // ModelBlockInfo holds information about an model and its
 // current blocks.
 type ModelBlockInfo struct {
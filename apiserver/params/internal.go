@@ -147,6 +147,31 @@ type ResolvedModeResults struct {
 	Results []ResolvedModeResult
 }
 
+// GoalStateStatus holds the status of a unit that is expected to
+// exist in a unit's goal state.
+type GoalStateStatus struct {
+	Status string
+	Since  *time.Time
+}
+
+// UnitsGoalState holds the goal state statuses of units, keyed by
+// unit name.
+type UnitsGoalState map[string]GoalStateStatus
+
+// GoalState holds the units of a unit's application, and of each
+// application related to it keyed by the relation's endpoint name,
+// that are expected to exist.
+type GoalState struct {
+	Units     UnitsGoalState
+	Relations map[string]UnitsGoalState
+}
+
+// GoalStateResult holds a unit's goal state or an error.
+type GoalStateResult struct {
+	Result *GoalState
+	Error  *Error
+}
+
 // StringBoolResult holds the result of an API call that returns a
 // string and a boolean.
 type StringBoolResult struct {
@@ -584,6 +609,15 @@ type ProvisioningInfo struct {
 	SubnetsToZones   map[string][]string
 	ImageMetadata    []CloudImageMetadata
 	EndpointBindings map[string]string
+	CharmLXDProfiles map[string]CharmLXDProfile
+}
+
+// CharmLXDProfile holds an LXD profile declared by a charm, to be
+// applied to the machines its units are deployed to.
+type CharmLXDProfile struct {
+	Description string
+	Config      map[string]string
+	Devices     map[string]map[string]string
 }
 
 // ProvisioningInfoResult holds machine provisioning info or an error.
@@ -36,3 +36,43 @@ type MetricResult struct {
 	Key   string    `json:"key"`
 	Value string    `json:"value"`
 }
+
+// MetricsQuery holds the arguments of an AggregateMetrics call.
+type MetricsQuery struct {
+	// Entities holds the tags of the units and applications whose
+	// metrics are aggregated. If empty, the metrics of all units in
+	// the model are aggregated.
+	Entities []Entity `json:"entities,omitempty"`
+
+	// Keys restricts the aggregation to metrics with the given keys.
+	Keys []string `json:"keys,omitempty"`
+
+	// Start and End bound the time range of the metrics aggregated.
+	// A zero time leaves the range unbounded.
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+
+	// Interval is the size of the time buckets the metrics are
+	// aggregated over; zero aggregates over the whole range.
+	Interval time.Duration `json:"interval"`
+
+	// Aggregation is one of "sum", "avg", "max" or "last".
+	Aggregation string `json:"aggregation"`
+}
+
+// AggregatedMetricsResult holds the results of an AggregateMetrics
+// call.
+type AggregatedMetricsResult struct {
+	Metrics []AggregatedMetric `json:"metrics,omitempty"`
+	Error   *Error             `json:"error,omitempty"`
+}
+
+// AggregatedMetric holds the aggregated value of a metric for a unit
+// over a single time bucket.
+type AggregatedMetric struct {
+	Unit  string    `json:"unit"`
+	Key   string    `json:"key"`
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+	Count int       `json:"count"`
+}
@@ -3,6 +3,13 @@
 
 package params
 
+import (
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/version"
+)
+
 // InitiateModelMigrationArgs holds the details required to start one
 // or more model migrations.
 type InitiateModelMigrationArgs struct {
@@ -40,15 +47,58 @@ type InitiateModelMigrationResult struct {
 	Id       string `json:"id"` // the ID for the migration attempt
 }
 
+// ModelMigrationPrecheckResults is used to return the result of
+// checking whether one or more models can be migrated.
+type ModelMigrationPrecheckResults struct {
+	Results []ModelMigrationPrecheckResult `json:"results"`
+}
+
+// ModelMigrationPrecheckResult is used to return the problems that
+// would prevent a single model from being migrated.
+type ModelMigrationPrecheckResult struct {
+	ModelTag string   `json:"model-tag"`
+	Problems []string `json:"problems"`
+	Error    *Error   `json:"error"`
+}
+
+// MigrationModelInfo describes a model being migrated, as needed by
+// the target controller to run its prechecks.
+type MigrationModelInfo struct {
+	UUID            string         `json:"uuid"`
+	Name            string         `json:"name"`
+	OwnerTag        string         `json:"owner-tag"`
+	AgentVersion    version.Number `json:"agent-version"`
+	CloudType       string         `json:"cloud-type"`
+	CloudRegion     string         `json:"cloud-region"`
+	CloudCredential string         `json:"cloud-credential"`
+}
+
+// MigrationPrecheckResult holds the problems found by the source or
+// target prechecks of a model migration.
+type MigrationPrecheckResult struct {
+	Problems []string `json:"problems"`
+}
+
 // SetMigrationPhaseArgs provides a migration phase to the
 // migrationmaster.SetPhase API method.
 type SetMigrationPhaseArgs struct {
 	Phase string `json:"phase"`
 }
 
-// SerializedModel wraps a buffer contain a serialised Juju model.
+// SerializedModel wraps a buffer contain a serialised Juju model. It
+// also contains lists of the charms and agent binaries used by the
+// model.
 type SerializedModel struct {
-	Bytes []byte `json:"bytes"`
+	Bytes  []byte                 `json:"bytes"`
+	Charms []string               `json:"charms"`
+	Tools  []SerializedModelTools `json:"tools"`
+}
+
+// SerializedModelTools holds the version and SHA256 hash of some
+// agent binaries used by a serialized model.
+type SerializedModelTools struct {
+	Version string `json:"version"`
+	SHA256  string `json:"sha256"`
 }
 
 // ModelArgs wraps a simple model tag.
@@ -56,10 +106,54 @@ type ModelArgs struct {
 	ModelTag string `json:"model-tag"`
 }
 
+// ModelLogsArgs holds the parameters for retrieving a page of a
+// migrating model's log history.
+type ModelLogsArgs struct {
+	// After specifies that only records logged strictly after this
+	// time should be returned.
+	After time.Time `json:"after"`
+
+	// Limit holds the approximate maximum number of records to
+	// return. All records sharing the time of the last record
+	// returned are always included.
+	Limit int `json:"limit"`
+}
+
+// MigrationLogRecord holds a single historical log record for a
+// migrating model.
+type MigrationLogRecord struct {
+	Time     time.Time   `json:"time"`
+	Entity   string      `json:"entity"`
+	Module   string      `json:"module"`
+	Location string      `json:"location"`
+	Level    loggo.Level `json:"level"`
+	Message  string      `json:"message"`
+}
+
+// ModelLogs holds a page of a migrating model's log history.
+type ModelLogs struct {
+	Records []MigrationLogRecord `json:"records"`
+}
+
+// MigrationLogs holds log records to be added to a model being
+// imported by a migration target controller.
+type MigrationLogs struct {
+	ModelTag string               `json:"model-tag"`
+	Records  []MigrationLogRecord `json:"records"`
+}
+
+// LatestLogTimeResult holds the time of the most recent log record
+// received for a model being imported. It is the zero time if no
+// records have been received.
+type LatestLogTimeResult struct {
+	Time time.Time `json:"time"`
+}
+
 // MigrationStatus reports the current status of a model migration.
 type MigrationStatus struct {
-	Attempt int    `json:"attempt"`
-	Phase   string `json:"phase"`
+	MigrationId string `json:"migration-id"`
+	Attempt     int    `json:"attempt"`
+	Phase       string `json:"phase"`
 
 	// TODO(mjs): I'm not convinced these Source fields will get used.
 	SourceAPIAddrs []string `json:"source-api-addrs"`
@@ -73,9 +167,53 @@ type MigrationStatus struct {
 // migration, including authentication details for the remote
 // controller.
 type FullMigrationStatus struct {
-	Spec    ModelMigrationSpec `json:"spec"`
-	Attempt int                `json:"attempt"`
-	Phase   string             `json:"phase"`
+	Spec        ModelMigrationSpec `json:"spec"`
+	MigrationId string             `json:"migration-id"`
+	Attempt     int                `json:"attempt"`
+	Phase       string             `json:"phase"`
+}
+
+// MinionReport holds the details of whether a migration minion
+// succeeded or failed for a specific migration phase.
+type MinionReport struct {
+	// MigrationId holds the id of the migration the agent is
+	// reporting about.
+	MigrationId string `json:"migration-id"`
+
+	// Phase holds the phase of the migration the agent is
+	// reporting about.
+	Phase string `json:"phase"`
+
+	// Success is true if the agent successfully completed its
+	// actions for the migration phase, false otherwise.
+	Success bool `json:"success"`
+}
+
+// MinionReports holds the details of which agents have reported
+// success or failure for the current phase of a migration.
+type MinionReports struct {
+	// MigrationId holds the id of the migration the reports are
+	// for.
+	MigrationId string `json:"migration-id"`
+
+	// Phase holds the phase of the migration the reports are for.
+	Phase string `json:"phase"`
+
+	// SuccessCount holds the number of agents which have
+	// successfully completed their actions for the phase.
+	SuccessCount int `json:"success-count"`
+
+	// UnknownCount holds the number of agents which are yet to
+	// report for the phase.
+	UnknownCount int `json:"unknown-count"`
+
+	// UnknownSample holds the tags of a limited number of the agents
+	// which are yet to report for the phase.
+	UnknownSample []string `json:"unknown-sample"`
+
+	// Failed holds the tags of the agents which failed to complete
+	// their actions for the phase.
+	Failed []string `json:"failed"`
 }
 
 type PhaseResult struct {
@@ -15,6 +15,66 @@ type ModelConfigResults struct {
 	Config map[string]interface{}
 }
 
+// ConfigValue encapsulates a configuration
+// value and its source.
+type ConfigValue struct {
+	Value  interface{} `json:"value"`
+	Source string      `json:"source"`
+}
+
+// ModelConfigSourceResults contains the result of client API calls
+// to get model config values annotated with their source.
+type ModelConfigSourceResults struct {
+	Config map[string]ConfigValue `json:"config"`
+}
+
+// RegionDefaults contains the default value of a model config
+// attribute for a cloud region.
+type RegionDefaults struct {
+	RegionName string      `json:"region-name"`
+	Value      interface{} `json:"value"`
+}
+
+// ModelDefaults holds the values of a model config attribute
+// that are inherited by models, at each level of inheritance.
+type ModelDefaults struct {
+	Default    interface{}      `json:"default,omitempty"`
+	Controller interface{}      `json:"controller,omitempty"`
+	Regions    []RegionDefaults `json:"regions,omitempty"`
+}
+
+// ModelDefaultsResult contains the result of the ModelDefaults
+// API call.
+type ModelDefaultsResult struct {
+	Config map[string]ModelDefaults `json:"config"`
+}
+
+// ModelDefaultValues contains the model config default values
+// to set for the controller, or for a cloud region if specified.
+type ModelDefaultValues struct {
+	CloudRegion string                 `json:"cloud-region,omitempty"`
+	Config      map[string]interface{} `json:"config"`
+}
+
+// SetModelDefaults contains the arguments for the SetModelDefaults
+// API call.
+type SetModelDefaults struct {
+	Config []ModelDefaultValues `json:"config"`
+}
+
+// ModelUnsetKeys contains the model config default keys to
+// unset for the controller, or for a cloud region if specified.
+type ModelUnsetKeys struct {
+	CloudRegion string   `json:"cloud-region,omitempty"`
+	Keys        []string `json:"keys"`
+}
+
+// UnsetModelDefaults contains the arguments for the UnsetModelDefaults
+// API call.
+type UnsetModelDefaults struct {
+	Keys []ModelUnsetKeys `json:"keys"`
+}
+
 // ModelSet contains the arguments for ModelSet client API
 // call.
 type ModelSet struct {
@@ -33,6 +93,13 @@ type SetModelAgentVersion struct {
 	Version version.Number
 }
 
+// SetModelAgentCanary contains the arguments for
+// SetModelAgentCanary client API call.
+type SetModelAgentCanary struct {
+	Version    version.Number `json:"version"`
+	MachineIds []string       `json:"machine-ids"`
+}
+
 // ModelInfo holds information about the Juju model.
 type ModelInfo struct {
 	// The json names for the fields below are as per the older
@@ -47,6 +114,10 @@ type ModelInfo struct {
 	// TODO(axw) make sure we're setting this everywhere
 	CloudCredential string `json:"CloudCredential,omitempty"`
 
+	// CharmStoreMirror reports whether the controller hosts a charm
+	// mirror that charm store URLs are resolved against.
+	CharmStoreMirror bool `json:"CharmStoreMirror,omitempty"`
+
 	// OwnerTag is the tag of the user that owns the model.
 	OwnerTag string `json:"OwnerTag"`
 
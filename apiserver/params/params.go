@@ -190,6 +190,65 @@ type AddMachinesResult struct {
 	Error   *Error `json:"Error"`
 }
 
+// UpgradeSeriesPrepareArg holds the parameters for preparing a machine
+// for an in-place series upgrade.
+type UpgradeSeriesPrepareArg struct {
+	Entity Entity `json:"entity"`
+	Series string `json:"series"`
+	Force  bool   `json:"force"`
+}
+
+// UpgradeSeriesStatusParam holds the upgrade-series status to record
+// for a single machine or unit.
+type UpgradeSeriesStatusParam struct {
+	Entity Entity              `json:"entity"`
+	Status UpgradeSeriesStatus `json:"status"`
+}
+
+// UpgradeSeriesStatusParams holds the arguments for recording the
+// upgrade-series status of several machines or units.
+type UpgradeSeriesStatusParams struct {
+	Params []UpgradeSeriesStatusParam `json:"params"`
+}
+
+// UpgradeSeriesStatusResult holds the upgrade-series status of a single
+// machine or unit.
+type UpgradeSeriesStatusResult struct {
+	Status UpgradeSeriesStatus `json:"status,omitempty"`
+	Error  *Error              `json:"error,omitempty"`
+}
+
+// UpgradeSeriesStatusResults holds the results of a bulk upgrade-series
+// status request.
+type UpgradeSeriesStatusResults struct {
+	Results []UpgradeSeriesStatusResult `json:"results,omitempty"`
+}
+
+// UpgradeSeriesUnitStatusesResult holds the upgrade-series status of
+// each unit on a machine, keyed by unit name.
+type UpgradeSeriesUnitStatusesResult struct {
+	Statuses map[string]UpgradeSeriesStatus `json:"statuses,omitempty"`
+	Error    *Error                         `json:"error,omitempty"`
+}
+
+// UpgradeSeriesUnitStatusesResults holds the results of a bulk request
+// for unit upgrade-series statuses.
+type UpgradeSeriesUnitStatusesResults struct {
+	Results []UpgradeSeriesUnitStatusesResult `json:"results,omitempty"`
+}
+
+// UpdateSeriesArg holds the series a machine is now running.
+type UpdateSeriesArg struct {
+	Entity Entity `json:"entity"`
+	Series string `json:"series"`
+}
+
+// UpdateSeriesArgs holds the arguments for recording the series of
+// several machines.
+type UpdateSeriesArgs struct {
+	Args []UpdateSeriesArg `json:"args"`
+}
+
 // DestroyMachines holds parameters for the DestroyMachines call.
 type DestroyMachines struct {
 	MachineNames []string
@@ -245,11 +304,41 @@ type ApplicationSetCharm struct {
 	// ResourceIDs is a map of resource names to resource IDs to activate during
 	// the upgrade.
 	ResourceIDs map[string]string `json:"resourceids"`
+	// BatchSize, if non-zero, upgrades at most this many units at a
+	// time, waiting for each batch to settle before the next.
+	BatchSize int `json:"batch-size,omitempty"`
+	// BatchTimeout, if non-zero, is how long each batch of units may
+	// take to settle before the upgrade is halted.
+	BatchTimeout time.Duration `json:"batch-timeout,omitempty"`
+}
+
+// ApplicationRollbackCharm holds the parameters for making the application
+// RollbackCharm call.
+type ApplicationRollbackCharm struct {
+	// ApplicationName is the name of the application to roll back.
+	ApplicationName string `json:"applicationname"`
+	// ForceUnits forces the rollback on units in an error state.
+	ForceUnits bool `json:"forceunits"`
+}
+
+// ApplicationPlacementPolicy holds the parameters for the application
+// SetPlacementPolicy call.
+type ApplicationPlacementPolicy struct {
+	ApplicationName string `json:"application"`
+	// Spread is the scope, one of "machine", "host" or "zone", within
+	// which no two units of the application may be placed.
+	Spread string `json:"spread,omitempty"`
+	// Colocate names an application whose machines are preferred
+	// when placing the application's units.
+	Colocate string `json:"colocate,omitempty"`
 }
 
 // ApplicationExpose holds the parameters for making the application Expose call.
 type ApplicationExpose struct {
 	ApplicationName string
+	// CIDRs, if set, restricts access to the application's opened
+	// ports to the given source CIDRs.
+	CIDRs []string
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -640,6 +729,23 @@ type LoginResultV1 struct {
 	ServerVersion string `json:"server-version,omitempty"`
 }
 
+// RedirectInfoResult holds the details of the controller that a model
+// has been migrated to. It is returned by Admin.RedirectInfo after a
+// Login call has failed with CodeRedirect.
+type RedirectInfoResult struct {
+	// ControllerTag holds the tag of the controller the model was
+	// migrated to.
+	ControllerTag string `json:"controller-tag"`
+
+	// Servers holds the host:port addresses of the API servers of
+	// the controller the model was migrated to.
+	Servers []string `json:"servers"`
+
+	// CACert holds the CA certificate used to validate the API
+	// servers' certificates, in PEM format.
+	CACert string `json:"ca-cert"`
+}
+
 // ControllersServersSpec contains arguments for
 // the EnableHA client API call.
 type ControllersSpec struct {
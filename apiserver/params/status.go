@@ -50,6 +50,10 @@ type MachineStatus struct {
 	Jobs       []multiwatcher.MachineJob `json:"jobs"`
 	HasVote    bool                      `json:"has-vote"`
 	WantsVote  bool                      `json:"wants-vote"`
+
+	// UpgradeSeries holds the machine's progress through an in-place
+	// series upgrade, if one is in progress.
+	UpgradeSeries string `json:"upgrade-series,omitempty"`
 }
 
 // ApplicationStatus holds status info about an application.
@@ -65,6 +69,28 @@ type ApplicationStatus struct {
 	Units         map[string]UnitStatus  `json:"units"`
 	MeterStatuses map[string]MeterStatus `json:"meter-statuses"`
 	Status        DetailedStatus         `json:"status"`
+
+	// RollingUpgrade holds the progress of the application's rolling
+	// charm upgrade, if one is in progress.
+	RollingUpgrade *RollingUpgradeStatus `json:"rolling-upgrade,omitempty"`
+
+	// PlacementViolations describes the units placed in breach of the
+	// application's placement policy.
+	PlacementViolations []string `json:"placement-violations,omitempty"`
+}
+
+// RollingUpgradeStatus holds the progress of a rolling charm upgrade.
+type RollingUpgradeStatus struct {
+	// PreviousCharm is the charm run by units not yet released to
+	// the upgrade.
+	PreviousCharm string `json:"previous-charm"`
+	// BatchSize is the number of units upgraded at a time.
+	BatchSize int `json:"batch-size"`
+	// Released holds the names of the units released to the upgrade.
+	Released []string `json:"released"`
+	// Halted indicates that the upgrade was stopped; Message says why.
+	Halted  bool   `json:"halted"`
+	Message string `json:"message,omitempty"`
 }
 
 // MeterStatus represents the meter status of a unit.
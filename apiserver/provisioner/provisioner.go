@@ -385,18 +385,36 @@ func environManagerInstances(st *state.State) ([]instance.Id, error) {
 }
 
 // commonServiceInstances returns instances with
-// services in common with the specified machine.
+// services in common with the specified machine. If any of
+// those services has a placement policy spreading its units
+// across zones, only the instances of such services are
+// returned, so that the provider keeps them apart rather than
+// balancing zones for every service on the machine.
 func commonServiceInstances(st *state.State, m *state.Machine) ([]instance.Id, error) {
 	units, err := m.Units()
 	if err != nil {
 		return nil, err
 	}
-	instanceIdSet := make(set.Strings)
+	var applicationNames, zoneSpreadNames []string
 	for _, unit := range units {
 		if !unit.IsPrincipal() {
 			continue
 		}
-		instanceIds, err := state.ServiceInstances(st, unit.ApplicationName())
+		application, err := unit.Application()
+		if err != nil {
+			return nil, err
+		}
+		applicationNames = append(applicationNames, application.Name())
+		if application.PlacementPolicy().Spread == state.PlacementScopeZone {
+			zoneSpreadNames = append(zoneSpreadNames, application.Name())
+		}
+	}
+	if len(zoneSpreadNames) > 0 {
+		applicationNames = zoneSpreadNames
+	}
+	instanceIdSet := make(set.Strings)
+	for _, applicationName := range applicationNames {
+		instanceIds, err := state.ServiceInstances(st, applicationName)
 		if err != nil {
 			return nil, err
 		}
@@ -505,6 +523,26 @@ func (p *ProvisionerAPI) WatchMachineErrorRetry() (params.NotifyWatchResult, err
 	return result, nil
 }
 
+// WatchMachineCharmProfiles returns a StringsWatcher that notifies of
+// the machines whose LXD profiles, as declared by the charms of their
+// principal units, may need updating because an application's charm
+// changed or a unit was assigned to or removed from the machine.
+func (p *ProvisionerAPI) WatchMachineCharmProfiles() (params.StringsWatchResult, error) {
+	result := params.StringsWatchResult{}
+	if !p.authorizer.AuthMachineAgent() && !p.authorizer.AuthModelManager() {
+		return result, common.ErrPerm
+	}
+	watch := p.st.WatchMachineCharmProfiles()
+	// Consume the initial event and forward it to the result.
+	if changes, ok := <-watch.Changes(); ok {
+		result.StringsWatcherId = p.resources.Register(watch)
+		result.Changes = changes
+	} else {
+		return result, watcher.EnsureErr(watch)
+	}
+	return result, nil
+}
+
 // ReleaseContainerAddresses finds addresses allocated to a container and marks
 // them as Dead, to be released and removed. It accepts container tags as
 // arguments.
@@ -764,6 +764,45 @@ func (s *withoutControllerSuite) TestDistributionGroup(c *gc.C) {
 	})
 }
 
+func (s *withoutControllerSuite) TestDistributionGroupZoneSpread(c *gc.C) {
+	setProvisioned := func(m *state.Machine) {
+		err := m.SetProvisioned(instance.Id("machine-"+m.Id()+"-inst"), "nonce", nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	addUnit := func(svc *state.Application, m *state.Machine) {
+		unit, err := svc.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.AssignToMachine(m)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := wordpress.SetPlacementPolicy(state.PlacementPolicy{Spread: state.PlacementScopeZone})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Machine 0 hosts both applications; only the instances of the
+	// zone-spread wordpress are considered when placing it.
+	addUnit(mysql, s.machines[0])
+	addUnit(wordpress, s.machines[0])
+	addUnit(mysql, s.machines[1])
+	addUnit(wordpress, s.machines[2])
+	setProvisioned(s.machines[1])
+	setProvisioned(s.machines[2])
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.machines[0].Tag().String()},
+		{Tag: s.machines[1].Tag().String()},
+	}}
+	result, err := s.provisioner.DistributionGroup(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.DistributionGroupResults{
+		Results: []params.DistributionGroupResult{
+			{Result: []instance.Id{"machine-2-inst"}},
+			{Result: []instance.Id{"machine-1-inst"}},
+		},
+	})
+}
+
 func (s *withoutControllerSuite) TestDistributionGroupEnvironManagerAuth(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{
 		{Tag: "machine-0"},
@@ -1221,6 +1260,34 @@ func (s *withoutControllerSuite) TestWatchMachineErrorRetry(c *gc.C) {
 	c.Assert(result, gc.DeepEquals, params.NotifyWatchResult{})
 }
 
+func (s *withoutControllerSuite) TestWatchMachineCharmProfiles(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	result, err := s.provisioner.WatchMachineCharmProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Changes, gc.HasLen, 0)
+
+	// Verify the resources were registered and stop them when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get(result.StringsWatcherId)
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned"
+	// in the Watch call)
+	wc := statetesting.NewStringsWatcherC(c, s.State, resource.(state.StringsWatcher))
+	wc.AssertNoChange()
+
+	// Assigning a unit to a machine reports the machine.
+	application := s.AddTestingService(c, "lxd-profile", s.AddTestingCharm(c, "lxd-profile"))
+	unit, err := application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.machines[1])
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(s.machines[1].Id())
+	wc.AssertNoChange()
+}
+
 func (s *withoutControllerSuite) TestFindTools(c *gc.C) {
 	args := params.FindToolsParams{
 		MajorVersion: -1,
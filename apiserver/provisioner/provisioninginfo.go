@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
@@ -88,6 +89,10 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine) (*params.Provisio
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot get available image metadata")
 	}
+	charmLXDProfiles, err := p.machineCharmLXDProfiles(m)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot determine charm LXD profiles")
+	}
 
 	return &params.ProvisioningInfo{
 		Constraints:      cons,
@@ -99,6 +104,7 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine) (*params.Provisio
 		SubnetsToZones:   subnetsToZones,
 		EndpointBindings: endpointBindings,
 		ImageMetadata:    imageMetadata,
+		CharmLXDProfiles: charmLXDProfiles,
 	}, nil
 }
 
@@ -268,6 +274,44 @@ func (p *ProvisionerAPI) machineSubnetsAndZones(m *state.Machine) (map[string][]
 	return subnetsToZones, nil
 }
 
+// machineCharmLXDProfiles returns the LXD profiles declared by the
+// charms of the principal units assigned to the machine, keyed by the
+// name of the LXD profile to create for each.
+func (p *ProvisionerAPI) machineCharmLXDProfiles(m *state.Machine) (map[string]params.CharmLXDProfile, error) {
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var profiles map[string]params.CharmLXDProfile
+	for _, unit := range units {
+		if !unit.IsPrincipal() {
+			continue
+		}
+		application, err := unit.Application()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ch, _, err := application.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		profile := ch.LXDProfile()
+		if profile == nil || profile.Empty() {
+			continue
+		}
+		if profiles == nil {
+			profiles = make(map[string]params.CharmLXDProfile)
+		}
+		name := lxdprofile.Name(p.st.ModelUUID(), application.Name(), ch.Revision())
+		profiles[name] = params.CharmLXDProfile{
+			Description: profile.Description,
+			Config:      profile.Config,
+			Devices:     profile.Devices,
+		}
+	}
+	return profiles, nil
+}
+
 func (p *ProvisionerAPI) machineEndpointBindings(m *state.Machine) (map[string]string, error) {
 	units, err := m.Units()
 	if err != nil {
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/apiserver/provisioner"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
@@ -246,6 +247,42 @@ func (s *withoutControllerSuite) TestProvisioningInfoWithEndpointBindings(c *gc.
 	c.Assert(result, jc.DeepEquals, expected)
 }
 
+func (s *withoutControllerSuite) TestProvisioningInfoWithCharmLXDProfiles(c *gc.C) {
+	machine, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	profileCharm := s.AddTestingCharm(c, "lxd-profile")
+	profileService := s.AddTestingService(c, "lxd-profile", profileCharm)
+	profileUnit, err := profileService.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = profileUnit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: machine.Tag().String()},
+	}}
+	result, err := s.provisioner.ProvisioningInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+
+	profileName := lxdprofile.Name(s.State.ModelUUID(), "lxd-profile", profileCharm.Revision())
+	c.Assert(result.Results[0].Result.CharmLXDProfiles, jc.DeepEquals, map[string]params.CharmLXDProfile{
+		profileName: {
+			Description: "lxd profile for testing",
+			Config: map[string]string{
+				"security.nesting":     "true",
+				"linux.kernel_modules": "openvswitch,nbd,ip_tables",
+			},
+			Devices: map[string]map[string]string{
+				"tun": {"path": "/dev/net/tun", "type": "unix-char"},
+			},
+		},
+	})
+}
+
 func (s *withoutControllerSuite) TestProvisioningInfoWithUnsuitableSpacesConstraints(c *gc.C) {
 	// Add an empty space.
 	_, err := s.State.AddSpace("empty", "", nil, true)
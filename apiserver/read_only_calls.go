@@ -30,6 +30,7 @@ var readOnlyCalls = set.NewStrings(
 	"Client.APIHostPorts",
 	"Client.CharmInfo",
 	"Client.ModelGet",
+	"Client.ModelGetWithSource",
 	"Client.ModelInfo",
 	"Client.ModelUserInfo",
 	"Client.FullStatus",
@@ -50,6 +51,7 @@ var readOnlyCalls = set.NewStrings(
 	"Cloud.Credentials",
 	// TODO: add controller work.
 	"KeyManager.ListKeys",
+	"ModelManager.ModelDefaults",
 	"ModelManager.ModelInfo",
 	"Spaces.ListSpaces",
 	"Storage.ListStorageDetails",
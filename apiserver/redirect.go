@@ -0,0 +1,111 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+)
+
+// redirectRoot is served in place of the usual initial API root to
+// clients connecting to a model which has been migrated to another
+// controller. Login always fails with a redirect error; once the
+// client's credentials have been verified against this controller,
+// the details of the new controller may be retrieved with
+// RedirectInfo.
+type redirectRoot struct {
+	admin *redirectAdmin
+}
+
+func newRedirectRoot(srv *Server, redirect *common.RedirectError) *redirectRoot {
+	return &redirectRoot{
+		admin: &redirectAdmin{
+			srv:      srv,
+			redirect: redirect,
+		},
+	}
+}
+
+// FindMethod implements rpc.MethodFinder.
+func (r *redirectRoot) FindMethod(rootName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	if rootName != "Admin" {
+		return nil, &rpcreflect.CallNotImplementedError{
+			RootMethod: rootName,
+			Version:    version,
+		}
+	}
+	if version < 3 {
+		return nil, &rpc.RequestError{
+			Code:    params.CodeNotSupported,
+			Message: "this version of Juju does not support login from old clients",
+		}
+	}
+	return rpcreflect.ValueOf(reflect.ValueOf(r.admin)).FindMethod(rootName, 0, methodName)
+}
+
+// redirectAdmin implements the Admin facade for connections to a
+// migrated model.
+type redirectAdmin struct {
+	srv      *Server
+	redirect *common.RedirectError
+
+	mu            sync.Mutex
+	authenticated bool
+}
+
+// Admin returns an object that provides API access to methods that can be
+// called even when not authenticated.
+func (a *redirectAdmin) Admin(id string) (*redirectAdmin, error) {
+	if id != "" {
+		// Safeguard id for possible future use.
+		return nil, common.ErrBadId
+	}
+	return a, nil
+}
+
+// Login checks the provided credentials against the controller and,
+// if they are valid, fails with a redirect error.
+func (a *redirectAdmin) Login(req params.LoginRequest) (params.LoginResultV1, error) {
+	var fail params.LoginResultV1
+
+	st := a.srv.statePool.SystemState()
+	_, _, err := doCheckCreds(st, req, false, a.srv.authCtxt)
+	if err != nil {
+		if err, ok := errors.Cause(err).(*common.DischargeRequiredError); ok {
+			return params.LoginResultV1{
+				DischargeRequired:       err.Macaroon,
+				DischargeRequiredReason: err.Error(),
+			}, nil
+		}
+		return fail, errors.Trace(err)
+	}
+
+	a.mu.Lock()
+	a.authenticated = true
+	a.mu.Unlock()
+	return fail, a.redirect
+}
+
+// RedirectInfo returns the details of the controller the model was
+// migrated to. It may only be called after a Login call has
+// successfully authenticated the client.
+func (a *redirectAdmin) RedirectInfo() (params.RedirectInfoResult, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.authenticated {
+		return params.RedirectInfoResult{}, common.ErrPerm
+	}
+	return params.RedirectInfoResult{
+		ControllerTag: a.redirect.ControllerTag.String(),
+		Servers:       a.redirect.Servers,
+		CACert:        a.redirect.CACert,
+	}, nil
+}
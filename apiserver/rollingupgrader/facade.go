@@ -0,0 +1,77 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Backend exposes functionality required by Facade.
+type Backend interface {
+
+	// RollingUpgrades returns the names of the applications with a
+	// rolling charm upgrade in progress.
+	RollingUpgrades() ([]string, error)
+
+	// AdvanceRollingUpgrade releases the next batch of the named
+	// application's units to its new charm once the previous batch
+	// has settled, or halts the upgrade if the batch has failed.
+	AdvanceRollingUpgrade(name string) error
+}
+
+// Facade allows model-manager clients to drive rolling charm upgrades.
+type Facade struct {
+	backend Backend
+}
+
+// NewFacade creates a new authorized Facade.
+func NewFacade(backend Backend, _ *common.Resources, auth common.Authorizer) (*Facade, error) {
+	if !auth.AuthModelManager() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend: backend,
+	}, nil
+}
+
+// RollingUpgrades returns the names of the applications with a rolling
+// charm upgrade in progress.
+func (facade *Facade) RollingUpgrades() params.StringsResult {
+	names, err := facade.backend.RollingUpgrades()
+	if err != nil {
+		return params.StringsResult{Error: common.ServerError(err)}
+	}
+	return params.StringsResult{Result: names}
+}
+
+// Advance moves along the rolling charm upgrades of the supplied
+// applications.
+func (facade *Facade) Advance(args params.Entities) params.ErrorResults {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		err := facade.advanceOne(entity.Tag)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result
+}
+
+// advanceOne moves along the rolling charm upgrade of the supplied
+// application; or returns a suitable error.
+func (facade *Facade) advanceOne(tagString string) error {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	applicationTag, ok := tag.(names.ApplicationTag)
+	if !ok {
+		return common.ErrPerm
+	}
+	return facade.backend.AdvanceRollingUpgrade(applicationTag.Id())
+}
@@ -0,0 +1,74 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/rollingupgrader"
+)
+
+type FacadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FacadeSuite{})
+
+func (s *FacadeSuite) TestModelManager(c *gc.C) {
+	facade, err := rollingupgrader.NewFacade(nil, nil, auth(true))
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(facade, gc.NotNil)
+}
+
+func (s *FacadeSuite) TestNotModelManager(c *gc.C) {
+	facade, err := rollingupgrader.NewFacade(nil, nil, auth(false))
+	c.Check(err, gc.Equals, common.ErrPerm)
+	c.Check(facade, gc.IsNil)
+}
+
+func (s *FacadeSuite) TestRollingUpgradesError(c *gc.C) {
+	result := newFacade(c, false).RollingUpgrades()
+	c.Check(result.Error, gc.ErrorMatches, "blammo")
+	c.Check(result.Result, gc.HasLen, 0)
+}
+
+func (s *FacadeSuite) TestRollingUpgradesSuccess(c *gc.C) {
+	result := newFacade(c, true).RollingUpgrades()
+	c.Check(result.Error, gc.IsNil)
+	c.Check(result.Result, jc.DeepEquals, []string{"pow", "zap"})
+}
+
+func (s *FacadeSuite) TestAdvanceNonsense(c *gc.C) {
+	result := newFacade(c, true).Advance(entities("burble plink"))
+	c.Assert(result.Results, gc.HasLen, 1)
+	err := result.Results[0].Error
+	c.Check(err, gc.ErrorMatches, `"burble plink" is not a valid tag`)
+}
+
+func (s *FacadeSuite) TestAdvanceUnauthorized(c *gc.C) {
+	result := newFacade(c, true).Advance(entities("unit-foo-27"))
+	c.Assert(result.Results, gc.HasLen, 1)
+	err := result.Results[0].Error
+	c.Check(err, gc.ErrorMatches, "permission denied")
+	c.Check(err, jc.Satisfies, params.IsCodeUnauthorized)
+}
+
+func (s *FacadeSuite) TestAdvanceNotFound(c *gc.C) {
+	result := newFacade(c, true).Advance(entities("application-missing"))
+	c.Assert(result.Results, gc.HasLen, 1)
+	err := result.Results[0].Error
+	c.Check(err, gc.ErrorMatches, "application not found")
+	c.Check(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *FacadeSuite) TestAdvanceMultiple(c *gc.C) {
+	result := newFacade(c, true).Advance(entities("application-error", "application-expected"))
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Check(result.Results[0].Error, gc.ErrorMatches, "blammo")
+	c.Check(result.Results[1].Error, gc.IsNil)
+}
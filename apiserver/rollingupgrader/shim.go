@@ -0,0 +1,55 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+func init() {
+	common.RegisterStandardFacade("RollingUpgrader", 1, newFacade)
+}
+
+// newFacade wraps the supplied *state.State for the use of the Facade.
+func newFacade(st *state.State, res *common.Resources, auth common.Authorizer) (*Facade, error) {
+	return NewFacade(backendShim{st, clock.WallClock}, res, auth)
+}
+
+// backendShim wraps a *State to implement Backend without pulling in
+// direct mongodb dependencies.
+type backendShim struct {
+	st    *state.State
+	clock clock.Clock
+}
+
+// RollingUpgrades is part of the Backend interface.
+func (shim backendShim) RollingUpgrades() ([]string, error) {
+	applications, err := shim.st.RollingUpgradeApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := make([]string, len(applications))
+	for i, application := range applications {
+		names[i] = application.Name()
+	}
+	return names, nil
+}
+
+// AdvanceRollingUpgrade is part of the Backend interface.
+func (shim backendShim) AdvanceRollingUpgrade(name string) error {
+	application, err := shim.st.Application(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return application.AdvanceRollingUpgrade(shim.clock.Now())
+}
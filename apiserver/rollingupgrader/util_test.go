@@ -0,0 +1,68 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/rollingupgrader"
+)
+
+// mockAuth implements common.Authorizer for the tests' convenience.
+type mockAuth struct {
+	common.Authorizer
+	modelManager bool
+}
+
+func (mock mockAuth) AuthModelManager() bool {
+	return mock.modelManager
+}
+
+// auth is a convenience constructor for a mockAuth.
+func auth(modelManager bool) common.Authorizer {
+	return mockAuth{modelManager: modelManager}
+}
+
+// mockBackend implements rollingupgrader.Backend for the tests'
+// convenience.
+type mockBackend struct {
+	working bool
+}
+
+func (backend mockBackend) RollingUpgrades() ([]string, error) {
+	if !backend.working {
+		return nil, errors.New("blammo")
+	}
+	return []string{"pow", "zap"}, nil
+}
+
+func (mockBackend) AdvanceRollingUpgrade(name string) error {
+	switch name {
+	case "expected":
+		return nil
+	case "missing":
+		return errors.NotFoundf("application")
+	default:
+		return errors.New("blammo")
+	}
+}
+
+func newFacade(c *gc.C, working bool) *rollingupgrader.Facade {
+	facade, err := rollingupgrader.NewFacade(mockBackend{working}, nil, auth(true))
+	c.Assert(err, jc.ErrorIsNil)
+	return facade
+}
+
+// entities is a convenience constructor for params.Entities.
+func entities(tags ...string) params.Entities {
+	entities := params.Entities{Entities: make([]params.Entity, len(tags))}
+	for i, tag := range tags {
+		entities.Entities[i].Tag = tag
+	}
+	return entities
+}
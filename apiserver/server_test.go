@@ -10,6 +10,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -287,6 +288,55 @@ func (s *serverSuite) TestMinTLSVersion(c *gc.C) {
 	c.Assert(conn, gc.IsNil)
 }
 
+func (s *serverSuite) TestCustomAPICertificate(c *gc.C) {
+	caCert, caKey, err := cert.NewCA("custom", "custom-uuid", time.Now().Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	srvCert, srvKey, err := cert.NewDefaultServer(caCert, caKey, []string{"localhost"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetAPICertificate(srvCert, srvKey)
+	c.Assert(err, jc.ErrorIsNil)
+
+	srv := newServer(c, s.State)
+	defer srv.Stop()
+	addr := fmt.Sprintf("localhost:%d", srv.Addr().Port)
+
+	// Clients asking for the controller by name, as agents do, are
+	// still given the certificate signed by the controller CA.
+	err = tlsDial(addr, "juju-apiserver", coretesting.CACert)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Other clients are given the custom certificate once the
+	// server has picked it up.
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		err = tlsDial(addr, "localhost", caCert)
+		if err == nil {
+			break
+		}
+	}
+	c.Assert(err, jc.ErrorIsNil)
+	err = tlsDial(addr, "localhost", coretesting.CACert)
+	c.Assert(err, gc.ErrorMatches, ".*certificate signed by unknown authority.*")
+}
+
+// tlsDial makes a TLS connection to the given address, verifying the
+// server certificate for the given name against the given CA.
+func tlsDial(addr, serverName, caCert string) error {
+	pool := x509.NewCertPool()
+	xcert, err := cert.ParseCert(caCert)
+	if err != nil {
+		return err
+	}
+	pool.AddCert(xcert)
+	tlsConfig := utils.SecureTLSConfig()
+	tlsConfig.ServerName = serverName
+	tlsConfig.RootCAs = pool
+	conn, err := tls.Dial("tcp", addr, tlsConfig)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
 func (s *serverSuite) TestNonCompatiblePathsAre404(c *gc.C) {
 	// we expose the API at '/' for compatibility, and at '/ModelUUID/api'
 	// for the correct location, but other Paths should fail.
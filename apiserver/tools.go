@@ -10,7 +10,6 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
-	"net/url"
 	"strings"
 
 	"github.com/juju/errors"
@@ -28,7 +27,8 @@ import (
 
 // toolsHandler handles tool upload through HTTPS in the API server.
 type toolsUploadHandler struct {
-	ctxt httpContext
+	ctxt          httpContext
+	stateAuthFunc func(*http.Request) (*state.State, state.Entity, error)
 }
 
 // toolsHandler handles tool download through HTTPS in the API server.
@@ -60,7 +60,7 @@ func (h *toolsDownloadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 func (h *toolsUploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Validate before authenticate because the authentication is dependent
 	// on the state connection that is determined during the validation.
-	st, _, err := h.ctxt.stateForRequestAuthenticatedUser(r)
+	st, _, err := h.stateAuthFunc(r)
 	if err != nil {
 		sendError(w, err)
 		return
@@ -196,7 +196,7 @@ func (h *toolsUploadHandler) processPost(r *http.Request, st *state.State) (*too
 	}
 
 	// Get the server root, so we know how to form the URL in the Tools returned.
-	serverRoot, err := h.getServerRoot(r, query, st)
+	serverRoot, err := h.getServerRoot(r, st)
 	if err != nil {
 		return nil, errors.NewBadRequest(err, "cannot to determine server root")
 	}
@@ -220,16 +220,15 @@ func (h *toolsUploadHandler) processPost(r *http.Request, st *state.State) (*too
 	return h.handleUpload(r.Body, toolsVersions, serverRoot, st)
 }
 
-func (h *toolsUploadHandler) getServerRoot(r *http.Request, query url.Values, st *state.State) (string, error) {
-	uuid := query.Get(":modeluuid")
-	if uuid == "" {
-		env, err := st.Model()
-		if err != nil {
-			return "", err
-		}
-		uuid = env.UUID()
+func (h *toolsUploadHandler) getServerRoot(r *http.Request, st *state.State) (string, error) {
+	// The model the tools are uploaded to is not necessarily the one
+	// in the request URL (see stateForMigrationImporting), so use
+	// the model of the state instead.
+	model, err := st.Model()
+	if err != nil {
+		return "", err
 	}
-	return fmt.Sprintf("https://%s/model/%s", r.Host, uuid), nil
+	return fmt.Sprintf("https://%s/model/%s", r.Host, model.UUID()), nil
 }
 
 // handleUpload uploads the tools data from the reader to env storage as the specified version.
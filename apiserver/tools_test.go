@@ -201,6 +201,37 @@ func (s *toolsSuite) TestUpload(c *gc.C) {
 	c.Assert(allMetadata, jc.DeepEquals, []binarystorage.Metadata{metadata})
 }
 
+func (s *toolsSuite) TestMigrateTools(c *gc.C) {
+	importingSt := s.setupImportingModel(c)
+	expectedTools, v, toolPath := s.setupToolsForUpload(c)
+	vers := v.String()
+
+	uri := s.baseURL(c)
+	uri.Path = "/migrate/tools"
+	uri.RawQuery = "binaryVersion=" + vers
+	resp := s.migrateRequest(c, uri.String(), "application/x-tar-gz", toolPath, importingSt.ModelUUID())
+
+	// The tools must be stored in the importing model, not the
+	// controller model.
+	expectedTools[0].URL = fmt.Sprintf("%s/model/%s/tools/%s", s.baseURL(c), importingSt.ModelUUID(), vers)
+	s.assertUploadResponse(c, resp, expectedTools[0])
+	_, uploadedData := s.getToolsFromStorage(c, importingSt, vers)
+	expectedData, err := ioutil.ReadFile(toolPath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uploadedData, gc.DeepEquals, expectedData)
+	s.assertToolsNotStored(c, vers)
+}
+
+func (s *toolsSuite) TestMigrateToolsRequiresModelHeader(c *gc.C) {
+	_, v, toolPath := s.setupToolsForUpload(c)
+
+	uri := s.baseURL(c)
+	uri.Path = "/migrate/tools"
+	uri.RawQuery = "binaryVersion=" + v.String()
+	resp := s.migrateRequest(c, uri.String(), "application/x-tar-gz", toolPath, "")
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, "missing X-Juju-Migration-Model-UUID header")
+}
+
 func (s *toolsSuite) TestBlockUpload(c *gc.C) {
 	// Make some fake tools.
 	_, v, toolPath := s.setupToolsForUpload(c)
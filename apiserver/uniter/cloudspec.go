@@ -0,0 +1,95 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/state"
+)
+
+// auditTag adapts a tag string to audit.Tagger.
+type auditTag string
+
+// Tag implements audit.Tagger.
+func (t auditTag) Tag() string {
+	return string(t)
+}
+
+// CloudSpec returns the cloud, region and credential of the model, for
+// use by charms that manage cloud resources directly. Only units of
+// applications that have been trusted by an operator may see them, and
+// every successful call is audited.
+func (u *UniterAPIV3) CloudSpec() (params.CloudSpecResult, error) {
+	var result params.CloudSpecResult
+	application, err := u.unit.Application()
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result, nil
+	}
+	if !application.IsTrusted() {
+		result.Error = common.ServerError(common.ErrPerm)
+		return result, nil
+	}
+	spec, err := modelCloudSpec(u.st)
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result, nil
+	}
+	audit.Audit(auditTag(u.unit.Tag().String()), "read cloud credential of model %s", u.st.ModelUUID())
+	result.Result = spec
+	return result, nil
+}
+
+// modelCloudSpec returns the cloud, region and credential of the model
+// of the given state.
+func modelCloudSpec(st *state.State) (*params.CloudSpec, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cloud, err := st.Cloud()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spec := &params.CloudSpec{
+		Type:            cloud.Type,
+		Region:          model.CloudRegion(),
+		Endpoint:        cloud.Endpoint,
+		StorageEndpoint: cloud.StorageEndpoint,
+	}
+	for _, region := range cloud.Regions {
+		if region.Name != spec.Region {
+			continue
+		}
+		if region.Endpoint != "" {
+			spec.Endpoint = region.Endpoint
+		}
+		if region.StorageEndpoint != "" {
+			spec.StorageEndpoint = region.StorageEndpoint
+		}
+		break
+	}
+
+	credentialName := model.CloudCredential()
+	if credentialName == "" {
+		return spec, nil
+	}
+	credentials, err := st.CloudCredentials(model.Owner())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	credential, ok := credentials[credentialName]
+	if !ok {
+		return nil, errors.NotFoundf("cloud credential %q", credentialName)
+	}
+	spec.Credential = &params.CloudCredential{
+		AuthType:   string(credential.AuthType()),
+		Attributes: credential.Attributes(),
+	}
+	return spec, nil
+}
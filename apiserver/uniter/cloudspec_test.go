@@ -0,0 +1,29 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+)
+
+func (s *uniterSuite) TestCloudSpecUntrusted(c *gc.C) {
+	result, err := s.uniter.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Result, gc.IsNil)
+}
+
+func (s *uniterSuite) TestCloudSpecTrusted(c *gc.C) {
+	err := s.wordpress.SetTrust(true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, gc.NotNil)
+	c.Assert(result.Result.Type, gc.Equals, "dummy")
+}
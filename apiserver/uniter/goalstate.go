@@ -0,0 +1,101 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// GoalState returns the units that are expected to exist for the
+// unit's application, and for each application related to it. Charms
+// use this to tell whether more units are still to come, rather than
+// relying only on the units that have joined their relations so far.
+func (u *UniterAPIV3) GoalState() (params.GoalStateResult, error) {
+	var result params.GoalStateResult
+	goalState, err := u.goalState()
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result, nil
+	}
+	result.Result = goalState
+	return result, nil
+}
+
+func (u *UniterAPIV3) goalState() (*params.GoalState, error) {
+	application, err := u.unit.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := goalStateUnits(application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	relations, err := application.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	goalState := &params.GoalState{
+		Units:     units,
+		Relations: make(map[string]params.UnitsGoalState),
+	}
+	for _, relation := range relations {
+		endpoint, err := relation.Endpoint(application.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		relatedEndpoints, err := relation.RelatedEndpoints(application.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		relationUnits, ok := goalState.Relations[endpoint.Name]
+		if !ok {
+			relationUnits = make(params.UnitsGoalState)
+			goalState.Relations[endpoint.Name] = relationUnits
+		}
+		for _, related := range relatedEndpoints {
+			relatedApplication, err := u.st.Application(related.ApplicationName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			units, err := goalStateUnits(relatedApplication)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for name, unitStatus := range units {
+				relationUnits[name] = unitStatus
+			}
+		}
+	}
+	return goalState, nil
+}
+
+// goalStateUnits returns the goal state statuses of all the units of
+// the given application. Units that are on their way out are reported
+// as dying, whatever their workload status.
+func goalStateUnits(application *state.Application) (params.UnitsGoalState, error) {
+	units, err := application.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(params.UnitsGoalState)
+	for _, unit := range units {
+		if unit.Life() != state.Alive {
+			result[unit.Name()] = params.GoalStateStatus{Status: "dying"}
+			continue
+		}
+		info, err := unit.Status()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[unit.Name()] = params.GoalStateStatus{
+			Status: string(info.Status),
+			Since:  info.Since,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/status"
+	jujuFactory "github.com/juju/juju/testing/factory"
+)
+
+func (s *uniterSuite) TestGoalStateNoRelations(c *gc.C) {
+	result, err := s.uniter.GoalState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result.Relations, gc.HasLen, 0)
+	c.Assert(result.Result.Units, gc.HasLen, 1)
+	_, ok := result.Result.Units["wordpress/0"]
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *uniterSuite) TestGoalState(c *gc.C) {
+	s.addRelation(c, "wordpress", "mysql")
+	// A second mysql unit, not yet started or joined to any relation,
+	// must still be reported.
+	s.Factory.MakeUnit(c, &jujuFactory.UnitParams{
+		Application: s.mysql,
+	})
+	err := s.mysqlUnit.SetStatus(status.StatusInfo{Status: status.StatusActive})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.GoalState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result.Relations, gc.HasLen, 1)
+	units := result.Result.Relations["db"]
+	c.Assert(units, gc.HasLen, 2)
+	c.Assert(units["mysql/0"].Status, gc.Equals, "active")
+	_, ok := units["mysql/1"]
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *uniterSuite) TestGoalStateDyingUnit(c *gc.C) {
+	s.addRelation(c, "wordpress", "mysql")
+	// Once the agent has started, destroying the unit leaves it dying
+	// rather than removing it outright.
+	err := s.mysqlUnit.SetAgentStatus(status.StatusInfo{Status: status.StatusIdle})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysqlUnit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.GoalState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result.Relations["db"], jc.DeepEquals, params.UnitsGoalState{
+		"mysql/0": {Status: "dying"},
+	})
+}
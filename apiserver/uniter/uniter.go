@@ -575,7 +575,10 @@ func (u *UniterAPIV3) charmModifiedVersion(tagStr string, canAccess func(names.T
 	return service.CharmModifiedVersion(), nil
 }
 
-// CharmURL returns the charm URL for all given units or services.
+// CharmURL returns the charm URL for all given units or services. The
+// charm URL of a service is the one that the authenticated unit should
+// be running, which differs from the service's charm while a rolling
+// charm upgrade has yet to release the unit.
 func (u *UniterAPIV3) CharmURL(args params.Entities) (params.StringBoolResults, error) {
 	result := params.StringBoolResults{
 		Results: make([]params.StringBoolResult, len(args.Entities)),
@@ -596,10 +599,18 @@ func (u *UniterAPIV3) CharmURL(args params.Entities) (params.StringBoolResults,
 			var unitOrService state.Entity
 			unitOrService, err = u.st.FindEntity(tag)
 			if err == nil {
-				charmURLer := unitOrService.(interface {
-					CharmURL() (*charm.URL, bool)
-				})
-				curl, ok := charmURLer.CharmURL()
+				var curl *charm.URL
+				var ok bool
+				if service, isService := unitOrService.(*state.Application); isService {
+					// A unit that has not yet been released to a
+					// rolling charm upgrade keeps its previous charm.
+					curl, ok = service.CharmURLForUnit(u.auth.GetAuthTag().Id())
+				} else {
+					charmURLer := unitOrService.(interface {
+						CharmURL() (*charm.URL, bool)
+					})
+					curl, ok = charmURLer.CharmURL()
+				}
 				if curl != nil {
 					result.Results[i].Result = curl.String()
 					result.Results[i].Ok = ok
@@ -750,6 +750,41 @@ func (s *uniterSuite) TestCharmURL(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestCharmURLRollingUpgrade(c *gc.C) {
+	newCharm := s.Factory.MakeCharm(c, &jujuFactory.CharmParams{
+		Name: "wordpress",
+		URL:  "cs:quantal/wordpress-4",
+	})
+	err := s.wordpress.SetCharm(state.SetCharmConfig{
+		Charm:     newCharm,
+		BatchSize: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The authenticated unit has not been released to the upgrade,
+	// so it is told to keep the previous charm.
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.CharmURL(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringBoolResults{
+		Results: []params.StringBoolResult{
+			{Result: s.wpCharm.String(), Ok: false},
+		},
+	})
+
+	err = s.wordpress.AdvanceRollingUpgrade(time.Now())
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.CharmURL(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringBoolResults{
+		Results: []params.StringBoolResult{
+			{Result: newCharm.String(), Ok: false},
+		},
+	})
+}
+
 func (s *uniterSuite) TestSetCharmURL(c *gc.C) {
 	_, ok := s.wordpressUnit.CharmURL()
 	c.Assert(ok, jc.IsFalse)
@@ -0,0 +1,91 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+func (s *uniterSuite) lockMachine0ForSeriesUpgrade(c *gc.C) {
+	err := s.machine0.CreateUpgradeSeriesLock([]string{s.wordpressUnit.Name()}, "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *uniterSuite) TestUpgradeSeriesUnitStatus(c *gc.C) {
+	s.lockMachine0ForSeriesUpgrade(c)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.UpgradeSeriesUnitStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.UpgradeSeriesStatusResults{
+		Results: []params.UpgradeSeriesStatusResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Status: params.UpgradeSeriesPrepareStarted},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestSetUpgradeSeriesUnitStatus(c *gc.C) {
+	s.lockMachine0ForSeriesUpgrade(c)
+
+	args := params.UpgradeSeriesStatusParams{Params: []params.UpgradeSeriesStatusParam{
+		{Entity: params.Entity{Tag: "unit-mysql-0"}, Status: params.UpgradeSeriesPrepareCompleted},
+		{Entity: params.Entity{Tag: "unit-wordpress-0"}, Status: params.UpgradeSeriesPrepareCompleted},
+		{Entity: params.Entity{Tag: "unit-foo-42"}, Status: params.UpgradeSeriesPrepareCompleted},
+	}}
+	result, err := s.uniter.SetUpgradeSeriesUnitStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	status, err := s.wordpressUnit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, state.UpgradeSeriesPrepareCompleted)
+}
+
+func (s *uniterSuite) TestWatchUpgradeSeriesNotifications(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.WatchUpgradeSeriesNotifications(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resource was registered and stop when done
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	s.lockMachine0ForSeriesUpgrade(c)
+	wc.AssertOneChange()
+}
@@ -104,16 +104,18 @@ func (u *UpgraderAPI) WatchAPIVersion(args params.Entities) (params.NotifyWatchR
 		}
 		err = common.ErrPerm
 		if u.authorizer.AuthOwner(tag) {
-			watch := u.st.WatchForModelConfigChanges()
-			// Consume the initial event. Technically, API
-			// calls to Watch 'transmit' the initial event
-			// in the Watch response. But NotifyWatchers
-			// have no state to transmit.
-			if _, ok := <-watch.Changes(); ok {
-				result.Results[i].NotifyWatcherId = u.resources.Register(watch)
-				err = nil
-			} else {
-				err = watcher.EnsureErr(watch)
+			var watch state.NotifyWatcher
+			watch, err = u.watchAgentVersion()
+			if err == nil {
+				// Consume the initial event. Technically, API
+				// calls to Watch 'transmit' the initial event
+				// in the Watch response. But NotifyWatchers
+				// have no state to transmit.
+				if _, ok := <-watch.Changes(); ok {
+					result.Results[i].NotifyWatcherId = u.resources.Register(watch)
+				} else {
+					err = watcher.EnsureErr(watch)
+				}
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -121,6 +123,19 @@ func (u *UpgraderAPI) WatchAPIVersion(args params.Entities) (params.NotifyWatchR
 	return result, nil
 }
 
+// watchAgentVersion returns a watcher that notifies when either the
+// model's agent version or its canary upgrade may have changed.
+func (u *UpgraderAPI) watchAgentVersion() (state.NotifyWatcher, error) {
+	model, err := u.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.NewMultiNotifyWatcher(
+		u.st.WatchForModelConfigChanges(),
+		model.Watch(),
+	), nil
+}
+
 func (u *UpgraderAPI) getGlobalAgentVersion() (version.Number, *config.Config, error) {
 	// Get the Agent Version requested in the Environment Config
 	cfg, err := u.st.ModelConfig()
@@ -156,12 +171,14 @@ func (u *UpgraderAPI) DesiredVersion(args params.Entities) (params.VersionResult
 	if len(args.Entities) == 0 {
 		return params.VersionResults{}, nil
 	}
-	agentVersion, _, err := u.getGlobalAgentVersion()
+	modelVersion, _, err := u.getGlobalAgentVersion()
+	if err != nil {
+		return params.VersionResults{}, common.ServerError(err)
+	}
+	model, err := u.st.Model()
 	if err != nil {
 		return params.VersionResults{}, common.ServerError(err)
 	}
-	// Is the desired version greater than the current API server version?
-	isNewerVersion := agentVersion.Compare(jujuversion.Current) > 0
 	for i, entity := range args.Entities {
 		tag, err := names.ParseTag(entity.Tag)
 		if err != nil {
@@ -170,6 +187,14 @@ func (u *UpgraderAPI) DesiredVersion(args params.Entities) (params.VersionResult
 		}
 		err = common.ErrPerm
 		if u.authorizer.AuthOwner(tag) {
+			// Machines taking part in a canary upgrade run the
+			// canary version ahead of the rest of the model.
+			agentVersion := modelVersion
+			if machineTag, ok := tag.(names.MachineTag); ok {
+				agentVersion = model.AgentVersionForMachine(machineTag.Id(), modelVersion)
+			}
+			// Is the desired version greater than the current API server version?
+			isNewerVersion := agentVersion.Compare(jujuversion.Current) > 0
 			// Only return the globally desired agent version if the
 			// asking entity is a machine agent with JobManageModel or
 			// if this API server is running the globally desired agent
@@ -99,6 +99,30 @@ func (s *upgraderSuite) TestWatchAPIVersion(c *gc.C) {
 	wc.AssertClosed()
 }
 
+func (s *upgraderSuite) TestWatchAPIVersionNoticesCanary(c *gc.C) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}},
+	}
+	results, err := s.upgrader.WatchAPIVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	resource := s.resources.Get(results.Results[0].NotifyWatcherId)
+	c.Assert(resource, gc.NotNil)
+
+	w := resource.(state.NotifyWatcher)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertNoChange()
+
+	newer := jujuversion.Current
+	newer.Patch++
+	err = s.State.SetModelAgentCanary(newer, []string{s.rawMachine.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	statetesting.AssertStop(c, w)
+	wc.AssertClosed()
+}
+
 func (s *upgraderSuite) TestUpgraderAPIRefusesNonMachineAgent(c *gc.C) {
 	anAuthorizer := s.authorizer
 	anAuthorizer.Tag = names.NewUnitTag("ubuntu/1")
@@ -289,6 +313,31 @@ func (s *upgraderSuite) TestDesiredVersionForAgent(c *gc.C) {
 	c.Check(*agentVersion, gc.DeepEquals, jujuversion.Current)
 }
 
+func (s *upgraderSuite) TestDesiredVersionForCanaryAgent(c *gc.C) {
+	older := jujuversion.Current
+	older.Patch--
+	err := statetesting.SetAgentVersion(s.State, older)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetModelAgentCanary(jujuversion.Current, []string{s.rawMachine.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}}}
+	results, err := s.upgrader.DesiredVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Version, gc.NotNil)
+	c.Check(*results.Results[0].Version, gc.Equals, jujuversion.Current)
+
+	err = s.State.AbortModelAgentCanary()
+	c.Assert(err, jc.ErrorIsNil)
+	results, err = s.upgrader.DesiredVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Version, gc.NotNil)
+	c.Check(*results.Results[0].Version, gc.Equals, older)
+}
+
 func (s *upgraderSuite) bumpDesiredAgentVersion(c *gc.C) version.Number {
 	// In order to call SetModelAgentVersion we have to first SetTools on
 	// all the existing machines
@@ -0,0 +1,167 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+func init() {
+	common.RegisterStandardFacade("UpgradeSeries", 1, NewAPI)
+}
+
+// API provides the machine agent's view of an in-place series upgrade
+// of its machine.
+type API struct {
+	st         *state.State
+	resources  *common.Resources
+	authorizer common.Authorizer
+}
+
+// NewAPI creates a new server-side UpgradeSeries API facade.
+func NewAPI(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*API, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		st:         st,
+		resources:  resources,
+		authorizer: authorizer,
+	}, nil
+}
+
+// getMachine returns the machine identified by the given tag, if the
+// authenticated agent is allowed to access it.
+func (a *API) getMachine(tag string) (*state.Machine, error) {
+	machineTag, err := names.ParseMachineTag(tag)
+	if err != nil {
+		return nil, common.ErrPerm
+	}
+	if !a.authorizer.AuthOwner(machineTag) {
+		return nil, common.ErrPerm
+	}
+	return a.st.Machine(machineTag.Id())
+}
+
+// MachineStatus returns the progress of each given machine through a
+// series upgrade.
+func (a *API) MachineStatus(args params.Entities) (params.UpgradeSeriesStatusResults, error) {
+	result := params.UpgradeSeriesStatusResults{
+		Results: make([]params.UpgradeSeriesStatusResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		machine, err := a.getMachine(entity.Tag)
+		if err == nil {
+			var status state.UpgradeSeriesStatus
+			status, err = machine.UpgradeSeriesStatus()
+			result.Results[i].Status = params.UpgradeSeriesStatus(status)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// SetMachineStatus records the progress of each given machine through
+// a series upgrade.
+func (a *API) SetMachineStatus(args params.UpgradeSeriesStatusParams) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Params)),
+	}
+	for i, p := range args.Params {
+		machine, err := a.getMachine(p.Entity.Tag)
+		if err == nil {
+			err = machine.SetUpgradeSeriesStatus(state.UpgradeSeriesStatus(p.Status))
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// TargetSeries returns the series each given machine is being
+// upgraded to.
+func (a *API) TargetSeries(args params.Entities) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		machine, err := a.getMachine(entity.Tag)
+		if err == nil {
+			result.Results[i].Result, err = machine.UpgradeSeriesTarget()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// UnitsStatuses returns the progress through a series upgrade of each
+// unit on each given machine.
+func (a *API) UnitsStatuses(args params.Entities) (params.UpgradeSeriesUnitStatusesResults, error) {
+	result := params.UpgradeSeriesUnitStatusesResults{
+		Results: make([]params.UpgradeSeriesUnitStatusesResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		machine, err := a.getMachine(entity.Tag)
+		if err == nil {
+			var statuses map[string]state.UpgradeSeriesStatus
+			statuses, err = machine.UpgradeSeriesUnitStatuses()
+			if err == nil {
+				result.Results[i].Statuses = make(map[string]params.UpgradeSeriesStatus)
+				for unitName, status := range statuses {
+					result.Results[i].Statuses[unitName] = params.UpgradeSeriesStatus(status)
+				}
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// FinishUpgradeSeries records the series each given machine is now
+// running, and releases its upgrade-series lock.
+func (a *API) FinishUpgradeSeries(args params.UpdateSeriesArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		machine, err := a.getMachine(arg.Entity.Tag)
+		if err == nil {
+			err = machine.UpdateMachineSeries(arg.Series)
+		}
+		if err == nil {
+			err = machine.RemoveUpgradeSeriesLock()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// WatchUpgradeSeriesNotifications returns a NotifyWatcher for each
+// given machine, which fires whenever its upgrade-series lock changes.
+func (a *API) WatchUpgradeSeriesNotifications(args params.Entities) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		machine, err := a.getMachine(entity.Tag)
+		if err == nil {
+			watch := machine.WatchUpgradeSeriesNotifications()
+			// Consume the initial event. Technically, API
+			// calls to Watch 'transmit' the initial event
+			// in the Watch response. But NotifyWatchers
+			// have no state to transmit.
+			if _, ok := <-watch.Changes(); ok {
+				result.Results[i].NotifyWatcherId = a.resources.Register(watch)
+			} else {
+				err = watcher.EnsureErr(watch)
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/apiserver/upgradeseries"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type upgradeSeriesSuite struct {
+	jujutesting.JujuConnSuite
+
+	machine   *state.Machine
+	unit      *state.Unit
+	resources *common.Resources
+	api       *upgradeseries.API
+	args      params.Entities
+}
+
+var _ = gc.Suite(&upgradeSeriesSuite{})
+
+func (s *upgradeSeriesSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+
+	s.machine = s.Factory.MakeMachine(c, nil)
+	s.unit = s.Factory.MakeUnit(c, &factory.UnitParams{Machine: s.machine})
+	err := s.machine.CreateUpgradeSeriesLock([]string{s.unit.Name()}, "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.resources = common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
+
+	authorizer := apiservertesting.FakeAuthorizer{Tag: s.machine.Tag()}
+	s.api, err = upgradeseries.NewAPI(s.State, s.resources, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.args = params.Entities{Entities: []params.Entity{
+		{Tag: s.machine.Tag().String()},
+		{Tag: "machine-42"},
+		{Tag: "unit-foo-0"},
+	}}
+}
+
+func (s *upgradeSeriesSuite) TestNewAPIRefusesNonMachineAgent(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{Tag: s.unit.Tag()}
+	_, err := upgradeseries.NewAPI(s.State, s.resources, authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *upgradeSeriesSuite) TestMachineStatus(c *gc.C) {
+	result, err := s.api.MachineStatus(s.args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.UpgradeSeriesStatusResults{
+		Results: []params.UpgradeSeriesStatusResult{
+			{Status: params.UpgradeSeriesPrepareStarted},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *upgradeSeriesSuite) TestSetMachineStatus(c *gc.C) {
+	result, err := s.api.SetMachineStatus(params.UpgradeSeriesStatusParams{
+		Params: []params.UpgradeSeriesStatusParam{
+			{Entity: s.args.Entities[0], Status: params.UpgradeSeriesPrepareCompleted},
+			{Entity: s.args.Entities[1], Status: params.UpgradeSeriesPrepareCompleted},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	status, err := s.machine.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, state.UpgradeSeriesPrepareCompleted)
+}
+
+func (s *upgradeSeriesSuite) TestTargetSeries(c *gc.C) {
+	result, err := s.api.TargetSeries(s.args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Result: "xenial"},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *upgradeSeriesSuite) TestUnitsStatuses(c *gc.C) {
+	result, err := s.api.UnitsStatuses(s.args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.UpgradeSeriesUnitStatusesResults{
+		Results: []params.UpgradeSeriesUnitStatusesResult{
+			{Statuses: map[string]params.UpgradeSeriesStatus{
+				s.unit.Name(): params.UpgradeSeriesPrepareStarted,
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *upgradeSeriesSuite) TestFinishUpgradeSeries(c *gc.C) {
+	result, err := s.api.FinishUpgradeSeries(params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{
+			{Entity: s.args.Entities[0], Series: "xenial"},
+			{Entity: s.args.Entities[1], Series: "xenial"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.Series(), gc.Equals, "xenial")
+	locked, err := s.machine.IsLockedForSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsFalse)
+}
+
+func (s *upgradeSeriesSuite) TestWatchUpgradeSeriesNotifications(c *gc.C) {
+	result, err := s.api.WatchUpgradeSeriesNotifications(s.args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	err = s.machine.SetUpgradeSeriesStatus(state.UpgradeSeriesPrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
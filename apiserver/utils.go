@@ -68,7 +68,21 @@ func validateModelUUID(args validateArgs) (string, error) {
 		return "", errors.Trace(common.UnknownModelError(args.modelUUID))
 	}
 	modelTag := names.NewModelTag(args.modelUUID)
-	if _, err := ssState.GetModel(modelTag); err != nil {
+	if _, err := ssState.GetModel(modelTag); errors.IsNotFound(err) {
+		// The model may have been migrated to another controller,
+		// in which case the client should be sent there.
+		redirect, rerr := ssState.ModelRedirect(args.modelUUID)
+		if rerr == nil {
+			return "", &common.RedirectError{
+				ControllerTag: names.NewModelTag(redirect.ControllerUUID),
+				Servers:       redirect.Addrs,
+				CACert:        redirect.CACert,
+			}
+		} else if !errors.IsNotFound(rerr) {
+			return "", errors.Trace(rerr)
+		}
+		return "", errors.Wrap(err, common.UnknownModelError(args.modelUUID))
+	} else if err != nil {
 		return "", errors.Wrap(err, common.UnknownModelError(args.modelUUID))
 	}
 	logger.Debugf("validate model uuid: %s", args.modelUUID)
@@ -4,6 +4,9 @@
 package cert
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha1"
@@ -19,8 +22,29 @@ import (
 	"github.com/juju/errors"
 )
 
+// KeyBits is the size of generated RSA keys.
 var KeyBits = 2048
 
+// KeyType identifies the type of key used for a certificate.
+type KeyType string
+
+const (
+	// RSA keys are KeyBits long.
+	RSA KeyType = "rsa"
+
+	// ECDSA keys use the NIST P-256 curve.
+	ECDSA KeyType = "ecdsa"
+)
+
+// Validate returns an error if the key type is not supported.
+func (t KeyType) Validate() error {
+	switch t {
+	case RSA, ECDSA:
+		return nil
+	}
+	return errors.NotValidf("key type %q", string(t))
+}
+
 // ParseCert parses the given PEM-formatted X509 certificate.
 func ParseCert(certPEM string) (*x509.Certificate, error) {
 	certPEMData := []byte(certPEM)
@@ -38,9 +62,35 @@ func ParseCert(certPEM string) (*x509.Certificate, error) {
 	return nil, errors.New("no certificates found")
 }
 
+// ParseCerts parses all the PEM-formatted X509 certificates in the
+// given data, such as a bundle of trusted CA certificates.
+func ParseCerts(certsPEM string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	certPEMData := []byte(certsPEM)
+	for len(certPEMData) > 0 {
+		var certBlock *pem.Block
+		certBlock, certPEMData = pem.Decode(certPEMData)
+		if certBlock == nil {
+			break
+		}
+		if certBlock.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(certBlock.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no certificates found")
+	}
+	return certs, nil
+}
+
 // ParseCertAndKey parses the given PEM-formatted X509 certificate
-// and RSA private key.
-func ParseCertAndKey(certPEM, keyPEM string) (*x509.Certificate, *rsa.PrivateKey, error) {
+// and RSA or ECDSA private key.
+func ParseCertAndKey(certPEM, keyPEM string) (*x509.Certificate, crypto.Signer, error) {
 	tlsCert, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
 	if err != nil {
 		return nil, nil, err
@@ -51,11 +101,14 @@ func ParseCertAndKey(certPEM, keyPEM string) (*x509.Certificate, *rsa.PrivateKey
 		return nil, nil, err
 	}
 
-	key, ok := tlsCert.PrivateKey.(*rsa.PrivateKey)
-	if !ok {
+	switch key := tlsCert.PrivateKey.(type) {
+	case *rsa.PrivateKey:
+		return cert, key, nil
+	case *ecdsa.PrivateKey:
+		return cert, key, nil
+	default:
 		return nil, nil, fmt.Errorf("private key with unexpected type %T", key)
 	}
-	return cert, key, nil
 }
 
 // Verify verifies that the given server certificate is valid with
@@ -81,9 +134,17 @@ func Verify(srvCertPEM, caCertPEM string, when time.Time) error {
 }
 
 // NewCA generates a CA certificate/key pair suitable for signing server
-// keys for an environment with the given name.
+// keys for an environment with the given name. The CA has an RSA key.
 func NewCA(envName, UUID string, expiry time.Time) (certPEM, keyPEM string, err error) {
-	key, err := rsa.GenerateKey(rand.Reader, KeyBits)
+	return NewCAWithKeyType(envName, UUID, expiry, RSA)
+}
+
+// NewCAWithKeyType generates a CA certificate/key pair suitable for
+// signing server keys for an environment with the given name, using a
+// key of the given type. Leaf certificates signed by the CA have keys
+// of the same type.
+func NewCAWithKeyType(envName, UUID string, expiry time.Time, keyType KeyType) (certPEM, keyPEM string, err error) {
+	key, err := generateKey(keyType)
 	if err != nil {
 		return "", "", err
 	}
@@ -106,13 +167,13 @@ func NewCA(envName, UUID string, expiry time.Time) (certPEM, keyPEM string, err
 		},
 		NotBefore:             now.UTC().AddDate(0, 0, -7),
 		NotAfter:              expiry.UTC(),
-		SubjectKeyId:          bigIntHash(key.N),
-		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		SubjectKeyId:          subjectKeyId(key.Public()),
+		KeyUsage:              keyUsage(key) | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
 		IsCA:                  true,
 		MaxPathLen:            0, // Disallow delegation for now.
 		BasicConstraintsValid: true,
 	}
-	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
 	if err != nil {
 		return "", "", fmt.Errorf("cannot create certificate: %v", err)
 	}
@@ -120,11 +181,11 @@ func NewCA(envName, UUID string, expiry time.Time) (certPEM, keyPEM string, err
 		Type:  "CERTIFICATE",
 		Bytes: certDER,
 	})
-	keyPEMData := pem.EncodeToMemory(&pem.Block{
-		Type:  "RSA PRIVATE KEY",
-		Bytes: x509.MarshalPKCS1PrivateKey(key),
-	})
-	return string(certPEMData), string(keyPEMData), nil
+	keyPEMData, err := encodeKey(key)
+	if err != nil {
+		return "", "", err
+	}
+	return string(certPEMData), keyPEMData, nil
 }
 
 // NewServer generates a certificate/key pair suitable for use by a server, with an
@@ -156,11 +217,15 @@ func newLeaf(caCertPEM, caKeyPEM string, expiry time.Time, hostnames []string, e
 	if !caCert.BasicConstraintsValid || !caCert.IsCA {
 		return "", "", errors.Errorf("CA certificate is not a valid CA")
 	}
-	caKey, ok := tlsCert.PrivateKey.(*rsa.PrivateKey)
+	caKey, ok := tlsCert.PrivateKey.(crypto.Signer)
 	if !ok {
 		return "", "", errors.Errorf("CA private key has unexpected type %T", tlsCert.PrivateKey)
 	}
-	key, err := rsa.GenerateKey(rand.Reader, KeyBits)
+	keyType, err := keyTypeOf(caKey)
+	if err != nil {
+		return "", "", errors.Annotate(err, "CA private key")
+	}
+	key, err := generateKey(keyType)
 	if err != nil {
 		return "", "", errors.Errorf("cannot generate key: %v", err)
 	}
@@ -177,8 +242,8 @@ func newLeaf(caCertPEM, caKeyPEM string, expiry time.Time, hostnames []string, e
 		NotBefore: now.UTC().AddDate(0, 0, -7),
 		NotAfter:  expiry.UTC(),
 
-		SubjectKeyId: bigIntHash(key.N),
-		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageKeyAgreement,
+		SubjectKeyId: subjectKeyId(key.Public()),
+		KeyUsage:     keyUsage(key) | x509.KeyUsageDigitalSignature | x509.KeyUsageKeyAgreement,
 		ExtKeyUsage:  extKeyUsage,
 	}
 	for _, hostname := range hostnames {
@@ -188,7 +253,7 @@ func newLeaf(caCertPEM, caKeyPEM string, expiry time.Time, hostnames []string, e
 			template.DNSNames = append(template.DNSNames, hostname)
 		}
 	}
-	certDER, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	certDER, err := x509.CreateCertificate(rand.Reader, template, caCert, key.Public(), caKey)
 	if err != nil {
 		return "", "", err
 	}
@@ -196,11 +261,79 @@ func newLeaf(caCertPEM, caKeyPEM string, expiry time.Time, hostnames []string, e
 		Type:  "CERTIFICATE",
 		Bytes: certDER,
 	})
-	keyPEMData := pem.EncodeToMemory(&pem.Block{
-		Type:  "RSA PRIVATE KEY",
-		Bytes: x509.MarshalPKCS1PrivateKey(key),
-	})
-	return string(certPEMData), string(keyPEMData), nil
+	keyPEMData, err := encodeKey(key)
+	if err != nil {
+		return "", "", err
+	}
+	return string(certPEMData), keyPEMData, nil
+}
+
+// generateKey generates a private key of the given type.
+func generateKey(keyType KeyType) (crypto.Signer, error) {
+	switch keyType {
+	case RSA:
+		return rsa.GenerateKey(rand.Reader, KeyBits)
+	case ECDSA:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	}
+	return nil, errors.NotValidf("key type %q", string(keyType))
+}
+
+// keyTypeOf returns the type of the given private key.
+func keyTypeOf(key crypto.Signer) (KeyType, error) {
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		return RSA, nil
+	case *ecdsa.PrivateKey:
+		return ECDSA, nil
+	}
+	return "", errors.Errorf("unexpected key type %T", key)
+}
+
+// keyUsage returns the key usages that depend on the type of the
+// given key: only RSA keys can be used for key encipherment.
+func keyUsage(key crypto.Signer) x509.KeyUsage {
+	if _, ok := key.(*rsa.PrivateKey); ok {
+		return x509.KeyUsageKeyEncipherment
+	}
+	return 0
+}
+
+// encodeKey returns the given private key in PEM format.
+func encodeKey(key crypto.Signer) (string, error) {
+	var block *pem.Block
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		block = &pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		}
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return "", errors.Annotate(err, "cannot marshal private key")
+		}
+		block = &pem.Block{
+			Type:  "EC PRIVATE KEY",
+			Bytes: der,
+		}
+	default:
+		return "", errors.Errorf("unexpected key type %T", key)
+	}
+	return string(pem.EncodeToMemory(block)), nil
+}
+
+// subjectKeyId returns an identifier for the given public key.
+func subjectKeyId(pub crypto.PublicKey) []byte {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return bigIntHash(pub.N)
+	case *ecdsa.PublicKey:
+		h := sha1.New()
+		h.Write(elliptic.Marshal(pub.Curve, pub.X, pub.Y))
+		return h.Sum(nil)
+	}
+	return nil
 }
 
 func bigIntHash(n *big.Int) []byte {
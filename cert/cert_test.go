@@ -5,6 +5,8 @@ package cert_test
 
 import (
 	"bytes"
+	"crypto"
+	"crypto/ecdsa"
 	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
@@ -57,13 +59,28 @@ func (certSuite) TestParseCertificate(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "no certificates found")
 }
 
+func (certSuite) TestParseCerts(c *gc.C) {
+	otherCertPEM, _, err := cert.NewCA("other", "1", time.Now().Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+
+	xcerts, err := cert.ParseCerts(caCertPEM + caKeyPEM + otherCertPEM)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(xcerts, gc.HasLen, 2)
+	c.Assert(xcerts[0].Subject.CommonName, gc.Equals, "juju testing")
+	c.Assert(xcerts[1].Subject.CommonName, gc.Equals, "juju-generated CA for model \"other\"")
+
+	xcerts, err = cert.ParseCerts(caKeyPEM)
+	c.Check(xcerts, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "no certificates found")
+}
+
 func (certSuite) TestParseCertAndKey(c *gc.C) {
 	xcert, key, err := cert.ParseCertAndKey(caCertPEM, caKeyPEM)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(xcert.Subject.CommonName, gc.Equals, "juju testing")
 	c.Assert(key, gc.NotNil)
 
-	c.Assert(xcert.PublicKey.(*rsa.PublicKey), gc.DeepEquals, &key.PublicKey)
+	c.Assert(xcert.PublicKey, gc.DeepEquals, key.Public())
 }
 
 func (certSuite) TestNewCA(c *gc.C) {
@@ -84,6 +101,42 @@ func (certSuite) TestNewCA(c *gc.C) {
 	//c.Assert(caCert.MaxPathLen, Equals, 0)	TODO it ends up as -1 - check that this is ok.
 }
 
+func (certSuite) TestNewCAWithKeyTypeECDSA(c *gc.C) {
+	now := time.Now()
+	expiry := roundTime(now.AddDate(0, 0, 1))
+	caCertPEM, caKeyPEM, err := cert.NewCAWithKeyType("foo", "1", expiry, cert.ECDSA)
+	c.Assert(err, jc.ErrorIsNil)
+
+	caCert, caKey, err := cert.ParseCertAndKey(caCertPEM, caKeyPEM)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(caKey, gc.FitsTypeOf, (*ecdsa.PrivateKey)(nil))
+	c.Check(caCert.Subject.CommonName, gc.Equals, `juju-generated CA for model "foo"`)
+	checkNotBefore(c, caCert, now)
+	checkNotAfter(c, caCert, expiry)
+	c.Check(caCert.IsCA, jc.IsTrue)
+	c.Check(caCert.KeyUsage&x509.KeyUsageKeyEncipherment, gc.Equals, x509.KeyUsage(0))
+
+	// Leaf certificates have keys of the same type as the CA's.
+	srvCertPEM, srvKeyPEM, err := cert.NewServer(caCertPEM, caKeyPEM, expiry, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, srvKey, err := cert.ParseCertAndKey(srvCertPEM, srvKeyPEM)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(srvKey, gc.FitsTypeOf, (*ecdsa.PrivateKey)(nil))
+	checkCertificate(c, caCert, srvCertPEM, srvKeyPEM, now, expiry)
+}
+
+func (certSuite) TestNewCAWithKeyTypeInvalid(c *gc.C) {
+	_, _, err := cert.NewCAWithKeyType("foo", "1", time.Now(), "dsa")
+	c.Assert(err, gc.ErrorMatches, `key type "dsa" not valid`)
+}
+
+func (certSuite) TestKeyTypeValidate(c *gc.C) {
+	c.Assert(cert.RSA.Validate(), jc.ErrorIsNil)
+	c.Assert(cert.ECDSA.Validate(), jc.ErrorIsNil)
+	c.Assert(cert.KeyType("dsa").Validate(), gc.ErrorMatches, `key type "dsa" not valid`)
+}
+
 func (certSuite) TestNewServer(c *gc.C) {
 	now := time.Now()
 	expiry := roundTime(now.AddDate(1, 0, 0))
@@ -222,7 +275,7 @@ func (certSuite) TestVerify(c *gc.C) {
 
 // checkTLSConnection checks that we can correctly perform a TLS
 // handshake using the given credentials.
-func checkTLSConnection(c *gc.C, caCert, srvCert *x509.Certificate, srvKey *rsa.PrivateKey) (caName string) {
+func checkTLSConnection(c *gc.C, caCert, srvCert *x509.Certificate, srvKey crypto.Signer) (caName string) {
 	clientCertPool := x509.NewCertPool()
 	clientCertPool.AddCert(caCert)
 
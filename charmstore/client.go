@@ -249,6 +249,41 @@ func (c Client) listResources(ch CharmID) ([]charmresource.Resource, error) {
 	return api2resources(resources)
 }
 
+// ArchiveData holds a charm or bundle archive downloaded from the
+// charmstore.
+type ArchiveData struct {
+	// ReadCloser holds the bytes of the archive.
+	io.ReadCloser
+
+	// URL is the fully resolved URL of the charm or bundle.
+	URL *charm.URL
+
+	// Hash is the SHA384 hash of the archive.
+	Hash string
+
+	// Size is the size of the archive in bytes.
+	Size int64
+}
+
+// GetArchive downloads the archive of the given charm or bundle from the
+// charmstore, resolving its URL.
+func (c Client) GetArchive(id CharmID) (ArchiveData, error) {
+	if err := c.jar.Activate(id.URL); err != nil {
+		return ArchiveData{}, errors.Trace(err)
+	}
+	defer c.jar.Deactivate()
+	r, curl, hash, size, err := c.csWrapper.GetArchive(id.Channel, id.URL)
+	if err != nil {
+		return ArchiveData{}, errors.Trace(err)
+	}
+	return ArchiveData{
+		ReadCloser: r,
+		URL:        curl,
+		Hash:       hash,
+		Size:       size,
+	}, nil
+}
+
 // csWrapper is a type that abstracts away the low-level implementation details
 // of the charmstore client.
 type csWrapper interface {
@@ -256,6 +291,7 @@ type csWrapper interface {
 	ListResources(channel csparams.Channel, id *charm.URL) ([]csparams.Resource, error)
 	GetResource(channel csparams.Channel, id *charm.URL, name string, revision int) (csclient.ResourceData, error)
 	ResourceMeta(channel csparams.Channel, id *charm.URL, name string, revision int) (csparams.Resource, error)
+	GetArchive(channel csparams.Channel, id *charm.URL) (io.ReadCloser, *charm.URL, string, int64, error)
 	ServerURL() string
 }
 
@@ -291,6 +327,12 @@ func (c csclientImpl) ResourceMeta(channel csparams.Channel, id *charm.URL, name
 	return client.ResourceMeta(id, name, revision)
 }
 
+// GetArchive downloads the archive of the charm or bundle on the channel.
+func (c csclientImpl) GetArchive(channel csparams.Channel, id *charm.URL) (io.ReadCloser, *charm.URL, string, int64, error) {
+	client := c.WithChannel(channel)
+	return client.GetArchive(id)
+}
+
 func api2resources(res []csparams.Resource) ([]charmresource.Resource, error) {
 	result := make([]charmresource.Resource, len(res))
 	for i, r := range res {
@@ -240,3 +240,26 @@ func (s *ClientSuite) TestResourceInfo(c *gc.C) {
 	// call #0 is a call to makeWrapper
 	s.wrapper.stub.CheckCall(c, 1, "ResourceMeta", params.StableChannel, req.Charm, req.Name, req.Revision)
 }
+
+func (s *ClientSuite) TestGetArchive(c *gc.C) {
+	rc := ioutil.NopCloser(strings.NewReader("archive"))
+	s.wrapper.ReturnGetArchive = ArchiveData{
+		ReadCloser: rc,
+		URL:        charm.MustParseURL("cs:trusty/mysql-7"),
+		Hash:       "hash",
+		Size:       7,
+	}
+
+	client, err := newCachingClient(s.cache, nil, s.wrapper.makeWrapper)
+	c.Assert(err, jc.ErrorIsNil)
+
+	id := CharmID{
+		URL:     charm.MustParseURL("cs:mysql"),
+		Channel: params.StableChannel,
+	}
+	data, err := client.GetArchive(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(data, jc.DeepEquals, s.wrapper.ReturnGetArchive)
+	// call #0 is a call to makeWrapper
+	s.wrapper.stub.CheckCall(c, 1, "GetArchive", params.StableChannel, id.URL)
+}
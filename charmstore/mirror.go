@@ -0,0 +1,303 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmstore
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+	"gopkg.in/yaml.v2"
+)
+
+// mirrorManifestPath is the path of the manifest within a charm
+// mirror archive.
+const mirrorManifestPath = "manifest.yaml"
+
+// MirrorManifest describes the content of a charm mirror archive, a
+// portable gzipped tarball of charm store charms, their resources and
+// bundles, used to import them into controllers that cannot reach the
+// charm store.
+type MirrorManifest struct {
+	Charms  []MirrorCharm  `yaml:"charms,omitempty"`
+	Bundles []MirrorBundle `yaml:"bundles,omitempty"`
+}
+
+// MirrorCharm describes a charm in a charm mirror archive.
+type MirrorCharm struct {
+	// URL is the charm's fully qualified charm store URL.
+	URL string `yaml:"url"`
+
+	// Path is the path of the charm's archive.
+	Path string `yaml:"path"`
+
+	// Hash is the SHA384 hash of the charm's archive, as recorded
+	// by the charm store.
+	Hash string `yaml:"hash"`
+
+	// Resources describes the charm's resources.
+	Resources []MirrorResource `yaml:"resources,omitempty"`
+}
+
+// MirrorResource describes a revision of a charm store resource in a
+// charm mirror archive.
+type MirrorResource struct {
+	Name        string `yaml:"name"`
+	Revision    int    `yaml:"revision"`
+	Fingerprint string `yaml:"fingerprint"`
+	Size        int64  `yaml:"size"`
+	Path        string `yaml:"path"`
+}
+
+// MirrorBundle describes a bundle in a charm mirror archive.
+type MirrorBundle struct {
+	// URL is the bundle's fully qualified charm store URL.
+	URL string `yaml:"url"`
+
+	// Path is the path of the bundle's archive.
+	Path string `yaml:"path"`
+}
+
+// mirrorFileName returns a file name for the given URL, unique within
+// a charm mirror archive.
+func mirrorFileName(curl *charm.URL) string {
+	return strings.NewReplacer(":", "-", "/", "-").Replace(curl.String())
+}
+
+// MirrorWriter writes a charm mirror archive.
+type MirrorWriter struct {
+	gz       *gzip.Writer
+	tw       *tar.Writer
+	manifest MirrorManifest
+}
+
+// NewMirrorWriter returns a MirrorWriter that writes a charm mirror
+// archive to w. The archive is only complete once Close is called.
+func NewMirrorWriter(w io.Writer) *MirrorWriter {
+	gz := gzip.NewWriter(w)
+	return &MirrorWriter{
+		gz: gz,
+		tw: tar.NewWriter(gz),
+	}
+}
+
+// writeFile writes the content read from r to the named file in the
+// archive, and returns its hex-encoded SHA384 hash.
+func (w *MirrorWriter) writeFile(name string, r io.Reader, size int64) (string, error) {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    size,
+		ModTime: time.Now(),
+	}
+	if err := w.tw.WriteHeader(hdr); err != nil {
+		return "", errors.Trace(err)
+	}
+	h := sha512.New384()
+	if _, err := io.Copy(io.MultiWriter(w.tw, h), r); err != nil {
+		return "", errors.Annotatef(err, "cannot write %q", name)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// AddCharm adds the archive of the charm store charm with the given
+// URL, which must include a series and revision, to the mirror archive.
+// The archive's content must match the given SHA384 hash.
+func (w *MirrorWriter) AddCharm(curl *charm.URL, hash string, r io.Reader, size int64) error {
+	if curl.Schema != "cs" || curl.Series == "" || curl.Revision < 0 {
+		return errors.NotValidf("charm URL %q without cs schema, series and revision", curl)
+	}
+	for _, ch := range w.manifest.Charms {
+		if ch.URL == curl.String() {
+			return nil
+		}
+	}
+	name := path.Join("charms", mirrorFileName(curl)+".zip")
+	written, err := w.writeFile(name, r, size)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if written != hash {
+		return errors.NotValidf("archive of charm %q with SHA384 %s, expected %s", curl, written, hash)
+	}
+	w.manifest.Charms = append(w.manifest.Charms, MirrorCharm{
+		URL:  curl.String(),
+		Path: name,
+		Hash: hash,
+	})
+	return nil
+}
+
+// AddResource adds the content of a revision of a resource of the
+// given charm, which must already have been added, to the mirror
+// archive. The content must match the resource's fingerprint.
+func (w *MirrorWriter) AddResource(curl *charm.URL, res charmresource.Resource, r io.Reader) error {
+	for i, ch := range w.manifest.Charms {
+		if ch.URL != curl.String() {
+			continue
+		}
+		name := path.Join("resources", mirrorFileName(curl), fmt.Sprintf("%s-%d", res.Name, res.Revision))
+		written, err := w.writeFile(name, r, res.Size)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if written != res.Fingerprint.String() {
+			return errors.NotValidf("resource %q of charm %q with fingerprint %s, expected %s", res.Name, curl, written, res.Fingerprint)
+		}
+		w.manifest.Charms[i].Resources = append(ch.Resources, MirrorResource{
+			Name:        res.Name,
+			Revision:    res.Revision,
+			Fingerprint: res.Fingerprint.String(),
+			Size:        res.Size,
+			Path:        name,
+		})
+		return nil
+	}
+	return errors.NotFoundf("charm %q in mirror archive", curl)
+}
+
+// AddBundle adds the archive of the charm store bundle with the given
+// URL to the mirror archive.
+func (w *MirrorWriter) AddBundle(curl *charm.URL, r io.Reader, size int64) error {
+	if curl.Schema != "cs" || curl.Revision < 0 {
+		return errors.NotValidf("bundle URL %q without cs schema and revision", curl)
+	}
+	name := path.Join("bundles", mirrorFileName(curl)+".zip")
+	if _, err := w.writeFile(name, r, size); err != nil {
+		return errors.Trace(err)
+	}
+	w.manifest.Bundles = append(w.manifest.Bundles, MirrorBundle{
+		URL:  curl.String(),
+		Path: name,
+	})
+	return nil
+}
+
+// Close writes the archive's manifest and completes the archive. It
+// does not close the underlying writer.
+func (w *MirrorWriter) Close() error {
+	data, err := yaml.Marshal(w.manifest)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := w.writeFile(mirrorManifestPath, strings.NewReader(string(data)), int64(len(data))); err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.tw.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(w.gz.Close())
+}
+
+// ExtractMirrorArchive extracts the charm mirror archive read from r
+// into dir, and returns its manifest. Paths in the returned manifest
+// are relative to dir. The extracted charm archives and resources are
+// verified against the hashes and fingerprints in the manifest.
+func ExtractMirrorArchive(r io.Reader, dir string) (*MirrorManifest, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read mirror archive")
+	}
+	defer gz.Close()
+	var manifest *MirrorManifest
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot read mirror archive")
+		}
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			continue
+		}
+		name := path.Clean(hdr.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, errors.NotValidf("mirror archive entry %q", hdr.Name)
+		}
+		if name == mirrorManifestPath {
+			data, err := ioutil.ReadAll(tr)
+			if err != nil {
+				return nil, errors.Annotate(err, "cannot read mirror archive manifest")
+			}
+			manifest = &MirrorManifest{}
+			if err := yaml.Unmarshal(data, manifest); err != nil {
+				return nil, errors.Annotate(err, "cannot parse mirror archive manifest")
+			}
+			continue
+		}
+		if err := extractMirrorFile(tr, filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if manifest == nil {
+		return nil, errors.NotValidf("mirror archive without manifest")
+	}
+	if err := manifest.verify(dir); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return manifest, nil
+}
+
+// verify checks that the charm archives and resources extracted into
+// dir match the hashes and fingerprints recorded in the manifest.
+func (m *MirrorManifest) verify(dir string) error {
+	for _, ch := range m.Charms {
+		if err := verifyMirrorFile(dir, ch.Path, ch.Hash); err != nil {
+			return errors.Annotatef(err, "charm %q", ch.URL)
+		}
+		for _, res := range ch.Resources {
+			if err := verifyMirrorFile(dir, res.Path, res.Fingerprint); err != nil {
+				return errors.Annotatef(err, "resource %q of charm %q", res.Name, ch.URL)
+			}
+		}
+	}
+	return nil
+}
+
+// verifyMirrorFile checks that the SHA384 hash of the named file in
+// dir matches the given hex-encoded hash.
+func verifyMirrorFile(dir, name, hash string) error {
+	f, err := os.Open(filepath.Join(dir, filepath.FromSlash(path.Clean(name))))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	h := sha512.New384()
+	if _, err := io.Copy(h, f); err != nil {
+		return errors.Trace(err)
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != hash {
+		return errors.NotValidf("content with SHA384 %s, expected %s", got, hash)
+	}
+	return nil
+}
+
+func extractMirrorFile(r io.Reader, filename string) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return errors.Trace(err)
+	}
+	f, err := os.Create(filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, r); err != nil {
+		return errors.Annotatef(err, "cannot extract %q", filename)
+	}
+	return nil
+}
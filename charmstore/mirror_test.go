@@ -0,0 +1,160 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmstore_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha512"
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+
+	"github.com/juju/juju/charmstore"
+)
+
+type MirrorSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&MirrorSuite{})
+
+func sha384(content string) string {
+	h := sha512.New384()
+	h.Write([]byte(content))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (MirrorSuite) TestRoundTrip(c *gc.C) {
+	var buf bytes.Buffer
+	w := charmstore.NewMirrorWriter(&buf)
+	curl := charm.MustParseURL("cs:~bob/trusty/mysql-3")
+	err := w.AddCharm(curl, sha384("charm"), strings.NewReader("charm"), 5)
+	c.Assert(err, jc.ErrorIsNil)
+	fp, err := charmresource.GenerateFingerprint(strings.NewReader("data"))
+	c.Assert(err, jc.ErrorIsNil)
+	res := charmresource.Resource{
+		Meta:        charmresource.Meta{Name: "data", Type: charmresource.TypeFile, Path: "data.tgz"},
+		Origin:      charmresource.OriginStore,
+		Revision:    2,
+		Fingerprint: fp,
+		Size:        4,
+	}
+	err = w.AddResource(curl, res, strings.NewReader("data"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = w.AddBundle(charm.MustParseURL("cs:bundle/wiki-1"), strings.NewReader("bundle"), 6)
+	c.Assert(err, jc.ErrorIsNil)
+	err = w.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	dir := c.MkDir()
+	manifest, err := charmstore.ExtractMirrorArchive(&buf, dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(manifest, jc.DeepEquals, &charmstore.MirrorManifest{
+		Charms: []charmstore.MirrorCharm{{
+			URL:  "cs:~bob/trusty/mysql-3",
+			Path: "charms/cs-~bob-trusty-mysql-3.zip",
+			Hash: sha384("charm"),
+			Resources: []charmstore.MirrorResource{{
+				Name:        "data",
+				Revision:    2,
+				Fingerprint: fp.String(),
+				Size:        4,
+				Path:        "resources/cs-~bob-trusty-mysql-3/data-2",
+			}},
+		}},
+		Bundles: []charmstore.MirrorBundle{{
+			URL:  "cs:bundle/wiki-1",
+			Path: "bundles/cs-bundle-wiki-1.zip",
+		}},
+	})
+	for path, expected := range map[string]string{
+		manifest.Charms[0].Path:              "charm",
+		manifest.Charms[0].Resources[0].Path: "data",
+		manifest.Bundles[0].Path:             "bundle",
+	} {
+		data, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(string(data), gc.Equals, expected)
+	}
+}
+
+func (MirrorSuite) TestAddCharmRequiresSeriesAndRevision(c *gc.C) {
+	w := charmstore.NewMirrorWriter(ioutil.Discard)
+	err := w.AddCharm(charm.MustParseURL("cs:mysql"), sha384(""), strings.NewReader(""), 0)
+	c.Assert(err, gc.ErrorMatches, `charm URL "cs:mysql" without cs schema, series and revision not valid`)
+}
+
+func (MirrorSuite) TestAddCharmHashMismatch(c *gc.C) {
+	w := charmstore.NewMirrorWriter(ioutil.Discard)
+	err := w.AddCharm(charm.MustParseURL("cs:trusty/mysql-1"), sha384("other"), strings.NewReader("charm"), 5)
+	c.Assert(err, gc.ErrorMatches, `archive of charm "cs:trusty/mysql-1" with SHA384 [0-9a-f]+, expected [0-9a-f]+ not valid`)
+}
+
+func (MirrorSuite) TestAddResourceFingerprintMismatch(c *gc.C) {
+	w := charmstore.NewMirrorWriter(ioutil.Discard)
+	curl := charm.MustParseURL("cs:trusty/mysql-1")
+	err := w.AddCharm(curl, sha384("charm"), strings.NewReader("charm"), 5)
+	c.Assert(err, jc.ErrorIsNil)
+	fp, err := charmresource.GenerateFingerprint(strings.NewReader("other"))
+	c.Assert(err, jc.ErrorIsNil)
+	res := charmresource.Resource{
+		Meta:        charmresource.Meta{Name: "data", Type: charmresource.TypeFile, Path: "data.tgz"},
+		Origin:      charmresource.OriginStore,
+		Revision:    2,
+		Fingerprint: fp,
+		Size:        4,
+	}
+	err = w.AddResource(curl, res, strings.NewReader("data"))
+	c.Assert(err, gc.ErrorMatches, `resource "data" of charm "cs:trusty/mysql-1" with fingerprint [0-9a-f]+, expected [0-9a-f]+ not valid`)
+}
+
+func (MirrorSuite) TestAddResourceRequiresCharm(c *gc.C) {
+	w := charmstore.NewMirrorWriter(ioutil.Discard)
+	err := w.AddResource(charm.MustParseURL("cs:trusty/mysql-1"), charmresource.Resource{}, strings.NewReader(""))
+	c.Assert(err, gc.ErrorMatches, `charm "cs:trusty/mysql-1" in mirror archive not found`)
+}
+
+func (MirrorSuite) TestExtractEmpty(c *gc.C) {
+	var buf bytes.Buffer
+	w := charmstore.NewMirrorWriter(&buf)
+	err := w.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	manifest, err := charmstore.ExtractMirrorArchive(&buf, c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(manifest, jc.DeepEquals, &charmstore.MirrorManifest{})
+}
+
+func (MirrorSuite) TestExtractInvalid(c *gc.C) {
+	_, err := charmstore.ExtractMirrorArchive(strings.NewReader("junk"), c.MkDir())
+	c.Assert(err, gc.ErrorMatches, "cannot read mirror archive: .*")
+}
+
+func (MirrorSuite) TestExtractVerifiesHashes(c *gc.C) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, f := range []struct{ name, content string }{
+		{"charms/cs-trusty-mysql-1.zip", "tampered"},
+		{"manifest.yaml", "charms:\n- url: cs:trusty/mysql-1\n  path: charms/cs-trusty-mysql-1.zip\n  hash: " + sha384("charm") + "\n"},
+	} {
+		err := tw.WriteHeader(&tar.Header{Name: f.name, Mode: 0644, Size: int64(len(f.content))})
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = tw.Write([]byte(f.content))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+	c.Assert(gz.Close(), jc.ErrorIsNil)
+
+	_, err := charmstore.ExtractMirrorArchive(&buf, c.MkDir())
+	c.Assert(err, gc.ErrorMatches, `charm "cs:trusty/mysql-1": content with SHA384 [0-9a-f]+, expected [0-9a-f]+ not valid`)
+}
@@ -5,6 +5,7 @@ package charmstore
 
 import (
 	"bytes"
+	"io"
 	"net/url"
 
 	"github.com/juju/testing"
@@ -51,6 +52,8 @@ type fakeWrapper struct {
 	ReturnGetResource csclient.ResourceData
 
 	ReturnResourceMeta params.Resource
+
+	ReturnGetArchive ArchiveData
 }
 
 func (f *fakeWrapper) makeWrapper(bakeryClient *httpbakery.Client, server *url.URL) csWrapper {
@@ -102,6 +105,12 @@ func (f *fakeWrapper) ResourceMeta(channel params.Channel, id *charm.URL, name s
 	return f.ReturnResourceMeta, nil
 }
 
+func (f *fakeWrapper) GetArchive(channel params.Channel, id *charm.URL) (io.ReadCloser, *charm.URL, string, int64, error) {
+	f.stub.AddCall("GetArchive", channel, id)
+	a := f.ReturnGetArchive
+	return a.ReadCloser, a.URL, a.Hash, a.Size, nil
+}
+
 func fakeParamsResource(name string, data []byte) params.Resource {
 	fp, err := resource.GenerateFingerprint(bytes.NewReader(data))
 	if err != nil {
@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/tags"
@@ -47,6 +48,12 @@ type InstanceConfig struct {
 	// should be populated using the InstanceTags method in this package.
 	Tags map[string]string
 
+	// CharmLXDProfiles holds the LXD profiles declared by the charms
+	// of the units to be deployed to the instance, keyed by the name
+	// of the LXD profile to create for each. They are only applied
+	// to LXD containers and instances.
+	CharmLXDProfiles map[string]lxdprofile.Profile
+
 	// Bootstrap contains bootstrap-specific configuration. If this is set,
 	// Controller must also be set.
 	Bootstrap *BootstrapConfig
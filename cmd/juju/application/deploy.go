@@ -372,6 +372,9 @@ func (c *DeployCommand) deployCharmOrBundle(ctx *cmd.Context, client *api.Client
 	csClient := newCharmStoreClient(bakeryClient).WithChannel(c.Channel)
 
 	resolver := newCharmURLResolver(conf, csClient)
+	if err := resolver.useCharmMirror(client); err != nil {
+		return errors.Trace(err)
+	}
 
 	var storeCharmOrBundleURL *charm.URL
 	var store *charmrepo.CharmStore
@@ -4,12 +4,16 @@
 package application
 
 import (
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/network"
 )
 
 var usageExposeSummary = `
@@ -19,8 +23,14 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+By default the application's opened ports may be reached from the
+model's ingress-cidrs, or from anywhere if that is not set. Use
+--to-cidrs to restrict access to a comma-separated list of source
+CIDRs instead.
+
 Examples:
     juju expose wordpress
+    juju expose mysql --to-cidrs 10.0.0.0/8,192.168.1.0/24
 
 See also: 
     unexpose`[1:]
@@ -34,6 +44,9 @@ func NewExposeCommand() cmd.Command {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	CIDRs           []string
+	cidrs           string
+	api             serviceExposeAPI
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,21 +58,37 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.cidrs, "to-cidrs", "", "Comma-separated source CIDRs allowed to reach the application")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
 	}
 	c.ApplicationName = args[0]
+	for _, cidr := range strings.Split(c.cidrs, ",") {
+		if cidr = strings.TrimSpace(cidr); cidr != "" {
+			c.CIDRs = append(c.CIDRs, cidr)
+		}
+	}
+	if err := network.ValidateCIDRs(c.CIDRs); err != nil {
+		return errors.Trace(err)
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
 type serviceExposeAPI interface {
+	BestAPIVersion() int
 	Close() error
-	Expose(serviceName string) error
+	Expose(serviceName string, cidrs ...string) error
 	Unexpose(serviceName string) error
 }
 
 func (c *exposeCommand) getAPI() (serviceExposeAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
 	root, err := c.NewAPIRoot()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -75,5 +104,8 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	if len(c.CIDRs) > 0 && client.BestAPIVersion() < 2 {
+		return errors.New("exposing to source CIDRs is not supported by this controller")
+	}
+	return block.ProcessBlockedError(client.Expose(c.ApplicationName, c.CIDRs...), block.BlockChange)
 }
@@ -10,6 +10,7 @@ import (
 	"gopkg.in/juju/charm.v6-unstable"
 
 	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/testcharms"
@@ -60,6 +61,68 @@ func (s *ExposeSuite) TestExpose(c *gc.C) {
 	})
 }
 
+func (s *ExposeSuite) TestExposeToCIDRs(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
+	err := runDeploy(c, ch, "some-application-name", "--series", "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runExpose(c, "some-application-name", "--to-cidrs", "10.0.0.0/8, 192.168.1.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExposed(c, "some-application-name")
+	svc, err := s.State.Application("some-application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+
+	// Exposing again without CIDRs opens the application to the
+	// model default.
+	err = runExpose(c, "some-application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	err = svc.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.ExposedCIDRs(), gc.HasLen, 0)
+}
+
+func (s *ExposeSuite) TestExposeInvalidCIDR(c *gc.C) {
+	err := runExpose(c, "some-application-name", "--to-cidrs", "10.0.0.1")
+	c.Assert(err, gc.ErrorMatches, `CIDR "10.0.0.1" not valid`)
+}
+
+func (s *ExposeSuite) TestExposeToCIDRsUnsupported(c *gc.C) {
+	api := &fakeExposeAPI{version: 1}
+	cmd := modelcmd.Wrap(&exposeCommand{api: api})
+	_, err := testing.RunCommand(c, cmd, "some-application-name", "--to-cidrs", "10.0.0.0/8")
+	c.Assert(err, gc.ErrorMatches, "exposing to source CIDRs is not supported by this controller")
+	c.Assert(api.exposed, gc.HasLen, 0)
+
+	// Exposing without CIDRs still works.
+	cmd = modelcmd.Wrap(&exposeCommand{api: api})
+	_, err = testing.RunCommand(c, cmd, "some-application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api.exposed, jc.DeepEquals, []string{"some-application-name"})
+}
+
+type fakeExposeAPI struct {
+	version int
+	exposed []string
+}
+
+func (f *fakeExposeAPI) BestAPIVersion() int {
+	return f.version
+}
+
+func (f *fakeExposeAPI) Close() error {
+	return nil
+}
+
+func (f *fakeExposeAPI) Expose(application string, cidrs ...string) error {
+	f.exposed = append(f.exposed, application)
+	return nil
+}
+
+func (f *fakeExposeAPI) Unexpose(application string) error {
+	return nil
+}
+
 func (s *ExposeSuite) TestBlockExpose(c *gc.C) {
 	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
 	err := runDeploy(c, ch, "some-application-name", "--series", "trusty")
@@ -0,0 +1,127 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageSetPlacementPolicySummary = `
+Sets how an application's units are placed.`[1:]
+
+var usageSetPlacementPolicyDetails = `
+A placement policy controls where new units of an application are put.
+
+--spread keeps units apart: no two units of the application are assigned
+to the same "machine", to the same "host" (a machine together with its
+containers), or to machines in the same availability zone ("zone").
+Assigning a unit somewhere that breaks the policy fails. A zone is only
+known once a machine has been provisioned, so units on new machines rely
+on the provider to spread them; juju status lists any units that end up
+sharing a zone.
+
+--colocate prefers the machines of another application: new units are
+put alongside that application's units where the spread policy allows,
+and on new machines otherwise.
+
+Running the command without --spread or --colocate clears the policy.
+Units already placed are not moved.
+
+Examples:
+    juju set-placement-policy cassandra --spread zone
+    juju set-placement-policy memcached --colocate wordpress --spread machine
+    juju set-placement-policy cassandra
+
+See also:
+    add-unit
+    deploy
+    status`[1:]
+
+// NewSetPlacementPolicyCommand returns a command to set the placement
+// policy of an application.
+func NewSetPlacementPolicyCommand() cmd.Command {
+	return modelcmd.Wrap(&setPlacementPolicyCommand{})
+}
+
+// setPlacementPolicyCommand is responsible for setting the placement
+// policy of applications.
+type setPlacementPolicyCommand struct {
+	modelcmd.ModelCommandBase
+	api setPlacementPolicyAPI
+
+	ApplicationName string
+	Spread          string
+	Colocate        string
+}
+
+type setPlacementPolicyAPI interface {
+	BestAPIVersion() int
+	Close() error
+	SetPlacementPolicy(application, spread, colocate string) error
+}
+
+func (c *setPlacementPolicyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-placement-policy",
+		Args:    "<application name>",
+		Purpose: usageSetPlacementPolicySummary,
+		Doc:     usageSetPlacementPolicyDetails,
+	}
+}
+
+func (c *setPlacementPolicyCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.Spread, "spread", "", `Keep units apart by "machine", "host" or "zone"`)
+	f.StringVar(&c.Colocate, "colocate", "", "Prefer the machines of this application")
+}
+
+func (c *setPlacementPolicyCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.ApplicationName = args[0]
+	switch c.Spread {
+	case "", "machine", "host", "zone":
+	default:
+		return errors.Errorf(`invalid --spread %q, expected "machine", "host" or "zone"`, c.Spread)
+	}
+	if c.Colocate != "" && !names.IsValidApplication(c.Colocate) {
+		return errors.Errorf("invalid application name %q", c.Colocate)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *setPlacementPolicyCommand) getAPI() (setPlacementPolicyAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run sets the application's placement policy.
+func (c *setPlacementPolicyCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 2 {
+		return errors.New("placement policies are not supported by this controller")
+	}
+	err = client.SetPlacementPolicy(c.ApplicationName, c.Spread, c.Colocate)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
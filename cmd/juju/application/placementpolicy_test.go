@@ -0,0 +1,104 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/testing"
+)
+
+type SetPlacementPolicySuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeSetPlacementPolicyAPI
+}
+
+var _ = gc.Suite(&SetPlacementPolicySuite{})
+
+func (s *SetPlacementPolicySuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeSetPlacementPolicyAPI{version: 2}
+}
+
+func (s *SetPlacementPolicySuite) runSetPlacementPolicy(c *gc.C, args ...string) error {
+	command := modelcmd.Wrap(&setPlacementPolicyCommand{api: s.fake})
+	_, err := testing.RunCommand(c, command, args...)
+	return err
+}
+
+func (s *SetPlacementPolicySuite) TestInit(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no application name specified",
+	}, {
+		args: []string{"foo/0"},
+		err:  `invalid application name "foo/0"`,
+	}, {
+		args: []string{"foo", "--spread", "rack"},
+		err:  `invalid --spread "rack", expected "machine", "host" or "zone"`,
+	}, {
+		args: []string{"foo", "--colocate", "bar/0"},
+		err:  `invalid application name "bar/0"`,
+	}, {
+		args: []string{"foo", "bar"},
+		err:  `unrecognized args: \["bar"\]`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		err := testing.InitCommand(&setPlacementPolicyCommand{}, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SetPlacementPolicySuite) TestSetPlacementPolicy(c *gc.C) {
+	err := s.runSetPlacementPolicy(c, "memcached", "--spread", "machine", "--colocate", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []jujutesting.StubCall{
+		{"SetPlacementPolicy", []interface{}{"memcached", "machine", "wordpress"}},
+		{"Close", nil},
+	})
+}
+
+func (s *SetPlacementPolicySuite) TestClear(c *gc.C) {
+	err := s.runSetPlacementPolicy(c, "memcached")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCall(c, 0, "SetPlacementPolicy", "memcached", "", "")
+}
+
+func (s *SetPlacementPolicySuite) TestError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	err := s.runSetPlacementPolicy(c, "memcached", "--spread", "zone")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *SetPlacementPolicySuite) TestUnsupported(c *gc.C) {
+	s.fake.version = 1
+	err := s.runSetPlacementPolicy(c, "memcached", "--spread", "zone")
+	c.Assert(err, gc.ErrorMatches, "placement policies are not supported by this controller")
+	s.fake.CheckCallNames(c, "Close")
+}
+
+type fakeSetPlacementPolicyAPI struct {
+	jujutesting.Stub
+	version int
+}
+
+func (f *fakeSetPlacementPolicyAPI) BestAPIVersion() int {
+	return f.version
+}
+
+func (f *fakeSetPlacementPolicyAPI) SetPlacementPolicy(application, spread, colocate string) error {
+	f.MethodCall(f, "SetPlacementPolicy", application, spread, colocate)
+	return f.NextErr()
+}
+
+func (f *fakeSetPlacementPolicyAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
@@ -0,0 +1,112 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageRollbackCharmSummary = `
+Reverts an application to the charm it ran before its last upgrade.`[1:]
+
+var usageRollbackCharmDetails = `
+When a charm upgrade misbehaves, rollback-charm switches the application
+back to the charm revision it was running before the most recent
+upgrade-charm. The previous charm is still held by the controller, so no
+charm store access is needed. Units run the upgrade-charm hook while
+moving back, exactly as they do for an upgrade.
+
+Only one step is remembered: running rollback-charm twice returns the
+application to the charm it was rolled back from.
+
+Units in an error state are not rolled back unless --force-units is given.
+
+Examples:
+    juju rollback-charm mysql
+    juju rollback-charm mysql --force-units
+
+See also:
+    upgrade-charm`[1:]
+
+// NewRollbackCharmCommand returns a command to roll an application back
+// to its previous charm.
+func NewRollbackCharmCommand() cmd.Command {
+	return modelcmd.Wrap(&rollbackCharmCommand{})
+}
+
+// rollbackCharmCommand is responsible for rolling back application charms.
+type rollbackCharmCommand struct {
+	modelcmd.ModelCommandBase
+	api rollbackCharmAPI
+
+	ApplicationName string
+	ForceUnits      bool
+}
+
+type rollbackCharmAPI interface {
+	BestAPIVersion() int
+	Close() error
+	RollbackCharm(application string, forceUnits bool) (*charm.URL, error)
+}
+
+func (c *rollbackCharmCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "rollback-charm",
+		Args:    "<application name>",
+		Purpose: usageRollbackCharmSummary,
+		Doc:     usageRollbackCharmDetails,
+	}
+}
+
+func (c *rollbackCharmCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.ForceUnits, "force-units", false, "Roll back all units immediately, even if in error state")
+}
+
+func (c *rollbackCharmCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.ApplicationName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *rollbackCharmCommand) getAPI() (rollbackCharmAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run switches the application back to its previous charm.
+func (c *rollbackCharmCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 2 {
+		return errors.New("rolling back charms is not supported by this controller")
+	}
+	curl, err := client.RollbackCharm(c.ApplicationName, c.ForceUnits)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Rolled back application %q to charm %q", c.ApplicationName, curl)
+	return nil
+}
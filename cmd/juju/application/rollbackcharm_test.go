@@ -0,0 +1,100 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/testing"
+)
+
+type RollbackCharmSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeRollbackCharmAPI
+}
+
+var _ = gc.Suite(&RollbackCharmSuite{})
+
+func (s *RollbackCharmSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeRollbackCharmAPI{
+		version: 2,
+		curl:    charm.MustParseURL("cs:trusty/mysql-1"),
+	}
+}
+
+func (s *RollbackCharmSuite) runRollbackCharm(c *gc.C, args ...string) (string, error) {
+	command := modelcmd.Wrap(&rollbackCharmCommand{api: s.fake})
+	ctx, err := testing.RunCommand(c, command, args...)
+	if err != nil {
+		return "", err
+	}
+	return testing.Stderr(ctx), nil
+}
+
+func (s *RollbackCharmSuite) TestInit(c *gc.C) {
+	err := testing.InitCommand(&rollbackCharmCommand{}, nil)
+	c.Assert(err, gc.ErrorMatches, "no application name specified")
+	err = testing.InitCommand(&rollbackCharmCommand{}, []string{"foo/0"})
+	c.Assert(err, gc.ErrorMatches, `invalid application name "foo/0"`)
+	err = testing.InitCommand(&rollbackCharmCommand{}, []string{"foo", "bar"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["bar"\]`)
+}
+
+func (s *RollbackCharmSuite) TestRollbackCharm(c *gc.C) {
+	stderr, err := s.runRollbackCharm(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stderr, gc.Equals, "Rolled back application \"mysql\" to charm \"cs:trusty/mysql-1\"\n")
+	s.fake.CheckCalls(c, []jujutesting.StubCall{
+		{"RollbackCharm", []interface{}{"mysql", false}},
+		{"Close", nil},
+	})
+}
+
+func (s *RollbackCharmSuite) TestForceUnits(c *gc.C) {
+	_, err := s.runRollbackCharm(c, "mysql", "--force-units")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCall(c, 0, "RollbackCharm", "mysql", true)
+}
+
+func (s *RollbackCharmSuite) TestError(c *gc.C) {
+	s.fake.SetErrors(errors.New(`cannot roll back charm of application "mysql": previous charm not found`))
+	_, err := s.runRollbackCharm(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, `cannot roll back charm of application "mysql": previous charm not found`)
+}
+
+func (s *RollbackCharmSuite) TestUnsupported(c *gc.C) {
+	s.fake.version = 1
+	_, err := s.runRollbackCharm(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "rolling back charms is not supported by this controller")
+	s.fake.CheckCallNames(c, "Close")
+}
+
+type fakeRollbackCharmAPI struct {
+	jujutesting.Stub
+	version int
+	curl    *charm.URL
+}
+
+func (f *fakeRollbackCharmAPI) BestAPIVersion() int {
+	return f.version
+}
+
+func (f *fakeRollbackCharmAPI) RollbackCharm(application string, forceUnits bool) (*charm.URL, error) {
+	f.MethodCall(f, "RollbackCharm", application, forceUnits)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.curl, nil
+}
+
+func (f *fakeRollbackCharmAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
@@ -63,6 +63,30 @@ type charmURLResolver struct {
 
 	// conf holds the current model configuration.
 	conf *config.Config
+
+	// mirror, if not nil, resolves charm URLs against the controller's
+	// charm mirror, for controllers with charmstore-mirror enabled.
+	mirror charmMirrorResolver
+}
+
+// charmMirrorResolver resolves charm URLs against the controller's
+// charm mirror. It is implemented by *api.Client.
+type charmMirrorResolver interface {
+	ModelInfo() (params.ModelInfo, error)
+	ResolveCharm(ref *charm.URL) (*charm.URL, error)
+}
+
+// useCharmMirror makes the resolver resolve charm URLs against the
+// controller's charm mirror, if the controller hosts one.
+func (r *charmURLResolver) useCharmMirror(client charmMirrorResolver) error {
+	info, err := client.ModelInfo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if info.CharmStoreMirror {
+		r.mirror = client
+	}
+	return nil
 }
 
 func newCharmURLResolver(conf *config.Config, csClient *csclient.Client) *charmURLResolver {
@@ -85,6 +109,15 @@ func (r *charmURLResolver) resolve(url *charm.URL) (*charm.URL, csparams.Channel
 	if url.Schema != "cs" {
 		return nil, csparams.NoChannel, nil, nil, errors.Errorf("unknown schema for charm URL %q", url)
 	}
+	if r.mirror != nil {
+		// The charm store is not reachable from the controller, so
+		// resolve against the charms imported into its mirror.
+		resultUrl, err := r.mirror.ResolveCharm(url)
+		if err != nil {
+			return nil, csparams.NoChannel, nil, nil, errors.Trace(err)
+		}
+		return resultUrl, csparams.NoChannel, []string{resultUrl.Series}, r.store, nil
+	}
 	// If the user hasn't explicitly asked for a particular series,
 	// query for the charm that matches the model's default series.
 	// If this fails, we'll fall back to asking for whatever charm is available.
@@ -0,0 +1,98 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageTrustSummary = `
+Allows an application to access the model's cloud credential.`[1:]
+
+var usageTrustDetails = `
+Some charms, such as those that manage storage or load balancers, need to
+call the cloud's API directly. Trusting an application allows its units to
+read the model's cloud credential with the credential-get hook tool. Every
+such access is recorded in the controller's audit log.
+
+Use --remove to revoke the trust again.
+
+Examples:
+    juju trust aws-integrator
+    juju trust aws-integrator --remove
+
+See also:
+    deploy`[1:]
+
+// NewTrustCommand returns a command to trust applications with the
+// model's cloud credential.
+func NewTrustCommand() cmd.Command {
+	return modelcmd.Wrap(&trustCommand{})
+}
+
+// trustCommand is responsible for trusting applications.
+type trustCommand struct {
+	modelcmd.ModelCommandBase
+	api trustAPI
+
+	ApplicationName string
+	Remove          bool
+}
+
+type trustAPI interface {
+	Close() error
+	SetTrust(application string, trust bool) error
+}
+
+func (c *trustCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "trust",
+		Args:    "<application name>",
+		Purpose: usageTrustSummary,
+		Doc:     usageTrustDetails,
+	}
+}
+
+func (c *trustCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.Remove, "remove", false, "Revoke the application's access to the cloud credential")
+}
+
+func (c *trustCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.ApplicationName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *trustCommand) getAPI() (trustAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run sets or clears the application's trust flag.
+func (c *trustCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return block.ProcessBlockedError(client.SetTrust(c.ApplicationName, !c.Remove), block.BlockChange)
+}
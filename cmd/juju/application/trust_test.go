@@ -0,0 +1,76 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/testing"
+)
+
+type TrustSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeTrustAPI
+}
+
+var _ = gc.Suite(&TrustSuite{})
+
+func (s *TrustSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeTrustAPI{}
+}
+
+func (s *TrustSuite) runTrust(c *gc.C, args ...string) error {
+	command := modelcmd.Wrap(&trustCommand{api: s.fake})
+	_, err := testing.RunCommand(c, command, args...)
+	return err
+}
+
+func (s *TrustSuite) TestInit(c *gc.C) {
+	err := testing.InitCommand(&trustCommand{}, nil)
+	c.Assert(err, gc.ErrorMatches, "no application name specified")
+	err = testing.InitCommand(&trustCommand{}, []string{"foo/0"})
+	c.Assert(err, gc.ErrorMatches, `invalid application name "foo/0"`)
+	err = testing.InitCommand(&trustCommand{}, []string{"foo", "bar"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["bar"\]`)
+}
+
+func (s *TrustSuite) TestTrust(c *gc.C) {
+	err := s.runTrust(c, "aws-integrator")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []jujutesting.StubCall{
+		{"SetTrust", []interface{}{"aws-integrator", true}},
+		{"Close", nil},
+	})
+}
+
+func (s *TrustSuite) TestRemove(c *gc.C) {
+	err := s.runTrust(c, "aws-integrator", "--remove")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCall(c, 0, "SetTrust", "aws-integrator", false)
+}
+
+func (s *TrustSuite) TestError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	err := s.runTrust(c, "aws-integrator")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeTrustAPI struct {
+	jujutesting.Stub
+}
+
+func (f *fakeTrustAPI) SetTrust(application string, trust bool) error {
+	f.MethodCall(f, "SetTrust", application, trust)
+	return f.NextErr()
+}
+
+func (f *fakeTrustAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -47,6 +48,11 @@ type upgradeCharmCommand struct {
 	// Channel holds the charmstore channel to use when obtaining
 	// the charm to be upgraded to.
 	Channel csclientparams.Channel
+
+	// BatchSize, if non-zero, holds the number of units to upgrade
+	// at a time; BatchTimeout limits how long each batch may take.
+	BatchSize    int
+	BatchTimeout time.Duration
 }
 
 const upgradeCharmDoc = `
@@ -108,6 +114,19 @@ would specify revision number 5 of the wordpress charm.
 Use of the --force-units flag is not generally recommended; units upgraded while in an
 error state will not have upgrade-charm hooks executed, and may cause unexpected
 behavior.
+
+By default all units are upgraded at once. The --batch-size flag instead performs a
+rolling upgrade, which upgrades that many units at a time and waits for each batch
+to return to an active workload and idle agent before upgrading the next. The
+controller drives the upgrade, so it continues if the client disconnects. If a unit
+in the batch goes into an error state, or the batch does not settle within the
+--batch-timeout, the upgrade is halted and the remaining units keep the previous
+charm. Progress is shown by "juju status --format yaml". Upgrading the application
+again replaces a halted or unfinished rolling upgrade.
+
+  juju upgrade-charm foo --batch-size 2 --batch-timeout 10m
+
+--batch-size and --force-units are mutually exclusive.
 `
 
 func (c *upgradeCharmCommand) Info() *cmd.Info {
@@ -127,6 +146,8 @@ func (c *upgradeCharmCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.CharmPath, "path", "", "upgrade to a charm located at path")
 	f.IntVar(&c.Revision, "revision", -1, "explicit revision of current charm")
 	f.Var(stringMap{&c.Resources}, "resource", "resource to be uploaded to the controller")
+	f.IntVar(&c.BatchSize, "batch-size", 0, "upgrade this many units at a time, waiting for each batch to settle")
+	f.DurationVar(&c.BatchTimeout, "batch-timeout", 0, "halt a rolling upgrade if a batch takes longer than this to settle")
 }
 
 func (c *upgradeCharmCommand) Init(args []string) error {
@@ -150,6 +171,18 @@ func (c *upgradeCharmCommand) Init(args []string) error {
 	if c.SwitchURL != "" && c.CharmPath != "" {
 		return fmt.Errorf("--switch and --path are mutually exclusive")
 	}
+	if c.BatchSize < 0 {
+		return fmt.Errorf("--batch-size must be a positive number of units")
+	}
+	if c.BatchTimeout < 0 {
+		return fmt.Errorf("--batch-timeout must not be negative")
+	}
+	if c.BatchTimeout != 0 && c.BatchSize == 0 {
+		return fmt.Errorf("--batch-timeout requires --batch-size")
+	}
+	if c.BatchSize != 0 && c.ForceUnits {
+		return fmt.Errorf("--batch-size and --force-units are mutually exclusive")
+	}
 	return nil
 }
 
@@ -174,6 +207,9 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.BatchSize != 0 && serviceClient.BestAPIVersion() < 2 {
+		return errors.New("--batch-size is not supported by this controller")
+	}
 
 	oldURL, err := serviceClient.GetCharmURL(c.ApplicationName)
 	if err != nil {
@@ -205,6 +241,9 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 	resolver := newCharmURLResolver(conf, csClient)
+	if err := resolver.useCharmMirror(client); err != nil {
+		return errors.Trace(err)
+	}
 	chID, csMac, err := c.addCharm(oldURL, newRef, client, resolver)
 	if err != nil {
 		return block.ProcessBlockedError(err, block.BlockChange)
@@ -222,6 +261,8 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		ForceSeries:     c.ForceSeries,
 		ForceUnits:      c.ForceUnits,
 		ResourceIDs:     ids,
+		BatchSize:       c.BatchSize,
+		BatchTimeout:    c.BatchTimeout,
 	}
 
 	return block.ProcessBlockedError(serviceClient.SetCharm(cfg), block.BlockChange)
@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"path"
 	"path/filepath"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -125,6 +126,24 @@ func (s *UpgradeCharmErrorsSuite) TestSwitchAndPathFails(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "--switch and --path are mutually exclusive")
 }
 
+func (s *UpgradeCharmErrorsSuite) TestNegativeBatchSizeFails(c *gc.C) {
+	s.deployService(c)
+	err := runUpgradeCharm(c, "riak", "--path=foo", "--batch-size=-1")
+	c.Assert(err, gc.ErrorMatches, "--batch-size must be a positive number of units")
+}
+
+func (s *UpgradeCharmErrorsSuite) TestBatchTimeoutWithoutBatchSizeFails(c *gc.C) {
+	s.deployService(c)
+	err := runUpgradeCharm(c, "riak", "--path=foo", "--batch-timeout=5m")
+	c.Assert(err, gc.ErrorMatches, "--batch-timeout requires --batch-size")
+}
+
+func (s *UpgradeCharmErrorsSuite) TestBatchSizeAndForceUnitsFails(c *gc.C) {
+	s.deployService(c)
+	err := runUpgradeCharm(c, "riak", "--path=foo", "--batch-size=2", "--force-units")
+	c.Assert(err, gc.ErrorMatches, "--batch-size and --force-units are mutually exclusive")
+}
+
 func (s *UpgradeCharmErrorsSuite) TestInvalidRevision(c *gc.C) {
 	s.deployService(c)
 	err := runUpgradeCharm(c, "riak", "--revision=blah")
@@ -271,6 +290,18 @@ func (s *UpgradeCharmSuccessSuite) TestForcedUnitsUpgrade(c *gc.C) {
 	s.assertLocalRevision(c, 7, s.path)
 }
 
+func (s *UpgradeCharmSuccessSuite) TestRollingUpgrade(c *gc.C) {
+	err := runUpgradeCharm(c, "riak", "--batch-size", "2", "--batch-timeout", "10m", "--path", s.path)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUpgraded(c, s.riak, 8, false)
+	rolling, ok := s.riak.RollingUpgrade()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(rolling.PreviousCharmURL.String(), gc.Equals, "local:quantal/riak-7")
+	c.Check(rolling.BatchSize, gc.Equals, 2)
+	c.Check(rolling.BatchTimeout, gc.Equals, 10*time.Minute)
+	c.Check(rolling.Released, gc.HasLen, 0)
+}
+
 func (s *UpgradeCharmSuccessSuite) TestBlockForcedUnitsUpgrade(c *gc.C) {
 	// Block operation
 	s.BlockAllChanges(c, "TestBlockForcedUpgrade")
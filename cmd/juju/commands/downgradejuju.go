@@ -0,0 +1,190 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/config"
+	coretools "github.com/juju/juju/tools"
+)
+
+var usageDowngradeJujuSummary = `
+Rolls back an agent upgrade in a model.`[1:]
+
+var usageDowngradeJujuDetails = `
+Without '--version', this command abandons the canary upgrade started with
+` + "`juju upgrade-juju --canary`" + `, returning the canary machines to the
+model's agent version.
+With '--version', the model's agent version is set back to the given,
+older, version. Any canary upgrade is abandoned first, as is any
+incomplete controller upgrade when downgrading the controller model.
+Agents whose upgrade steps failed return to the version they were upgraded
+from; otherwise agents only move back to an older patch release of the
+same major.minor version.
+Backups are recommended prior to downgrading.
+
+Examples:
+    juju downgrade-juju
+    juju downgrade-juju --version 2.0.1
+
+See also:
+    upgrade-juju`
+
+func newDowngradeJujuCommand() cmd.Command {
+	return modelcmd.Wrap(&downgradeJujuCommand{})
+}
+
+// downgradeJujuCommand rolls back an agent upgrade in a model.
+type downgradeJujuCommand struct {
+	modelcmd.ModelCommandBase
+	vers      string
+	Version   version.Number
+	AssumeYes bool
+}
+
+func (c *downgradeJujuCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "downgrade-juju",
+		Purpose: usageDowngradeJujuSummary,
+		Doc:     usageDowngradeJujuDetails,
+	}
+}
+
+func (c *downgradeJujuCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.vers, "version", "", "Roll back to specific version")
+	f.BoolVar(&c.AssumeYes, "y", false, "Answer 'yes' to confirmation prompts")
+	f.BoolVar(&c.AssumeYes, "yes", false, "")
+}
+
+func (c *downgradeJujuCommand) Init(args []string) error {
+	if c.vers != "" {
+		vers, err := version.Parse(c.vers)
+		if err != nil {
+			return err
+		}
+		c.Version = vers
+	}
+	return cmd.CheckEmpty(args)
+}
+
+type downgradeJujuAPI interface {
+	ModelGet() (map[string]interface{}, error)
+	FindTools(majorVersion, minorVersion int, series, arch string) (result params.FindToolsResult, err error)
+	AbortCurrentUpgrade() error
+	AbortModelAgentCanary() error
+	SetModelAgentVersion(version version.Number) error
+	Close() error
+}
+
+var getDowngradeJujuAPI = func(c *downgradeJujuCommand) (downgradeJujuAPI, error) {
+	return c.NewAPIClient()
+}
+
+// Run rolls back the model's canary upgrade, or its agent version.
+func (c *downgradeJujuCommand) Run(ctx *cmd.Context) error {
+	client, err := getDowngradeJujuAPI(c)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if c.Version == version.Zero {
+		err := client.AbortModelAgentCanary()
+		if params.IsCodeNotFound(err) {
+			return errors.New("no canary upgrade in progress; specify --version to roll back the model")
+		} else if err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+		ctx.Infof("canary upgrade abandoned")
+		return nil
+	}
+
+	attrs, err := client.ModelGet()
+	if err != nil {
+		return err
+	}
+	cfg, err := config.New(config.NoDefaults, attrs)
+	if err != nil {
+		return err
+	}
+	agentVersion, ok := cfg.AgentVersion()
+	if !ok {
+		// Can't happen. In theory.
+		return fmt.Errorf("incomplete model configuration")
+	}
+	if c.Version.Compare(agentVersion) >= 0 {
+		return errors.Errorf("cannot downgrade from %s to %s", agentVersion, c.Version)
+	}
+
+	findResult, err := client.FindTools(c.Version.Major, c.Version.Minor, "", "")
+	if err == nil {
+		err = findResult.Error
+	}
+	if err != nil {
+		return errors.Annotatef(err, "cannot find agent binaries for %s", c.Version)
+	}
+	if _, err := findResult.List.Match(coretools.Filter{Number: c.Version}); err != nil {
+		return errors.Errorf("no agent binaries available for %s", c.Version)
+	}
+
+	if ok, err := c.confirmDowngrade(ctx, agentVersion); !ok || err != nil {
+		const message = "model not downgraded"
+		if err != nil {
+			return errors.Annotate(err, message)
+		}
+		return errors.New(message)
+	}
+	if err := client.AbortModelAgentCanary(); err != nil && !params.IsCodeNotFound(err) {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	// Only the controller model records controller upgrades.
+	controller, err := c.ClientStore().ControllerByName(c.ControllerName())
+	if err != nil {
+		return err
+	}
+	if cfg.UUID() == controller.ControllerUUID {
+		if err := client.AbortCurrentUpgrade(); err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+	}
+	if err := client.SetModelAgentVersion(c.Version); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	logger.Infof("started downgrade from %s to %s", agentVersion, c.Version)
+	return nil
+}
+
+const downgradeMessage = `
+WARNING! downgrading from %s to %s abandons any upgrade in progress.
+Only agents whose upgrade steps failed, or which can move to an older
+patch release, will be downgraded.
+
+Continue [y/N]? `
+
+func (c *downgradeJujuCommand) confirmDowngrade(ctx *cmd.Context, agentVersion version.Number) (bool, error) {
+	if c.AssumeYes {
+		return true, nil
+	}
+	fmt.Fprintf(ctx.Stdout, downgradeMessage[1:], agentVersion, c.Version)
+	scanner := bufio.NewScanner(ctx.Stdin)
+	scanner.Scan()
+	err := scanner.Err()
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	answer := strings.ToLower(scanner.Text())
+	return answer == "y" || answer == "yes", nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/provider/dummy"
+	coretesting "github.com/juju/juju/testing"
+	coretools "github.com/juju/juju/tools"
+)
+
+type downgradeJujuSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	fakeAPI *fakeDowngradeJujuAPI
+	store   *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&downgradeJujuSuite{})
+
+func (s *downgradeJujuSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fakeAPI = &fakeDowngradeJujuAPI{
+		modelUUID:    coretesting.ModelTag.Id(),
+		agentVersion: "2.0.5",
+		tools:        []string{"2.0.3-trusty-amd64", "2.0.4-trusty-amd64"},
+	}
+	s.PatchValue(&getDowngradeJujuAPI, func(*downgradeJujuCommand) (downgradeJujuAPI, error) {
+		return s.fakeAPI, nil
+	})
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "ctrl"
+	s.store.Controllers["ctrl"] = jujuclient.ControllerDetails{
+		ControllerUUID: coretesting.ModelTag.Id(),
+	}
+	s.store.Accounts["ctrl"] = &jujuclient.ControllerAccounts{
+		CurrentAccount: "admin@local",
+	}
+}
+
+func (s *downgradeJujuSuite) runDowngradeJuju(c *gc.C, stdin string, args ...string) (*cmd.Context, error) {
+	command := &downgradeJujuCommand{}
+	command.SetClientStore(s.store)
+	wrapped := modelcmd.Wrap(command)
+	if err := coretesting.InitCommand(wrapped, append([]string{"-m", "ctrl:admin"}, args...)); err != nil {
+		return nil, err
+	}
+	ctx := coretesting.Context(c)
+	ctx.Stdin = strings.NewReader(stdin)
+	return ctx, wrapped.Run(ctx)
+}
+
+func (s *downgradeJujuSuite) TestAbortCanary(c *gc.C) {
+	ctx, err := s.runDowngradeJuju(c, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, "canary upgrade abandoned\n")
+	c.Assert(s.fakeAPI.abortCanaryCalled, jc.IsTrue)
+	c.Assert(s.fakeAPI.setVersionCalledWith, gc.Equals, version.Zero)
+}
+
+func (s *downgradeJujuSuite) TestAbortCanaryNoneInProgress(c *gc.C) {
+	s.fakeAPI.abortCanaryErr = &params.Error{Code: params.CodeNotFound, Message: "canary upgrade not found"}
+	_, err := s.runDowngradeJuju(c, "")
+	c.Assert(err, gc.ErrorMatches, "no canary upgrade in progress; specify --version to roll back the model")
+}
+
+func (s *downgradeJujuSuite) TestDowngradeControllerModel(c *gc.C) {
+	_, err := s.runDowngradeJuju(c, "", "--version", "2.0.3", "-y")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeAPI.abortCanaryCalled, jc.IsTrue)
+	c.Assert(s.fakeAPI.abortCurrentUpgradeCalled, jc.IsTrue)
+	c.Assert(s.fakeAPI.setVersionCalledWith, gc.Equals, version.MustParse("2.0.3"))
+}
+
+func (s *downgradeJujuSuite) TestDowngradeHostedModel(c *gc.C) {
+	s.fakeAPI.modelUUID = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	s.fakeAPI.abortCanaryErr = &params.Error{Code: params.CodeNotFound, Message: "canary upgrade not found"}
+	_, err := s.runDowngradeJuju(c, "y\n", "--version", "2.0.4")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeAPI.abortCurrentUpgradeCalled, jc.IsFalse)
+	c.Assert(s.fakeAPI.setVersionCalledWith, gc.Equals, version.MustParse("2.0.4"))
+}
+
+func (s *downgradeJujuSuite) TestDowngradeNotConfirmed(c *gc.C) {
+	ctx, err := s.runDowngradeJuju(c, "n\n", "--version", "2.0.3")
+	c.Assert(err, gc.ErrorMatches, "model not downgraded")
+	c.Assert(coretesting.Stdout(ctx), gc.Matches, "(?s)WARNING! downgrading from 2.0.5 to 2.0.3 .*")
+	c.Assert(s.fakeAPI.abortCanaryCalled, jc.IsFalse)
+	c.Assert(s.fakeAPI.setVersionCalledWith, gc.Equals, version.Zero)
+}
+
+func (s *downgradeJujuSuite) TestDowngradeNotOlder(c *gc.C) {
+	_, err := s.runDowngradeJuju(c, "", "--version", "2.0.6", "-y")
+	c.Assert(err, gc.ErrorMatches, "cannot downgrade from 2.0.5 to 2.0.6")
+	c.Assert(s.fakeAPI.setVersionCalledWith, gc.Equals, version.Zero)
+}
+
+func (s *downgradeJujuSuite) TestDowngradeNoAgentBinaries(c *gc.C) {
+	_, err := s.runDowngradeJuju(c, "", "--version", "2.0.1", "-y")
+	c.Assert(err, gc.ErrorMatches, "no agent binaries available for 2.0.1")
+	c.Assert(s.fakeAPI.setVersionCalledWith, gc.Equals, version.Zero)
+}
+
+type fakeDowngradeJujuAPI struct {
+	modelUUID                 string
+	agentVersion              string
+	tools                     []string
+	abortCanaryErr            error
+	abortCanaryCalled         bool
+	abortCurrentUpgradeCalled bool
+	setVersionCalledWith      version.Number
+}
+
+func (a *fakeDowngradeJujuAPI) ModelGet() (map[string]interface{}, error) {
+	return dummy.SampleConfig().Merge(map[string]interface{}{
+		"uuid":          a.modelUUID,
+		"agent-version": a.agentVersion,
+	}), nil
+}
+
+func (a *fakeDowngradeJujuAPI) FindTools(majorVersion, minorVersion int, series, arch string) (params.FindToolsResult, error) {
+	var list coretools.List
+	for _, vers := range a.tools {
+		list = append(list, &coretools.Tools{Version: version.MustParseBinary(vers)})
+	}
+	return params.FindToolsResult{List: list}, nil
+}
+
+func (a *fakeDowngradeJujuAPI) AbortCurrentUpgrade() error {
+	a.abortCurrentUpgradeCalled = true
+	return nil
+}
+
+func (a *fakeDowngradeJujuAPI) AbortModelAgentCanary() error {
+	a.abortCanaryCalled = true
+	return a.abortCanaryErr
+}
+
+func (a *fakeDowngradeJujuAPI) SetModelAgentVersion(v version.Number) error {
+	a.setVersionCalledWith = v
+	return nil
+}
+
+func (a *fakeDowngradeJujuAPI) Close() error {
+	return nil
+}
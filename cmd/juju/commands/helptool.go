@@ -12,6 +12,7 @@ import (
 	"gopkg.in/juju/charm.v6-unstable"
 	"launchpad.net/gnuflag"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -48,6 +49,12 @@ func (dummyHookContext) OpenedPorts() []network.PortRange {
 func (dummyHookContext) ConfigSettings() (charm.Settings, error) {
 	return charm.NewConfig().DefaultSettings(), nil
 }
+func (dummyHookContext) CloudSpec() (*params.CloudSpec, error) {
+	return nil, errors.NotFoundf("CloudSpec")
+}
+func (dummyHookContext) GoalState() (*params.GoalState, error) {
+	return nil, errors.NotFoundf("GoalState")
+}
 func (dummyHookContext) HookRelation() (jujuc.ContextRelation, error) {
 	return nil, errors.NotFoundf("HookRelation")
 }
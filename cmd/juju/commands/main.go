@@ -245,8 +245,12 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewModelGetConstraintsCommand())
 	r.Register(model.NewModelSetConstraintsCommand())
 	r.Register(newSyncToolsCommand())
+	r.Register(newSyncAgentBinariesCommand())
+	r.Register(newSyncCharmsCommand())
 	r.Register(newUpgradeJujuCommand(nil))
+	r.Register(newDowngradeJujuCommand())
 	r.Register(application.NewUpgradeCharmCommand())
+	r.Register(application.NewRollbackCharmCommand())
 
 	// Charm publishing commands.
 	r.Register(newPublishCommand())
@@ -287,6 +291,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	// Manage machines
 	r.Register(machine.NewAddCommand())
 	r.Register(machine.NewRemoveCommand())
+	r.Register(machine.NewUpgradeSeriesCommand())
 	r.Register(machine.NewListMachinesCommand())
 	r.Register(machine.NewShowMachineCommand())
 
@@ -294,6 +299,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewGetCommand())
 	r.Register(model.NewSetCommand())
 	r.Register(model.NewUnsetCommand())
+	r.Register(model.NewDefaultsCommand())
+	r.Register(model.NewSetDefaultsCommand())
+	r.Register(model.NewUnsetDefaultsCommand())
 	r.Register(model.NewRetryProvisioningCommand())
 	r.Register(model.NewDestroyCommand())
 	r.Register(model.NewUsersCommand())
@@ -320,6 +328,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewSetCommand())
 	r.Register(application.NewDeployCommand())
 	r.Register(application.NewExposeCommand())
+	r.Register(application.NewTrustCommand())
+	r.Register(application.NewSetPlacementPolicyCommand())
 	r.Register(application.NewUnexposeCommand())
 	r.Register(application.NewServiceGetConstraintsCommand())
 	r.Register(application.NewServiceSetConstraintsCommand())
@@ -362,12 +372,15 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewRegisterCommand())
 	r.Register(controller.NewUnregisterCommand(jujuclient.NewFileClientStore()))
 	r.Register(controller.NewRemoveBlocksCommand())
+	r.Register(controller.NewRotateCertificatesCommand())
+	r.Register(controller.NewSetAPICertificateCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewGetConfigCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
 	r.Register(metricsdebug.NewCollectMetricsCommand())
+	r.Register(metricsdebug.NewMetricsCommand())
 	r.Register(setmeterstatus.New())
 
 	// Manage clouds and credentials
@@ -396,6 +396,7 @@ var commandNames = []string{
 	"destroy-application",
 	"destroy-unit",
 	"disable-user",
+	"downgrade-juju",
 	"download-backup",
 	"enable-ha",
 	"enable-user",
@@ -439,6 +440,8 @@ var commandNames = []string{
 	"logout",
 	"machine",
 	"machines",
+	"metrics",
+	"model-defaults",
 	"models",
 	"plans",
 	"publish",
@@ -459,9 +462,12 @@ var commandNames = []string{
 	"restore-backup",
 	"retry-provisioning",
 	"revoke",
+	"rollback-charm",
+	"rotate-certificates",
 	"run",
 	"run-action",
 	"scp",
+	"set-api-certificate",
 	"set-budget",
 	"set-config",
 	"set-configs",
@@ -471,6 +477,8 @@ var commandNames = []string{
 	"set-meter-status",
 	"set-model-config",
 	"set-model-constraints",
+	"set-model-defaults",
+	"set-placement-policy",
 	"set-plan",
 	"ssh-key",
 	"ssh-keys",
@@ -496,17 +504,22 @@ var commandNames = []string{
 	"storage-pools",
 	"subnets",
 	"switch",
+	"sync-agent-binaries",
+	"sync-charms",
 	"sync-tools",
+	"trust",
 	"unblock",
 	"unexpose",
 	"update-allocation",
 	"upload-backup",
 	"unregister",
 	"unset-model-config",
+	"unset-model-defaults",
 	"update-clouds",
 	"upgrade-charm",
 	"upgrade-gui",
 	"upgrade-juju",
+	"upgrade-series",
 	"users",
 	"version",
 }
@@ -4,8 +4,11 @@
 package commands
 
 import (
+	"fmt"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -22,10 +25,12 @@ type migrateCommand struct {
 
 	model            string
 	targetController string
+	dryRun           bool
 }
 
 type migrateAPI interface {
 	InitiateModelMigration(spec controller.ModelMigrationSpec) (string, error)
+	PrecheckModelMigration(spec controller.ModelMigrationSpec) ([]string, error)
 }
 
 const migrateDoc = `
@@ -51,6 +56,14 @@ This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
 "status" command and by consulting the logs.
 
+Before a migration starts, checks are run on both controllers: every
+machine and unit agent must be running and at the model's version, no
+unit may be in error, neither controller may be upgrading, and the
+target controller must run at least the model's version and have the
+model's cloud, region and credential. If any check fails the migration
+is aborted. Use --dry-run to run only these checks and list every
+problem found, without starting a migration.
+
 See Also:
    juju help login
    juju help controllers
@@ -67,6 +80,11 @@ func (c *migrateCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.dryRun, "dry-run", false, "Only check whether the model can be migrated")
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -127,6 +145,9 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.dryRun {
+		return c.precheck(ctx, api, spec)
+	}
 	id, err := api.InitiateModelMigration(*spec)
 	if err != nil {
 		return err
@@ -135,6 +156,21 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+func (c *migrateCommand) precheck(ctx *cmd.Context, api migrateAPI, spec *controller.ModelMigrationSpec) error {
+	problems, err := api.PrecheckModelMigration(*spec)
+	if err != nil {
+		return err
+	}
+	if len(problems) == 0 {
+		ctx.Infof("Migration prechecks passed")
+		return nil
+	}
+	for _, problem := range problems {
+		fmt.Fprintln(ctx.Stdout, problem)
+	}
+	return errors.Errorf("model %q cannot be migrated to %q", c.model, c.targetController)
+}
+
 func (c *migrateCommand) getAPI() (migrateAPI, error) {
 	if c.api != nil {
 		return c.api, nil
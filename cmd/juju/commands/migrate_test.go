@@ -106,6 +106,36 @@ func (s *MigrateSuite) TestSuccess(c *gc.C) {
 	})
 }
 
+func (s *MigrateSuite) TestDryRun(c *gc.C) {
+	ctx, err := s.runCommand(c, "model", "target", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(testing.Stderr(ctx), gc.Equals, "Migration prechecks passed\n")
+	c.Check(s.api.precheckSpecSeen, jc.DeepEquals, &controller.ModelMigrationSpec{
+		ModelUUID:            modelUUID,
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "admin@local",
+		TargetPassword:       "secret",
+	})
+	c.Check(s.api.specSeen, gc.IsNil) // No migration should have been started.
+}
+
+func (s *MigrateSuite) TestDryRunProblems(c *gc.C) {
+	s.api.problems = []string{
+		"machine 0 agent is not running",
+		"target controller upgrade is in progress",
+	}
+	ctx, err := s.runCommand(c, "model", "target", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, `model "model" cannot be migrated to "target"`)
+
+	c.Check(testing.Stdout(ctx), gc.Equals, ""+
+		"machine 0 agent is not running\n"+
+		"target controller upgrade is in progress\n")
+	c.Check(s.api.specSeen, gc.IsNil)
+}
+
 func (s *MigrateSuite) TestModelDoesntExist(c *gc.C) {
 	_, err := s.runCommand(c, "wat", "target")
 	c.Check(err, gc.ErrorMatches, "model .+ not found")
@@ -127,10 +157,17 @@ func (s *MigrateSuite) runCommand(c *gc.C, args ...string) (*cmd.Context, error)
 }
 
 type fakeMigrateAPI struct {
-	specSeen *controller.ModelMigrationSpec
+	specSeen         *controller.ModelMigrationSpec
+	precheckSpecSeen *controller.ModelMigrationSpec
+	problems         []string
 }
 
 func (a *fakeMigrateAPI) InitiateModelMigration(spec controller.ModelMigrationSpec) (string, error) {
 	a.specSeen = &spec
 	return "uuid:0", nil
 }
+
+func (a *fakeMigrateAPI) PrecheckModelMigration(spec controller.ModelMigrationSpec) ([]string, error) {
+	a.precheckSpecSeen = &spec
+	return a.problems, nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"golang.org/x/crypto/ssh/terminal"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/filestorage"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/sync"
+)
+
+var (
+	syncImageMetadata = sync.SyncImageMetadata
+	signMetadata      = sync.SignMetadata
+)
+
+func newSyncAgentBinariesCommand() cmd.Command {
+	return modelcmd.Wrap(&syncAgentBinariesCommand{})
+}
+
+// syncAgentBinariesCommand mirrors agent binaries, and optionally image
+// metadata, into a model or a self-contained local simplestreams mirror.
+// It extends sync-tools, which it shares its agent binary flags with.
+type syncAgentBinariesCommand struct {
+	syncToolsCommand
+	imageSource string
+	imageStream string
+	signingKey  string
+}
+
+// signingKeyPassphraseEnvKey names the environment variable holding the
+// passphrase used to decrypt the signing key.
+const signingKeyPassphraseEnvKey = "JUJU_SIGNING_KEY_PASSPHRASE"
+
+var _ cmd.Command = (*syncAgentBinariesCommand)(nil)
+
+const syncAgentBinariesDoc = `
+This copies the Juju agent binaries from the official store (located at
+https://streams.canonical.com/juju), or from a local source directory, so
+that they are available to models without Internet access.
+
+Without '--local-dir', the agent binaries are uploaded to the controller's
+blob storage for the model. With '--local-dir', a self-contained
+simplestreams mirror is built in the given directory instead, suitable
+for use as the 'agent-metadata-url' and 'image-metadata-url' of an
+air-gapped cloud. Image metadata may be added to the mirror with
+'--image-source', and the mirror's metadata may be signed with a local
+GPG key using '--signing-key'. The key's passphrase is read from the
+JUJU_SIGNING_KEY_PASSPHRASE environment variable if it is set, and is
+prompted for otherwise; leave it empty if the key is not encrypted.
+
+Only agent binaries and images missing from the destination are copied,
+so the command may be run again to update an existing mirror.
+
+Examples:
+
+Upload the latest agent binaries to the model:
+
+    juju sync-agent-binaries
+
+Build a signed mirror of agent binaries and image metadata:
+
+    juju sync-agent-binaries --local-dir=/srv/mirror \
+        --image-source=/home/ubuntu/images \
+        --signing-key=/home/ubuntu/mirror-key.asc
+
+See also:
+    sync-tools
+    upgrade-juju
+`
+
+func (c *syncAgentBinariesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "sync-agent-binaries",
+		Purpose: "Mirror agent binaries and image metadata for offline clouds.",
+		Doc:     syncAgentBinariesDoc,
+	}
+}
+
+func (c *syncAgentBinariesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.setSyncFlags(f)
+	f.StringVar(&c.imageSource, "image-source", "", "Directory or URL of image metadata to add to the mirror")
+	f.StringVar(&c.imageStream, "image-stream", imagemetadata.ReleasedStream, "Simplestreams stream for which to sync image metadata")
+	f.StringVar(&c.signingKey, "signing-key", "", "File containing the armored private key used to sign the mirror's metadata")
+}
+
+func (c *syncAgentBinariesCommand) Init(args []string) error {
+	if c.localDir == "" {
+		if c.imageSource != "" {
+			return errors.New("--image-source requires --local-dir")
+		}
+		if c.signingKey != "" {
+			return errors.New("--signing-key requires --local-dir")
+		}
+	}
+	return c.syncToolsCommand.Init(args)
+}
+
+// Run copies any missing agent binaries and image metadata to the
+// destination, and signs the destination's metadata if requested.
+func (c *syncAgentBinariesCommand) Run(ctx *cmd.Context) error {
+	// Register writer for output on screen.
+	loggo.RegisterWriter("syncagentbinaries", cmd.NewCommandLogWriter("juju.environs.sync", ctx.Stdout, ctx.Stderr), loggo.INFO)
+	defer loggo.RemoveWriter("syncagentbinaries")
+
+	if c.localDir == "" {
+		return c.syncToModel()
+	}
+
+	// Read the signing key and its passphrase up front, so that
+	// problems are reported before anything is copied.
+	var signingKey, passphrase string
+	if c.signingKey != "" {
+		keyData, err := ioutil.ReadFile(ctx.AbsPath(c.signingKey))
+		if err != nil {
+			return errors.Annotate(err, "cannot read signing key")
+		}
+		signingKey = string(keyData)
+		if passphrase, err = readSigningKeyPassphrase(ctx); err != nil {
+			return errors.Annotate(err, "cannot read signing key passphrase")
+		}
+	}
+
+	stor, err := filestorage.NewFileStorageWriter(ctx.AbsPath(c.localDir))
+	if err != nil {
+		return err
+	}
+	if err := c.syncToStorage(stor); err != nil {
+		return errors.Annotate(err, "cannot sync agent binaries")
+	}
+	if c.imageSource != "" {
+		if _, err := syncImageMetadata(c.imageSource, c.imageStream, stor, c.dryRun); err != nil {
+			return errors.Annotate(err, "cannot sync image metadata")
+		}
+	}
+	if signingKey != "" && !c.dryRun {
+		if err := signMetadata(stor, signingKey, passphrase); err != nil {
+			return errors.Annotate(err, "cannot sign metadata")
+		}
+	}
+	return nil
+}
+
+// readSigningKeyPassphrase returns the signing key passphrase from the
+// environment, or prompts for it. Reading the passphrase from a flag
+// would expose it in the process list and shell history.
+func readSigningKeyPassphrase(ctx *cmd.Context) (string, error) {
+	if passphrase, ok := os.LookupEnv(signingKeyPassphraseEnvKey); ok {
+		return passphrase, nil
+	}
+	fmt.Fprint(ctx.Stderr, "Enter signing key passphrase (empty if none): ")
+	defer fmt.Fprintln(ctx.Stderr)
+	if f, ok := ctx.Stdin.(*os.File); ok && terminal.IsTerminal(int(f.Fd())) {
+		passphrase, err := terminal.ReadPassword(int(f.Fd()))
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		return string(passphrase), nil
+	}
+	line, err := bufio.NewReader(ctx.Stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", errors.Trace(err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
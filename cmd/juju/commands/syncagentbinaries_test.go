@@ -0,0 +1,223 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/storage"
+	"github.com/juju/juju/environs/sync"
+	envtools "github.com/juju/juju/environs/tools"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type syncAgentBinariesSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	fakeSyncToolsAPI *fakeSyncToolsAPI
+	store            *jujuclienttesting.MemStore
+	calls            []string
+}
+
+var _ = gc.Suite(&syncAgentBinariesSuite{})
+
+func (s *syncAgentBinariesSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fakeSyncToolsAPI = &fakeSyncToolsAPI{}
+	s.PatchValue(&getSyncToolsAPI, func(*syncToolsCommand) (syncToolsAPI, error) {
+		return s.fakeSyncToolsAPI, nil
+	})
+	s.calls = nil
+	s.PatchValue(&syncTools, func(*sync.SyncContext) error {
+		s.calls = append(s.calls, "syncTools")
+		return nil
+	})
+	s.PatchValue(&syncImageMetadata, func(source, stream string, target storage.Storage, dryRun bool) (int, error) {
+		s.calls = append(s.calls, "syncImageMetadata")
+		return 0, nil
+	})
+	s.PatchValue(&signMetadata, func(stor storage.Storage, key, passphrase string) error {
+		s.calls = append(s.calls, "signMetadata")
+		return nil
+	})
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "ctrl"
+	s.store.Accounts["ctrl"] = &jujuclient.ControllerAccounts{
+		CurrentAccount: "admin@local",
+	}
+}
+
+func (s *syncAgentBinariesSuite) runSyncAgentBinaries(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &syncAgentBinariesCommand{}
+	command.SetClientStore(s.store)
+	return coretesting.RunCommand(c, modelcmd.Wrap(command), append([]string{"-m", "test-target"}, args...)...)
+}
+
+func (s *syncAgentBinariesSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--version", "foo"},
+		err:  `invalid major version number foo: .*`,
+	}, {
+		args: []string{"--image-source", "/images"},
+		err:  "--image-source requires --local-dir",
+	}, {
+		args: []string{"--signing-key", "key.asc"},
+		err:  "--signing-key requires --local-dir",
+	}, {
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		command := &syncAgentBinariesCommand{}
+		command.SetClientStore(s.store)
+		err := coretesting.InitCommand(modelcmd.Wrap(command), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *syncAgentBinariesSuite) TestSyncToModel(c *gc.C) {
+	s.PatchValue(&syncTools, func(sctx *sync.SyncContext) error {
+		c.Assert(sctx.AllVersions, jc.IsTrue)
+		c.Assert(sctx.MajorVersion, gc.Equals, 2)
+		c.Assert(sctx.MinorVersion, gc.Equals, 0)
+		c.Assert(sctx.TargetToolsUploader, gc.FitsTypeOf, syncToolsAPIAdapter{})
+		uploader := sctx.TargetToolsUploader.(syncToolsAPIAdapter)
+		c.Assert(uploader.syncToolsAPI, gc.Equals, s.fakeSyncToolsAPI)
+		s.calls = append(s.calls, "syncTools")
+		return nil
+	})
+	_, err := s.runSyncAgentBinaries(c, "--all", "--version", "2.0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.calls, jc.DeepEquals, []string{"syncTools"})
+}
+
+func (s *syncAgentBinariesSuite) TestSyncToLocalDir(c *gc.C) {
+	dir := c.MkDir()
+	s.PatchValue(&syncTools, func(sctx *sync.SyncContext) error {
+		c.Assert(sctx.Stream, gc.Equals, "proposed")
+		c.Assert(sctx.TargetToolsUploader, gc.FitsTypeOf, sync.StorageToolsUploader{})
+		uploader := sctx.TargetToolsUploader.(sync.StorageToolsUploader)
+		c.Assert(uploader.WriteMetadata, jc.IsTrue)
+		c.Assert(uploader.WriteMirrors, gc.Equals, envtools.WriteMirrors)
+		url, err := uploader.Storage.URL("")
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(url, gc.Equals, utils.MakeFileURL(dir))
+		s.calls = append(s.calls, "syncTools")
+		return nil
+	})
+	_, err := s.runSyncAgentBinaries(c, "--local-dir", dir, "--stream", "proposed", "--public")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.calls, jc.DeepEquals, []string{"syncTools"})
+}
+
+func (s *syncAgentBinariesSuite) TestSyncImagesAndSign(c *gc.C) {
+	dir := c.MkDir()
+	keyFile := filepath.Join(c.MkDir(), "key.asc")
+	err := ioutil.WriteFile(keyFile, []byte("private key"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(&syncImageMetadata, func(source, stream string, target storage.Storage, dryRun bool) (int, error) {
+		c.Assert(source, gc.Equals, "/images")
+		c.Assert(stream, gc.Equals, "daily")
+		c.Assert(dryRun, jc.IsFalse)
+		url, err := target.URL("")
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(url, gc.Equals, utils.MakeFileURL(dir))
+		s.calls = append(s.calls, "syncImageMetadata")
+		return 1, nil
+	})
+	s.PatchValue(&signMetadata, func(stor storage.Storage, key, passphrase string) error {
+		c.Assert(key, gc.Equals, "private key")
+		c.Assert(passphrase, gc.Equals, "secret")
+		s.calls = append(s.calls, "signMetadata")
+		return nil
+	})
+	s.PatchEnvironment(signingKeyPassphraseEnvKey, "secret")
+	_, err = s.runSyncAgentBinaries(c,
+		"--local-dir", dir,
+		"--image-source", "/images",
+		"--image-stream", "daily",
+		"--signing-key", keyFile,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.calls, jc.DeepEquals, []string{"syncTools", "syncImageMetadata", "signMetadata"})
+}
+
+func (s *syncAgentBinariesSuite) TestPassphraseFlagRemoved(c *gc.C) {
+	command := &syncAgentBinariesCommand{}
+	command.SetClientStore(s.store)
+	err := coretesting.InitCommand(modelcmd.Wrap(command), []string{"--passphrase", "secret"})
+	c.Assert(err, gc.ErrorMatches, "flag provided but not defined: --passphrase")
+}
+
+func (s *syncAgentBinariesSuite) TestSignPromptsForPassphrase(c *gc.C) {
+	s.PatchEnvironment(signingKeyPassphraseEnvKey, "")
+	os.Unsetenv(signingKeyPassphraseEnvKey)
+	keyFile := filepath.Join(c.MkDir(), "key.asc")
+	err := ioutil.WriteFile(keyFile, []byte("private key"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(&signMetadata, func(stor storage.Storage, key, passphrase string) error {
+		c.Assert(passphrase, gc.Equals, "secret")
+		s.calls = append(s.calls, "signMetadata")
+		return nil
+	})
+
+	command := &syncAgentBinariesCommand{}
+	command.SetClientStore(s.store)
+	wrapped := modelcmd.Wrap(command)
+	err = coretesting.InitCommand(wrapped, []string{
+		"-m", "test-target", "--local-dir", c.MkDir(), "--signing-key", keyFile,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := coretesting.Context(c)
+	ctx.Stdin = strings.NewReader("secret\n")
+	err = wrapped.Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stderr(ctx), gc.Matches, "Enter signing key passphrase.*\n")
+	c.Assert(s.calls, jc.DeepEquals, []string{"syncTools", "signMetadata"})
+}
+
+func (s *syncAgentBinariesSuite) TestDryRunDoesNotSign(c *gc.C) {
+	keyFile := filepath.Join(c.MkDir(), "key.asc")
+	err := ioutil.WriteFile(keyFile, []byte("private key"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.runSyncAgentBinaries(c,
+		"--local-dir", c.MkDir(),
+		"--image-source", "/images",
+		"--signing-key", keyFile,
+		"--dry-run",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.calls, jc.DeepEquals, []string{"syncTools", "syncImageMetadata"})
+}
+
+func (s *syncAgentBinariesSuite) TestMissingSigningKey(c *gc.C) {
+	_, err := s.runSyncAgentBinaries(c,
+		"--local-dir", c.MkDir(),
+		"--signing-key", filepath.Join(c.MkDir(), "missing.asc"),
+	)
+	c.Assert(err, gc.ErrorMatches, "cannot read signing key: .*")
+	c.Assert(s.calls, gc.HasLen, 0)
+}
+
+func (s *syncAgentBinariesSuite) TestSyncImagesError(c *gc.C) {
+	s.PatchValue(&syncImageMetadata, func(source, stream string, target storage.Storage, dryRun bool) (int, error) {
+		return 0, errors.New("boom")
+	})
+	_, err := s.runSyncAgentBinaries(c, "--local-dir", c.MkDir(), "--image-source", "/images")
+	c.Assert(err, gc.ErrorMatches, "cannot sync image metadata: boom")
+}
@@ -0,0 +1,399 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/macaroon-bakery.v1/httpbakery"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func newSyncCharmsCommand() cmd.Command {
+	return modelcmd.Wrap(&syncCharmsCommand{})
+}
+
+// syncCharmsCommand copies charms, bundles and their resources from the
+// charm store into a portable archive, and imports such archives into
+// the controller's charm mirror.
+type syncCharmsCommand struct {
+	modelcmd.ModelCommandBase
+	urls    []*charm.URL
+	channel string
+	output  string
+	source  string
+}
+
+var _ cmd.Command = (*syncCharmsCommand)(nil)
+
+const syncCharmsDoc = `
+This copies charms and bundles, and the charms' resources, from the charm
+store into the controller's charm mirror. It is generally done when the
+controller is without Internet access.
+
+Syncing is done in two steps. First, on a host with access to the charm
+store, the given charms and bundles are downloaded into a portable archive
+with --output. The archive is then imported into the controller with
+--source, from a host with access to the controller. The SHA384 hashes of
+charms and resources recorded by the charm store are verified on both
+steps, and again by the controller.
+
+The controller must have been bootstrapped with
+--config charmstore-mirror=true, and importing requires controller
+administrator access. Charm store URLs deployed to any model of the
+controller are then resolved against the mirror, and charms are upgraded
+to the latest mirrored revisions.
+
+Examples:
+
+Download charms and a bundle into an archive:
+
+    juju sync-charms cs:mysql cs:trusty/wordpress cs:bundle/wiki-simple --output mirror.tar.gz
+
+Import the archive into the current controller's mirror:
+
+    juju sync-charms --source mirror.tar.gz
+
+See also: sync-tools
+          deploy
+
+`
+
+func (c *syncCharmsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "sync-charms",
+		Args:    "[<charm or bundle> ...]",
+		Purpose: "Copy charms from the charm store into the controller's charm mirror.",
+		Doc:     syncCharmsDoc,
+	}
+}
+
+func (c *syncCharmsCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.channel, "channel", string(csparams.StableChannel), "charm store channel to copy charms from")
+	f.StringVar(&c.output, "output", "", "archive file to copy the charms to")
+	f.StringVar(&c.source, "source", "", "archive file to import the charms from")
+}
+
+func (c *syncCharmsCommand) Init(args []string) error {
+	switch {
+	case c.output != "" && c.source != "":
+		return errors.New("cannot specify both --output and --source")
+	case c.source != "":
+		return cmd.CheckEmpty(args)
+	case c.output == "":
+		return errors.New("one of --output or --source must be specified")
+	case len(args) == 0:
+		return errors.New("no charms or bundles specified")
+	}
+	for _, arg := range args {
+		curl, err := charm.ParseURL(arg)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if curl.Schema != "cs" {
+			return errors.Errorf("%q is not a charm store URL", arg)
+		}
+		c.urls = append(c.urls, curl)
+	}
+	return nil
+}
+
+// syncCharmsStore provides the charm store functionality needed to
+// sync charms. This exists to enable mocking.
+type syncCharmsStore interface {
+	GetArchive(charmstore.CharmID) (charmstore.ArchiveData, error)
+	ListResources([]charmstore.CharmID) ([][]charmresource.Resource, error)
+	GetResource(charmstore.ResourceRequest) (charmstore.ResourceData, error)
+}
+
+var getSyncCharmsStore = func() (syncCharmsStore, error) {
+	return charmstore.NewCustomClient(httpbakery.NewClient(), nil)
+}
+
+// syncCharmsAPI provides an interface with a subset of the
+// api.Client API. This exists to enable mocking.
+type syncCharmsAPI interface {
+	UploadMirroredCharm(curl *charm.URL, hash string, content io.ReadSeeker) error
+	UploadMirroredResource(curl *charm.URL, res charmresource.Resource, content io.ReadSeeker) error
+	Close() error
+}
+
+var getSyncCharmsAPI = func(c *syncCharmsCommand) (syncCharmsAPI, error) {
+	return c.NewAPIClient()
+}
+
+func (c *syncCharmsCommand) Run(ctx *cmd.Context) error {
+	if c.source != "" {
+		return c.importArchive(ctx)
+	}
+	return c.exportArchive(ctx)
+}
+
+// exportArchive downloads the requested charms and bundles into a
+// charm mirror archive.
+func (c *syncCharmsCommand) exportArchive(ctx *cmd.Context) (err error) {
+	store, err := getSyncCharmsStore()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	dir, err := ioutil.TempDir("", "juju-sync-charms")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.RemoveAll(dir)
+
+	f, err := os.Create(ctx.AbsPath(c.output))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = errors.Trace(closeErr)
+		}
+	}()
+	e := &charmExporter{
+		store:   store,
+		channel: csparams.Channel(c.channel),
+		dir:     dir,
+		w:       charmstore.NewMirrorWriter(f),
+		ctx:     ctx,
+		done:    make(map[string]bool),
+	}
+	for _, curl := range c.urls {
+		if err := e.export(curl); err != nil {
+			return errors.Annotatef(err, "cannot sync %q", curl)
+		}
+	}
+	return errors.Trace(e.w.Close())
+}
+
+// charmExporter writes charm store charms, bundles and resources to a
+// charm mirror archive.
+type charmExporter struct {
+	store   syncCharmsStore
+	channel csparams.Channel
+	dir     string
+	w       *charmstore.MirrorWriter
+	ctx     *cmd.Context
+	done    map[string]bool
+}
+
+// download stores the given content in a temporary file and
+// returns its path.
+func (e *charmExporter) download(r io.ReadCloser) (string, error) {
+	defer r.Close()
+	f, err := ioutil.TempFile(e.dir, "download")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, r); err != nil {
+		return "", errors.Trace(err)
+	}
+	return f.Name(), nil
+}
+
+// addFile adds the content of the file at path to the archive with add.
+func addFile(path string, add func(r io.Reader, size int64) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return add(f, info.Size())
+}
+
+func (e *charmExporter) export(curl *charm.URL) error {
+	archive, err := e.store.GetArchive(charmstore.CharmID{URL: curl, Channel: e.channel})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resolved := archive.URL
+	path, err := e.download(archive.ReadCloser)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if e.done[resolved.String()] {
+		return nil
+	}
+	e.done[resolved.String()] = true
+	if resolved.Series == "bundle" {
+		return errors.Trace(e.exportBundle(resolved, path))
+	}
+	return errors.Trace(e.exportCharm(resolved, archive.Hash, path))
+}
+
+func (e *charmExporter) exportBundle(curl *charm.URL, path string) error {
+	bundle, err := charm.ReadBundleArchive(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var names []string
+	for name := range bundle.Data().Applications {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		charmURL, err := charm.ParseURL(bundle.Data().Applications[name].Charm)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if charmURL.Schema != "cs" {
+			continue
+		}
+		if err := e.export(charmURL); err != nil {
+			return errors.Annotatef(err, "cannot sync charm %q of application %q", charmURL, name)
+		}
+	}
+	err = addFile(path, func(r io.Reader, size int64) error {
+		return e.w.AddBundle(curl, r, size)
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	e.ctx.Infof("synced bundle %s", curl)
+	return nil
+}
+
+func (e *charmExporter) exportCharm(curl *charm.URL, hash, path string) error {
+	// Multi-series charms are added once for each supported series,
+	// as charm URLs in the mirror are fully qualified.
+	urls := []*charm.URL{curl}
+	if curl.Series == "" {
+		ch, err := charm.ReadCharmArchive(path)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(ch.Meta().Series) == 0 {
+			return errors.Errorf("charm %q has no series", curl)
+		}
+		urls = nil
+		for _, series := range ch.Meta().Series {
+			urls = append(urls, curl.WithSeries(series))
+		}
+	}
+	for _, seriesURL := range urls {
+		err := addFile(path, func(r io.Reader, size int64) error {
+			return e.w.AddCharm(seriesURL, hash, r, size)
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	results, err := e.store.ListResources([]charmstore.CharmID{{URL: curl, Channel: e.channel}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, res := range results[0] {
+		data, err := e.store.GetResource(charmstore.ResourceRequest{
+			Charm:    curl,
+			Channel:  e.channel,
+			Name:     res.Name,
+			Revision: res.Revision,
+		})
+		if err != nil {
+			return errors.Annotatef(err, "cannot get resource %q", res.Name)
+		}
+		resPath, err := e.download(data.ReadCloser)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, seriesURL := range urls {
+			err := addFile(resPath, func(r io.Reader, size int64) error {
+				return e.w.AddResource(seriesURL, data.Resource, r)
+			})
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	for _, seriesURL := range urls {
+		e.ctx.Infof("synced charm %s", seriesURL)
+	}
+	return nil
+}
+
+// importArchive uploads the charms and resources in a charm mirror
+// archive to the controller's charm mirror.
+func (c *syncCharmsCommand) importArchive(ctx *cmd.Context) error {
+	f, err := os.Open(ctx.AbsPath(c.source))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	dir, err := ioutil.TempDir("", "juju-sync-charms")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.RemoveAll(dir)
+	manifest, err := charmstore.ExtractMirrorArchive(f, dir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := getSyncCharmsAPI(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	for _, ch := range manifest.Charms {
+		curl, err := charm.ParseURL(ch.URL)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		err = uploadFile(filepath.Join(dir, filepath.FromSlash(ch.Path)), func(r io.ReadSeeker) error {
+			return client.UploadMirroredCharm(curl, ch.Hash, r)
+		})
+		if err != nil {
+			return errors.Annotatef(err, "cannot import charm %q", curl)
+		}
+		for _, mres := range ch.Resources {
+			fp, err := charmresource.ParseFingerprint(mres.Fingerprint)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			res := charmresource.Resource{
+				Meta:        charmresource.Meta{Name: mres.Name},
+				Origin:      charmresource.OriginStore,
+				Revision:    mres.Revision,
+				Fingerprint: fp,
+				Size:        mres.Size,
+			}
+			err = uploadFile(filepath.Join(dir, filepath.FromSlash(mres.Path)), func(r io.ReadSeeker) error {
+				return client.UploadMirroredResource(curl, res, r)
+			})
+			if err != nil {
+				return errors.Annotatef(err, "cannot import resource %q of charm %q", mres.Name, curl)
+			}
+		}
+		ctx.Infof("imported charm %s", curl)
+	}
+	return nil
+}
+
+func uploadFile(path string, upload func(io.ReadSeeker) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	return upload(f)
+}
@@ -0,0 +1,233 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+
+	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testcharms"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type syncCharmsSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	fakeStore *fakeSyncCharmsStore
+	fakeAPI   *fakeSyncCharmsAPI
+	store     *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&syncCharmsSuite{})
+
+func (s *syncCharmsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fakeStore = &fakeSyncCharmsStore{
+		archives:  make(map[string]string),
+		resources: make(map[string]string),
+	}
+	s.PatchValue(&getSyncCharmsStore, func() (syncCharmsStore, error) {
+		return s.fakeStore, nil
+	})
+	s.fakeAPI = &fakeSyncCharmsAPI{
+		charms:    make(map[string]string),
+		hashes:    make(map[string]string),
+		resources: make(map[string]string),
+	}
+	s.PatchValue(&getSyncCharmsAPI, func(*syncCharmsCommand) (syncCharmsAPI, error) {
+		return s.fakeAPI, nil
+	})
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "ctrl"
+	s.store.Accounts["ctrl"] = &jujuclient.ControllerAccounts{
+		CurrentAccount: "admin@local",
+	}
+}
+
+func (s *syncCharmsSuite) runSyncCharmsCommand(c *gc.C, args ...string) (*cmd.Context, error) {
+	cmd := &syncCharmsCommand{}
+	cmd.SetClientStore(s.store)
+	return coretesting.RunCommand(c, modelcmd.Wrap(cmd), append([]string{"-m", "test-target"}, args...)...)
+}
+
+func (s *syncCharmsSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"cs:mysql"},
+		err:  "one of --output or --source must be specified",
+	}, {
+		args: []string{"--output", "a", "--source", "b"},
+		err:  "cannot specify both --output and --source",
+	}, {
+		args: []string{"--output", "a"},
+		err:  "no charms or bundles specified",
+	}, {
+		args: []string{"--output", "a", "local:trusty/mysql"},
+		err:  `"local:trusty/mysql" is not a charm store URL`,
+	}, {
+		args: []string{"--source", "a", "cs:mysql"},
+		err:  `unrecognized args: \["cs:mysql"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := coretesting.InitCommand(&syncCharmsCommand{}, test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *syncCharmsSuite) TestSyncCharms(c *gc.C) {
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	s.fakeStore.archives["cs:dummy"] = ch.Path
+	s.fakeStore.resolved = map[string]string{"cs:dummy": "cs:quantal/dummy-5"}
+	s.fakeStore.resources["data"] = "spam"
+
+	output := filepath.Join(c.MkDir(), "mirror.tar.gz")
+	_, err := s.runSyncCharmsCommand(c, "cs:dummy", "--output", output)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.runSyncCharmsCommand(c, "--source", output)
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected, err := ioutil.ReadFile(ch.Path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeAPI.charms, jc.DeepEquals, map[string]string{
+		"cs:quantal/dummy-5": string(expected),
+	})
+	c.Assert(s.fakeAPI.hashes, jc.DeepEquals, map[string]string{
+		"cs:quantal/dummy-5": sha384(string(expected)),
+	})
+	c.Assert(s.fakeAPI.resources, jc.DeepEquals, map[string]string{
+		"cs:quantal/dummy-5 data-3": "spam",
+	})
+	c.Assert(s.fakeAPI.closed, jc.IsTrue)
+}
+
+func (s *syncCharmsSuite) TestSyncCharmsHashMismatch(c *gc.C) {
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	s.fakeStore.archives["cs:dummy"] = ch.Path
+	s.fakeStore.resolved = map[string]string{"cs:dummy": "cs:quantal/dummy-5"}
+	s.fakeStore.hash = sha384("other")
+
+	output := filepath.Join(c.MkDir(), "mirror.tar.gz")
+	_, err := s.runSyncCharmsCommand(c, "cs:dummy", "--output", output)
+	c.Assert(err, gc.ErrorMatches, `cannot sync "cs:dummy": archive of charm "cs:quantal/dummy-5" with SHA384 [0-9a-f]+, expected [0-9a-f]+ not valid`)
+}
+
+func (s *syncCharmsSuite) TestSyncCharmsNotFound(c *gc.C) {
+	output := filepath.Join(c.MkDir(), "mirror.tar.gz")
+	_, err := s.runSyncCharmsCommand(c, "cs:dummy", "--output", output)
+	c.Assert(err, gc.ErrorMatches, `cannot sync "cs:dummy": charm not found`)
+}
+
+type fakeSyncCharmsStore struct {
+	// archives holds the paths of the charm archives by URL.
+	archives map[string]string
+	// resolved holds the resolved URLs of the charms.
+	resolved map[string]string
+	// resources holds the content of each charm's resources by name.
+	resources map[string]string
+	// hash, if set, overrides the SHA384 hash reported for archives.
+	hash string
+}
+
+func sha384(content string) string {
+	h := sha512.New384()
+	h.Write([]byte(content))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (f *fakeSyncCharmsStore) GetArchive(id charmstore.CharmID) (charmstore.ArchiveData, error) {
+	path, ok := f.archives[id.URL.String()]
+	if !ok {
+		return charmstore.ArchiveData{}, errors.NotFoundf("charm")
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return charmstore.ArchiveData{}, err
+	}
+	hash := f.hash
+	if hash == "" {
+		hash = sha384(string(data))
+	}
+	return charmstore.ArchiveData{
+		ReadCloser: ioutil.NopCloser(strings.NewReader(string(data))),
+		URL:        charm.MustParseURL(f.resolved[id.URL.String()]),
+		Hash:       hash,
+		Size:       int64(len(data)),
+	}, nil
+}
+
+func (f *fakeSyncCharmsStore) resource(name string) charmresource.Resource {
+	content := f.resources[name]
+	fp, _ := charmresource.GenerateFingerprint(strings.NewReader(content))
+	return charmresource.Resource{
+		Meta:        charmresource.Meta{Name: name, Type: charmresource.TypeFile, Path: name + ".tgz"},
+		Origin:      charmresource.OriginStore,
+		Revision:    3,
+		Fingerprint: fp,
+		Size:        int64(len(content)),
+	}
+}
+
+func (f *fakeSyncCharmsStore) ListResources(ids []charmstore.CharmID) ([][]charmresource.Resource, error) {
+	var resources []charmresource.Resource
+	for name := range f.resources {
+		resources = append(resources, f.resource(name))
+	}
+	return [][]charmresource.Resource{resources}, nil
+}
+
+func (f *fakeSyncCharmsStore) GetResource(req charmstore.ResourceRequest) (charmstore.ResourceData, error) {
+	return charmstore.ResourceData{
+		ReadCloser: ioutil.NopCloser(strings.NewReader(f.resources[req.Name])),
+		Resource:   f.resource(req.Name),
+	}, nil
+}
+
+type fakeSyncCharmsAPI struct {
+	charms    map[string]string
+	hashes    map[string]string
+	resources map[string]string
+	closed    bool
+}
+
+func (f *fakeSyncCharmsAPI) UploadMirroredCharm(curl *charm.URL, hash string, content io.ReadSeeker) error {
+	data, err := ioutil.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	f.charms[curl.String()] = string(data)
+	f.hashes[curl.String()] = hash
+	return nil
+}
+
+func (f *fakeSyncCharmsAPI) UploadMirroredResource(curl *charm.URL, res charmresource.Resource, content io.ReadSeeker) error {
+	data, err := ioutil.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	f.resources[fmt.Sprintf("%s %s-%d", curl, res.Name, res.Revision)] = string(data)
+	return nil
+}
+
+func (f *fakeSyncCharmsAPI) Close() error {
+	f.closed = true
+	return nil
+}
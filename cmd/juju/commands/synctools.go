@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/environs/filestorage"
+	"github.com/juju/juju/environs/storage"
 	"github.com/juju/juju/environs/sync"
 	envtools "github.com/juju/juju/environs/tools"
 	coretools "github.com/juju/juju/tools"
@@ -82,15 +83,21 @@ func (c *syncToolsCommand) Info() *cmd.Info {
 }
 
 func (c *syncToolsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.setSyncFlags(f)
+	f.BoolVar(&c.dev, "dev", false, "consider development versions as well as released ones\n    DEPRECATED: use --stream instead")
+	f.StringVar(&c.destination, "destination", "", "local destination directory\n    DEPRECATED: use --local-dir instead")
+}
+
+// setSyncFlags sets the flags shared by the commands that sync agent
+// binaries.
+func (c *syncToolsCommand) setSyncFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.allVersions, "all", false, "copy all versions, not just the latest")
 	f.StringVar(&c.versionStr, "version", "", "copy a specific major[.minor] version")
 	f.BoolVar(&c.dryRun, "dry-run", false, "don't copy, just print what would be copied")
-	f.BoolVar(&c.dev, "dev", false, "consider development versions as well as released ones\n    DEPRECATED: use --stream instead")
 	f.BoolVar(&c.public, "public", false, "tools are for a public cloud, so generate mirrors information")
 	f.StringVar(&c.source, "source", "", "local source directory")
 	f.StringVar(&c.stream, "stream", "", "simplestreams stream for which to sync metadata")
 	f.StringVar(&c.localDir, "local-dir", "", "local destination directory")
-	f.StringVar(&c.destination, "destination", "", "local destination directory\n    DEPRECATED: use --local-dir instead")
 }
 
 func (c *syncToolsCommand) Init(args []string) error {
@@ -128,7 +135,18 @@ func (c *syncToolsCommand) Run(ctx *cmd.Context) (resultErr error) {
 	loggo.RegisterWriter("synctools", cmd.NewCommandLogWriter("juju.environs.sync", ctx.Stdout, ctx.Stderr), loggo.INFO)
 	defer loggo.RemoveWriter("synctools")
 
-	sctx := &sync.SyncContext{
+	if c.localDir == "" {
+		return c.syncToModel()
+	}
+	stor, err := filestorage.NewFileStorageWriter(c.localDir)
+	if err != nil {
+		return err
+	}
+	return c.syncToStorage(stor)
+}
+
+func (c *syncToolsCommand) newSyncContext() *sync.SyncContext {
+	return &sync.SyncContext{
 		AllVersions:  c.allVersions,
 		MajorVersion: c.majorVersion,
 		MinorVersion: c.minorVersion,
@@ -136,34 +154,39 @@ func (c *syncToolsCommand) Run(ctx *cmd.Context) (resultErr error) {
 		Stream:       c.stream,
 		Source:       c.source,
 	}
+}
 
-	if c.localDir != "" {
-		stor, err := filestorage.NewFileStorageWriter(c.localDir)
-		if err != nil {
-			return err
-		}
-		writeMirrors := envtools.DoNotWriteMirrors
-		if c.public {
-			writeMirrors = envtools.WriteMirrors
-		}
-		sctx.TargetToolsFinder = sync.StorageToolsFinder{Storage: stor}
-		sctx.TargetToolsUploader = sync.StorageToolsUploader{
-			Storage:       stor,
-			WriteMetadata: true,
-			WriteMirrors:  writeMirrors,
-		}
-	} else {
-		if c.public {
-			logger.Infof("--public is ignored unless --local-dir is specified")
-		}
-		api, err := getSyncToolsAPI(c)
-		if err != nil {
-			return err
-		}
-		defer api.Close()
-		adapter := syncToolsAPIAdapter{api}
-		sctx.TargetToolsFinder = adapter
-		sctx.TargetToolsUploader = adapter
+// syncToModel copies any missing tools to the controller's blob
+// storage for the model.
+func (c *syncToolsCommand) syncToModel() error {
+	if c.public {
+		logger.Infof("--public is ignored unless --local-dir is specified")
+	}
+	api, err := getSyncToolsAPI(c)
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+	adapter := syncToolsAPIAdapter{api}
+	sctx := c.newSyncContext()
+	sctx.TargetToolsFinder = adapter
+	sctx.TargetToolsUploader = adapter
+	return block.ProcessBlockedError(syncTools(sctx), block.BlockChange)
+}
+
+// syncToStorage copies any missing tools to the given storage, and
+// writes simplestreams metadata for them.
+func (c *syncToolsCommand) syncToStorage(stor storage.Storage) error {
+	writeMirrors := envtools.DoNotWriteMirrors
+	if c.public {
+		writeMirrors = envtools.WriteMirrors
+	}
+	sctx := c.newSyncContext()
+	sctx.TargetToolsFinder = sync.StorageToolsFinder{Storage: stor}
+	sctx.TargetToolsUploader = sync.StorageToolsUploader{
+		Storage:       stor,
+		WriteMetadata: true,
+		WriteMirrors:  writeMirrors,
 	}
 	return block.ProcessBlockedError(syncTools(sctx), block.BlockChange)
 }
@@ -11,11 +11,13 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/utils/series"
 	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/apiserver/params"
@@ -23,6 +25,7 @@ import (
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/sync"
+	"github.com/juju/juju/status"
 	coretools "github.com/juju/juju/tools"
 	jujuversion "github.com/juju/juju/version"
 )
@@ -50,13 +53,22 @@ a previous upgrade was not fully completed (e.g.: if one of the
 controllers in a high availability model failed to upgrade).
 If a failed upgrade has been resolved, '--reset-previous-upgrade' can be
 used to allow the upgrade to proceed.
+The '--canary' option upgrades the given machines, and the units they host,
+first. Once their agents report that they are running the new version the
+rest of the model is upgraded; if any of them fails, or does not finish
+within '--canary-timeout', the canary upgrade is abandoned and the canary
+machines return to the model's current version. Agents cannot move back to
+an older minor version, so '--canary' is limited to upgrades to a newer
+patch release of the model's current major.minor version.
 Backups are recommended prior to upgrading.
 
 Examples:
     juju upgrade-juju --dry-run
     juju upgrade-juju --version 2.0.1
+    juju upgrade-juju --version 2.0.2 --canary 3,4
     
 See also: 
+    downgrade-juju
     sync-tools`
 
 func newUpgradeJujuCommand(minUpgradeVers map[int]version.Number, options ...modelcmd.WrapEnvOption) cmd.Command {
@@ -75,6 +87,13 @@ type upgradeJujuCommand struct {
 	DryRun        bool
 	ResetPrevious bool
 	AssumeYes     bool
+	canary        string
+
+	// CanaryMachines holds the ids of the machines to upgrade ahead
+	// of the rest of the model, and CanaryTimeout how long they may
+	// take to do so.
+	CanaryMachines []string
+	CanaryTimeout  time.Duration
 
 	// minMajorUpgradeVersion maps known major numbers to
 	// the minimum version that can be upgraded to that
@@ -98,6 +117,8 @@ func (c *upgradeJujuCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.ResetPrevious, "reset-previous-upgrade", false, "Clear the previous (incomplete) upgrade status (use with care)")
 	f.BoolVar(&c.AssumeYes, "y", false, "Answer 'yes' to confirmation prompts")
 	f.BoolVar(&c.AssumeYes, "yes", false, "")
+	f.StringVar(&c.canary, "canary", "", "Comma-separated ids of machines to upgrade before the rest of the model")
+	f.DurationVar(&c.CanaryTimeout, "canary-timeout", 10*time.Minute, "How long to wait for the canary machines to upgrade")
 }
 
 func (c *upgradeJujuCommand) Init(args []string) error {
@@ -117,6 +138,18 @@ func (c *upgradeJujuCommand) Init(args []string) error {
 		}
 		c.Version = vers
 	}
+	if c.canary != "" {
+		for _, id := range strings.Split(c.canary, ",") {
+			id = strings.TrimSpace(id)
+			if !names.IsValidMachine(id) {
+				return errors.Errorf("invalid canary machine id %q", id)
+			}
+			c.CanaryMachines = append(c.CanaryMachines, id)
+		}
+		if c.CanaryTimeout <= 0 {
+			return errors.New("canary timeout must be positive")
+		}
+	}
 	return cmd.CheckEmpty(args)
 }
 
@@ -169,6 +202,9 @@ type upgradeJujuAPI interface {
 	UploadTools(r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (coretools.List, error)
 	AbortCurrentUpgrade() error
 	SetModelAgentVersion(version version.Number) error
+	SetModelAgentCanary(version version.Number, machineIds []string) error
+	AbortModelAgentCanary() error
+	Status(patterns []string) (*params.FullStatus, error)
 	Close() error
 }
 
@@ -288,6 +324,11 @@ func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
 	if err := context.validate(); err != nil {
 		return err
 	}
+	if len(c.CanaryMachines) > 0 {
+		if err := validateCanaryVersion(agentVersion, context.chosen); err != nil {
+			return err
+		}
+	}
 	// TODO(fwereade): this list may be incomplete, pending envtools.Upload change.
 	ctx.Infof("available tools:\n%s", formatTools(context.tools))
 	ctx.Infof("best version:\n    %s", context.chosen)
@@ -309,6 +350,11 @@ func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
 				return block.ProcessBlockedError(err, block.BlockChange)
 			}
 		}
+		if len(c.CanaryMachines) > 0 {
+			if err := c.upgradeCanaries(ctx, client, context.chosen); err != nil {
+				return err
+			}
+		}
 		if err := client.SetModelAgentVersion(context.chosen); err != nil {
 			if params.IsCodeUpgradeInProgress(err) {
 				return errors.Errorf("%s\n\n"+
@@ -325,6 +371,103 @@ func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
 	return nil
 }
 
+// canaryPollInterval is how often the status of the canary machines is
+// checked while waiting for them to upgrade.
+var canaryPollInterval = 5 * time.Second
+
+// upgradeCanaries upgrades the canary machines to the given version and
+// waits for their agents to report that they are running it. If any of
+// them fails or does not upgrade in time, the canary upgrade is
+// abandoned so that the canary machines return to the model's current
+// version.
+func (c *upgradeJujuCommand) upgradeCanaries(ctx *cmd.Context, client upgradeJujuAPI, vers version.Number) error {
+	if err := client.SetModelAgentCanary(vers, c.CanaryMachines); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("upgrading canary machines %s to %s", strings.Join(c.CanaryMachines, ", "), vers)
+	err := waitForCanaries(client, vers, c.CanaryMachines, c.CanaryTimeout)
+	if err == nil {
+		ctx.Infof("canary machines upgraded")
+		return nil
+	}
+	if abortErr := client.AbortModelAgentCanary(); abortErr != nil {
+		logger.Errorf("cannot abort canary upgrade: %v", abortErr)
+	}
+	return errors.Annotate(err, "canary upgrade abandoned")
+}
+
+// validateCanaryVersion returns an error if a canary upgrade from the
+// model's agent version to the chosen version could not be rolled back,
+// because agents refuse to move back to an older minor version.
+func validateCanaryVersion(agentVersion, chosen version.Number) error {
+	if chosen.Major != agentVersion.Major || chosen.Minor != agentVersion.Minor {
+		return errors.Errorf(
+			"cannot upgrade canary machines to %s: canary upgrades are limited to patch releases of %d.%d",
+			chosen, agentVersion.Major, agentVersion.Minor,
+		)
+	}
+	return nil
+}
+
+// waitForCanaries waits until the agents of all the identified machines
+// are started and running the given version.
+func waitForCanaries(client upgradeJujuAPI, vers version.Number, machineIds []string, timeout time.Duration) error {
+	deadline := time.After(timeout)
+	for {
+		pending, err := pendingCanaries(client, vers, machineIds)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		select {
+		case <-deadline:
+			return errors.Errorf("timed out waiting for machines %s to upgrade", strings.Join(pending, ", "))
+		case <-time.After(canaryPollInterval):
+		}
+	}
+}
+
+// pendingCanaries returns the ids of the machines whose agents are not
+// yet started and running the given version. It returns an error if
+// any of the machines' agents is in error.
+func pendingCanaries(client upgradeJujuAPI, vers version.Number, machineIds []string) ([]string, error) {
+	fullStatus, err := client.Status(nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var pending []string
+	for _, id := range machineIds {
+		machine, ok := findMachineStatus(fullStatus.Machines, id)
+		if !ok {
+			return nil, errors.NotFoundf("machine %s", id)
+		}
+		agent := machine.AgentStatus
+		if agent.Status == string(status.StatusError) {
+			return nil, errors.Errorf("machine %s failed to upgrade: %s", id, agent.Info)
+		}
+		if agent.Version != vers.String() || agent.Status != string(status.StatusStarted) {
+			pending = append(pending, id)
+		}
+	}
+	return pending, nil
+}
+
+// findMachineStatus returns the status of the identified machine, which
+// may be a container, from the given machine statuses.
+func findMachineStatus(machines map[string]params.MachineStatus, id string) (params.MachineStatus, bool) {
+	if machine, ok := machines[id]; ok {
+		return machine, true
+	}
+	for _, machine := range machines {
+		if found, ok := findMachineStatus(machine.Containers, id); ok {
+			return found, true
+		}
+	}
+	return params.MachineStatus{}, false
+}
+
 const resetPreviousUpgradeMessage = `
 WARNING! using --reset-previous-upgrade when an upgrade is in progress
 will cause the upgrade to fail. Only use this option to clear an
@@ -10,6 +10,7 @@ import (
 	"io"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
@@ -841,6 +842,84 @@ func (s *UpgradeJujuSuite) TestResetPreviousUpgrade(c *gc.C) {
 	}
 }
 
+func (s *UpgradeJujuSuite) TestCanaryInvalidMachine(c *gc.C) {
+	cmd := &upgradeJujuCommand{}
+	err := coretesting.InitCommand(modelcmd.Wrap(cmd), []string{"--canary", "1,foo"})
+	c.Assert(err, gc.ErrorMatches, `invalid canary machine id "foo"`)
+}
+
+func (s *UpgradeJujuSuite) runCanaryUpgrade(c *gc.C, fakeAPI *fakeUpgradeJujuAPI) error {
+	s.PatchValue(&canaryPollInterval, time.Millisecond)
+	cmd := &upgradeJujuCommand{}
+	err := coretesting.InitCommand(modelcmd.Wrap(cmd), []string{
+		"--canary", "0/lxd/1", "--canary-timeout", "50ms",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return modelcmd.Wrap(cmd).Run(coretesting.Context(c))
+}
+
+// newCanaryUpgradeJujuAPI returns a fake API offering the next patch
+// release, the only kind of upgrade a canary upgrade may make.
+func newCanaryUpgradeJujuAPI(c *gc.C, st *state.State) *fakeUpgradeJujuAPI {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, st)
+	fakeAPI.nextVersion.Number = jujuversion.Current
+	fakeAPI.nextVersion.Patch++
+	return fakeAPI
+}
+
+func (s *UpgradeJujuSuite) TestCanaryUpgrade(c *gc.C) {
+	fakeAPI := newCanaryUpgradeJujuAPI(c, s.State)
+	fakeAPI.patch(s)
+	fakeAPI.machineStatus.AgentStatus = params.DetailedStatus{
+		Status:  "started",
+		Version: fakeAPI.nextVersion.Number.String(),
+	}
+
+	err := s.runCanaryUpgrade(c, fakeAPI)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeAPI.canaryCalledWith, jc.DeepEquals, []string{"0/lxd/1"})
+	c.Assert(fakeAPI.abortCanaryCalled, jc.IsFalse)
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, fakeAPI.nextVersion.Number)
+}
+
+func (s *UpgradeJujuSuite) TestCanaryUpgradeFailed(c *gc.C) {
+	fakeAPI := newCanaryUpgradeJujuAPI(c, s.State)
+	fakeAPI.patch(s)
+	fakeAPI.machineStatus.AgentStatus = params.DetailedStatus{
+		Status: "error",
+		Info:   "upgrade failed",
+	}
+
+	err := s.runCanaryUpgrade(c, fakeAPI)
+	c.Assert(err, gc.ErrorMatches, "canary upgrade abandoned: machine 0/lxd/1 failed to upgrade: upgrade failed")
+	c.Assert(fakeAPI.abortCanaryCalled, jc.IsTrue)
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, version.Number{})
+}
+
+func (s *UpgradeJujuSuite) TestCanaryUpgradeTimeout(c *gc.C) {
+	fakeAPI := newCanaryUpgradeJujuAPI(c, s.State)
+	fakeAPI.patch(s)
+	fakeAPI.machineStatus.AgentStatus = params.DetailedStatus{
+		Status:  "started",
+		Version: jujuversion.Current.String(),
+	}
+
+	err := s.runCanaryUpgrade(c, fakeAPI)
+	c.Assert(err, gc.ErrorMatches, "canary upgrade abandoned: timed out waiting for machines 0/lxd/1 to upgrade")
+	c.Assert(fakeAPI.abortCanaryCalled, jc.IsTrue)
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, version.Number{})
+}
+
+func (s *UpgradeJujuSuite) TestCanaryUpgradeNotPatchRelease(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.patch(s)
+
+	err := s.runCanaryUpgrade(c, fakeAPI)
+	c.Assert(err, gc.ErrorMatches, "cannot upgrade canary machines to .*: canary upgrades are limited to patch releases of .*")
+	c.Assert(fakeAPI.canaryCalledWith, gc.IsNil)
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, version.Number{})
+}
+
 func NewFakeUpgradeJujuAPI(c *gc.C, st *state.State) *fakeUpgradeJujuAPI {
 	nextVersion := version.Binary{
 		Number: jujuversion.Current,
@@ -864,6 +943,9 @@ type fakeUpgradeJujuAPI struct {
 	setVersionCalledWith      version.Number
 	tools                     []string
 	findToolsCalled           bool
+	canaryCalledWith          []string
+	abortCanaryCalled         bool
+	machineStatus             params.MachineStatus
 }
 
 func (a *fakeUpgradeJujuAPI) reset() {
@@ -872,6 +954,9 @@ func (a *fakeUpgradeJujuAPI) reset() {
 	a.setVersionCalledWith = version.Number{}
 	a.tools = []string{}
 	a.findToolsCalled = false
+	a.canaryCalledWith = nil
+	a.abortCanaryCalled = false
+	a.machineStatus = params.MachineStatus{}
 }
 
 func (a *fakeUpgradeJujuAPI) patch(s *UpgradeJujuSuite) {
@@ -920,6 +1005,27 @@ func (a *fakeUpgradeJujuAPI) SetModelAgentVersion(v version.Number) error {
 	return a.setVersionErr
 }
 
+func (a *fakeUpgradeJujuAPI) SetModelAgentCanary(v version.Number, machineIds []string) error {
+	a.c.Check(v, gc.Equals, a.nextVersion.Number)
+	a.canaryCalledWith = machineIds
+	return nil
+}
+
+func (a *fakeUpgradeJujuAPI) AbortModelAgentCanary() error {
+	a.abortCanaryCalled = true
+	return nil
+}
+
+func (a *fakeUpgradeJujuAPI) Status(patterns []string) (*params.FullStatus, error) {
+	return &params.FullStatus{
+		Machines: map[string]params.MachineStatus{
+			"0": {Containers: map[string]params.MachineStatus{
+				"0/lxd/1": a.machineStatus,
+			}},
+		},
+	}, nil
+}
+
 func (a *fakeUpgradeJujuAPI) Close() error {
 	return nil
 }
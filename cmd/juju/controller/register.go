@@ -23,6 +23,7 @@ import (
 	"golang.org/x/crypto/nacl/secretbox"
 	"golang.org/x/crypto/ssh/terminal"
 	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
@@ -52,6 +53,7 @@ type registerCommand struct {
 	refreshModels func(_ jujuclient.ClientStore, controller, account string) error
 	store         jujuclient.ClientStore
 	EncodedData   string
+	trustSystemCA bool
 }
 
 var usageRegisterSummary = `
@@ -67,6 +69,10 @@ can now either add a model or wait for a model to be shared with them.
 Some machine providers will require the user to be in possession of 
 certain credentials in order to add a model.
 
+If the controller presents a custom certificate for its API (see
+"juju set-api-certificate"), use --trust-system-ca to verify it with
+the system's trusted CAs rather than the controller's own CA.
+
 Examples:
 
     juju register MFATA3JvZDAnExMxMDQuMTU0LjQyLjQ0OjE3MDcwExAxMC4xMjguMC4yOjE3MDcw
@@ -74,7 +80,8 @@ Examples:
 
 See also: 
     add-user
-    change-user-password`
+    change-user-password
+    set-api-certificate`
 
 // Info implements Command.Info
 // `register` may seem generic, but is seen as simple and without potential
@@ -88,7 +95,13 @@ func (c *registerCommand) Info() *cmd.Info {
 	}
 }
 
-// SetFlags implements Command.Init.
+// SetFlags implements Command.SetFlags.
+func (c *registerCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.JujuCommandBase.SetFlags(f)
+	f.BoolVar(&c.trustSystemCA, "trust-system-ca", false, "Verify the controller with the system's trusted CAs instead of its own CA")
+}
+
+// Init implements Command.Init.
 func (c *registerCommand) Init(args []string) error {
 	if len(args) < 1 {
 		return errors.New("registration data missing")
@@ -159,6 +172,10 @@ func (c *registerCommand) Run(ctx *cmd.Context) error {
 		ControllerUUID: responsePayload.ControllerUUID,
 		CACert:         responsePayload.CACert,
 	}
+	if c.trustSystemCA {
+		controllerDetails.CACert = ""
+		controllerDetails.TrustSystemCA = true
+	}
 	if err := c.store.UpdateController(registrationParams.controllerName, controllerDetails); err != nil {
 		return errors.Trace(err)
 	}
@@ -197,7 +197,15 @@ one of them:
 `[1:])
 }
 
-func (s *RegisterSuite) testRegister(c *gc.C) *cmd.Context {
+func (s *RegisterSuite) TestRegisterTrustSystemCA(c *gc.C) {
+	s.testRegister(c, "--trust-system-ca")
+	controller, err := s.store.ControllerByName("controller-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(controller.CACert, gc.Equals, "")
+	c.Assert(controller.TrustSystemCA, jc.IsTrue)
+}
+
+func (s *RegisterSuite) testRegister(c *gc.C, args ...string) *cmd.Context {
 	secretKey := []byte(strings.Repeat("X", 32))
 	respNonce := []byte(strings.Repeat("X", 24))
 
@@ -231,7 +239,7 @@ func (s *RegisterSuite) testRegister(c *gc.C) *cmd.Context {
 
 	registrationData := s.encodeRegistrationData(c, "bob", secretKey)
 	stdin := strings.NewReader("controller-name\nhunter2\nhunter2\n")
-	ctx, err := s.run(c, stdin, registrationData)
+	ctx, err := s.run(c, stdin, append(args, registrationData)...)
 	c.Assert(err, jc.ErrorIsNil)
 
 	// There should have been one POST command to "/register".
@@ -254,12 +262,21 @@ func (s *RegisterSuite) testRegister(c *gc.C) *cmd.Context {
 	// the specified controller name ("controller-name") and user
 	// name from the registration string.
 
+	expectCACert := testing.CACert
+	expectTrustSystemCA := false
+	for _, arg := range args {
+		if arg == "--trust-system-ca" {
+			expectCACert = ""
+			expectTrustSystemCA = true
+		}
+	}
 	controller, err := s.store.ControllerByName("controller-name")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(controller, jc.DeepEquals, &jujuclient.ControllerDetails{
 		ControllerUUID: controllerUUID,
 		APIEndpoints:   []string{s.apiConnection.addr},
-		CACert:         testing.CACert,
+		CACert:         expectCACert,
+		TrustSystemCA:  expectTrustSystemCA,
 	})
 	account, err := s.store.AccountByName("controller-name", "bob@local")
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,118 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewRotateCertificatesCommand returns a command that allows a
+// controller admin to rotate the controller CA and API server
+// certificates.
+func NewRotateCertificatesCommand() cmd.Command {
+	return modelcmd.WrapController(&rotateCertificatesCommand{})
+}
+
+type rotateCertificatesCommand struct {
+	modelcmd.ControllerCommandBase
+	api    rotateCertificatesAPI
+	finish bool
+}
+
+type rotateCertificatesAPI interface {
+	Close() error
+	RotateCertificates(finish bool) (string, bool, error)
+}
+
+var rotateCertificatesDoc = `
+Rotate the CA and API server certificates of a Juju controller.
+
+Rotation happens in two steps. The first step generates a new CA for the
+controller. Both the old and the new CA are trusted while agents learn
+of the new one, and the local client's record of the controller is
+updated to trust both.
+
+Once all agents have picked up the new CA, run the command again with
+--finish. The new CA then replaces the old one, and the controllers
+reissue their API server and MongoDB certificates with it, restarting
+MongoDB to serve them. Both CAs remain trusted meanwhile.
+
+Once the controllers have reissued their certificates, run the command
+with --finish a second time to retire the old CA; the command reports
+any controllers that have not yet done so. The old CA is then no
+longer trusted by the local client, and agents that have not learned
+of the new CA will be unable to connect to the controller.
+
+Other clients of the controller need to have their record of the
+controller updated, for example by registering the controller again.
+
+Examples:
+    juju rotate-certificates
+    juju rotate-certificates --finish
+
+See also:
+    show-controller
+`
+
+// Info implements Command.Info
+func (c *rotateCertificatesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "rotate-certificates",
+		Purpose: "Rotates the controller CA and API server certificates.",
+		Doc:     rotateCertificatesDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *rotateCertificatesCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.finish, "finish", false, "Finish the rotation in progress, retiring the old CA")
+}
+
+func (c *rotateCertificatesCommand) getAPI() (rotateCertificatesAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+// Run implements Command.Run
+func (c *rotateCertificatesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	caCert, complete, err := client.RotateCertificates(c.finish)
+	if err != nil {
+		return errors.Annotate(err, "cannot rotate certificates")
+	}
+
+	controllerName := c.ControllerName()
+	store := c.ClientStore()
+	details, err := store.ControllerByName(controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	details.CACert = caCert
+	if err := store.UpdateController(controllerName, *details); err != nil {
+		return errors.Annotatef(err, "cannot update CA certificate of controller %q", controllerName)
+	}
+
+	switch {
+	case complete:
+		ctx.Infof("Finished rotating certificates for controller %q; the old CA is no longer trusted.", controllerName)
+	case c.finish:
+		ctx.Infof("Controller %q is reissuing its certificates with the new CA.", controllerName)
+		ctx.Infof("Run %q again once it has, to retire the old CA.", "juju rotate-certificates --finish")
+	default:
+		ctx.Infof("Started rotating certificates for controller %q.", controllerName)
+		ctx.Infof("Run %q once all agents have picked up the new CA.", "juju rotate-certificates --finish")
+	}
+	return nil
+}
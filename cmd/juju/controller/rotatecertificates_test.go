@@ -0,0 +1,104 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type rotateCertificatesSuite struct {
+	baseControllerSuite
+	api   *fakeRotateCertificatesAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&rotateCertificatesSuite{})
+
+func (s *rotateCertificatesSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeRotateCertificatesAPI{caCert: "old and new"}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{
+		ControllerUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		CACert:         "old",
+	}
+}
+
+func (s *rotateCertificatesSuite) newCommand() cmd.Command {
+	return controller.NewRotateCertificatesCommandForTest(s.api, s.store)
+}
+
+func (s *rotateCertificatesSuite) TestStart(c *gc.C) {
+	ctx, err := testing.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.called, jc.IsTrue)
+	c.Assert(s.api.finish, jc.IsFalse)
+	c.Assert(s.store.Controllers["fake"].CACert, gc.Equals, "old and new")
+	c.Assert(testing.Stderr(ctx), gc.Matches, `(?s)Started rotating certificates for controller "fake".*--finish.*`)
+}
+
+func (s *rotateCertificatesSuite) TestFinish(c *gc.C) {
+	s.api.caCert = "new and old"
+	ctx, err := testing.RunCommand(c, s.newCommand(), "--finish")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.called, jc.IsTrue)
+	c.Assert(s.api.finish, jc.IsTrue)
+	c.Assert(s.store.Controllers["fake"].CACert, gc.Equals, "new and old")
+	c.Assert(testing.Stderr(ctx), gc.Matches, `(?s)Controller "fake" is reissuing its certificates.*--finish.*`)
+}
+
+func (s *rotateCertificatesSuite) TestFinishComplete(c *gc.C) {
+	s.api.caCert = "new"
+	s.api.complete = true
+	ctx, err := testing.RunCommand(c, s.newCommand(), "--finish")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.called, jc.IsTrue)
+	c.Assert(s.api.finish, jc.IsTrue)
+	c.Assert(s.store.Controllers["fake"].CACert, gc.Equals, "new")
+	c.Assert(testing.Stderr(ctx), gc.Matches, `Finished rotating certificates for controller "fake".*\n`)
+}
+
+func (s *rotateCertificatesSuite) TestUnrecognizedArg(c *gc.C) {
+	_, err := testing.RunCommand(c, s.newCommand(), "whoops")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["whoops"\]`)
+	c.Assert(s.api.called, jc.IsFalse)
+}
+
+func (s *rotateCertificatesSuite) TestError(c *gc.C) {
+	s.api.err = common.ErrPerm
+	_, err := testing.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "cannot rotate certificates: permission denied")
+	c.Assert(s.store.Controllers["fake"].CACert, gc.Equals, "old")
+}
+
+type fakeRotateCertificatesAPI struct {
+	caCert   string
+	complete bool
+	err      error
+	called   bool
+	finish   bool
+}
+
+func (f *fakeRotateCertificatesAPI) Close() error {
+	return nil
+}
+
+func (f *fakeRotateCertificatesAPI) RotateCertificates(finish bool) (string, bool, error) {
+	f.called = true
+	f.finish = finish
+	if f.err != nil {
+		return "", false, f.err
+	}
+	return f.caCert, f.complete, nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cert"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewSetAPICertificateCommand returns a command that installs a custom
+// certificate for clients of the controller API.
+func NewSetAPICertificateCommand() cmd.Command {
+	return modelcmd.WrapController(&setAPICertificateCommand{})
+}
+
+type setAPICertificateCommand struct {
+	modelcmd.ControllerCommandBase
+	api      setAPICertificateAPI
+	certFile string
+	keyFile  string
+	reset    bool
+}
+
+type setAPICertificateAPI interface {
+	Close() error
+	SetAPICertificate(certPEM, keyPEM string) error
+}
+
+var setAPICertificateDoc = `
+Install a custom TLS certificate for clients of the controller API.
+
+By default the controller API servers present a certificate signed by
+the controller's own CA, which clients must be given to trust. This
+command installs a certificate chain and private key, for example
+issued by an organisation's PKI, that the API servers present to
+clients instead. The certificate file should hold the server
+certificate followed by any intermediate certificates, all in PEM
+format.
+
+Agents, and clients that trust the controller CA, continue to be
+served the certificate signed by the controller CA. Other clients are
+given the custom certificate, and should connect using a host name
+that it is valid for.
+
+Use --reset to remove the custom certificate.
+
+Examples:
+    juju set-api-certificate api.example.com.pem api.example.com.key
+    juju set-api-certificate --reset
+
+See also:
+    register
+    rotate-certificates
+`
+
+// Info implements Command.Info
+func (c *setAPICertificateCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-api-certificate",
+		Args:    "<certificate file> <private key file>",
+		Purpose: "Sets the TLS certificate presented to clients of the controller API.",
+		Doc:     setAPICertificateDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setAPICertificateCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.reset, "reset", false, "Remove the custom certificate")
+}
+
+// Init implements Command.Init.
+func (c *setAPICertificateCommand) Init(args []string) error {
+	if c.reset {
+		return cmd.CheckEmpty(args)
+	}
+	switch len(args) {
+	case 0, 1:
+		return errors.New("certificate and private key files must be specified")
+	default:
+		c.certFile, c.keyFile = args[0], args[1]
+		return cmd.CheckEmpty(args[2:])
+	}
+}
+
+func (c *setAPICertificateCommand) getAPI() (setAPICertificateAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+// Run implements Command.Run
+func (c *setAPICertificateCommand) Run(ctx *cmd.Context) error {
+	var certPEM, keyPEM string
+	if !c.reset {
+		certData, err := ioutil.ReadFile(ctx.AbsPath(c.certFile))
+		if err != nil {
+			return errors.Annotate(err, "cannot read certificate")
+		}
+		keyData, err := ioutil.ReadFile(ctx.AbsPath(c.keyFile))
+		if err != nil {
+			return errors.Annotate(err, "cannot read private key")
+		}
+		certPEM, keyPEM = string(certData), string(keyData)
+		if _, _, err := cert.ParseCertAndKey(certPEM, keyPEM); err != nil {
+			return errors.Annotate(err, "invalid certificate or private key")
+		}
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	if err := client.SetAPICertificate(certPEM, keyPEM); err != nil {
+		return errors.Annotate(err, "cannot set API certificate")
+	}
+	return nil
+}
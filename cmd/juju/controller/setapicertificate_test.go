@@ -0,0 +1,124 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type setAPICertificateSuite struct {
+	baseControllerSuite
+	api      *fakeSetAPICertificateAPI
+	store    *jujuclienttesting.MemStore
+	certFile string
+	keyFile  string
+}
+
+var _ = gc.Suite(&setAPICertificateSuite{})
+
+func (s *setAPICertificateSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeSetAPICertificateAPI{}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{
+		ControllerUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		CACert:         testing.CACert,
+	}
+
+	dir := c.MkDir()
+	s.certFile = filepath.Join(dir, "api.pem")
+	s.keyFile = filepath.Join(dir, "api.key")
+	err := ioutil.WriteFile(s.certFile, []byte(testing.ServerCert), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(s.keyFile, []byte(testing.ServerKey), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *setAPICertificateSuite) newCommand() cmd.Command {
+	return controller.NewSetAPICertificateCommandForTest(s.api, s.store)
+}
+
+func (s *setAPICertificateSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "certificate and private key files must be specified",
+	}, {
+		args: []string{"api.pem"},
+		err:  "certificate and private key files must be specified",
+	}, {
+		args: []string{"api.pem", "api.key", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"--reset", "api.pem"},
+		err:  `unrecognized args: \["api.pem"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := testing.RunCommand(c, s.newCommand(), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	c.Assert(s.api.called, jc.IsFalse)
+}
+
+func (s *setAPICertificateSuite) TestSet(c *gc.C) {
+	_, err := testing.RunCommand(c, s.newCommand(), s.certFile, s.keyFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.called, jc.IsTrue)
+	c.Assert(s.api.certPEM, gc.Equals, testing.ServerCert)
+	c.Assert(s.api.keyPEM, gc.Equals, testing.ServerKey)
+}
+
+func (s *setAPICertificateSuite) TestReset(c *gc.C) {
+	_, err := testing.RunCommand(c, s.newCommand(), "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.called, jc.IsTrue)
+	c.Assert(s.api.certPEM, gc.Equals, "")
+	c.Assert(s.api.keyPEM, gc.Equals, "")
+}
+
+func (s *setAPICertificateSuite) TestMismatchedKey(c *gc.C) {
+	err := ioutil.WriteFile(s.keyFile, []byte(testing.CAKey), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = testing.RunCommand(c, s.newCommand(), s.certFile, s.keyFile)
+	c.Assert(err, gc.ErrorMatches, "invalid certificate or private key: .*")
+	c.Assert(s.api.called, jc.IsFalse)
+}
+
+func (s *setAPICertificateSuite) TestError(c *gc.C) {
+	s.api.err = common.ErrPerm
+	_, err := testing.RunCommand(c, s.newCommand(), s.certFile, s.keyFile)
+	c.Assert(err, gc.ErrorMatches, "cannot set API certificate: permission denied")
+}
+
+type fakeSetAPICertificateAPI struct {
+	err     error
+	called  bool
+	certPEM string
+	keyPEM  string
+}
+
+func (f *fakeSetAPICertificateAPI) Close() error {
+	return nil
+}
+
+func (f *fakeSetAPICertificateAPI) SetAPICertificate(certPEM, keyPEM string) error {
+	f.called = true
+	f.certPEM = certPEM
+	f.keyPEM = keyPEM
+	return f.err
+}
@@ -54,3 +54,9 @@ func NewRemoveCommandForTest(api RemoveMachineAPI) (cmd.Command, *RemoveCommand)
 func NewDisksFlag(disks *[]storage.Constraints) *disksFlag {
 	return &disksFlag{disks}
 }
+
+// NewUpgradeSeriesCommandForTest returns an upgrade-series command with
+// the api provided as specified.
+func NewUpgradeSeriesCommandForTest(api UpgradeSeriesAPI) cmd.Command {
+	return modelcmd.Wrap(&upgradeSeriesCommand{api: api})
+}
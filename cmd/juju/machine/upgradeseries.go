@@ -0,0 +1,175 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const (
+	upgradeSeriesPrepare  = "prepare"
+	upgradeSeriesComplete = "complete"
+	upgradeSeriesAbort    = "abort"
+)
+
+// NewUpgradeSeriesCommand returns a command used to upgrade the OS
+// series of a machine in place.
+func NewUpgradeSeriesCommand() cmd.Command {
+	return modelcmd.Wrap(&upgradeSeriesCommand{})
+}
+
+// UpgradeSeriesAPI defines the methods used by the upgrade-series
+// command.
+type UpgradeSeriesAPI interface {
+	UpgradeSeriesPrepare(machineName, series string, force bool) error
+	UpgradeSeriesComplete(machineName string) error
+	UpgradeSeriesAbort(machineName string) error
+	BestAPIVersion() int
+	Close() error
+}
+
+// upgradeSeriesCommand prepares a machine for, and completes, an
+// in-place upgrade of its OS series.
+type upgradeSeriesCommand struct {
+	modelcmd.ModelCommandBase
+	api UpgradeSeriesAPI
+
+	machineId string
+	operation string
+	series    string
+	force     bool
+}
+
+const upgradeSeriesDoc = `
+Upgrading the series of a machine is done in two steps, either side of
+the operator upgrading the OS release of the machine itself.
+
+The "prepare" step locks the machine and runs the pre-series-upgrade
+hook of every unit on it, giving charms the chance to stop their
+workloads cleanly. The Juju agents on the machine are then configured
+for the init system of the new series. Once "juju status --format=yaml"
+shows the machine's upgrade-series status as "prepare completed", upgrade
+the OS release (for example with do-release-upgrade) and reboot the
+machine.
+
+The "complete" step runs the post-series-upgrade hook of every unit on
+the machine, then records the new series of the machine and unlocks it.
+
+If preparing the machine fails (its upgrade-series status is "error"),
+or the upgrade is no longer wanted, the "abort" step runs the
+post-series-upgrade hook of every unit so that workloads are restarted,
+then unlocks the machine without changing its series. Do not abort once
+the OS release of the machine has been upgraded; complete the upgrade
+instead.
+
+By default, the upgrade is refused if the charm of any unit on the
+machine does not support the new series. Use --force to upgrade anyway.
+
+Examples:
+
+    juju upgrade-series 2 prepare xenial
+    juju upgrade-series 2 complete
+    juju upgrade-series 2 abort
+
+See also:
+    machines
+    status
+`
+
+// Info implements Command.Info.
+func (c *upgradeSeriesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "upgrade-series",
+		Args:    "<machine> prepare <series> | <machine> complete | <machine> abort",
+		Purpose: "Upgrades the OS series of a machine in place.",
+		Doc:     upgradeSeriesDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *upgradeSeriesCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.force, "force", false, "Upgrade even if a unit's charm does not support the new series")
+}
+
+// Init implements Command.Init.
+func (c *upgradeSeriesCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("expected a machine and an operation (prepare, complete or abort)")
+	}
+	c.machineId, c.operation = args[0], args[1]
+	if !names.IsValidMachine(c.machineId) {
+		return errors.Errorf("invalid machine id %q", c.machineId)
+	}
+	args = args[2:]
+	switch c.operation {
+	case upgradeSeriesPrepare:
+		if len(args) == 0 {
+			return errors.New("no series specified")
+		}
+		c.series, args = args[0], args[1:]
+	case upgradeSeriesComplete, upgradeSeriesAbort:
+		if c.force {
+			return errors.New("--force is only valid when preparing an upgrade")
+		}
+	default:
+		return errors.Errorf("unknown operation %q; expected prepare, complete or abort", c.operation)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *upgradeSeriesCommand) getAPI() (UpgradeSeriesAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *upgradeSeriesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 3 {
+		return errors.New("upgrading the series of a machine is not supported by this controller")
+	}
+
+	switch c.operation {
+	case upgradeSeriesPrepare:
+		err = client.UpgradeSeriesPrepare(c.machineId, c.series, c.force)
+		if err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+		ctx.Infof(
+			"machine %s is being prepared for an upgrade to %s; once it is prepared, upgrade\n"+
+				"the OS release, reboot, and run \"juju upgrade-series %s complete\"",
+			c.machineId, c.series, c.machineId,
+		)
+	case upgradeSeriesComplete:
+		err = client.UpgradeSeriesComplete(c.machineId)
+		if err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+		ctx.Infof("completing the series upgrade of machine %s", c.machineId)
+	case upgradeSeriesAbort:
+		err = client.UpgradeSeriesAbort(c.machineId)
+		if err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+		ctx.Infof("aborting the series upgrade of machine %s", c.machineId)
+	}
+	return nil
+}
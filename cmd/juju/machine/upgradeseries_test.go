@@ -0,0 +1,143 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type UpgradeSeriesSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeUpgradeSeriesAPI
+}
+
+var _ = gc.Suite(&UpgradeSeriesSuite{})
+
+func (s *UpgradeSeriesSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeUpgradeSeriesAPI{version: 3}
+}
+
+func (s *UpgradeSeriesSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, machine.NewUpgradeSeriesCommandForTest(s.fake), args...)
+}
+
+func (s *UpgradeSeriesSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  `expected a machine and an operation \(prepare, complete or abort\)`,
+	}, {
+		args: []string{"1"},
+		err:  `expected a machine and an operation \(prepare, complete or abort\)`,
+	}, {
+		args: []string{"lxd", "prepare", "xenial"},
+		err:  `invalid machine id "lxd"`,
+	}, {
+		args: []string{"1", "upgrade", "xenial"},
+		err:  `unknown operation "upgrade"; expected prepare, complete or abort`,
+	}, {
+		args: []string{"1", "prepare"},
+		err:  "no series specified",
+	}, {
+		args: []string{"1", "prepare", "xenial", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"1", "complete", "--force"},
+		err:  "--force is only valid when preparing an upgrade",
+	}, {
+		args: []string{"1", "abort", "--force"},
+		err:  "--force is only valid when preparing an upgrade",
+	}, {
+		args: []string{"1", "abort", "xenial"},
+		err:  `unrecognized args: \["xenial"\]`,
+	}} {
+		c.Logf("test %d", i)
+		err := testing.InitCommand(machine.NewUpgradeSeriesCommandForTest(s.fake), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *UpgradeSeriesSuite) TestPrepare(c *gc.C) {
+	ctx, err := s.run(c, "1", "prepare", "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCall(c, 0, "UpgradeSeriesPrepare", "1", "xenial", false)
+	c.Assert(testing.Stderr(ctx), gc.Matches, `(?s)machine 1 is being prepared for an upgrade to xenial.*juju upgrade-series 1 complete.*`)
+}
+
+func (s *UpgradeSeriesSuite) TestPrepareForce(c *gc.C) {
+	_, err := s.run(c, "1", "prepare", "yakkety", "--force")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCall(c, 0, "UpgradeSeriesPrepare", "1", "yakkety", true)
+}
+
+func (s *UpgradeSeriesSuite) TestPrepareError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := s.run(c, "1", "prepare", "xenial")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *UpgradeSeriesSuite) TestComplete(c *gc.C) {
+	_, err := s.run(c, "1", "complete")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []jujutesting.StubCall{
+		{"UpgradeSeriesComplete", []interface{}{"1"}},
+		{"Close", nil},
+	})
+}
+
+func (s *UpgradeSeriesSuite) TestAbort(c *gc.C) {
+	ctx, err := s.run(c, "1", "abort")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []jujutesting.StubCall{
+		{"UpgradeSeriesAbort", []interface{}{"1"}},
+		{"Close", nil},
+	})
+	c.Assert(testing.Stderr(ctx), gc.Equals, "aborting the series upgrade of machine 1\n")
+}
+
+func (s *UpgradeSeriesSuite) TestNotSupported(c *gc.C) {
+	s.fake.version = 2
+	_, err := s.run(c, "1", "prepare", "xenial")
+	c.Assert(err, gc.ErrorMatches, "upgrading the series of a machine is not supported by this controller")
+	s.fake.CheckCallNames(c, "Close")
+}
+
+type fakeUpgradeSeriesAPI struct {
+	jujutesting.Stub
+	version int
+}
+
+func (f *fakeUpgradeSeriesAPI) UpgradeSeriesPrepare(machineName, series string, force bool) error {
+	f.MethodCall(f, "UpgradeSeriesPrepare", machineName, series, force)
+	return f.NextErr()
+}
+
+func (f *fakeUpgradeSeriesAPI) UpgradeSeriesComplete(machineName string) error {
+	f.MethodCall(f, "UpgradeSeriesComplete", machineName)
+	return f.NextErr()
+}
+
+func (f *fakeUpgradeSeriesAPI) UpgradeSeriesAbort(machineName string) error {
+	f.MethodCall(f, "UpgradeSeriesAbort", machineName)
+	return f.NextErr()
+}
+
+func (f *fakeUpgradeSeriesAPI) BestAPIVersion() int {
+	return f.version
+}
+
+func (f *fakeUpgradeSeriesAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
@@ -12,10 +12,11 @@ import (
 )
 
 var (
-	NewClient        = &newClient
-	NewRunClient     = &newRunClient
-	NewServiceClient = &newServiceClient
-	NewAPIConn       = &newAPIConn
+	NewClient             = &newClient
+	NewMetricsQueryClient = &newMetricsQueryClient
+	NewRunClient          = &newRunClient
+	NewServiceClient      = &newServiceClient
+	NewAPIConn            = &newAPIConn
 )
 
 // NewRunClientFnc returns a function that returns a struct that implements the
@@ -0,0 +1,231 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsdebug
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/metricsdebug"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const metricsDoc = `
+Aggregate the metrics collected from units in the model.
+
+The numeric values of each metric are aggregated per unit and metric
+key, optionally over time buckets of the size given by --interval.
+With no arguments, the metrics of all units in the model are
+aggregated; otherwise only those of the given applications and units.
+
+The time range may be bounded with --from and --to, each of which is
+either an RFC3339 timestamp or a duration before the current time.
+
+Examples:
+    juju metrics
+    juju metrics mysql --aggregate max --interval 1h --from 24h
+    juju metrics mysql/0 --keys connections,queries --format json
+    juju metrics --from 2016-08-01T00:00:00Z --to 2016-09-01T00:00:00Z
+
+See also:
+    collect-metrics
+    debug-metrics
+`
+
+// metricsCommand aggregates metrics stored in the juju controller.
+type metricsCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	entities    []params.Entity
+	keys        string
+	from        string
+	to          string
+	interval    time.Duration
+	aggregation string
+}
+
+// NewMetricsCommand creates a new metricsCommand.
+func NewMetricsCommand() cmd.Command {
+	return modelcmd.Wrap(&metricsCommand{})
+}
+
+// Info implements Command.Info.
+func (c *metricsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "metrics",
+		Args:    "[<application or unit> ...]",
+		Purpose: "Aggregates the metrics collected from units.",
+		Doc:     metricsDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *metricsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.keys, "keys", "", "Comma separated metric keys to aggregate")
+	f.StringVar(&c.from, "from", "", "Start of the time range, as an RFC3339 timestamp or a duration ago")
+	f.StringVar(&c.to, "to", "", "End of the time range, as an RFC3339 timestamp or a duration ago")
+	f.DurationVar(&c.interval, "interval", 0, "Size of the time buckets to aggregate over")
+	f.StringVar(&c.aggregation, "aggregate", "sum", "Aggregation to use: sum, avg, max or last")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatMetricsTabular,
+	})
+}
+
+// Init reads and verifies the cli arguments for the metricsCommand.
+func (c *metricsCommand) Init(args []string) error {
+	for _, arg := range args {
+		if names.IsValidUnit(arg) {
+			c.entities = append(c.entities, params.Entity{names.NewUnitTag(arg).String()})
+		} else if names.IsValidApplication(arg) {
+			c.entities = append(c.entities, params.Entity{names.NewApplicationTag(arg).String()})
+		} else {
+			return errors.Errorf("%q is not a valid unit or application", arg)
+		}
+	}
+	switch c.aggregation {
+	case "sum", "avg", "max", "last":
+	default:
+		return errors.Errorf("unknown aggregation %q", c.aggregation)
+	}
+	if c.interval < 0 {
+		return errors.Errorf("invalid interval %v", c.interval)
+	}
+	return nil
+}
+
+// parseTime parses a time given either as an RFC3339 timestamp or
+// as a duration before now.
+func parseTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.Errorf("%q is neither an RFC3339 timestamp nor a duration", value)
+	}
+	return t, nil
+}
+
+// MetricsQueryClient defines the API methods used by the metrics command.
+type MetricsQueryClient interface {
+	AggregateMetrics(query params.MetricsQuery) ([]params.AggregatedMetric, error)
+	BestAPIVersion() int
+	Close() error
+}
+
+var newMetricsQueryClient = func(env modelcmd.ModelCommandBase) (MetricsQueryClient, error) {
+	state, err := env.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return metricsdebug.NewClient(state), nil
+}
+
+// aggregatedMetric is the output form of an aggregated metric.
+type aggregatedMetric struct {
+	Unit  string  `json:"unit" yaml:"unit"`
+	Key   string  `json:"key" yaml:"key"`
+	Time  string  `json:"time,omitempty" yaml:"time,omitempty"`
+	Value float64 `json:"value" yaml:"value"`
+	Count int     `json:"count" yaml:"count"`
+}
+
+// Run implements Command.Run.
+func (c *metricsCommand) Run(ctx *cmd.Context) error {
+	// TODO(fwereade): 2016-03-17 lp:1558657
+	now := time.Now()
+	start, err := parseTime(c.from, now)
+	if err != nil {
+		return errors.Annotate(err, "invalid --from")
+	}
+	end, err := parseTime(c.to, now)
+	if err != nil {
+		return errors.Annotate(err, "invalid --to")
+	}
+	var keys []string
+	for _, key := range strings.Split(c.keys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	client, err := newMetricsQueryClient(c.ModelCommandBase)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 3 {
+		return errors.New("aggregating metrics is not supported by this controller")
+	}
+	metrics, err := client.AggregateMetrics(params.MetricsQuery{
+		Entities:    c.entities,
+		Keys:        keys,
+		Start:       start,
+		End:         end,
+		Interval:    c.interval,
+		Aggregation: c.aggregation,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	result := make([]aggregatedMetric, len(metrics))
+	for i, m := range metrics {
+		result[i] = aggregatedMetric{
+			Unit:  m.Unit,
+			Key:   m.Key,
+			Value: m.Value,
+			Count: m.Count,
+		}
+		if !m.Time.IsZero() {
+			result[i].Time = m.Time.UTC().Format(time.RFC3339)
+		}
+	}
+	return c.out.Write(ctx, result)
+}
+
+// formatMetricsTabular returns a tabular summary of aggregated metrics.
+func formatMetricsTabular(value interface{}) ([]byte, error) {
+	metrics, ok := value.([]aggregatedMetric)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", metrics, value)
+	}
+	if len(metrics) == 0 {
+		return nil, nil
+	}
+	withTime := metrics[0].Time != ""
+	var out bytes.Buffer
+	tw := tabwriter.NewWriter(&out, 0, 1, 1, ' ', 0)
+	if withTime {
+		fmt.Fprintf(tw, "UNIT\tMETRIC\tTIME\tVALUE\tCOUNT\n")
+	} else {
+		fmt.Fprintf(tw, "UNIT\tMETRIC\tVALUE\tCOUNT\n")
+	}
+	for _, m := range metrics {
+		value := strconv.FormatFloat(m.Value, 'f', -1, 64)
+		if withTime {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\n", m.Unit, m.Key, m.Time, value, m.Count)
+		} else {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\n", m.Unit, m.Key, value, m.Count)
+		}
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsdebug_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/metricsdebug"
+	"github.com/juju/juju/cmd/modelcmd"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockMetricsQueryClient struct {
+	testing.Stub
+	version int
+	metrics []params.AggregatedMetric
+}
+
+func (m *mockMetricsQueryClient) AggregateMetrics(query params.MetricsQuery) ([]params.AggregatedMetric, error) {
+	m.Stub.MethodCall(m, "AggregateMetrics", query)
+	return m.metrics, m.NextErr()
+}
+
+func (m *mockMetricsQueryClient) BestAPIVersion() int {
+	return m.version
+}
+
+func (m *mockMetricsQueryClient) Close() error {
+	m.Stub.MethodCall(m, "Close")
+	return nil
+}
+
+type MetricsSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	client *mockMetricsQueryClient
+}
+
+var _ = gc.Suite(&MetricsSuite{})
+
+func (s *MetricsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.client = &mockMetricsQueryClient{version: 3}
+	s.PatchValue(metricsdebug.NewMetricsQueryClient, func(_ modelcmd.ModelCommandBase) (metricsdebug.MetricsQueryClient, error) {
+		return s.client, nil
+	})
+}
+
+func (s *MetricsSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"!!!"},
+		err:  `"!!!" is not a valid unit or application`,
+	}, {
+		args: []string{"--aggregate", "median"},
+		err:  `unknown aggregation "median"`,
+	}, {
+		args: []string{"--interval", "-1h"},
+		err:  `invalid interval -1h0m0s`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := coretesting.RunCommand(c, metricsdebug.NewMetricsCommand(), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.client.CheckNoCalls(c)
+}
+
+func (s *MetricsSuite) TestQuery(c *gc.C) {
+	_, err := coretesting.RunCommand(c, metricsdebug.NewMetricsCommand(),
+		"metered", "metered/1",
+		"--keys", "pings, juju-units",
+		"--from", "2016-08-01T00:00:00Z",
+		"--to", "2016-08-02T00:00:00Z",
+		"--interval", "1h",
+		"--aggregate", "max",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.CheckCall(c, 0, "AggregateMetrics", params.MetricsQuery{
+		Entities: []params.Entity{
+			{"application-metered"},
+			{"unit-metered-1"},
+		},
+		Keys:        []string{"pings", "juju-units"},
+		Start:       time.Date(2016, 8, 1, 0, 0, 0, 0, time.UTC),
+		End:         time.Date(2016, 8, 2, 0, 0, 0, 0, time.UTC),
+		Interval:    time.Hour,
+		Aggregation: "max",
+	})
+}
+
+func (s *MetricsSuite) TestQueryRelativeTime(c *gc.C) {
+	before := time.Now()
+	_, err := coretesting.RunCommand(c, metricsdebug.NewMetricsCommand(), "--from", "24h")
+	c.Assert(err, jc.ErrorIsNil)
+	after := time.Now()
+	s.client.CheckCallNames(c, "AggregateMetrics", "Close")
+	query := s.client.Calls()[0].Args[0].(params.MetricsQuery)
+	c.Assert(query.Entities, gc.HasLen, 0)
+	c.Assert(query.Aggregation, gc.Equals, "sum")
+	c.Assert(query.End.IsZero(), jc.IsTrue)
+	c.Assert(query.Start, jc.TimeBetween(before.Add(-24*time.Hour), after.Add(-24*time.Hour)))
+}
+
+func (s *MetricsSuite) TestInvalidTime(c *gc.C) {
+	_, err := coretesting.RunCommand(c, metricsdebug.NewMetricsCommand(), "--from", "yesterday")
+	c.Assert(err, gc.ErrorMatches, `invalid --from: "yesterday" is neither an RFC3339 timestamp nor a duration`)
+	s.client.CheckNoCalls(c)
+}
+
+func (s *MetricsSuite) TestTabularOutput(c *gc.C) {
+	s.client.metrics = []params.AggregatedMetric{
+		{Unit: "metered/0", Key: "pings", Value: 15.5, Count: 2},
+		{Unit: "metered/1", Key: "pings", Value: 3, Count: 1},
+	}
+	ctx, err := coretesting.RunCommand(c, metricsdebug.NewMetricsCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"UNIT      METRIC VALUE COUNT\n"+
+		"metered/0 pings  15.5  2\n"+
+		"metered/1 pings  3     1\n"+
+		"\n")
+}
+
+func (s *MetricsSuite) TestTabularOutputWithInterval(c *gc.C) {
+	t := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	s.client.metrics = []params.AggregatedMetric{
+		{Unit: "metered/0", Key: "pings", Time: t, Value: 5, Count: 2},
+		{Unit: "metered/0", Key: "pings", Time: t.Add(time.Hour), Value: 7, Count: 1},
+	}
+	ctx, err := coretesting.RunCommand(c, metricsdebug.NewMetricsCommand(), "--interval", "1h")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"UNIT      METRIC TIME                 VALUE COUNT\n"+
+		"metered/0 pings  2016-08-01T10:00:00Z 5     2\n"+
+		"metered/0 pings  2016-08-01T11:00:00Z 7     1\n"+
+		"\n")
+}
+
+func (s *MetricsSuite) TestJSONOutput(c *gc.C) {
+	t := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	s.client.metrics = []params.AggregatedMetric{
+		{Unit: "metered/0", Key: "pings", Time: t, Value: 5, Count: 2},
+	}
+	ctx, err := coretesting.RunCommand(c, metricsdebug.NewMetricsCommand(), "--interval", "1h", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals,
+		`[{"unit":"metered/0","key":"pings","time":"2016-08-01T10:00:00Z","value":5,"count":2}]`+"\n")
+}
+
+func (s *MetricsSuite) TestNoMetrics(c *gc.C) {
+	ctx, err := coretesting.RunCommand(c, metricsdebug.NewMetricsCommand(), "metered")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+}
+
+func (s *MetricsSuite) TestNotSupported(c *gc.C) {
+	s.client.version = 2
+	_, err := coretesting.RunCommand(c, metricsdebug.NewMetricsCommand())
+	c.Assert(err, gc.ErrorMatches, "aggregating metrics is not supported by this controller")
+	s.client.CheckCallNames(c, "Close")
+}
@@ -0,0 +1,346 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/config"
+)
+
+const modelDefaultsHelpDoc = `
+Model config defaults are inherited by every model on the controller,
+unless overridden by a default for the model's cloud region or by
+setting the value on the model itself.
+By default, all defaults (keys, source, and values) are displayed if a
+key is not specified.
+
+Examples:
+
+    juju model-defaults
+    juju model-defaults http-proxy
+
+See also: set-model-defaults
+          unset-model-defaults
+          get-model-config
+`
+
+// NewDefaultsCommand returns a command used to display
+// the model config defaults of a controller.
+func NewDefaultsCommand() cmd.Command {
+	return modelcmd.WrapController(&defaultsCommand{})
+}
+
+// defaultsCommand is able to output either all of the model config
+// defaults, or the defaults for a single key.
+type defaultsCommand struct {
+	modelcmd.ControllerCommandBase
+	api DefaultsAPI
+	key string
+	out cmd.Output
+}
+
+// DefaultsAPI defines the API methods used by the model-defaults command.
+type DefaultsAPI interface {
+	Close() error
+	BestAPIVersion() int
+	ModelDefaults() (config.ModelDefaultAttributes, error)
+}
+
+// Info implements cmd.Command.
+func (c *defaultsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "model-defaults",
+		Args:    "[<model key>]",
+		Purpose: "Displays default configuration settings for new and existing models.",
+		Doc:     strings.TrimSpace(modelDefaultsHelpDoc),
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *defaultsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatDefaultConfigTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *defaultsCommand) Init(args []string) (err error) {
+	c.key, err = cmd.ZeroOrOneArgs(args)
+	return
+}
+
+func (c *defaultsCommand) getAPI() (DefaultsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewModelManagerAPIClient()
+}
+
+// Run implements cmd.Command.
+func (c *defaultsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if err := checkModelDefaultsSupported(client.BestAPIVersion()); err != nil {
+		return err
+	}
+
+	attrs, err := client.ModelDefaults()
+	if err != nil {
+		return err
+	}
+
+	if c.key != "" {
+		value, found := attrs[c.key]
+		if !found {
+			return errors.Errorf("key %q not found in %q model defaults.", c.key, c.ControllerName())
+		}
+		attrs = config.ModelDefaultAttributes{c.key: value}
+	}
+	return c.out.Write(ctx, attrs)
+}
+
+// formatDefaultConfigTabular writes a tabular summary of the model
+// config defaults, with one row for each level of inheritance.
+func formatDefaultConfigTabular(value interface{}) ([]byte, error) {
+	defaultValues, ok := value.(config.ModelDefaultAttributes)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", defaultValues, value)
+	}
+
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+
+	var valueNames []string
+	for name := range defaultValues {
+		valueNames = append(valueNames, name)
+	}
+	sort.Strings(valueNames)
+	fmt.Fprintln(tw, "ATTRIBUTE\tFROM\tVALUE")
+
+	for _, name := range valueNames {
+		info := defaultValues[name]
+		attr := name
+		printRow := func(source string, value interface{}) {
+			fmt.Fprintf(tw, "%s\t%s\t%v\n", attr, source, value)
+			// Only name the attribute on its first row.
+			attr = ""
+		}
+		if info.Default != nil {
+			printRow(config.JujuDefaultSource, info.Default)
+		}
+		if info.Controller != nil {
+			printRow(config.JujuControllerSource, info.Controller)
+		}
+		for _, region := range info.Regions {
+			printRow(config.JujuRegionSource+" "+region.Name, region.Value)
+		}
+	}
+
+	tw.Flush()
+	return out.Bytes(), nil
+}
+
+const setModelDefaultsHelpDoc = `
+Sets model config defaults for the controller, or for models in a cloud
+region if --region is specified. The new defaults are inherited by new
+and existing models that do not set the keys themselves.
+
+Examples:
+
+    juju set-model-defaults http-proxy=http://proxy.example.com:3128
+    juju set-model-defaults --region us-east-1 apt-mirror=http://mirror.example.com
+
+See also: model-defaults
+          unset-model-defaults
+          set-model-config
+`
+
+// NewSetDefaultsCommand returns a command used to set
+// the model config defaults of a controller.
+func NewSetDefaultsCommand() cmd.Command {
+	return modelcmd.WrapController(&setDefaultsCommand{})
+}
+
+// setDefaultsCommand sets model config defaults.
+type setDefaultsCommand struct {
+	modelcmd.ControllerCommandBase
+	api    SetDefaultsAPI
+	region string
+	values attributes
+}
+
+// SetDefaultsAPI defines the API methods used by the set-model-defaults command.
+type SetDefaultsAPI interface {
+	Close() error
+	BestAPIVersion() int
+	SetModelDefaults(cloudRegion string, config map[string]interface{}) error
+}
+
+// Info implements cmd.Command.
+func (c *setDefaultsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-model-defaults",
+		Args:    "<model key>=<value> ...",
+		Purpose: "Sets default configuration keys for new and existing models.",
+		Doc:     strings.TrimSpace(setModelDefaultsHelpDoc),
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *setDefaultsCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.region, "region", "", "Set defaults for models in the specified cloud region only")
+}
+
+// Init implements cmd.Command.
+func (c *setDefaultsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no key, value pairs specified")
+	}
+	options, err := keyvalues.Parse(args, true)
+	if err != nil {
+		return err
+	}
+	c.values = make(attributes)
+	for key, value := range options {
+		if key == config.AgentVersionKey {
+			return errors.New("agent-version must be set via upgrade-juju")
+		}
+		c.values[key] = value
+	}
+	return nil
+}
+
+func (c *setDefaultsCommand) getAPI() (SetDefaultsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewModelManagerAPIClient()
+}
+
+// Run implements cmd.Command.
+func (c *setDefaultsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if err := checkModelDefaultsSupported(client.BestAPIVersion()); err != nil {
+		return err
+	}
+	return client.SetModelDefaults(c.region, c.values)
+}
+
+const unsetModelDefaultsHelpDoc = `
+Removes model config defaults from the controller, or from models in a
+cloud region if --region is specified. Models that do not set the keys
+themselves go back to inheriting the value from the next level up.
+
+Examples:
+
+    juju unset-model-defaults http-proxy
+    juju unset-model-defaults --region us-east-1 apt-mirror
+
+See also: model-defaults
+          set-model-defaults
+`
+
+// NewUnsetDefaultsCommand returns a command used to remove
+// model config defaults from a controller.
+func NewUnsetDefaultsCommand() cmd.Command {
+	return modelcmd.WrapController(&unsetDefaultsCommand{})
+}
+
+// unsetDefaultsCommand removes model config defaults.
+type unsetDefaultsCommand struct {
+	modelcmd.ControllerCommandBase
+	api    UnsetDefaultsAPI
+	region string
+	keys   []string
+}
+
+// UnsetDefaultsAPI defines the API methods used by the
+// unset-model-defaults command.
+type UnsetDefaultsAPI interface {
+	Close() error
+	BestAPIVersion() int
+	UnsetModelDefaults(cloudRegion string, keys ...string) error
+}
+
+// Info implements cmd.Command.
+func (c *unsetDefaultsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "unset-model-defaults",
+		Args:    "<model key> ...",
+		Purpose: "Unsets default configuration keys for new and existing models.",
+		Doc:     strings.TrimSpace(unsetModelDefaultsHelpDoc),
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *unsetDefaultsCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.region, "region", "", "Unset defaults for models in the specified cloud region only")
+}
+
+// Init implements cmd.Command.
+func (c *unsetDefaultsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no keys specified")
+	}
+	c.keys = args
+	return nil
+}
+
+func (c *unsetDefaultsCommand) getAPI() (UnsetDefaultsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewModelManagerAPIClient()
+}
+
+// Run implements cmd.Command.
+func (c *unsetDefaultsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if err := checkModelDefaultsSupported(client.BestAPIVersion()); err != nil {
+		return err
+	}
+	return client.UnsetModelDefaults(c.region, c.keys...)
+}
+
+// checkModelDefaultsSupported returns an error if the controller's
+// ModelManager facade, at the given version, does not support model
+// defaults.
+func checkModelDefaultsSupported(version int) error {
+	if version < 3 {
+		return errors.New("model defaults are not supported by this controller")
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type DefaultsCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  *fakeModelDefaultsAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&DefaultsCommandSuite{})
+
+func (s *DefaultsCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeModelDefaultsAPI{
+		version: 3,
+		defaults: config.ModelDefaultAttributes{
+			"attr": {Controller: "foo"},
+			"attr2": {
+				Default:    "bar",
+				Controller: "baz",
+				Regions: []config.RegionDefaultValue{{
+					Name:  "dummy-region",
+					Value: "dummy-value",
+				}},
+			},
+		},
+	}
+	controllerName := "test-master"
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = controllerName
+	s.store.Controllers[controllerName] = jujuclient.ControllerDetails{}
+	s.store.Accounts[controllerName] = &jujuclient.ControllerAccounts{
+		Accounts: map[string]jujuclient.AccountDetails{
+			"bob@local": {User: "bob@local"},
+		},
+		CurrentAccount: "bob@local",
+	}
+}
+
+func (s *DefaultsCommandSuite) runDefaults(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := model.NewDefaultsCommandForTest(s.fake, s.store)
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *DefaultsCommandSuite) TestDefaultsInit(c *gc.C) {
+	err := testing.InitCommand(model.NewDefaultsCommandForTest(s.fake, s.store), []string{"one", "two"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["two"\]`)
+}
+
+func (s *DefaultsCommandSuite) TestDefaultsTabular(c *gc.C) {
+	context, err := s.runDefaults(c)
+	c.Assert(err, jc.ErrorIsNil)
+	output := strings.TrimSpace(testing.Stdout(context))
+	expected := "" +
+		"ATTRIBUTE  FROM                 VALUE\n" +
+		"attr       controller           foo\n" +
+		"attr2      default              bar\n" +
+		"           controller           baz\n" +
+		"           region dummy-region  dummy-value"
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *DefaultsCommandSuite) TestDefaultsSingleValueYAML(c *gc.C) {
+	context, err := s.runDefaults(c, "attr", "--format=yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	output := strings.TrimSpace(testing.Stdout(context))
+	c.Assert(output, gc.Equals, "attr:\n  controller: foo")
+}
+
+func (s *DefaultsCommandSuite) TestDefaultsUnknownKey(c *gc.C) {
+	_, err := s.runDefaults(c, "unknown")
+	c.Assert(err, gc.ErrorMatches, `key "unknown" not found in "test-master" model defaults.`)
+}
+
+func (s *DefaultsCommandSuite) TestDefaultsNotSupported(c *gc.C) {
+	s.fake.version = 2
+	_, err := s.runDefaults(c)
+	c.Assert(err, gc.ErrorMatches, "model defaults are not supported by this controller")
+}
+
+func (s *DefaultsCommandSuite) TestSetDefaults(c *gc.C) {
+	command := model.NewSetDefaultsCommandForTest(s.fake, s.store)
+	_, err := testing.RunCommand(c, command, "--region", "dummy-region", "attr=value")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.region, gc.Equals, "dummy-region")
+	c.Assert(s.fake.values, jc.DeepEquals, map[string]interface{}{"attr": "value"})
+}
+
+func (s *DefaultsCommandSuite) TestSetDefaultsInit(c *gc.C) {
+	command := model.NewSetDefaultsCommandForTest(s.fake, s.store)
+	err := testing.InitCommand(command, nil)
+	c.Assert(err, gc.ErrorMatches, "no key, value pairs specified")
+	command = model.NewSetDefaultsCommandForTest(s.fake, s.store)
+	err = testing.InitCommand(command, []string{"agent-version=2.0.0"})
+	c.Assert(err, gc.ErrorMatches, "agent-version must be set via upgrade-juju")
+}
+
+func (s *DefaultsCommandSuite) TestSetDefaultsError(c *gc.C) {
+	s.fake.err = errors.New("boom")
+	command := model.NewSetDefaultsCommandForTest(s.fake, s.store)
+	_, err := testing.RunCommand(c, command, "attr=value")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *DefaultsCommandSuite) TestSetDefaultsNotSupported(c *gc.C) {
+	s.fake.version = 2
+	command := model.NewSetDefaultsCommandForTest(s.fake, s.store)
+	_, err := testing.RunCommand(c, command, "attr=value")
+	c.Assert(err, gc.ErrorMatches, "model defaults are not supported by this controller")
+	c.Assert(s.fake.values, gc.IsNil)
+}
+
+func (s *DefaultsCommandSuite) TestUnsetDefaults(c *gc.C) {
+	command := model.NewUnsetDefaultsCommandForTest(s.fake, s.store)
+	_, err := testing.RunCommand(c, command, "attr", "attr2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.region, gc.Equals, "")
+	c.Assert(s.fake.keys, jc.DeepEquals, []string{"attr", "attr2"})
+}
+
+func (s *DefaultsCommandSuite) TestUnsetDefaultsInit(c *gc.C) {
+	command := model.NewUnsetDefaultsCommandForTest(s.fake, s.store)
+	err := testing.InitCommand(command, nil)
+	c.Assert(err, gc.ErrorMatches, "no keys specified")
+}
+
+func (s *DefaultsCommandSuite) TestUnsetDefaultsNotSupported(c *gc.C) {
+	s.fake.version = 2
+	command := model.NewUnsetDefaultsCommandForTest(s.fake, s.store)
+	_, err := testing.RunCommand(c, command, "attr")
+	c.Assert(err, gc.ErrorMatches, "model defaults are not supported by this controller")
+	c.Assert(s.fake.keys, gc.IsNil)
+}
+
+type fakeModelDefaultsAPI struct {
+	version  int
+	defaults config.ModelDefaultAttributes
+	region   string
+	values   map[string]interface{}
+	keys     []string
+	err      error
+}
+
+func (f *fakeModelDefaultsAPI) Close() error {
+	return nil
+}
+
+func (f *fakeModelDefaultsAPI) BestAPIVersion() int {
+	return f.version
+}
+
+func (f *fakeModelDefaultsAPI) ModelDefaults() (config.ModelDefaultAttributes, error) {
+	return f.defaults, f.err
+}
+
+func (f *fakeModelDefaultsAPI) SetModelDefaults(cloudRegion string, values map[string]interface{}) error {
+	f.region = cloudRegion
+	f.values = values
+	return f.err
+}
+
+func (f *fakeModelDefaultsAPI) UnsetModelDefaults(cloudRegion string, keys ...string) error {
+	f.region = cloudRegion
+	f.keys = keys
+	return f.err
+}
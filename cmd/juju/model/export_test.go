@@ -94,3 +94,24 @@ func NewRevokeCommandForTest(api RevokeModelAPI, store jujuclient.ClientStore) (
 	cmd.SetClientStore(store)
 	return modelcmd.WrapController(cmd), &RevokeCommand{cmd}
 }
+
+// NewDefaultsCommandForTest returns a defaultsCommand with the api provided as specified.
+func NewDefaultsCommandForTest(api DefaultsAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &defaultsCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
+
+// NewSetDefaultsCommandForTest returns a setDefaultsCommand with the api provided as specified.
+func NewSetDefaultsCommandForTest(api SetDefaultsAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &setDefaultsCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
+
+// NewUnsetDefaultsCommandForTest returns an unsetDefaultsCommand with the api provided as specified.
+func NewUnsetDefaultsCommandForTest(api UnsetDefaultsAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &unsetDefaultsCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
@@ -6,6 +6,7 @@ package model_test
 import (
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/testing"
 )
 
@@ -17,28 +18,51 @@ type fakeEnvSuite struct {
 func (s *fakeEnvSuite) SetUpTest(c *gc.C) {
 	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
 	s.fake = &fakeEnvAPI{
+		version: 2,
 		values: map[string]interface{}{
 			"name":    "test-model",
 			"special": "special value",
 			"running": true,
 		},
+		sources: map[string]string{
+			"running": "default",
+			"special": "controller",
+		},
 	}
 }
 
 type fakeEnvAPI struct {
-	values map[string]interface{}
-	err    error
-	keys   []string
+	version int
+	values  map[string]interface{}
+	sources map[string]string
+	err     error
+	keys    []string
 }
 
 func (f *fakeEnvAPI) Close() error {
 	return nil
 }
 
+func (f *fakeEnvAPI) BestAPIVersion() int {
+	return f.version
+}
+
 func (f *fakeEnvAPI) ModelGet() (map[string]interface{}, error) {
 	return f.values, nil
 }
 
+func (f *fakeEnvAPI) ModelGetWithSource() (config.ConfigValues, error) {
+	result := make(config.ConfigValues)
+	for name, val := range f.values {
+		source, ok := f.sources[name]
+		if !ok {
+			source = "model"
+		}
+		result[name] = config.ConfigValue{Value: val, Source: source}
+	}
+	return result, nil
+}
+
 func (f *fakeEnvAPI) ModelSet(config map[string]interface{}) error {
 	f.values = config
 	return f.err
@@ -4,13 +4,17 @@
 package model
 
 import (
+	"bytes"
 	"fmt"
+	"sort"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/juju/cmd"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/config"
 )
 
 func NewGetCommand() cmd.Command {
@@ -27,10 +31,19 @@ type getCommand struct {
 }
 
 const getModelHelpDoc = `
-By default, all configuration (keys and values) for the model are
-displayed if a key is not specified.
+By default, all configuration (keys, source, and values) for the model
+are displayed if a key is not specified.
 By default, the model is the current model.
 
+The source of each value is one of:
+
+    default      the value is hard coded into Juju
+    controller   the value is a default set for all models on the controller
+    region       the value is a default set for models in the cloud region
+    model        the value has been set on the model itself
+
+Sources are only shown in the tabular format.
+
 Examples:
 
     juju get-model-config default-series
@@ -39,6 +52,7 @@ Examples:
 See also: models
           set-model-config
           unset-model-config
+          model-defaults
 `
 
 func (c *getCommand) Info() *cmd.Info {
@@ -51,7 +65,11 @@ func (c *getCommand) Info() *cmd.Info {
 }
 
 func (c *getCommand) SetFlags(f *gnuflag.FlagSet) {
-	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    formatConfigValues(cmd.FormatYaml),
+		"json":    formatConfigValues(cmd.FormatJson),
+		"tabular": formatConfigTabular,
+	})
 }
 
 func (c *getCommand) Init(args []string) (err error) {
@@ -61,7 +79,9 @@ func (c *getCommand) Init(args []string) (err error) {
 
 type GetEnvironmentAPI interface {
 	Close() error
+	BestAPIVersion() int
 	ModelGet() (map[string]interface{}, error)
+	ModelGetWithSource() (config.ConfigValues, error)
 }
 
 func (c *getCommand) getAPI() (GetEnvironmentAPI, error) {
@@ -78,6 +98,28 @@ func (c *getCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if client.BestAPIVersion() < 2 {
+		// Older controllers do not report the source of values.
+		return c.writeValues(ctx, client)
+	}
+	attrs, err := client.ModelGetWithSource()
+	if err != nil {
+		return err
+	}
+
+	if c.key != "" {
+		if value, found := attrs[c.key]; found {
+			return c.out.Write(ctx, value.Value)
+		}
+		return fmt.Errorf("key %q not found in %q model.", c.key, attrs["name"].Value)
+	}
+	// If key is empty, write out the whole lot.
+	return c.out.Write(ctx, attrs)
+}
+
+// writeValues writes out the model config values without their
+// sources.
+func (c *getCommand) writeValues(ctx *cmd.Context, client GetEnvironmentAPI) error {
 	attrs, err := client.ModelGet()
 	if err != nil {
 		return err
@@ -89,6 +131,59 @@ func (c *getCommand) Run(ctx *cmd.Context) error {
 		}
 		return fmt.Errorf("key %q not found in %q model.", c.key, attrs["name"])
 	}
-	// If key is empty, write out the whole lot.
 	return c.out.Write(ctx, attrs)
 }
+
+// formatConfigValues returns a formatter which writes config values
+// keyed by name, leaving out their sources, using the given formatter.
+func formatConfigValues(format cmd.Formatter) cmd.Formatter {
+	return func(value interface{}) ([]byte, error) {
+		if configValues, ok := value.(config.ConfigValues); ok {
+			values := make(map[string]interface{}, len(configValues))
+			for name, info := range configValues {
+				values[name] = info.Value
+			}
+			value = values
+		}
+		return format(value)
+	}
+}
+
+// formatConfigTabular writes a tabular summary of config information,
+// or the smart formatted value if a single value was requested.
+func formatConfigTabular(value interface{}) ([]byte, error) {
+	configValues, ok := value.(config.ConfigValues)
+	if !ok {
+		return cmd.FormatSmart(value)
+	}
+
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+
+	var valueNames []string
+	for name := range configValues {
+		valueNames = append(valueNames, name)
+	}
+	sort.Strings(valueNames)
+	fmt.Fprintln(tw, "ATTRIBUTE\tFROM\tVALUE")
+
+	for _, name := range valueNames {
+		info := configValues[name]
+		lines := strings.Split(strings.TrimSpace(fmt.Sprint(info.Value)), "\n")
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", name, info.Source, lines[0])
+		for _, line := range lines[1:] {
+			fmt.Fprintf(tw, "\t\t%s\n", line)
+		}
+	}
+
+	tw.Flush()
+	return out.Bytes(), nil
+}
@@ -58,9 +58,10 @@ func (s *GetSuite) TestAllValues(c *gc.C) {
 
 	output := strings.TrimSpace(testing.Stdout(context))
 	expected := "" +
-		"name: test-model\n" +
-		"running: true\n" +
-		"special: special value"
+		"ATTRIBUTE  FROM        VALUE\n" +
+		"name       model       test-model\n" +
+		"running    default     true\n" +
+		"special    controller  special value"
 	c.Assert(output, gc.Equals, expected)
 }
 
@@ -72,3 +73,37 @@ func (s *GetSuite) TestAllValuesJSON(c *gc.C) {
 	expected := `{"name":"test-model","running":true,"special":"special value"}`
 	c.Assert(output, gc.Equals, expected)
 }
+
+func (s *GetSuite) TestAllValuesYAML(c *gc.C) {
+	context, err := s.run(c, "--format=yaml")
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := strings.TrimSpace(testing.Stdout(context))
+	expected := "" +
+		"name: test-model\n" +
+		"running: true\n" +
+		"special: special value"
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *GetSuite) TestAllValuesOldController(c *gc.C) {
+	s.fake.version = 1
+	context, err := s.run(c, "--format=yaml")
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := strings.TrimSpace(testing.Stdout(context))
+	expected := "" +
+		"name: test-model\n" +
+		"running: true\n" +
+		"special: special value"
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *GetSuite) TestSingleValueOldController(c *gc.C) {
+	s.fake.version = 1
+	context, err := s.run(c, "special")
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := strings.TrimSpace(testing.Stdout(context))
+	c.Assert(output, gc.Equals, "special value")
+}
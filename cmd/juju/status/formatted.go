@@ -46,6 +46,7 @@ type machineStatus struct {
 	Containers    map[string]machineStatus `json:"containers,omitempty" yaml:"containers,omitempty"`
 	Hardware      string                   `json:"hardware,omitempty" yaml:"hardware,omitempty"`
 	HAStatus      string                   `json:"controller-member-status,omitempty" yaml:"controller-member-status,omitempty"`
+	UpgradeSeries string                   `json:"upgrade-series,omitempty" yaml:"upgrade-series,omitempty"`
 }
 
 // A goyaml bug means we can't declare these types
@@ -81,6 +82,17 @@ type applicationStatus struct {
 	Relations     map[string][]string   `json:"relations,omitempty" yaml:"relations,omitempty"`
 	SubordinateTo []string              `json:"subordinate-to,omitempty" yaml:"subordinate-to,omitempty"`
 	Units         map[string]unitStatus `json:"units,omitempty" yaml:"units,omitempty"`
+
+	RollingUpgrade      *rollingUpgradeStatus `json:"rolling-upgrade,omitempty" yaml:"rolling-upgrade,omitempty"`
+	PlacementViolations []string              `json:"placement-violations,omitempty" yaml:"placement-violations,omitempty"`
+}
+
+type rollingUpgradeStatus struct {
+	PreviousCharm string   `json:"previous-charm" yaml:"previous-charm"`
+	BatchSize     int      `json:"batch-size" yaml:"batch-size"`
+	Released      []string `json:"released,omitempty" yaml:"released,omitempty"`
+	Halted        bool     `json:"halted,omitempty" yaml:"halted,omitempty"`
+	Message       string   `json:"message,omitempty" yaml:"message,omitempty"`
 }
 
 type applicationStatusNoMarshal applicationStatus
@@ -97,6 +97,7 @@ func (sf *statusFormatter) formatMachine(machine params.MachineStatus) machineSt
 		Id:            machine.Id,
 		Containers:    make(map[string]machineStatus),
 		Hardware:      machine.Hardware,
+		UpgradeSeries: machine.UpgradeSeries,
 	}
 
 	for k, m := range machine.Containers {
@@ -151,6 +152,17 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 		SubordinateTo: application.SubordinateTo,
 		Units:         make(map[string]unitStatus),
 		StatusInfo:    sf.getServiceStatusInfo(application),
+
+		PlacementViolations: application.PlacementViolations,
+	}
+	if rolling := application.RollingUpgrade; rolling != nil {
+		out.RollingUpgrade = &rollingUpgradeStatus{
+			PreviousCharm: rolling.PreviousCharm,
+			BatchSize:     rolling.BatchSize,
+			Released:      rolling.Released,
+			Halted:        rolling.Halted,
+			Message:       rolling.Message,
+		}
 	}
 	for k, m := range application.Units {
 		out.Units[k] = sf.formatUnit(unitFormatInfo{
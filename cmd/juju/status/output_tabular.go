@@ -156,6 +156,29 @@ func FormatTabular(value interface{}) ([]byte, error) {
 		}
 	}
 
+	var rolling []string
+	for _, appName := range common.SortStringsNaturally(stringKeysFromMap(fs.Applications)) {
+		if fs.Applications[appName].RollingUpgrade != nil {
+			rolling = append(rolling, appName)
+		}
+	}
+	if len(rolling) > 0 {
+		outputHeaders("ROLLING-UPGRADE", "FROM", "BATCH", "RELEASED", "STATE", "MESSAGE")
+		for _, appName := range rolling {
+			app := fs.Applications[appName]
+			state := "running"
+			if app.RollingUpgrade.Halted {
+				state = "halted"
+			}
+			p(appName,
+				app.RollingUpgrade.PreviousCharm,
+				app.RollingUpgrade.BatchSize,
+				fmt.Sprintf("%d/%d", len(app.RollingUpgrade.Released), len(app.Units)),
+				state,
+				app.RollingUpgrade.Message)
+		}
+	}
+
 	pUnit := func(name string, u unitStatus, level int) {
 		message := u.WorkloadStatusInfo.Message
 		agentDoing := agentDoing(u.JujuStatusInfo)
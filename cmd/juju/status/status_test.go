@@ -3380,6 +3380,44 @@ MACHINE  STATE  DNS  INS-ID  SERIES  AZ
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatTabularRollingUpgrade(c *gc.C) {
+	status := formattedStatus{
+		Applications: map[string]applicationStatus{
+			"foo": {
+				Units: map[string]unitStatus{
+					"foo/0": {},
+					"foo/1": {},
+				},
+				RollingUpgrade: &rollingUpgradeStatus{
+					PreviousCharm: "cs:quantal/foo-1",
+					BatchSize:     1,
+					Released:      []string{"foo/0"},
+					Halted:        true,
+					Message:       "unit foo/0 is in error: hook failed",
+				},
+			},
+		},
+	}
+	out, err := FormatTabular(status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, `
+MODEL  CONTROLLER  CLOUD  VERSION  
+                                   
+
+APP  STATUS  EXPOSED  ORIGIN  CHARM  REV  OS  
+foo          false                   0        
+
+ROLLING-UPGRADE  FROM              BATCH  RELEASED  STATE   MESSAGE                              
+foo              cs:quantal/foo-1  1      1/2       halted  unit foo/0 is in error: hook failed  
+
+UNIT   WORKLOAD  AGENT  MACHINE  PORTS  PUBLIC-ADDRESS  MESSAGE  
+foo/0                                                            
+foo/1                                                            
+
+MACHINE  STATE  DNS  INS-ID  SERIES  AZ  
+`[1:])
+}
+
 //
 // Filtering Feature
 //
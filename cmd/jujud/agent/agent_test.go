@@ -63,6 +63,9 @@ func (s *AgentSuite) SetUpSuite(c *gc.C) {
 	s.PatchValue(&cmdutil.EnsureMongoServer, func(mongo.EnsureServerParams) error {
 		return nil
 	})
+	s.PatchValue(&restartMongo, func() error {
+		return nil
+	})
 }
 
 func (s *AgentSuite) SetUpTest(c *gc.C) {
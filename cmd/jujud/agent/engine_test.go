@@ -42,6 +42,7 @@ var (
 		"migration-fortress",
 		"migration-master",
 		"application-scaler",
+		"rolling-upgrader",
 		"space-importer",
 		"state-cleaner",
 		"status-history-pruner",
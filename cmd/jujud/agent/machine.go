@@ -95,6 +95,7 @@ var (
 	newCertificateUpdater = certupdater.NewCertificateUpdater
 	newMetadataUpdater    = imagemetadataworker.NewWorker
 	newUpgradeMongoWorker = mongoupgrader.New
+	restartMongo          = mongo.ReStartService
 	reportOpenedState     = func(*state.State) {}
 )
 
@@ -372,6 +373,26 @@ func (a *MachineAgent) upgradeCertificateDNSNames() error {
 	})
 }
 
+// updateMongoCertificate makes mongo serve the certificate in the given
+// state serving info if it was issued by a different CA from the
+// agent's current certificate, as happens when the controller CA is
+// rotated. The certificate is written and mongo restarted before the
+// agent config is changed, so that an interrupted update is retried
+// when the agent restarts. Certificates reissued only to cover new
+// addresses are picked up when mongo is next restarted.
+func (a *MachineAgent) updateMongoCertificate(info params.StateServingInfo) error {
+	agentConfig := a.CurrentConfig()
+	current, ok := agentConfig.StateServingInfo()
+	if ok && current.CAPrivateKey == info.CAPrivateKey {
+		return nil
+	}
+	if err := mongo.UpdateSSLKey(agentConfig.DataDir(), info.Cert, info.PrivateKey); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("restarting mongo to serve certificate issued by the new controller CA")
+	return errors.Annotate(restartMongo(), "cannot restart mongo")
+}
+
 // Run runs a machine agent.
 func (a *MachineAgent) Run(*cmd.Context) error {
 
@@ -933,6 +954,9 @@ func (a *MachineAgent) startStateWorkers(st *state.State) (worker.Worker, error)
 			}
 			runner.StartWorker("apiserver", a.apiserverWorkerStarter(stateOpener, certChangedChan))
 			var stateServingSetter certupdater.StateServingInfoSetter = func(info params.StateServingInfo, done <-chan struct{}) error {
+				if err := a.updateMongoCertificate(info); err != nil {
+					return errors.Annotate(err, "cannot update mongo certificate")
+				}
 				return a.ChangeConfig(func(config agent.ConfigSetter) error {
 					config.SetStateServingInfo(info)
 					logger.Infof("update apiserver worker with new certificate")
@@ -994,6 +1018,7 @@ func (a *MachineAgent) startModelWorkers(uuid string) (worker.Worker, error) {
 		StatusHistoryPrunerMaxHistoryTime: 336 * time.Hour, // 2 weeks
 		StatusHistoryPrunerMaxHistoryMB:   5120,            // 5G
 		StatusHistoryPrunerInterval:       5 * time.Minute,
+		RollingUpgradeInterval:            10 * time.Second,
 		SpacesImportedGate:                a.discoverSpacesComplete,
 	})
 	if err := dependency.Install(engine, manifolds); err != nil {
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
 	"github.com/juju/juju/worker/authenticationworker"
+	"github.com/juju/juju/worker/cacertupdater"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/deployer"
 	"github.com/juju/juju/worker/diskmanager"
@@ -39,6 +40,7 @@ import (
 	"github.com/juju/juju/worker/terminationworker"
 	"github.com/juju/juju/worker/toolsversionchecker"
 	"github.com/juju/juju/worker/upgrader"
+	"github.com/juju/juju/worker/upgradeseries"
 	"github.com/juju/juju/worker/upgradesteps"
 	"github.com/juju/utils/clock"
 	"github.com/juju/version"
@@ -262,6 +264,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 			FortressName:  migrationFortressName,
 
+			APIOpen:   apicaller.APIOpen,
 			NewFacade: migrationminion.NewFacade,
 			NewWorker: migrationminion.NewWorker,
 		})),
@@ -323,6 +326,14 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 		})),
 
+		// The CA cert updater is a leaf worker that rewrites agent config
+		// as the CA certificates trusted by the controller change, such as
+		// when the controller CA is rotated.
+		caCertUpdaterName: ifFullyUpgraded(cacertupdater.Manifold(cacertupdater.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+		})),
+
 		// The machiner Worker will wait for the identified machine to become
 		// Dying and make it Dead; or until the machine becomes Dead by other
 		// means.
@@ -397,6 +408,14 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     hostkeyreporter.NewFacade,
 			NewWorker:     hostkeyreporter.NewWorker,
 		})),
+
+		// The upgrade-series worker drives the machine side of an
+		// in-place series upgrade, preparing the machine's agents
+		// for the new series and recording it once complete.
+		upgradeSeriesName: ifFullyUpgraded(upgradeseries.Manifold(upgradeseries.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+		})),
 	}
 }
 
@@ -432,6 +451,7 @@ const (
 	diskManagerName          = "disk-manager"
 	proxyConfigUpdater       = "proxy-config-updater"
 	apiAddressUpdaterName    = "api-address-updater"
+	caCertUpdaterName        = "ca-cert-updater"
 	machinerName             = "machiner"
 	logSenderName            = "log-sender"
 	deployerName             = "unit-agent-deployer"
@@ -443,4 +463,5 @@ const (
 	apiConfigWatcherName     = "api-config-watcher"
 	machineActionName        = "machine-action-runner"
 	hostKeyReporterName      = "host-key-reporter"
+	upgradeSeriesName        = "upgrade-series"
 )
@@ -1072,6 +1072,36 @@ func (s *MachineSuite) TestCertificateUpdateWorkerUpdatesCertificate(c *gc.C) {
 	s.assertChannelActive(c, updated, "certificate to be updated")
 }
 
+func (s *MachineSuite) TestUpdateMongoCertificateOnCAChange(c *gc.C) {
+	var restarted int
+	s.PatchValue(&restartMongo, func() error {
+		restarted++
+		return nil
+	})
+	m, _, _ := s.primeAgent(c, state.JobManageModel)
+	a := s.newAgent(c, m)
+	a.ReadConfig(names.NewMachineTag(m.Id()).String())
+	info, ok := a.CurrentConfig().StateServingInfo()
+	c.Assert(ok, jc.IsTrue)
+
+	// A certificate issued by the same CA is not served by mongo
+	// until it is next restarted.
+	err := a.updateMongoCertificate(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restarted, gc.Equals, 0)
+
+	// A certificate issued by a new CA is served immediately.
+	info.Cert = coretesting.ServerCert
+	info.PrivateKey = coretesting.ServerKey
+	info.CAPrivateKey = coretesting.OtherCAKey
+	err = a.updateMongoCertificate(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restarted, gc.Equals, 1)
+	pemContent, err := ioutil.ReadFile(filepath.Join(s.DataDir(), "server.pem"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(pemContent), gc.Equals, info.Cert+"\n"+info.PrivateKey)
+}
+
 func (s *MachineSuite) TestCertificateDNSUpdated(c *gc.C) {
 	// Disable the certificate work so it doesn't update the certificate.
 	newUpdater := func(certupdater.AddressWatcher, certupdater.StateServingInfoGetter, certupdater.ControllerConfigGetter,
@@ -32,6 +32,7 @@ import (
 	"github.com/juju/juju/worker/metricworker"
 	"github.com/juju/juju/worker/migrationmaster"
 	"github.com/juju/juju/worker/provisioner"
+	"github.com/juju/juju/worker/rollingupgrader"
 	"github.com/juju/juju/worker/singular"
 	"github.com/juju/juju/worker/statushistorypruner"
 	"github.com/juju/juju/worker/storageprovisioner"
@@ -80,6 +81,11 @@ type ManifoldsConfig struct {
 	StatusHistoryPrunerMaxHistoryMB   uint
 	StatusHistoryPrunerInterval       time.Duration
 
+	// RollingUpgradeInterval determines how often the rolling-
+	// upgrader worker will check on the progress of rolling charm
+	// upgrades.
+	RollingUpgradeInterval time.Duration
+
 	// SpacesImportedGate will be unlocked when spaces are known to
 	// have been imported.
 	SpacesImportedGate gate.Lock
@@ -148,6 +154,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		migrationMasterName: ifNotDead(migrationmaster.Manifold(migrationmaster.ManifoldConfig{
 			APICallerName: apiCallerName,
 			FortressName:  migrationFortressName,
+			ClockName:     clockName,
 
 			NewFacade: migrationmaster.NewFacade,
 			NewWorker: migrationmaster.NewWorker,
@@ -218,6 +225,12 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     applicationscaler.NewFacade,
 			NewWorker:     applicationscaler.New,
 		})),
+		rollingUpgraderName: ifNotDead(rollingupgrader.Manifold(rollingupgrader.ManifoldConfig{
+			APICallerName: apiCallerName,
+			Period:        config.RollingUpgradeInterval,
+			NewFacade:     rollingupgrader.NewFacade,
+			NewWorker:     rollingupgrader.New,
+		})),
 		instancePollerName: ifNotDead(instancepoller.Manifold(instancepoller.ManifoldConfig{
 			ClockName:     clockName,
 			Delay:         config.InstPollerAggregationDelay,
@@ -309,6 +322,7 @@ const (
 	firewallerName           = "firewaller"
 	unitAssignerName         = "unit-assigner"
 	applicationscalerName    = "application-scaler"
+	rollingUpgraderName      = "rolling-upgrader"
 	instancePollerName       = "instance-poller"
 	charmRevisionUpdaterName = "charm-revision-updater"
 	metricWorkerName         = "metric-worker"
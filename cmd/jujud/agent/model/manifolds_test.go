@@ -48,6 +48,7 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 		"not-alive-flag",
 		"not-dead-flag",
 		"application-scaler",
+		"rolling-upgrader",
 		"space-importer",
 		"spaces-imported-gate",
 		"state-cleaner",
@@ -49,6 +49,7 @@ func (s *ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"logging-config-updater",
 		"proxy-config-updater",
 		"api-address-updater",
+		"ca-cert-updater",
 		"charm-dir",
 		"leadership-tracker",
 		"hook-retry-strategy",
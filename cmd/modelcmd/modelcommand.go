@@ -6,6 +6,7 @@ package modelcmd
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -224,6 +225,83 @@ func (c *ModelCommandBase) NewAPIRoot() (api.Connection, error) {
 			return nil, errors.Annotate(err, "refreshing models")
 		}
 	}
+	conn, err := opener.Open(c.store, c.controllerName, c.accountName, c.modelName)
+	if redirect, ok := errors.Cause(err).(*api.RedirectError); ok {
+		return c.followRedirect(opener, redirect)
+	}
+	return conn, err
+}
+
+// followRedirect updates the client store after the model has been
+// migrated to another controller, and reconnects to the model there.
+// The target controller must already be known to the client store;
+// otherwise the user is told how to find the model again. The CA
+// certificate of a known controller is never replaced: the redirect
+// is only followed if the target presents the certificate already
+// recorded for it, or if the controller is verified against the
+// system's trusted CAs, which apply equally to its new addresses.
+func (c *ModelCommandBase) followRedirect(opener APIOpener, redirect *api.RedirectError) (api.Connection, error) {
+	details, err := c.store.ModelByName(c.controllerName, c.accountName, c.modelName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	controllers, err := c.store.AllControllers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var controllerNames []string
+	for name := range controllers {
+		controllerNames = append(controllerNames, name)
+	}
+	sort.Strings(controllerNames)
+	var targetName string
+	for _, name := range controllerNames {
+		if controllers[name].ControllerUUID == redirect.ControllerTag.Id() {
+			targetName = name
+			break
+		}
+	}
+	if targetName == "" {
+		return nil, errors.Errorf(
+			"model %q has been migrated to controller %s (%s); "+
+				"please register or log in to that controller to continue using the model",
+			c.modelName, redirect.ControllerTag.Id(), strings.Join(redirect.Servers, ", "),
+		)
+	}
+
+	target := controllers[targetName]
+	if !target.TrustSystemCA && strings.TrimSpace(target.CACert) != strings.TrimSpace(redirect.CACert) {
+		return nil, errors.Errorf(
+			"model %q has been migrated to controller %q, but the controller's CA certificate "+
+				"does not match the one recorded for it; please verify the controller and "+
+				"log in to it again to continue using the model",
+			c.modelName, targetName,
+		)
+	}
+	target.APIEndpoints = redirect.Servers
+	target.UnresolvedAPIEndpoints = redirect.Servers
+	if err := c.store.UpdateController(targetName, target); err != nil {
+		return nil, errors.Trace(err)
+	}
+	accountName, err := c.store.CurrentAccount(targetName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := c.store.UpdateModel(targetName, accountName, c.modelName, *details); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := c.store.RemoveModel(c.controllerName, c.accountName, c.modelName); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	message := fmt.Sprintf("model %q has been migrated from controller %q to controller %q", c.modelName, c.controllerName, targetName)
+	if c.cmdContext != nil {
+		c.cmdContext.Infof("%s", message)
+	} else {
+		logger.Infof("%s", message)
+	}
+	c.controllerName = targetName
+	c.accountName = accountName
 	return opener.Open(c.store, c.controllerName, c.accountName, c.modelName)
 }
 
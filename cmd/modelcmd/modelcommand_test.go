@@ -9,10 +9,12 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api"
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/juju/osenv"
@@ -179,6 +181,152 @@ func initTestCommand(c *gc.C, store jujuclient.ClientStore, args ...string) (*te
 	return cmd, cmdtesting.InitCommand(wrapped, args)
 }
 
+type redirectSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store     *jujuclienttesting.MemStore
+	redirect  *api.RedirectError
+	openCalls []string
+}
+
+var _ = gc.Suite(&redirectSuite{})
+
+const (
+	sourceControllerUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	targetControllerUUID = "deadbeef-1bad-500d-9000-4b1d0d06f00d"
+	migratedModelUUID    = "deadbeef-2bad-600d-a000-4b1d0d06f00d"
+)
+
+func (s *redirectSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.openCalls = nil
+	s.redirect = &api.RedirectError{
+		ControllerTag: names.NewModelTag(targetControllerUUID),
+		Servers:       []string{"10.0.0.1:17070"},
+		CACert:        "target-cert",
+	}
+
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.Controllers["source"] = jujuclient.ControllerDetails{
+		ControllerUUID: sourceControllerUUID,
+		APIEndpoints:   []string{"10.0.0.2:17070"},
+	}
+	s.store.Accounts["source"] = &jujuclient.ControllerAccounts{
+		Accounts:       map[string]jujuclient.AccountDetails{"admin@local": {User: "admin@local"}},
+		CurrentAccount: "admin@local",
+	}
+	s.store.Models["source"] = jujuclient.ControllerAccountModels{
+		AccountModels: map[string]*jujuclient.AccountModels{
+			"admin@local": {
+				Models: map[string]jujuclient.ModelDetails{
+					"migrated": {migratedModelUUID},
+				},
+			},
+		},
+	}
+}
+
+func (s *redirectSuite) addTargetController() {
+	s.store.Controllers["target"] = jujuclient.ControllerDetails{
+		ControllerUUID: targetControllerUUID,
+		APIEndpoints:   []string{"10.0.0.3:17070"},
+		CACert:         "target-cert",
+	}
+	s.store.Accounts["target"] = &jujuclient.ControllerAccounts{
+		Accounts:       map[string]jujuclient.AccountDetails{"bob@local": {User: "bob@local"}},
+		CurrentAccount: "bob@local",
+	}
+}
+
+func (s *redirectSuite) open(store jujuclient.ClientStore, controllerName, accountName, modelName string) (api.Connection, error) {
+	s.openCalls = append(s.openCalls, controllerName+":"+accountName+":"+modelName)
+	if controllerName == "source" {
+		return nil, errors.Trace(s.redirect)
+	}
+	return nil, nil
+}
+
+func (s *redirectSuite) TestRedirectToKnownController(c *gc.C) {
+	s.addTargetController()
+	cmd := modelcmd.NewModelCommandBase(s.store, "source", "admin@local", "migrated")
+	cmd.SetAPIOpener(modelcmd.OpenFunc(s.open))
+
+	_, err := cmd.NewAPIRoot()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.openCalls, jc.DeepEquals, []string{
+		"source:admin@local:migrated",
+		"target:bob@local:migrated",
+	})
+	c.Assert(cmd.ControllerName(), gc.Equals, "target")
+	c.Assert(cmd.AccountName(), gc.Equals, "bob@local")
+
+	target, err := s.store.ControllerByName("target")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(target.APIEndpoints, jc.DeepEquals, s.redirect.Servers)
+	c.Assert(target.UnresolvedAPIEndpoints, jc.DeepEquals, s.redirect.Servers)
+	c.Assert(target.CACert, gc.Equals, "target-cert")
+
+	model, err := s.store.ModelByName("target", "bob@local", "migrated")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.ModelUUID, gc.Equals, migratedModelUUID)
+	_, err = s.store.ModelByName("source", "admin@local", "migrated")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *redirectSuite) TestRedirectCACertMismatch(c *gc.C) {
+	s.addTargetController()
+	s.redirect.CACert = "other-cert"
+	cmd := modelcmd.NewModelCommandBase(s.store, "source", "admin@local", "migrated")
+	cmd.SetAPIOpener(modelcmd.OpenFunc(s.open))
+
+	_, err := cmd.NewAPIRoot()
+	c.Assert(err, gc.ErrorMatches, `model "migrated" has been migrated to controller "target", `+
+		`but the controller's CA certificate does not match the one recorded for it; .*`)
+	c.Assert(s.openCalls, gc.HasLen, 1)
+
+	// The known controller's details are left alone.
+	target, err := s.store.ControllerByName("target")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(target.APIEndpoints, jc.DeepEquals, []string{"10.0.0.3:17070"})
+	c.Assert(target.CACert, gc.Equals, "target-cert")
+	_, err = s.store.ModelByName("source", "admin@local", "migrated")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *redirectSuite) TestRedirectTrustingSystemCA(c *gc.C) {
+	s.addTargetController()
+	target := s.store.Controllers["target"]
+	target.CACert = ""
+	target.TrustSystemCA = true
+	s.store.Controllers["target"] = target
+	cmd := modelcmd.NewModelCommandBase(s.store, "source", "admin@local", "migrated")
+	cmd.SetAPIOpener(modelcmd.OpenFunc(s.open))
+
+	_, err := cmd.NewAPIRoot()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmd.ControllerName(), gc.Equals, "target")
+
+	// The controller is still verified against the system's trusted
+	// CAs rather than the certificate from the redirect.
+	updated, err := s.store.ControllerByName("target")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updated.APIEndpoints, jc.DeepEquals, s.redirect.Servers)
+	c.Assert(updated.CACert, gc.Equals, "")
+	c.Assert(updated.TrustSystemCA, jc.IsTrue)
+}
+
+func (s *redirectSuite) TestRedirectToUnknownController(c *gc.C) {
+	cmd := modelcmd.NewModelCommandBase(s.store, "source", "admin@local", "migrated")
+	cmd.SetAPIOpener(modelcmd.OpenFunc(s.open))
+
+	_, err := cmd.NewAPIRoot()
+	c.Assert(err, gc.ErrorMatches, `model "migrated" has been migrated to controller `+
+		targetControllerUUID+` \(10.0.0.1:17070\); please register or log in to that controller .*`)
+	c.Assert(s.openCalls, gc.HasLen, 1)
+
+	_, err = s.store.ModelByName("source", "admin@local", "migrated")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 type closer struct{}
 
 func (*closer) Close() error {
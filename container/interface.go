@@ -6,6 +6,7 @@ package container
 import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/status"
 )
@@ -53,6 +54,15 @@ type Manager interface {
 	Namespace() instance.Namespace
 }
 
+// LXDProfileManager is implemented by container managers which can
+// apply the LXD profiles declared by charms to existing containers.
+type LXDProfileManager interface {
+	// SetCharmLXDProfiles ensures that the container for the given
+	// machine has exactly the given charm profiles applied, keyed by
+	// profile name, creating any profiles that do not yet exist.
+	SetCharmLXDProfiles(machineId string, profiles map[string]lxdprofile.Profile) error
+}
+
 // Initialiser is responsible for performing the steps required to initialise
 // a host machine so it can run containers.
 type Initialiser interface {
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
@@ -41,6 +42,9 @@ type containerManager struct {
 // containerManager implements container.Manager.
 var _ container.Manager = (*containerManager)(nil)
 
+// containerManager implements container.LXDProfileManager.
+var _ container.LXDProfileManager = (*containerManager)(nil)
+
 func ConnectLocal() (*lxdclient.Client, error) {
 	cfg := lxdclient.Config{
 		Remote: lxdclient.Local,
@@ -152,6 +156,16 @@ func (manager *containerManager) CreateContainer(
 		logger.Infof("instance %q configured with %v network devices", name, nics)
 	}
 
+	charmProfiles, err := manager.client.EnsureCharmProfiles(instanceConfig.CharmLXDProfiles)
+	if err != nil {
+		err = errors.Annotatef(err, "failed to create charm LXD profiles")
+		return
+	}
+	if len(charmProfiles) > 0 {
+		logger.Infof("instance %q configured with charm profiles %v", name, charmProfiles)
+		profiles = append(profiles, charmProfiles...)
+	}
+
 	spec := lxdclient.InstanceSpec{
 		Name:     name,
 		Image:    manager.client.ImageNameForSeries(series),
@@ -172,6 +186,26 @@ func (manager *containerManager) CreateContainer(
 	return
 }
 
+// SetCharmLXDProfiles implements container.LXDProfileManager.
+func (manager *containerManager) SetCharmLXDProfiles(machineId string, profiles map[string]lxdprofile.Profile) error {
+	if manager.client == nil {
+		var err error
+		manager.client, err = ConnectLocal()
+		if err != nil {
+			return errors.Annotatef(err, "failed to connect to local LXD")
+		}
+	}
+	name, err := manager.namespace.Hostname(machineId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	charmProfiles, err := manager.client.EnsureCharmProfiles(profiles)
+	if err != nil {
+		return errors.Annotatef(err, "failed to create charm LXD profiles")
+	}
+	return errors.Trace(manager.client.ReplaceCharmProfiles(name, charmProfiles))
+}
+
 func (manager *containerManager) DestroyContainer(id instance.Id) error {
 	if manager.client == nil {
 		var err error
@@ -36,6 +36,10 @@ const (
 	// CAPrivateKey is the key for the controller's CA certificate private key.
 	CAPrivateKey = "ca-private-key"
 
+	// CAKeyType is the key for the type of private key generated for
+	// the controller's CA, and so for the certificates it signs.
+	CAKeyType = "ca-key-type"
+
 	// ControllerUUIDKey is the key for the controller UUID attribute.
 	ControllerUUIDKey = "controller-uuid"
 
@@ -44,6 +48,12 @@ const (
 
 	// IdentityPublicKey sets the public key of the identity manager.
 	IdentityPublicKey = "identity-public-key"
+
+	// CharmStoreMirror, when true, makes the controller resolve and
+	// fetch charm store charms and resources from its charm mirror,
+	// the charms imported into it with sync-charms, rather than from
+	// the charm store itself.
+	CharmStoreMirror = "charmstore-mirror"
 )
 
 // ControllerOnlyConfigAttributes are attributes which are only relevant
@@ -53,9 +63,11 @@ var ControllerOnlyConfigAttributes = []string{
 	StatePort,
 	CACertKey,
 	CAPrivateKey,
+	CAKeyType,
 	ControllerUUIDKey,
 	IdentityURL,
 	IdentityPublicKey,
+	CharmStoreMirror,
 }
 
 type Config map[string]interface{}
@@ -140,6 +152,15 @@ func (c Config) CAPrivateKey() (key string, ok bool) {
 	return "", false
 }
 
+// CAKeyType returns the type of private key generated for the
+// controller's CA, defaulting to RSA.
+func (c Config) CAKeyType() cert.KeyType {
+	if s := c.asString(CAKeyType); s != "" {
+		return cert.KeyType(s)
+	}
+	return cert.RSA
+}
+
 // IdentityURL returns the url of the identity manager.
 func (c Config) IdentityURL() string {
 	return c.asString(IdentityURL)
@@ -161,6 +182,14 @@ func (c Config) IdentityPublicKey() *bakery.PublicKey {
 	return &pubKey
 }
 
+// CharmStoreMirror reports whether the controller resolves and fetches
+// charm store charms and resources from its charm mirror rather than
+// from the charm store.
+func (c Config) CharmStoreMirror() bool {
+	mirror, _ := c[CharmStoreMirror].(bool)
+	return mirror
+}
+
 // maybeReadAttrFromFile sets defined[attr] to:
 //
 // 1) The content of the file defined[attr+"-path"], if that's set
@@ -249,6 +278,10 @@ func Validate(c Config) error {
 		}
 	}
 
+	if err := c.CAKeyType().Validate(); err != nil {
+		return errors.Annotate(err, "invalid CA key type in configuration")
+	}
+
 	if uuid, ok := c[ControllerUUIDKey].(string); ok && !utils.IsValidUUIDString(uuid) {
 		return errors.Errorf("controller-uuid: expected UUID, got string(%q)", uuid)
 	}
@@ -299,6 +332,13 @@ var ConfigSchema = environschema.Fields{
 		Description: "Path to file containing CA private key",
 		Type:        environschema.Tstring,
 	},
+	CAKeyType: {
+		Description: `The type of private key to generate for the controller's CA and the certificates it signs, either "rsa" or "ecdsa"`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{string(cert.RSA), string(cert.ECDSA)},
+		Group:       environschema.EnvironGroup,
+		Immutable:   true,
+	},
 	StatePort: {
 		Description: "Port for the API server to listen on.",
 		Type:        environschema.Tint,
@@ -323,4 +363,10 @@ var ConfigSchema = environschema.Fields{
 		Group:       environschema.JujuGroup,
 		Immutable:   true,
 	},
+	CharmStoreMirror: {
+		Description: "Whether charm store charms and resources are resolved from the charms imported with sync-charms rather than from the charm store (default false)",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+		Immutable:   true,
+	},
 }
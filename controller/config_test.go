@@ -72,3 +72,28 @@ func (s *ConfigSuite) TestGenerateControllerCertAndKey(c *gc.C) {
 		c.Assert(sanIPs, jc.SameContents, test.sanValues)
 	}
 }
+
+func (s *ConfigSuite) TestCAKeyType(c *gc.C) {
+	cfg := controller.Config{}
+	c.Assert(cfg.CAKeyType(), gc.Equals, cert.RSA)
+	c.Assert(controller.Validate(cfg), jc.ErrorIsNil)
+
+	cfg[controller.CAKeyType] = "ecdsa"
+	c.Assert(cfg.CAKeyType(), gc.Equals, cert.ECDSA)
+	c.Assert(controller.Validate(cfg), jc.ErrorIsNil)
+
+	cfg[controller.CAKeyType] = "dsa"
+	err := controller.Validate(cfg)
+	c.Assert(err, gc.ErrorMatches, `invalid CA key type in configuration: key type "dsa" not valid`)
+}
+
+func (s *ConfigSuite) TestCharmStoreMirror(c *gc.C) {
+	cfg := controller.Config{}
+	c.Assert(cfg.CharmStoreMirror(), jc.IsFalse)
+	cfg[controller.CharmStoreMirror] = true
+	c.Assert(cfg.CharmStoreMirror(), jc.IsTrue)
+	c.Assert(controller.ControllerConfig(map[string]interface{}{
+		"name":                      "foo",
+		controller.CharmStoreMirror: true,
+	}), jc.DeepEquals, cfg)
+}
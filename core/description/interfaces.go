@@ -218,7 +218,11 @@ type Application interface {
 	CharmModifiedVersion() int
 	ForceCharm() bool
 	Exposed() bool
+	ExposedCIDRs() []string
 	MinUnits() int
+	Trust() bool
+	PlacementSpread() string
+	PlacementColocate() string
 
 	Settings() map[string]interface{}
 	SettingsRefCount() int
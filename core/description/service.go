@@ -32,6 +32,19 @@ type application struct {
 	Exposed_    bool `yaml:"exposed,omitempty"`
 	MinUnits_   int  `yaml:"min-units,omitempty"`
 
+	// ExposedCIDRs restricts access to an exposed application's ports
+	// to the given source CIDRs.
+	ExposedCIDRs_ []string `yaml:"exposed-cidrs,omitempty"`
+
+	// Trust is true if the application's units may access the
+	// model's cloud credential.
+	Trust_ bool `yaml:"trust,omitempty"`
+
+	// PlacementSpread and PlacementColocate hold the application's
+	// placement policy.
+	PlacementSpread_   string `yaml:"placement-spread,omitempty"`
+	PlacementColocate_ string `yaml:"placement-colocate,omitempty"`
+
 	Status_        *status `yaml:"status"`
 	StatusHistory_ `yaml:"status-history"`
 
@@ -63,7 +76,11 @@ type ApplicationArgs struct {
 	CharmModifiedVersion int
 	ForceCharm           bool
 	Exposed              bool
+	ExposedCIDRs         []string
 	MinUnits             int
+	Trust                bool
+	PlacementSpread      string
+	PlacementColocate    string
 	Settings             map[string]interface{}
 	SettingsRefCount     int
 	Leader               string
@@ -82,7 +99,11 @@ func newApplication(args ApplicationArgs) *application {
 		CharmModifiedVersion_: args.CharmModifiedVersion,
 		ForceCharm_:           args.ForceCharm,
 		Exposed_:              args.Exposed,
+		ExposedCIDRs_:         args.ExposedCIDRs,
 		MinUnits_:             args.MinUnits,
+		Trust_:                args.Trust,
+		PlacementSpread_:      args.PlacementSpread,
+		PlacementColocate_:    args.PlacementColocate,
 		Settings_:             args.Settings,
 		SettingsRefCount_:     args.SettingsRefCount,
 		Leader_:               args.Leader,
@@ -144,6 +165,26 @@ func (s *application) MinUnits() int {
 	return s.MinUnits_
 }
 
+// ExposedCIDRs implements Application.
+func (s *application) ExposedCIDRs() []string {
+	return s.ExposedCIDRs_
+}
+
+// Trust implements Application.
+func (s *application) Trust() bool {
+	return s.Trust_
+}
+
+// PlacementSpread implements Application.
+func (s *application) PlacementSpread() string {
+	return s.PlacementSpread_
+}
+
+// PlacementColocate implements Application.
+func (s *application) PlacementColocate() string {
+	return s.PlacementColocate_
+}
+
 // Settings implements Application.
 func (s *application) Settings() map[string]interface{} {
 	return s.Settings_
@@ -307,7 +348,11 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		"charm-mod-version":   schema.Int(),
 		"force-charm":         schema.Bool(),
 		"exposed":             schema.Bool(),
+		"exposed-cidrs":       schema.List(schema.String()),
 		"min-units":           schema.Int(),
+		"trust":               schema.Bool(),
+		"placement-spread":    schema.String(),
+		"placement-colocate":  schema.String(),
 		"status":              schema.StringMap(schema.Any()),
 		"settings":            schema.StringMap(schema.Any()),
 		"settings-refcount":   schema.Int(),
@@ -318,12 +363,16 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 	}
 
 	defaults := schema.Defaults{
-		"subordinate":   false,
-		"force-charm":   false,
-		"exposed":       false,
-		"min-units":     int64(0),
-		"leader":        "",
-		"metrics-creds": "",
+		"subordinate":        false,
+		"force-charm":        false,
+		"exposed":            false,
+		"exposed-cidrs":      schema.Omit,
+		"min-units":          int64(0),
+		"trust":              false,
+		"placement-spread":   "",
+		"placement-colocate": "",
+		"leader":             "",
+		"metrics-creds":      "",
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -346,7 +395,11 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		CharmModifiedVersion_: int(valid["charm-mod-version"].(int64)),
 		ForceCharm_:           valid["force-charm"].(bool),
 		Exposed_:              valid["exposed"].(bool),
+		ExposedCIDRs_:         convertToStringSlice(valid["exposed-cidrs"]),
 		MinUnits_:             int(valid["min-units"].(int64)),
+		Trust_:                valid["trust"].(bool),
+		PlacementSpread_:      valid["placement-spread"].(string),
+		PlacementColocate_:    valid["placement-colocate"].(string),
 		Settings_:             valid["settings"].(map[string]interface{}),
 		SettingsRefCount_:     int(valid["settings-refcount"].(int64)),
 		Leader_:               valid["leader"].(string),
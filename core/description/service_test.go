@@ -110,7 +110,11 @@ func (s *ApplicationSerializationSuite) TestNewApplication(c *gc.C) {
 		CharmModifiedVersion: 1,
 		ForceCharm:           true,
 		Exposed:              true,
+		ExposedCIDRs:         []string{"10.0.0.0/8"},
 		MinUnits:             42, // no judgement is made by the migration code
+		Trust:                true,
+		PlacementSpread:      "zone",
+		PlacementColocate:    "mysql",
 		Settings: map[string]interface{}{
 			"key": "value",
 		},
@@ -132,7 +136,11 @@ func (s *ApplicationSerializationSuite) TestNewApplication(c *gc.C) {
 	c.Assert(application.CharmModifiedVersion(), gc.Equals, 1)
 	c.Assert(application.ForceCharm(), jc.IsTrue)
 	c.Assert(application.Exposed(), jc.IsTrue)
+	c.Assert(application.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
 	c.Assert(application.MinUnits(), gc.Equals, 42)
+	c.Assert(application.Trust(), jc.IsTrue)
+	c.Assert(application.PlacementSpread(), gc.Equals, "zone")
+	c.Assert(application.PlacementColocate(), gc.Equals, "mysql")
 	c.Assert(application.Settings(), jc.DeepEquals, args.Settings)
 	c.Assert(application.SettingsRefCount(), gc.Equals, 1)
 	c.Assert(application.Leader(), gc.Equals, "magic/1")
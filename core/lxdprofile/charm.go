@@ -0,0 +1,66 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxdprofile
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+)
+
+// ProfileFile is the name of the file in which a charm declares the
+// LXD profile to apply to the containers its units are deployed to.
+const ProfileFile = "lxd-profile.yaml"
+
+// ReadCharmProfile returns the LXD profile declared by the given
+// charm, or nil if it does not declare one. Only charm archives and
+// charm directories can declare profiles.
+func ReadCharmProfile(ch charm.Charm) (*Profile, error) {
+	var data []byte
+	var err error
+	switch ch := ch.(type) {
+	case *charm.CharmArchive:
+		data, err = readArchiveFile(ch.Path, ProfileFile)
+	case *charm.CharmDir:
+		data, err = ioutil.ReadFile(filepath.Join(ch.Path, ProfileFile))
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read %s", ProfileFile)
+	}
+	if data == nil {
+		return nil, nil
+	}
+	return ParseProfile(data)
+}
+
+// readArchiveFile returns the contents of the named file in the zip
+// archive at path, or nil if the archive does not contain it.
+func readArchiveFile(path, name string) ([]byte, error) {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer reader.Close()
+	for _, file := range reader.File {
+		if file.Name != name {
+			continue
+		}
+		f, err := file.Open()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer f.Close()
+		return ioutil.ReadAll(f)
+	}
+	return nil, nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxdprofile
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Profile holds the LXD profile declared by a charm in its
+// lxd-profile.yaml file.
+type Profile struct {
+	// Description is a free-form description of the profile.
+	Description string `yaml:"description,omitempty"`
+
+	// Config holds the LXD container configuration keys and values
+	// to apply to the container.
+	Config map[string]string `yaml:"config,omitempty"`
+
+	// Devices holds the devices to add to the container, keyed by
+	// device name. Each device must declare its "type".
+	Devices map[string]map[string]string `yaml:"devices,omitempty"`
+}
+
+// Empty returns true if the profile neither sets any configuration
+// nor adds any devices.
+func (p Profile) Empty() bool {
+	return len(p.Config) == 0 && len(p.Devices) == 0
+}
+
+// allowedConfigKeys holds the LXD configuration keys that charms
+// are permitted to set.
+var allowedConfigKeys = map[string]bool{
+	"linux.kernel_modules": true,
+	"security.nesting":     true,
+	"security.privileged":  true,
+}
+
+// allowedConfigPrefixes holds the prefixes of LXD configuration keys
+// that charms are permitted to set.
+var allowedConfigPrefixes = []string{
+	"environment.",
+}
+
+// allowedDeviceTypes holds the LXD device types that charms are
+// permitted to add to containers.
+var allowedDeviceTypes = map[string]bool{
+	"gpu":        true,
+	"infiniband": true,
+	"unix-block": true,
+	"unix-char":  true,
+	"usb":        true,
+}
+
+// Validate returns an error if the profile sets any configuration
+// key or adds any device type which charms are not permitted to use.
+func (p Profile) Validate() error {
+	for _, key := range sortedKeys(p.Config) {
+		if !configKeyAllowed(key) {
+			return errors.NotValidf("config key %q", key)
+		}
+	}
+	deviceNames := make([]string, 0, len(p.Devices))
+	for name := range p.Devices {
+		deviceNames = append(deviceNames, name)
+	}
+	sort.Strings(deviceNames)
+	for _, name := range deviceNames {
+		deviceType := p.Devices[name]["type"]
+		if deviceType == "" {
+			return errors.NotValidf("device %q without type", name)
+		}
+		if !allowedDeviceTypes[deviceType] {
+			return errors.NotValidf("device %q of type %q", name, deviceType)
+		}
+	}
+	return nil
+}
+
+func configKeyAllowed(key string) bool {
+	if allowedConfigKeys[key] {
+		return true
+	}
+	for _, prefix := range allowedConfigPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ParseProfile parses the contents of a charm's lxd-profile.yaml
+// file. The profile is not validated.
+func ParseProfile(data []byte) (*Profile, error) {
+	var profile Profile
+	if err := yaml.Unmarshal(data, &profile); err != nil {
+		return nil, errors.Annotatef(err, "cannot parse %s", ProfileFile)
+	}
+	return &profile, nil
+}
+
+// charmProfilePrefix is the prefix of the names of all LXD profiles
+// created from charm profiles.
+const charmProfilePrefix = "juju-charm-"
+
+// uuidSuffixDigits defines how many of the model UUID's digits are
+// used to distinguish the profiles of different models.
+const uuidSuffixDigits = 6
+
+// Name returns the name of the LXD profile created from the profile
+// declared by the given revision of the charm used by the named
+// application.
+func Name(modelUUID, appName string, revision int) string {
+	suffix := modelUUID
+	if len(suffix) > uuidSuffixDigits {
+		suffix = suffix[len(suffix)-uuidSuffixDigits:]
+	}
+	return fmt.Sprintf("%s%s-%s-%d", charmProfilePrefix, suffix, appName, revision)
+}
+
+// IsCharmProfile returns true if the named LXD profile was created
+// from a charm profile.
+func IsCharmProfile(name string) bool {
+	return strings.HasPrefix(name, charmProfilePrefix)
+}
@@ -0,0 +1,154 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxdprofile_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/core/lxdprofile"
+)
+
+type ProfileSuite struct{}
+
+var _ = gc.Suite(&ProfileSuite{})
+
+const profileYAML = `
+description: test profile
+config:
+  security.nesting: "true"
+  linux.kernel_modules: openvswitch,nbd
+devices:
+  tun:
+    path: /dev/net/tun
+    type: unix-char
+`
+
+var expectedProfile = &lxdprofile.Profile{
+	Description: "test profile",
+	Config: map[string]string{
+		"security.nesting":     "true",
+		"linux.kernel_modules": "openvswitch,nbd",
+	},
+	Devices: map[string]map[string]string{
+		"tun": {"path": "/dev/net/tun", "type": "unix-char"},
+	},
+}
+
+func (*ProfileSuite) TestParseProfile(c *gc.C) {
+	profile, err := lxdprofile.ParseProfile([]byte(profileYAML))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, jc.DeepEquals, expectedProfile)
+	c.Assert(profile.Validate(), jc.ErrorIsNil)
+	c.Assert(profile.Empty(), jc.IsFalse)
+}
+
+func (*ProfileSuite) TestParseProfileInvalidYAML(c *gc.C) {
+	_, err := lxdprofile.ParseProfile([]byte("config: [}"))
+	c.Assert(err, gc.ErrorMatches, "cannot parse lxd-profile.yaml: .*")
+}
+
+func (*ProfileSuite) TestEmpty(c *gc.C) {
+	profile := lxdprofile.Profile{Description: "nothing to see"}
+	c.Assert(profile.Empty(), jc.IsTrue)
+}
+
+func (*ProfileSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		profile lxdprofile.Profile
+		err     string
+	}{{
+		profile: lxdprofile.Profile{Config: map[string]string{"environment.http_proxy": "x"}},
+	}, {
+		profile: lxdprofile.Profile{Config: map[string]string{"security.privileged": "true"}},
+	}, {
+		profile: lxdprofile.Profile{Config: map[string]string{"boot.autostart": "false"}},
+		err:     `config key "boot.autostart" not valid`,
+	}, {
+		profile: lxdprofile.Profile{Config: map[string]string{"raw.lxc": "lxc.aa_profile=unconfined"}},
+		err:     `config key "raw.lxc" not valid`,
+	}, {
+		profile: lxdprofile.Profile{Devices: map[string]map[string]string{
+			"gpu": {"type": "gpu"},
+		}},
+	}, {
+		profile: lxdprofile.Profile{Devices: map[string]map[string]string{
+			"root": {"type": "disk", "source": "/"},
+		}},
+		err: `device "root" of type "disk" not valid`,
+	}, {
+		profile: lxdprofile.Profile{Devices: map[string]map[string]string{
+			"tun": {"path": "/dev/net/tun"},
+		}},
+		err: `device "tun" without type not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := test.profile.Validate()
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (*ProfileSuite) TestName(c *gc.C) {
+	name := lxdprofile.Name("deadbeef-0bad-400d-8000-4b1d0d06f00d", "mysql", 3)
+	c.Assert(name, gc.Equals, "juju-charm-06f00d-mysql-3")
+	c.Assert(lxdprofile.IsCharmProfile(name), jc.IsTrue)
+	c.Assert(lxdprofile.IsCharmProfile("default"), jc.IsFalse)
+	c.Assert(lxdprofile.IsCharmProfile("juju-mymodel"), jc.IsFalse)
+}
+
+func (*ProfileSuite) writeCharmDir(c *gc.C, withProfile bool) string {
+	dir := filepath.Join(c.MkDir(), "charm")
+	err := os.Mkdir(dir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	meta := "name: profiled\nsummary: s\ndescription: d\n"
+	err = ioutil.WriteFile(filepath.Join(dir, "metadata.yaml"), []byte(meta), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	if withProfile {
+		err = ioutil.WriteFile(filepath.Join(dir, lxdprofile.ProfileFile), []byte(profileYAML), 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return dir
+}
+
+func (s *ProfileSuite) TestReadCharmProfileDir(c *gc.C) {
+	ch, err := charm.ReadCharmDir(s.writeCharmDir(c, true))
+	c.Assert(err, jc.ErrorIsNil)
+	profile, err := lxdprofile.ReadCharmProfile(ch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, jc.DeepEquals, expectedProfile)
+}
+
+func (s *ProfileSuite) TestReadCharmProfileArchive(c *gc.C) {
+	dir, err := charm.ReadCharmDir(s.writeCharmDir(c, true))
+	c.Assert(err, jc.ErrorIsNil)
+	path := filepath.Join(c.MkDir(), "charm.zip")
+	f, err := os.Create(path)
+	c.Assert(err, jc.ErrorIsNil)
+	err = dir.ArchiveTo(f)
+	f.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	ch, err := charm.ReadCharmArchive(path)
+	c.Assert(err, jc.ErrorIsNil)
+
+	profile, err := lxdprofile.ReadCharmProfile(ch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, jc.DeepEquals, expectedProfile)
+}
+
+func (s *ProfileSuite) TestReadCharmProfileMissing(c *gc.C) {
+	ch, err := charm.ReadCharmDir(s.writeCharmDir(c, false))
+	c.Assert(err, jc.ErrorIsNil)
+	profile, err := lxdprofile.ReadCharmProfile(ch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, gc.IsNil)
+}
@@ -0,0 +1,21 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"time"
+
+	"github.com/juju/loggo"
+)
+
+// LogRecord holds a single historical log record for a model being
+// migrated.
+type LogRecord struct {
+	Time     time.Time
+	Entity   string
+	Module   string
+	Location string
+	Level    loggo.Level
+	Message  string
+}
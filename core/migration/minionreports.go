@@ -0,0 +1,25 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import "gopkg.in/juju/names.v2"
+
+// MinionReports records which agents have reported success or failure
+// for the current phase of a migration, and how many are yet to
+// report.
+type MinionReports struct {
+	MigrationId  string
+	Phase        Phase
+	SuccessCount int
+	UnknownCount int
+
+	// SomeUnknown holds the tags of some of the agents which are
+	// yet to report. It is a sample so that the size of the reports
+	// stays bounded for large models.
+	SomeUnknown []names.Tag
+
+	// Failed holds the tags of every agent which failed to complete
+	// its actions for the phase.
+	Failed []names.Tag
+}
@@ -0,0 +1,21 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+)
+
+// ModelInfo describes a model being migrated, as needed by the target
+// controller to decide whether it can accept the model.
+type ModelInfo struct {
+	UUID            string
+	Name            string
+	Owner           names.UserTag
+	AgentVersion    version.Number
+	CloudType       string
+	CloudRegion     string
+	CloudCredential string
+}
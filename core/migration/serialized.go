@@ -0,0 +1,21 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import "github.com/juju/version"
+
+// SerializedModel wraps a buffer contain a serialised Juju model. It
+// also contains lists of the charms and agent binaries used by the
+// model.
+type SerializedModel struct {
+	// Bytes contains the serialized data for the model.
+	Bytes []byte
+
+	// Charms lists the charm URLs in use in the model.
+	Charms []string
+
+	// Tools maps the agent binary versions in use in the model to
+	// their SHA256 hashes.
+	Tools map[version.Binary]string
+}
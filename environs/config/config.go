@@ -5,6 +5,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"strings"
 	"time"
@@ -16,6 +17,7 @@ import (
 	"github.com/juju/utils/proxy"
 	"github.com/juju/utils/series"
 	"github.com/juju/version"
+	"golang.org/x/crypto/openpgp"
 	"gopkg.in/juju/charmrepo.v2-unstable"
 	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/juju/names.v2"
@@ -23,6 +25,8 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/utils/pgpkeys"
 )
 
 var logger = loggo.GetLogger("juju.environs.config")
@@ -42,6 +46,23 @@ const (
 	// instance security groups.
 	FwNone = "none"
 
+	// MetricsSenderCollector sends charm metrics to the Juju
+	// metrics collection service. This is the default.
+	MetricsSenderCollector = "collector"
+
+	// MetricsSenderHTTP posts charm metrics as JSON to the
+	// endpoint given by metrics-sender-url.
+	MetricsSenderHTTP = "http"
+
+	// MetricsSenderPushgateway pushes charm metrics to the
+	// Prometheus pushgateway given by metrics-sender-url.
+	MetricsSenderPushgateway = "pushgateway"
+
+	// MetricsSenderFile appends charm metrics to a file named after
+	// the model in the "metrics" directory of the controller's data
+	// directory. It does not take a metrics-sender-url.
+	MetricsSenderFile = "file"
+
 	// DefaultStatePort is the default port the controller is listening on.
 	DefaultStatePort int = 37017
 
@@ -129,6 +150,25 @@ const (
 	// of k=v pairs, defining the tags for ResourceTags.
 	ResourceTagsKey = "resource-tags"
 
+	// IngressCIDRsKey is an optional comma-separated list of source
+	// CIDRs from which the ports of exposed applications may be
+	// reached, unless an application was exposed to specific CIDRs.
+	IngressCIDRsKey = "ingress-cidrs"
+
+	// MetricsSenderKey selects where the controller sends the charm
+	// metrics collected in the model.
+	MetricsSenderKey = "metrics-sender"
+
+	// MetricsSenderURLKey is the URL that charm metrics are sent to,
+	// for metrics senders other than the collector.
+	MetricsSenderURLKey = "metrics-sender-url"
+
+	// SimplestreamsTrustedKeysKey holds armored GPG public keys, in
+	// addition to the built-in keys, that are trusted to sign the
+	// simplestreams metadata found at agent-metadata-url and
+	// image-metadata-url.
+	SimplestreamsTrustedKeysKey = "simplestreams-trusted-keys"
+
 	// CloudImageBaseURL allows a user to override the default url that the
 	// 'ubuntu-cloudimg-query' executable uses to find container images. This
 	// is primarily for enabling Juju to work cleanly in a closed network.
@@ -233,6 +273,70 @@ type Config struct {
 	defined, unknown map[string]interface{}
 }
 
+// The following constants describe where a model config
+// attribute's value comes from.
+const (
+	// JujuDefaultSource is used to label model config attributes that
+	// come from hard coded defaults.
+	JujuDefaultSource = "default"
+
+	// JujuControllerSource is used to label model config attributes that
+	// come from those associated with the controller.
+	JujuControllerSource = "controller"
+
+	// JujuRegionSource is used to label model config attributes that
+	// come from those associated with the model's cloud region.
+	JujuRegionSource = "region"
+
+	// JujuModelConfigSource is used to label model config attributes that
+	// have been explicitly set by the user.
+	JujuModelConfigSource = "model"
+)
+
+// ConfigValue contains a model config attribute value
+// and its source.
+type ConfigValue struct {
+	// Value is the value of the config attribute.
+	Value interface{} `json:"value" yaml:"value"`
+
+	// Source is the name of the inheritance layer that
+	// supplied the value, one of the Juju*Source constants.
+	Source string `json:"source" yaml:"source"`
+}
+
+// ConfigValues represents model config values
+// annotated with their source.
+type ConfigValues map[string]ConfigValue
+
+// RegionDefaultValue holds the default value of a model config
+// attribute for a particular cloud region.
+type RegionDefaultValue struct {
+	// Name is the name of the cloud region.
+	Name string `json:"name" yaml:"name"`
+
+	// Value is the default value for models in the region.
+	Value interface{} `json:"value" yaml:"value"`
+}
+
+// AttributeDefaultValues holds the values of a model config attribute
+// at each of the levels from which new models inherit it.
+type AttributeDefaultValues struct {
+	// Default is the value hard coded into Juju, if any.
+	Default interface{} `json:"default,omitempty" yaml:"default,omitempty"`
+
+	// Controller is the value set for all models on the
+	// controller, if any.
+	Controller interface{} `json:"controller,omitempty" yaml:"controller,omitempty"`
+
+	// Regions holds the values set for models in particular
+	// cloud regions.
+	Regions []RegionDefaultValue `json:"regions,omitempty" yaml:"regions,omitempty"`
+}
+
+// ModelDefaultAttributes maps model config attribute names to
+// their inherited default values.
+type ModelDefaultAttributes map[string]AttributeDefaultValues
+
 // Defaulting is a value that specifies whether a configuration
 // creator should use defaults from the environment.
 type Defaulting bool
@@ -448,6 +552,18 @@ func Validate(cfg, old *Config) error {
 		return errors.Annotate(err, "validating resource tags")
 	}
 
+	if _, err := cfg.ingressCIDRs(); err != nil {
+		return errors.Annotate(err, "validating ingress CIDRs")
+	}
+
+	if err := cfg.validateMetricsSender(); err != nil {
+		return errors.Annotate(err, "validating metrics sender")
+	}
+
+	if _, err := cfg.simplestreamsTrustedKeys(); err != nil {
+		return errors.Annotate(err, "validating simplestreams trusted keys")
+	}
+
 	// Check the immutable config values.  These can't change
 	if old != nil {
 		allImmutableAttributes := append(immutableAttributes, controller.ControllerOnlyConfigAttributes...)
@@ -860,6 +976,107 @@ func (c *Config) resourceTags() (map[string]string, error) {
 	return v, nil
 }
 
+// IngressCIDRs returns the source CIDRs from which the ports of exposed
+// applications may be reached by default. If none are configured, the
+// ports may be reached from anywhere.
+func (c *Config) IngressCIDRs() []string {
+	cidrs, err := c.ingressCIDRs()
+	if err != nil {
+		panic(err) // should be prevented by Validate
+	}
+	return cidrs
+}
+
+// MetricsSender returns the kind of sink that charm metrics collected
+// in the model are sent to, one of the MetricsSender* constants.
+func (c *Config) MetricsSender() string {
+	if sender := c.asString(MetricsSenderKey); sender != "" {
+		return sender
+	}
+	return MetricsSenderCollector
+}
+
+// MetricsSenderURL returns the URL that charm metrics are sent to by
+// senders other than the collector.
+func (c *Config) MetricsSenderURL() string {
+	return c.asString(MetricsSenderURLKey)
+}
+
+// SimplestreamsTrustedKeys returns the armored GPG public keys, in
+// addition to the built-in keys, that are trusted to sign the model's
+// simplestreams metadata.
+func (c *Config) SimplestreamsTrustedKeys() []string {
+	keys, err := c.simplestreamsTrustedKeys()
+	if err != nil {
+		panic(err) // should be prevented by Validate
+	}
+	return keys
+}
+
+func (c *Config) simplestreamsTrustedKeys() ([]string, error) {
+	keys := pgpkeys.SplitArmored(c.asString(SimplestreamsTrustedKeysKey))
+	for i, key := range keys {
+		keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key))
+		if err != nil {
+			return nil, errors.Annotatef(err, "key %d", i+1)
+		}
+		for _, entity := range keyring {
+			if entity.PrivateKey != nil {
+				return nil, errors.Errorf("key %d (0x%s) is a private key", i+1, entity.PrimaryKey.KeyIdString())
+			}
+		}
+	}
+	return keys, nil
+}
+
+func (c *Config) validateMetricsSender() error {
+	sender := c.MetricsSender()
+	senderURL := c.MetricsSenderURL()
+	var schemes []string
+	switch sender {
+	case MetricsSenderCollector:
+		return nil
+	case MetricsSenderHTTP, MetricsSenderPushgateway:
+		schemes = []string{"http", "https"}
+	case MetricsSenderFile:
+		// The file is always written to the controller's data
+		// directory, so that model admins cannot have the
+		// controller write to arbitrary paths.
+		if senderURL != "" {
+			return errors.Errorf("%s metrics sender does not accept %s", sender, MetricsSenderURLKey)
+		}
+		return nil
+	default:
+		return errors.NotValidf("metrics sender %q", sender)
+	}
+	if senderURL == "" {
+		return errors.Errorf("%s metrics sender requires %s", sender, MetricsSenderURLKey)
+	}
+	u, err := url.Parse(senderURL)
+	if err != nil {
+		return errors.Annotatef(err, "invalid %s", MetricsSenderURLKey)
+	}
+	for _, scheme := range schemes {
+		if u.Scheme == scheme {
+			return nil
+		}
+	}
+	return errors.Errorf("%s %q must use one of the schemes %v", MetricsSenderURLKey, senderURL, schemes)
+}
+
+func (c *Config) ingressCIDRs() ([]string, error) {
+	var cidrs []string
+	for _, cidr := range strings.Split(c.asString(IngressCIDRsKey), ",") {
+		if cidr = strings.TrimSpace(cidr); cidr != "" {
+			cidrs = append(cidrs, cidr)
+		}
+	}
+	if err := network.ValidateCIDRs(cidrs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cidrs, nil
+}
+
 // UnknownAttrs returns a copy of the raw configuration attributes
 // that are supposedly specific to the environment type. They could
 // also be wrong attributes, though. Only the specific environment
@@ -927,6 +1144,8 @@ var alwaysOptional = schema.Defaults{
 	controller.ControllerUUIDKey:      schema.Omit,
 	controller.CACertKey:              schema.Omit,
 	controller.CAPrivateKey:           schema.Omit,
+	controller.CAKeyType:              schema.Omit,
+	controller.CharmStoreMirror:       schema.Omit,
 	controller.ApiPort:                schema.Omit,
 	controller.StatePort:              schema.Omit,
 	controller.IdentityURL:            schema.Omit,
@@ -957,6 +1176,10 @@ var alwaysOptional = schema.Defaults{
 	AgentStreamKey:               schema.Omit,
 	SetNumaControlPolicyKey:      DefaultNumaControlPolicy,
 	ResourceTagsKey:              schema.Omit,
+	IngressCIDRsKey:              schema.Omit,
+	MetricsSenderKey:             schema.Omit,
+	MetricsSenderURLKey:          schema.Omit,
+	SimplestreamsTrustedKeysKey:  schema.Omit,
 	CloudImageBaseURL:            schema.Omit,
 
 	// AutomaticallyRetryHooks is assumed to be true if missing
@@ -1010,6 +1233,21 @@ func allDefaults() schema.Defaults {
 	return d
 }
 
+// ConfigDefaults returns the hard coded defaults for model config
+// attributes that have them. Controller attributes, which are parsed
+// along with model config, are not included.
+func ConfigDefaults() map[string]interface{} {
+	result := make(map[string]interface{})
+	for attr, val := range defaults {
+		if val == schema.Omit {
+			continue
+		}
+		result[attr] = val
+	}
+	controller.RemoveControllerAttributes(result)
+	return result
+}
+
 // allowedWithDefaultsOnly holds those attributes
 // that are only allowed in a configuration that is
 // being created with UseDefaults.
@@ -1319,6 +1557,27 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	IngressCIDRsKey: {
+		Description: "Source CIDRs from which exposed applications may be reached by default (comma-separated)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MetricsSenderKey: {
+		Description: `Where charm metrics are sent: the Juju metrics collector ("collector", the default), an HTTP endpoint accepting JSON ("http"), a Prometheus pushgateway ("pushgateway") or a file in the controller's data directory ("file")`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{"", MetricsSenderCollector, MetricsSenderHTTP, MetricsSenderPushgateway, MetricsSenderFile},
+		Group:       environschema.EnvironGroup,
+	},
+	MetricsSenderURLKey: {
+		Description: "The URL charm metrics are sent to by the http and pushgateway metrics senders",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	SimplestreamsTrustedKeysKey: {
+		Description: "Armored GPG public keys, in addition to the built-in keys, trusted to sign the simplestreams metadata at agent-metadata-url and image-metadata-url",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	NameKey: {
 		Description: "The name of the current model",
 		Type:        environschema.Tstring,
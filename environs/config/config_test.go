@@ -25,6 +25,7 @@ import (
 
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/config"
+	sstesting "github.com/juju/juju/environs/simplestreams/testing"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/testing"
 )
@@ -1200,6 +1201,18 @@ func (s *ConfigSuite) TestValidateChange(c *gc.C) {
 	}
 }
 
+func (s *ConfigSuite) TestConfigDefaults(c *gc.C) {
+	defaults := config.ConfigDefaults()
+	c.Assert(defaults["firewall-mode"], gc.Equals, config.FwInstance)
+	c.Assert(defaults["ssl-hostname-verification"], gc.Equals, true)
+	// Controller attributes and attributes without
+	// a default value are not reported.
+	for _, attr := range []string{"api-port", "state-port", "agent-version"} {
+		_, ok := defaults[attr]
+		c.Check(ok, jc.IsFalse, gc.Commentf("%s", attr))
+	}
+}
+
 func (s *ConfigSuite) addJujuFiles(c *gc.C) {
 	s.FakeHomeSuite.Home.AddFiles(c, []gitjujutesting.TestFile{
 		{".ssh/id_rsa.pub", "rsa\n"},
@@ -1367,6 +1380,120 @@ func (s *ConfigSuite) TestCloudImageBaseURLSet(c *gc.C) {
 	c.Assert(config.CloudImageBaseURL(), gc.Equals, "http://local.foo/query")
 }
 
+func (s *ConfigSuite) TestIngressCIDRs(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.IngressCIDRs(), gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestIngressCIDRsSet(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{
+		"ingress-cidrs": "10.0.0.0/8, 192.168.0.0/16"})
+	c.Assert(config.IngressCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.0.0/16"})
+}
+
+func (s *ConfigSuite) TestIngressCIDRsInvalid(c *gc.C) {
+	s.addJujuFiles(c)
+	_, err := config.New(config.UseDefaults, testing.Attrs{
+		"type": "my-type", "name": "my-name",
+		"uuid":            testing.ModelTag.Id(),
+		"controller-uuid": testing.ModelTag.Id(),
+		"ingress-cidrs":   "10.0.0.0/8,foo",
+	})
+	c.Assert(err, gc.ErrorMatches, `validating ingress CIDRs: CIDR "foo" not valid`)
+}
+
+func (s *ConfigSuite) TestSimplestreamsTrustedKeys(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.SimplestreamsTrustedKeys(), gc.HasLen, 0)
+	config = newTestConfig(c, testing.Attrs{
+		"simplestreams-trusted-keys": sstesting.SignedMetadataPublicKey + sstesting.SignedMetadataPublicKey,
+	})
+	c.Assert(config.SimplestreamsTrustedKeys(), jc.DeepEquals, []string{
+		strings.TrimSpace(sstesting.SignedMetadataPublicKey),
+		strings.TrimSpace(sstesting.SignedMetadataPublicKey),
+	})
+}
+
+func (s *ConfigSuite) TestSimplestreamsTrustedKeysInvalid(c *gc.C) {
+	s.addJujuFiles(c)
+	for i, test := range []struct {
+		keys string
+		err  string
+	}{{
+		keys: sstesting.SignedMetadataPublicKey + "\n-----BEGIN PGP PUBLIC KEY BLOCK-----\n\ngarbage\n-----END PGP PUBLIC KEY BLOCK-----\n",
+		err:  `validating simplestreams trusted keys: key 2: .*`,
+	}, {
+		keys: sstesting.SignedMetadataPrivateKey,
+		err:  `validating simplestreams trusted keys: key 1 \(0x[0-9A-F]+\) is a private key`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := config.New(config.UseDefaults, testing.Attrs{
+			"type": "my-type", "name": "my-name",
+			"uuid":                       testing.ModelTag.Id(),
+			"controller-uuid":            testing.ModelTag.Id(),
+			"simplestreams-trusted-keys": test.keys,
+		})
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ConfigSuite) TestMetricsSenderDefault(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.MetricsSender(), gc.Equals, "collector")
+	c.Assert(config.MetricsSenderURL(), gc.Equals, "")
+}
+
+func (s *ConfigSuite) TestMetricsSender(c *gc.C) {
+	s.addJujuFiles(c)
+	for i, test := range []struct {
+		sender string
+		url    string
+		err    string
+	}{{
+		sender: "http",
+		url:    "https://metrics.example.com/juju",
+	}, {
+		sender: "pushgateway",
+		url:    "http://10.0.0.1:9091",
+	}, {
+		sender: "file",
+	}, {
+		sender: "http",
+		err:    `validating metrics sender: http metrics sender requires metrics-sender-url`,
+	}, {
+		sender: "pushgateway",
+		url:    "file:///tmp/metrics",
+		err:    `validating metrics sender: metrics-sender-url "file:///tmp/metrics" must use one of the schemes \[http https\]`,
+	}, {
+		sender: "file",
+		url:    "file:///etc/passwd",
+		err:    `validating metrics sender: file metrics sender does not accept metrics-sender-url`,
+	}, {
+		sender: "carrier-pigeon",
+		err:    `metrics-sender: expected one of .*`,
+	}} {
+		c.Logf("test %d: %s %s", i, test.sender, test.url)
+		config, err := config.New(config.UseDefaults, testing.Attrs{
+			"type": "my-type", "name": "my-name",
+			"uuid":               testing.ModelTag.Id(),
+			"controller-uuid":    testing.ModelTag.Id(),
+			"metrics-sender":     test.sender,
+			"metrics-sender-url": test.url,
+		})
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(config.MetricsSender(), gc.Equals, test.sender)
+		c.Check(config.MetricsSenderURL(), gc.Equals, test.url)
+	}
+}
+
 func (s *ConfigSuite) TestProxyValuesWithFallback(c *gc.C) {
 	s.addJujuFiles(c)
 
@@ -1641,3 +1768,4 @@ var invalidCACert = `
 MIIBOgIBAAJAZabKgKInuOxj5vDWLwHHQtK3/45KB+32D15w94Nt83BmuGxo90lw
 -----END CERTIFICATE-----
 `[1:]
+
@@ -90,7 +90,8 @@ func ImageMetadataSources(env Environ) ([]simplestreams.DataSource, error) {
 		if !config.SSLHostnameVerification() {
 			verify = utils.NoVerifySSLHostnames
 		}
-		publicKey, _ := simplestreams.UserPublicSigningKey()
+		userKey, _ := simplestreams.UserPublicSigningKey()
+		publicKey := simplestreams.JoinPublicKeys(append([]string{userKey}, config.SimplestreamsTrustedKeys()...)...)
 		sources = append(sources, simplestreams.NewURLSignedDataSource("image-metadata-url", userURL, publicKey, verify, simplestreams.SPECIFIC_CLOUD_DATA, false))
 	}
 
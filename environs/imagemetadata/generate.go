@@ -120,3 +120,47 @@ func writeMetadata(metadata []*ImageMetadata, cloudSpec []simplestreams.CloudSpe
 	}
 	return nil
 }
+
+// MergeAndWriteMirroredMetadata merges metadata copied from another
+// simplestreams source, which may span any number of series and regions,
+// with the existing metadata for the same stream in storage (if any), and
+// writes the resulting metadata to storage. Copied records replace
+// existing records for the same image.
+func MergeAndWriteMirroredMetadata(stream string, metadata []*ImageMetadata, metadataStore storage.Storage) error {
+	dataSource := storage.NewStorageSimpleStreamsDataSource("existing metadata", metadataStore, storage.BaseImagesPath, simplestreams.EXISTING_CLOUD_DATA, false)
+	imageConstraint := NewImageConstraint(simplestreams.LookupParams{Stream: stream})
+	existingMetadata, _, err := Fetch([]simplestreams.DataSource{dataSource}, imageConstraint)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	var toWrite []*ImageMetadata
+	imageIds := make(map[string]bool)
+	regions := make(map[string]bool)
+	var allCloudSpecs []simplestreams.CloudSpec
+	add := func(im *ImageMetadata) {
+		record := *im
+		record.Stream = stream
+		key := fmt.Sprintf("%s-%s-%s", mapKey(&record), record.VirtType, record.Storage)
+		if imageIds[key] {
+			return
+		}
+		imageIds[key] = true
+		toWrite = append(toWrite, &record)
+		if regions[record.RegionName] {
+			return
+		}
+		regions[record.RegionName] = true
+		allCloudSpecs = append(allCloudSpecs, simplestreams.CloudSpec{
+			Region:   record.RegionName,
+			Endpoint: record.Endpoint,
+		})
+	}
+	for _, im := range metadata {
+		add(im)
+	}
+	for _, im := range existingMetadata {
+		add(im)
+	}
+	return writeMetadata(toWrite, allCloudSpecs, metadataStore)
+}
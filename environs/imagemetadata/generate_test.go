@@ -194,3 +194,44 @@ func (s *generateSuite) TestWriteMetadataMergeDifferentRegion(c *gc.C) {
 	assertFetch(c, targetStorage, "raring", "amd64", "region", "endpoint", "1234")
 	assertFetch(c, targetStorage, "raring", "amd64", "region2", "endpoint2", "abcd")
 }
+
+func (s *generateSuite) TestWriteMirroredMetadata(c *gc.C) {
+	existingImageMetadata := []*imagemetadata.ImageMetadata{
+		{
+			Id:      "1234",
+			Arch:    "amd64",
+			Version: "13.04",
+		},
+	}
+	cloudSpec := &simplestreams.CloudSpec{
+		Region:   "region",
+		Endpoint: "endpoint",
+	}
+	dir := c.MkDir()
+	targetStorage, err := filestorage.NewFileStorageWriter(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	err = imagemetadata.MergeAndWriteMetadata("raring", existingImageMetadata, cloudSpec, targetStorage)
+	c.Assert(err, jc.ErrorIsNil)
+	mirroredImageMetadata := []*imagemetadata.ImageMetadata{
+		{
+			Id:         "abcd",
+			Arch:       "amd64",
+			Version:    "13.04",
+			RegionName: "region",
+			Endpoint:   "endpoint",
+		},
+		{
+			Id:         "xyz",
+			Arch:       "amd64",
+			Version:    "12.04",
+			RegionName: "region2",
+			Endpoint:   "endpoint2",
+		},
+	}
+	err = imagemetadata.MergeAndWriteMirroredMetadata("released", mirroredImageMetadata, targetStorage)
+	c.Assert(err, jc.ErrorIsNil)
+	metadata := testing.ParseMetadataFromDir(c, dir)
+	c.Assert(metadata, gc.HasLen, 2)
+	assertFetch(c, targetStorage, "raring", "amd64", "region", "endpoint", "abcd")
+	assertFetch(c, targetStorage, "precise", "amd64", "region2", "endpoint2", "xyz")
+}
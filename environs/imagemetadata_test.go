@@ -4,6 +4,8 @@
 package environs_test
 
 import (
+	"strings"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -43,6 +45,10 @@ func (s *ImageMetadataSuite) env(c *gc.C, imageMetadataURL, stream string) envir
 			"image-metadata-url": imageMetadataURL,
 		})
 	}
+	return s.envWithAttrs(c, attrs)
+}
+
+func (s *ImageMetadataSuite) envWithAttrs(c *gc.C, attrs testing.Attrs) environs.Environ {
 	env, err := environs.Prepare(
 		envtesting.BootstrapContext(c),
 		jujuclienttesting.NewMemStore(),
@@ -77,6 +83,20 @@ func (s *ImageMetadataSuite) TestImageMetadataURLs(c *gc.C) {
 	})
 }
 
+func (s *ImageMetadataSuite) TestImageMetadataURLsTrustedKeys(c *gc.C) {
+	env := s.envWithAttrs(c, dummy.SampleConfig().Merge(testing.Attrs{
+		"image-metadata-url":         "config-image-metadata-url",
+		"simplestreams-trusted-keys": sstesting.SignedMetadataPublicKey,
+	}))
+	sources, err := environs.ImageMetadataSources(env)
+	c.Assert(err, jc.ErrorIsNil)
+	sstesting.AssertExpectedSources(c, sources, []sstesting.SourceDetails{
+		{"config-image-metadata-url/", strings.TrimSpace(sstesting.SignedMetadataPublicKey)},
+		{"https://streams.canonical.com/juju/images/releases/", imagemetadata.SimplestreamsImagesPublicKey},
+		{"http://cloud-images.ubuntu.com/releases/", imagemetadata.SimplestreamsImagesPublicKey},
+	})
+}
+
 func (s *ImageMetadataSuite) TestImageMetadataURLsRegisteredFuncs(c *gc.C) {
 	environs.RegisterImageDataSourceFunc("id0", func(environs.Environ) (simplestreams.DataSource, error) {
 		return simplestreams.NewURLDataSource("id0", "betwixt/releases", utils.NoVerifySSLHostnames, simplestreams.DEFAULT_CLOUD_DATA, false), nil
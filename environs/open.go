@@ -243,7 +243,8 @@ func ensureCertificate(cfg *config.Config) (*config.Config, string, error) {
 	}
 
 	// TODO(perrito666) 2016-05-02 lp:1558657
-	caCert, caKey, err := cert.NewCA(cfg.Name(), cfg.UUID(), time.Now().UTC().AddDate(10, 0, 0))
+	expiry := time.Now().UTC().AddDate(10, 0, 0)
+	caCert, caKey, err := cert.NewCAWithKeyType(cfg.Name(), cfg.UUID(), expiry, controllerCfg.CAKeyType())
 	if err != nil {
 		return nil, "", errors.Trace(err)
 	}
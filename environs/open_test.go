@@ -4,6 +4,8 @@
 package environs_test
 
 import (
+	"crypto/ecdsa"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -265,6 +267,30 @@ func (*OpenSuite) TestPrepareWithExistingKeyPair(c *gc.C) {
 	c.Assert(string(cfgKeyPEM), gc.DeepEquals, testing.CAKey)
 }
 
+func (*OpenSuite) TestPrepareWithECDSAKeyType(c *gc.C) {
+	cfg, err := config.New(config.NoDefaults, dummy.SampleConfig().Delete("ca-cert", "ca-private-key").Merge(
+		testing.Attrs{
+			"controller":  false,
+			"name":        "erewhemos",
+			"ca-key-type": "ecdsa",
+		},
+	))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := envtesting.BootstrapContext(c)
+	env, err := environs.Prepare(ctx, jujuclienttesting.NewMemStore(), environs.PrepareParams{
+		ControllerName: cfg.Name(),
+		BaseConfig:     cfg.AllAttrs(),
+		CloudName:      "dummy",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	controllerCfg := controller.Config(env.Config().AllAttrs())
+	cfgCertPEM, _ := controllerCfg.CACert()
+	cfgKeyPEM, _ := controllerCfg.CAPrivateKey()
+	_, caKey, err := cert.ParseCertAndKey(cfgCertPEM, cfgKeyPEM)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(caKey, gc.FitsTypeOf, (*ecdsa.PrivateKey)(nil))
+}
+
 func (*OpenSuite) TestDestroy(c *gc.C) {
 	cfg, err := config.New(config.NoDefaults, dummy.SampleConfig().Merge(
 		testing.Attrs{
@@ -96,6 +96,20 @@ func NewURLSignedDataSource(description, baseURL, publicKey string, hostnameVeri
 	}
 }
 
+// JoinPublicKeys combines armored public keys, ignoring any that are
+// empty, into a single key suitable for NewURLSignedDataSource. Signed
+// metadata read from such a datasource is accepted if it was signed by
+// any one of the keys.
+func JoinPublicKeys(keys ...string) string {
+	var nonEmpty []string
+	for _, key := range keys {
+		if strings.TrimSpace(key) != "" {
+			nonEmpty = append(nonEmpty, key)
+		}
+	}
+	return strings.Join(nonEmpty, "\n")
+}
+
 // Description is defined in simplestreams.DataSource.
 func (u *urlDataSource) Description() string {
 	return u.description
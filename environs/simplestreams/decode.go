@@ -8,13 +8,19 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"sort"
+	"strings"
 
 	"golang.org/x/crypto/openpgp"
 	"golang.org/x/crypto/openpgp/clearsign"
+
+	"github.com/juju/juju/utils/pgpkeys"
 )
 
 // DecodeCheckSignature parses the inline signed PGP text, checks the signature,
-// and returns plain text if the signature matches.
+// and returns plain text if the signature matches. The armored public key may
+// hold several concatenated keys, in which case a signature made by any one of
+// them is accepted.
 func DecodeCheckSignature(r io.Reader, armoredPublicKey string) ([]byte, error) {
 	data, err := ioutil.ReadAll(r)
 	if err != nil {
@@ -24,16 +30,61 @@ func DecodeCheckSignature(r io.Reader, armoredPublicKey string) ([]byte, error)
 	if b == nil {
 		return nil, &NotPGPSignedError{}
 	}
-	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewBufferString(armoredPublicKey))
+	signature, err := ioutil.ReadAll(b.ArmoredSignature.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse public key: %v", err)
+		return nil, err
+	}
+	keys := pgpkeys.SplitArmored(armoredPublicKey)
+	if len(keys) <= 1 {
+		keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewBufferString(armoredPublicKey))
+		if err != nil {
+			if len(keys) == 1 {
+				return nil, fmt.Errorf("key 1: failed to parse public key: %v", err)
+			}
+			return nil, fmt.Errorf("failed to parse public key: %v", err)
+		}
+		_, err = openpgp.CheckDetachedSignature(keyring, bytes.NewReader(b.Bytes), bytes.NewReader(signature))
+		if err != nil {
+			return nil, err
+		}
+		return b.Plaintext, nil
 	}
 
-	_, err = openpgp.CheckDetachedSignature(keyring, bytes.NewBuffer(b.Bytes), b.ArmoredSignature.Body)
-	if err != nil {
-		return nil, err
+	// Try each key in turn, so that the error can say
+	// which keys were tried and why each of them failed.
+	var failures []string
+	for i, key := range keys {
+		keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key))
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("key %d: failed to parse public key: %v", i+1, err))
+			continue
+		}
+		_, err = openpgp.CheckDetachedSignature(keyring, bytes.NewReader(b.Bytes), bytes.NewReader(signature))
+		if err == nil {
+			return b.Plaintext, nil
+		}
+		failures = append(failures, fmt.Sprintf("key %d %s: %v", i+1, describeKeyRing(keyring), err))
+	}
+	return nil, fmt.Errorf("signature not verified by any trusted key: %s", strings.Join(failures, "; "))
+}
+
+// describeKeyRing returns a description of the keys in the key ring,
+// giving the id and a user identity of each key.
+func describeKeyRing(keyring openpgp.EntityList) string {
+	var descriptions []string
+	for _, entity := range keyring {
+		description := "0x" + entity.PrimaryKey.KeyIdString()
+		var names []string
+		for name := range entity.Identities {
+			names = append(names, name)
+		}
+		if len(names) > 0 {
+			sort.Strings(names)
+			description += fmt.Sprintf(" (%s)", names[0])
+		}
+		descriptions = append(descriptions, description)
 	}
-	return b.Plaintext, nil
+	return strings.Join(descriptions, ", ")
 }
 
 // NotPGPSignedError is used when PGP text does not contain an inline signature.
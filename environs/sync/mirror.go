@@ -0,0 +1,95 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sync
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/environs/storage"
+)
+
+// SyncImageMetadata copies the image metadata for the given stream from
+// source, a local directory or URL, into the target storage, merging it
+// with any image metadata already there. It returns the number of image
+// records copied.
+func SyncImageMetadata(source, stream string, target storage.Storage, dryRun bool) (int, error) {
+	sourceURL, err := imagemetadata.ImageMetadataURL(source, stream)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if sourceURL == "" {
+		return 0, errors.New("no image metadata source specified")
+	}
+	logger.Infof("using image metadata source: %v", sourceURL)
+	publicKey, err := simplestreams.UserPublicSigningKey()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if publicKey == "" {
+		publicKey = imagemetadata.SimplestreamsImagesPublicKey
+	}
+	dataSource := simplestreams.NewURLSignedDataSource(
+		"image metadata source", sourceURL, publicKey, utils.VerifySSLHostnames, simplestreams.CUSTOM_CLOUD_DATA, false,
+	)
+	cons := imagemetadata.NewImageConstraint(simplestreams.LookupParams{Stream: stream})
+	metadata, _, err := imagemetadata.Fetch([]simplestreams.DataSource{dataSource}, cons)
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot read image metadata")
+	}
+	logger.Infof("found %d images", len(metadata))
+	if dryRun {
+		for _, im := range metadata {
+			logger.Infof("copying image %s (%s %s) in region %q", im.Id, im.Version, im.Arch, im.RegionName)
+		}
+		return len(metadata), nil
+	}
+	if err := imagemetadata.MergeAndWriteMirroredMetadata(stream, metadata, target); err != nil {
+		return 0, errors.Annotate(err, "cannot write image metadata")
+	}
+	logger.Infof("copied %d images", len(metadata))
+	return len(metadata), nil
+}
+
+// SignMetadata inline signs every simplestreams metadata file in the
+// storage with the given armored private key, writing each signed file
+// alongside the unsigned file it was made from. Signed files are always
+// regenerated, so that they reflect any metadata merged into the storage
+// since they were last written.
+func SignMetadata(stor storage.Storage, armoredPrivateKey, passphrase string) error {
+	names, err := storage.List(stor, "")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, name := range names {
+		if !strings.HasSuffix(name, simplestreams.UnsignedSuffix) {
+			continue
+		}
+		if err := signMetadataFile(stor, name, armoredPrivateKey, passphrase); err != nil {
+			return errors.Annotatef(err, "cannot sign %q", name)
+		}
+	}
+	return nil
+}
+
+// signMetadataFile inline signs the named simplestreams metadata file.
+func signMetadataFile(stor storage.Storage, name, armoredPrivateKey, passphrase string) error {
+	r, err := storage.Get(stor, name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer r.Close()
+	encoded, err := simplestreams.Encode(r, armoredPrivateKey, passphrase)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	signedName := strings.TrimSuffix(name, simplestreams.UnsignedSuffix) + simplestreams.SignedSuffix
+	logger.Infof("signing %q", name)
+	return stor.Put(signedName, bytes.NewReader(encoded), int64(len(encoded)))
+}
@@ -0,0 +1,97 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sync_test
+
+import (
+	"bytes"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/filestorage"
+	"github.com/juju/juju/environs/imagemetadata"
+	imagetesting "github.com/juju/juju/environs/imagemetadata/testing"
+	"github.com/juju/juju/environs/simplestreams"
+	sstesting "github.com/juju/juju/environs/simplestreams/testing"
+	"github.com/juju/juju/environs/storage"
+	"github.com/juju/juju/environs/sync"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mirrorSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	sourceDir string
+	targetDir string
+	target    storage.Storage
+}
+
+var _ = gc.Suite(&mirrorSuite{})
+
+func (s *mirrorSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.sourceDir = c.MkDir()
+	source, err := filestorage.NewFileStorageWriter(s.sourceDir)
+	c.Assert(err, jc.ErrorIsNil)
+	err = imagemetadata.MergeAndWriteMetadata("trusty", []*imagemetadata.ImageMetadata{{
+		Id:      "image-1",
+		Arch:    "amd64",
+		Version: "14.04",
+	}}, &simplestreams.CloudSpec{Region: "region", Endpoint: "endpoint"}, source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.targetDir = c.MkDir()
+	s.target, err = filestorage.NewFileStorageWriter(s.targetDir)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *mirrorSuite) TestSyncImageMetadata(c *gc.C) {
+	copied, err := sync.SyncImageMetadata(s.sourceDir, "released", s.target, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(copied, gc.Equals, 1)
+	metadata := imagetesting.ParseMetadataFromDir(c, s.targetDir)
+	c.Assert(metadata, gc.HasLen, 1)
+	c.Assert(metadata[0].Id, gc.Equals, "image-1")
+	c.Assert(metadata[0].RegionName, gc.Equals, "region")
+}
+
+func (s *mirrorSuite) TestSyncImageMetadataDryRun(c *gc.C) {
+	copied, err := sync.SyncImageMetadata(s.sourceDir, "released", s.target, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(copied, gc.Equals, 1)
+	names, err := s.target.List("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, gc.HasLen, 0)
+}
+
+func (s *mirrorSuite) TestSyncImageMetadataNoSource(c *gc.C) {
+	_, err := sync.SyncImageMetadata("", "released", s.target, false)
+	c.Assert(err, gc.ErrorMatches, "no image metadata source specified")
+}
+
+func (s *mirrorSuite) TestSignMetadata(c *gc.C) {
+	name := "images/streams/v1/index.json"
+	data := []byte("hello world")
+	err := s.target.Put(name, bytes.NewReader(data), int64(len(data)))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = sync.SignMetadata(s.target, sstesting.SignedMetadataPrivateKey, sstesting.PrivateKeyPassphrase)
+	c.Assert(err, jc.ErrorIsNil)
+
+	r, err := s.target.Get(strings.Replace(name, ".json", ".sjson", 1))
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	decoded, err := simplestreams.DecodeCheckSignature(r, sstesting.SignedMetadataPublicKey)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(decoded), gc.Equals, "hello world\n")
+}
+
+func (s *mirrorSuite) TestSignMetadataBadKey(c *gc.C) {
+	data := []byte("hello world")
+	err := s.target.Put("index.json", bytes.NewReader(data), int64(len(data)))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = sync.SignMetadata(s.target, "not a key", "")
+	c.Assert(err, gc.ErrorMatches, `cannot sign "index.json": .*`)
+}
@@ -6,6 +6,7 @@ package tools_test
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -43,6 +44,10 @@ func (s *URLsSuite) env(c *gc.C, toolsMetadataURL string) environs.Environ {
 			"agent-metadata-url": toolsMetadataURL,
 		})
 	}
+	return s.envWithAttrs(c, attrs)
+}
+
+func (s *URLsSuite) envWithAttrs(c *gc.C, attrs testing.Attrs) environs.Environ {
 	env, err := environs.Prepare(envtesting.BootstrapContext(c),
 		jujuclienttesting.NewMemStore(),
 		environs.PrepareParams{
@@ -72,6 +77,20 @@ func (s *URLsSuite) TestToolsSources(c *gc.C) {
 	})
 }
 
+func (s *URLsSuite) TestToolsSourcesTrustedKeys(c *gc.C) {
+	env := s.envWithAttrs(c, dummy.SampleConfig().Merge(testing.Attrs{
+		"agent-metadata-url":         "config-tools-metadata-url",
+		"simplestreams-trusted-keys": sstesting.SignedMetadataPublicKey,
+	}))
+	sources, err := tools.GetMetadataSources(env)
+	c.Assert(err, jc.ErrorIsNil)
+	trustedKey := strings.TrimSpace(sstesting.SignedMetadataPublicKey)
+	sstesting.AssertExpectedSources(c, sources, []sstesting.SourceDetails{
+		{"config-tools-metadata-url/", simplestreams.JoinPublicKeys(juju.JujuPublicKey, trustedKey)},
+		{"https://streams.canonical.com/juju/tools/", juju.JujuPublicKey},
+	})
+}
+
 func (s *URLsSuite) TestToolsMetadataURLsRegisteredFuncs(c *gc.C) {
 	tools.RegisterToolsDataSourceFunc("id0", func(environs.Environ) (simplestreams.DataSource, error) {
 		return simplestreams.NewURLDataSource("id0", "betwixt/releases", utils.NoVerifySSLHostnames, simplestreams.DEFAULT_CLOUD_DATA, false), nil
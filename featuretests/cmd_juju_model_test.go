@@ -123,6 +123,20 @@ func (s *cmdModelSuite) TestUnset(c *gc.C) {
 	s.assertEnvValueMissing(c, "special")
 }
 
+func (s *cmdModelSuite) TestModelDefaults(c *gc.C) {
+	s.run(c, "set-model-defaults", "special=known")
+	defaults, err := s.State.ModelConfigDefaults()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(defaults["special"], gc.Equals, "known")
+	s.assertEnvValue(c, "special", "known")
+
+	context := s.run(c, "model-defaults", "special", "--format=yaml")
+	c.Assert(testing.Stdout(context), gc.Equals, "special:\n  controller: known\n")
+
+	s.run(c, "unset-model-defaults", "special")
+	s.assertEnvValueMissing(c, "special")
+}
+
 func (s *cmdModelSuite) TestRetryProvisioning(c *gc.C) {
 	s.Factory.MakeMachine(c, &factory.MachineParams{
 		Jobs: []state.MachineJob{state.JobManageModel},
@@ -229,8 +229,9 @@ func apiInfoConnect(
 
 	logger.Infof("connecting to API addresses: %v", controller.APIEndpoints)
 	apiInfo := &api.Info{
-		Addrs:  controller.APIEndpoints,
-		CACert: controller.CACert,
+		Addrs:         controller.APIEndpoints,
+		CACert:        controller.CACert,
+		TrustSystemCA: controller.TrustSystemCA,
 	}
 	st, err := commonConnect(apiOpen, apiInfo, account, modelUUID, dialOpts)
 	if err != nil {
@@ -30,6 +30,7 @@ import (
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/bootstrap"
 	"github.com/juju/juju/environs/config"
@@ -508,11 +509,16 @@ func AddCharm(st *state.State, curl *charm.URL, ch charm.Charm) (*state.Charm, e
 	if err := stor.Put(storagePath, f, size); err != nil {
 		return nil, fmt.Errorf("cannot put charm: %v", err)
 	}
+	profile, err := lxdprofile.ReadCharmProfile(ch)
+	if err != nil {
+		return nil, err
+	}
 	info := state.CharmInfo{
 		Charm:       ch,
 		ID:          curl,
 		StoragePath: storagePath,
 		SHA256:      digest,
+		LXDProfile:  profile,
 	}
 	sch, err := st.AddCharm(info)
 	if err != nil {
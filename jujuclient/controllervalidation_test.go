@@ -4,6 +4,7 @@
 package jujuclient_test
 
 import (
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/jujuclient"
@@ -43,6 +44,13 @@ func (s *ControllerValidationSuite) TestValidateControllerDetailsNoCACert(c *gc.
 	s.assertValidateControllerDetailsFails(c, "missing ca-cert, controller details not valid")
 }
 
+func (s *ControllerValidationSuite) TestValidateControllerDetailsTrustSystemCA(c *gc.C) {
+	s.controller.CACert = ""
+	s.controller.TrustSystemCA = true
+	err := jujuclient.ValidateControllerDetails(s.controller)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *ControllerValidationSuite) assertValidateControllerDetailsFails(c *gc.C, failureMessage string) {
 	err := jujuclient.ValidateControllerDetails(s.controller)
 	c.Assert(err, gc.ErrorMatches, failureMessage)
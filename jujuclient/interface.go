@@ -25,6 +25,11 @@ type ControllerDetails struct {
 	// CACert is a security certificate for this controller.
 	CACert string `yaml:"ca-cert"`
 
+	// TrustSystemCA specifies that the controller is verified
+	// against the system's trusted CAs rather than CACert, for
+	// controllers presenting a custom certificate.
+	TrustSystemCA bool `yaml:"trust-system-ca,omitempty"`
+
 	// Cloud is the name of the cloud that this controller runs in.
 	Cloud string `yaml:"cloud"`
 
@@ -13,7 +13,7 @@ func ValidateControllerDetails(details ControllerDetails) error {
 	if details.ControllerUUID == "" {
 		return errors.NotValidf("missing uuid, controller details")
 	}
-	if details.CACert == "" {
+	if details.CACert == "" && !details.TrustSystemCA {
 		return errors.NotValidf("missing ca-cert, controller details")
 	}
 	return nil
@@ -3,7 +3,4 @@
 
 package migration
 
-var (
-	UpdateConfigFromProvider = updateConfigFromProvider
-	GetCharmStoragePath      = getCharmStoragePath
-)
+var UpdateConfigFromProvider = updateConfigFromProvider
@@ -4,6 +4,8 @@
 package migration
 
 import (
+	"crypto/sha256"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
@@ -13,15 +15,11 @@ import (
 	"github.com/juju/utils/set"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6-unstable"
-	"gopkg.in/mgo.v2"
 
-	"github.com/juju/juju/api"
 	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
-	"github.com/juju/juju/state/binarystorage"
-	"github.com/juju/juju/state/storage"
 	"github.com/juju/juju/tools"
 )
 
@@ -95,82 +93,58 @@ func updateConfigFromProvider(model description.Model, getter environs.EnvironCo
 	return nil
 }
 
-// UploadBackend define the methods on *state.State that are needed for
-// uploading the tools and charms from the current controller to a different
-// controller.
-type UploadBackend interface {
-	Charm(*charm.URL) (*state.Charm, error)
-	ModelUUID() string
-	MongoSession() *mgo.Session
-	ToolsStorage() (binarystorage.StorageCloser, error)
+// CharmDownloader defines a single method interface that is used to
+// retrieve a charm archive from the source controller.
+type CharmDownloader interface {
+	OpenCharm(*charm.URL) (io.ReadCloser, error)
 }
 
-// CharmUploader defines a simple single method interface that is used to
-// upload a charm to the target controller
+// CharmUploader defines a single method interface that is used to
+// upload a charm to the target controller.
 type CharmUploader interface {
 	UploadCharm(*charm.URL, io.ReadSeeker) (*charm.URL, error)
 }
 
-// ToolsUploader defines a simple single method interface that is used to
-// upload tools to the target controller
-type ToolsUploader interface {
-	UploadTools(io.ReadSeeker, version.Binary, ...string) (tools.List, error)
+// ToolsDownloader defines a single method interface that is used to
+// retrieve agent binaries from the source controller.
+type ToolsDownloader interface {
+	OpenTools(version.Binary) (io.ReadCloser, error)
 }
 
-// UploadBinariesConfig provides all the configuration that the UploadBinaries
-// function needs to operate. The functions are configurable for testing
-// purposes. To construct the config with the default functions, use
-// `NewUploadBinariesConfig`.
-type UploadBinariesConfig struct {
-	State  UploadBackend
-	Model  description.Model
-	Target api.Connection
-
-	GetCharmUploader func(api.Connection) CharmUploader
-	GetToolsUploader func(api.Connection) ToolsUploader
-
-	GetStateStorage     func(UploadBackend) storage.Storage
-	GetCharmStoragePath func(UploadBackend, *charm.URL) (string, error)
+// ToolsUploader defines a single method interface that is used to
+// upload agent binaries to the target controller.
+type ToolsUploader interface {
+	UploadTools(io.ReadSeeker, version.Binary) (tools.List, error)
 }
 
-// NewUploadBinariesConfig constructs a `UploadBinariesConfig` with the default
-// functions to get the uploaders for the target api connection, and functions
-// used to get the charm data out of the database.
-func NewUploadBinariesConfig(backend UploadBackend, model description.Model, target api.Connection) UploadBinariesConfig {
-	return UploadBinariesConfig{
-		State:  backend,
-		Model:  model,
-		Target: target,
-
-		GetCharmUploader:    getCharmUploader,
-		GetStateStorage:     getStateStorage,
-		GetToolsUploader:    getToolsUploader,
-		GetCharmStoragePath: getCharmStoragePath,
-	}
+// UploadBinariesConfig provides all the configuration that the
+// UploadBinaries function needs to operate.
+type UploadBinariesConfig struct {
+	// Charms holds the URLs of the charms to transfer.
+	Charms          []string
+	CharmDownloader CharmDownloader
+	CharmUploader   CharmUploader
+
+	// Tools maps the agent binary versions to transfer to their
+	// expected SHA256 hashes.
+	Tools           map[version.Binary]string
+	ToolsDownloader ToolsDownloader
+	ToolsUploader   ToolsUploader
 }
 
 // Validate makes sure that all the config values are non-nil.
 func (c *UploadBinariesConfig) Validate() error {
-	if c.State == nil {
-		return errors.NotValidf("missing UploadBackend")
-	}
-	if c.Model == nil {
-		return errors.NotValidf("missing Model")
+	if c.CharmDownloader == nil {
+		return errors.NotValidf("missing CharmDownloader")
 	}
-	if c.Target == nil {
-		return errors.NotValidf("missing Target")
+	if c.CharmUploader == nil {
+		return errors.NotValidf("missing CharmUploader")
 	}
-	if c.GetCharmUploader == nil {
-		return errors.NotValidf("missing GetCharmUploader")
+	if c.ToolsDownloader == nil {
+		return errors.NotValidf("missing ToolsDownloader")
 	}
-	if c.GetStateStorage == nil {
-		return errors.NotValidf("missing GetStateStorage")
-	}
-	if c.GetToolsUploader == nil {
-		return errors.NotValidf("missing GetToolsUploader")
-	}
-	if c.GetCharmStoragePath == nil {
-		return errors.NotValidf("missing GetCharmStoragePath")
+	if c.ToolsUploader == nil {
+		return errors.NotValidf("missing ToolsUploader")
 	}
 	return nil
 }
@@ -181,70 +155,104 @@ func UploadBinaries(config UploadBinariesConfig) error {
 	if err := config.Validate(); err != nil {
 		return errors.Trace(err)
 	}
-	if err := uploadTools(config); err != nil {
+	if err := uploadCharms(config); err != nil {
 		return errors.Trace(err)
 	}
-
-	if err := uploadCharms(config); err != nil {
+	if err := uploadTools(config); err != nil {
 		return errors.Trace(err)
 	}
-
 	return nil
 }
 
-func getStateStorage(backend UploadBackend) storage.Storage {
-	return storage.NewStorage(backend.ModelUUID(), backend.MongoSession())
+func uploadCharms(config UploadBinariesConfig) error {
+	for _, charmURL := range config.Charms {
+		if err := uploadCharm(config, charmURL); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
 }
 
-func getToolsUploader(target api.Connection) ToolsUploader {
-	return target.Client()
-}
+// uploadCharm sends a single charm to the target. Each charm is sent by
+// its own call so that its reader and temporary file are released
+// before the next charm is opened.
+func uploadCharm(config UploadBinariesConfig, charmURL string) error {
+	logger.Debugf("sending charm %s to target", charmURL)
 
-func getCharmUploader(target api.Connection) CharmUploader {
-	return target.Client()
-}
+	curl, err := charm.ParseURL(charmURL)
+	if err != nil {
+		return errors.Annotate(err, "bad charm URL")
+	}
 
-func uploadTools(config UploadBinariesConfig) error {
-	storage, err := config.State.ToolsStorage()
+	reader, err := config.CharmDownloader.OpenCharm(curl)
+	if err != nil {
+		return errors.Annotate(err, "cannot open charm")
+	}
+	defer reader.Close()
+
+	content, cleanup, err := streamThroughTempFile(reader)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	defer storage.Close()
+	defer cleanup()
 
-	usedVersions := getUsedToolsVersions(config.Model)
-	toolsUploader := config.GetToolsUploader(config.Target)
+	if _, err := config.CharmUploader.UploadCharm(curl, content); err != nil {
+		return errors.Annotate(err, "cannot upload charm")
+	}
+	return nil
+}
 
-	for toolsVersion := range usedVersions {
-		logger.Debugf("send tools version %s to target", toolsVersion)
-		_, reader, err := storage.Open(toolsVersion.String())
-		if err != nil {
+func uploadTools(config UploadBinariesConfig) error {
+	for v, expectedSHA256 := range config.Tools {
+		if err := uploadAgentBinaries(config, v, expectedSHA256); err != nil {
 			return errors.Trace(err)
 		}
-		defer reader.Close()
+	}
+	return nil
+}
 
-		content, cleanup, err := streamThroughTempFile(reader)
-		if err != nil {
-			return errors.Trace(err)
-		}
-		defer cleanup()
+// uploadAgentBinaries sends a single agent binary version to the
+// target, releasing its reader and temporary file before returning.
+func uploadAgentBinaries(config UploadBinariesConfig, v version.Binary, expectedSHA256 string) error {
+	logger.Debugf("sending agent binaries %s to target", v)
 
-		// UploadTools encapsulates the HTTP POST necessary to send the tools
-		// to the target API server.
-		if _, err := toolsUploader.UploadTools(content, toolsVersion); err != nil {
-			return errors.Trace(err)
+	reader, err := config.ToolsDownloader.OpenTools(v)
+	if err != nil {
+		return errors.Annotate(err, "cannot open agent binaries")
+	}
+	defer reader.Close()
+
+	hasher := sha256.New()
+	content, cleanup, err := streamThroughTempFile(io.TeeReader(reader, hasher))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer cleanup()
+
+	if expectedSHA256 != "" {
+		actualSHA256 := fmt.Sprintf("%x", hasher.Sum(nil))
+		if actualSHA256 != expectedSHA256 {
+			return errors.Errorf(
+				"agent binaries %s SHA256 mismatch: expected %s, got %s",
+				v, expectedSHA256, actualSHA256,
+			)
 		}
 	}
 
+	if _, err := config.ToolsUploader.UploadTools(content, v); err != nil {
+		return errors.Annotate(err, "cannot upload agent binaries")
+	}
 	return nil
 }
 
 func streamThroughTempFile(r io.Reader) (_ io.ReadSeeker, cleanup func(), err error) {
-	tempFile, err := ioutil.TempFile("", "juju-tools")
+	tempFile, err := ioutil.TempFile("", "juju-migration-binary")
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
 	defer func() {
 		if err != nil {
+			tempFile.Close()
 			os.Remove(tempFile.Name())
 		}
 	}()
@@ -252,7 +260,9 @@ func streamThroughTempFile(r io.Reader) (_ io.ReadSeeker, cleanup func(), err er
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
-	tempFile.Seek(0, 0)
+	if _, err = tempFile.Seek(0, 0); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	rmTempFile := func() {
 		filename := tempFile.Name()
 		tempFile.Close()
@@ -262,84 +272,48 @@ func streamThroughTempFile(r io.Reader) (_ io.ReadSeeker, cleanup func(), err er
 	return tempFile, rmTempFile, nil
 }
 
-func getUsedToolsVersions(model description.Model) map[version.Binary]bool {
-	// Iterate through the model for all tools, and make a map of them.
-	usedVersions := make(map[version.Binary]bool)
+// ModelCharms returns the URLs of every charm used by the
+// applications in the model. Units part way through a rolling charm
+// upgrade may still run an application's previous charm, which is not
+// recorded in the model description; SourcePrecheck refuses to migrate
+// such models.
+func ModelCharms(model description.Model) []string {
+	result := set.NewStrings()
+	for _, application := range model.Applications() {
+		result.Add(application.CharmURL())
+	}
+	return result.SortedValues()
+}
+
+// ModelTools returns every agent binary version used by the machines,
+// containers and units in the model, mapped to its SHA256 hash.
+func ModelTools(model description.Model) map[version.Binary]string {
 	// It is most likely that the preconditions will limit the number of
 	// tools versions in use, but that is not depended on here.
+	result := make(map[version.Binary]string)
 	for _, machine := range model.Machines() {
-		addToolsVersionForMachine(machine, usedVersions)
+		addToolsForMachine(machine, result)
 	}
-
 	for _, application := range model.Applications() {
 		for _, unit := range application.Units() {
-			tools := unit.Tools()
-			usedVersions[tools.Version()] = true
+			addTools(unit.Tools(), result)
 		}
 	}
-	return usedVersions
+	return result
 }
 
-func addToolsVersionForMachine(machine description.Machine, usedVersions map[version.Binary]bool) {
-	tools := machine.Tools()
-	usedVersions[tools.Version()] = true
+func addToolsForMachine(machine description.Machine, result map[version.Binary]string) {
+	addTools(machine.Tools(), result)
 	for _, container := range machine.Containers() {
-		addToolsVersionForMachine(container, usedVersions)
+		addToolsForMachine(container, result)
 	}
 }
 
-func uploadCharms(config UploadBinariesConfig) error {
-	storage := config.GetStateStorage(config.State)
-	usedCharms := getUsedCharms(config.Model)
-	charmUploader := config.GetCharmUploader(config.Target)
-
-	for _, charmUrl := range usedCharms.Values() {
-		logger.Debugf("send charm %s to target", charmUrl)
-
-		curl, err := charm.ParseURL(charmUrl)
-		if err != nil {
-			return errors.Annotate(err, "bad charm URL")
-		}
-
-		path, err := config.GetCharmStoragePath(config.State, curl)
-		if err != nil {
-			return errors.Trace(err)
-		}
-
-		reader, _, err := storage.Get(path)
-		if err != nil {
-			return errors.Annotate(err, "cannot get charm from storage")
-		}
-		defer reader.Close()
-
-		content, cleanup, err := streamThroughTempFile(reader)
-		if err != nil {
-			return errors.Trace(err)
-		}
-		defer cleanup()
-
-		if _, err := charmUploader.UploadCharm(curl, content); err != nil {
-			return errors.Annotate(err, "cannot upload charm")
-		}
-	}
-	return nil
-}
-
-func getUsedCharms(model description.Model) set.Strings {
-	result := set.NewStrings()
-	for _, application := range model.Applications() {
-		result.Add(application.CharmURL())
+func addTools(tools description.AgentTools, result map[version.Binary]string) {
+	if tools == nil {
+		return
 	}
-	return result
-}
-
-func getCharmStoragePath(st UploadBackend, curl *charm.URL) (string, error) {
-	ch, err := st.Charm(curl)
-	if err != nil {
-		return "", errors.Annotate(err, "cannot get charm from state")
-	}
-
-	return ch.StoragePath(), nil
+	result[tools.Version()] = tools.SHA256()
 }
 
 // PrecheckBackend is implemented by *state.State but defined as an interface
@@ -5,6 +5,7 @@ package migration_test
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -16,9 +17,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
-	"gopkg.in/mgo.v2"
 
-	"github.com/juju/juju/api"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/environs"
@@ -28,8 +27,6 @@ import (
 	"github.com/juju/juju/provider/dummy"
 	_ "github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
-	"github.com/juju/juju/state/binarystorage"
-	"github.com/juju/juju/state/storage"
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/tools"
@@ -97,7 +94,7 @@ func (s *ImportSuite) TestImportModel(c *gc.C) {
 	c.Assert(dbConfig.Name(), gc.Equals, "new-model")
 }
 
-func (s *ImportSuite) TestUploadBinariesTools(c *gc.C) {
+func (s *ImportSuite) TestModelTools(c *gc.C) {
 	// Create a model that has three different tools versions:
 	// one for a machine, one for a container, and one for a unit agent.
 	// We don't care about the actual validity of the model (it isn't).
@@ -109,12 +106,14 @@ func (s *ImportSuite) TestUploadBinariesTools(c *gc.C) {
 	})
 	machine.SetTools(description.AgentToolsArgs{
 		Version: version.MustParseBinary("2.0.1-trusty-amd64"),
+		SHA256:  "sha-1",
 	})
 	container := machine.AddContainer(description.MachineArgs{
 		Id: names.NewMachineTag("0/lxd/0"),
 	})
 	container.SetTools(description.AgentToolsArgs{
 		Version: version.MustParseBinary("2.0.5-trusty-amd64"),
+		SHA256:  "sha-5",
 	})
 	application := model.AddApplication(description.ApplicationArgs{
 		Tag:      names.NewApplicationTag("magic"),
@@ -125,31 +124,17 @@ func (s *ImportSuite) TestUploadBinariesTools(c *gc.C) {
 	})
 	unit.SetTools(description.AgentToolsArgs{
 		Version: version.MustParseBinary("2.0.3-trusty-amd64"),
+		SHA256:  "sha-3",
 	})
 
-	uploader := &fakeUploader{tools: make(map[version.Binary]string)}
-	config := migration.UploadBinariesConfig{
-		State:            &fakeStateStorage{},
-		Model:            model,
-		Target:           &fakeAPIConnection{},
-		GetCharmUploader: func(api.Connection) migration.CharmUploader { return &noOpUploader{} },
-		GetToolsUploader: func(target api.Connection) migration.ToolsUploader {
-			return uploader
-		},
-		GetStateStorage:     func(migration.UploadBackend) storage.Storage { return &fakeCharmsStorage{} },
-		GetCharmStoragePath: func(migration.UploadBackend, *charm.URL) (string, error) { return "", nil },
-	}
-	err := migration.UploadBinaries(config)
-	c.Assert(err, jc.ErrorIsNil)
-
-	c.Assert(uploader.tools, jc.DeepEquals, map[version.Binary]string{
-		version.MustParseBinary("2.0.1-trusty-amd64"): "fake tools 2.0.1-trusty-amd64",
-		version.MustParseBinary("2.0.3-trusty-amd64"): "fake tools 2.0.3-trusty-amd64",
-		version.MustParseBinary("2.0.5-trusty-amd64"): "fake tools 2.0.5-trusty-amd64",
+	c.Assert(migration.ModelTools(model), jc.DeepEquals, map[version.Binary]string{
+		version.MustParseBinary("2.0.1-trusty-amd64"): "sha-1",
+		version.MustParseBinary("2.0.3-trusty-amd64"): "sha-3",
+		version.MustParseBinary("2.0.5-trusty-amd64"): "sha-5",
 	})
 }
 
-func (s *ImportSuite) TestStreamCharmsTools(c *gc.C) {
+func (s *ImportSuite) TestModelCharms(c *gc.C) {
 	model := description.NewModel(description.ModelArgs{
 		Owner: names.NewUserTag("me"),
 	})
@@ -158,78 +143,156 @@ func (s *ImportSuite) TestStreamCharmsTools(c *gc.C) {
 		CharmURL: "local:trusty/magic",
 	})
 	model.AddApplication(description.ApplicationArgs{
-		Tag:      names.NewApplicationTag("magic"),
+		Tag:      names.NewApplicationTag("postgresql"),
 		CharmURL: "cs:trusty/postgresql-42",
 	})
+	model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("more-magic"),
+		CharmURL: "local:trusty/magic",
+	})
 
-	uploader := &fakeUploader{charms: make(map[string]string)}
+	c.Assert(migration.ModelCharms(model), jc.DeepEquals, []string{
+		"cs:trusty/postgresql-42",
+		"local:trusty/magic",
+	})
+}
+
+type UploadBinariesSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&UploadBinariesSuite{})
+
+func (s *UploadBinariesSuite) TestUploadBinaries(c *gc.C) {
+	toolsContent := "fake tools 2.0.1-trusty-amd64"
+	v := version.MustParseBinary("2.0.1-trusty-amd64")
+	downloader := &fakeDownloader{}
+	uploader := &fakeUploader{
+		tools:  make(map[version.Binary]string),
+		charms: make(map[string]string),
+	}
 	config := migration.UploadBinariesConfig{
-		State:            &fakeStateStorage{},
-		Model:            model,
-		Target:           &fakeAPIConnection{},
-		GetCharmUploader: func(api.Connection) migration.CharmUploader { return uploader },
-		GetToolsUploader: func(target api.Connection) migration.ToolsUploader { return &noOpUploader{} },
-		GetStateStorage:  func(migration.UploadBackend) storage.Storage { return &fakeCharmsStorage{} },
-		GetCharmStoragePath: func(_ migration.UploadBackend, u *charm.URL) (string, error) {
-			return "/path/for/" + u.String(), nil
-		},
+		Charms:          []string{"local:trusty/magic", "cs:trusty/postgresql-42"},
+		CharmDownloader: downloader,
+		CharmUploader:   uploader,
+		Tools:           map[version.Binary]string{v: sha256Of(toolsContent)},
+		ToolsDownloader: downloader,
+		ToolsUploader:   uploader,
 	}
 	err := migration.UploadBinaries(config)
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Assert(uploader.charms, jc.DeepEquals, map[string]string{
-		"local:trusty/magic":      "fake file at /path/for/local:trusty/magic",
-		"cs:trusty/postgresql-42": "fake file at /path/for/cs:trusty/postgresql-42",
+		"local:trusty/magic":      "fake charm local:trusty/magic",
+		"cs:trusty/postgresql-42": "fake charm cs:trusty/postgresql-42",
+	})
+	c.Assert(uploader.tools, jc.DeepEquals, map[version.Binary]string{
+		v: toolsContent,
 	})
 }
 
-type fakeStateStorage struct {
-	tools  fakeToolsStorage
-	charms fakeCharmsStorage
+func (s *UploadBinariesSuite) TestUploadBinariesClosesEachReader(c *gc.C) {
+	downloader := &fakeDownloader{}
+	uploader := &fakeUploader{
+		tools:  make(map[version.Binary]string),
+		charms: make(map[string]string),
+	}
+	config := migration.UploadBinariesConfig{
+		Charms:          []string{"local:trusty/magic", "cs:trusty/postgresql-42"},
+		CharmDownloader: downloader,
+		CharmUploader:   uploader,
+		Tools: map[version.Binary]string{
+			version.MustParseBinary("2.0.1-trusty-amd64"): "",
+			version.MustParseBinary("2.0.1-xenial-amd64"): "",
+		},
+		ToolsDownloader: downloader,
+		ToolsUploader:   uploader,
+	}
+	err := migration.UploadBinaries(config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(downloader.maxOpen, gc.Equals, 1)
+	c.Assert(downloader.open, gc.Equals, 0)
 }
 
-type fakeCharmsStorage struct {
-	storage.Storage
+func (s *UploadBinariesSuite) TestToolsHashMismatch(c *gc.C) {
+	v := version.MustParseBinary("2.0.1-trusty-amd64")
+	downloader := &fakeDownloader{}
+	uploader := &fakeUploader{tools: make(map[version.Binary]string)}
+	config := migration.UploadBinariesConfig{
+		CharmDownloader: downloader,
+		CharmUploader:   uploader,
+		Tools:           map[version.Binary]string{v: sha256Of("something else")},
+		ToolsDownloader: downloader,
+		ToolsUploader:   uploader,
+	}
+	err := migration.UploadBinaries(config)
+	c.Assert(err, gc.ErrorMatches, "agent binaries 2.0.1-trusty-amd64 SHA256 mismatch: .*")
+	c.Assert(uploader.tools, gc.HasLen, 0)
 }
 
-type fakeAPIConnection struct {
-	api.Connection
+func (s *UploadBinariesSuite) TestCharmOpenError(c *gc.C) {
+	downloader := &fakeDownloader{err: errors.New("boom")}
+	uploader := &fakeUploader{charms: make(map[string]string)}
+	config := migration.UploadBinariesConfig{
+		Charms:          []string{"local:trusty/magic"},
+		CharmDownloader: downloader,
+		CharmUploader:   uploader,
+		ToolsDownloader: downloader,
+		ToolsUploader:   uploader,
+	}
+	err := migration.UploadBinaries(config)
+	c.Assert(err, gc.ErrorMatches, "cannot open charm: boom")
 }
 
-type fakeToolsStorage struct {
-	binarystorage.Storage
-	closed bool
+func (s *UploadBinariesSuite) TestValidate(c *gc.C) {
+	err := migration.UploadBinaries(migration.UploadBinariesConfig{})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "missing CharmDownloader not valid")
 }
 
-func (f *fakeStateStorage) ToolsStorage() (binarystorage.StorageCloser, error) {
-	return &f.tools, nil
+func sha256Of(content string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
 }
 
-func (f *fakeStateStorage) ModelUUID() string {
-	return testing.ModelTag.Id()
+type fakeDownloader struct {
+	err error
+
+	// open counts the readers not yet closed, and maxOpen the
+	// most that were ever open at once.
+	open    int
+	maxOpen int
 }
 
-func (f *fakeStateStorage) MongoSession() *mgo.Session {
-	return nil
+func (f *fakeDownloader) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.newReader("fake charm " + curl.String()), nil
 }
 
-func (f *fakeStateStorage) Charm(*charm.URL) (*state.Charm, error) {
-	return nil, nil
+func (f *fakeDownloader) OpenTools(v version.Binary) (io.ReadCloser, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.newReader("fake tools " + v.String()), nil
 }
 
-func (f *fakeToolsStorage) Open(v string) (binarystorage.Metadata, io.ReadCloser, error) {
-	buff := bytes.NewBufferString(fmt.Sprintf("fake tools %s", v))
-	return binarystorage.Metadata{}, ioutil.NopCloser(buff), nil
+func (f *fakeDownloader) newReader(content string) io.ReadCloser {
+	f.open++
+	if f.open > f.maxOpen {
+		f.maxOpen = f.open
+	}
+	return &fakeReadCloser{Reader: bytes.NewBufferString(content), downloader: f}
 }
 
-func (f *fakeToolsStorage) Close() error {
-	f.closed = true
-	return nil
+type fakeReadCloser struct {
+	io.Reader
+	downloader *fakeDownloader
 }
 
-func (f *fakeCharmsStorage) Get(path string) (io.ReadCloser, int64, error) {
-	buff := bytes.NewBufferString(fmt.Sprintf("fake file at %s", path))
-	return ioutil.NopCloser(buff), int64(buff.Len()), nil
+func (r *fakeReadCloser) Close() error {
+	r.downloader.open--
+	return nil
 }
 
 type fakeUploader struct {
@@ -237,7 +300,7 @@ type fakeUploader struct {
 	charms map[string]string
 }
 
-func (f *fakeUploader) UploadTools(r io.ReadSeeker, v version.Binary, _ ...string) (tools.List, error) {
+func (f *fakeUploader) UploadTools(r io.ReadSeeker, v version.Binary) (tools.List, error) {
 	data, err := ioutil.ReadAll(r)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -261,16 +324,6 @@ func (f *fakeUploader) UploadCharm(u *charm.URL, r io.ReadSeeker) (*charm.URL, e
 	return u, nil
 }
 
-type noOpUploader struct{}
-
-func (*noOpUploader) UploadCharm(*charm.URL, io.ReadSeeker) (*charm.URL, error) {
-	return nil, nil
-}
-
-func (*noOpUploader) UploadTools(io.ReadSeeker, version.Binary, ...string) (tools.List, error) {
-	return nil, nil
-}
-
 type ExportSuite struct {
 	statetesting.StateSuite
 }
@@ -366,17 +419,3 @@ func (s *InternalSuite) TestUpdateConfigFromProvider(c *gc.C) {
 	modelConfig := model.Config()
 	c.Assert(modelConfig["controller-uuid"], gc.Equals, controllerModelConfig.UUID())
 }
-
-type CharmInternalSuite struct {
-	statetesting.StateSuite
-}
-
-var _ = gc.Suite(&CharmInternalSuite{})
-
-func (s *CharmInternalSuite) TestCharmStoragePath(c *gc.C) {
-	charm := s.Factory.MakeCharm(c, nil)
-
-	path, err := migration.GetCharmStoragePath(s.State, charm.URL())
-	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(path, gc.Equals, "fake-storage-path")
-}
@@ -0,0 +1,274 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cloud"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/tools"
+)
+
+// PrecheckBackend defines the source model functionality needed by
+// SourcePrecheck.
+type PrecheckBackend interface {
+	ModelInfo() (coremigration.ModelInfo, error)
+	IsUpgrading() (bool, error)
+	ModelLife() (state.Life, error)
+	AllMachines() ([]PrecheckMachine, error)
+	AllApplications() ([]PrecheckApplication, error)
+}
+
+// PrecheckMachine describes the machine functionality needed by
+// SourcePrecheck.
+type PrecheckMachine interface {
+	Id() string
+	Life() state.Life
+	Status() (status.StatusInfo, error)
+	InstanceId() (instance.Id, error)
+	AgentPresence() (bool, error)
+	AgentTools() (*tools.Tools, error)
+}
+
+// PrecheckApplication describes the application functionality needed
+// by SourcePrecheck.
+type PrecheckApplication interface {
+	Name() string
+	Life() state.Life
+	RollingUpgrade() (state.RollingUpgrade, bool)
+	AllUnits() ([]PrecheckUnit, error)
+}
+
+// PrecheckUnit describes the unit functionality needed by
+// SourcePrecheck.
+type PrecheckUnit interface {
+	Name() string
+	Life() state.Life
+	AgentStatus() (status.StatusInfo, error)
+	AgentPresence() (bool, error)
+	AgentTools() (*tools.Tools, error)
+}
+
+// SourcePrecheck checks that the source model is in a fit state to be
+// migrated, returning a description of every problem found. A model
+// can only be migrated when it is alive, no upgrade is in progress,
+// and every machine and unit agent is running, not in error and at the
+// model's agent version. Applications part way through a rolling charm
+// upgrade are refused, since the model description records only the
+// application's charm and not the previous charm some units still run.
+func SourcePrecheck(backend PrecheckBackend) ([]string, error) {
+	var problems []string
+	addProblem := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	life, err := backend.ModelLife()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving model")
+	}
+	if life != state.Alive {
+		addProblem("model is %s", life)
+	}
+	if upgrading, err := backend.IsUpgrading(); err != nil {
+		return nil, errors.Annotate(err, "checking for upgrades")
+	} else if upgrading {
+		addProblem("controller upgrade is in progress")
+	}
+	model, err := backend.ModelInfo()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving model info")
+	}
+	modelVersion := model.AgentVersion
+
+	machines, err := backend.AllMachines()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving machines")
+	}
+	for _, machine := range machines {
+		what := "machine " + machine.Id()
+		if machine.Life() != state.Alive {
+			addProblem("%s is %s", what, machine.Life())
+			continue
+		}
+		if _, err := machine.InstanceId(); errors.IsNotProvisioned(err) {
+			addProblem("%s is not provisioned", what)
+			continue
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "retrieving %s instance", what)
+		}
+		info, err := machine.Status()
+		if err != nil {
+			return nil, errors.Annotatef(err, "retrieving %s status", what)
+		}
+		if info.Status == status.StatusError {
+			addProblem("%s is in error: %s", what, info.Message)
+		}
+		agentProblem, err := checkAgent(what, machine, modelVersion)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if agentProblem != "" {
+			addProblem("%s", agentProblem)
+		}
+	}
+
+	applications, err := backend.AllApplications()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving applications")
+	}
+	for _, application := range applications {
+		if application.Life() != state.Alive {
+			addProblem("application %s is %s", application.Name(), application.Life())
+		}
+		if rolling, ok := application.RollingUpgrade(); ok {
+			addProblem("application %s has a rolling upgrade from %s in progress",
+				application.Name(), rolling.PreviousCharmURL)
+		}
+		units, err := application.AllUnits()
+		if err != nil {
+			return nil, errors.Annotatef(err, "retrieving units of application %s", application.Name())
+		}
+		for _, unit := range units {
+			what := "unit " + unit.Name()
+			if unit.Life() != state.Alive {
+				addProblem("%s is %s", what, unit.Life())
+				continue
+			}
+			info, err := unit.AgentStatus()
+			if err != nil {
+				return nil, errors.Annotatef(err, "retrieving %s status", what)
+			}
+			if info.Status == status.StatusError {
+				addProblem("%s is in error: %s", what, info.Message)
+			}
+			agentProblem, err := checkAgent(what, unit, modelVersion)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if agentProblem != "" {
+				addProblem("%s", agentProblem)
+			}
+		}
+	}
+	return problems, nil
+}
+
+type agent interface {
+	AgentPresence() (bool, error)
+	AgentTools() (*tools.Tools, error)
+}
+
+// checkAgent returns a description of the problem with the described
+// entity's agent, or "" if the agent is running the model's version.
+func checkAgent(what string, entity agent, modelVersion version.Number) (string, error) {
+	present, err := entity.AgentPresence()
+	if err != nil {
+		return "", errors.Annotatef(err, "retrieving %s agent presence", what)
+	}
+	if !present {
+		return what + " agent is not running", nil
+	}
+	agentTools, err := entity.AgentTools()
+	if errors.IsNotFound(err) {
+		return what + " agent has not reported its version", nil
+	} else if err != nil {
+		return "", errors.Annotatef(err, "retrieving %s agent version", what)
+	}
+	if agentTools.Version.Number != modelVersion {
+		return fmt.Sprintf("%s agent is at version %s, model is at %s",
+			what, agentTools.Version.Number, modelVersion), nil
+	}
+	return "", nil
+}
+
+// TargetPrecheckBackend defines the target controller functionality
+// needed by TargetPrecheck.
+type TargetPrecheckBackend interface {
+	AgentVersion() (version.Number, error)
+	IsUpgrading() (bool, error)
+	Cloud() (cloud.Cloud, error)
+	CloudCredentials(names.UserTag) (map[string]cloud.Credential, error)
+	AllModels() ([]PrecheckModel, error)
+}
+
+// PrecheckModel describes the model functionality needed by
+// TargetPrecheck.
+type PrecheckModel interface {
+	UUID() string
+	Name() string
+	Owner() names.UserTag
+}
+
+// TargetPrecheck checks that the target controller is able to accept
+// the described model, returning a description of every problem found.
+func TargetPrecheck(backend TargetPrecheckBackend, model coremigration.ModelInfo) ([]string, error) {
+	var problems []string
+	addProblem := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if upgrading, err := backend.IsUpgrading(); err != nil {
+		return nil, errors.Annotate(err, "checking for upgrades")
+	} else if upgrading {
+		addProblem("target controller upgrade is in progress")
+	}
+	controllerVersion, err := backend.AgentVersion()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving controller version")
+	}
+	if controllerVersion.Compare(model.AgentVersion) < 0 {
+		addProblem("target controller is at version %s, model is at %s", controllerVersion, model.AgentVersion)
+	}
+
+	targetCloud, err := backend.Cloud()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving cloud")
+	}
+	if targetCloud.Type != model.CloudType {
+		addProblem("target controller cloud type is %q, model cloud type is %q", targetCloud.Type, model.CloudType)
+	} else if model.CloudRegion != "" && !hasRegion(targetCloud, model.CloudRegion) {
+		addProblem("target controller cloud has no region %q", model.CloudRegion)
+	}
+	if model.CloudCredential != "" {
+		credentials, err := backend.CloudCredentials(model.Owner)
+		if err != nil {
+			return nil, errors.Annotate(err, "retrieving cloud credentials")
+		}
+		if _, ok := credentials[model.CloudCredential]; !ok {
+			addProblem("target controller has no cloud credential %q for %s",
+				model.CloudCredential, model.Owner.Canonical())
+		}
+	}
+
+	models, err := backend.AllModels()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving models")
+	}
+	for _, existing := range models {
+		if existing.UUID() == model.UUID {
+			addProblem("target controller already has model %s", model.UUID)
+		} else if existing.Name() == model.Name && existing.Owner() == model.Owner {
+			addProblem("target controller already has a model named %q owned by %s",
+				model.Name, model.Owner.Canonical())
+		}
+	}
+	return problems, nil
+}
+
+func hasRegion(c cloud.Cloud, name string) bool {
+	for _, region := range c.Regions {
+		if region.Name == name {
+			return true
+		}
+	}
+	return false
+}
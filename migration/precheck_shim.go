@@ -0,0 +1,149 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// PrecheckShim wraps a *state.State for a model so that it implements
+// PrecheckBackend.
+func PrecheckShim(st *state.State) PrecheckBackend {
+	return &precheckShim{st}
+}
+
+// TargetPrecheckShim wraps a *state.State for a controller so that it
+// implements TargetPrecheckBackend.
+func TargetPrecheckShim(st *state.State) TargetPrecheckBackend {
+	return &targetPrecheckShim{st}
+}
+
+func agentVersion(cfg *config.Config) (version.Number, error) {
+	v, ok := cfg.AgentVersion()
+	if !ok {
+		return version.Zero, errors.New("no agent version in model config")
+	}
+	return v, nil
+}
+
+type precheckShim struct {
+	st *state.State
+}
+
+// ModelInfo implements PrecheckBackend.
+func (s *precheckShim) ModelInfo() (coremigration.ModelInfo, error) {
+	model, err := s.st.Model()
+	if err != nil {
+		return coremigration.ModelInfo{}, errors.Trace(err)
+	}
+	cfg, err := s.st.ModelConfig()
+	if err != nil {
+		return coremigration.ModelInfo{}, errors.Trace(err)
+	}
+	agentVersion, err := agentVersion(cfg)
+	if err != nil {
+		return coremigration.ModelInfo{}, errors.Trace(err)
+	}
+	return coremigration.ModelInfo{
+		UUID:            model.UUID(),
+		Name:            model.Name(),
+		Owner:           model.Owner(),
+		AgentVersion:    agentVersion,
+		CloudType:       cfg.Type(),
+		CloudRegion:     model.CloudRegion(),
+		CloudCredential: model.CloudCredential(),
+	}, nil
+}
+
+// IsUpgrading implements PrecheckBackend.
+func (s *precheckShim) IsUpgrading() (bool, error) {
+	return s.st.IsUpgrading()
+}
+
+// ModelLife implements PrecheckBackend.
+func (s *precheckShim) ModelLife() (state.Life, error) {
+	model, err := s.st.Model()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return model.Life(), nil
+}
+
+// AllMachines implements PrecheckBackend.
+func (s *precheckShim) AllMachines() ([]PrecheckMachine, error) {
+	machines, err := s.st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]PrecheckMachine, len(machines))
+	for i, machine := range machines {
+		out[i] = machine
+	}
+	return out, nil
+}
+
+// AllApplications implements PrecheckBackend.
+func (s *precheckShim) AllApplications() ([]PrecheckApplication, error) {
+	applications, err := s.st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]PrecheckApplication, len(applications))
+	for i, application := range applications {
+		out[i] = &precheckApplicationShim{application}
+	}
+	return out, nil
+}
+
+type precheckApplicationShim struct {
+	*state.Application
+}
+
+// AllUnits implements PrecheckApplication.
+func (s *precheckApplicationShim) AllUnits() ([]PrecheckUnit, error) {
+	units, err := s.Application.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]PrecheckUnit, len(units))
+	for i, unit := range units {
+		out[i] = unit
+	}
+	return out, nil
+}
+
+type targetPrecheckShim struct {
+	*state.State
+}
+
+// AgentVersion implements TargetPrecheckBackend.
+func (s *targetPrecheckShim) AgentVersion() (version.Number, error) {
+	model, err := s.State.ControllerModel()
+	if err != nil {
+		return version.Zero, errors.Trace(err)
+	}
+	cfg, err := model.Config()
+	if err != nil {
+		return version.Zero, errors.Trace(err)
+	}
+	return agentVersion(cfg)
+}
+
+// AllModels implements TargetPrecheckBackend.
+func (s *targetPrecheckShim) AllModels() ([]PrecheckModel, error) {
+	models, err := s.State.AllModels()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]PrecheckModel, len(models))
+	for i, model := range models {
+		out[i] = model
+	}
+	return out, nil
+}
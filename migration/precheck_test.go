@@ -0,0 +1,346 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cloud"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+var modelVersion = version.MustParse("2.0.0")
+
+type SourcePrecheckSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&SourcePrecheckSuite{})
+
+func (*SourcePrecheckSuite) TestSuccess(c *gc.C) {
+	problems, err := migration.SourcePrecheck(newHappyBackend())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 0)
+}
+
+func (*SourcePrecheckSuite) TestReportsEveryProblem(c *gc.C) {
+	backend := newHappyBackend()
+	backend.modelLife = state.Dying
+	backend.upgrading = true
+	backend.machines = []migration.PrecheckMachine{
+		&fakeMachine{id: "0", life: state.Dying},
+		&fakeMachine{id: "1", notProvisioned: true},
+		&fakeMachine{id: "2", status: status.StatusError, message: "boom", agentVersion: modelVersion, present: true},
+		&fakeMachine{id: "3", status: status.StatusStarted, agentVersion: modelVersion},
+		&fakeMachine{id: "4", status: status.StatusStarted, present: true},
+		&fakeMachine{id: "5", status: status.StatusStarted, agentVersion: version.MustParse("1.25.6"), present: true},
+	}
+	backend.applications = []migration.PrecheckApplication{
+		&fakeApplication{name: "mysql", life: state.Dying, units: []migration.PrecheckUnit{
+			&fakeUnit{name: "mysql/0", status: status.StatusError, message: "hook failed", agentVersion: modelVersion, present: true},
+			&fakeUnit{name: "mysql/1", life: state.Dead},
+		}},
+		&fakeApplication{name: "wordpress", rolling: &state.RollingUpgrade{
+			PreviousCharmURL: charm.MustParseURL("cs:wordpress-3"),
+			BatchSize:        1,
+		}},
+	}
+
+	problems, err := migration.SourcePrecheck(backend)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, jc.DeepEquals, []string{
+		"model is dying",
+		"controller upgrade is in progress",
+		"machine 0 is dying",
+		"machine 1 is not provisioned",
+		"machine 2 is in error: boom",
+		"machine 3 agent is not running",
+		"machine 4 agent has not reported its version",
+		"machine 5 agent is at version 1.25.6, model is at 2.0.0",
+		"application mysql is dying",
+		"unit mysql/0 is in error: hook failed",
+		"unit mysql/1 is dead",
+		"application wordpress has a rolling upgrade from cs:wordpress-3 in progress",
+	})
+}
+
+func (*SourcePrecheckSuite) TestError(c *gc.C) {
+	backend := newHappyBackend()
+	backend.machinesErr = errors.New("boom")
+	_, err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "retrieving machines: boom")
+}
+
+type TargetPrecheckSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&TargetPrecheckSuite{})
+
+var modelOwner = names.NewUserTag("bob")
+
+func targetModelInfo() coremigration.ModelInfo {
+	return coremigration.ModelInfo{
+		UUID:            "model-uuid",
+		Name:            "model",
+		Owner:           modelOwner,
+		AgentVersion:    modelVersion,
+		CloudType:       "ec2",
+		CloudRegion:     "us-east-1",
+		CloudCredential: "default",
+	}
+}
+
+func (*TargetPrecheckSuite) TestSuccess(c *gc.C) {
+	problems, err := migration.TargetPrecheck(newHappyTargetBackend(), targetModelInfo())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 0)
+}
+
+func (*TargetPrecheckSuite) TestReportsEveryProblem(c *gc.C) {
+	backend := newHappyTargetBackend()
+	backend.upgrading = true
+	backend.agentVersion = version.MustParse("1.25.6")
+	backend.cloud.Regions = nil
+	backend.credentials = nil
+	backend.models = []migration.PrecheckModel{
+		&fakeModel{uuid: "model-uuid", name: "other", owner: names.NewUserTag("mary")},
+		&fakeModel{uuid: "other-uuid", name: "model", owner: modelOwner},
+	}
+
+	problems, err := migration.TargetPrecheck(backend, targetModelInfo())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, jc.DeepEquals, []string{
+		"target controller upgrade is in progress",
+		"target controller is at version 1.25.6, model is at 2.0.0",
+		`target controller cloud has no region "us-east-1"`,
+		`target controller has no cloud credential "default" for bob@local`,
+		"target controller already has model model-uuid",
+		`target controller already has a model named "model" owned by bob@local`,
+	})
+}
+
+func (*TargetPrecheckSuite) TestCloudTypeMismatch(c *gc.C) {
+	backend := newHappyTargetBackend()
+	backend.cloud.Type = "maas"
+	problems, err := migration.TargetPrecheck(backend, targetModelInfo())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, jc.DeepEquals, []string{
+		`target controller cloud type is "maas", model cloud type is "ec2"`,
+	})
+}
+
+func newHappyBackend() *fakeBackend {
+	return &fakeBackend{
+		modelLife: state.Alive,
+		machines: []migration.PrecheckMachine{
+			&fakeMachine{id: "0", status: status.StatusStarted, agentVersion: modelVersion, present: true},
+		},
+		applications: []migration.PrecheckApplication{
+			&fakeApplication{name: "mysql", units: []migration.PrecheckUnit{
+				&fakeUnit{name: "mysql/0", status: status.StatusIdle, agentVersion: modelVersion, present: true},
+			}},
+		},
+	}
+}
+
+type fakeBackend struct {
+	modelLife    state.Life
+	upgrading    bool
+	machines     []migration.PrecheckMachine
+	machinesErr  error
+	applications []migration.PrecheckApplication
+}
+
+func (b *fakeBackend) ModelInfo() (coremigration.ModelInfo, error) {
+	return coremigration.ModelInfo{UUID: "model-uuid", AgentVersion: modelVersion}, nil
+}
+
+func (b *fakeBackend) IsUpgrading() (bool, error) {
+	return b.upgrading, nil
+}
+
+func (b *fakeBackend) ModelLife() (state.Life, error) {
+	return b.modelLife, nil
+}
+
+func (b *fakeBackend) AllMachines() ([]migration.PrecheckMachine, error) {
+	return b.machines, b.machinesErr
+}
+
+func (b *fakeBackend) AllApplications() ([]migration.PrecheckApplication, error) {
+	return b.applications, nil
+}
+
+func fakeAgentTools(agentVersion version.Number) (*tools.Tools, error) {
+	if agentVersion == version.Zero {
+		return nil, errors.NotFoundf("agent tools")
+	}
+	return &tools.Tools{Version: version.Binary{Number: agentVersion}}, nil
+}
+
+type fakeMachine struct {
+	id             string
+	life           state.Life
+	notProvisioned bool
+	status         status.Status
+	message        string
+	present        bool
+	agentVersion   version.Number
+}
+
+func (m *fakeMachine) Id() string {
+	return m.id
+}
+
+func (m *fakeMachine) Life() state.Life {
+	return m.life
+}
+
+func (m *fakeMachine) Status() (status.StatusInfo, error) {
+	return status.StatusInfo{Status: m.status, Message: m.message}, nil
+}
+
+func (m *fakeMachine) InstanceId() (instance.Id, error) {
+	if m.notProvisioned {
+		return "", errors.NotProvisionedf("machine %s", m.id)
+	}
+	return instance.Id("inst-" + m.id), nil
+}
+
+func (m *fakeMachine) AgentPresence() (bool, error) {
+	return m.present, nil
+}
+
+func (m *fakeMachine) AgentTools() (*tools.Tools, error) {
+	return fakeAgentTools(m.agentVersion)
+}
+
+type fakeApplication struct {
+	name    string
+	life    state.Life
+	rolling *state.RollingUpgrade
+	units   []migration.PrecheckUnit
+}
+
+func (a *fakeApplication) Name() string {
+	return a.name
+}
+
+func (a *fakeApplication) Life() state.Life {
+	return a.life
+}
+
+func (a *fakeApplication) RollingUpgrade() (state.RollingUpgrade, bool) {
+	if a.rolling == nil {
+		return state.RollingUpgrade{}, false
+	}
+	return *a.rolling, true
+}
+
+func (a *fakeApplication) AllUnits() ([]migration.PrecheckUnit, error) {
+	return a.units, nil
+}
+
+type fakeUnit struct {
+	name         string
+	life         state.Life
+	status       status.Status
+	message      string
+	present      bool
+	agentVersion version.Number
+}
+
+func (u *fakeUnit) Name() string {
+	return u.name
+}
+
+func (u *fakeUnit) Life() state.Life {
+	return u.life
+}
+
+func (u *fakeUnit) AgentStatus() (status.StatusInfo, error) {
+	return status.StatusInfo{Status: u.status, Message: u.message}, nil
+}
+
+func (u *fakeUnit) AgentPresence() (bool, error) {
+	return u.present, nil
+}
+
+func (u *fakeUnit) AgentTools() (*tools.Tools, error) {
+	return fakeAgentTools(u.agentVersion)
+}
+
+func newHappyTargetBackend() *fakeTargetBackend {
+	return &fakeTargetBackend{
+		agentVersion: modelVersion,
+		cloud: cloud.Cloud{
+			Type:    "ec2",
+			Regions: []cloud.Region{{Name: "us-east-1"}},
+		},
+		credentials: map[string]cloud.Credential{
+			"default": cloud.NewEmptyCredential(),
+		},
+		models: []migration.PrecheckModel{
+			&fakeModel{uuid: "controller-uuid", name: "controller", owner: names.NewUserTag("admin")},
+		},
+	}
+}
+
+type fakeTargetBackend struct {
+	agentVersion version.Number
+	upgrading    bool
+	cloud        cloud.Cloud
+	credentials  map[string]cloud.Credential
+	models       []migration.PrecheckModel
+}
+
+func (b *fakeTargetBackend) AgentVersion() (version.Number, error) {
+	return b.agentVersion, nil
+}
+
+func (b *fakeTargetBackend) IsUpgrading() (bool, error) {
+	return b.upgrading, nil
+}
+
+func (b *fakeTargetBackend) Cloud() (cloud.Cloud, error) {
+	return b.cloud, nil
+}
+
+func (b *fakeTargetBackend) CloudCredentials(names.UserTag) (map[string]cloud.Credential, error) {
+	return b.credentials, nil
+}
+
+func (b *fakeTargetBackend) AllModels() ([]migration.PrecheckModel, error) {
+	return b.models, nil
+}
+
+type fakeModel struct {
+	uuid  string
+	name  string
+	owner names.UserTag
+}
+
+func (m *fakeModel) UUID() string {
+	return m.uuid
+}
+
+func (m *fakeModel) Name() string {
+	return m.name
+}
+
+func (m *fakeModel) Owner() names.UserTag {
+	return m.owner
+}
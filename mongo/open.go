@@ -106,12 +106,14 @@ func DialInfo(info Info, opts DialOpts) (*mgo.DialInfo, error) {
 	if len(info.CACert) == 0 {
 		return nil, stderrors.New("missing CA certificate")
 	}
-	xcert, err := cert.ParseCert(info.CACert)
+	xcerts, err := cert.ParseCerts(info.CACert)
 	if err != nil {
 		return nil, fmt.Errorf("cannot parse CA certificate: %v", err)
 	}
 	pool := x509.NewCertPool()
-	pool.AddCert(xcert)
+	for _, xcert := range xcerts {
+		pool.AddCert(xcert)
+	}
 	tlsConfig := utils.SecureTLSConfig()
 
 	// TODO(natefinch): revisit this when are full-time on mongo 3.
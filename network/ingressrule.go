@@ -0,0 +1,140 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// AnyIPv4CIDR is the source CIDR that allows IPv4 traffic from
+	// anywhere.
+	AnyIPv4CIDR = "0.0.0.0/0"
+
+	// AnyIPv6CIDR is the source CIDR that allows IPv6 traffic from
+	// anywhere.
+	AnyIPv6CIDR = "::/0"
+)
+
+// IngressRule represents a range of ports that is opened to traffic
+// from a set of source CIDRs.
+type IngressRule struct {
+	PortRange
+	// SourceCIDRs are the networks from which the ports may be
+	// reached. It is always sorted and never empty.
+	SourceCIDRs []string
+}
+
+// NewIngressRule returns an IngressRule for the given port range and
+// source CIDRs, which are validated and sorted. If no source CIDRs are
+// given, the ports are opened to traffic from anywhere.
+func NewIngressRule(portRange PortRange, sourceCIDRs ...string) (IngressRule, error) {
+	if len(sourceCIDRs) == 0 {
+		sourceCIDRs = []string{AnyIPv4CIDR}
+	}
+	cidrs := make([]string, len(sourceCIDRs))
+	for i, cidr := range sourceCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return IngressRule{}, errors.NotValidf("source CIDR %q", cidr)
+		}
+		cidrs[i] = cidr
+	}
+	sort.Strings(cidrs)
+	return IngressRule{PortRange: portRange, SourceCIDRs: cidrs}, nil
+}
+
+// MustNewIngressRule is like NewIngressRule but panics if the source
+// CIDRs are not valid. It is intended for use in tests.
+func MustNewIngressRule(portRange PortRange, sourceCIDRs ...string) IngressRule {
+	rule, err := NewIngressRule(portRange, sourceCIDRs...)
+	if err != nil {
+		panic(err)
+	}
+	return rule
+}
+
+// IngressRulesFromPorts returns rules that open each of the given port
+// ranges to traffic from anywhere.
+func IngressRulesFromPorts(ports []PortRange) []IngressRule {
+	rules := make([]IngressRule, len(ports))
+	for i, portRange := range ports {
+		rules[i] = IngressRule{PortRange: portRange, SourceCIDRs: []string{AnyIPv4CIDR}}
+	}
+	return rules
+}
+
+// ValidateCIDRs returns an error if any of the given strings is not a
+// valid CIDR.
+func ValidateCIDRs(cidrs []string) error {
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
+	return nil
+}
+
+// OpenToAll reports whether the rule allows traffic from anywhere,
+// over either IPv4 or IPv6.
+func (r IngressRule) OpenToAll() bool {
+	for _, cidr := range r.SourceCIDRs {
+		if cidr == AnyIPv4CIDR || cidr == AnyIPv6CIDR {
+			return true
+		}
+	}
+	return false
+}
+
+func (r IngressRule) String() string {
+	return fmt.Sprintf("%s from %s", r.PortRange, strings.Join(r.SourceCIDRs, ","))
+}
+
+func (r IngressRule) GoString() string {
+	return r.String()
+}
+
+// SplitIngressRules returns the given rules split into one rule per
+// source CIDR, sorted and without duplicates. Comparing rules at this
+// granularity lets rules whose source CIDRs overlap be opened and
+// closed independently of each other, however a provider groups the
+// source CIDRs of a port range.
+func SplitIngressRules(rules []IngressRule) []IngressRule {
+	seen := make(map[string]bool)
+	var split []IngressRule
+	for _, rule := range rules {
+		for _, cidr := range rule.SourceCIDRs {
+			single := IngressRule{PortRange: rule.PortRange, SourceCIDRs: []string{cidr}}
+			key := single.String()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			split = append(split, single)
+		}
+	}
+	SortIngressRules(split)
+	return split
+}
+
+type ingressRuleSlice []IngressRule
+
+func (s ingressRuleSlice) Len() int      { return len(s) }
+func (s ingressRuleSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s ingressRuleSlice) Less(i, j int) bool {
+	if s[i].PortRange != s[j].PortRange {
+		return portRangeSlice{s[i].PortRange, s[j].PortRange}.Less(0, 1)
+	}
+	return strings.Join(s[i].SourceCIDRs, ",") < strings.Join(s[j].SourceCIDRs, ",")
+}
+
+// SortIngressRules sorts the given rules, first by port range, then by
+// source CIDRs.
+func SortIngressRules(rules []IngressRule) {
+	sort.Sort(ingressRuleSlice(rules))
+}
@@ -0,0 +1,93 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type IngressRuleSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&IngressRuleSuite{})
+
+func (*IngressRuleSuite) TestNewIngressRuleDefaultsToAnywhere(c *gc.C) {
+	rule, err := network.NewIngressRule(network.MustParsePortRange("80/tcp"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.SourceCIDRs, jc.DeepEquals, []string{"0.0.0.0/0"})
+	c.Assert(rule.OpenToAll(), jc.IsTrue)
+	c.Assert(rule.String(), gc.Equals, "80/tcp from 0.0.0.0/0")
+}
+
+func (*IngressRuleSuite) TestNewIngressRuleSortsCIDRs(c *gc.C) {
+	rule, err := network.NewIngressRule(network.MustParsePortRange("80-90/tcp"), "192.168.0.0/16", "10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.SourceCIDRs, jc.DeepEquals, []string{"10.0.0.0/8", "192.168.0.0/16"})
+	c.Assert(rule.OpenToAll(), jc.IsFalse)
+	c.Assert(rule.String(), gc.Equals, "80-90/tcp from 10.0.0.0/8,192.168.0.0/16")
+}
+
+func (*IngressRuleSuite) TestOpenToAllIPv6(c *gc.C) {
+	rule := network.MustNewIngressRule(network.MustParsePortRange("80/tcp"), "::/0")
+	c.Assert(rule.OpenToAll(), jc.IsTrue)
+	rule = network.MustNewIngressRule(network.MustParsePortRange("80/tcp"), "2001:db8::/32")
+	c.Assert(rule.OpenToAll(), jc.IsFalse)
+}
+
+func (*IngressRuleSuite) TestNewIngressRuleInvalidCIDR(c *gc.C) {
+	_, err := network.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0")
+	c.Assert(err, gc.ErrorMatches, `source CIDR "10.0.0.0" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (*IngressRuleSuite) TestIngressRulesFromPorts(c *gc.C) {
+	rules := network.IngressRulesFromPorts([]network.PortRange{
+		network.MustParsePortRange("80/tcp"),
+		network.MustParsePortRange("53/udp"),
+	})
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule(network.MustParsePortRange("80/tcp")),
+		network.MustNewIngressRule(network.MustParsePortRange("53/udp")),
+	})
+}
+
+func (*IngressRuleSuite) TestSortIngressRules(c *gc.C) {
+	rules := []network.IngressRule{
+		network.MustNewIngressRule(network.MustParsePortRange("80/tcp"), "192.168.0.0/16"),
+		network.MustNewIngressRule(network.MustParsePortRange("53/udp")),
+		network.MustNewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+		network.MustNewIngressRule(network.MustParsePortRange("22/tcp")),
+	}
+	network.SortIngressRules(rules)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule(network.MustParsePortRange("22/tcp")),
+		network.MustNewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+		network.MustNewIngressRule(network.MustParsePortRange("80/tcp"), "192.168.0.0/16"),
+		network.MustNewIngressRule(network.MustParsePortRange("53/udp")),
+	})
+}
+
+func (*IngressRuleSuite) TestSplitIngressRules(c *gc.C) {
+	rules := network.SplitIngressRules([]network.IngressRule{
+		network.MustNewIngressRule(network.MustParsePortRange("80/tcp"), "192.168.0.0/16", "10.0.0.0/8"),
+		network.MustNewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+		network.MustNewIngressRule(network.MustParsePortRange("22/tcp")),
+	})
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule(network.MustParsePortRange("22/tcp")),
+		network.MustNewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+		network.MustNewIngressRule(network.MustParsePortRange("80/tcp"), "192.168.0.0/16"),
+	})
+}
+
+func (*IngressRuleSuite) TestValidateCIDRs(c *gc.C) {
+	c.Assert(network.ValidateCIDRs([]string{"10.0.0.0/8", "0.0.0.0/0"}), jc.ErrorIsNil)
+	c.Assert(network.ValidateCIDRs([]string{"bad"}), gc.ErrorMatches, `CIDR "bad" not valid`)
+}
@@ -185,6 +185,13 @@ func (inst *azureInstance) internalNetworkAddress() (jujunetwork.Address, error)
 
 // OpenPorts is specified in the Instance interface.
 func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortRange) error {
+	return inst.OpenIngressRules(machineId, jujunetwork.IngressRulesFromPorts(ports))
+}
+
+// OpenIngressRules is specified in the
+// instance.InstanceIngressRuleFirewaller interface. A security rule is
+// created for each source CIDR of each ingress rule.
+func (inst *azureInstance) OpenIngressRules(machineId string, ingressRules []jujunetwork.IngressRule) error {
 	inst.env.mu.Lock()
 	nsgClient := network.SecurityGroupsClient{inst.env.network}
 	securityRuleClient := network.SecurityRulesClient{inst.env.network}
@@ -216,73 +223,89 @@ func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortR
 	// NSG in memory, so we can easily tell which priorities are available.
 	vmName := resourceName(names.NewMachineTag(machineId))
 	prefix := instanceNetworkSecurityRulePrefix(instance.Id(vmName))
-	for _, ports := range ports {
-		ruleName := securityRuleName(prefix, ports)
-
-		// Check if the rule already exists; OpenPorts must be idempotent.
-		var found bool
-		for _, rule := range securityRules {
-			if to.String(rule.Name) == ruleName {
-				found = true
-				break
+	for _, ingressRule := range ingressRules {
+		ports := ingressRule.PortRange
+		for _, cidr := range ingressRule.SourceCIDRs {
+			ruleName := ingressSecurityRuleName(prefix, ports, cidr)
+
+			// Check if the rule already exists; OpenPorts must be idempotent.
+			var found bool
+			for _, rule := range securityRules {
+				if to.String(rule.Name) == ruleName {
+					found = true
+					break
+				}
 			}
-		}
-		if found {
-			logger.Debugf("security rule %q already exists", ruleName)
-			continue
-		}
-		logger.Debugf("creating security rule %q", ruleName)
+			if found {
+				logger.Debugf("security rule %q already exists", ruleName)
+				continue
+			}
+			logger.Debugf("creating security rule %q", ruleName)
 
-		priority, err := nextSecurityRulePriority(nsg, securityRuleInternalMax+1, securityRuleMax)
-		if err != nil {
-			return errors.Annotatef(err, "getting security rule priority for %s", ports)
-		}
+			priority, err := nextSecurityRulePriority(nsg, securityRuleInternalMax+1, securityRuleMax)
+			if err != nil {
+				return errors.Annotatef(err, "getting security rule priority for %s", ports)
+			}
 
-		var protocol network.SecurityRuleProtocol
-		switch ports.Protocol {
-		case "tcp":
-			protocol = network.TCP
-		case "udp":
-			protocol = network.UDP
-		default:
-			return errors.Errorf("invalid protocol %q", ports.Protocol)
-		}
+			var protocol network.SecurityRuleProtocol
+			switch ports.Protocol {
+			case "tcp":
+				protocol = network.TCP
+			case "udp":
+				protocol = network.UDP
+			default:
+				return errors.Errorf("invalid protocol %q", ports.Protocol)
+			}
 
-		var portRange string
-		if ports.FromPort != ports.ToPort {
-			portRange = fmt.Sprintf("%d-%d", ports.FromPort, ports.ToPort)
-		} else {
-			portRange = fmt.Sprint(ports.FromPort)
-		}
+			var portRange string
+			if ports.FromPort != ports.ToPort {
+				portRange = fmt.Sprintf("%d-%d", ports.FromPort, ports.ToPort)
+			} else {
+				portRange = fmt.Sprint(ports.FromPort)
+			}
 
-		rule := network.SecurityRule{
-			Properties: &network.SecurityRulePropertiesFormat{
-				Description:              to.StringPtr(ports.String()),
-				Protocol:                 protocol,
-				SourcePortRange:          to.StringPtr("*"),
-				DestinationPortRange:     to.StringPtr(portRange),
-				SourceAddressPrefix:      to.StringPtr("*"),
-				DestinationAddressPrefix: to.StringPtr(internalNetworkAddress.Value),
-				Access:    network.Allow,
-				Priority:  to.IntPtr(priority),
-				Direction: network.Inbound,
-			},
-		}
-		if err := inst.env.callAPI(func() (autorest.Response, error) {
-			result, err := securityRuleClient.CreateOrUpdate(
-				inst.env.resourceGroup, securityGroupName, ruleName, rule,
-			)
-			return result.Response, err
-		}); err != nil {
-			return errors.Annotatef(err, "creating security rule for %s", ports)
+			description := ports.String()
+			sourceAddressPrefix := "*"
+			if cidr != jujunetwork.AnyIPv4CIDR {
+				description = fmt.Sprintf("%s from %s", ports, cidr)
+				sourceAddressPrefix = cidr
+			}
+
+			rule := network.SecurityRule{
+				Properties: &network.SecurityRulePropertiesFormat{
+					Description:              to.StringPtr(description),
+					Protocol:                 protocol,
+					SourcePortRange:          to.StringPtr("*"),
+					DestinationPortRange:     to.StringPtr(portRange),
+					SourceAddressPrefix:      to.StringPtr(sourceAddressPrefix),
+					DestinationAddressPrefix: to.StringPtr(internalNetworkAddress.Value),
+					Access:    network.Allow,
+					Priority:  to.IntPtr(priority),
+					Direction: network.Inbound,
+				},
+			}
+			if err := inst.env.callAPI(func() (autorest.Response, error) {
+				result, err := securityRuleClient.CreateOrUpdate(
+					inst.env.resourceGroup, securityGroupName, ruleName, rule,
+				)
+				return result.Response, err
+			}); err != nil {
+				return errors.Annotatef(err, "creating security rule for %s", ports)
+			}
+			securityRules = append(securityRules, rule)
 		}
-		securityRules = append(securityRules, rule)
 	}
 	return nil
 }
 
 // ClosePorts is specified in the Instance interface.
 func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.PortRange) error {
+	return inst.CloseIngressRules(machineId, jujunetwork.IngressRulesFromPorts(ports))
+}
+
+// CloseIngressRules is specified in the
+// instance.InstanceIngressRuleFirewaller interface.
+func (inst *azureInstance) CloseIngressRules(machineId string, ingressRules []jujunetwork.IngressRule) error {
 	inst.env.mu.Lock()
 	securityRuleClient := network.SecurityRulesClient{inst.env.network}
 	inst.env.mu.Unlock()
@@ -292,19 +315,21 @@ func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.Port
 	// on changes made by the provisioner.
 	vmName := resourceName(names.NewMachineTag(machineId))
 	prefix := instanceNetworkSecurityRulePrefix(instance.Id(vmName))
-	for _, ports := range ports {
-		ruleName := securityRuleName(prefix, ports)
-		logger.Debugf("deleting security rule %q", ruleName)
-		var result autorest.Response
-		if err := inst.env.callAPI(func() (autorest.Response, error) {
-			var err error
-			result, err = securityRuleClient.Delete(
-				inst.env.resourceGroup, securityGroupName, ruleName,
-			)
-			return result, err
-		}); err != nil {
-			if result.Response == nil || result.StatusCode != http.StatusNotFound {
-				return errors.Annotatef(err, "deleting security rule %q", ruleName)
+	for _, ingressRule := range ingressRules {
+		for _, cidr := range ingressRule.SourceCIDRs {
+			ruleName := ingressSecurityRuleName(prefix, ingressRule.PortRange, cidr)
+			logger.Debugf("deleting security rule %q", ruleName)
+			var result autorest.Response
+			if err := inst.env.callAPI(func() (autorest.Response, error) {
+				var err error
+				result, err = securityRuleClient.Delete(
+					inst.env.resourceGroup, securityGroupName, ruleName,
+				)
+				return result, err
+			}); err != nil {
+				if result.Response == nil || result.StatusCode != http.StatusNotFound {
+					return errors.Annotatef(err, "deleting security rule %q", ruleName)
+				}
 			}
 		}
 	}
@@ -312,7 +337,34 @@ func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.Port
 }
 
 // Ports is specified in the Instance interface.
-func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRange, err error) {
+func (inst *azureInstance) Ports(machineId string) ([]jujunetwork.PortRange, error) {
+	rules, err := inst.ingressRules(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ports []jujunetwork.PortRange
+	for _, rule := range rules {
+		ports = append(ports, rule.PortRange)
+	}
+	return ports, nil
+}
+
+// IngressRules is specified in the
+// instance.InstanceIngressRuleFirewaller interface.
+func (inst *azureInstance) IngressRules(machineId string) ([]jujunetwork.IngressRule, error) {
+	rules, err := inst.ingressRules(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	jujunetwork.SortIngressRules(rules)
+	return rules, nil
+}
+
+// ingressRules returns the ingress rules opened for the machine, in the
+// order their security rules appear in the network security group.
+// Security rules for the same port range with different source address
+// prefixes are combined into a single ingress rule.
+func (inst *azureInstance) ingressRules(machineId string) ([]jujunetwork.IngressRule, error) {
 	inst.env.mu.Lock()
 	nsgClient := network.SecurityGroupsClient{inst.env.network}
 	inst.env.mu.Unlock()
@@ -330,6 +382,8 @@ func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRang
 		return nil, nil
 	}
 
+	var ports []jujunetwork.PortRange
+	cidrs := make(map[jujunetwork.PortRange][]string)
 	vmName := resourceName(names.NewMachineTag(machineId))
 	prefix := instanceNetworkSecurityRulePrefix(instance.Id(vmName))
 	for _, rule := range *nsg.Properties.SecurityRules {
@@ -351,6 +405,7 @@ func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRang
 			portRange.FromPort = 0
 			portRange.ToPort = 65535
 		} else {
+			var err error
 			portRange, err = jujunetwork.ParsePortRange(
 				*rule.Properties.DestinationPortRange,
 			)
@@ -371,12 +426,28 @@ func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRang
 		default:
 			protocols = []string{"tcp", "udp"}
 		}
+		cidr := to.String(rule.Properties.SourceAddressPrefix)
+		if cidr == "" || cidr == "*" {
+			cidr = jujunetwork.AnyIPv4CIDR
+		}
 		for _, protocol := range protocols {
 			portRange.Protocol = protocol
-			ports = append(ports, portRange)
+			if _, ok := cidrs[portRange]; !ok {
+				ports = append(ports, portRange)
+			}
+			cidrs[portRange] = append(cidrs[portRange], cidr)
 		}
 	}
-	return ports, nil
+
+	rules := make([]jujunetwork.IngressRule, len(ports))
+	for i, portRange := range ports {
+		rule, err := jujunetwork.NewIngressRule(portRange, cidrs[portRange]...)
+		if err != nil {
+			return nil, errors.Annotatef(err, "parsing source address prefixes for %s", portRange)
+		}
+		rules[i] = rule
+	}
+	return rules, nil
 }
 
 // deleteInstanceNetworkSecurityRules deletes network security rules in the
@@ -437,3 +508,15 @@ func securityRuleName(prefix string, ports jujunetwork.PortRange) string {
 	}
 	return ruleName
 }
+
+// ingressSecurityRuleName returns the security rule name for the given
+// port range and source CIDR, and prefix returned by
+// instanceNetworkSecurityRulePrefix. Rules open to anywhere keep the
+// name given by securityRuleName.
+func ingressSecurityRuleName(prefix string, ports jujunetwork.PortRange, cidr string) string {
+	ruleName := securityRuleName(prefix, ports)
+	if cidr == jujunetwork.AnyIPv4CIDR {
+		return ruleName
+	}
+	return ruleName + "-" + strings.NewReplacer(".", "-", "/", "-", ":", "-").Replace(cidr)
+}
@@ -1105,31 +1105,40 @@ func (e *environ) allControllerManagedVolumes(controllerUUID string) ([]string,
 }
 
 func portsToIPPerms(ports []network.PortRange) []ec2.IPPerm {
-	ipPerms := make([]ec2.IPPerm, len(ports))
-	for i, p := range ports {
+	return rulesToIPPerms(network.IngressRulesFromPorts(ports))
+}
+
+func rulesToIPPerms(rules []network.IngressRule) []ec2.IPPerm {
+	ipPerms := make([]ec2.IPPerm, len(rules))
+	for i, r := range rules {
 		ipPerms[i] = ec2.IPPerm{
-			Protocol:  p.Protocol,
-			FromPort:  p.FromPort,
-			ToPort:    p.ToPort,
-			SourceIPs: []string{"0.0.0.0/0"},
+			Protocol:  r.Protocol,
+			FromPort:  r.FromPort,
+			ToPort:    r.ToPort,
+			SourceIPs: r.SourceCIDRs,
 		}
 	}
 	return ipPerms
 }
 
 func (e *environ) openPortsInGroup(name string, ports []network.PortRange) error {
-	if len(ports) == 0 {
+	// Give permissions for anyone to access the given ports.
+	return e.openIngressRulesInGroup(name, network.IngressRulesFromPorts(ports))
+}
+
+func (e *environ) openIngressRulesInGroup(name string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
 		return nil
 	}
-	// Give permissions for anyone to access the given ports.
+	// Give permissions for the rules' source CIDRs to access their ports.
 	g, err := e.groupByName(name)
 	if err != nil {
 		return err
 	}
-	ipPerms := portsToIPPerms(ports)
+	ipPerms := rulesToIPPerms(rules)
 	_, err = e.ec2().AuthorizeSecurityGroup(g, ipPerms)
 	if err != nil && ec2ErrCode(err) == "InvalidPermission.Duplicate" {
-		if len(ports) == 1 {
+		if len(rules) == 1 {
 			return nil
 		}
 		// If there's more than one port and we get a duplicate error,
@@ -1151,23 +1160,54 @@ func (e *environ) openPortsInGroup(name string, ports []network.PortRange) error
 }
 
 func (e *environ) closePortsInGroup(name string, ports []network.PortRange) error {
-	if len(ports) == 0 {
+	// Revoke permissions for anyone to access the given ports.
+	return e.closeIngressRulesInGroup(name, network.IngressRulesFromPorts(ports))
+}
+
+func (e *environ) closeIngressRulesInGroup(name string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
 		return nil
 	}
-	// Revoke permissions for anyone to access the given ports.
+	// Revoke permissions for the rules' source CIDRs to access their ports.
 	// Note that ec2 allows the revocation of permissions that aren't
 	// granted, so this is naturally idempotent.
 	g, err := e.groupByName(name)
 	if err != nil {
 		return err
 	}
-	_, err = e.ec2().RevokeSecurityGroup(g, portsToIPPerms(ports))
+	_, err = e.ec2().RevokeSecurityGroup(g, rulesToIPPerms(rules))
 	if err != nil {
 		return fmt.Errorf("cannot close ports: %v", err)
 	}
 	return nil
 }
 
+func (e *environ) ingressRulesInGroup(name string) (rules []network.IngressRule, err error) {
+	group, err := e.groupInfoByName(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range group.IPPerms {
+		if len(p.SourceIPs) == 0 {
+			// Permissions granted to other security groups, such
+			// as the model's own, are not ingress rules.
+			continue
+		}
+		portRange := network.PortRange{
+			Protocol: p.Protocol,
+			FromPort: p.FromPort,
+			ToPort:   p.ToPort,
+		}
+		rule, err := network.NewIngressRule(portRange, p.SourceIPs...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
 func (e *environ) portsInGroup(name string) (ports []network.PortRange, err error) {
 	group, err := e.groupInfoByName(name)
 	if err != nil {
@@ -1217,6 +1257,38 @@ func (e *environ) Ports() ([]network.PortRange, error) {
 	return e.portsInGroup(e.globalGroupName())
 }
 
+// OpenIngressRules is part of the environs.IngressRuleFirewaller interface.
+func (e *environ) OpenIngressRules(rules []network.IngressRule) error {
+	if e.Config().FirewallMode() != config.FwGlobal {
+		return errors.Errorf("invalid firewall mode %q for opening ports on model", e.Config().FirewallMode())
+	}
+	if err := e.openIngressRulesInGroup(e.globalGroupName(), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("opened ingress rules in global group: %v", rules)
+	return nil
+}
+
+// CloseIngressRules is part of the environs.IngressRuleFirewaller interface.
+func (e *environ) CloseIngressRules(rules []network.IngressRule) error {
+	if e.Config().FirewallMode() != config.FwGlobal {
+		return errors.Errorf("invalid firewall mode %q for closing ports on model", e.Config().FirewallMode())
+	}
+	if err := e.closeIngressRulesInGroup(e.globalGroupName(), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("closed ingress rules in global group: %v", rules)
+	return nil
+}
+
+// IngressRules is part of the environs.IngressRuleFirewaller interface.
+func (e *environ) IngressRules() ([]network.IngressRule, error) {
+	if e.Config().FirewallMode() != config.FwGlobal {
+		return nil, errors.Errorf("invalid firewall mode %q for retrieving ports from model", e.Config().FirewallMode())
+	}
+	return e.ingressRulesInGroup(e.globalGroupName())
+}
+
 func (*environ) Provider() environs.EnvironProvider {
 	return &providerInstance
 }
@@ -734,6 +734,29 @@ func (t *localServerSuite) TestStartInstanceHardwareCharacteristics(c *gc.C) {
 	c.Assert(*hc.CpuPower, gc.Equals, uint64(300))
 }
 
+func (t *localServerSuite) TestInstanceIngressRules(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	inst, _ := testing.AssertStartInstance(c, env, t.ControllerUUID, "1")
+	fw, ok := inst.(instance.InstanceIngressRuleFirewaller)
+	c.Assert(ok, jc.IsTrue)
+
+	rules := []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}),
+		network.MustNewIngressRule(network.PortRange{3306, 3306, "tcp"}, "10.0.0.0/8", "192.168.1.0/24"),
+	}
+	err := fw.OpenIngressRules("1", rules)
+	c.Assert(err, jc.ErrorIsNil)
+	got, err := fw.IngressRules("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, rules)
+
+	err = fw.CloseIngressRules("1", rules[1:])
+	c.Assert(err, jc.ErrorIsNil)
+	got, err = fw.IngressRules("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, rules[:1])
+}
+
 func (t *localServerSuite) TestStartInstanceAvailZone(c *gc.C) {
 	inst, err := t.testStartInstanceAvailZone(c, "test-available")
 	c.Assert(err, jc.ErrorIsNil)
@@ -4,7 +4,12 @@
 package google
 
 import (
+	"crypto/sha1"
+	"fmt"
+	"strings"
+
 	"github.com/juju/errors"
+	"google.golang.org/api/compute/v1"
 
 	"github.com/juju/juju/network"
 )
@@ -111,3 +116,141 @@ func (gce Connection) ClosePorts(fwname string, ports ...network.PortRange) erro
 	}
 	return nil
 }
+
+// ingressFirewallName returns the name of the firewall that holds the
+// rules opened to the given source CIDRs for instances tagged with
+// fwname. Rules open to anywhere live in the firewall named fwname
+// itself, so that they are shared with OpenPorts and ClosePorts; other
+// sets of source CIDRs each get a firewall of their own.
+func ingressFirewallName(fwname string, sourceCIDRs []string) string {
+	if len(sourceCIDRs) == 1 && sourceCIDRs[0] == network.AnyIPv4CIDR {
+		return fwname
+	}
+	hash := sha1.Sum([]byte(strings.Join(sourceCIDRs, ",")))
+	return fmt.Sprintf("%s-%x", fwname, hash[:4])
+}
+
+// IngressRules returns the ingress rules opened for instances tagged
+// with the given firewall name. If there are none, the list will be
+// empty and no error is returned.
+func (gce Connection) IngressRules(fwname string) ([]network.IngressRule, error) {
+	firewalls, err := gce.raw.GetFirewalls(gce.projectID, fwname)
+	if err != nil {
+		return nil, errors.Annotate(err, "while getting ingress rules from GCE")
+	}
+	var rules []network.IngressRule
+	for _, firewall := range firewalls {
+		if firewall.Name != fwname && !strings.HasPrefix(firewall.Name, fwname+"-") {
+			continue
+		}
+		for _, allowed := range firewall.Allowed {
+			for _, portRangeStr := range allowed.Ports {
+				portRange, err := network.ParsePortRange(portRangeStr)
+				if err != nil {
+					return nil, errors.Annotate(err, "bad ports from GCE")
+				}
+				portRange.Protocol = allowed.IPProtocol
+				rule, err := network.NewIngressRule(portRange, firewall.SourceRanges...)
+				if err != nil {
+					return nil, errors.Annotate(err, "bad source ranges from GCE")
+				}
+				rules = append(rules, rule)
+			}
+		}
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+// OpenIngressRules sends requests to the GCE API to open the provided
+// ingress rules for instances tagged with the given firewall name,
+// creating or updating one firewall for each distinct set of source
+// CIDRs. The call blocks until the rules are opened or a request fails.
+func (gce Connection) OpenIngressRules(fwname string, rules ...network.IngressRule) error {
+	return gce.updateIngressRules(fwname, rules, network.PortSet.Union)
+}
+
+// CloseIngressRules sends requests to the GCE API to close the provided
+// ingress rules for instances tagged with the given firewall name. Any
+// firewall left with no ports is removed. The call blocks until the
+// rules are closed or a request fails.
+func (gce Connection) CloseIngressRules(fwname string, rules ...network.IngressRule) error {
+	return gce.updateIngressRules(fwname, rules, network.PortSet.Difference)
+}
+
+// updateIngressRules combines, using the given operation, the ports of
+// each firewall with the ports of the rules that belong in it, and
+// writes the result back to GCE.
+func (gce Connection) updateIngressRules(
+	fwname string,
+	rules []network.IngressRule,
+	combine func(current, input network.PortSet) network.PortSet,
+) error {
+	// Group the rules by the firewall they belong in.
+	type group struct {
+		sourceCIDRs []string
+		ports       []network.PortRange
+	}
+	groups := make(map[string]*group)
+	for _, rule := range rules {
+		name := ingressFirewallName(fwname, rule.SourceCIDRs)
+		if groups[name] == nil {
+			groups[name] = &group{sourceCIDRs: rule.SourceCIDRs}
+		}
+		groups[name].ports = append(groups[name].ports, rule.PortRange)
+	}
+
+	for name, g := range groups {
+		current, err := gce.raw.GetFirewall(gce.projectID, name)
+		if errors.IsNotFound(err) {
+			current = nil
+		} else if err != nil {
+			return errors.Annotate(err, "while getting ports from GCE")
+		}
+		currentPorts, err := firewallPorts(current)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		currentPortsSet := network.NewPortSet(currentPorts...)
+		newPortsSet := combine(currentPortsSet, network.NewPortSet(g.ports...))
+
+		switch {
+		case newPortsSet.IsEmpty() && current == nil:
+		case newPortsSet.IsEmpty():
+			if err := gce.raw.RemoveFirewall(gce.projectID, name); err != nil {
+				return errors.Annotatef(err, "closing port(s) %+v", g.ports)
+			}
+		case current == nil:
+			firewall := ingressFirewallSpec(name, fwname, g.sourceCIDRs, newPortsSet)
+			if err := gce.raw.AddFirewall(gce.projectID, firewall); err != nil {
+				return errors.Annotatef(err, "opening port(s) %+v", g.ports)
+			}
+		default:
+			firewall := ingressFirewallSpec(name, fwname, g.sourceCIDRs, newPortsSet)
+			if err := gce.raw.UpdateFirewall(gce.projectID, name, firewall); err != nil {
+				return errors.Annotatef(err, "updating port(s) %+v", g.ports)
+			}
+		}
+	}
+	return nil
+}
+
+// firewallPorts returns the port ranges allowed by the given firewall,
+// which may be nil.
+func firewallPorts(firewall *compute.Firewall) ([]network.PortRange, error) {
+	if firewall == nil {
+		return nil, nil
+	}
+	var ports []network.PortRange
+	for _, allowed := range firewall.Allowed {
+		for _, portRangeStr := range allowed.Ports {
+			portRange, err := network.ParsePortRange(portRangeStr)
+			if err != nil {
+				return nil, errors.Annotate(err, "bad ports from GCE")
+			}
+			portRange.Protocol = allowed.IPProtocol
+			ports = append(ports, portRange)
+		}
+	}
+	return ports, nil
+}
@@ -173,3 +173,84 @@ func (s *connSuite) TestConnectionClosePortsUpdate(c *gc.C) {
 		}},
 	})
 }
+
+func (s *connSuite) TestConnectionIngressRules(c *gc.C) {
+	s.FakeConn.Firewalls = []*compute.Firewall{{
+		Name:         "spam",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"0.0.0.0/0"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"80-81"},
+		}},
+	}, {
+		Name:         "spam-1a2b3c4d",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"3306"},
+		}},
+	}, {
+		Name:         "spamalot",
+		TargetTags:   []string{"spamalot"},
+		SourceRanges: []string{"0.0.0.0/0"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"22"},
+		}},
+	}}
+
+	rules, err := s.Conn.IngressRules("spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{80, 81, "tcp"}),
+		network.MustNewIngressRule(network.PortRange{3306, 3306, "tcp"}, "10.0.0.0/8"),
+	})
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewalls")
+	c.Check(s.FakeConn.Calls[0].Prefix, gc.Equals, "spam")
+}
+
+func (s *connSuite) TestConnectionOpenIngressRulesRestricted(c *gc.C) {
+	s.FakeConn.Err = errors.NotFoundf("spam")
+	s.FakeConn.FailOnCall = 0
+
+	rule := network.MustNewIngressRule(network.PortRange{3306, 3306, "tcp"}, "10.0.0.0/8")
+	err := s.Conn.OpenIngressRules("spam", rule)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewall")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "AddFirewall")
+	firewall := s.FakeConn.Calls[1].Firewall
+	c.Check(firewall.Name, gc.Matches, "spam-[0-9a-f]{8}")
+	c.Check(firewall.TargetTags, jc.DeepEquals, []string{"spam"})
+	c.Check(firewall.SourceRanges, jc.DeepEquals, []string{"10.0.0.0/8"})
+	c.Check(firewall.Allowed, jc.DeepEquals, []*compute.FirewallAllowed{{
+		IPProtocol: "tcp",
+		Ports:      []string{"3306"},
+	}})
+}
+
+func (s *connSuite) TestConnectionCloseIngressRulesRemovesFirewall(c *gc.C) {
+	s.FakeConn.Firewall = &compute.Firewall{
+		Name:         "spam",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"0.0.0.0/0"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"80"},
+		}},
+	}
+
+	err := s.Conn.CloseIngressRules("spam", network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewall")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[1].Name, gc.Equals, "spam")
+}
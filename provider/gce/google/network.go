@@ -58,14 +58,21 @@ func (ns *NetworkSpec) newInterface(name string) *compute.NetworkInterface {
 // firewallSpec expands a port range set in to compute.FirewallAllowed
 // and returns a compute.Firewall for the provided name.
 func firewallSpec(name string, ps network.PortSet) *compute.Firewall {
+	return ingressFirewallSpec(name, name, []string{network.AnyIPv4CIDR}, ps)
+}
+
+// ingressFirewallSpec returns a compute.Firewall with the provided name
+// that opens the port range set to the given source CIDRs on instances
+// tagged with target.
+func ingressFirewallSpec(name, target string, sourceCIDRs []string, ps network.PortSet) *compute.Firewall {
 	firewall := compute.Firewall{
 		// Allowed is set below.
 		// Description is not set.
 		Name: name,
 		// Network: (defaults to global)
 		// SourceTags is not set.
-		TargetTags:   []string{name},
-		SourceRanges: []string{"0.0.0.0/0"},
+		TargetTags:   []string{target},
+		SourceRanges: sourceCIDRs,
 	}
 
 	for _, protocol := range ps.Protocols() {
@@ -27,8 +27,21 @@ func isController(icfg *instancecfg.InstanceConfig) bool {
 	return multiwatcher.AnyJobNeedsState(icfg.Jobs...)
 }
 
-// MaintainInstance is specified in the InstanceBroker interface.
-func (*environ) MaintainInstance(args environs.StartInstanceParams) error {
+// MaintainInstance is specified in the InstanceBroker interface. It
+// applies the LXD profiles declared by the charms deployed to the
+// instance, replacing those of any previous charm revisions.
+func (env *environ) MaintainInstance(args environs.StartInstanceParams) error {
+	name, err := env.namespace.Hostname(args.InstanceConfig.MachineId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	charmProfiles, err := env.raw.EnsureCharmProfiles(args.InstanceConfig.CharmLXDProfiles)
+	if err != nil {
+		return errors.Annotate(err, "cannot create charm LXD profiles")
+	}
+	if err := env.raw.ReplaceCharmProfiles(name, charmProfiles); err != nil {
+		return errors.Annotate(err, "cannot update charm LXD profiles")
+	}
 	return nil
 }
 
@@ -164,6 +177,22 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams) (*lxdclien
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+
+	profiles := []string{
+		//TODO(wwitzel3) allow the user to specify lxc profiles to apply. This allows the
+		// user to setup any custom devices order config settings for their environment.
+		// Also we must ensure that a device with the parent: lxcbr0 exists in at least
+		// one of the profiles.
+		"default",
+		env.profileName(),
+	}
+	if len(args.InstanceConfig.CharmLXDProfiles) > 0 {
+		charmProfiles, err := env.raw.EnsureCharmProfiles(args.InstanceConfig.CharmLXDProfiles)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot create charm LXD profiles")
+		}
+		profiles = append(profiles, charmProfiles...)
+	}
 	//tags := []string{
 	//	env.globalFirewallName(),
 	//	machineID,
@@ -179,14 +208,7 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams) (*lxdclien
 		//Disks:             getDisks(spec, args.Constraints),
 		//NetworkInterfaces: []string{"ExternalNAT"},
 		Metadata: metadata,
-		Profiles: []string{
-			//TODO(wwitzel3) allow the user to specify lxc profiles to apply. This allows the
-			// user to setup any custom devices order config settings for their environment.
-			// Also we must ensure that a device with the parent: lxcbr0 exists in at least
-			// one of the profiles.
-			"default",
-			env.profileName(),
-		},
+		Profiles: profiles,
 		//Tags:              tags,
 		// Network is omitted (left empty).
 	}
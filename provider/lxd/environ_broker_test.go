@@ -6,12 +6,15 @@
 package lxd_test
 
 import (
+	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/provider/lxd"
+	"github.com/juju/juju/tools/lxdclient"
 )
 
 type environBrokerSuite struct {
@@ -38,6 +41,59 @@ func (s *environBrokerSuite) TestStartInstance(c *gc.C) {
 	c.Assert(s.StartInstArgs.InstanceConfig.AgentVersion().Arch, gc.Equals, arch.ARM64)
 }
 
+func (s *environBrokerSuite) TestStartInstanceWithCharmProfiles(c *gc.C) {
+	s.Client.Inst = s.RawInstance
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+	profiles := map[string]lxdprofile.Profile{
+		"juju-charm-f75cba-app-1": {Config: map[string]string{"security.nesting": "true"}},
+	}
+	s.StartInstArgs.InstanceConfig.CharmLXDProfiles = profiles
+
+	_, err := s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var specs []lxdclient.InstanceSpec
+	for _, call := range s.Stub.Calls() {
+		switch call.FuncName {
+		case "EnsureCharmProfiles":
+			c.Check(call.Args, jc.DeepEquals, []interface{}{profiles})
+		case "AddInstance":
+			specs = append(specs, call.Args[0].(lxdclient.InstanceSpec))
+		}
+	}
+	c.Assert(specs, gc.HasLen, 1)
+	c.Assert(specs[0].Profiles, jc.DeepEquals, []string{
+		"default", "juju-testenv", "juju-charm-f75cba-app-1",
+	})
+}
+
+func (s *environBrokerSuite) TestMaintainInstance(c *gc.C) {
+	profiles := map[string]lxdprofile.Profile{
+		"juju-charm-f75cba-app-2": {Config: map[string]string{"security.nesting": "true"}},
+	}
+	s.StartInstArgs.InstanceConfig.MachineId = "42"
+	s.StartInstArgs.InstanceConfig.CharmLXDProfiles = profiles
+
+	err := s.Env.MaintainInstance(s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Stub.CheckCalls(c, []gitjujutesting.StubCall{{
+		FuncName: "EnsureCharmProfiles",
+		Args:     []interface{}{profiles},
+	}, {
+		FuncName: "ReplaceCharmProfiles",
+		Args:     []interface{}{s.InstName, []string{"juju-charm-f75cba-app-2"}},
+	}})
+}
+
+func (s *environBrokerSuite) TestMaintainInstanceError(c *gc.C) {
+	s.StartInstArgs.InstanceConfig.MachineId = "42"
+	s.Stub.SetErrors(nil, errors.New("boom"))
+
+	err := s.Env.MaintainInstance(s.StartInstArgs)
+	c.Assert(err, gc.ErrorMatches, "cannot update charm LXD profiles: boom")
+}
+
 func (s *environBrokerSuite) TestStartInstanceNoTools(c *gc.C) {
 	s.Client.Inst = s.RawInstance
 
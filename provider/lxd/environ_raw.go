@@ -8,6 +8,7 @@ package lxd
 import (
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/tools/lxdclient"
@@ -26,11 +27,13 @@ type lxdInstances interface {
 	AddInstance(lxdclient.InstanceSpec) (*lxdclient.Instance, error)
 	RemoveInstances(string, ...string) error
 	Addresses(string) ([]network.Address, error)
+	ReplaceCharmProfiles(string, []string) error
 }
 
 type lxdProfiles interface {
 	CreateProfile(string, map[string]string) error
 	HasProfile(string) (bool, error)
+	EnsureCharmProfiles(map[string]lxdprofile.Profile) ([]string, error)
 }
 
 type lxdImages interface {
@@ -9,6 +9,7 @@ import (
 	"crypto/tls"
 	"encoding/pem"
 	"os"
+	"sort"
 
 	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
@@ -19,6 +20,7 @@ import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/cloudconfig/providerinit"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/tags"
@@ -312,6 +314,7 @@ func (s *BaseSuite) SetUpTest(c *gc.C) {
 	// Patch out all expensive external deps.
 	s.Env.raw = &rawProvider{
 		lxdInstances:   s.Client,
+		lxdProfiles:    s.Client,
 		lxdImages:      s.Client,
 		Firewaller:     s.Firewaller,
 		policyProvider: s.Policy,
@@ -529,6 +532,39 @@ func (conn *StubClient) EnsureImageExists(series string, _ []lxdclient.Remote, _
 	return nil
 }
 
+func (conn *StubClient) CreateProfile(name string, config map[string]string) error {
+	conn.AddCall("CreateProfile", name, config)
+	return conn.NextErr()
+}
+
+func (conn *StubClient) HasProfile(name string) (bool, error) {
+	conn.AddCall("HasProfile", name)
+	if err := conn.NextErr(); err != nil {
+		return false, errors.Trace(err)
+	}
+
+	return false, nil
+}
+
+func (conn *StubClient) EnsureCharmProfiles(profiles map[string]lxdprofile.Profile) ([]string, error) {
+	conn.AddCall("EnsureCharmProfiles", profiles)
+	if err := conn.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var names []string
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (conn *StubClient) ReplaceCharmProfiles(name string, profiles []string) error {
+	conn.AddCall("ReplaceCharmProfiles", name, profiles)
+	return conn.NextErr()
+}
+
 func (conn *StubClient) Addresses(name string) ([]network.Address, error) {
 	conn.AddCall("Addresses", name)
 	if err := conn.NextErr(); err != nil {
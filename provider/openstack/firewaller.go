@@ -12,6 +12,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/retry"
 	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
 	gooseerrors "gopkg.in/goose.v1/errors"
 	"gopkg.in/goose.v1/nova"
 
@@ -63,6 +64,24 @@ type Firewaller interface {
 
 	// InstancePorts returns the port ranges opened for the specified  instance.
 	InstancePorts(inst instance.Instance, machineId string) ([]network.PortRange, error)
+
+	// OpenIngressRules opens the given ingress rules for the whole environment.
+	OpenIngressRules(rules []network.IngressRule) error
+
+	// CloseIngressRules closes the given ingress rules for the whole environment.
+	CloseIngressRules(rules []network.IngressRule) error
+
+	// IngressRules returns the ingress rules opened for the whole environment.
+	IngressRules() ([]network.IngressRule, error)
+
+	// OpenInstanceIngressRules opens the given ingress rules for the specified instance.
+	OpenInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error
+
+	// CloseInstanceIngressRules closes the given ingress rules for the specified instance.
+	CloseInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error
+
+	// InstanceIngressRules returns the ingress rules opened for the specified instance.
+	InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error)
 }
 
 type firewallerFactory struct {
@@ -383,6 +402,78 @@ func (c *defaultFirewaller) InstancePorts(inst instance.Instance, machineId stri
 	return portRanges, nil
 }
 
+// OpenIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) OpenIngressRules(rules []network.IngressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwGlobal {
+		return fmt.Errorf("invalid firewall mode %q for opening ingress rules on model",
+			c.environ.Config().FirewallMode())
+	}
+	if err := c.openIngressRulesInGroup(c.globalGroupRegexp(), rules); err != nil {
+		return err
+	}
+	logger.Infof("opened ingress rules in global group: %v", rules)
+	return nil
+}
+
+// CloseIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) CloseIngressRules(rules []network.IngressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwGlobal {
+		return fmt.Errorf("invalid firewall mode %q for closing ingress rules on model",
+			c.environ.Config().FirewallMode())
+	}
+	if err := c.closeIngressRulesInGroup(c.globalGroupRegexp(), rules); err != nil {
+		return err
+	}
+	logger.Infof("closed ingress rules in global group: %v", rules)
+	return nil
+}
+
+// IngressRules implements Firewaller interface.
+func (c *defaultFirewaller) IngressRules() ([]network.IngressRule, error) {
+	if c.environ.Config().FirewallMode() != config.FwGlobal {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ingress rules from model",
+			c.environ.Config().FirewallMode())
+	}
+	return c.ingressRulesInGroup(c.globalGroupRegexp())
+}
+
+// OpenInstanceIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) OpenInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for opening ingress rules on instance",
+			c.environ.Config().FirewallMode())
+	}
+	nameRegexp := c.machineGroupRegexp(machineId)
+	if err := c.openIngressRulesInGroup(nameRegexp, rules); err != nil {
+		return err
+	}
+	logger.Infof("opened ingress rules in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
+	return nil
+}
+
+// CloseInstanceIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) CloseInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for closing ingress rules on instance",
+			c.environ.Config().FirewallMode())
+	}
+	nameRegexp := c.machineGroupRegexp(machineId)
+	if err := c.closeIngressRulesInGroup(nameRegexp, rules); err != nil {
+		return err
+	}
+	logger.Infof("closed ingress rules in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
+	return nil
+}
+
+// InstanceIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ingress rules from instance",
+			c.environ.Config().FirewallMode())
+	}
+	return c.ingressRulesInGroup(c.machineGroupRegexp(machineId))
+}
+
 func (c *defaultFirewaller) matchingGroup(nameRegExp string) (nova.SecurityGroup, error) {
 	re, err := regexp.Compile(nameRegExp)
 	if err != nil {
@@ -406,12 +497,16 @@ func (c *defaultFirewaller) matchingGroup(nameRegExp string) (nova.SecurityGroup
 }
 
 func (c *defaultFirewaller) openPortsInGroup(nameRegExp string, portRanges []network.PortRange) error {
+	return c.openIngressRulesInGroup(nameRegExp, network.IngressRulesFromPorts(portRanges))
+}
+
+func (c *defaultFirewaller) openIngressRulesInGroup(nameRegExp string, ingressRules []network.IngressRule) error {
 	group, err := c.matchingGroup(nameRegExp)
 	if err != nil {
 		return err
 	}
 	novaclient := c.environ.nova()
-	rules := portsToRuleInfo(group.Id, portRanges)
+	rules := ingressRulesToRuleInfo(group.Id, ingressRules)
 	for _, rule := range rules {
 		_, err := novaclient.CreateSecurityGroupRule(rule)
 		if err != nil {
@@ -457,6 +552,70 @@ func (c *defaultFirewaller) closePortsInGroup(nameRegExp string, portRanges []ne
 	return nil
 }
 
+// ruleCIDR returns the source CIDR of the supplied nova security group
+// rule. Rules without one apply to traffic from anywhere.
+func ruleCIDR(rule nova.SecurityGroupRule) string {
+	if cidr := rule.IPRange["cidr"]; cidr != "" {
+		return cidr
+	}
+	return network.AnyIPv4CIDR
+}
+
+func (c *defaultFirewaller) closeIngressRulesInGroup(nameRegExp string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	group, err := c.matchingGroup(nameRegExp)
+	if err != nil {
+		return err
+	}
+	novaclient := c.environ.nova()
+	for _, rule := range rules {
+		cidrs := set.NewStrings(rule.SourceCIDRs...)
+		for _, p := range group.Rules {
+			if !ruleMatchesPortRange(p, rule.PortRange) || !cidrs.Contains(ruleCIDR(p)) {
+				continue
+			}
+			if err := novaclient.DeleteSecurityGroupRule(p.Id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *defaultFirewaller) ingressRulesInGroup(nameRegexp string) ([]network.IngressRule, error) {
+	group, err := c.matchingGroup(nameRegexp)
+	if err != nil {
+		return nil, err
+	}
+	// Nova holds one rule per source CIDR; gather the CIDRs of each
+	// port range back into a single ingress rule.
+	var portRanges []network.PortRange
+	cidrs := make(map[network.PortRange][]string)
+	for _, p := range group.Rules {
+		portRange := network.PortRange{
+			Protocol: *p.IPProtocol,
+			FromPort: *p.FromPort,
+			ToPort:   *p.ToPort,
+		}
+		if _, ok := cidrs[portRange]; !ok {
+			portRanges = append(portRanges, portRange)
+		}
+		cidrs[portRange] = append(cidrs[portRange], ruleCIDR(p))
+	}
+	rules := make([]network.IngressRule, len(portRanges))
+	for i, portRange := range portRanges {
+		rule, err := network.NewIngressRule(portRange, cidrs[portRange]...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules[i] = rule
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
 func (c *defaultFirewaller) portsInGroup(nameRegexp string) (portRanges []network.PortRange, err error) {
 	group, err := c.matchingGroup(nameRegexp)
 	if err != nil {
@@ -395,6 +395,24 @@ func (inst *openstackInstance) Ports(machineId string) ([]network.PortRange, err
 	return inst.e.firewaller.InstancePorts(inst, machineId)
 }
 
+// OpenIngressRules is specified in the
+// instance.InstanceIngressRuleFirewaller interface.
+func (inst *openstackInstance) OpenIngressRules(machineId string, rules []network.IngressRule) error {
+	return inst.e.firewaller.OpenInstanceIngressRules(inst, machineId, rules)
+}
+
+// CloseIngressRules is specified in the
+// instance.InstanceIngressRuleFirewaller interface.
+func (inst *openstackInstance) CloseIngressRules(machineId string, rules []network.IngressRule) error {
+	return inst.e.firewaller.CloseInstanceIngressRules(inst, machineId, rules)
+}
+
+// IngressRules is specified in the
+// instance.InstanceIngressRuleFirewaller interface.
+func (inst *openstackInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
+	return inst.e.firewaller.InstanceIngressRules(inst, machineId)
+}
+
 func (e *Environ) ecfg() *environConfig {
 	e.ecfgMutex.Lock()
 	ecfg := e.ecfgUnlocked
@@ -1352,14 +1370,22 @@ func (e *Environ) machinesFilter() *nova.Filter {
 
 // portsToRuleInfo maps port ranges to nova rules
 func portsToRuleInfo(groupId string, ports []network.PortRange) []nova.RuleInfo {
-	rules := make([]nova.RuleInfo, len(ports))
-	for i, portRange := range ports {
-		rules[i] = nova.RuleInfo{
-			ParentGroupId: groupId,
-			FromPort:      portRange.FromPort,
-			ToPort:        portRange.ToPort,
-			IPProtocol:    portRange.Protocol,
-			Cidr:          "0.0.0.0/0",
+	return ingressRulesToRuleInfo(groupId, network.IngressRulesFromPorts(ports))
+}
+
+// ingressRulesToRuleInfo maps ingress rules to nova rules, one for each
+// source CIDR of each rule.
+func ingressRulesToRuleInfo(groupId string, ingressRules []network.IngressRule) []nova.RuleInfo {
+	var rules []nova.RuleInfo
+	for _, ingressRule := range ingressRules {
+		for _, cidr := range ingressRule.SourceCIDRs {
+			rules = append(rules, nova.RuleInfo{
+				ParentGroupId: groupId,
+				FromPort:      ingressRule.FromPort,
+				ToPort:        ingressRule.ToPort,
+				IPProtocol:    ingressRule.Protocol,
+				Cidr:          cidr,
+			})
 		}
 	}
 	return rules
@@ -1377,6 +1403,24 @@ func (e *Environ) Ports() ([]network.PortRange, error) {
 	return e.firewaller.Ports()
 }
 
+// OpenIngressRules is specified in the environs.IngressRuleFirewaller
+// interface.
+func (e *Environ) OpenIngressRules(rules []network.IngressRule) error {
+	return e.firewaller.OpenIngressRules(rules)
+}
+
+// CloseIngressRules is specified in the environs.IngressRuleFirewaller
+// interface.
+func (e *Environ) CloseIngressRules(rules []network.IngressRule) error {
+	return e.firewaller.CloseIngressRules(rules)
+}
+
+// IngressRules is specified in the environs.IngressRuleFirewaller
+// interface.
+func (e *Environ) IngressRules() ([]network.IngressRule, error) {
+	return e.firewaller.IngressRules()
+}
+
 func (e *Environ) Provider() environs.EnvironProvider {
 	return providerInstance
 }
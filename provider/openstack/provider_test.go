@@ -281,6 +281,33 @@ func (*localTests) TestPortsToRuleInfo(c *gc.C) {
 	}
 }
 
+func (*localTests) TestIngressRulesToRuleInfo(c *gc.C) {
+	groupId := "groupid"
+	rules := IngressRulesToRuleInfo(groupId, []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}),
+		network.MustNewIngressRule(network.PortRange{3306, 3306, "tcp"}, "192.168.1.0/24", "10.0.0.0/8"),
+	})
+	c.Check(rules, gc.DeepEquals, []nova.RuleInfo{{
+		IPProtocol:    "tcp",
+		FromPort:      80,
+		ToPort:        80,
+		Cidr:          "0.0.0.0/0",
+		ParentGroupId: groupId,
+	}, {
+		IPProtocol:    "tcp",
+		FromPort:      3306,
+		ToPort:        3306,
+		Cidr:          "10.0.0.0/8",
+		ParentGroupId: groupId,
+	}, {
+		IPProtocol:    "tcp",
+		FromPort:      3306,
+		ToPort:        3306,
+		Cidr:          "192.168.1.0/24",
+		ParentGroupId: groupId,
+	}})
+}
+
 func (*localTests) TestRuleMatchesPortRange(c *gc.C) {
 	proto_tcp := "tcp"
 	proto_udp := "udp"
@@ -94,6 +94,63 @@ func (c *rackspaceFirewaller) InstancePorts(inst instance.Instance, machineId st
 	return configurator.FindOpenPorts()
 }
 
+// OpenIngressRules is not supported.
+func (c *rackspaceFirewaller) OpenIngressRules(rules []network.IngressRule) error {
+	return errors.NotSupportedf("OpenIngressRules")
+}
+
+// CloseIngressRules is not supported.
+func (c *rackspaceFirewaller) CloseIngressRules(rules []network.IngressRule) error {
+	return errors.NotSupportedf("CloseIngressRules")
+}
+
+// IngressRules is not supported.
+func (c *rackspaceFirewaller) IngressRules() ([]network.IngressRule, error) {
+	return nil, errors.NotSupportedf("IngressRules")
+}
+
+// OpenInstanceIngressRules implements Firewaller interface. Ports are
+// opened with iptables on the instance, which cannot restrict the
+// source of traffic, so only rules open to anywhere are supported.
+func (c *rackspaceFirewaller) OpenInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	ports, err := unrestrictedPorts(rules)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.changePorts(inst, true, ports)
+}
+
+// CloseInstanceIngressRules implements Firewaller interface.
+func (c *rackspaceFirewaller) CloseInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	ports, err := unrestrictedPorts(rules)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.changePorts(inst, false, ports)
+}
+
+// InstanceIngressRules implements Firewaller interface.
+func (c *rackspaceFirewaller) InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
+	ports, err := c.InstancePorts(inst, machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return network.IngressRulesFromPorts(ports), nil
+}
+
+// unrestrictedPorts returns the port ranges of the given rules, which
+// must all be open to anywhere.
+func unrestrictedPorts(rules []network.IngressRule) ([]network.PortRange, error) {
+	ports := make([]network.PortRange, len(rules))
+	for i, rule := range rules {
+		if !rule.OpenToAll() {
+			return nil, errors.NotSupportedf("restricting ingress rule %v to source CIDRs", rule)
+		}
+		ports[i] = rule.PortRange
+	}
+	return ports, nil
+}
+
 func (c *rackspaceFirewaller) changePorts(inst instance.Instance, insert bool, ports []network.PortRange) error {
 	addresses, sshClient, err := c.getInstanceConfigurator(inst)
 	if err != nil {
@@ -100,8 +100,10 @@ func newResource(c *gc.C, name, username, data string) (resource.Resource, api.R
 type stubFacade struct {
 	basetesting.StubFacadeCaller
 
-	apiResults map[string]api.ResourcesResult
-	pendingIDs []string
+	apiResults    map[string]api.ResourcesResult
+	pendingIDs    []string
+	history       []api.HistoryEntry
+	rollbackError *params.Error
 }
 
 func newStubFacade(c *gc.C, stub *testing.Stub) *stubFacade {
@@ -134,6 +136,12 @@ func newStubFacade(c *gc.C, stub *testing.Stub) *stubFacade {
 			}
 		case *api.AddPendingResourcesResult:
 			typedResponse.PendingIDs = s.pendingIDs
+		case *api.ResourceHistoryResults:
+			typedResponse.Results = []api.ResourceHistoryResult{{
+				History: s.history,
+			}}
+		case *params.ErrorResult:
+			typedResponse.Error = s.rollbackError
 		default:
 			c.Errorf("bad type %T", response)
 		}
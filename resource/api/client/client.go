@@ -13,6 +13,7 @@ import (
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/api"
@@ -83,6 +84,58 @@ func (c Client) ListResources(services []string) ([]resource.ServiceResources, e
 	return results, nil
 }
 
+// ListResourceHistory calls the ListResourceHistory API server method
+// with the given application name.
+func (c Client) ListResourceHistory(service string) ([]resource.HistoryEntry, error) {
+	args, err := api.NewListResourcesArgs([]string{service})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var apiResults api.ResourceHistoryResults
+	if err := c.FacadeCall("ListResourceHistory", &args, &apiResults); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(apiResults.Results) != 1 {
+		return nil, errors.Errorf("got invalid data from server (expected 1 result, got %d)", len(apiResults.Results))
+	}
+	apiResult := apiResults.Results[0]
+	if apiResult.Error != nil {
+		err := common.RestoreError(apiResult.Error)
+		return nil, errors.Trace(err)
+	}
+
+	var history []resource.HistoryEntry
+	for _, apiEntry := range apiResult.History {
+		entry, err := api.API2HistoryEntry(apiEntry)
+		if err != nil {
+			return nil, errors.Annotate(err, "got bad data from server")
+		}
+		history = append(history, entry)
+	}
+	return history, nil
+}
+
+// RollbackResource calls the RollbackResource API server method to
+// make the identified revision in the history of the application's
+// resource the active resource.
+func (c Client) RollbackResource(service, name string, revision int) error {
+	args, err := api.NewRollbackResourceArgs(service, name, revision)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var result params.ErrorResult
+	if err := c.FacadeCall("RollbackResource", &args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		err := common.RestoreError(result.Error)
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 // Upload sends the provided resource blob up to Juju.
 func (c Client) Upload(service, name, filename string, reader io.ReadSeeker) error {
 	uReq, err := api.NewUploadRequest(service, name, filename, reader)
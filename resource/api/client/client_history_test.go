@@ -0,0 +1,99 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/resource/api"
+	"github.com/juju/juju/resource/api/client"
+)
+
+var _ = gc.Suite(&ResourceHistorySuite{})
+
+type ResourceHistorySuite struct {
+	BaseSuite
+}
+
+func (s *ResourceHistorySuite) TestListResourceHistory(c *gc.C) {
+	res1, apiRes1 := newResource(c, "spam", "a-user", "spamspamspam")
+	res2, apiRes2 := newResource(c, "spam", "a-user", "eggs")
+	s.facade.history = []api.HistoryEntry{{
+		Resource:        apiRes1,
+		HistoryRevision: 1,
+	}, {
+		Resource:        apiRes2,
+		HistoryRevision: 2,
+		Current:         true,
+	}}
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	history, err := cl.ListResourceHistory("a-application")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(history, jc.DeepEquals, []resource.HistoryEntry{{
+		Resource:        res1,
+		HistoryRevision: 1,
+	}, {
+		Resource:        res2,
+		HistoryRevision: 2,
+		Current:         true,
+	}})
+	s.stub.CheckCallNames(c, "FacadeCall")
+	s.stub.CheckCall(c, 0, "FacadeCall",
+		"ListResourceHistory",
+		&api.ListResourcesArgs{[]params.Entity{{
+			Tag: "application-a-application",
+		}}},
+		&api.ResourceHistoryResults{
+			Results: []api.ResourceHistoryResult{{
+				History: s.facade.history,
+			}},
+		},
+	)
+}
+
+func (s *ResourceHistorySuite) TestListResourceHistoryBadService(c *gc.C) {
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	_, err := cl.ListResourceHistory("???")
+
+	c.Check(err, gc.ErrorMatches, `.*invalid application.*`)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *ResourceHistorySuite) TestRollbackResource(c *gc.C) {
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.RollbackResource("a-application", "spam", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "FacadeCall")
+	s.stub.CheckCall(c, 0, "FacadeCall",
+		"RollbackResource",
+		&api.RollbackResourceArgs{
+			Entity:          params.Entity{Tag: "application-a-application"},
+			Name:            "spam",
+			HistoryRevision: 2,
+		},
+		&params.ErrorResult{},
+	)
+}
+
+func (s *ResourceHistorySuite) TestRollbackResourceNotFound(c *gc.C) {
+	s.facade.rollbackError = &params.Error{
+		Message: `revision 3 of resource "a-application/spam" not found`,
+		Code:    params.CodeNotFound,
+	}
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.RollbackResource("a-application", "spam", 3)
+
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	c.Check(err, gc.ErrorMatches, `revision 3 of resource "a-application/spam" not found`)
+}
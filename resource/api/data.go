@@ -126,6 +126,66 @@ type UnitResources struct {
 	DownloadProgress map[string]int64
 }
 
+// ResourceHistoryResults holds the resource history that results from
+// a bulk API call.
+type ResourceHistoryResults struct {
+	// Results is the list of resource history results.
+	Results []ResourceHistoryResult
+}
+
+// ResourceHistoryResult holds the history of each of the resources of
+// a single application.
+type ResourceHistoryResult struct {
+	params.ErrorResult
+
+	// History is the list of history entries, sorted by resource name
+	// and revision.
+	History []HistoryEntry
+}
+
+// HistoryEntry contains info about a revision in the history of an
+// application's resource.
+type HistoryEntry struct {
+	Resource
+
+	// HistoryRevision identifies the entry within the history of the
+	// application's resource.
+	HistoryRevision int `json:"history-revision"`
+
+	// Current indicates whether the application currently uses the
+	// entry's resource.
+	Current bool `json:"current"`
+}
+
+// RollbackResourceArgs holds the arguments to the RollbackResource
+// API endpoint.
+type RollbackResourceArgs struct {
+	params.Entity
+
+	// Name identifies the application's resource.
+	Name string `json:"name"`
+
+	// HistoryRevision identifies the entry in the resource's history
+	// to roll back to.
+	HistoryRevision int `json:"history-revision"`
+}
+
+// NewRollbackResourceArgs returns the arguments for the
+// RollbackResource API endpoint.
+func NewRollbackResourceArgs(applicationID, name string, revision int) (RollbackResourceArgs, error) {
+	var args RollbackResourceArgs
+	if !names.IsValidApplication(applicationID) {
+		return args, errors.Errorf("invalid application %q", applicationID)
+	}
+	if name == "" {
+		return args, errors.New("missing resource name")
+	}
+	args.Tag = names.NewApplicationTag(applicationID).String()
+	args.Name = name
+	args.HistoryRevision = revision
+	return args, nil
+}
+
 // UploadResult is the response from an upload request.
 type UploadResult struct {
 	params.ErrorResult
@@ -118,6 +118,30 @@ func ServiceResources2APIResult(svcRes resource.ServiceResources) ResourcesResul
 	return result
 }
 
+// HistoryEntry2API converts a resource.HistoryEntry into
+// a HistoryEntry struct.
+func HistoryEntry2API(entry resource.HistoryEntry) HistoryEntry {
+	return HistoryEntry{
+		Resource:        Resource2API(entry.Resource),
+		HistoryRevision: entry.HistoryRevision,
+		Current:         entry.Current,
+	}
+}
+
+// API2HistoryEntry converts an API HistoryEntry struct into
+// a resource.HistoryEntry.
+func API2HistoryEntry(apiEntry HistoryEntry) (resource.HistoryEntry, error) {
+	res, err := API2Resource(apiEntry.Resource)
+	if err != nil {
+		return resource.HistoryEntry{}, errors.Trace(err)
+	}
+	return resource.HistoryEntry{
+		Resource:        res,
+		HistoryRevision: apiEntry.HistoryRevision,
+		Current:         apiEntry.Current,
+	}, nil
+}
+
 // API2Resource converts an API Resource struct into
 // a resource.Resource.
 func API2Resource(apiRes Resource) (resource.Resource, error) {
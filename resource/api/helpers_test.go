@@ -448,6 +448,26 @@ func (HelpersSuite) TestAPI2Resource(c *gc.C) {
 	c.Check(res, jc.DeepEquals, expected)
 }
 
+func (HelpersSuite) TestHistoryEntryRoundTrip(c *gc.C) {
+	res := resourcetesting.NewResource(c, nil, "spam", "a-application", "spamspamspam").Resource
+	entry := resource.HistoryEntry{
+		Resource:        res,
+		HistoryRevision: 3,
+		Current:         true,
+	}
+
+	apiEntry := api.HistoryEntry2API(entry)
+	c.Check(apiEntry, jc.DeepEquals, api.HistoryEntry{
+		Resource:        api.Resource2API(res),
+		HistoryRevision: 3,
+		Current:         true,
+	})
+
+	converted, err := api.API2HistoryEntry(apiEntry)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(converted, jc.DeepEquals, entry)
+}
+
 func (HelpersSuite) TestCharmResource2API(c *gc.C) {
 	fp, err := charmresource.NewFingerprint([]byte(fingerprint))
 	c.Assert(err, jc.ErrorIsNil)
@@ -80,6 +80,7 @@ type stubDataStore struct {
 	ReturnGetPendingResource    resource.Resource
 	ReturnSetResource           resource.Resource
 	ReturnUpdatePendingResource resource.Resource
+	ReturnListResourceHistory   []resource.HistoryEntry
 }
 
 func (s *stubDataStore) ListResources(service string) (resource.ServiceResources, error) {
@@ -136,6 +137,24 @@ func (s *stubDataStore) UpdatePendingResource(applicationID, pendingID, userID s
 	return s.ReturnUpdatePendingResource, nil
 }
 
+func (s *stubDataStore) ListResourceHistory(applicationID string) ([]resource.HistoryEntry, error) {
+	s.stub.AddCall("ListResourceHistory", applicationID)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.ReturnListResourceHistory, nil
+}
+
+func (s *stubDataStore) RollbackResource(applicationID, name string, revision int) error {
+	s.stub.AddCall("RollbackResource", applicationID, name, revision)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
 type stubCSClient struct {
 	*testing.Stub
 
@@ -85,6 +85,14 @@ type resourceInfoStore interface {
 	// it is resolved. The returned ID is used to identify the pending
 	// resources when resolving it.
 	AddPendingResource(applicationID, userID string, chRes charmresource.Resource, r io.Reader) (string, error)
+
+	// ListResourceHistory returns the history of each of the
+	// application's resources.
+	ListResourceHistory(applicationID string) ([]resource.HistoryEntry, error)
+
+	// RollbackResource makes the identified revision in the history of
+	// the application's resource the active resource.
+	RollbackResource(applicationID, name string, revision int) error
 }
 
 // ListResources returns the list of resources for the given application.
@@ -115,6 +123,50 @@ func (f Facade) ListResources(args api.ListResourcesArgs) (api.ResourcesResults,
 	return r, nil
 }
 
+// ListResourceHistory returns the history of the resources of each of
+// the given applications.
+func (f Facade) ListResourceHistory(args api.ListResourcesArgs) (api.ResourceHistoryResults, error) {
+	var r api.ResourceHistoryResults
+	r.Results = make([]api.ResourceHistoryResult, len(args.Entities))
+
+	for i, e := range args.Entities {
+		tag, apierr := parseApplicationTag(e.Tag)
+		if apierr != nil {
+			r.Results[i].Error = apierr
+			continue
+		}
+
+		history, err := f.store.ListResourceHistory(tag.Id())
+		if err != nil {
+			r.Results[i].Error = common.ServerError(err)
+			continue
+		}
+
+		for _, entry := range history {
+			r.Results[i].History = append(r.Results[i].History, api.HistoryEntry2API(entry))
+		}
+	}
+	return r, nil
+}
+
+// RollbackResource makes the identified revision in the history of the
+// application's resource the active resource. As with a new upload,
+// this triggers a charm upgrade on the application's units.
+func (f Facade) RollbackResource(args api.RollbackResourceArgs) (params.ErrorResult, error) {
+	var result params.ErrorResult
+
+	tag, apiErr := parseApplicationTag(args.Tag)
+	if apiErr != nil {
+		result.Error = apiErr
+		return result, nil
+	}
+
+	if err := f.store.RollbackResource(tag.Id(), args.Name, args.HistoryRevision); err != nil {
+		result.Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // AddPendingResources adds the provided resources (info) to the Juju
 // model in a pending state, meaning they are not available until
 // resolved.
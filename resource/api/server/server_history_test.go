@@ -0,0 +1,106 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package server_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/resource/api"
+	"github.com/juju/juju/resource/api/server"
+)
+
+var _ = gc.Suite(&ResourceHistorySuite{})
+
+type ResourceHistorySuite struct {
+	BaseSuite
+}
+
+func (s *ResourceHistorySuite) TestListResourceHistory(c *gc.C) {
+	res1, apiRes1 := newResource(c, "spam", "a-user", "spamspamspam")
+	res2, apiRes2 := newResource(c, "spam", "a-user", "eggs")
+	s.data.ReturnListResourceHistory = []resource.HistoryEntry{{
+		Resource:        res1,
+		HistoryRevision: 1,
+	}, {
+		Resource:        res2,
+		HistoryRevision: 2,
+		Current:         true,
+	}}
+	facade, err := server.NewFacade(s.data, s.newCSClient)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := facade.ListResourceHistory(api.ListResourcesArgs{
+		Entities: []params.Entity{{
+			Tag: "application-a-application",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(results, jc.DeepEquals, api.ResourceHistoryResults{
+		Results: []api.ResourceHistoryResult{{
+			History: []api.HistoryEntry{{
+				Resource:        apiRes1,
+				HistoryRevision: 1,
+			}, {
+				Resource:        apiRes2,
+				HistoryRevision: 2,
+				Current:         true,
+			}},
+		}},
+	})
+	s.stub.CheckCallNames(c, "ListResourceHistory")
+	s.stub.CheckCall(c, 0, "ListResourceHistory", "a-application")
+}
+
+func (s *ResourceHistorySuite) TestListResourceHistoryBadTag(c *gc.C) {
+	facade, err := server.NewFacade(s.data, s.newCSClient)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := facade.ListResourceHistory(api.ListResourcesArgs{
+		Entities: []params.Entity{{
+			Tag: "unit-a-application-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `"unit-a-application-0" is not a valid application tag`)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *ResourceHistorySuite) TestRollbackResource(c *gc.C) {
+	facade, err := server.NewFacade(s.data, s.newCSClient)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.RollbackResource(api.RollbackResourceArgs{
+		Entity:          params.Entity{Tag: "application-a-application"},
+		Name:            "spam",
+		HistoryRevision: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(result.Error, gc.IsNil)
+	s.stub.CheckCallNames(c, "RollbackResource")
+	s.stub.CheckCall(c, 0, "RollbackResource", "a-application", "spam", 2)
+}
+
+func (s *ResourceHistorySuite) TestRollbackResourceNotFound(c *gc.C) {
+	s.stub.SetErrors(errors.NotFoundf(`revision 3 of resource "a-application/spam"`))
+	facade, err := server.NewFacade(s.data, s.newCSClient)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.RollbackResource(api.RollbackResourceArgs{
+		Entity:          params.Entity{Tag: "application-a-application"},
+		Name:            "spam",
+		HistoryRevision: 3,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(result.Error, gc.ErrorMatches, `revision 3 of resource "a-application/spam" not found`)
+	c.Check(result.Error.Code, gc.Equals, params.CodeNotFound)
+}
@@ -74,3 +74,28 @@ type FormattedServiceDetails struct {
 // FormattedDetailResource is the data for the tabular output for juju resources
 // <unit> --details.
 type FormattedUnitDetails []FormattedDetailResource
+
+// FormattedHistoryEntry holds the formatted representation of a
+// revision in the history of an application's resource.
+type FormattedHistoryEntry struct {
+	// These fields are exported for the sake of serialization.
+	Name            string    `json:"name" yaml:"name"`
+	HistoryRevision int       `json:"historyRevision" yaml:"historyRevision"`
+	Revision        int       `json:"revision,omitempty" yaml:"revision,omitempty"`
+	Fingerprint     string    `json:"fingerprint" yaml:"fingerprint"`
+	Size            int64     `json:"size" yaml:"size"`
+	Origin          string    `json:"origin" yaml:"origin"`
+	Timestamp       time.Time `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+	Username        string    `json:"username,omitempty" yaml:"username,omitempty"`
+	Current         bool      `json:"current" yaml:"current"`
+
+	// These fields are not exported so they won't be serialized, since they are
+	// specific to the tabular output.
+	combinedOrigin string
+	added          string
+	currentYesNo   string
+}
+
+// FormattedServiceHistory is the data for the tabular output for juju
+// resources <application> --history.
+type FormattedServiceHistory []FormattedHistoryEntry
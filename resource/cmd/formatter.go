@@ -77,6 +77,37 @@ func FormatSvcResource(res resource.Resource) FormattedSvcResource {
 	}
 }
 
+// FormatHistoryEntry converts the history entry into a
+// FormattedHistoryEntry.
+func FormatHistoryEntry(entry resource.HistoryEntry) FormattedHistoryEntry {
+	added := "-"
+	if !entry.Timestamp.IsZero() {
+		added = entry.Timestamp.Format("2006-02-01T15:04")
+	}
+	return FormattedHistoryEntry{
+		Name:            entry.Name,
+		HistoryRevision: entry.HistoryRevision,
+		Revision:        entry.Revision,
+		Fingerprint:     entry.Fingerprint.String(),
+		Size:            entry.Size,
+		Origin:          entry.Origin.String(),
+		Timestamp:       entry.Timestamp,
+		Username:        entry.Username,
+		Current:         entry.Current,
+		combinedOrigin:  combinedOrigin(true, entry.Resource),
+		added:           added,
+		currentYesNo:    usedYesNo(entry.Current),
+	}
+}
+
+func formatServiceHistory(history []resource.HistoryEntry) FormattedServiceHistory {
+	formatted := make(FormattedServiceHistory, len(history))
+	for i, entry := range history {
+		formatted[i] = FormatHistoryEntry(entry)
+	}
+	return formatted
+}
+
 func formatServiceResources(sr resource.ServiceResources) (FormattedServiceInfo, error) {
 	var formatted FormattedServiceInfo
 	updates, err := sr.Updates()
@@ -54,6 +54,8 @@ func FormatSvcTabular(value interface{}) ([]byte, error) {
 		return formatServiceDetailTabular(resources), nil
 	case FormattedUnitDetails:
 		return formatUnitDetailTabular(resources), nil
+	case FormattedServiceHistory:
+		return formatServiceHistoryTabular(resources), nil
 	default:
 		return nil, errors.Errorf("unexpected type for data: %T", resources)
 	}
@@ -185,6 +187,29 @@ func formatUnitDetailTabular(resources FormattedUnitDetails) []byte {
 	return out.Bytes()
 }
 
+func formatServiceHistoryTabular(history FormattedServiceHistory) []byte {
+	var out bytes.Buffer
+	fmt.Fprintln(&out, "[History]")
+
+	// To format things into columns.
+	tw := tabwriter.NewWriter(&out, 0, 1, 1, ' ', 0)
+
+	// Write the header.
+	fmt.Fprintln(tw, "RESOURCE\tREVISION\tSUPPLIED BY\tADDED\tCURRENT")
+
+	for _, r := range history {
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\n",
+			r.Name,
+			r.HistoryRevision,
+			r.combinedOrigin,
+			r.added,
+			r.currentYesNo,
+		)
+	}
+	tw.Flush()
+	return out.Bytes()
+}
+
 type byUnitID []FormattedDetailResource
 
 func (b byUnitID) Len() int      { return len(b) }
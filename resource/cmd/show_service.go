@@ -17,6 +17,8 @@ import (
 type ShowServiceClient interface {
 	// ListResources returns info about resources for applications in the model.
 	ListResources(services []string) ([]resource.ServiceResources, error)
+	// ListResourceHistory returns the history of an application's resources.
+	ListResourceHistory(service string) ([]resource.HistoryEntry, error)
 	// Close closes the connection.
 	Close() error
 }
@@ -34,6 +36,7 @@ type ShowServiceCommand struct {
 	modelcmd.ModelCommandBase
 
 	details bool
+	history bool
 	deps    ShowServiceDeps
 	out     cmd.Output
 	target  string
@@ -56,6 +59,10 @@ func (c *ShowServiceCommand) Info() *cmd.Info {
 This command shows the resources required by and those in use by an existing
 application or unit in your model.  When run for an application, it will also show any
 updates available for resources from the charmstore.
+
+With --history, it shows the revisions of an application's resources that
+are kept by the controller. The application may be rolled back to any of
+them with "juju attach <application> <resource> --revision <revision>".
 `,
 	}
 }
@@ -70,6 +77,7 @@ func (c *ShowServiceCommand) SetFlags(f *gnuflag.FlagSet) {
 	})
 
 	f.BoolVar(&c.details, "details", false, "show detailed information about resources used by each unit.")
+	f.BoolVar(&c.history, "history", false, "show the revisions of an application's resources that may be rolled back to.")
 }
 
 // Init implements cmd.Command.Init. It will return an error satisfying
@@ -82,6 +90,9 @@ func (c *ShowServiceCommand) Init(args []string) error {
 	if err := cmd.CheckEmpty(args[1:]); err != nil {
 		return errors.NewBadRequest(err, "")
 	}
+	if c.history && !names.IsValidApplication(c.target) {
+		return errors.NewBadRequest(nil, "--history requires an application name")
+	}
 	return nil
 }
 
@@ -105,6 +116,14 @@ func (c *ShowServiceCommand) Run(ctx *cmd.Context) error {
 		unit = c.target
 	}
 
+	if c.history {
+		history, err := apiclient.ListResourceHistory(service)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return c.out.Write(ctx, formatServiceHistory(history))
+	}
+
 	vals, err := apiclient.ListResources([]string{service})
 	if err != nil {
 		return errors.Trace(err)
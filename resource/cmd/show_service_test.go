@@ -4,6 +4,7 @@
 package cmd
 
 import (
+	"strings"
 	"time"
 
 	jujucmd "github.com/juju/cmd"
@@ -56,6 +57,14 @@ func (*ShowServiceSuite) TestInitTooManyArgs(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsBadRequest)
 }
 
+func (*ShowServiceSuite) TestInitHistoryUnit(c *gc.C) {
+	s := ShowServiceCommand{history: true}
+
+	err := s.Init([]string{"foo/0"})
+	c.Assert(err, jc.Satisfies, errors.IsBadRequest)
+	c.Assert(err, gc.ErrorMatches, "--history requires an application name")
+}
+
 func (s *ShowServiceSuite) TestInfo(c *gc.C) {
 	var command ShowServiceCommand
 	info := command.Info()
@@ -69,6 +78,10 @@ func (s *ShowServiceSuite) TestInfo(c *gc.C) {
 This command shows the resources required by and those in use by an existing
 application or unit in your model.  When run for an application, it will also show any
 updates available for resources from the charmstore.
+
+With --history, it shows the revisions of an application's resources that
+are kept by the controller. The application may be rolled back to any of
+them with "juju attach <application> <resource> --revision <revision>".
 `,
 	})
 }
@@ -552,6 +565,59 @@ charlie  2011-11-11T11:11 2012-12-12T12:12 (fetching: 0%)
 	s.stubDeps.stub.CheckCall(c, 1, "ListResources", []string{"svc"})
 }
 
+func (s *ShowServiceSuite) TestRunHistory(c *gc.C) {
+	fp, err := charmresource.GenerateFingerprint(strings.NewReader("spamspamspam"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.stubDeps.client.ReturnHistory = []resource.HistoryEntry{{
+		Resource: resource.Resource{
+			Resource: charmresource.Resource{
+				Meta: charmresource.Meta{
+					Name: "openjdk",
+				},
+				Origin:   charmresource.OriginStore,
+				Revision: 7,
+			},
+		},
+		HistoryRevision: 1,
+	}, {
+		Resource: resource.Resource{
+			Resource: charmresource.Resource{
+				Meta: charmresource.Meta{
+					Name: "website",
+				},
+				Origin:      charmresource.OriginUpload,
+				Fingerprint: fp,
+				Size:        12,
+			},
+			Username:  "Bill User",
+			Timestamp: time.Date(2012, 12, 12, 12, 12, 12, 0, time.UTC),
+		},
+		HistoryRevision: 2,
+		Current:         true,
+	}}
+
+	cmd := &ShowServiceCommand{
+		deps: ShowServiceDeps{
+			NewClient: s.stubDeps.NewClient,
+		},
+	}
+
+	code, stdout, stderr := runCmd(c, cmd, "svc", "--history")
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(stderr, gc.Equals, "")
+
+	c.Check(stdout, gc.Equals, `
+[History]
+RESOURCE REVISION SUPPLIED BY ADDED            CURRENT
+openjdk  1        charmstore  -                no
+website  2        Bill User   2012-12-12T12:12 yes
+
+`[1:])
+
+	s.stubDeps.stub.CheckCallNames(c, "NewClient", "ListResourceHistory", "Close")
+	s.stubDeps.stub.CheckCall(c, 1, "ListResourceHistory", "svc")
+}
+
 type stubShowServiceDeps struct {
 	stub   *testing.Stub
 	client *stubServiceClient
@@ -569,6 +635,7 @@ func (s *stubShowServiceDeps) NewClient(c *ShowServiceCommand) (ShowServiceClien
 type stubServiceClient struct {
 	stub            *testing.Stub
 	ReturnResources []resource.ServiceResources
+	ReturnHistory   []resource.HistoryEntry
 }
 
 func (s *stubServiceClient) ListResources(services []string) ([]resource.ServiceResources, error) {
@@ -579,6 +646,14 @@ func (s *stubServiceClient) ListResources(services []string) ([]resource.Service
 	return s.ReturnResources, nil
 }
 
+func (s *stubServiceClient) ListResourceHistory(service string) ([]resource.HistoryEntry, error) {
+	s.stub.AddCall("ListResourceHistory", service)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.ReturnHistory, nil
+}
+
 func (s *stubServiceClient) Close() error {
 	s.stub.AddCall("Close")
 	if err := s.stub.NextErr(); err != nil {
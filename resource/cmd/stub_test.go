@@ -59,6 +59,15 @@ func (s *stubAPIClient) Upload(service, name, filename string, resource io.ReadS
 	return nil
 }
 
+func (s *stubAPIClient) RollbackResource(service, name string, revision int) error {
+	s.stub.AddCall("RollbackResource", service, name, revision)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
 func (s *stubAPIClient) Close() error {
 	s.stub.AddCall("Close")
 	if err := s.stub.NextErr(); err != nil {
@@ -5,9 +5,11 @@ package cmd
 
 import (
 	"io"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -17,6 +19,10 @@ type UploadClient interface {
 	// Upload sends the resource to Juju.
 	Upload(service, name, filename string, resource io.ReadSeeker) error
 
+	// RollbackResource makes the identified revision in the history of
+	// the application's resource the active resource.
+	RollbackResource(service, name string, revision int) error
+
 	// Close closes the client.
 	Close() error
 }
@@ -43,6 +49,7 @@ type UploadCommand struct {
 	modelcmd.ModelCommandBase
 	service      string
 	resourceFile resourceFile
+	revision     int
 }
 
 // NewUploadCommand returns a new command that lists resources defined
@@ -60,10 +67,19 @@ func (c *UploadCommand) Info() *cmd.Info {
 		Doc: `
 This command uploads a file from your local disk to the juju controller to be
 used as a resource for an application.
+
+With --revision, it instead rolls the application's resource back to the given
+revision, as shown by "juju resources --history <application>". As with an
+upload, this triggers a charm upgrade on the application's units.
 `,
 	}
 }
 
+// SetFlags implements cmd.Command.SetFlags.
+func (c *UploadCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.IntVar(&c.revision, "revision", 0, "roll the resource back to this revision in its history instead of uploading a file")
+}
+
 // Init implements cmd.Command.Init. It will return an error satisfying
 // errors.BadRequest if you give it an incorrect number of arguments.
 func (c *UploadCommand) Init(args []string) error {
@@ -80,8 +96,21 @@ func (c *UploadCommand) Init(args []string) error {
 	}
 	c.service = service
 
-	if err := c.addResourceFile(args[1]); err != nil {
-		return errors.Trace(err)
+	switch {
+	case c.revision < 0:
+		return errors.NotValidf("revision %d", c.revision)
+	case c.revision > 0:
+		if strings.Contains(args[1], "=") {
+			return errors.BadRequestf("cannot specify a file with --revision")
+		}
+		c.resourceFile = resourceFile{
+			service: c.service,
+			name:    args[1],
+		}
+	default:
+		if err := c.addResourceFile(args[1]); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	if err := cmd.CheckEmpty(args[2:]); err != nil {
 		return errors.NewBadRequest(err, "")
@@ -114,6 +143,14 @@ func (c *UploadCommand) Run(*cmd.Context) error {
 	}
 	defer apiclient.Close()
 
+	if c.revision > 0 {
+		err := apiclient.RollbackResource(c.service, c.resourceFile.name, c.revision)
+		if err != nil {
+			return errors.Annotatef(err, "failed to roll back resource %q", c.resourceFile.name)
+		}
+		return nil
+	}
+
 	if err := c.upload(c.resourceFile, apiclient); err != nil {
 		return errors.Annotatef(err, "failed to upload resource %q", c.resourceFile.name)
 	}
@@ -0,0 +1,42 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resource
+
+import (
+	"sort"
+)
+
+// HistoryEntry is a revision of an application's resource that was
+// stored in the model, whether uploaded or fetched from the charm
+// store. A bounded number of entries is kept for each resource so that
+// the application may be rolled back to an earlier revision.
+type HistoryEntry struct {
+	Resource
+
+	// HistoryRevision identifies the entry within the history of the
+	// application's resource. It increases each time the resource is
+	// stored.
+	HistoryRevision int
+
+	// Current indicates whether the entry holds the resource that the
+	// application currently uses.
+	Current bool
+}
+
+// SortHistory sorts the provided history entries by resource name and
+// then by history revision.
+func SortHistory(history []HistoryEntry) {
+	sort.Sort(byNameAndHistoryRevision(history))
+}
+
+type byNameAndHistoryRevision []HistoryEntry
+
+func (sorted byNameAndHistoryRevision) Len() int      { return len(sorted) }
+func (sorted byNameAndHistoryRevision) Swap(i, j int) { sorted[i], sorted[j] = sorted[j], sorted[i] }
+func (sorted byNameAndHistoryRevision) Less(i, j int) bool {
+	if sorted[i].Name != sorted[j].Name {
+		return sorted[i].Name < sorted[j].Name
+	}
+	return sorted[i].HistoryRevision < sorted[j].HistoryRevision
+}
@@ -29,6 +29,13 @@ func NewPublicFacade(st *corestate.State, _ *common.Resources, authorizer common
 		return nil, errors.Trace(err)
 	}
 	newClient := func() (server.CharmStore, error) {
+		controllerConfig, err := st.ControllerConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if controllerConfig.CharmStoreMirror() {
+			return &charmMirror{st}, nil
+		}
 		return newCharmStoreClient(st)
 	}
 	facade, err := server.NewFacade(rst, newClient)
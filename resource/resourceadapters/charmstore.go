@@ -125,3 +125,45 @@ func (client CSRetryClient) GetResource(req charmstore.ResourceRequest) (charmst
 
 	return data, nil
 }
+
+// charmMirror provides the charm store functionality needed for
+// resources from the controller's charm mirror, for controllers that
+// cannot reach the charm store.
+type charmMirror struct {
+	st *state.State
+}
+
+// GetResource implements charmstore.StoreResourceGetter.
+func (m *charmMirror) GetResource(req charmstore.ResourceRequest) (charmstore.ResourceData, error) {
+	res, reader, err := m.st.OpenMirroredResource(req.Charm, req.Name, req.Revision)
+	if err != nil {
+		return charmstore.ResourceData{}, errors.Trace(err)
+	}
+	return charmstore.ResourceData{
+		ReadCloser: reader,
+		Resource:   res,
+	}, nil
+}
+
+// ListResources implements server.CharmStore.
+func (m *charmMirror) ListResources(charms []charmstore.CharmID) ([][]charmresource.Resource, error) {
+	results := make([][]charmresource.Resource, len(charms))
+	for i, ch := range charms {
+		resources, err := m.st.MirroredResources(ch.URL)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		results[i] = resources
+	}
+	return results, nil
+}
+
+// ResourceInfo implements server.CharmStore.
+func (m *charmMirror) ResourceInfo(req charmstore.ResourceRequest) (charmresource.Resource, error) {
+	res, reader, err := m.st.OpenMirroredResource(req.Charm, req.Name, req.Revision)
+	if err != nil {
+		return charmresource.Resource{}, errors.Trace(err)
+	}
+	reader.Close()
+	return res, nil
+}
@@ -36,11 +36,20 @@ func (ro *resourceOpener) OpenResource(name string) (resource.Opened, error) {
 		Channel: svc.Channel(),
 	}
 
-	csOpener := newCharmstoreOpener(ro.st)
-	client, err := csOpener.NewClient()
+	controllerConfig, err := ro.st.ControllerConfig()
 	if err != nil {
 		return resource.Opened{}, errors.Trace(err)
 	}
+	var client charmstore.StoreResourceGetter
+	if controllerConfig.CharmStoreMirror() {
+		client = &charmMirror{ro.st}
+	} else {
+		csOpener := newCharmstoreOpener(ro.st)
+		client, err = csOpener.NewClient()
+		if err != nil {
+			return resource.Opened{}, errors.Trace(err)
+		}
+	}
 
 	cache := &charmstoreEntityCache{
 		st:            ro.res,
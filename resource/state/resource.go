@@ -56,6 +56,14 @@ type resourcePersistence interface {
 	// NewResolvePendingResourceOps generates mongo transaction operations
 	// to set the identified resource as active.
 	NewResolvePendingResourceOps(resID, pendingID string) ([]txn.Op, error)
+
+	// ListResourceHistory returns the history of each of the
+	// application's resources.
+	ListResourceHistory(applicationID string) ([]resource.HistoryEntry, error)
+
+	// RollbackResource makes the identified revision in the history
+	// of the resource the active resource.
+	RollbackResource(id string, revision int) error
 }
 
 // StagedResource represents resource info that has been added to the
@@ -169,6 +177,34 @@ func (st resourceState) GetPendingResource(applicationID, name, pendingID string
 	return res, errors.NotFoundf("pending resource %q (%s)", name, pendingID)
 }
 
+// ListResourceHistory returns the history of each of the application's
+// resources, sorted by resource name and revision.
+func (st resourceState) ListResourceHistory(applicationID string) ([]resource.HistoryEntry, error) {
+	history, err := st.persist.ListResourceHistory(applicationID)
+	if err != nil {
+		if err := st.raw.VerifyService(applicationID); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return nil, errors.Trace(err)
+	}
+	return history, nil
+}
+
+// RollbackResource makes the identified revision in the history of the
+// application's resource the active resource. As with a new upload,
+// this triggers a charm upgrade on the application's units.
+func (st resourceState) RollbackResource(applicationID, name string, revision int) error {
+	logger.Tracef("rolling back resource %q for application %q to revision %d", name, applicationID, revision)
+	id := newResourceID(applicationID, name)
+	if err := st.persist.RollbackResource(id, revision); err != nil {
+		if err := st.raw.VerifyService(applicationID); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 // TODO(ericsnow) Separate setting the metadata from storing the blob?
 
 // SetResource stores the resource in the Juju model.
@@ -247,7 +283,17 @@ func (st resourceState) storeResource(res resource.Resource, r io.Reader) error
 	// is stored separately and adding to both should be an atomic
 	// operation.
 
-	storagePath := storagePath(res.Name, res.ApplicationID, res.PendingID)
+	// Each upload is stored at its own path, so that earlier uploads
+	// remain available in the resource's history.
+	uniqueID := res.PendingID
+	if uniqueID == "" {
+		var err error
+		uniqueID, err = st.newPendingID()
+		if err != nil {
+			return errors.Annotate(err, "could not generate storage ID")
+		}
+	}
+	storagePath := storagePath(res.Name, res.ApplicationID, uniqueID)
 	staged, err := st.persist.StageResource(res, storagePath)
 	if err != nil {
 		return errors.Trace(err)
@@ -406,12 +452,12 @@ func newResourceID(applicationID, name string) string {
 // be unique and that it be organized in a structured way. In this case
 // we start with a top-level (the application), then under that application use
 // the "resources" section. The provided ID is located under there.
-func storagePath(name, applicationID, pendingID string) string {
+func storagePath(name, applicationID, uniqueID string) string {
 	// TODO(ericsnow) Use applications/<application>/resources/<resource>?
 	id := name
-	if pendingID != "" {
+	if uniqueID != "" {
 		// TODO(ericsnow) How to resolve this later?
-		id += "-" + pendingID
+		id += "-" + uniqueID
 	}
 	return path.Join("application-"+applicationID, "resources", id)
 }
@@ -66,6 +66,7 @@ type stubPersistence struct {
 	ReturnGetResourcePath              string
 	ReturnStageResource                *stubStagedResource
 	ReturnNewResolvePendingResourceOps [][]txn.Op
+	ReturnListResourceHistory          []resource.HistoryEntry
 
 	CallsForNewResolvePendingResourceOps map[string]string
 }
@@ -142,6 +143,24 @@ func (s *stubPersistence) SetUnitResourceProgress(unitID string, res resource.Re
 	return nil
 }
 
+func (s *stubPersistence) ListResourceHistory(applicationID string) ([]resource.HistoryEntry, error) {
+	s.stub.AddCall("ListResourceHistory", applicationID)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.ReturnListResourceHistory, nil
+}
+
+func (s *stubPersistence) RollbackResource(id string, revision int) error {
+	s.stub.AddCall("RollbackResource", id, revision)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
 func (s *stubPersistence) NewResolvePendingResourceOps(resID, pendingID string) ([]txn.Op, error) {
 	s.stub.AddCall("NewResolvePendingResourceOps", resID, pendingID)
 	if err := s.stub.NextErr(); err != nil {
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/shell"
+	"github.com/juju/utils/symlink"
 
 	"github.com/juju/juju/service/common"
 )
@@ -512,6 +513,60 @@ func (s *Service) writeConf() (string, error) {
 	return filename, nil
 }
 
+// WriteService writes the service conf and links it into systemd's unit
+// directories directly, without going through dbus. This allows the
+// service to be installed on a machine that is not yet running systemd,
+// such as one that is about to be upgraded to a systemd-based series;
+// systemd will start it on the next boot.
+func (s *Service) WriteService() error {
+	if s.NoConf() {
+		return s.errorf(nil, "missing conf")
+	}
+
+	filename, err := s.writeConf()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, dirname := range []string{systemUnitDir, path.Join(systemUnitDir, wantedByTarget+".wants")} {
+		if err := mkdirAll(dirname); err != nil {
+			return s.errorf(err, "failed to create systemd dir %q", dirname)
+		}
+		link := path.Join(dirname, s.UnitName)
+		if err := symlinkFile(filename, link); err != nil {
+			return s.errorf(err, "failed to link conf file %q to %q", filename, link)
+		}
+	}
+	return nil
+}
+
+// RemoveService removes the links and conf written by WriteService,
+// again without going through dbus.
+func (s *Service) RemoveService() error {
+	for _, dirname := range []string{systemUnitDir, path.Join(systemUnitDir, wantedByTarget+".wants")} {
+		link := path.Join(dirname, s.UnitName)
+		if err := removeAll(link); err != nil {
+			return s.errorf(err, "failed to remove link %q", link)
+		}
+	}
+	if err := removeAll(s.Dirname); err != nil {
+		return s.errorf(err, "failed to delete juju-managed conf dir")
+	}
+	return nil
+}
+
+const (
+	// systemUnitDir is where systemd looks for locally installed units.
+	systemUnitDir = "/etc/systemd/system"
+
+	// wantedByTarget is the target that juju's services are enabled for.
+	wantedByTarget = "multi-user.target"
+)
+
+var symlinkFile = func(oldname, newname string) error {
+	return symlink.Replace(newname, oldname)
+}
+
 var mkdirAll = func(dirname string) error {
 	return os.MkdirAll(dirname, 0755)
 }
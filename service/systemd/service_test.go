@@ -673,6 +673,50 @@ func (s *initSystemSuite) TestInstall(c *gc.C) {
 	s.checkCreateFileCall(c, 2, filename, s.newConfStr(s.name), 0644)
 }
 
+func (s *initSystemSuite) TestWriteService(c *gc.C) {
+	err := s.service.WriteService()
+	c.Assert(err, jc.ErrorIsNil)
+
+	dirname := fmt.Sprintf("%s/init/%s", s.dataDir, s.name)
+	filename := fmt.Sprintf("%s/%s.service", dirname, s.name)
+	createFileOutput := s.stub.Calls()[1].Args[1] // gross
+	s.stub.CheckCalls(c, []testing.StubCall{{
+		FuncName: "MkdirAll",
+		Args:     []interface{}{dirname},
+	}, {
+		FuncName: "CreateFile",
+		Args:     []interface{}{filename, createFileOutput, os.FileMode(0644)},
+	}, {
+		FuncName: "MkdirAll",
+		Args:     []interface{}{"/etc/systemd/system"},
+	}, {
+		FuncName: "Symlink",
+		Args:     []interface{}{filename, "/etc/systemd/system/" + s.name + ".service"},
+	}, {
+		FuncName: "MkdirAll",
+		Args:     []interface{}{"/etc/systemd/system/multi-user.target.wants"},
+	}, {
+		FuncName: "Symlink",
+		Args:     []interface{}{filename, "/etc/systemd/system/multi-user.target.wants/" + s.name + ".service"},
+	}})
+}
+
+func (s *initSystemSuite) TestRemoveService(c *gc.C) {
+	err := s.service.RemoveService()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCalls(c, []testing.StubCall{{
+		FuncName: "RemoveAll",
+		Args:     []interface{}{"/etc/systemd/system/" + s.name + ".service"},
+	}, {
+		FuncName: "RemoveAll",
+		Args:     []interface{}{"/etc/systemd/system/multi-user.target.wants/" + s.name + ".service"},
+	}, {
+		FuncName: "RemoveAll",
+		Args:     []interface{}{fmt.Sprintf("%s/init/%s", s.dataDir, s.name)},
+	}})
+}
+
 func (s *initSystemSuite) TestInstallAlreadyInstalled(c *gc.C) {
 	s.addService("jujud-machine-0", "inactive")
 	s.addListResponse()
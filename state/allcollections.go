@@ -99,6 +99,17 @@ func allCollections() collectionSchema {
 		// This collection holds Juju GUI current version and other settings.
 		guisettingsC: {global: true},
 
+		// These collections record the charm store charms and resources
+		// imported into the controller's charm mirror with sync-charms,
+		// which is shared by all models.
+		mirroredCharmsC: {
+			global: true,
+			indexes: []mgo.Index{{
+				Key: []string{"name"},
+			}},
+		},
+		mirroredResourcesC: {global: true},
+
 		// This collection holds model information; in particular its
 		// Life and its UUID.
 		modelsC: {global: true},
@@ -124,6 +135,14 @@ func allCollections() collectionSchema {
 		// one model migration document exists per environment.
 		migrationsActiveC: {global: true},
 
+		// This collection records the reports made by the agents of
+		// a migrating model as they complete each migration phase.
+		migrationsMinionSyncC: {global: true},
+
+		// This collection records where models which have been
+		// migrated away from this controller can now be found.
+		modelRedirectsC: {global: true},
+
 		// This collection holds user information that's not specific to any
 		// one model.
 		usersC: {
@@ -223,6 +242,7 @@ func allCollections() collectionSchema {
 		},
 		minUnitsC: {},
 
+
 		// This collection holds documents that indicate units which are queued
 		// to be assigned to machines. It is used exclusively by the
 		// AssignUnitWorker.
@@ -249,6 +269,10 @@ func allCollections() collectionSchema {
 		rebootC:        {},
 		sshHostKeysC:   {},
 
+		// This collection holds the locks taken while a machine's
+		// series is upgraded in place.
+		upgradeSeriesLocksC: {},
+
 		// -----
 
 		// These collections hold information associated with storage.
@@ -386,11 +410,15 @@ const (
 	minUnitsC                = "minunits"
 	migrationsStatusC        = "migrations.status"
 	migrationsActiveC        = "migrations.active"
+	migrationsMinionSyncC    = "migrations.minionsync"
 	migrationsC              = "migrations"
+	mirroredCharmsC          = "mirroredCharms"
+	mirroredResourcesC       = "mirroredResources"
 	modelUserLastConnectionC = "modelUserLastConnection"
 	modelUsersC              = "modelusers"
 	modelsC                  = "models"
 	modelEntityRefsC         = "modelEntityRefs"
+	modelRedirectsC          = "modelredirects"
 	openedPortsC             = "openedPorts"
 	providerIDsC             = "providerIDs"
 	rebootC                  = "reboot"
@@ -418,6 +446,7 @@ const (
 	txnsC                    = "txns"
 	unitsC                   = "units"
 	upgradeInfoC             = "upgradeInfo"
+	upgradeSeriesLocksC      = "upgradeSeriesLocks"
 	userLastLoginC           = "userLastLogin"
 	usermodelnameC           = "usermodelname"
 	usersC                   = "users"
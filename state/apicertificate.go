@@ -0,0 +1,102 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/cert"
+)
+
+// apiCertificateKey is the key for the document holding the custom
+// certificate served to clients of the controller API.
+const apiCertificateKey = "apiCertificate"
+
+type apiCertificateDoc struct {
+	DocID      string `bson:"_id"`
+	Cert       string `bson:"cert"`
+	PrivateKey string `bson:"private-key"`
+}
+
+// SetAPICertificate records the PEM-encoded certificate chain and
+// private key that the API servers present to clients, in place of
+// the certificate signed by the controller CA. Agents continue to
+// be served the controller's own certificate. Setting an empty
+// certificate and key removes any custom certificate.
+func (st *State) SetAPICertificate(certPEM, keyPEM string) error {
+	if certPEM == "" && keyPEM == "" {
+		return st.removeAPICertificate()
+	}
+	if _, _, err := cert.ParseCertAndKey(certPEM, keyPEM); err != nil {
+		return errors.NewNotValid(err, "API certificate")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		_, _, err := st.APICertificate()
+		if errors.IsNotFound(err) {
+			return []txn.Op{{
+				C:      controllersC,
+				Id:     apiCertificateKey,
+				Assert: txn.DocMissing,
+				Insert: &apiCertificateDoc{
+					DocID:      apiCertificateKey,
+					Cert:       certPEM,
+					PrivateKey: keyPEM,
+				},
+			}}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     apiCertificateKey,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"cert", certPEM},
+				{"private-key", keyPEM},
+			}}},
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot set API certificate")
+	}
+	return nil
+}
+
+func (st *State) removeAPICertificate() error {
+	ops := []txn.Op{{
+		C:      controllersC,
+		Id:     apiCertificateKey,
+		Remove: true,
+	}}
+	if err := st.runTransaction(ops); err != nil {
+		return errors.Annotate(err, "cannot remove API certificate")
+	}
+	return nil
+}
+
+// APICertificate returns the PEM-encoded certificate chain and private
+// key recorded by SetAPICertificate. If there is none, an error
+// satisfying errors.IsNotFound is returned.
+func (st *State) APICertificate() (certPEM, keyPEM string, err error) {
+	controllers, closer := st.getCollection(controllersC)
+	defer closer()
+
+	var doc apiCertificateDoc
+	err = controllers.FindId(apiCertificateKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return "", "", errors.NotFoundf("API certificate")
+	} else if err != nil {
+		return "", "", errors.Annotate(err, "cannot get API certificate")
+	}
+	return doc.Cert, doc.PrivateKey, nil
+}
+
+// WatchAPICertificate returns a NotifyWatcher that notifies when the
+// custom API certificate is set or removed.
+func (st *State) WatchAPICertificate() NotifyWatcher {
+	return newDocWatcher(st, []docKey{{controllersC, apiCertificateKey}})
+}
@@ -0,0 +1,76 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing"
+)
+
+type APICertificateSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&APICertificateSuite{})
+
+func (s *APICertificateSuite) TestNoAPICertificate(c *gc.C) {
+	_, _, err := s.State.APICertificate()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *APICertificateSuite) TestSetAPICertificate(c *gc.C) {
+	err := s.State.SetAPICertificate(testing.ServerCert, testing.ServerKey)
+	c.Assert(err, jc.ErrorIsNil)
+	certPEM, keyPEM, err := s.State.APICertificate()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(certPEM, gc.Equals, testing.ServerCert)
+	c.Assert(keyPEM, gc.Equals, testing.ServerKey)
+
+	// Setting it again replaces the existing certificate.
+	err = s.State.SetAPICertificate(testing.CACert, testing.CAKey)
+	c.Assert(err, jc.ErrorIsNil)
+	certPEM, keyPEM, err = s.State.APICertificate()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(certPEM, gc.Equals, testing.CACert)
+	c.Assert(keyPEM, gc.Equals, testing.CAKey)
+}
+
+func (s *APICertificateSuite) TestSetAPICertificateInvalid(c *gc.C) {
+	err := s.State.SetAPICertificate(testing.ServerCert, testing.CAKey)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	_, _, err = s.State.APICertificate()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *APICertificateSuite) TestRemoveAPICertificate(c *gc.C) {
+	err := s.State.SetAPICertificate(testing.ServerCert, testing.ServerKey)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetAPICertificate("", "")
+	c.Assert(err, jc.ErrorIsNil)
+	_, _, err = s.State.APICertificate()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing it again is not an error.
+	err = s.State.SetAPICertificate("", "")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *APICertificateSuite) TestWatchAPICertificate(c *gc.C) {
+	w := s.State.WatchAPICertificate()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.SetAPICertificate(testing.ServerCert, testing.ServerKey)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.SetAPICertificate("", "")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 )
 
@@ -41,6 +42,7 @@ type applicationDoc struct {
 	Series               string     `bson:"series"`
 	Subordinate          bool       `bson:"subordinate"`
 	CharmURL             *charm.URL `bson:"charmurl"`
+	PreviousCharmURL     *charm.URL `bson:"previous-charmurl,omitempty"`
 	Channel              string     `bson:"cs-channel"`
 	CharmModifiedVersion int        `bson:"charmmodifiedversion"`
 	ForceCharm           bool       `bson:"forcecharm"`
@@ -48,9 +50,14 @@ type applicationDoc struct {
 	UnitCount            int        `bson:"unitcount"`
 	RelationCount        int        `bson:"relationcount"`
 	Exposed              bool       `bson:"exposed"`
+	ExposedCIDRs         []string   `bson:"exposed-cidrs,omitempty"`
 	MinUnits             int        `bson:"minunits"`
+	Trust                bool       `bson:"trust,omitempty"`
 	TxnRevno             int64      `bson:"txn-revno"`
 	MetricCredentials    []byte     `bson:"metric-credentials"`
+
+	RollingUpgrade  *rollingUpgradeDoc  `bson:"rolling-upgrade,omitempty"`
+	PlacementPolicy *placementPolicyDoc `bson:"placement-policy,omitempty"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -286,29 +293,92 @@ func (s *Application) IsExposed() bool {
 	return s.doc.Exposed
 }
 
-// SetExposed marks the application as exposed.
+// ExposedCIDRs returns the source CIDRs from which the ports of an
+// exposed application may be reached. If empty, the model's default
+// ingress CIDRs apply. See SetExposedCIDRs.
+func (s *Application) ExposedCIDRs() []string {
+	if len(s.doc.ExposedCIDRs) == 0 {
+		return nil
+	}
+	cidrs := make([]string, len(s.doc.ExposedCIDRs))
+	copy(cidrs, s.doc.ExposedCIDRs)
+	return cidrs
+}
+
+// SetExposed marks the application as exposed, to the model's default
+// ingress CIDRs.
 // See ClearExposed and IsExposed.
 func (s *Application) SetExposed() error {
-	return s.setExposed(true)
+	return s.setExposed(true, nil)
+}
+
+// SetExposedCIDRs marks the application as exposed, restricting access
+// to its opened ports to the given source CIDRs. If no CIDRs are given,
+// it behaves like SetExposed.
+// See ClearExposed and IsExposed.
+func (s *Application) SetExposedCIDRs(cidrs []string) error {
+	if err := network.ValidateCIDRs(cidrs); err != nil {
+		return errors.Annotatef(err, "cannot expose application %q", s)
+	}
+	return s.setExposed(true, cidrs)
 }
 
 // ClearExposed removes the exposed flag from the service.
 // See SetExposed and IsExposed.
 func (s *Application) ClearExposed() error {
-	return s.setExposed(false)
+	return s.setExposed(false, nil)
 }
 
-func (s *Application) setExposed(exposed bool) (err error) {
+func (s *Application) setExposed(exposed bool, cidrs []string) (err error) {
+	// Copy the CIDRs, so that the document does not share the
+	// caller's slice.
+	if len(cidrs) > 0 {
+		cidrs = append([]string(nil), cidrs...)
+	} else {
+		cidrs = nil
+	}
+	var update bson.D
+	if len(cidrs) > 0 {
+		update = bson.D{{"$set", bson.D{{"exposed", exposed}, {"exposed-cidrs", cidrs}}}}
+	} else {
+		update = bson.D{
+			{"$set", bson.D{{"exposed", exposed}}},
+			{"$unset", bson.D{{"exposed-cidrs", nil}}},
+		}
+	}
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     s.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: update,
 	}}
 	if err := s.st.runTransaction(ops); err != nil {
 		return fmt.Errorf("cannot set exposed flag for application %q to %v: %v", s, exposed, onAbort(err, errNotAlive))
 	}
 	s.doc.Exposed = exposed
+	s.doc.ExposedCIDRs = cidrs
+	return nil
+}
+
+// IsTrusted returns whether the application's units may access the
+// model's cloud credential.
+func (s *Application) IsTrusted() bool {
+	return s.doc.Trust
+}
+
+// SetTrust sets whether the application's units may access the model's
+// cloud credential.
+func (s *Application) SetTrust(trust bool) error {
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     s.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"trust", trust}}}},
+	}}
+	if err := s.st.runTransaction(ops); err != nil {
+		return errors.Annotatef(onAbort(err, errNotAlive), "cannot set trust for application %q to %v", s, trust)
+	}
+	s.doc.Trust = trust
 	return nil
 }
 
@@ -342,6 +412,38 @@ func (s *Application) CharmURL() (curl *charm.URL, force bool) {
 	return s.doc.CharmURL, s.doc.ForceCharm
 }
 
+// PreviousCharmURL returns the URL of the charm the application ran
+// before its charm was last changed, or nil if it has never changed.
+func (s *Application) PreviousCharmURL() *charm.URL {
+	return s.doc.PreviousCharmURL
+}
+
+// RollbackCharm changes the application's charm back to the one it ran
+// before its charm was last changed; its units run the upgrade-charm hook
+// as for any other upgrade. The previous charm's archive remains stored
+// in the model, so this works for local charms whose source has since
+// changed. Rolling back twice returns to the charm in use before the
+// first rollback. If forceUnits is true, units in an error state are
+// rolled back too.
+func (s *Application) RollbackCharm(forceUnits bool) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot roll back charm of application %q", s)
+	previous := s.doc.PreviousCharmURL
+	if previous == nil {
+		return errors.NotFoundf("previous charm")
+	}
+	ch, err := s.st.Charm(previous)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.SetCharm(SetCharmConfig{
+		Charm:   ch,
+		Channel: s.Channel(),
+		// The previous charm already ran on the application's series.
+		ForceSeries: true,
+		ForceUnits:  forceUnits,
+	}))
+}
+
 // Channel identifies the charm store channel from which the service's
 // charm was deployed. It is only needed when interacting with the charm
 // store.
@@ -580,6 +682,7 @@ func (s *Application) changeCharmOps(ch *Charm, channel string, forceUnits bool,
 			Assert: append(notDeadDoc, differentCharm...),
 			Update: bson.D{{"$set", bson.D{
 				{"charmurl", ch.URL()},
+				{"previous-charmurl", s.doc.CharmURL},
 				{"cs-channel", channel},
 				{"forcecharm", forceUnits},
 			}}},
@@ -685,6 +788,13 @@ type SetCharmConfig struct {
 	// ResourceIDs is a map of resource names to resource IDs to activate during
 	// the upgrade.
 	ResourceIDs map[string]string `json:"resourceids"`
+	// BatchSize, if non-zero, causes the upgrade to be applied to at
+	// most this many units at a time. Each batch must return to an
+	// active and idle state before the next one is upgraded.
+	BatchSize int `json:"batchsize"`
+	// BatchTimeout, if non-zero, is how long each batch of units may
+	// take to settle before a rolling upgrade is halted.
+	BatchTimeout time.Duration `json:"batchtimeout"`
 }
 
 // SetCharm changes the charm for the application. New units will be started with
@@ -751,6 +861,10 @@ func (s *Application) SetCharm(cfg SetCharmConfig) error {
 	// this value holds the *previous* charm modified version, before this
 	// transaction commits.
 	var charmModifiedVersion int
+	// these values hold the rolling upgrade recorded by the transaction,
+	// which only changes along with the charm URL.
+	var charmChanged bool
+	var rollingUpgrade *rollingUpgradeDoc
 	channel := string(cfg.Channel)
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		if attempt > 0 {
@@ -799,6 +913,7 @@ func (s *Application) SetCharm(cfg SetCharmConfig) error {
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		charmChanged, rollingUpgrade = false, nil
 		if count > 0 {
 			// Charm URL already set; just update the force flag and channel.
 			sameCharm := bson.D{{"charmurl", cfg.Charm.URL()}}
@@ -818,12 +933,22 @@ func (s *Application) SetCharm(cfg SetCharmConfig) error {
 				return nil, errors.Trace(err)
 			}
 			ops = append(ops, chng...)
+			rollingOp, rolling, err := s.setRollingUpgradeOp(cfg.BatchSize, cfg.BatchTimeout)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, rollingOp)
+			charmChanged, rollingUpgrade = true, rolling
 		}
 
 		return ops, nil
 	}
 	err := s.st.run(buildTxn)
 	if err == nil {
+		if charmChanged {
+			s.doc.PreviousCharmURL = s.doc.CharmURL
+			s.doc.RollingUpgrade = rollingUpgrade
+		}
 		s.doc.CharmURL = cfg.Charm.URL()
 		s.doc.Channel = channel
 		s.doc.ForceCharm = cfg.ForceUnits
@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage/provider"
 	"github.com/juju/juju/storage/provider/registry"
+	"github.com/juju/juju/testing/factory"
 )
 
 type ServiceSuite struct {
@@ -75,6 +76,35 @@ func (s *ServiceSuite) TestSetCharm(c *gc.C) {
 	c.Assert(force, jc.IsTrue)
 }
 
+func (s *ServiceSuite) TestRollbackCharm(c *gc.C) {
+	c.Assert(s.mysql.PreviousCharmURL(), gc.IsNil)
+	err := s.mysql.RollbackCharm(false)
+	c.Assert(err, gc.ErrorMatches, `cannot roll back charm of application "mysql": previous charm not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	sch := s.AddMetaCharm(c, "mysql", metaBase, 2)
+	err = s.mysql.SetCharm(state.SetCharmConfig{Charm: sch})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.PreviousCharmURL(), gc.DeepEquals, s.charm.URL())
+
+	err = s.mysql.RollbackCharm(true)
+	c.Assert(err, jc.ErrorIsNil)
+	url, force := s.mysql.CharmURL()
+	c.Assert(url, gc.DeepEquals, s.charm.URL())
+	c.Assert(force, jc.IsTrue)
+	c.Assert(s.mysql.PreviousCharmURL(), gc.DeepEquals, sch.URL())
+
+	// Rolling back again returns to the newer charm.
+	err = s.mysql.RollbackCharm(false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	url, force = s.mysql.CharmURL()
+	c.Assert(url, gc.DeepEquals, sch.URL())
+	c.Assert(force, jc.IsFalse)
+	c.Assert(s.mysql.PreviousCharmURL(), gc.DeepEquals, s.charm.URL())
+}
+
 func (s *ServiceSuite) TestSetCharmLegacy(c *gc.C) {
 	chDifferentSeries := state.AddTestingCharmForSeries(c, s.State, "precise", "mysql")
 
@@ -1298,6 +1328,66 @@ func (s *ServiceSuite) TestServiceRefresh(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *ServiceSuite) TestServiceTrust(c *gc.C) {
+	c.Assert(s.mysql.IsTrusted(), jc.IsFalse)
+
+	err := s.mysql.SetTrust(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsTrusted(), jc.IsTrue)
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsTrusted(), jc.IsTrue)
+
+	err = s.mysql.SetTrust(false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsTrusted(), jc.IsFalse)
+
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetTrust(true)
+	c.Assert(err, gc.ErrorMatches, `cannot set trust for application "mysql" to true: .*`)
+}
+
+func (s *ServiceSuite) TestServiceExposedCIDRs(c *gc.C) {
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+
+	err := s.mysql.SetExposedCIDRs([]string{"10.0.0.0/8", "192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+
+	// Exposing without CIDRs opens the ports to the model default again.
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+
+	err = s.mysql.SetExposedCIDRs([]string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+
+	err = s.mysql.SetExposedCIDRs([]string{"10.0.0.1"})
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "mysql": CIDR "10.0.0.1" not valid`)
+}
+
+func (s *ServiceSuite) TestServiceExposedCIDRsCopied(c *gc.C) {
+	cidrs := []string{"10.0.0.0/8"}
+	err := s.mysql.SetExposedCIDRs(cidrs)
+	c.Assert(err, jc.ErrorIsNil)
+	cidrs[0] = "192.168.1.0/24"
+	c.Assert(s.mysql.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
+}
+
 func (s *ServiceSuite) TestServiceExposed(c *gc.C) {
 	// Check that querying for the exposed flag works correctly.
 	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
@@ -2073,6 +2163,65 @@ func (s *ServiceSuite) TestWatchService(c *gc.C) {
 	testing.NewNotifyWatcherC(c, s.State, w).AssertOneChange()
 }
 
+func (s *ServiceSuite) TestWatchMachineCharmProfiles(c *gc.C) {
+	unit, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchMachineCharmProfiles()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange(machine.Id())
+	wc.AssertNoChange()
+
+	// Other changes to the application are ignored.
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Upgrading the charm reports the machines hosting its units.
+	newCharm := s.AddConfigCharm(c, "mysql", stringConfig, 2)
+	err = s.mysql.SetCharm(state.SetCharmConfig{Charm: newCharm})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(machine.Id())
+	wc.AssertNoChange()
+
+	// Assigning a unit to a machine reports the machine.
+	unit2, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+	machine2, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit2.AssignToMachine(machine2)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(machine2.Id())
+	wc.AssertNoChange()
+
+	// So does removing it.
+	err = unit2.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+	err = unit2.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(machine2.Id())
+	wc.AssertNoChange()
+
+	// Applications in other models are ignored.
+	otherState := s.Factory.MakeModel(c, nil)
+	defer otherState.Close()
+	f := factory.NewFactory(otherState)
+	f.MakeUnit(c, nil)
+	wc.AssertNoChange()
+
+	testing.AssertStop(c, w)
+	wc.AssertClosed()
+}
+
 func (s *ServiceSuite) TestMetricCredentials(c *gc.C) {
 	err := s.mysql.SetMetricCredentials([]byte("hello there"))
 	c.Assert(err, jc.ErrorIsNil)
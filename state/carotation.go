@@ -0,0 +1,297 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	jujucontroller "github.com/juju/juju/controller"
+)
+
+// caRotationKey is the key for the document recording a controller
+// CA rotation in progress.
+const caRotationKey = "caRotation"
+
+// CARotation holds the details of a rotation of the controller CA
+// certificate that has been started but not yet finished.
+//
+// While a rotation is in progress, both the old and the new CA
+// certificates are trusted, so that agents and clients can learn of
+// the new CA before the controllers' API server and MongoDB
+// certificates are reissued with it. The old CA stays trusted until
+// every controller has reissued its certificates.
+type CARotation struct {
+	// NewCACert is the PEM-encoded CA certificate that replaces the
+	// old one.
+	NewCACert string
+
+	// Started records when the rotation was started.
+	Started time.Time
+
+	// Finishing records whether the new CA has been made the
+	// controller CA, and the controllers are reissuing their
+	// certificates with it.
+	Finishing bool
+
+	// Reissued holds the ids of the controller machines that have
+	// reissued their certificates with the new CA.
+	Reissued []string
+}
+
+type caRotationDoc struct {
+	DocID           string    `bson:"_id"`
+	NewCACert       string    `bson:"new-ca-cert"`
+	NewCAPrivateKey string    `bson:"new-ca-private-key"`
+	OldCACert       string    `bson:"old-ca-cert,omitempty"`
+	Started         time.Time `bson:"started"`
+	Finishing       bool      `bson:"finishing"`
+	Reissued        []string  `bson:"reissued,omitempty"`
+}
+
+// StartCARotation records the given CA certificate and private key as
+// the replacement for the current controller CA. The new CA is trusted
+// alongside the current one until FinishCARotation is called. It is an
+// error to start a rotation while another is in progress.
+func (st *State) StartCARotation(caCert, caPrivateKey string) error {
+	if caCert == "" || caPrivateKey == "" {
+		return errors.NotValidf("empty CA certificate or private key")
+	}
+	ops := []txn.Op{{
+		C:      controllersC,
+		Id:     caRotationKey,
+		Assert: txn.DocMissing,
+		Insert: &caRotationDoc{
+			DocID:           caRotationKey,
+			NewCACert:       caCert,
+			NewCAPrivateKey: caPrivateKey,
+			Started:         nowToTheSecond(),
+		},
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.AlreadyExistsf("CA rotation")
+	} else if err != nil {
+		return errors.Annotate(err, "cannot start CA rotation")
+	}
+	return nil
+}
+
+// CARotation returns the details of the controller CA rotation in
+// progress. If there is none, an error satisfying errors.IsNotFound is
+// returned.
+func (st *State) CARotation() (*CARotation, error) {
+	doc, err := st.caRotationDoc()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &CARotation{
+		NewCACert: doc.NewCACert,
+		Started:   doc.Started,
+		Finishing: doc.Finishing,
+		Reissued:  doc.Reissued,
+	}, nil
+}
+
+func (st *State) caRotationDoc() (*caRotationDoc, error) {
+	controllers, closer := st.getCollection(controllersC)
+	defer closer()
+
+	var doc caRotationDoc
+	err := controllers.FindId(caRotationKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("CA rotation")
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot get CA rotation")
+	}
+	return &doc, nil
+}
+
+// FinishCARotation makes the CA recorded by StartCARotation the
+// controller CA. Controllers then reissue their API server and MongoDB
+// certificates with it, restarting MongoDB so that it serves the new
+// certificate, and record that they have done so with SetCAReissued.
+// The old CA is trusted until FinishCARotation is called again once
+// every controller has reissued its certificates, which completes the
+// rotation; until then, calling it again fails. If there are no
+// controller machines, the rotation is completed immediately.
+func (st *State) FinishCARotation() error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, err := st.caRotationDoc()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		waiting, assertControllersOp, err := st.caReissueWaiting(doc.Reissued)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if doc.Finishing {
+			if len(waiting) > 0 {
+				return nil, errors.Errorf(
+					"controller machines %s have not yet reissued their certificates",
+					strings.Join(waiting, ", "),
+				)
+			}
+			return []txn.Op{assertControllersOp, completeCARotationOp(doc)}, nil
+		}
+
+		cfg, err := st.ControllerConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		oldCACert, ok := cfg.CACert()
+		if !ok {
+			return nil, errors.New("controller config has no CA certificate")
+		}
+		ops := []txn.Op{{
+			C:      controllersC,
+			Id:     controllerSettingsGlobalKey,
+			Assert: txn.DocExists,
+			Update: setUnsetUpdateSettings(bson.M{
+				jujucontroller.CACertKey: doc.NewCACert,
+			}, nil),
+		}, {
+			C:      controllersC,
+			Id:     stateServingInfoKey,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"caprivatekey", doc.NewCAPrivateKey}}}},
+		}, assertControllersOp}
+		if len(waiting) == 0 {
+			// There are no controllers to reissue certificates,
+			// so the rotation is complete.
+			return append(ops, completeCARotationOp(doc)), nil
+		}
+		return append(ops, txn.Op{
+			C:      controllersC,
+			Id:     caRotationKey,
+			Assert: bson.D{{"new-ca-cert", doc.NewCACert}, {"finishing", false}},
+			Update: bson.D{{"$set", bson.D{
+				{"old-ca-cert", oldCACert},
+				{"finishing", true},
+			}}},
+		}), nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot finish CA rotation")
+	}
+	return nil
+}
+
+// SetCAReissued records that the given controller machine has reissued
+// its API server and MongoDB certificates with the new CA of a
+// rotation being finished, and is serving them. It is not an error to
+// record a machine more than once, or after the rotation is complete.
+func (st *State) SetCAReissued(machineId string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, err := st.caRotationDoc()
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !doc.Finishing {
+			return nil, errors.New("CA rotation is not being finished")
+		}
+		if set.NewStrings(doc.Reissued...).Contains(machineId) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     caRotationKey,
+			Assert: bson.D{{"new-ca-cert", doc.NewCACert}, {"finishing", true}},
+			Update: bson.D{{"$addToSet", bson.D{{"reissued", machineId}}}},
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot record reissued certificates of machine %q", machineId)
+	}
+	return nil
+}
+
+// caReissueWaiting returns the ids of the controller machines that are
+// not among those given as having reissued their certificates, along
+// with an operation asserting that the controller machines do not
+// change.
+func (st *State) caReissueWaiting(reissuedIds []string) ([]string, txn.Op, error) {
+	info, err := st.ControllerInfo()
+	if err != nil {
+		return nil, txn.Op{}, errors.Trace(err)
+	}
+	reissued := set.NewStrings(reissuedIds...)
+	var waiting []string
+	for _, id := range info.MachineIds {
+		if !reissued.Contains(id) {
+			waiting = append(waiting, id)
+		}
+	}
+	return waiting, txn.Op{
+		C:      controllersC,
+		Id:     modelGlobalKey,
+		Assert: bson.D{{"machineids", info.MachineIds}},
+	}, nil
+}
+
+// completeCARotationOp returns the operation that completes the given
+// rotation, so that only the new CA is trusted.
+func completeCARotationOp(doc *caRotationDoc) txn.Op {
+	return txn.Op{
+		C:      controllersC,
+		Id:     caRotationKey,
+		Assert: bson.D{{"new-ca-cert", doc.NewCACert}},
+		Remove: true,
+	}
+}
+
+// TrustedCACert returns the PEM-encoded CA certificates that agents
+// and clients should trust when connecting to the controller: the
+// controller CA, followed by the other CA of a rotation in progress.
+func (st *State) TrustedCACert() (string, error) {
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	caCert, ok := cfg.CACert()
+	if !ok {
+		return "", errors.New("controller config has no CA certificate")
+	}
+	doc, err := st.caRotationDoc()
+	if errors.IsNotFound(err) {
+		return caCert, nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	if doc.Finishing {
+		return joinPEM(caCert, doc.OldCACert), nil
+	}
+	return joinPEM(caCert, doc.NewCACert), nil
+}
+
+// joinPEM concatenates the given PEM-encoded blocks, making sure each
+// starts on a line of its own.
+func joinPEM(blocks ...string) string {
+	var result string
+	for _, block := range blocks {
+		if result != "" && result[len(result)-1] != '\n' {
+			result += "\n"
+		}
+		result += block
+	}
+	return result
+}
+
+// WatchCACert returns a NotifyWatcher that notifies when the CA
+// certificates trusted by the controller may have changed, that is
+// when a CA rotation is started, finished or completed.
+func (st *State) WatchCACert() NotifyWatcher {
+	return newDocWatcher(st, []docKey{
+		{controllersC, controllerSettingsGlobalKey},
+		{controllersC, caRotationKey},
+	})
+}
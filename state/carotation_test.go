@@ -0,0 +1,163 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing"
+)
+
+type CARotationSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&CARotationSuite{})
+
+func (s *CARotationSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	err := s.State.SetStateServingInfo(state.StateServingInfo{
+		APIPort:      1234,
+		StatePort:    2345,
+		Cert:         testing.ServerCert,
+		PrivateKey:   testing.ServerKey,
+		CAPrivateKey: testing.CAKey,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CARotationSuite) TestNoRotation(c *gc.C) {
+	_, err := s.State.CARotation()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	trusted, err := s.State.TrustedCACert()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(trusted, gc.Equals, testing.CACert)
+
+	err = s.State.FinishCARotation()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CARotationSuite) TestStartCARotation(c *gc.C) {
+	err := s.State.StartCARotation(testing.OtherCACert, testing.OtherCAKey)
+	c.Assert(err, jc.ErrorIsNil)
+
+	rotation, err := s.State.CARotation()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rotation.NewCACert, gc.Equals, testing.OtherCACert)
+	c.Assert(rotation.Started.IsZero(), jc.IsFalse)
+
+	trusted, err := s.State.TrustedCACert()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.HasPrefix(trusted, testing.CACert), jc.IsTrue)
+	c.Assert(strings.HasSuffix(trusted, testing.OtherCACert), jc.IsTrue)
+
+	// The controller CA is unchanged until the rotation is finished.
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	caCert, _ := cfg.CACert()
+	c.Assert(caCert, gc.Equals, testing.CACert)
+
+	err = s.State.StartCARotation(testing.OtherCACert, testing.OtherCAKey)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *CARotationSuite) TestStartCARotationInvalid(c *gc.C) {
+	err := s.State.StartCARotation(testing.OtherCACert, "")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *CARotationSuite) TestFinishCARotation(c *gc.C) {
+	err := s.State.StartCARotation(testing.OtherCACert, testing.OtherCAKey)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.FinishCARotation()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.CARotation()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	caCert, _ := cfg.CACert()
+	c.Assert(caCert, gc.Equals, testing.OtherCACert)
+
+	info, err := s.State.StateServingInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.CAPrivateKey, gc.Equals, testing.OtherCAKey)
+
+	trusted, err := s.State.TrustedCACert()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(trusted, gc.Equals, testing.OtherCACert)
+}
+
+func (s *CARotationSuite) TestFinishCARotationWaitsForControllers(c *gc.C) {
+	m0, err := s.State.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.StartCARotation(testing.OtherCACert, testing.OtherCAKey)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.FinishCARotation()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The new CA is the controller CA, but the old one is trusted
+	// until the controller has reissued its certificates.
+	rotation, err := s.State.CARotation()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rotation.Finishing, jc.IsTrue)
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	caCert, _ := cfg.CACert()
+	c.Assert(caCert, gc.Equals, testing.OtherCACert)
+	trusted, err := s.State.TrustedCACert()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.HasPrefix(trusted, testing.OtherCACert), jc.IsTrue)
+	c.Assert(strings.HasSuffix(trusted, testing.CACert), jc.IsTrue)
+
+	err = s.State.FinishCARotation()
+	c.Assert(err, gc.ErrorMatches, `cannot finish CA rotation: controller machines 0 have not yet reissued their certificates`)
+
+	err = s.State.SetCAReissued(m0.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	// Recording a controller again is harmless.
+	err = s.State.SetCAReissued(m0.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	rotation, err = s.State.CARotation()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rotation.Reissued, jc.DeepEquals, []string{m0.Id()})
+
+	err = s.State.FinishCARotation()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.CARotation()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	trusted, err = s.State.TrustedCACert()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(trusted, gc.Equals, testing.OtherCACert)
+}
+
+func (s *CARotationSuite) TestSetCAReissuedNotFinishing(c *gc.C) {
+	err := s.State.StartCARotation(testing.OtherCACert, testing.OtherCAKey)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetCAReissued("0")
+	c.Assert(err, gc.ErrorMatches, `cannot record reissued certificates of machine "0": CA rotation is not being finished`)
+}
+
+func (s *CARotationSuite) TestWatchCACert(c *gc.C) {
+	w := s.State.WatchCACert()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.StartCARotation(testing.OtherCACert, testing.OtherCAKey)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.FinishCARotation()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -17,6 +17,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state/storage"
 	jujuversion "github.com/juju/juju/version"
@@ -64,6 +65,10 @@ type charmDoc struct {
 	Actions *charm.Actions `bson:"actions"`
 	Metrics *charm.Metrics `bson:"metrics"`
 
+	// LXDProfile holds the LXD profile declared by the charm, if
+	// any. Its keys are escaped in the same way as Config's.
+	LXDProfile *lxdprofile.Profile `bson:"lxd-profile,omitempty"`
+
 	// DEPRECATED: BundleURL is deprecated, and exists here
 	// only for migration purposes. We should remove this
 	// when migrations are no longer necessary.
@@ -83,6 +88,7 @@ type CharmInfo struct {
 	StoragePath string
 	SHA256      string
 	Macaroon    macaroon.Slice
+	LXDProfile  *lxdprofile.Profile
 }
 
 // insertCharmOps returns the txn operations necessary to insert the supplied
@@ -100,6 +106,7 @@ func insertCharmOps(st *State, info CharmInfo) ([]txn.Op, error) {
 		Config:       safeConfig(info.Charm),
 		Metrics:      info.Charm.Metrics(),
 		Actions:      info.Charm.Actions(),
+		LXDProfile:   safeLXDProfile(info.LXDProfile),
 		BundleSha256: info.SHA256,
 		StoragePath:  info.StoragePath,
 	}
@@ -166,6 +173,7 @@ func updateCharmOps(
 		{"config", safeConfig(info.Charm)},
 		{"actions", info.Charm.Actions()},
 		{"metrics", info.Charm.Metrics()},
+		{"lxd-profile", safeLXDProfile(info.LXDProfile)},
 		{"storagepath", info.StoragePath},
 		{"bundlesha256", info.SHA256},
 		{"pendingupload", false},
@@ -252,6 +260,36 @@ func safeConfig(ch charm.Charm) *charm.Config {
 	return escapedConfig
 }
 
+// safeLXDProfile returns a copy of the supplied profile with any "$"
+// and "." in its config keys and device names and properties escaped.
+func safeLXDProfile(profile *lxdprofile.Profile) *lxdprofile.Profile {
+	return mapLXDProfileKeys(escapeReplacer.Replace, profile)
+}
+
+func mapLXDProfileKeys(f func(string) string, profile *lxdprofile.Profile) *lxdprofile.Profile {
+	if profile == nil {
+		return nil
+	}
+	result := &lxdprofile.Profile{Description: profile.Description}
+	if profile.Config != nil {
+		result.Config = make(map[string]string)
+		for key, value := range profile.Config {
+			result.Config[f(key)] = value
+		}
+	}
+	if profile.Devices != nil {
+		result.Devices = make(map[string]map[string]string)
+		for name, device := range profile.Devices {
+			mapped := make(map[string]string)
+			for key, value := range device {
+				mapped[f(key)] = value
+			}
+			result.Devices[f(name)] = mapped
+		}
+	}
+	return result
+}
+
 // Charm represents the state of a charm in the model.
 type Charm struct {
 	st  *State
@@ -270,6 +308,9 @@ func newCharm(st *State, cdoc *charmDoc) *Charm {
 		}
 		cdoc.Config = unescapedConfig
 	}
+	if cdoc != nil {
+		cdoc.LXDProfile = mapLXDProfileKeys(unescapeReplacer.Replace, cdoc.LXDProfile)
+	}
 	ch := Charm{st: st, doc: *cdoc}
 	return &ch
 }
@@ -328,6 +369,12 @@ func (c *Charm) Actions() *charm.Actions {
 	return c.doc.Actions
 }
 
+// LXDProfile returns the LXD profile declared by the charm, or nil
+// if it does not declare one.
+func (c *Charm) LXDProfile() *lxdprofile.Profile {
+	return c.doc.LXDProfile
+}
+
 // StoragePath returns the storage path of the charm bundle.
 func (c *Charm) StoragePath() string {
 	return c.doc.StoragePath
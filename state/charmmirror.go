@@ -0,0 +1,377 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/state/storage"
+)
+
+// mirroredCharmDoc records a charm store charm that was imported into
+// the controller's charm mirror, so that charm store URLs can be
+// resolved and charms added to models without access to the charm
+// store. The mirror is shared by all the controller's models.
+type mirroredCharmDoc struct {
+	DocID       string `bson:"_id"`
+	URL         string `bson:"url"`
+	User        string `bson:"user"`
+	Name        string `bson:"name"`
+	Series      string `bson:"series"`
+	Revision    int    `bson:"revision"`
+	SHA384      string `bson:"sha384"`
+	Size        int64  `bson:"size"`
+	StoragePath string `bson:"storage-path"`
+}
+
+// mirroredResourceDoc records the content of a charm store resource
+// that was imported into the controller's charm mirror.
+type mirroredResourceDoc struct {
+	DocID       string `bson:"_id"`
+	CharmURL    string `bson:"charm-url"`
+	Name        string `bson:"name"`
+	Type        string `bson:"type"`
+	Path        string `bson:"path"`
+	Description string `bson:"description"`
+	Revision    int    `bson:"revision"`
+	Fingerprint string `bson:"fingerprint"`
+	Size        int64  `bson:"size"`
+	StoragePath string `bson:"storage-path"`
+}
+
+// mirroredResourceID returns the id of the document recording a
+// revision of a resource of the given charm. Resource revisions are
+// independent of charm revisions, so the charm's revision is ignored.
+func mirroredResourceID(curl *charm.URL, name string, revision int) string {
+	return fmt.Sprintf("%s#%s#%d", curl.WithRevision(-1), name, revision)
+}
+
+// charmMirrorStorage returns the storage holding the content of the
+// charm mirror, which lives with the controller model's blobs.
+func (st *State) charmMirrorStorage() storage.Storage {
+	return storage.NewStorage(st.controllerTag.Id(), st.MongoSession())
+}
+
+// AddMirroredCharm stores the archive of the charm store charm with
+// the given URL, which must include a series and revision, in the
+// controller's charm mirror. The archive read from r must have the
+// given size and hex-encoded SHA384 hash, as recorded by the charm
+// store. Adding a charm that is already mirrored with the same hash is
+// not an error; adding one with a different hash is, so that mirrored
+// charms cannot be replaced.
+func (st *State) AddMirroredCharm(curl *charm.URL, sha384 string, r io.Reader, size int64) (err error) {
+	if curl.Schema != "cs" {
+		return errors.NotValidf("charm URL %q without cs schema", curl)
+	}
+	if curl.Revision < 0 || curl.Series == "" {
+		return errors.NotValidf("charm URL %q without series and revision", curl)
+	}
+	if doc, err := st.mirroredCharm(curl); err == nil {
+		if doc.SHA384 != sha384 {
+			return errors.AlreadyExistsf("charm %q with SHA384 %s in the charm mirror", curl, doc.SHA384)
+		}
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	storagePath := fmt.Sprintf("mirror/charms/%s-%s", curl, uuid)
+	hash := sha512.New384()
+	stor := st.charmMirrorStorage()
+	if err := stor.Put(storagePath, io.TeeReader(r, hash), size); err != nil {
+		return errors.Annotatef(err, "cannot store charm %q", curl)
+	}
+	defer func() {
+		if err != nil {
+			if err := stor.Remove(storagePath); err != nil {
+				logger.Errorf("cannot remove mirrored charm %q from storage: %v", storagePath, err)
+			}
+		}
+	}()
+	if got := hex.EncodeToString(hash.Sum(nil)); got != sha384 {
+		return errors.NotValidf("charm %q archive with SHA384 %s", curl, got)
+	}
+
+	ops := []txn.Op{{
+		C:      mirroredCharmsC,
+		Id:     curl.String(),
+		Assert: txn.DocMissing,
+		Insert: &mirroredCharmDoc{
+			DocID:       curl.String(),
+			URL:         curl.String(),
+			User:        curl.User,
+			Name:        curl.Name,
+			Series:      curl.Series,
+			Revision:    curl.Revision,
+			SHA384:      sha384,
+			Size:        size,
+			StoragePath: storagePath,
+		},
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.AlreadyExistsf("charm %q in the charm mirror", curl)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot add mirrored charm %q", curl)
+	}
+	return nil
+}
+
+func (st *State) mirroredCharm(curl *charm.URL) (mirroredCharmDoc, error) {
+	mirrored, closer := st.getCollection(mirroredCharmsC)
+	defer closer()
+	var doc mirroredCharmDoc
+	err := mirrored.FindId(curl.String()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return mirroredCharmDoc{}, errors.NotFoundf("charm %q in the charm mirror", curl)
+	} else if err != nil {
+		return mirroredCharmDoc{}, errors.Annotatef(err, "cannot get mirrored charm %q", curl)
+	}
+	return doc, nil
+}
+
+// OpenMirroredCharm returns the archive of the charm with the given
+// URL in the controller's charm mirror, and its hex-encoded SHA384
+// hash. If the charm is not mirrored, an error satisfying
+// errors.IsNotFound is returned.
+func (st *State) OpenMirroredCharm(curl *charm.URL) (io.ReadCloser, string, error) {
+	doc, err := st.mirroredCharm(curl)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	reader, _, err := st.charmMirrorStorage().Get(doc.StoragePath)
+	if err != nil {
+		return nil, "", errors.Annotatef(err, "cannot read mirrored charm %q", curl)
+	}
+	return reader, doc.SHA384, nil
+}
+
+// ResolveMirroredCharm resolves a charm store reference against the
+// charms in the controller's charm mirror. A reference without a
+// series is resolved to the model's default series if the mirror holds
+// the charm for it, and otherwise to the first series, in alphabetical
+// order, that it holds the charm for. A reference without a revision is
+// resolved to the latest mirrored revision. If no mirrored charm
+// matches, an error satisfying errors.IsNotFound is returned.
+func (st *State) ResolveMirroredCharm(ref *charm.URL) (*charm.URL, error) {
+	if ref.Schema != "cs" {
+		return nil, errors.NotValidf("charm URL %q without cs schema", ref)
+	}
+	query := bson.D{{"user", ref.User}, {"name", ref.Name}}
+	if ref.Series != "" {
+		query = append(query, bson.DocElem{"series", ref.Series})
+	}
+	if ref.Revision >= 0 {
+		query = append(query, bson.DocElem{"revision", ref.Revision})
+	}
+	mirrored, closer := st.getCollection(mirroredCharmsC)
+	defer closer()
+	var docs []mirroredCharmDoc
+	if err := mirrored.Find(query).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot resolve charm %q", ref)
+	}
+	if len(docs) == 0 {
+		return nil, errors.NotFoundf("charm %q in the charm mirror", ref)
+	}
+
+	series := ref.Series
+	if series == "" {
+		seriesSet := make(map[string]bool)
+		for _, doc := range docs {
+			seriesSet[doc.Series] = true
+		}
+		cfg, err := st.ModelConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if defaultSeries, ok := cfg.DefaultSeries(); ok && seriesSet[defaultSeries] {
+			series = defaultSeries
+		} else {
+			all := make([]string, 0, len(seriesSet))
+			for s := range seriesSet {
+				all = append(all, s)
+			}
+			sort.Strings(all)
+			series = all[0]
+		}
+	}
+	var latest *mirroredCharmDoc
+	for i, doc := range docs {
+		if doc.Series != series {
+			continue
+		}
+		if latest == nil || doc.Revision > latest.Revision {
+			latest = &docs[i]
+		}
+	}
+	return charm.ParseURL(latest.URL)
+}
+
+// LatestMirroredCharm returns the URL of the latest revision of the
+// given charm in the controller's charm mirror. The revision of the given
+// URL is ignored.
+func (st *State) LatestMirroredCharm(curl *charm.URL) (*charm.URL, error) {
+	return st.ResolveMirroredCharm(curl.WithRevision(-1))
+}
+
+// AddMirroredResource stores the content of a charm store resource in
+// the controller's charm mirror, for the given charm. The resource must
+// be fully specified, and the content must match its fingerprint and
+// size. Adding a resource revision that is already mirrored with the
+// same fingerprint is not an error; adding one with a different
+// fingerprint is.
+func (st *State) AddMirroredResource(curl *charm.URL, res charmresource.Resource, r io.Reader) (err error) {
+	if curl.Schema != "cs" {
+		return errors.NotValidf("charm URL %q without cs schema", curl)
+	}
+	if err := res.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if res.Origin != charmresource.OriginStore {
+		return errors.NotValidf("resource %q with origin %q", res.Name, res.Origin)
+	}
+	id := mirroredResourceID(curl, res.Name, res.Revision)
+	if existing, reader, err := st.OpenMirroredResource(curl, res.Name, res.Revision); err == nil {
+		reader.Close()
+		if existing.Fingerprint.String() != res.Fingerprint.String() {
+			return errors.AlreadyExistsf("resource %q revision %d with fingerprint %s in the charm mirror", res.Name, res.Revision, existing.Fingerprint)
+		}
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	storagePath := fmt.Sprintf("mirror/resources/%s-%s", id, uuid)
+	checker := charmresource.NewFingerprintHash()
+	stor := st.charmMirrorStorage()
+	if err := stor.Put(storagePath, io.TeeReader(r, checker), res.Size); err != nil {
+		return errors.Annotatef(err, "cannot store resource %q", res.Name)
+	}
+	defer func() {
+		if err != nil {
+			if err := stor.Remove(storagePath); err != nil {
+				logger.Errorf("cannot remove mirrored resource %q from storage: %v", storagePath, err)
+			}
+		}
+	}()
+	if fp := checker.Fingerprint(); fp.String() != res.Fingerprint.String() {
+		return errors.NotValidf("resource %q content with fingerprint %s", res.Name, fp)
+	}
+
+	ops := []txn.Op{{
+		C:      mirroredResourcesC,
+		Id:     id,
+		Assert: txn.DocMissing,
+		Insert: &mirroredResourceDoc{
+			DocID:       id,
+			CharmURL:    curl.WithRevision(-1).String(),
+			Name:        res.Name,
+			Type:        res.Type.String(),
+			Path:        res.Path,
+			Description: res.Description,
+			Revision:    res.Revision,
+			Fingerprint: res.Fingerprint.String(),
+			Size:        res.Size,
+			StoragePath: storagePath,
+		},
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.AlreadyExistsf("resource %q revision %d in the charm mirror", res.Name, res.Revision)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot add mirrored resource %q", res.Name)
+	}
+	return nil
+}
+
+// OpenMirroredResource returns the details and content of a revision
+// of a resource of the given charm in the controller's charm mirror.
+// The charm's revision is ignored. If the resource revision is not
+// mirrored, an error satisfying errors.IsNotFound is returned.
+func (st *State) OpenMirroredResource(curl *charm.URL, name string, revision int) (charmresource.Resource, io.ReadCloser, error) {
+	mirrored, closer := st.getCollection(mirroredResourcesC)
+	defer closer()
+	var doc mirroredResourceDoc
+	err := mirrored.FindId(mirroredResourceID(curl, name, revision)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return charmresource.Resource{}, nil, errors.NotFoundf("resource %q revision %d of charm %q in the charm mirror", name, revision, curl)
+	} else if err != nil {
+		return charmresource.Resource{}, nil, errors.Annotatef(err, "cannot get mirrored resource %q", name)
+	}
+	res, err := doc.resource()
+	if err != nil {
+		return charmresource.Resource{}, nil, errors.Trace(err)
+	}
+	reader, _, err := st.charmMirrorStorage().Get(doc.StoragePath)
+	if err != nil {
+		return charmresource.Resource{}, nil, errors.Annotatef(err, "cannot read mirrored resource %q", name)
+	}
+	return res, reader, nil
+}
+
+func (doc *mirroredResourceDoc) resource() (charmresource.Resource, error) {
+	resType, err := charmresource.ParseType(doc.Type)
+	if err != nil {
+		return charmresource.Resource{}, errors.Annotate(err, "got invalid data from DB")
+	}
+	fp, err := charmresource.ParseFingerprint(doc.Fingerprint)
+	if err != nil {
+		return charmresource.Resource{}, errors.Annotate(err, "got invalid data from DB")
+	}
+	return charmresource.Resource{
+		Meta: charmresource.Meta{
+			Name:        doc.Name,
+			Type:        resType,
+			Path:        doc.Path,
+			Description: doc.Description,
+		},
+		Origin:      charmresource.OriginStore,
+		Revision:    doc.Revision,
+		Fingerprint: fp,
+		Size:        doc.Size,
+	}, nil
+}
+
+// MirroredResources returns the details of the latest mirrored
+// revision of each resource of the given charm in the controller's
+// charm mirror. The charm's revision is ignored.
+func (st *State) MirroredResources(curl *charm.URL) ([]charmresource.Resource, error) {
+	mirrored, closer := st.getCollection(mirroredResourcesC)
+	defer closer()
+	var docs []mirroredResourceDoc
+	query := bson.D{{"charm-url", curl.WithRevision(-1).String()}}
+	if err := mirrored.Find(query).Sort("name", "-revision").All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get mirrored resources of charm %q", curl)
+	}
+	var resources []charmresource.Resource
+	for _, doc := range docs {
+		if n := len(resources); n > 0 && resources[n-1].Name == doc.Name {
+			continue
+		}
+		res, err := doc.resource()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		resources = append(resources, res)
+	}
+	return resources, nil
+}
@@ -0,0 +1,229 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+
+	"github.com/juju/juju/testing/factory"
+)
+
+type CharmMirrorSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&CharmMirrorSuite{})
+
+func (s *CharmMirrorSuite) addMirroredCharm(c *gc.C, url string) {
+	s.Factory.MakeMirroredCharm(c, &factory.CharmParams{Name: "mysql", URL: url})
+}
+
+func sha384(content string) string {
+	hash := sha512.Sum384([]byte(content))
+	return hex.EncodeToString(hash[:])
+}
+
+func (s *CharmMirrorSuite) TestMirroredCharm(c *gc.C) {
+	curl := charm.MustParseURL("cs:quantal/mysql-1")
+	err := s.State.AddMirroredCharm(curl, sha384("spam"), strings.NewReader("spam"), 4)
+	c.Assert(err, jc.ErrorIsNil)
+
+	reader, hash, err := s.State.OpenMirroredCharm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+	c.Assert(hash, gc.Equals, sha384("spam"))
+	data, err := ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "spam")
+
+	_, _, err = s.State.OpenMirroredCharm(curl.WithRevision(2))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CharmMirrorSuite) TestAddMirroredCharmBadContent(c *gc.C) {
+	curl := charm.MustParseURL("cs:quantal/mysql-1")
+	err := s.State.AddMirroredCharm(curl, sha384("spam"), strings.NewReader("eggs"), 4)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	_, _, err = s.State.OpenMirroredCharm(curl)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CharmMirrorSuite) TestAddMirroredCharmInvalid(c *gc.C) {
+	for _, url := range []string{"local:quantal/mysql-1", "cs:quantal/mysql", "cs:mysql-1"} {
+		err := s.State.AddMirroredCharm(charm.MustParseURL(url), sha384(""), strings.NewReader(""), 0)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *CharmMirrorSuite) TestAddMirroredCharmTwice(c *gc.C) {
+	curl := charm.MustParseURL("cs:quantal/mysql-1")
+	err := s.State.AddMirroredCharm(curl, sha384("spam"), strings.NewReader("spam"), 4)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddMirroredCharm(curl, sha384("spam"), strings.NewReader("spam"), 4)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A mirrored charm cannot be replaced with different content.
+	err = s.State.AddMirroredCharm(curl, sha384("eggs"), strings.NewReader("eggs"), 4)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	reader, hash, err := s.State.OpenMirroredCharm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	reader.Close()
+	c.Assert(hash, gc.Equals, sha384("spam"))
+}
+
+func (s *CharmMirrorSuite) TestMirrorSharedByModels(c *gc.C) {
+	s.addMirroredCharm(c, "cs:quantal/mysql-1")
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	curl, err := st.ResolveMirroredCharm(charm.MustParseURL("cs:quantal/mysql"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl.String(), gc.Equals, "cs:quantal/mysql-1")
+	reader, _, err := st.OpenMirroredCharm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	reader.Close()
+}
+
+func (s *CharmMirrorSuite) TestResolveMirroredCharm(c *gc.C) {
+	s.addMirroredCharm(c, "cs:quantal/mysql-1")
+	s.addMirroredCharm(c, "cs:quantal/mysql-3")
+	s.addMirroredCharm(c, "cs:precise/mysql-7")
+	s.addMirroredCharm(c, "cs:~bob/quantal/mysql-9")
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"default-series": "quantal",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i, test := range []struct {
+		ref      string
+		expected string
+		err      string
+	}{{
+		ref:      "cs:quantal/mysql",
+		expected: "cs:quantal/mysql-3",
+	}, {
+		ref:      "cs:quantal/mysql-1",
+		expected: "cs:quantal/mysql-1",
+	}, {
+		ref:      "cs:precise/mysql",
+		expected: "cs:precise/mysql-7",
+	}, {
+		// The model's default series is quantal.
+		ref:      "cs:mysql",
+		expected: "cs:quantal/mysql-3",
+	}, {
+		ref:      "cs:~bob/mysql",
+		expected: "cs:~bob/quantal/mysql-9",
+	}, {
+		ref: "cs:quantal/mysql-2",
+		err: `charm "cs:quantal/mysql-2" in the charm mirror not found`,
+	}, {
+		ref: "cs:trusty/wordpress",
+		err: `charm "cs:trusty/wordpress" in the charm mirror not found`,
+	}} {
+		c.Logf("test %d: %s", i, test.ref)
+		curl, err := s.State.ResolveMirroredCharm(charm.MustParseURL(test.ref))
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			c.Check(err, jc.Satisfies, errors.IsNotFound)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(curl.String(), gc.Equals, test.expected)
+	}
+
+	curl, err := s.State.LatestMirroredCharm(charm.MustParseURL("cs:quantal/mysql-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl.String(), gc.Equals, "cs:quantal/mysql-3")
+}
+
+func (s *CharmMirrorSuite) TestResolveMirroredCharmOtherSeries(c *gc.C) {
+	s.addMirroredCharm(c, "cs:trusty/mysql-2")
+	s.addMirroredCharm(c, "cs:precise/mysql-7")
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"default-series": "quantal",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	curl, err := s.State.ResolveMirroredCharm(charm.MustParseURL("cs:mysql"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl.String(), gc.Equals, "cs:precise/mysql-7")
+}
+
+func newMirroredResource(c *gc.C, content string, revision int) charmresource.Resource {
+	fp, err := charmresource.GenerateFingerprint(strings.NewReader(content))
+	c.Assert(err, jc.ErrorIsNil)
+	return charmresource.Resource{
+		Meta: charmresource.Meta{
+			Name:        "data",
+			Type:        charmresource.TypeFile,
+			Path:        "data.tgz",
+			Description: "some data",
+		},
+		Origin:      charmresource.OriginStore,
+		Revision:    revision,
+		Fingerprint: fp,
+		Size:        int64(len(content)),
+	}
+}
+
+func (s *CharmMirrorSuite) TestMirroredResource(c *gc.C) {
+	curl := charm.MustParseURL("cs:quantal/mysql-1")
+	res := newMirroredResource(c, "spam", 3)
+	err := s.State.AddMirroredResource(curl, res, strings.NewReader("spam"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Resource revisions are independent of the charm's revision.
+	opened, reader, err := s.State.OpenMirroredResource(curl.WithRevision(2), "data", 3)
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+	c.Assert(opened, jc.DeepEquals, res)
+	data, err := ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "spam")
+
+	// Adding it again is not an error.
+	err = s.State.AddMirroredResource(curl, res, strings.NewReader("spam"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Replacing it with different content is.
+	err = s.State.AddMirroredResource(curl, newMirroredResource(c, "eggs", 3), strings.NewReader("eggs"))
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	_, _, err = s.State.OpenMirroredResource(curl, "data", 4)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CharmMirrorSuite) TestAddMirroredResourceBadContent(c *gc.C) {
+	curl := charm.MustParseURL("cs:quantal/mysql-1")
+	res := newMirroredResource(c, "spam", 3)
+	err := s.State.AddMirroredResource(curl, res, strings.NewReader("eggs"))
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	_, _, err = s.State.OpenMirroredResource(curl, "data", 3)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CharmMirrorSuite) TestMirroredResources(c *gc.C) {
+	curl := charm.MustParseURL("cs:quantal/mysql-1")
+	for i, content := range []string{"spam", "eggs", "ham"} {
+		res := newMirroredResource(c, content, i+1)
+		err := s.State.AddMirroredResource(curl, res, strings.NewReader(content))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	resources, err := s.State.MirroredResources(curl.WithRevision(5))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, jc.DeepEquals, []charmresource.Resource{newMirroredResource(c, "ham", 3)})
+
+	resources, err = s.State.MirroredResources(charm.MustParseURL("cs:quantal/wordpress"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, gc.HasLen, 0)
+}
@@ -6,7 +6,9 @@ package state
 import (
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/cloud"
 	jujucontroller "github.com/juju/juju/controller"
+	"github.com/juju/juju/environs/config"
 )
 
 const (
@@ -17,6 +19,12 @@ const (
 	defaultModelSettingsGlobalKey = "defaultModelSettings"
 )
 
+// regionSettingsGlobalKey returns the key for default settings shared
+// across models in the named cloud region.
+func regionSettingsGlobalKey(regionName string) string {
+	return defaultModelSettingsGlobalKey + "#" + regionName
+}
+
 func controllerOnlyAttribute(attr string) bool {
 	for _, a := range jujucontroller.ControllerOnlyConfigAttributes {
 		if attr == a {
@@ -60,3 +68,116 @@ func (st *State) ModelConfigDefaults() (map[string]interface{}, error) {
 	}
 	return settings.Map(), nil
 }
+
+// regionModelConfigDefaults returns the config values shared across
+// models in the named cloud region. It is not an error for the region
+// to have no defaults set.
+func (st *State) regionModelConfigDefaults(regionName string) (map[string]interface{}, error) {
+	settings, err := readSettings(st, controllersC, regionSettingsGlobalKey(regionName))
+	if errors.IsNotFound(err) {
+		return make(map[string]interface{}), nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return settings.Map(), nil
+}
+
+// ModelConfigDefaultValues returns the default values inherited by new
+// and existing models for each model config attribute: the values hard
+// coded into Juju, those set for the whole controller and those set for
+// each of the controller cloud's regions.
+func (st *State) ModelConfigDefaultValues() (config.ModelDefaultAttributes, error) {
+	controllerDefaults, err := st.ModelConfigDefaults()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	controllerCloud, err := st.Cloud()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	result := make(config.ModelDefaultAttributes)
+	for attr, value := range config.ConfigDefaults() {
+		result[attr] = config.AttributeDefaultValues{Default: value}
+	}
+	for attr, value := range controllerDefaults {
+		ds := result[attr]
+		ds.Controller = value
+		result[attr] = ds
+	}
+	// Regions are sorted by name in the cloud definition, so
+	// the region values for each attribute are sorted too.
+	for _, region := range controllerCloud.Regions {
+		regionDefaults, err := st.regionModelConfigDefaults(region.Name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for attr, value := range regionDefaults {
+			ds := result[attr]
+			ds.Regions = append(ds.Regions, config.RegionDefaultValue{
+				Name:  region.Name,
+				Value: value,
+			})
+			result[attr] = ds
+		}
+	}
+	return result, nil
+}
+
+// UpdateModelConfigDefaultValues adds, updates or removes the defaults
+// inherited by models. If regionName is empty, the controller-wide
+// defaults are changed; otherwise only the defaults for models in the
+// named cloud region are.
+func (st *State) UpdateModelConfigDefaultValues(updateAttrs map[string]interface{}, removeAttrs []string, regionName string) error {
+	if len(updateAttrs)+len(removeAttrs) == 0 {
+		return nil
+	}
+	if err := checkModelConfigDefaults(updateAttrs); err != nil {
+		return errors.Trace(err)
+	}
+	for _, attr := range []string{config.NameKey, config.TypeKey, config.UUIDKey} {
+		if _, ok := updateAttrs[attr]; ok {
+			return errors.Errorf("config defaults cannot contain %q", attr)
+		}
+	}
+
+	// Check that the new values are acceptable
+	// in a model's config before storing them.
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := cfg.Apply(updateAttrs); err != nil {
+		return errors.Annotate(err, "invalid config defaults")
+	}
+
+	key := defaultModelSettingsGlobalKey
+	if regionName != "" {
+		controllerCloud, err := st.Cloud()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		region, err := cloud.RegionByName(controllerCloud.Regions, regionName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		key = regionSettingsGlobalKey(region.Name)
+	}
+	settings, err := readSettings(st, controllersC, key)
+	if errors.IsNotFound(err) && regionName != "" {
+		if len(updateAttrs) == 0 {
+			// Nothing to remove.
+			return nil
+		}
+		_, err = createSettings(st, controllersC, key, updateAttrs)
+		return errors.Trace(err)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	settings.Update(updateAttrs)
+	for _, attr := range removeAttrs {
+		settings.Delete(attr)
+	}
+	_, err = settings.Write()
+	return errors.Trace(err)
+}
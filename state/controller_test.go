@@ -24,7 +24,11 @@ func (s *ControllerConfigSuite) TestControllerAndModelConfigInitialisation(c *gc
 	c.Assert(err, jc.ErrorIsNil)
 
 	optional := func(attr string) bool {
-		return attr == controller.IdentityURL || attr == controller.IdentityPublicKey
+		switch attr {
+		case controller.IdentityURL, controller.IdentityPublicKey, controller.CharmStoreMirror:
+			return true
+		}
+		return false
 	}
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
 		v, ok := controllerSettings.Get(controllerAttr)
@@ -54,6 +54,7 @@ var (
 	ReadSettings                  = readSettings
 	DefaultModelSettingsGlobalKey = defaultModelSettingsGlobalKey
 	MergeBindings                 = mergeBindings
+	MetricsQueryFilter            = metricsQueryFilter
 	UpgradeInProgressError        = errUpgradeInProgress
 )
 
@@ -511,6 +511,93 @@ func logDocToRecord(doc *logDoc) *LogRecord {
 	}
 }
 
+// ModelLogs returns up to limit log records for the model, ordered by
+// time, which were recorded strictly after the time given. In order to
+// allow callers to resume from the time of the last record returned,
+// all records sharing that time are always included, so slightly more
+// than limit records may be returned. A limit of zero or less returns
+// all remaining records.
+func ModelLogs(st LoggingState, after time.Time, limit int) ([]*LogRecord, error) {
+	session, logsColl := initLogsSession(st)
+	defer session.Close()
+
+	sel := bson.M{"e": st.ModelUUID(), "t": bson.M{"$gt": after}}
+	query := logsColl.Find(sel).Sort("t", "_id")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var docs []logDoc
+	if err := query.All(&docs); err != nil {
+		return nil, errors.Annotate(err, "log query failed")
+	}
+	if limit > 0 && len(docs) == limit {
+		// Replace the records at the end of the page which share the
+		// last timestamp with the full set of records at that time.
+		lastTime := docs[len(docs)-1].Time
+		for len(docs) > 0 && docs[len(docs)-1].Time.Equal(lastTime) {
+			docs = docs[:len(docs)-1]
+		}
+		var tail []logDoc
+		err := logsColl.Find(bson.M{
+			"e": st.ModelUUID(),
+			"t": lastTime,
+		}).Sort("_id").All(&tail)
+		if err != nil {
+			return nil, errors.Annotate(err, "log query failed")
+		}
+		docs = append(docs, tail...)
+	}
+
+	records := make([]*LogRecord, len(docs))
+	for i := range docs {
+		records[i] = logDocToRecord(&docs[i])
+	}
+	return records, nil
+}
+
+// ImportLogs inserts the given log records into the logs collection
+// for the model. It is used to bring across the log history of a model
+// being migrated from another controller.
+func ImportLogs(st LoggingState, records []*LogRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	session, logsColl := initLogsSession(st)
+	defer session.Close()
+
+	docs := make([]interface{}, len(records))
+	for i, rec := range records {
+		docs[i] = &logDoc{
+			Id:        bson.NewObjectId(),
+			Time:      rec.Time,
+			ModelUUID: st.ModelUUID(),
+			Entity:    rec.Entity,
+			Module:    rec.Module,
+			Location:  rec.Location,
+			Level:     rec.Level,
+			Message:   rec.Message,
+		}
+	}
+	return errors.Annotate(logsColl.Insert(docs...), "cannot import logs")
+}
+
+// removeModelLogs removes all log records, and any record of their
+// forwarding, for the model.
+func removeModelLogs(st LoggingState) error {
+	session, logsColl := initLogsSession(st)
+	defer session.Close()
+
+	modelUUID := st.ModelUUID()
+	if _, err := logsColl.RemoveAll(bson.M{"e": modelUUID}); err != nil {
+		return errors.Annotate(err, "cannot remove model logs")
+	}
+	forwardedColl := session.DB(logsDB).C(forwardedC)
+	if _, err := forwardedColl.RemoveAll(bson.M{"model-uuid": modelUUID}); err != nil {
+		return errors.Annotate(err, "cannot remove model log forwarding records")
+	}
+	return nil
+}
+
 // PruneLogs removes old log documents in order to control the size of
 // logs collection. All logs older than minLogTime are
 // removed. Further removal is also performed if the logs collection
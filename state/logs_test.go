@@ -111,6 +111,78 @@ func (s *LogsSuite) TestDbLogger(c *gc.C) {
 	c.Assert(docs[1]["x"], gc.Equals, "oh noes")
 }
 
+func (s *LogsSuite) TestModelLogs(c *gc.C) {
+	logger := state.NewDbLogger(s.State, names.NewMachineTag("0"))
+	defer logger.Close()
+	t0 := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Second)
+	t2 := t1.Add(time.Second)
+	for i, t := range []time.Time{t0, t1, t1, t1, t2} {
+		err := logger.Log(t, "module", "loc", loggo.INFO, strconv.Itoa(i))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	otherSt := s.Factory.MakeModel(c, nil)
+	defer otherSt.Close()
+	otherLogger := state.NewDbLogger(otherSt, names.NewMachineTag("0"))
+	defer otherLogger.Close()
+	err := otherLogger.Log(t1, "module", "loc", loggo.INFO, "other")
+	c.Assert(err, jc.ErrorIsNil)
+
+	messages := func(records []*state.LogRecord) []string {
+		var out []string
+		for _, rec := range records {
+			out = append(out, rec.Message)
+		}
+		return out
+	}
+
+	// All the records sharing the last timestamp are returned, even
+	// when that exceeds the limit.
+	records, err := state.ModelLogs(s.State, time.Time{}, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(messages(records), jc.DeepEquals, []string{"0", "1", "2", "3"})
+	c.Assert(records[3].Time.Equal(t1), jc.IsTrue)
+
+	records, err = state.ModelLogs(s.State, records[3].Time, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(messages(records), jc.DeepEquals, []string{"4"})
+	c.Assert(records[0].Entity, gc.Equals, "machine-0")
+
+	records, err = state.ModelLogs(s.State, t2, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 0)
+
+	records, err = state.ModelLogs(s.State, t0, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(messages(records), jc.DeepEquals, []string{"1", "2", "3", "4"})
+}
+
+func (s *LogsSuite) TestImportLogs(c *gc.C) {
+	t0 := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	err := state.ImportLogs(s.State, []*state.LogRecord{{
+		Time:      t0,
+		Entity:    "unit-foo-0",
+		Module:    "juju.worker.uniter",
+		Location:  "uniter.go:42",
+		Level:     loggo.WARNING,
+		Message:   "migrated",
+		ModelUUID: "some-other-model",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var docs []bson.M
+	err = s.logsColl.Find(nil).All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(docs, gc.HasLen, 1)
+	c.Assert(docs[0]["t"].(time.Time).Equal(t0), jc.IsTrue)
+	c.Assert(docs[0]["e"], gc.Equals, s.State.ModelUUID())
+	c.Assert(docs[0]["n"], gc.Equals, "unit-foo-0")
+	c.Assert(docs[0]["m"], gc.Equals, "juju.worker.uniter")
+	c.Assert(docs[0]["l"], gc.Equals, "uniter.go:42")
+	c.Assert(docs[0]["v"], gc.Equals, int(loggo.WARNING))
+	c.Assert(docs[0]["x"], gc.Equals, "migrated")
+}
+
 func (s *LogsSuite) TestPruneLogsByTime(c *gc.C) {
 	dbLogger := state.NewDbLogger(s.State, names.NewMachineTag("22"))
 	defer dbLogger.Close()
@@ -5,6 +5,8 @@ package state
 
 import (
 	"encoding/json"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/juju/errors"
@@ -378,3 +380,212 @@ func (st *State) SetMetricBatchesSent(batchUUIDs []string) error {
 	}
 	return nil
 }
+
+// MetricAggregation identifies how metric values are combined by
+// AggregateMetrics.
+type MetricAggregation string
+
+const (
+	// MetricSum sums the values of a metric.
+	MetricSum MetricAggregation = "sum"
+
+	// MetricAverage averages the values of a metric.
+	MetricAverage MetricAggregation = "avg"
+
+	// MetricMax takes the largest value of a metric.
+	MetricMax MetricAggregation = "max"
+
+	// MetricLast takes the most recently recorded value of a metric.
+	MetricLast MetricAggregation = "last"
+)
+
+// Validate returns an error if the aggregation is not known.
+func (a MetricAggregation) Validate() error {
+	switch a {
+	case MetricSum, MetricAverage, MetricMax, MetricLast:
+		return nil
+	}
+	return errors.NotValidf("metric aggregation %q", a)
+}
+
+// MetricsQuery selects the metrics combined by AggregateMetrics.
+type MetricsQuery struct {
+	// Units restricts the query to the metrics of the named units.
+	// If empty, the metrics of all units in the model are used.
+	Units []string
+
+	// Keys restricts the query to metrics with the given keys.
+	// If empty, metrics with any key are used.
+	Keys []string
+
+	// Start and End restrict the query to metrics recorded at or
+	// after Start and before End. Either may be zero, leaving the
+	// range unbounded at that end.
+	Start time.Time
+	End   time.Time
+
+	// Interval is the size of the time buckets the metrics are
+	// aggregated over. If zero, all metrics in the range are
+	// aggregated into a single bucket.
+	Interval time.Duration
+
+	// Aggregation determines how the values in each bucket are
+	// combined.
+	Aggregation MetricAggregation
+}
+
+// AggregatedMetric holds the aggregated values of a metric for a
+// unit over a single time bucket.
+type AggregatedMetric struct {
+	// Unit is the name of the unit that recorded the metric.
+	Unit string
+
+	// Key is the metric key.
+	Key string
+
+	// Time is the start of the time bucket. It is zero when the
+	// query has no interval.
+	Time time.Time
+
+	// Value is the aggregated value of the metric.
+	Value float64
+
+	// Count is the number of values that were aggregated.
+	Count int
+}
+
+type metricBucket struct {
+	unit string
+	key  string
+	time time.Time
+}
+
+// AggregateMetrics combines the numeric values of the metrics selected
+// by the query, grouped by unit, key and time bucket. Metrics with
+// non-numeric values are ignored. The results are ordered by unit,
+// key and time.
+func (st *State) AggregateMetrics(query MetricsQuery) ([]AggregatedMetric, error) {
+	if err := query.Aggregation.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if query.Interval < 0 {
+		return nil, errors.NotValidf("negative interval %v", query.Interval)
+	}
+	c, closer := st.getCollection(metricsC)
+	defer closer()
+
+	keys := make(map[string]bool)
+	for _, key := range query.Keys {
+		keys[key] = true
+	}
+	buckets := make(map[metricBucket]*AggregatedMetric)
+	lastTimes := make(map[metricBucket]time.Time)
+	var doc metricBatchDoc
+	iter := c.Find(metricsQueryFilter(st.ModelUUID(), query)).Iter()
+	for iter.Next(&doc) {
+		// The filter selects batches holding at least one
+		// matching metric, so each metric is checked again.
+		for _, m := range doc.Metrics {
+			if len(keys) > 0 && !keys[m.Key] {
+				continue
+			}
+			if !query.Start.IsZero() && m.Time.Before(query.Start) {
+				continue
+			}
+			if !query.End.IsZero() && !m.Time.Before(query.End) {
+				continue
+			}
+			value, err := strconv.ParseFloat(m.Value, 64)
+			if err != nil {
+				continue
+			}
+			bucket := metricBucket{unit: doc.Unit, key: m.Key}
+			if query.Interval > 0 {
+				bucket.time = m.Time.UTC().Truncate(query.Interval)
+			}
+			agg, ok := buckets[bucket]
+			if !ok {
+				agg = &AggregatedMetric{
+					Unit:  bucket.unit,
+					Key:   bucket.key,
+					Time:  bucket.time,
+					Value: value,
+				}
+				buckets[bucket] = agg
+				lastTimes[bucket] = m.Time
+			} else {
+				switch query.Aggregation {
+				case MetricSum, MetricAverage:
+					agg.Value += value
+				case MetricMax:
+					if value > agg.Value {
+						agg.Value = value
+					}
+				case MetricLast:
+					if !m.Time.Before(lastTimes[bucket]) {
+						agg.Value = value
+						lastTimes[bucket] = m.Time
+					}
+				}
+			}
+			agg.Count++
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "cannot get metrics")
+	}
+
+	results := make([]AggregatedMetric, 0, len(buckets))
+	for _, agg := range buckets {
+		if query.Aggregation == MetricAverage {
+			agg.Value /= float64(agg.Count)
+		}
+		results = append(results, *agg)
+	}
+	sort.Sort(aggregatedMetrics(results))
+	return results, nil
+}
+
+// metricsQueryFilter returns a mongo filter selecting the model's metric
+// batches that hold at least one metric matched by the query. Batches
+// are matched on the times of their metrics rather than their created
+// time, since nothing guarantees a batch is created after the metrics
+// it holds were recorded.
+func metricsQueryFilter(modelUUID string, query MetricsQuery) bson.D {
+	filter := bson.D{{"model-uuid", modelUUID}}
+	if len(query.Units) > 0 {
+		filter = append(filter, bson.DocElem{"unit", bson.D{{"$in", query.Units}}})
+	}
+	var match bson.D
+	if len(query.Keys) > 0 {
+		match = append(match, bson.DocElem{"key", bson.D{{"$in", query.Keys}}})
+	}
+	var timeRange bson.D
+	if !query.Start.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{"$gte", query.Start})
+	}
+	if !query.End.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{"$lt", query.End})
+	}
+	if len(timeRange) > 0 {
+		match = append(match, bson.DocElem{"time", timeRange})
+	}
+	if len(match) > 0 {
+		filter = append(filter, bson.DocElem{"metrics", bson.D{{"$elemMatch", match}}})
+	}
+	return filter
+}
+
+type aggregatedMetrics []AggregatedMetric
+
+func (m aggregatedMetrics) Len() int      { return len(m) }
+func (m aggregatedMetrics) Swap(i, j int) { m[i], m[j] = m[j], m[i] }
+func (m aggregatedMetrics) Less(i, j int) bool {
+	if m[i].Unit != m[j].Unit {
+		return m[i].Unit < m[j].Unit
+	}
+	if m[i].Key != m[j].Key {
+		return m[i].Key < m[j].Key
+	}
+	return m[i].Time.Before(m[j].Time)
+}
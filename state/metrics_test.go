@@ -11,6 +11,7 @@ import (
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing/factory"
@@ -652,6 +653,139 @@ func (s *MetricSuite) TestUnitMetricBatchesReturnsJustLocal(c *gc.C) {
 	c.Assert(metricBatches, gc.HasLen, 1)
 }
 
+func (s *MetricSuite) addPings(c *gc.C, unit *state.Unit, values map[time.Duration]string, start time.Time) {
+	var metrics []state.Metric
+	for offset, value := range values {
+		metrics = append(metrics, state.Metric{"pings", value, start.Add(offset)})
+	}
+	_, err := s.State.AddMetrics(
+		state.BatchParam{
+			UUID:     utils.MustNewUUID().String(),
+			Created:  start,
+			CharmURL: s.meteredCharm.URL().String(),
+			Metrics:  metrics,
+			Unit:     unit.UnitTag(),
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MetricSuite) TestAggregateMetrics(c *gc.C) {
+	start := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	s.addPings(c, s.unit, map[time.Duration]string{
+		0:                "1",
+		10 * time.Minute: "4",
+		70 * time.Minute: "2",
+	}, start)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.service, SetCharmURL: true})
+	s.addPings(c, unit, map[time.Duration]string{
+		5 * time.Minute: "3",
+	}, start)
+
+	for i, test := range []struct {
+		aggregation state.MetricAggregation
+		expected    []float64
+	}{
+		{state.MetricSum, []float64{5, 2, 3}},
+		{state.MetricAverage, []float64{2.5, 2, 3}},
+		{state.MetricMax, []float64{4, 2, 3}},
+		{state.MetricLast, []float64{4, 2, 3}},
+	} {
+		c.Logf("test %d: %s", i, test.aggregation)
+		result, err := s.State.AggregateMetrics(state.MetricsQuery{
+			Interval:    time.Hour,
+			Aggregation: test.aggregation,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, jc.DeepEquals, []state.AggregatedMetric{{
+			Unit:  "metered/0",
+			Key:   "pings",
+			Time:  start,
+			Value: test.expected[0],
+			Count: 2,
+		}, {
+			Unit:  "metered/0",
+			Key:   "pings",
+			Time:  start.Add(time.Hour),
+			Value: test.expected[1],
+			Count: 1,
+		}, {
+			Unit:  "metered/1",
+			Key:   "pings",
+			Time:  start,
+			Value: test.expected[2],
+			Count: 1,
+		}})
+	}
+}
+
+func (s *MetricSuite) TestAggregateMetricsFiltered(c *gc.C) {
+	start := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	s.addPings(c, s.unit, map[time.Duration]string{
+		0:                "1",
+		10 * time.Minute: "4",
+		70 * time.Minute: "2",
+	}, start)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.service, SetCharmURL: true})
+	s.addPings(c, unit, map[time.Duration]string{
+		5 * time.Minute: "3",
+	}, start)
+
+	result, err := s.State.AggregateMetrics(state.MetricsQuery{
+		Units:       []string{"metered/0"},
+		Keys:        []string{"pings"},
+		Start:       start.Add(5 * time.Minute),
+		End:         start.Add(70 * time.Minute),
+		Aggregation: state.MetricSum,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []state.AggregatedMetric{{
+		Unit:  "metered/0",
+		Key:   "pings",
+		Value: 4,
+		Count: 1,
+	}})
+
+	result, err = s.State.AggregateMetrics(state.MetricsQuery{
+		Keys:        []string{"juju-units"},
+		Aggregation: state.MetricSum,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.HasLen, 0)
+}
+
+func (s *MetricSuite) TestMetricsQueryFilter(c *gc.C) {
+	start := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	filter := state.MetricsQueryFilter("uuid", state.MetricsQuery{
+		Units: []string{"metered/0"},
+		Keys:  []string{"pings"},
+		Start: start,
+		End:   end,
+	})
+	c.Assert(filter, jc.DeepEquals, bson.D{
+		{"model-uuid", "uuid"},
+		{"unit", bson.D{{"$in", []string{"metered/0"}}}},
+		{"metrics", bson.D{{"$elemMatch", bson.D{
+			{"key", bson.D{{"$in", []string{"pings"}}}},
+			{"time", bson.D{{"$gte", start}, {"$lt", end}}},
+		}}}},
+	})
+
+	filter = state.MetricsQueryFilter("uuid", state.MetricsQuery{})
+	c.Assert(filter, jc.DeepEquals, bson.D{{"model-uuid", "uuid"}})
+}
+
+func (s *MetricSuite) TestAggregateMetricsInvalid(c *gc.C) {
+	_, err := s.State.AggregateMetrics(state.MetricsQuery{Aggregation: "median"})
+	c.Assert(err, gc.ErrorMatches, `metric aggregation "median" not valid`)
+	_, err = s.State.AggregateMetrics(state.MetricsQuery{
+		Aggregation: state.MetricSum,
+		Interval:    -time.Hour,
+	})
+	c.Assert(err, gc.ErrorMatches, `negative interval -1h0m0s not valid`)
+}
+
 type MetricLocalCharmSuite struct {
 	ConnSuite
 	unit         *state.Unit
@@ -442,6 +442,7 @@ func (e *exporter) addApplication(application *Application, refcounts map[string
 		return errors.Errorf("missing leadership settings for application %q", application.Name())
 	}
 
+	policy := application.PlacementPolicy()
 	args := description.ApplicationArgs{
 		Tag:                  application.ApplicationTag(),
 		Series:               application.doc.Series,
@@ -451,7 +452,11 @@ func (e *exporter) addApplication(application *Application, refcounts map[string
 		CharmModifiedVersion: application.doc.CharmModifiedVersion,
 		ForceCharm:           application.doc.ForceCharm,
 		Exposed:              application.doc.Exposed,
+		ExposedCIDRs:         application.doc.ExposedCIDRs,
 		MinUnits:             application.doc.MinUnits,
+		Trust:                application.doc.Trust,
+		PlacementSpread:      string(policy.Spread),
+		PlacementColocate:    policy.Colocate,
 		Settings:             applicationSettingsDoc.Settings,
 		SettingsRefCount:     refCount,
 		Leader:               leader,
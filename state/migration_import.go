@@ -662,6 +662,14 @@ func (i *importer) makeApplicationDoc(s description.Application) (*applicationDo
 		return nil, errors.Trace(err)
 	}
 
+	var placementPolicy *placementPolicyDoc
+	if s.PlacementSpread() != "" || s.PlacementColocate() != "" {
+		placementPolicy = &placementPolicyDoc{
+			Spread:   s.PlacementSpread(),
+			Colocate: s.PlacementColocate(),
+		}
+	}
+
 	return &applicationDoc{
 		Name:                 s.Name(),
 		Series:               s.Series(),
@@ -674,8 +682,11 @@ func (i *importer) makeApplicationDoc(s description.Application) (*applicationDo
 		UnitCount:            len(s.Units()),
 		RelationCount:        i.relationCount(s.Name()),
 		Exposed:              s.Exposed(),
+		ExposedCIDRs:         s.ExposedCIDRs(),
 		MinUnits:             s.MinUnits(),
+		Trust:                s.Trust(),
 		MetricCredentials:    s.MetricsCredentials(),
+		PlacementPolicy:      placementPolicy,
 	}, nil
 }
 
@@ -286,7 +286,9 @@ func (s *MigrationImportSuite) TestServices(c *gc.C) {
 	err = service.SetMetricCredentials([]byte("sekrit"))
 	c.Assert(err, jc.ErrorIsNil)
 	// Expose the service.
-	c.Assert(service.SetExposed(), jc.ErrorIsNil)
+	c.Assert(service.SetExposedCIDRs([]string{"10.0.0.0/8"}), jc.ErrorIsNil)
+	c.Assert(service.SetTrust(true), jc.ErrorIsNil)
+	c.Assert(service.SetPlacementPolicy(state.PlacementPolicy{Spread: state.PlacementScopeZone}), jc.ErrorIsNil)
 	err = s.State.SetAnnotations(service, testAnnotations)
 	c.Assert(err, jc.ErrorIsNil)
 	s.primeStatusHistory(c, service, status.StatusActive, 5)
@@ -308,6 +310,9 @@ func (s *MigrationImportSuite) TestServices(c *gc.C) {
 	c.Assert(imported.ApplicationTag(), gc.Equals, exported.ApplicationTag())
 	c.Assert(imported.Series(), gc.Equals, exported.Series())
 	c.Assert(imported.IsExposed(), gc.Equals, exported.IsExposed())
+	c.Assert(imported.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
+	c.Assert(imported.IsTrusted(), jc.IsTrue)
+	c.Assert(imported.PlacementPolicy(), gc.Equals, state.PlacementPolicy{Spread: state.PlacementScopeZone})
 	c.Assert(imported.MetricCredentials(), jc.DeepEquals, exported.MetricCredentials())
 
 	exportedConfig, err := exported.ConfigSettings()
@@ -60,6 +60,9 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		guimetadataC,
 		// This is controller global, not migrated.
 		guisettingsC,
+		// The charm mirror is controller global, not migrated.
+		mirroredCharmsC,
+		mirroredResourcesC,
 		// Users aren't migrated.
 		usersC,
 		userLastLoginC,
@@ -87,6 +90,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		migrationsC,
 		migrationsStatusC,
 		migrationsActiveC,
+		migrationsMinionSyncC,
+		// Redirects are only recorded for models which have
+		// migrated away.
+		modelRedirectsC,
 
 		// The container ref document is primarily there to keep track
 		// of a particular machine's containers. The migration format
@@ -118,6 +125,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 
 		// machine
 		rebootC,
+		upgradeSeriesLocksC,
 
 		// service / unit
 		charmsC,
@@ -183,6 +191,9 @@ func (s *MigrationSuite) TestModelDocFields(c *gc.C) {
 		"CloudRegion",
 		"CloudCredential",
 		"LatestAvailableTools",
+		// UpgradeCanary is transient, and the model's agent version
+		// is what is migrated.
+		"UpgradeCanary",
 	)
 	s.AssertExportedFields(c, modelDoc{}, fields)
 }
@@ -297,6 +308,12 @@ func (s *MigrationSuite) TestServiceDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// Rolling charm upgrades are transient and are not migrated;
+		// any units yet to be released upgrade once the model lands.
+		"RollingUpgrade",
+		// The charm to roll back to is not migrated; a migrated
+		// application can only be rolled back by upgrading it.
+		"PreviousCharmURL",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -307,7 +324,10 @@ func (s *MigrationSuite) TestServiceDocFields(c *gc.C) {
 		"CharmModifiedVersion",
 		"ForceCharm",
 		"Exposed",
+		"ExposedCIDRs",
 		"MinUnits",
+		"Trust",
+		"PlacementPolicy",
 		"MetricCredentials",
 	)
 	s.AssertExportedFields(c, applicationDoc{}, migrated.Union(ignored))
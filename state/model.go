@@ -74,6 +74,10 @@ type modelDoc struct {
 	// LatestAvailableTools is a string representing the newest version
 	// found while checking streams for new versions.
 	LatestAvailableTools string `bson:"available-tools,omitempty"`
+
+	// UpgradeCanary records the agent upgrade being tried out on a
+	// few of the model's machines, if any.
+	UpgradeCanary *upgradeCanaryDoc `bson:"upgrade-canary,omitempty"`
 }
 
 // modelEntityRefsDoc records references to the top-level entities
@@ -245,9 +249,9 @@ func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
 	}()
 	newSt.controllerTag = st.controllerTag
 
-	configDefaults, err := st.ModelConfigDefaults()
+	configDefaults, err := st.regionConfigDefaults(args.CloudRegion)
 	if err != nil {
-		return nil, nil, errors.Annotate(err, "could not read cloud config for new model")
+		return nil, nil, errors.Annotate(err, "could not read config defaults for new model")
 	}
 	modelOps, err := newSt.modelSetupOps(args, configDefaults)
 	if err != nil {
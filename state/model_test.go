@@ -829,6 +829,51 @@ func (s *ModelCloudValidationSuite) TestNewModelMissingCloudCredentialSupportsEm
 	newSt.Close()
 }
 
+func (s *ModelCloudValidationSuite) TestNewModelInheritsRegionDefaults(c *gc.C) {
+	regions := []cloud.Region{{Name: "some-region"}, {Name: "other-region"}}
+	st, owner := s.initializeState(c, regions, []cloud.AuthType{cloud.EmptyAuthType}, nil)
+	defer st.Close()
+	err := st.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"apt-mirror": "http://controller-mirror",
+	}, nil, "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = st.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"apt-mirror": "http://region-mirror",
+	}, nil, "other-region")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The controller model is in "some-region", so it only
+	// inherits the controller-wide default.
+	cfg, err := st.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AptMirror(), gc.Equals, "http://controller-mirror")
+
+	cfg, _ = createTestModelConfig(c, st.ModelUUID())
+	cfg, err = cfg.Apply(map[string]interface{}{"name": "whatever"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, newSt, err := st.NewModel(state.ModelArgs{
+		Config: cfg, Owner: owner, CloudRegion: "other-region",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer newSt.Close()
+
+	values, err := newSt.ModelConfigValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["apt-mirror"], jc.DeepEquals, config.ConfigValue{
+		Value: "http://region-mirror", Source: config.JujuRegionSource,
+	})
+
+	defaults, err := st.ModelConfigDefaultValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(defaults["apt-mirror"], jc.DeepEquals, config.AttributeDefaultValues{
+		Controller: "http://controller-mirror",
+		Regions: []config.RegionDefaultValue{{
+			Name:  "other-region",
+			Value: "http://region-mirror",
+		}},
+	})
+}
+
 func (s *ModelCloudValidationSuite) initializeState(
 	c *gc.C,
 	regions []cloud.Region,
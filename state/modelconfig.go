@@ -4,6 +4,9 @@
 package state
 
 import (
+	"fmt"
+	"reflect"
+
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/controller"
@@ -13,15 +16,14 @@ import (
 // ModelConfig returns the complete config for the model represented
 // by this state.
 func (st *State) ModelConfig() (*config.Config, error) {
-	defaultModelSettings, err := readSettings(st, controllersC, defaultModelSettingsGlobalKey)
+	modelSettings, err := readSettings(st, settingsC, modelGlobalKey)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	modelSettings, err := readSettings(st, settingsC, modelGlobalKey)
+	attrs, err := st.modelConfigDefaults()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	attrs := defaultModelSettings.Map()
 
 	// Merge in model specific settings.
 	for k, v := range modelSettings.Map() {
@@ -31,6 +33,108 @@ func (st *State) ModelConfig() (*config.Config, error) {
 	return config.New(config.NoDefaults, attrs)
 }
 
+// modelConfigDefaults returns the config defaults inherited by the
+// model represented by this state: the controller-wide defaults
+// overlaid with those set for the model's cloud region.
+func (st *State) modelConfigDefaults() (map[string]interface{}, error) {
+	var regionName string
+	model, err := st.Model()
+	if err == nil {
+		regionName = model.CloudRegion()
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	return st.regionConfigDefaults(regionName)
+}
+
+// regionConfigDefaults returns the controller-wide config defaults
+// overlaid with those set for the named cloud region, if any.
+func (st *State) regionConfigDefaults(regionName string) (map[string]interface{}, error) {
+	attrs, err := st.ModelConfigDefaults()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if regionName == "" {
+		return attrs, nil
+	}
+	regionAttrs, err := st.regionModelConfigDefaults(regionName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for k, v := range regionAttrs {
+		attrs[k] = v
+	}
+	return attrs, nil
+}
+
+// ModelConfigValues returns the config values for the model represented
+// by this state, annotated with the layer each value is inherited from.
+//
+// The model's settings hold values copied from the other layers when
+// the model was created, so a value is attributed to the innermost
+// layer beneath the model that defines the attribute if the two values
+// match, and to the model itself otherwise.
+func (st *State) ModelConfigValues() (config.ConfigValues, error) {
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	controllerDefaults, err := st.ModelConfigDefaults()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var regionDefaults map[string]interface{}
+	if model.CloudRegion() != "" {
+		regionDefaults, err = st.regionModelConfigDefaults(model.CloudRegion())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	// Innermost first.
+	layers := []struct {
+		source string
+		attrs  map[string]interface{}
+	}{
+		{config.JujuRegionSource, regionDefaults},
+		{config.JujuControllerSource, controllerDefaults},
+		{config.JujuDefaultSource, config.ConfigDefaults()},
+	}
+	result := make(config.ConfigValues)
+	for attr, value := range cfg.AllAttrs() {
+		source := config.JujuModelConfigSource
+		for _, layer := range layers {
+			inherited, ok := layer.attrs[attr]
+			if !ok {
+				continue
+			}
+			if sameConfigValue(value, inherited) {
+				source = layer.source
+			}
+			break
+		}
+		result[attr] = config.ConfigValue{
+			Value:  value,
+			Source: source,
+		}
+	}
+	return result, nil
+}
+
+// sameConfigValue reports whether two config values are the same. The
+// values may have been coerced to different types, for instance by
+// being read back from mongo, so they are also compared as text.
+func sameConfigValue(a, b interface{}) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
 // checkModelConfig returns an error if the config is definitely invalid.
 func checkModelConfig(cfg *config.Config) error {
 	if cfg.AdminSecret() != "" {
@@ -125,11 +229,12 @@ func (st *State) UpdateModelConfig(updateAttrs map[string]interface{}, removeAtt
 		}
 	}
 
-	// Remove any attributes that are the same as what's in cloud config.
+	// Remove any attributes that are the same as what's inherited
+	// from the controller and cloud region defaults.
 	// TODO(wallyworld) if/when cloud config becomes mutable, we must check
 	// for concurrent changes when writing config to ensure the validation
 	// we do here remains true
-	defaultAttrs, err := st.ModelConfigDefaults()
+	defaultAttrs, err := st.modelConfigDefaults()
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -114,3 +114,105 @@ func (s *ModelConfigSuite) TestModelConfigInheritsCloudValue(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg.AllAttrs()["apt-mirror"], gc.Equals, "http://mirror")
 }
+
+func (s *ModelConfigSuite) TestUpdateModelConfigDefaultValues(c *gc.C) {
+	err := s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"apt-mirror":     "http://mirror",
+		"logging-config": "<root>=DEBUG",
+	}, nil, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AptMirror(), gc.Equals, "http://mirror")
+
+	err = s.State.UpdateModelConfigDefaultValues(nil, []string{"apt-mirror"}, "")
+	c.Assert(err, jc.ErrorIsNil)
+	defaults, err := s.State.ModelConfigDefaults()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(defaults["logging-config"], gc.Equals, "<root>=DEBUG")
+	_, ok := defaults["apt-mirror"]
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *ModelConfigSuite) TestUpdateModelConfigDefaultValuesRejectsControllerConfig(c *gc.C) {
+	err := s.State.UpdateModelConfigDefaultValues(map[string]interface{}{"api-port": 1234}, nil, "")
+	c.Assert(err, gc.ErrorMatches, `config defaults cannot contain controller attribute "api-port"`)
+}
+
+func (s *ModelConfigSuite) TestUpdateModelConfigDefaultValuesRejectsModelIdentity(c *gc.C) {
+	err := s.State.UpdateModelConfigDefaultValues(map[string]interface{}{"name": "foo"}, nil, "")
+	c.Assert(err, gc.ErrorMatches, `config defaults cannot contain "name"`)
+}
+
+func (s *ModelConfigSuite) TestUpdateModelConfigDefaultValuesInvalidValue(c *gc.C) {
+	err := s.State.UpdateModelConfigDefaultValues(map[string]interface{}{"firewall-mode": "bogus"}, nil, "")
+	c.Assert(err, gc.ErrorMatches, `invalid config defaults: .*`)
+}
+
+func (s *ModelConfigSuite) TestUpdateModelConfigDefaultValuesUnknownRegion(c *gc.C) {
+	err := s.State.UpdateModelConfigDefaultValues(map[string]interface{}{"apt-mirror": "http://mirror"}, nil, "nowhere")
+	c.Assert(err, gc.ErrorMatches, `region "nowhere" not found .*`)
+}
+
+func (s *ModelConfigSuite) TestModelConfigValues(c *gc.C) {
+	err := s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"apt-mirror":     "http://mirror",
+		"default-series": "xenial",
+	}, nil, "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfig(map[string]interface{}{"default-series": "trusty"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	values, err := s.State.ModelConfigValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["apt-mirror"], jc.DeepEquals, config.ConfigValue{
+		Value: "http://mirror", Source: config.JujuControllerSource,
+	})
+	c.Assert(values["default-series"], jc.DeepEquals, config.ConfigValue{
+		Value: "trusty", Source: config.JujuModelConfigSource,
+	})
+	c.Assert(values["name"].Source, gc.Equals, config.JujuModelConfigSource)
+}
+
+func (s *ModelConfigSuite) TestModelConfigValuesComparesLayers(c *gc.C) {
+	// The model's settings hold every value it was created with,
+	// but only those that differ from the inherited value come
+	// from the model.
+	err := s.State.UpdateModelConfig(map[string]interface{}{"development": true}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	values, err := s.State.ModelConfigValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["ssl-hostname-verification"], jc.DeepEquals, config.ConfigValue{
+		Value: true, Source: config.JujuDefaultSource,
+	})
+	c.Assert(values["development"], jc.DeepEquals, config.ConfigValue{
+		Value: true, Source: config.JujuModelConfigSource,
+	})
+
+	err = s.State.UpdateModelConfigDefaultValues(map[string]interface{}{"development": true}, nil, "")
+	c.Assert(err, jc.ErrorIsNil)
+	values, err = s.State.ModelConfigValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["development"], jc.DeepEquals, config.ConfigValue{
+		Value: true, Source: config.JujuControllerSource,
+	})
+}
+
+func (s *ModelConfigSuite) TestModelConfigDefaultValues(c *gc.C) {
+	err := s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"apt-mirror":                "http://mirror",
+		"ssl-hostname-verification": false,
+	}, nil, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	defaults, err := s.State.ModelConfigDefaultValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(defaults["apt-mirror"], jc.DeepEquals, config.AttributeDefaultValues{
+		Controller: "http://mirror",
+	})
+	c.Assert(defaults["ssl-hostname-verification"], jc.DeepEquals, config.AttributeDefaultValues{
+		Default: true, Controller: false,
+	})
+}
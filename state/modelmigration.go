@@ -6,6 +6,7 @@ package state
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -71,11 +72,34 @@ type ModelMigration interface {
 	// current progress of the migration.
 	SetStatusMessage(text string) error
 
+	// SubmitMinionReport records a report from a migration minion
+	// worker about the success or failure to complete its actions
+	// for a given migration phase.
+	SubmitMinionReport(tag names.Tag, phase migration.Phase, success bool) error
+
+	// MinionReports returns details of the agents which have
+	// reported success or failure for the current migration phase,
+	// as well as those which are yet to report.
+	MinionReports() (*MinionReports, error)
+
+	// WatchMinionReports returns a notify watcher which triggers
+	// when a migration minion has reported back about the success
+	// or failure of its actions for the current migration phase.
+	WatchMinionReports() (NotifyWatcher, error)
+
 	// Refresh updates the contents of the ModelMigration from the
 	// underlying state.
 	Refresh() error
 }
 
+// MinionReports indicates which agents have reported success or
+// failure for a migration phase, and which are yet to report.
+type MinionReports struct {
+	Succeeded []names.Tag
+	Failed    []names.Tag
+	Unknown   []names.Tag
+}
+
 // modelMigration is an implementation of ModelMigration.
 type modelMigration struct {
 	st        *State
@@ -155,6 +179,31 @@ type modelMigStatusDoc struct {
 	StatusMessage string `bson:"status-message"`
 }
 
+// modelMigMinionSyncDoc records the report of a single migration
+// minion for a migration phase. These are written into
+// migrationsMinionSyncC.
+type modelMigMinionSyncDoc struct {
+	// Id has the format "<migration id>:<phase>:<agent tag>".
+	Id string `bson:"_id"`
+
+	// MigrationId holds the id of the migration being reported on.
+	MigrationId string `bson:"migration-id"`
+
+	// Phase holds the migration phase being reported on.
+	Phase string `bson:"phase"`
+
+	// EntityKey holds the tag of the agent making the report.
+	EntityKey string `bson:"entity-key"`
+
+	// Time holds the time the report was made (stored as per
+	// UnixNano).
+	Time int64 `bson:"time"`
+
+	// Success records whether the agent completed its actions for
+	// the phase.
+	Success bool `bson:"success"`
+}
+
 // Id implements ModelMigration.
 func (mig *modelMigration) Id() string {
 	return mig.doc.Id
@@ -301,6 +350,135 @@ func (mig *modelMigration) SetStatusMessage(text string) error {
 	return nil
 }
 
+// SubmitMinionReport implements ModelMigration.
+func (mig *modelMigration) SubmitMinionReport(tag names.Tag, phase migration.Phase, success bool) error {
+	doc := modelMigMinionSyncDoc{
+		Id:          minionReportId(mig.Id(), phase, tag),
+		MigrationId: mig.Id(),
+		Phase:       phase.String(),
+		EntityKey:   tag.String(),
+		Time:        GetClock().Now().UnixNano(),
+		Success:     success,
+	}
+	ops := []txn.Op{{
+		C:      migrationsMinionSyncC,
+		Id:     doc.Id,
+		Insert: &doc,
+		Assert: txn.DocMissing,
+	}}
+	err := mig.st.runTransaction(ops)
+	if errors.Cause(err) == txn.ErrAborted {
+		coll, closer := mig.st.getCollection(migrationsMinionSyncC)
+		defer closer()
+		var existingDoc modelMigMinionSyncDoc
+		err := coll.FindId(doc.Id).Select(bson.M{"success": 1}).One(&existingDoc)
+		if err != nil {
+			return errors.Annotate(err, "checking existing report")
+		}
+		if existingDoc.Success != success {
+			return errors.Errorf("conflicting reports received for %s/%s/%s",
+				mig.Id(), phase, tag)
+		}
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// MinionReports implements ModelMigration.
+func (mig *modelMigration) MinionReports() (*MinionReports, error) {
+	all, err := mig.st.allAgentTags()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving agents")
+	}
+	phase, err := mig.Phase()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving phase")
+	}
+
+	coll, closer := mig.st.getCollection(migrationsMinionSyncC)
+	defer closer()
+	var docs []modelMigMinionSyncDoc
+	err = coll.Find(bson.M{
+		"migration-id": mig.Id(),
+		"phase":        phase.String(),
+	}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving minion reports")
+	}
+
+	reported := make(map[string]bool)
+	reports := new(MinionReports)
+	for _, doc := range docs {
+		tag, err := names.ParseTag(doc.EntityKey)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid agent tag in report %q", doc.Id)
+		}
+		reported[doc.EntityKey] = true
+		if doc.Success {
+			reports.Succeeded = append(reports.Succeeded, tag)
+		} else {
+			reports.Failed = append(reports.Failed, tag)
+		}
+	}
+	for _, tag := range all {
+		if !reported[tag.String()] {
+			reports.Unknown = append(reports.Unknown, tag)
+		}
+	}
+	return reports, nil
+}
+
+// WatchMinionReports implements ModelMigration.
+func (mig *modelMigration) WatchMinionReports() (NotifyWatcher, error) {
+	phase, err := mig.Phase()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving phase")
+	}
+	prefix := minionReportIdPrefix(mig.Id(), phase)
+	filter := func(rawId interface{}) bool {
+		id, ok := rawId.(string)
+		return ok && strings.HasPrefix(id, prefix)
+	}
+	return newNotifyCollWatcher(mig.st, migrationsMinionSyncC, filter), nil
+}
+
+func minionReportIdPrefix(migId string, phase migration.Phase) string {
+	return fmt.Sprintf("%s:%s:", migId, phase)
+}
+
+func minionReportId(migId string, phase migration.Phase, tag names.Tag) string {
+	return minionReportIdPrefix(migId, phase) + tag.String()
+}
+
+// allAgentTags returns the tags of every machine and unit agent in
+// the model.
+func (st *State) allAgentTags() ([]names.Tag, error) {
+	machines, err := st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var tags []names.Tag
+	for _, machine := range machines {
+		tags = append(tags, machine.Tag())
+	}
+	applications, err := st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, application := range applications {
+		units, err := application.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, unit := range units {
+			tags = append(tags, unit.Tag())
+		}
+	}
+	return tags, nil
+}
+
 // Refresh implements ModelMigration.
 func (mig *modelMigration) Refresh() error {
 	// Only the status document is updated. The modelMigDoc is static
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	"github.com/juju/utils/clock"
@@ -18,6 +19,7 @@ import (
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type ModelMigrationSuite struct {
@@ -554,6 +556,122 @@ func (s *ModelMigrationSuite) createStatusWatcher(c *gc.C, st *state.State) (
 	return w, statetesting.NewNotifyWatcherC(c, st, w)
 }
 
+func (s *ModelMigrationSuite) TestMinionReports(c *gc.C) {
+	f := factory.NewFactory(s.State2)
+	machine := f.MakeMachine(c, nil)
+	unit := f.MakeUnit(c, &factory.UnitParams{Machine: machine})
+
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	reports, err := mig.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reports.Succeeded, gc.HasLen, 0)
+	c.Check(reports.Failed, gc.HasLen, 0)
+	c.Check(reports.Unknown, jc.SameContents, []names.Tag{machine.Tag(), unit.Tag()})
+
+	err = mig.SubmitMinionReport(machine.Tag(), migration.QUIESCE, true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = mig.SubmitMinionReport(unit.Tag(), migration.QUIESCE, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	reports, err = mig.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reports.Succeeded, jc.DeepEquals, []names.Tag{machine.Tag()})
+	c.Check(reports.Failed, jc.DeepEquals, []names.Tag{unit.Tag()})
+	c.Check(reports.Unknown, gc.HasLen, 0)
+
+	// Reports for other phases are not included.
+	c.Assert(mig.SetPhase(migration.READONLY), jc.ErrorIsNil)
+	reports, err = mig.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reports.Succeeded, gc.HasLen, 0)
+	c.Check(reports.Failed, gc.HasLen, 0)
+	c.Check(reports.Unknown, jc.SameContents, []names.Tag{machine.Tag(), unit.Tag()})
+}
+
+func (s *ModelMigrationSuite) TestDuplicateMinionReport(c *gc.C) {
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	tag := names.NewMachineTag("42")
+
+	err = mig.SubmitMinionReport(tag, migration.QUIESCE, true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = mig.SubmitMinionReport(tag, migration.QUIESCE, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = mig.SubmitMinionReport(tag, migration.QUIESCE, false)
+	c.Assert(err, gc.ErrorMatches, "conflicting reports received for .+/QUIESCE/machine-42")
+}
+
+func (s *ModelMigrationSuite) TestWatchMinionReports(c *gc.C) {
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := mig.WatchMinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State2, w)
+	wc.AssertOneChange() // Initial event.
+
+	err = mig.SubmitMinionReport(names.NewMachineTag("0"), migration.QUIESCE, true)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Reports for other phases are ignored.
+	err = mig.SubmitMinionReport(names.NewMachineTag("0"), migration.READONLY, true)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+func (s *ModelMigrationSuite) TestRemoveExportingModelDocs(c *gc.C) {
+	_, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := s.State2.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetMigrationMode(state.MigrationModeExporting)
+	c.Assert(err, jc.ErrorIsNil)
+	logger := state.NewDbLogger(s.State2, names.NewMachineTag("0"))
+	defer logger.Close()
+	err = logger.Log(time.Now(), "module", "loc", loggo.INFO, "message")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State2.RemoveExportingModelDocs()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.GetModel(model.ModelTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	records, err := state.ModelLogs(s.State2, time.Time{}, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 0)
+
+	redirect, err := s.State.ModelRedirect(s.State2.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(redirect, jc.DeepEquals, &state.ModelRedirect{
+		ModelUUID:      s.State2.ModelUUID(),
+		ControllerUUID: s.stdSpec.TargetInfo.ControllerTag.Id(),
+		Addrs:          s.stdSpec.TargetInfo.Addrs,
+		CACert:         s.stdSpec.TargetInfo.CACert,
+	})
+}
+
+func (s *ModelMigrationSuite) TestRemoveExportingModelDocsNotExporting(c *gc.C) {
+	_, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State2.RemoveExportingModelDocs()
+	c.Assert(err, gc.ErrorMatches, "transaction aborted")
+
+	_, err = s.State.ModelRedirect(s.State2.ModelUUID())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ModelMigrationSuite) TestModelRedirectNotFound(c *gc.C) {
+	_, err := s.State.ModelRedirect(s.State2.ModelUUID())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `redirect for model ".+" not found`)
+}
+
 func assertPhase(c *gc.C, mig state.ModelMigration, phase migration.Phase) {
 	actualPhase, err := mig.Phase()
 	c.Assert(err, jc.ErrorIsNil)
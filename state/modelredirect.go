@@ -0,0 +1,87 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ModelRedirect holds the details of the controller that a model was
+// migrated to. It is left behind when a model is removed from the
+// source controller after a successful migration so that clients
+// which still know about the model can be pointed at its new home.
+type ModelRedirect struct {
+	// ModelUUID holds the UUID of the migrated model.
+	ModelUUID string
+
+	// ControllerUUID holds the UUID of the controller the model was
+	// migrated to.
+	ControllerUUID string
+
+	// Addrs holds the host:port values of the target controller's
+	// API servers.
+	Addrs []string
+
+	// CACert holds the certificate used to validate the target
+	// controller's API server certificate, in PEM format.
+	CACert string
+}
+
+// modelRedirectDoc is the document stored in modelRedirectsC for
+// each model which has been migrated away from this controller.
+type modelRedirectDoc struct {
+	ModelUUID      string   `bson:"_id"`
+	ControllerUUID string   `bson:"controller-uuid"`
+	Addrs          []string `bson:"addrs"`
+	CACert         string   `bson:"cacert"`
+}
+
+// ModelRedirect returns the redirect record left for a model which
+// has been migrated to another controller. A NotFound error is
+// returned if the model has not been migrated away.
+func (st *State) ModelRedirect(modelUUID string) (*ModelRedirect, error) {
+	coll, closer := st.getCollection(modelRedirectsC)
+	defer closer()
+
+	var doc modelRedirectDoc
+	err := coll.FindId(modelUUID).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("redirect for model %q", modelUUID)
+	} else if err != nil {
+		return nil, errors.Annotate(err, "redirect lookup failed")
+	}
+	return &ModelRedirect{
+		ModelUUID:      doc.ModelUUID,
+		ControllerUUID: doc.ControllerUUID,
+		Addrs:          doc.Addrs,
+		CACert:         doc.CACert,
+	}, nil
+}
+
+// addModelRedirectOp returns the operation which records a redirect
+// for the State's model to the target controller of its active
+// migration.
+func (st *State) addModelRedirectOp() (txn.Op, error) {
+	mig, err := st.GetModelMigration()
+	if err != nil {
+		return txn.Op{}, errors.Trace(err)
+	}
+	targetInfo, err := mig.TargetInfo()
+	if err != nil {
+		return txn.Op{}, errors.Trace(err)
+	}
+	return txn.Op{
+		C:      modelRedirectsC,
+		Id:     st.ModelUUID(),
+		Assert: txn.DocMissing,
+		Insert: &modelRedirectDoc{
+			ModelUUID:      st.ModelUUID(),
+			ControllerUUID: targetInfo.ControllerTag.Id(),
+			Addrs:          targetInfo.Addrs,
+			CACert:         targetInfo.CACert,
+		},
+	}, nil
+}
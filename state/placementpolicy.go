@@ -0,0 +1,353 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// PlacementScope identifies the extent within which a placement policy
+// keeps an application's units apart.
+type PlacementScope string
+
+const (
+	// PlacementScopeNone places no restriction on where units go.
+	PlacementScopeNone PlacementScope = ""
+
+	// PlacementScopeMachine allows no two units on the same machine
+	// or container.
+	PlacementScopeMachine PlacementScope = "machine"
+
+	// PlacementScopeHost allows no two units on the same top level
+	// machine, including any containers it hosts.
+	PlacementScopeHost PlacementScope = "host"
+
+	// PlacementScopeZone allows no two units in the same availability
+	// zone.
+	PlacementScopeZone PlacementScope = "zone"
+)
+
+// Validate returns an error if the scope is not known.
+func (s PlacementScope) Validate() error {
+	switch s {
+	case PlacementScopeNone, PlacementScopeMachine, PlacementScopeHost, PlacementScopeZone:
+		return nil
+	}
+	return errors.NotValidf("placement scope %q", string(s))
+}
+
+// PlacementPolicy describes how an application's units are placed
+// relative to each other and to other applications.
+type PlacementPolicy struct {
+	// Spread is the scope within which no two of the application's
+	// units may be placed.
+	Spread PlacementScope
+
+	// Colocate names an application whose machines are preferred when
+	// assigning the application's units, as long as Spread allows it.
+	Colocate string
+}
+
+// Validate returns an error if the policy is not valid.
+func (p PlacementPolicy) Validate() error {
+	if err := p.Spread.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if p.Colocate != "" && !names.IsValidApplication(p.Colocate) {
+		return errors.NotValidf("application name %q", p.Colocate)
+	}
+	return nil
+}
+
+// placementPolicyDoc is the persistent form of a PlacementPolicy,
+// embedded in the application document.
+type placementPolicyDoc struct {
+	Spread   string `bson:"spread,omitempty"`
+	Colocate string `bson:"colocate,omitempty"`
+}
+
+// ErrPlacementPolicyViolated is returned when a unit cannot be assigned
+// to a machine without breaking its application's placement policy.
+type ErrPlacementPolicyViolated struct {
+	message string
+}
+
+func (e *ErrPlacementPolicyViolated) Error() string {
+	return "placement policy violated: " + e.message
+}
+
+// IsPlacementPolicyViolatedError returns if the given error or its cause
+// is ErrPlacementPolicyViolated.
+func IsPlacementPolicyViolatedError(err interface{}) bool {
+	if err == nil {
+		return false
+	}
+	// In case of a wrapped error, check the cause first.
+	value := err
+	cause := errors.Cause(err.(error))
+	if cause != nil {
+		value = cause
+	}
+	_, ok := value.(*ErrPlacementPolicyViolated)
+	return ok
+}
+
+// PlacementPolicy returns the application's placement policy.
+func (s *Application) PlacementPolicy() PlacementPolicy {
+	doc := s.doc.PlacementPolicy
+	if doc == nil {
+		return PlacementPolicy{}
+	}
+	return PlacementPolicy{
+		Spread:   PlacementScope(doc.Spread),
+		Colocate: doc.Colocate,
+	}
+}
+
+// SetPlacementPolicy sets the application's placement policy. The policy
+// applies to units assigned from now on; units already placed are left
+// where they are, and any that break the policy are reported by
+// PlacementPolicyViolations.
+func (s *Application) SetPlacementPolicy(policy PlacementPolicy) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set placement policy for application %q", s)
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if policy.Colocate == s.doc.Name {
+		return errors.New("application cannot be co-located with itself")
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     s.doc.DocID,
+		Assert: isAliveDoc,
+	}}
+	var doc *placementPolicyDoc
+	if policy == (PlacementPolicy{}) {
+		ops[0].Update = bson.D{{"$unset", bson.D{{"placement-policy", nil}}}}
+	} else {
+		doc = &placementPolicyDoc{
+			Spread:   string(policy.Spread),
+			Colocate: policy.Colocate,
+		}
+		ops[0].Update = bson.D{{"$set", bson.D{{"placement-policy", doc}}}}
+	}
+	if policy.Colocate != "" {
+		colocate, err := s.st.Application(policy.Colocate)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ops = append(ops, txn.Op{
+			C:      applicationsC,
+			Id:     colocate.doc.DocID,
+			Assert: isAliveDoc,
+		})
+	}
+	if err := s.st.runTransaction(ops); err != nil {
+		return onAbort(err, errNotAlive)
+	}
+	s.doc.PlacementPolicy = doc
+	return nil
+}
+
+// PlacementPolicyViolations describes each pair of the application's
+// units that share the scope its placement policy spreads them across.
+// A policy may be broken when units were placed before it was set, or
+// when the provider could only start a machine in a zone already in use.
+func (s *Application) PlacementPolicyViolations() ([]string, error) {
+	spread := s.PlacementPolicy().Spread
+	if spread == PlacementScopeNone {
+		return nil, nil
+	}
+	units, err := allUnits(s.st, s.doc.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Sort(unitsByName(units))
+	locator := newPlacementLocator(s.st, spread)
+	occupied := make(map[string]string)
+	var violations []string
+	for _, unit := range units {
+		if unit.Life() == Dead || unit.doc.MachineId == "" {
+			continue
+		}
+		location, err := locator.location(unit.doc.MachineId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if location == "" {
+			continue
+		}
+		if other, ok := occupied[location]; ok {
+			violations = append(violations, fmt.Sprintf(
+				"units %s and %s share %s %s", other, unit.Name(), spread, location,
+			))
+			continue
+		}
+		occupied[location] = unit.Name()
+	}
+	return violations, nil
+}
+
+// checkPlacementPolicy returns an ErrPlacementPolicyViolated if assigning
+// the unit to the supplied machine would put it within the same spread
+// scope as another unit of its application. A zone is only known once a
+// machine is provisioned, so zone spreading is not checked for machines
+// still waiting for an instance.
+func (u *Unit) checkPlacementPolicy(m *Machine) error {
+	application, err := u.Application()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	spread := application.PlacementPolicy().Spread
+	if spread == PlacementScopeNone {
+		return nil
+	}
+	locator := newPlacementLocator(u.st, spread)
+	target, err := locator.location(m.Id())
+	if err != nil || target == "" {
+		return errors.Trace(err)
+	}
+	units, err := allUnits(u.st, u.doc.Application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, other := range units {
+		if other.Name() == u.Name() || other.Life() == Dead || other.doc.MachineId == "" {
+			continue
+		}
+		location, err := locator.location(other.doc.MachineId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if location == target {
+			return &ErrPlacementPolicyViolated{fmt.Sprintf(
+				"unit %s already in %s %s", other.Name(), spread, target,
+			)}
+		}
+	}
+	return nil
+}
+
+// assignToColocatedMachineIfPreferred assigns the unit to a machine of
+// the application its placement policy prefers to be co-located with, if
+// there is one. It returns noCleanMachines if the policy names no such
+// application or none of its machines accepts the unit.
+func (u *Unit) assignToColocatedMachineIfPreferred() (*Machine, error) {
+	application, err := u.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	colocate := application.PlacementPolicy().Colocate
+	if colocate == "" {
+		return nil, noCleanMachines
+	}
+	return u.assignToColocatedMachine(colocate)
+}
+
+// assignToColocatedMachine assigns the unit to a machine hosting a unit
+// of the named application, trying machines in unit order and skipping
+// any that cannot take the unit or would break its placement policy.
+// It returns noCleanMachines if no such machine accepts the unit.
+func (u *Unit) assignToColocatedMachine(applicationName string) (*Machine, error) {
+	units, err := allUnits(u.st, applicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Sort(unitsByName(units))
+	storageParams, err := u.machineStorageParams()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tried := make(map[string]bool)
+	for _, unit := range units {
+		machineId := unit.doc.MachineId
+		if machineId == "" || tried[machineId] {
+			continue
+		}
+		tried[machineId] = true
+		m, err := u.st.Machine(machineId)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if m.Life() != Alive || m.Series() != u.doc.Series || !hasJob(m.doc.Jobs, JobHostUnits) {
+			continue
+		}
+		if err := validateDynamicMachineStorageParams(m, storageParams); err != nil {
+			if errors.IsNotSupported(err) {
+				continue
+			}
+			return nil, errors.Trace(err)
+		}
+		err = u.assignToMachine(m, false)
+		if err == nil {
+			return m, nil
+		}
+		if IsPlacementPolicyViolatedError(err) || errors.Cause(err) == machineNotAliveErr {
+			continue
+		}
+		return nil, err
+	}
+	return nil, noCleanMachines
+}
+
+// placementLocator maps machines to the location that identifies them
+// within a placement scope, caching what it has looked up.
+type placementLocator struct {
+	st    *State
+	scope PlacementScope
+	zones map[string]string
+}
+
+func newPlacementLocator(st *State, scope PlacementScope) *placementLocator {
+	return &placementLocator{
+		st:    st,
+		scope: scope,
+		zones: make(map[string]string),
+	}
+}
+
+// location returns the machine id, host machine id or availability zone
+// of the identified machine, according to the locator's scope. It
+// returns "" if the location is not yet known.
+func (l *placementLocator) location(machineId string) (string, error) {
+	switch l.scope {
+	case PlacementScopeMachine:
+		return machineId, nil
+	case PlacementScopeHost:
+		return TopParentId(machineId), nil
+	case PlacementScopeZone:
+		hostId := TopParentId(machineId)
+		if zone, ok := l.zones[hostId]; ok {
+			return zone, nil
+		}
+		host, err := l.st.Machine(hostId)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		zone, err := host.AvailabilityZone()
+		if errors.IsNotProvisioned(err) {
+			zone = ""
+		} else if err != nil {
+			return "", errors.Trace(err)
+		}
+		l.zones[hostId] = zone
+		return zone, nil
+	}
+	return "", nil
+}
+
+// unitsByName sorts units by name.
+type unitsByName []*Unit
+
+func (u unitsByName) Len() int           { return len(u) }
+func (u unitsByName) Less(i, j int) bool { return u[i].Name() < u[j].Name() }
+func (u unitsByName) Swap(i, j int)      { u[i], u[j] = u[j], u[i] }
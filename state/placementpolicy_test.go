@@ -0,0 +1,200 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+)
+
+type PlacementPolicySuite struct {
+	ConnSuite
+	wordpress *state.Application
+	mysql     *state.Application
+}
+
+var _ = gc.Suite(&PlacementPolicySuite{})
+
+func (s *PlacementPolicySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.wordpress = s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.mysql = s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+}
+
+func (s *PlacementPolicySuite) setPolicy(c *gc.C, policy state.PlacementPolicy) {
+	err := s.wordpress.SetPlacementPolicy(policy)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *PlacementPolicySuite) addUnit(c *gc.C, application *state.Application) *state.Unit {
+	unit, err := application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	return unit
+}
+
+func (s *PlacementPolicySuite) addMachine(c *gc.C, zone string) *state.Machine {
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	if zone != "" {
+		hc := instance.HardwareCharacteristics{AvailabilityZone: &zone}
+		err = m.SetProvisioned(instance.Id("inst-"+m.Id()), "fake_nonce", &hc)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return m
+}
+
+func (s *PlacementPolicySuite) addContainer(c *gc.C, host *state.Machine) *state.Machine {
+	m, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, host.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	return m
+}
+
+func (s *PlacementPolicySuite) assignToMachine(c *gc.C, unit *state.Unit, m *state.Machine) {
+	err := unit.AssignToMachine(m)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *PlacementPolicySuite) TestSetPlacementPolicy(c *gc.C) {
+	c.Assert(s.wordpress.PlacementPolicy(), gc.Equals, state.PlacementPolicy{})
+
+	policy := state.PlacementPolicy{
+		Spread:   state.PlacementScopeZone,
+		Colocate: "mysql",
+	}
+	s.setPolicy(c, policy)
+	c.Assert(s.wordpress.PlacementPolicy(), gc.Equals, policy)
+	err := s.wordpress.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpress.PlacementPolicy(), gc.Equals, policy)
+
+	s.setPolicy(c, state.PlacementPolicy{})
+	err = s.wordpress.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpress.PlacementPolicy(), gc.Equals, state.PlacementPolicy{})
+}
+
+func (s *PlacementPolicySuite) TestSetPlacementPolicyInvalid(c *gc.C) {
+	err := s.wordpress.SetPlacementPolicy(state.PlacementPolicy{Spread: "rack"})
+	c.Assert(err, gc.ErrorMatches, `cannot set placement policy for application "wordpress": placement scope "rack" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	err = s.wordpress.SetPlacementPolicy(state.PlacementPolicy{Colocate: "wordpress"})
+	c.Assert(err, gc.ErrorMatches, `cannot set placement policy for application "wordpress": application cannot be co-located with itself`)
+
+	err = s.wordpress.SetPlacementPolicy(state.PlacementPolicy{Colocate: "varnish"})
+	c.Assert(err, gc.ErrorMatches, `cannot set placement policy for application "wordpress": application "varnish" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *PlacementPolicySuite) TestSpreadMachine(c *gc.C) {
+	s.setPolicy(c, state.PlacementPolicy{Spread: state.PlacementScopeMachine})
+	m := s.addMachine(c, "")
+	s.assignToMachine(c, s.addUnit(c, s.wordpress), m)
+
+	err := s.addUnit(c, s.wordpress).AssignToMachine(m)
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "wordpress/1" to machine 0: placement policy violated: unit wordpress/0 already in machine 0`)
+	c.Assert(err, jc.Satisfies, state.IsPlacementPolicyViolatedError)
+
+	// A container on the same host is a different machine.
+	s.assignToMachine(c, s.addUnit(c, s.wordpress), s.addContainer(c, m))
+}
+
+func (s *PlacementPolicySuite) TestSpreadHost(c *gc.C) {
+	s.setPolicy(c, state.PlacementPolicy{Spread: state.PlacementScopeHost})
+	m := s.addMachine(c, "")
+	s.assignToMachine(c, s.addUnit(c, s.wordpress), m)
+
+	err := s.addUnit(c, s.wordpress).AssignToMachine(s.addContainer(c, m))
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "wordpress/1" to machine 0/lxd/0: placement policy violated: unit wordpress/0 already in host 0`)
+
+	// Units of other applications are not affected.
+	s.assignToMachine(c, s.addUnit(c, s.mysql), m)
+}
+
+func (s *PlacementPolicySuite) TestSpreadZone(c *gc.C) {
+	s.setPolicy(c, state.PlacementPolicy{Spread: state.PlacementScopeZone})
+	s.assignToMachine(c, s.addUnit(c, s.wordpress), s.addMachine(c, "zone-a"))
+
+	unit := s.addUnit(c, s.wordpress)
+	err := unit.AssignToMachine(s.addMachine(c, "zone-a"))
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "wordpress/1" to machine 1: placement policy violated: unit wordpress/0 already in zone zone-a`)
+
+	s.assignToMachine(c, unit, s.addMachine(c, "zone-b"))
+
+	// The zone of an unprovisioned machine is not yet known.
+	s.assignToMachine(c, s.addUnit(c, s.wordpress), s.addMachine(c, ""))
+}
+
+func (s *PlacementPolicySuite) TestSpreadSkipsCleanMachines(c *gc.C) {
+	s.setPolicy(c, state.PlacementPolicy{Spread: state.PlacementScopeZone})
+	s.assignToMachine(c, s.addUnit(c, s.wordpress), s.addMachine(c, "zone-a"))
+	s.addMachine(c, "zone-a")
+	other := s.addMachine(c, "zone-b")
+
+	unit := s.addUnit(c, s.wordpress)
+	m, err := unit.AssignToCleanMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Id(), gc.Equals, other.Id())
+}
+
+func (s *PlacementPolicySuite) TestColocate(c *gc.C) {
+	s.setPolicy(c, state.PlacementPolicy{Colocate: "mysql"})
+	m := s.addMachine(c, "")
+	s.assignToMachine(c, s.addUnit(c, s.mysql), m)
+
+	unit := s.addUnit(c, s.wordpress)
+	err := s.State.AssignUnit(unit, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, m.Id())
+}
+
+func (s *PlacementPolicySuite) TestColocateRespectsSpread(c *gc.C) {
+	s.setPolicy(c, state.PlacementPolicy{
+		Spread:   state.PlacementScopeMachine,
+		Colocate: "mysql",
+	})
+	m := s.addMachine(c, "")
+	s.assignToMachine(c, s.addUnit(c, s.mysql), m)
+
+	first := s.addUnit(c, s.wordpress)
+	err := s.State.AssignUnit(first, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	second := s.addUnit(c, s.wordpress)
+	err = s.State.AssignUnit(second, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	machineId, err := first.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, m.Id())
+	machineId, err = second.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Not(gc.Equals), m.Id())
+}
+
+func (s *PlacementPolicySuite) TestPlacementPolicyViolations(c *gc.C) {
+	m0 := s.addMachine(c, "zone-a")
+	m1 := s.addMachine(c, "zone-a")
+	s.assignToMachine(c, s.addUnit(c, s.wordpress), m0)
+	s.assignToMachine(c, s.addUnit(c, s.wordpress), m1)
+
+	violations, err := s.wordpress.PlacementPolicyViolations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(violations, gc.HasLen, 0)
+
+	s.setPolicy(c, state.PlacementPolicy{Spread: state.PlacementScopeZone})
+	violations, err = s.wordpress.PlacementPolicyViolations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(violations, jc.DeepEquals, []string{
+		"units wordpress/0 and wordpress/1 share zone zone-a",
+	})
+}
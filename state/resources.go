@@ -43,6 +43,14 @@ type Resources interface {
 	// OpenResourceForUniter returns the metadata for a resource and a reader for the resource.
 	OpenResourceForUniter(unit resource.Unit, name string) (resource.Resource, io.ReadCloser, error)
 
+	// ListResourceHistory returns the history of each of the
+	// application's resources.
+	ListResourceHistory(applicationID string) ([]resource.HistoryEntry, error)
+
+	// RollbackResource makes the identified revision in the history of
+	// the application's resource the active resource.
+	RollbackResource(applicationID, name string, revision int) error
+
 	// SetCharmStoreResources sets the "polled" resources for the
 	// service to the provided values.
 	SetCharmStoreResources(applicationID string, info []charmresource.Resource, lastPolled time.Time) error
@@ -46,6 +46,10 @@ func unitResourceID(id, unitID string) string {
 	return resourceID(id, "unit", unitID)
 }
 
+func historyResourceID(id string, revision int) string {
+	return resourceID(id, "history", fmt.Sprint(revision))
+}
+
 // stagedResourceID converts an external resource ID into an internal
 // staged one.
 func stagedResourceID(id string) string {
@@ -173,6 +177,17 @@ func newUpdateUnitResourceOps(unitID string, stored storedResource, progress *in
 	}}, newInsertUnitResourceOps(unitID, stored, progress)...)
 }
 
+func newInsertResourceHistoryOps(stored storedResource, revision int) []txn.Op {
+	doc := newResourceHistoryDoc(stored, revision)
+
+	return []txn.Op{{
+		C:      resourcesC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+}
+
 func newRemoveResourcesOps(docs []resourceDoc) []txn.Op {
 	// The likelihood of a race is small and the consequences are minor,
 	// so we don't worry about the corner case of missing a doc here.
@@ -246,6 +261,15 @@ func newResourceDoc(stored storedResource) *resourceDoc {
 	return resource2doc(fullID, stored)
 }
 
+// newResourceHistoryDoc generates a doc that records the given
+// resource in the history of the application's resource.
+func newResourceHistoryDoc(stored storedResource, revision int) *resourceDoc {
+	fullID := historyResourceID(stored.ID, revision)
+	doc := resource2doc(fullID, stored)
+	doc.HistoryRevision = revision
+	return doc
+}
+
 // newStagedResourceDoc generates a staging doc that represents
 // the given resource.
 func newStagedResourceDoc(stored storedResource) *resourceDoc {
@@ -265,6 +289,19 @@ func (p ResourcePersistence) resources(applicationID string) ([]resourceDoc, err
 	return docs, nil
 }
 
+// resourceHistory returns the history docs for the given resource.
+func (p ResourcePersistence) resourceHistory(resID string) ([]resourceDoc, error) {
+	var docs []resourceDoc
+	query := bson.D{
+		{"resource-id", resID},
+		{"history-revision", bson.D{{"$gt", 0}}},
+	}
+	if err := p.base.All(resourcesC, query, &docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return docs, nil
+}
+
 func (p ResourcePersistence) unitResources(unitID string) ([]resourceDoc, error) {
 	var docs []resourceDoc
 	query := bson.D{{"unit-id", unitID}}
@@ -285,6 +322,18 @@ func (p ResourcePersistence) getOne(resID string) (resourceDoc, error) {
 	return doc, nil
 }
 
+// getOneHistory returns the identified revision in the history of
+// the resource.
+func (p ResourcePersistence) getOneHistory(resID string, revision int) (resourceDoc, error) {
+	logger.Tracef("querying db for resource %q (history revision %d)", resID, revision)
+	id := historyResourceID(resID, revision)
+	var doc resourceDoc
+	if err := p.base.One(resourcesC, id, &doc); err != nil {
+		return doc, errors.Trace(err)
+	}
+	return doc, nil
+}
+
 // getOnePending returns the resource that matches the provided model ID.
 func (p ResourcePersistence) getOnePending(resID, pendingID string) (resourceDoc, error) {
 	logger.Tracef("querying db for resource %q (pending %q)", resID, pendingID)
@@ -323,6 +372,8 @@ type resourceDoc struct {
 	DownloadProgress *int64 `bson:"download-progress,omitempty"`
 
 	LastPolled time.Time `bson:"timestamp-when-last-polled"`
+
+	HistoryRevision int `bson:"history-revision,omitempty"`
 }
 
 func charmStoreResource2Doc(id string, res charmStoreResource) *resourceDoc {
@@ -4,6 +4,8 @@
 package state
 
 import (
+	"bytes"
+	"sort"
 	"time"
 
 	"github.com/juju/errors"
@@ -19,6 +21,10 @@ const (
 	// CleanupKindResourceBlob identifies the cleanup kind
 	// for resource blobs.
 	CleanupKindResourceBlob = "resourceBlob"
+
+	// resourceHistorySize is the number of revisions kept in the
+	// history of each of an application's resources.
+	resourceHistorySize = 5
 )
 
 // ResourcePersistenceBase exposes the core persistence functionality
@@ -78,7 +84,7 @@ func (p ResourcePersistence) ListResources(applicationID string) (resource.Servi
 
 	var results resource.ServiceResources
 	for _, doc := range docs {
-		if doc.PendingID != "" {
+		if doc.PendingID != "" || doc.HistoryRevision != 0 {
 			continue
 		}
 
@@ -143,6 +149,40 @@ func (p ResourcePersistence) ListPendingResources(applicationID string) ([]resou
 	return resources, nil
 }
 
+// ListResourceHistory returns the history of each of the identified
+// application's resources, sorted by resource name and revision.
+func (p ResourcePersistence) ListResourceHistory(applicationID string) ([]resource.HistoryEntry, error) {
+	docs, err := p.resources(applicationID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	current := make(map[string]string)
+	for _, doc := range docs {
+		if doc.DocID == applicationResourceID(doc.ID) {
+			current[doc.ID] = doc.StoragePath
+		}
+	}
+
+	var history []resource.HistoryEntry
+	for _, doc := range docs {
+		if doc.HistoryRevision == 0 {
+			continue
+		}
+		res, err := doc2basicResource(doc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		history = append(history, resource.HistoryEntry{
+			Resource:        res,
+			HistoryRevision: doc.HistoryRevision,
+			Current:         current[doc.ID] == doc.StoragePath,
+		})
+	}
+	resource.SortHistory(history)
+	return history, nil
+}
+
 // GetResource returns the extended, model-related info for the non-pending
 // resource.
 func (p ResourcePersistence) GetResource(id string) (res resource.Resource, storagePath string, _ error) {
@@ -369,6 +409,126 @@ func (p ResourcePersistence) NewResolvePendingResourceOps(resID, pendingID strin
 	return ops, nil
 }
 
+// RollbackResource makes the identified revision in the history of the
+// resource the active resource. As with a new upload, the application's
+// CharmModifiedVersion is incremented if the content changes.
+func (p ResourcePersistence) RollbackResource(id string, revision int) error {
+	doc, err := p.getOneHistory(id, revision)
+	if errors.IsNotFound(err) {
+		return errors.NotFoundf("revision %d of resource %q", revision, id)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	entry, err := doc2resource(doc)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		current, err := p.getOne(id)
+		if errors.IsNotFound(err) {
+			return nil, errors.NotFoundf("resource %q", id)
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if current.StoragePath == entry.storagePath {
+			return nil, jujutxn.ErrNoOperations
+		}
+
+		// The current resource is recorded in the history, if it
+		// isn't there already, so that the rollback may be undone.
+		ops, err := p.newResourceHistoryOps(id, entry.storagePath)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, newUpdateResourceOps(entry)...)
+		ops = append(ops, p.base.ApplicationExistsOps(entry.ApplicationID)...)
+		if !bytes.Equal(current.Fingerprint, entry.Fingerprint.Bytes()) {
+			ops = append(ops, p.base.IncCharmModifiedVersionOps(entry.ApplicationID)...)
+		}
+		return ops, nil
+	}
+	if err := p.base.Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// newResourceHistoryOps returns the operations that record the given
+// resources, in order, in the history of the identified resource. The
+// resource's current content is recorded first if it isn't in the
+// history already, as is the case for resources resolved at deploy
+// time. The oldest entries beyond resourceHistorySize are dropped,
+// along with their blobs, except for the one with the active storage
+// path.
+func (p ResourcePersistence) newResourceHistoryOps(id, active string, added ...storedResource) ([]txn.Op, error) {
+	history, err := p.resourceHistory(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Sort(byHistoryRevision(history))
+
+	latest := 0
+	recorded := make(map[string]bool)
+	for _, doc := range history {
+		recorded[doc.StoragePath] = true
+		latest = doc.HistoryRevision
+	}
+
+	current, err := p.getOne(id)
+	if errors.IsNotFound(err) {
+		// There is nothing to record yet.
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	} else if current.StoragePath != "" && !recorded[current.StoragePath] {
+		stored, err := doc2resource(current)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		added = append([]storedResource{stored}, added...)
+	}
+
+	var ops []txn.Op
+	kept := make(map[string]bool)
+	for _, stored := range added {
+		if stored.storagePath == "" {
+			// There is no content to roll back to.
+			continue
+		}
+		latest++
+		kept[stored.storagePath] = true
+		ops = append(ops, newInsertResourceHistoryOps(stored, latest)...)
+	}
+
+	// The oldest entries are dropped first, but never the content
+	// that is about to become current.
+	count := len(history) + len(ops)
+	var dropped []resourceDoc
+	for _, doc := range history {
+		if count > resourceHistorySize && doc.StoragePath != active {
+			dropped = append(dropped, doc)
+			count--
+			continue
+		}
+		kept[doc.StoragePath] = true
+	}
+	for _, doc := range dropped {
+		ops = append(ops, newRemoveResourcesOps([]resourceDoc{doc})...)
+		if !kept[doc.StoragePath] {
+			ops = append(ops, p.base.NewCleanupOp(CleanupKindResourceBlob, doc.StoragePath))
+		}
+	}
+	return ops, nil
+}
+
+type byHistoryRevision []resourceDoc
+
+func (b byHistoryRevision) Len() int           { return len(b) }
+func (b byHistoryRevision) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byHistoryRevision) Less(i, j int) bool { return b[i].HistoryRevision < b[j].HistoryRevision }
+
 // NewRemoveUnitResourcesOps returns mgo transaction operations
 // that remove resource information specific to the unit from state.
 func (p ResourcePersistence) NewRemoveUnitResourcesOps(unitID string) ([]txn.Op, error) {
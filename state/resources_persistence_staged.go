@@ -96,6 +96,15 @@ func (staged StagedResource) Activate() error {
 				incOps := staged.base.IncCharmModifiedVersionOps(staged.stored.ApplicationID)
 				ops = append(ops, incOps...)
 			}
+
+			// The resource is recorded in its history so that the
+			// application may later be rolled back to it.
+			p := NewResourcePersistence(staged.base)
+			historyOps, err := p.newResourceHistoryOps(staged.stored.ID, staged.stored.storagePath, staged.stored)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, historyOps...)
 		}
 		logger.Debugf("activate ops: %#v", ops)
 		return ops, nil
@@ -4,6 +4,8 @@
 package state
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -117,15 +119,18 @@ func (s *StagedResourceSuite) TestUnstageOkay(c *gc.C) {
 
 func (s *StagedResourceSuite) TestActivateOkay(c *gc.C) {
 	staged, doc := s.newStagedResource(c, "a-application", "spam")
+	historyDoc := doc
+	historyDoc.DocID += "#history-1"
+	historyDoc.HistoryRevision = 1
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, nil, nil, nil, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, nil, nil, nil, nil, ignoredErr)
 
 	err := staged.Activate()
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.stub.CheckCallNames(c, "Run", "ApplicationExistsOps", "One", "IncCharmModifiedVersionOps", "RunTransaction")
+	s.stub.CheckCallNames(c, "Run", "ApplicationExistsOps", "One", "IncCharmModifiedVersionOps", "All", "One", "RunTransaction")
 	s.stub.CheckCall(c, 3, "IncCharmModifiedVersionOps", "a-application")
-	s.stub.CheckCall(c, 4, "RunTransaction", []txn.Op{{
+	s.stub.CheckCall(c, 6, "RunTransaction", []txn.Op{{
 		C:      "resources",
 		Id:     "resource#a-application/spam",
 		Assert: txn.DocMissing,
@@ -138,20 +143,31 @@ func (s *StagedResourceSuite) TestActivateOkay(c *gc.C) {
 		C:      "resources",
 		Id:     "resource#a-application/spam#staged",
 		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-application/spam#history-1",
+		Assert: txn.DocMissing,
+		Insert: &historyDoc,
 	}})
 }
 
 func (s *StagedResourceSuite) TestActivateExists(c *gc.C) {
 	staged, doc := s.newStagedResource(c, "a-application", "spam")
+	historyDoc := doc
+	historyDoc.DocID += "#history-1"
+	historyDoc.HistoryRevision = 1
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, nil, nil, txn.ErrAborted, nil, nil, nil, nil, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, nil, nil, nil, txn.ErrAborted, nil, nil, nil, nil, nil, nil, ignoredErr)
 
 	err := staged.Activate()
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.stub.CheckCallNames(c, "Run", "ApplicationExistsOps", "One", "IncCharmModifiedVersionOps", "RunTransaction", "ApplicationExistsOps", "One", "IncCharmModifiedVersionOps", "RunTransaction")
+	s.stub.CheckCallNames(c,
+		"Run", "ApplicationExistsOps", "One", "IncCharmModifiedVersionOps", "All", "One", "RunTransaction",
+		"ApplicationExistsOps", "One", "IncCharmModifiedVersionOps", "All", "One", "RunTransaction",
+	)
 	s.stub.CheckCall(c, 3, "IncCharmModifiedVersionOps", "a-application")
-	s.stub.CheckCall(c, 4, "RunTransaction", []txn.Op{{
+	s.stub.CheckCall(c, 6, "RunTransaction", []txn.Op{{
 		C:      "resources",
 		Id:     "resource#a-application/spam",
 		Assert: txn.DocMissing,
@@ -164,9 +180,14 @@ func (s *StagedResourceSuite) TestActivateExists(c *gc.C) {
 		C:      "resources",
 		Id:     "resource#a-application/spam#staged",
 		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-application/spam#history-1",
+		Assert: txn.DocMissing,
+		Insert: &historyDoc,
 	}})
-	s.stub.CheckCall(c, 7, "IncCharmModifiedVersionOps", "a-application")
-	s.stub.CheckCall(c, 8, "RunTransaction", []txn.Op{{
+	s.stub.CheckCall(c, 9, "IncCharmModifiedVersionOps", "a-application")
+	s.stub.CheckCall(c, 12, "RunTransaction", []txn.Op{{
 		C:      "resources",
 		Id:     "resource#a-application/spam",
 		Assert: txn.DocExists,
@@ -184,5 +205,64 @@ func (s *StagedResourceSuite) TestActivateExists(c *gc.C) {
 		C:      "resources",
 		Id:     "resource#a-application/spam#staged",
 		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-application/spam#history-1",
+		Assert: txn.DocMissing,
+		Insert: &historyDoc,
+	}})
+}
+
+func (s *StagedResourceSuite) TestActivateDropsOldHistory(c *gc.C) {
+	staged, doc := s.newStagedResource(c, "a-application", "spam")
+	staged.stored.storagePath += "-new"
+	doc.StoragePath += "-new"
+	var history []resourceDoc
+	for i := 1; i <= 5; i++ {
+		_, old := newPersistenceResource(c, "a-application", "spam")
+		old.DocID += fmt.Sprintf("#history-%d", i)
+		old.StoragePath += fmt.Sprintf("-%d", i)
+		old.HistoryRevision = i
+		history = append(history, old)
+	}
+	s.base.ReturnAll = history
+	s.base.ReturnOne = history[4]
+	s.base.ReturnNewCleanupOp = &txn.Op{C: "cleanups", Id: "cleanup-id"}
+	historyDoc := doc
+	historyDoc.DocID += "#history-6"
+	historyDoc.HistoryRevision = 6
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, nil, nil, nil, nil, nil, ignoredErr)
+
+	err := staged.Activate()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "ApplicationExistsOps", "One", "All", "One", "NewCleanupOp", "RunTransaction")
+	s.stub.CheckCall(c, 5, "NewCleanupOp", "resourceBlob", "application-a-application/resources/spam-1")
+	s.stub.CheckCall(c, 6, "RunTransaction", []txn.Op{{
+		C:      "resources",
+		Id:     "resource#a-application/spam",
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}, {
+		C:      "application",
+		Id:     "a-application",
+		Assert: txn.DocExists,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-application/spam#staged",
+		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-application/spam#history-6",
+		Assert: txn.DocMissing,
+		Insert: &historyDoc,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-application/spam#history-1",
+		Remove: true,
+	}, {
+		C:  "cleanups",
+		Id: "cleanup-id",
 	}})
 }
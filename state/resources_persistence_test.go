@@ -4,6 +4,7 @@
 package state
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/juju/errors"
@@ -103,6 +104,66 @@ func (s *ResourcePersistenceSuite) TestListResourcesIgnorePending(c *gc.C) {
 	checkResources(c, resources, expected)
 }
 
+func (s *ResourcePersistenceSuite) TestListResourcesIgnoreHistory(c *gc.C) {
+	expected, docs := newPersistenceResources(c, "a-application", "spam")
+	_, historyDoc := newPersistenceResource(c, "a-application", "spam")
+	historyDoc.DocID += "#history-1"
+	historyDoc.HistoryRevision = 1
+	docs = append(docs, historyDoc)
+	s.base.ReturnAll = docs
+	p := NewResourcePersistence(s.base)
+
+	resources, err := p.ListResources("a-application")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "All")
+	checkResources(c, resources, expected)
+}
+
+func (s *ResourcePersistenceSuite) TestListResourceHistory(c *gc.C) {
+	_, current := newPersistenceResource(c, "a-application", "spam")
+	var docs []resourceDoc
+	var expected []resource.HistoryEntry
+	for i := 2; i >= 1; i-- {
+		stored, doc := newPersistenceResource(c, "a-application", "spam")
+		doc.DocID += fmt.Sprintf("#history-%d", i)
+		doc.StoragePath += fmt.Sprintf("-%d", i)
+		doc.HistoryRevision = i
+		docs = append(docs, doc)
+		expected = append([]resource.HistoryEntry{{
+			Resource:        stored.Resource,
+			HistoryRevision: i,
+			Current:         i == 2,
+		}}, expected...)
+	}
+	current.StoragePath = docs[0].StoragePath
+	docs = append(docs, current)
+	s.base.ReturnAll = docs
+	p := NewResourcePersistence(s.base)
+
+	history, err := p.ListResourceHistory("a-application")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "All")
+	s.stub.CheckCall(c, 0, "All",
+		"resources",
+		bson.D{{"application-id", "a-application"}},
+		&docs,
+	)
+	c.Check(history, jc.DeepEquals, expected)
+}
+
+func (s *ResourcePersistenceSuite) TestRollbackResourceNotFound(c *gc.C) {
+	p := NewResourcePersistence(s.base)
+
+	err := p.RollbackResource("a-application/spam", 3)
+
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	c.Check(err, gc.ErrorMatches, `revision 3 of resource "a-application/spam" not found`)
+	s.stub.CheckCallNames(c, "One")
+	s.stub.CheckCall(c, 0, "One", "resources", "resource#a-application/spam#history-3", &resourceDoc{})
+}
+
 func (s *ResourcePersistenceSuite) TestListResourcesBaseError(c *gc.C) {
 	failure := errors.New("<failure>")
 	s.stub.SetErrors(failure)
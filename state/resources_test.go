@@ -5,6 +5,7 @@ package state_test
 
 import (
 	"bytes"
+	"io/ioutil"
 	"time"
 
 	jc "github.com/juju/testing/checkers"
@@ -63,6 +64,59 @@ func (s *ResourcesSuite) TestFunctional(c *gc.C) {
 	// TODO(ericsnow) Add more as state.Resources grows more functionality.
 }
 
+func (s *ResourcesSuite) TestHistoryAndRollback(c *gc.C) {
+	ch := s.ConnSuite.AddTestingCharm(c, "wordpress")
+	app := s.ConnSuite.AddTestingService(c, "a-application", ch)
+
+	st, err := s.State.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+
+	first := newResource(c, "spam", "spamspamspam")
+	_, err = st.SetResource("a-application", first.Username, first.Resource, bytes.NewBufferString("spamspamspam"))
+	c.Assert(err, jc.ErrorIsNil)
+	second := newResource(c, "spam", "eggs")
+	_, err = st.SetResource("a-application", second.Username, second.Resource, bytes.NewBufferString("eggs"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := st.ListResourceHistory("a-application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Check(history[0].HistoryRevision, gc.Equals, 1)
+	c.Check(history[0].Fingerprint, jc.DeepEquals, first.Fingerprint)
+	c.Check(history[0].Current, jc.IsFalse)
+	c.Check(history[1].HistoryRevision, gc.Equals, 2)
+	c.Check(history[1].Fingerprint, jc.DeepEquals, second.Fingerprint)
+	c.Check(history[1].Current, jc.IsTrue)
+
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	version := app.CharmModifiedVersion()
+
+	err = st.RollbackResource("a-application", "spam", 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	res, reader, err := st.OpenResource("a-application", "spam")
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+	c.Check(res.Fingerprint, jc.DeepEquals, first.Fingerprint)
+	data, err := ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "spamspamspam")
+
+	history, err = st.ListResourceHistory("a-application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Check(history[0].Current, jc.IsTrue)
+	c.Check(history[1].Current, jc.IsFalse)
+
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(app.CharmModifiedVersion(), gc.Equals, version+1)
+
+	err = st.RollbackResource("a-application", "spam", 3)
+	c.Check(err, gc.ErrorMatches, `revision 3 of resource "a-application/spam" not found`)
+}
+
 func newResource(c *gc.C, name, data string) resource.Resource {
 	opened := resourcetesting.NewResource(c, nil, name, "a-application", data)
 	res := opened.Resource
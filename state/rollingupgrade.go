@@ -0,0 +1,275 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/status"
+)
+
+// rollingUpgradeDoc records the progress of a charm upgrade that is
+// applied to an application's units in batches. It is embedded in the
+// application document while the upgrade is in progress, and removed
+// once every unit has been released to the new charm.
+type rollingUpgradeDoc struct {
+	PreviousCharmURL *charm.URL    `bson:"previous-charmurl"`
+	BatchSize        int           `bson:"batch-size"`
+	BatchTimeout     time.Duration `bson:"batch-timeout"`
+	Released         []string      `bson:"released"`
+	BatchStarted     time.Time     `bson:"batch-started"`
+	Halted           bool          `bson:"halted"`
+	Message          string        `bson:"message,omitempty"`
+}
+
+// RollingUpgrade describes the progress of a charm upgrade that is being
+// applied to an application's units in batches.
+type RollingUpgrade struct {
+	// PreviousCharmURL is the charm that units not yet released to
+	// the upgrade continue to run.
+	PreviousCharmURL *charm.URL
+
+	// BatchSize is the maximum number of units upgraded at once.
+	BatchSize int
+
+	// BatchTimeout is how long a batch of units may take to return
+	// to an active and idle state before the upgrade is halted. Zero
+	// means no limit.
+	BatchTimeout time.Duration
+
+	// Released holds the names of the units that have been allowed
+	// to upgrade to the application's charm.
+	Released []string
+
+	// BatchStarted is when the most recent batch of units was
+	// released.
+	BatchStarted time.Time
+
+	// Halted indicates that the upgrade was stopped because a unit
+	// failed or did not settle in time; Message describes why.
+	Halted  bool
+	Message string
+}
+
+// RollingUpgrade returns the progress of the application's rolling
+// charm upgrade, and whether one is in progress.
+func (s *Application) RollingUpgrade() (RollingUpgrade, bool) {
+	doc := s.doc.RollingUpgrade
+	if doc == nil {
+		return RollingUpgrade{}, false
+	}
+	released := make([]string, len(doc.Released))
+	copy(released, doc.Released)
+	return RollingUpgrade{
+		PreviousCharmURL: doc.PreviousCharmURL,
+		BatchSize:        doc.BatchSize,
+		BatchTimeout:     doc.BatchTimeout,
+		Released:         released,
+		BatchStarted:     doc.BatchStarted,
+		Halted:           doc.Halted,
+		Message:          doc.Message,
+	}, true
+}
+
+// CharmURLForUnit returns the charm URL that the named unit of the
+// application should be running, along with the force flag. This is
+// the application's charm unless a rolling upgrade is in progress and
+// the unit has not yet been released to it.
+func (s *Application) CharmURLForUnit(unitName string) (curl *charm.URL, force bool) {
+	doc := s.doc.RollingUpgrade
+	if doc == nil {
+		return s.CharmURL()
+	}
+	for _, name := range doc.Released {
+		if name == unitName {
+			return s.CharmURL()
+		}
+	}
+	return doc.PreviousCharmURL, false
+}
+
+// setRollingUpgradeOp returns an operation that starts a rolling upgrade
+// away from the application's current charm, along with the recorded
+// upgrade; or that abandons any rolling upgrade in progress if batchSize
+// is zero.
+func (s *Application) setRollingUpgradeOp(batchSize int, batchTimeout time.Duration) (txn.Op, *rollingUpgradeDoc, error) {
+	if batchSize < 0 {
+		return txn.Op{}, nil, errors.NotValidf("batch size %d", batchSize)
+	}
+	if batchTimeout < 0 {
+		return txn.Op{}, nil, errors.NotValidf("batch timeout %v", batchTimeout)
+	}
+	op := txn.Op{
+		C:      applicationsC,
+		Id:     s.doc.DocID,
+		Update: bson.D{{"$unset", bson.D{{"rolling-upgrade", nil}}}},
+	}
+	if batchSize == 0 {
+		return op, nil, nil
+	}
+	doc := &rollingUpgradeDoc{
+		PreviousCharmURL: s.doc.CharmURL,
+		BatchSize:        batchSize,
+		BatchTimeout:     batchTimeout,
+		Released:         []string{},
+	}
+	op.Update = bson.D{{"$set", bson.D{{"rolling-upgrade", doc}}}}
+	return op, doc, nil
+}
+
+// AdvanceRollingUpgrade moves the application's rolling charm upgrade
+// along. Once every unit in the current batch runs the new charm and is
+// active and idle, the next batch of units is released to upgrade; when
+// no units remain the upgrade is complete. The upgrade is halted if a
+// released unit is in error, or if the batch does not settle within the
+// batch timeout measured from now. It does nothing if no rolling upgrade
+// is in progress or if the upgrade has been halted.
+func (s *Application) AdvanceRollingUpgrade(now time.Time) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot advance rolling upgrade of application %q", s)
+	service := &Application{st: s.st, doc: s.doc}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := service.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		doc := service.doc.RollingUpgrade
+		if doc == nil || doc.Halted {
+			return nil, jujutxn.ErrNoOperations
+		}
+		update, err := service.advanceRollingUpgrade(doc, now)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if update == nil {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     service.doc.DocID,
+			Assert: bson.D{{"txn-revno", service.doc.TxnRevno}},
+			Update: update,
+		}}, nil
+	}
+	if err := s.st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.Refresh())
+}
+
+// advanceRollingUpgrade returns the update to apply to the application
+// document to move the supplied rolling upgrade along, or nil if it must
+// wait for released units to settle.
+func (s *Application) advanceRollingUpgrade(doc *rollingUpgradeDoc, now time.Time) (bson.D, error) {
+	units, err := s.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	released := set.NewStrings(doc.Released...)
+	var pending, waiting []string
+	for _, unit := range units {
+		if unit.Life() != Alive {
+			continue
+		}
+		if !released.Contains(unit.Name()) {
+			pending = append(pending, unit.Name())
+			continue
+		}
+		ready, failure, err := s.rollingUpgradeUnitReady(unit)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if failure != "" {
+			return haltRollingUpgradeUpdate(failure), nil
+		}
+		if !ready {
+			waiting = append(waiting, unit.Name())
+		}
+	}
+
+	if len(waiting) > 0 {
+		if doc.BatchTimeout > 0 && now.Sub(doc.BatchStarted) > doc.BatchTimeout {
+			sort.Strings(waiting)
+			return haltRollingUpgradeUpdate(fmt.Sprintf(
+				"timed out waiting for units %s", strings.Join(waiting, ", "),
+			)), nil
+		}
+		return nil, nil
+	}
+	if len(pending) == 0 {
+		return bson.D{{"$unset", bson.D{{"rolling-upgrade", nil}}}}, nil
+	}
+
+	sort.Strings(pending)
+	if len(pending) > doc.BatchSize {
+		pending = pending[:doc.BatchSize]
+	}
+	return bson.D{
+		{"$push", bson.D{{"rolling-upgrade.released", bson.D{{"$each", pending}}}}},
+		{"$set", bson.D{{"rolling-upgrade.batch-started", now}}},
+	}, nil
+}
+
+// rollingUpgradeUnitReady reports whether the supplied unit, which has been
+// released to upgrade, runs the application's charm and has returned to an
+// active workload and an idle agent. If the unit is in error, a description
+// of the failure is returned instead.
+func (s *Application) rollingUpgradeUnitReady(unit *Unit) (bool, string, error) {
+	agentStatus, err := unit.AgentStatus()
+	if err != nil {
+		return false, "", errors.Trace(err)
+	}
+	workloadStatus, err := unit.Status()
+	if err != nil {
+		return false, "", errors.Trace(err)
+	}
+	for _, info := range []status.StatusInfo{agentStatus, workloadStatus} {
+		if info.Status == status.StatusError {
+			return false, fmt.Sprintf("unit %s is in error: %s", unit.Name(), info.Message), nil
+		}
+	}
+	curl, _ := unit.CharmURL()
+	if curl == nil || *curl != *s.doc.CharmURL {
+		return false, "", nil
+	}
+	ready := agentStatus.Status == status.StatusIdle && workloadStatus.Status == status.StatusActive
+	return ready, "", nil
+}
+
+// haltRollingUpgradeUpdate returns an update that halts a rolling upgrade
+// for the supplied reason.
+func haltRollingUpgradeUpdate(message string) bson.D {
+	return bson.D{{"$set", bson.D{
+		{"rolling-upgrade.halted", true},
+		{"rolling-upgrade.message", message},
+	}}}
+}
+
+// RollingUpgradeApplications returns the applications with a rolling charm
+// upgrade in progress, including those whose upgrade has been halted.
+func (st *State) RollingUpgradeApplications() ([]*Application, error) {
+	applicationsCollection, closer := st.getCollection(applicationsC)
+	defer closer()
+
+	var docs []applicationDoc
+	query := bson.D{{"rolling-upgrade", bson.D{{"$exists", true}}}}
+	if err := applicationsCollection.Find(query).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get applications with rolling upgrades")
+	}
+	applications := make([]*Application, len(docs))
+	for i := range docs {
+		applications[i] = newApplication(st, &docs[i])
+	}
+	return applications, nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type RollingUpgradeSuite struct {
+	ConnSuite
+	charm    *state.Charm
+	newCharm *state.Charm
+	mysql    *state.Application
+	units    []*state.Unit
+	now      time.Time
+}
+
+var _ = gc.Suite(&RollingUpgradeSuite{})
+
+func (s *RollingUpgradeSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.charm = s.AddTestingCharm(c, "mysql")
+	s.newCharm = s.AddMetaCharm(c, "mysql", metaBase, 2)
+	s.mysql = s.AddTestingService(c, "mysql", s.charm)
+	s.units = nil
+	for i := 0; i < 3; i++ {
+		unit, err := s.mysql.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+		s.units = append(s.units, unit)
+	}
+	s.now = time.Date(2016, 7, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *RollingUpgradeSuite) startRollingUpgrade(c *gc.C, batchTimeout time.Duration) {
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:        s.newCharm,
+		BatchSize:    2,
+		BatchTimeout: batchTimeout,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RollingUpgradeSuite) setUnitReady(c *gc.C, unit *state.Unit) {
+	err := unit.SetCharmURL(s.newCharm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetAgentStatus(status.StatusInfo{Status: status.StatusIdle})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetStatus(status.StatusInfo{Status: status.StatusActive})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RollingUpgradeSuite) assertCharmURLs(c *gc.C, expect ...*state.Charm) {
+	err := s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	for i, unit := range s.units {
+		curl, _ := s.mysql.CharmURLForUnit(unit.Name())
+		c.Check(curl, gc.DeepEquals, expect[i].URL(), gc.Commentf("unit %s", unit.Name()))
+	}
+}
+
+func (s *RollingUpgradeSuite) TestSetCharmStartsRollingUpgrade(c *gc.C) {
+	s.startRollingUpgrade(c, time.Minute)
+
+	rolling, ok := s.mysql.RollingUpgrade()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(rolling, jc.DeepEquals, state.RollingUpgrade{
+		PreviousCharmURL: s.charm.URL(),
+		BatchSize:        2,
+		BatchTimeout:     time.Minute,
+		Released:         []string{},
+	})
+	curl, _ := s.mysql.CharmURL()
+	c.Check(curl, gc.DeepEquals, s.newCharm.URL())
+	s.assertCharmURLs(c, s.charm, s.charm, s.charm)
+}
+
+func (s *RollingUpgradeSuite) TestSetCharmWithoutBatchSize(c *gc.C) {
+	err := s.mysql.SetCharm(state.SetCharmConfig{Charm: s.newCharm})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, ok := s.mysql.RollingUpgrade()
+	c.Check(ok, jc.IsFalse)
+	s.assertCharmURLs(c, s.newCharm, s.newCharm, s.newCharm)
+}
+
+func (s *RollingUpgradeSuite) TestSetCharmAbandonsRollingUpgrade(c *gc.C) {
+	s.startRollingUpgrade(c, 0)
+	newerCharm := s.AddMetaCharm(c, "mysql", metaBase, 3)
+
+	err := s.mysql.SetCharm(state.SetCharmConfig{Charm: newerCharm})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, ok := s.mysql.RollingUpgrade()
+	c.Check(ok, jc.IsFalse)
+	s.assertCharmURLs(c, newerCharm, newerCharm, newerCharm)
+}
+
+func (s *RollingUpgradeSuite) TestSetCharmNegativeBatchSize(c *gc.C) {
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:     s.newCharm,
+		BatchSize: -1,
+	})
+	c.Assert(err, gc.ErrorMatches, "batch size -1 not valid")
+}
+
+func (s *RollingUpgradeSuite) TestAdvanceReleasesBatches(c *gc.C) {
+	s.startRollingUpgrade(c, 0)
+
+	err := s.mysql.AdvanceRollingUpgrade(s.now)
+	c.Assert(err, jc.ErrorIsNil)
+	rolling, ok := s.mysql.RollingUpgrade()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(rolling.Released, jc.DeepEquals, []string{"mysql/0", "mysql/1"})
+	c.Check(rolling.BatchStarted.Equal(s.now), jc.IsTrue)
+	s.assertCharmURLs(c, s.newCharm, s.newCharm, s.charm)
+
+	// The next batch waits for the first to settle.
+	s.setUnitReady(c, s.units[0])
+	err = s.mysql.AdvanceRollingUpgrade(s.now.Add(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCharmURLs(c, s.newCharm, s.newCharm, s.charm)
+
+	s.setUnitReady(c, s.units[1])
+	err = s.mysql.AdvanceRollingUpgrade(s.now.Add(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCharmURLs(c, s.newCharm, s.newCharm, s.newCharm)
+
+	s.setUnitReady(c, s.units[2])
+	err = s.mysql.AdvanceRollingUpgrade(s.now.Add(2 * time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.mysql.RollingUpgrade()
+	c.Check(ok, jc.IsFalse)
+
+	applications, err := s.State.RollingUpgradeApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(applications, gc.HasLen, 0)
+}
+
+func (s *RollingUpgradeSuite) TestAdvanceHaltsOnError(c *gc.C) {
+	s.startRollingUpgrade(c, 0)
+	err := s.mysql.AdvanceRollingUpgrade(s.now)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.units[1].SetAgentStatus(status.StatusInfo{
+		Status:  status.StatusError,
+		Message: "hook failed: \"upgrade-charm\"",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.AdvanceRollingUpgrade(s.now)
+	c.Assert(err, jc.ErrorIsNil)
+
+	rolling, ok := s.mysql.RollingUpgrade()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(rolling.Halted, jc.IsTrue)
+	c.Check(rolling.Message, gc.Equals, `unit mysql/1 is in error: hook failed: "upgrade-charm"`)
+
+	// A halted upgrade releases no more units.
+	s.setUnitReady(c, s.units[0])
+	s.setUnitReady(c, s.units[1])
+	err = s.mysql.AdvanceRollingUpgrade(s.now)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCharmURLs(c, s.newCharm, s.newCharm, s.charm)
+
+	applications, err := s.State.RollingUpgradeApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(applications, gc.HasLen, 1)
+	c.Check(applications[0].Name(), gc.Equals, "mysql")
+}
+
+func (s *RollingUpgradeSuite) TestAdvanceHaltsOnTimeout(c *gc.C) {
+	s.startRollingUpgrade(c, 5*time.Minute)
+	err := s.mysql.AdvanceRollingUpgrade(s.now)
+	c.Assert(err, jc.ErrorIsNil)
+	s.setUnitReady(c, s.units[0])
+
+	err = s.mysql.AdvanceRollingUpgrade(s.now.Add(5 * time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	rolling, _ := s.mysql.RollingUpgrade()
+	c.Check(rolling.Halted, jc.IsFalse)
+
+	err = s.mysql.AdvanceRollingUpgrade(s.now.Add(6 * time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	rolling, _ = s.mysql.RollingUpgrade()
+	c.Check(rolling.Halted, jc.IsTrue)
+	c.Check(rolling.Message, gc.Equals, "timed out waiting for units mysql/1")
+}
+
+func (s *RollingUpgradeSuite) TestAdvanceWithoutRollingUpgrade(c *gc.C) {
+	err := s.mysql.AdvanceRollingUpgrade(s.now)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := s.mysql.RollingUpgrade()
+	c.Check(ok, jc.IsFalse)
+}
@@ -148,13 +148,31 @@ func (st *State) RemoveImportingModelDocs() error {
 	return st.removeAllModelDocs(bson.D{{"migration-mode", MigrationModeImporting}})
 }
 
-func (st *State) removeAllModelDocs(modelAssertion bson.D) error {
+// RemoveExportingModelDocs removes all documents from multi-model
+// collections for the current model, along with its logs, once it has
+// been migrated to another controller. A redirect to the migration's
+// target controller is recorded in their place. This method asserts
+// that the model's migration mode is "exporting".
+func (st *State) RemoveExportingModelDocs() error {
+	redirectOp, err := st.addModelRedirectOp()
+	if err != nil {
+		return errors.Annotate(err, "cannot record model redirect")
+	}
+	err = st.removeAllModelDocs(bson.D{{"migration-mode", MigrationModeExporting}}, redirectOp)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(removeModelLogs(st))
+}
+
+func (st *State) removeAllModelDocs(modelAssertion bson.D, extraOps ...txn.Op) error {
 	env, err := st.Model()
 	if err != nil {
 		return errors.Trace(err)
 	}
 	id := userModelNameIndex(env.Owner().Canonical(), env.Name())
-	ops := []txn.Op{{
+	ops := append([]txn.Op{}, extraOps...)
+	ops = append(ops, []txn.Op{{
 		// Cleanup the owner:envName unique key.
 		C:      usermodelnameC,
 		Id:     id,
@@ -168,7 +186,7 @@ func (st *State) removeAllModelDocs(modelAssertion bson.D) error {
 		Id:     st.ModelUUID(),
 		Assert: modelAssertion,
 		Remove: true,
-	}}
+	}}...)
 	if !st.IsController() {
 		ops = append(ops, decHostedModelCountOp())
 	}
@@ -582,6 +600,12 @@ func (st *State) SetModelAgentVersion(newVersion version.Number) (err error) {
 				},
 			},
 		}
+		// Setting the agent version ends any canary upgrade.
+		canaryOp, err := st.endUpgradeCanaryOp()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, canaryOp)
 		return ops, nil
 	}
 	if err = st.run(buildTxn); err == jujutxn.ErrExcessiveContention {
@@ -1653,11 +1677,17 @@ func (st *State) AssignUnit(u *Unit, policy AssignmentPolicy) (err error) {
 		}
 		return u.AssignToMachine(m)
 	case AssignClean:
+		if _, err = u.assignToColocatedMachineIfPreferred(); err != noCleanMachines {
+			return errors.Trace(err)
+		}
 		if _, err = u.AssignToCleanMachine(); err != noCleanMachines {
 			return errors.Trace(err)
 		}
 		return u.AssignToNewMachineOrContainer()
 	case AssignCleanEmpty:
+		if _, err = u.assignToColocatedMachineIfPreferred(); err != noCleanMachines {
+			return errors.Trace(err)
+		}
 		if _, err = u.AssignToCleanEmptyMachine(); err != noCleanMachines {
 			return errors.Trace(err)
 		}
@@ -1183,6 +1183,7 @@ var (
 // - unitNotAliveErr when the unit is not alive.
 // - alreadyAssignedErr when the unit has already been assigned
 // - inUseErr when the machine already has a unit assigned (if unused is true)
+// - ErrPlacementPolicyViolated when the unit's placement policy forbids the machine
 func (u *Unit) assignToMachine(m *Machine, unused bool) (err error) {
 	originalm := m
 	buildTxn := func(attempt int) ([]txn.Op, error) {
@@ -1192,6 +1193,12 @@ func (u *Unit) assignToMachine(m *Machine, unused bool) (err error) {
 				return nil, errors.Trace(err)
 			}
 		}
+		// The placement policy is not asserted by the transaction, so
+		// units of the same application assigned concurrently may
+		// still share a scope; PlacementPolicyViolations reports them.
+		if err := u.checkPlacementPolicy(m); err != nil {
+			return nil, err
+		}
 		return u.assignToMachineOps(m, unused)
 	}
 	if err := u.st.run(buildTxn); err != nil {
@@ -2032,6 +2039,9 @@ func (u *Unit) assignToCleanMaybeEmptyMachine(requireEmpty bool) (m *Machine, er
 		if err == nil {
 			return m, nil
 		}
+		if IsPlacementPolicyViolatedError(err) {
+			continue
+		}
 		switch errors.Cause(err) {
 		case inUseErr, machineNotAliveErr:
 		default:
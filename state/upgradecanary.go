@@ -0,0 +1,230 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	jujuversion "github.com/juju/juju/version"
+)
+
+// upgradeCanaryDoc records an agent upgrade that is being tried out on
+// a few of the model's machines before the whole model is upgraded. It
+// is embedded in the model document while the canary upgrade is in
+// progress, and removed when the model's agent version is next set or
+// the canary upgrade is aborted.
+type upgradeCanaryDoc struct {
+	Version    version.Number `bson:"version"`
+	MachineIds []string       `bson:"machine-ids"`
+	Started    time.Time      `bson:"started"`
+}
+
+// UpgradeCanary describes an agent upgrade that is being tried out on
+// a few of the model's machines before the whole model is upgraded.
+type UpgradeCanary struct {
+	// Version is the agent version that the canary machines run.
+	Version version.Number
+
+	// MachineIds holds the ids of the canary machines.
+	MachineIds []string
+
+	// Started is when the canary upgrade was started.
+	Started time.Time
+}
+
+// UpgradeCanary returns the model's canary agent upgrade, and whether
+// one is in progress.
+func (m *Model) UpgradeCanary() (UpgradeCanary, bool) {
+	doc := m.doc.UpgradeCanary
+	if doc == nil {
+		return UpgradeCanary{}, false
+	}
+	machineIds := make([]string, len(doc.MachineIds))
+	copy(machineIds, doc.MachineIds)
+	return UpgradeCanary{
+		Version:    doc.Version,
+		MachineIds: machineIds,
+		Started:    doc.Started,
+	}, true
+}
+
+// AgentVersionForMachine returns the agent version that the identified
+// machine should run: the canary version if the machine is part of a
+// canary upgrade, and the model's agent version otherwise.
+func (m *Model) AgentVersionForMachine(machineId string, modelVersion version.Number) version.Number {
+	doc := m.doc.UpgradeCanary
+	if doc == nil {
+		return modelVersion
+	}
+	for _, id := range doc.MachineIds {
+		if id == machineId {
+			return doc.Version
+		}
+	}
+	return modelVersion
+}
+
+// SetModelAgentCanary starts a canary upgrade, in which only the given
+// machines (and the units they host) are upgraded to newVersion. The
+// rest of the model follows once SetModelAgentVersion is called with
+// the same version; AbortModelAgentCanary returns the canary machines
+// to the model's agent version instead. Controller machines cannot be
+// canaries, and only one canary upgrade may be in progress at a time.
+//
+// Agents refuse to move back to an older minor version once their
+// upgrade steps have run, so a canary upgrade may only move to a newer
+// patch release of the model's major.minor version; otherwise aborting
+// it could not return the canary machines to the model's version.
+func (st *State) SetModelAgentCanary(newVersion version.Number, machineIds []string) error {
+	if len(machineIds) == 0 {
+		return errors.New("no canary machines specified")
+	}
+	if newVersion.Compare(jujuversion.Current) > 0 && !st.IsController() {
+		return errors.Errorf("a hosted model cannot have a higher version than the server model: %s > %s",
+			newVersion.String(),
+			jujuversion.Current,
+		)
+	}
+	ids := set.NewStrings()
+	for _, id := range machineIds {
+		if !names.IsValidMachine(id) {
+			return errors.NotValidf("machine id %q", id)
+		}
+		ids.Add(id)
+	}
+	canary := &upgradeCanaryDoc{
+		Version:    newVersion,
+		MachineIds: ids.SortedValues(),
+		Started:    GetClock().Now().UTC(),
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		model, err := st.Model()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if current, ok := model.UpgradeCanary(); ok {
+			return nil, errors.Errorf("canary upgrade to %s already in progress", current.Version)
+		}
+		settings, err := readSettings(st, settingsC, modelGlobalKey)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		agentVersion, ok := settings.Get("agent-version")
+		if !ok {
+			return nil, errors.Errorf("no agent version set in the model")
+		}
+		versionString, ok := agentVersion.(string)
+		if !ok {
+			return nil, errors.Errorf("invalid agent version format: expected string, got %v", agentVersion)
+		}
+		currentVersion, err := version.Parse(versionString)
+		if err != nil {
+			return nil, errors.Annotate(err, "invalid model agent version")
+		}
+		if newVersion.Compare(currentVersion) <= 0 {
+			return nil, errors.Errorf("canary version %s is not newer than model agent version %s", newVersion, currentVersion)
+		}
+		if newVersion.Major != currentVersion.Major || newVersion.Minor != currentVersion.Minor {
+			return nil, errors.Errorf(
+				"canary version %s is not a patch release of model agent version %s",
+				newVersion, currentVersion,
+			)
+		}
+
+		ops := []txn.Op{{
+			C:      upgradeInfoC,
+			Id:     currentUpgradeId,
+			Assert: txn.DocMissing,
+		}, {
+			C:      settingsC,
+			Id:     st.docID(modelGlobalKey),
+			Assert: bson.D{{"version", settings.version}},
+		}, {
+			C:      modelsC,
+			Id:     st.ModelUUID(),
+			Assert: bson.D{{"upgrade-canary", bson.D{{"$exists", false}}}},
+			Update: bson.D{{"$set", bson.D{{"upgrade-canary", canary}}}},
+		}}
+		for _, id := range canary.MachineIds {
+			machine, err := st.Machine(id)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if machine.IsManager() {
+				return nil, errors.Errorf("controller machine %s cannot be an upgrade canary", id)
+			}
+			if machine.Life() == Dead {
+				return nil, errors.Errorf("machine %s is dead", id)
+			}
+			ops = append(ops, txn.Op{
+				C:      machinesC,
+				Id:     machine.doc.DocID,
+				Assert: notDeadDoc,
+			})
+		}
+		return ops, nil
+	}
+	if err := st.run(buildTxn); err == jujutxn.ErrExcessiveContention {
+		if upgrading, _ := st.IsUpgrading(); upgrading {
+			return errUpgradeInProgress
+		}
+		return errors.Annotate(err, "cannot start canary upgrade")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// AbortModelAgentCanary abandons the model's canary upgrade, so that
+// the canary machines return to the model's agent version. It returns
+// a NotFound error if no canary upgrade is in progress.
+func (st *State) AbortModelAgentCanary() error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		model, err := st.Model()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, ok := model.UpgradeCanary(); !ok {
+			return nil, errors.NotFoundf("canary upgrade")
+		}
+		return []txn.Op{{
+			C:      modelsC,
+			Id:     st.ModelUUID(),
+			Assert: bson.D{{"upgrade-canary", bson.D{{"$exists", true}}}},
+			Update: bson.D{{"$unset", bson.D{{"upgrade-canary", nil}}}},
+		}}, nil
+	}
+	return errors.Trace(st.run(buildTxn))
+}
+
+// endUpgradeCanaryOp returns an operation that removes any canary
+// upgrade from the model, or that asserts there is none.
+func (st *State) endUpgradeCanaryOp() (txn.Op, error) {
+	model, err := st.Model()
+	if err != nil {
+		return txn.Op{}, errors.Trace(err)
+	}
+	if _, ok := model.UpgradeCanary(); !ok {
+		return txn.Op{
+			C:      modelsC,
+			Id:     st.ModelUUID(),
+			Assert: bson.D{{"upgrade-canary", bson.D{{"$exists", false}}}},
+		}, nil
+	}
+	return txn.Op{
+		C:      modelsC,
+		Id:     st.ModelUUID(),
+		Assert: bson.D{{"upgrade-canary", bson.D{{"$exists", true}}}},
+		Update: bson.D{{"$unset", bson.D{{"upgrade-canary", nil}}}},
+	}, nil
+}
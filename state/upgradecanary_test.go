@@ -0,0 +1,138 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type UpgradeCanarySuite struct {
+	ConnSuite
+	machine      *state.Machine
+	agentVersion version.Number
+	newVersion   version.Number
+}
+
+var _ = gc.Suite(&UpgradeCanarySuite{})
+
+func (s *UpgradeCanarySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	agentVersion, ok := cfg.AgentVersion()
+	c.Assert(ok, jc.IsTrue)
+	s.agentVersion = agentVersion
+	s.newVersion = agentVersion
+	s.newVersion.Patch++
+
+	s.machine, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetAgentVersion(version.Binary{
+		Number: s.agentVersion,
+		Series: "quantal",
+		Arch:   "amd64",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UpgradeCanarySuite) assertCanary(c *gc.C, expectVersion version.Number, expectIds ...string) {
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	canary, ok := model.UpgradeCanary()
+	if len(expectIds) == 0 {
+		c.Assert(ok, jc.IsFalse)
+		return
+	}
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(canary.Version, gc.Equals, expectVersion)
+	c.Assert(canary.MachineIds, jc.DeepEquals, expectIds)
+	c.Assert(canary.Started.IsZero(), jc.IsFalse)
+}
+
+func (s *UpgradeCanarySuite) TestSetModelAgentCanary(c *gc.C) {
+	other, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetModelAgentCanary(s.newVersion, []string{s.machine.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCanary(c, s.newVersion, s.machine.Id())
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.AgentVersionForMachine(s.machine.Id(), s.agentVersion), gc.Equals, s.newVersion)
+	c.Assert(model.AgentVersionForMachine(other.Id(), s.agentVersion), gc.Equals, s.agentVersion)
+}
+
+func (s *UpgradeCanarySuite) TestSetModelAgentCanaryNoMachines(c *gc.C) {
+	err := s.State.SetModelAgentCanary(s.newVersion, nil)
+	c.Assert(err, gc.ErrorMatches, "no canary machines specified")
+}
+
+func (s *UpgradeCanarySuite) TestSetModelAgentCanaryUnknownMachine(c *gc.C) {
+	err := s.State.SetModelAgentCanary(s.newVersion, []string{"42"})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	s.assertCanary(c, version.Zero)
+}
+
+func (s *UpgradeCanarySuite) TestSetModelAgentCanaryNotNewer(c *gc.C) {
+	err := s.State.SetModelAgentCanary(s.agentVersion, []string{s.machine.Id()})
+	c.Assert(err, gc.ErrorMatches, "canary version .* is not newer than model agent version .*")
+	s.assertCanary(c, version.Zero)
+}
+
+func (s *UpgradeCanarySuite) TestSetModelAgentCanaryNotPatchRelease(c *gc.C) {
+	newer := s.agentVersion
+	newer.Minor++
+	newer.Patch = 0
+	err := s.State.SetModelAgentCanary(newer, []string{s.machine.Id()})
+	c.Assert(err, gc.ErrorMatches, "canary version .* is not a patch release of model agent version .*")
+	s.assertCanary(c, version.Zero)
+}
+
+func (s *UpgradeCanarySuite) TestSetModelAgentCanaryControllerMachine(c *gc.C) {
+	controller, err := s.State.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetModelAgentCanary(s.newVersion, []string{controller.Id()})
+	c.Assert(err, gc.ErrorMatches, `controller machine .* cannot be an upgrade canary`)
+	s.assertCanary(c, version.Zero)
+}
+
+func (s *UpgradeCanarySuite) TestSetModelAgentCanaryAlreadyInProgress(c *gc.C) {
+	err := s.State.SetModelAgentCanary(s.newVersion, []string{s.machine.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetModelAgentCanary(s.newVersion, []string{s.machine.Id()})
+	c.Assert(err, gc.ErrorMatches, "canary upgrade to .* already in progress")
+}
+
+func (s *UpgradeCanarySuite) TestAbortModelAgentCanary(c *gc.C) {
+	err := s.State.SetModelAgentCanary(s.newVersion, []string{s.machine.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AbortModelAgentCanary()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCanary(c, version.Zero)
+
+	err = s.State.AbortModelAgentCanary()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *UpgradeCanarySuite) TestSetModelAgentVersionEndsCanary(c *gc.C) {
+	err := s.State.SetModelAgentCanary(s.newVersion, []string{s.machine.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetAgentVersion(version.Binary{
+		Number: s.newVersion,
+		Series: "quantal",
+		Arch:   "amd64",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetModelAgentVersion(s.newVersion)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCanary(c, version.Zero)
+}
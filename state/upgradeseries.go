@@ -0,0 +1,335 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// UpgradeSeriesStatus describes how far a machine, or one of the units
+// deployed to it, has progressed through an in-place series upgrade.
+type UpgradeSeriesStatus string
+
+const (
+	// UpgradeSeriesNotStarted indicates that no series upgrade is
+	// in progress.
+	UpgradeSeriesNotStarted UpgradeSeriesStatus = "not started"
+
+	// UpgradeSeriesPrepareStarted indicates that the machine has been
+	// locked and the pre-series-upgrade hooks have been requested.
+	UpgradeSeriesPrepareStarted UpgradeSeriesStatus = "prepare started"
+
+	// UpgradeSeriesPrepareCompleted indicates that the pre-series-upgrade
+	// work is done, and the operator may upgrade the OS release.
+	UpgradeSeriesPrepareCompleted UpgradeSeriesStatus = "prepare completed"
+
+	// UpgradeSeriesCompleteStarted indicates that the operator has
+	// upgraded the OS release and the post-series-upgrade hooks have
+	// been requested.
+	UpgradeSeriesCompleteStarted UpgradeSeriesStatus = "complete started"
+
+	// UpgradeSeriesCompleted indicates that the post-series-upgrade
+	// work is done.
+	UpgradeSeriesCompleted UpgradeSeriesStatus = "completed"
+
+	// UpgradeSeriesError indicates that the series upgrade could not
+	// proceed and needs operator attention.
+	UpgradeSeriesError UpgradeSeriesStatus = "error"
+)
+
+// Validate returns an error if the status is not one of the known
+// upgrade-series statuses.
+func (s UpgradeSeriesStatus) Validate() error {
+	switch s {
+	case UpgradeSeriesNotStarted,
+		UpgradeSeriesPrepareStarted,
+		UpgradeSeriesPrepareCompleted,
+		UpgradeSeriesCompleteStarted,
+		UpgradeSeriesCompleted,
+		UpgradeSeriesError:
+		return nil
+	}
+	return errors.NotValidf("upgrade series status %q", s)
+}
+
+// upgradeSeriesLockDoc records an in-place series upgrade of a machine.
+// While the document exists the machine is locked against further
+// series upgrades.
+type upgradeSeriesLockDoc struct {
+	DocID         string                         `bson:"_id"`
+	Id            string                         `bson:"machineid"`
+	ModelUUID     string                         `bson:"model-uuid"`
+	FromSeries    string                         `bson:"from-series"`
+	ToSeries      string                         `bson:"to-series"`
+	MachineStatus UpgradeSeriesStatus            `bson:"machine-status"`
+	UnitStatuses  map[string]UpgradeSeriesStatus `bson:"unit-statuses"`
+}
+
+func removeUpgradeSeriesLockOp(st *State, machineId string) txn.Op {
+	return txn.Op{
+		C:      upgradeSeriesLocksC,
+		Id:     st.docID(machineId),
+		Remove: true,
+	}
+}
+
+func (m *Machine) getUpgradeSeriesLock() (*upgradeSeriesLockDoc, error) {
+	locks, closer := m.st.getCollection(upgradeSeriesLocksC)
+	defer closer()
+
+	var lock upgradeSeriesLockDoc
+	err := locks.FindId(m.doc.DocID).One(&lock)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("upgrade series lock for machine %q", m.Id())
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get upgrade series lock for machine %q", m.Id())
+	}
+	return &lock, nil
+}
+
+// CreateUpgradeSeriesLock locks the machine for an in-place upgrade to
+// toSeries. Each of the named units is expected to run its
+// pre-series-upgrade hook before the machine may be upgraded.
+func (m *Machine) CreateUpgradeSeriesLock(unitNames []string, toSeries string) error {
+	if toSeries == "" {
+		return errors.New("missing series")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.Life() != Alive {
+			return nil, machineNotAliveErr
+		}
+		if m.Series() == toSeries {
+			return nil, errors.Errorf("machine is already running series %q", toSeries)
+		}
+		locked, err := m.IsLockedForSeriesUpgrade()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if locked {
+			return nil, errors.AlreadyExistsf("upgrade series lock for machine %q", m.Id())
+		}
+		unitStatuses := make(map[string]UpgradeSeriesStatus)
+		for _, name := range unitNames {
+			unitStatuses[name] = UpgradeSeriesPrepareStarted
+		}
+		return []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: bson.D{{"life", Alive}, {"series", m.Series()}},
+		}, {
+			C:      upgradeSeriesLocksC,
+			Id:     m.doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: &upgradeSeriesLockDoc{
+				Id:            m.Id(),
+				FromSeries:    m.Series(),
+				ToSeries:      toSeries,
+				MachineStatus: UpgradeSeriesPrepareStarted,
+				UnitStatuses:  unitStatuses,
+			},
+		}}, nil
+	}
+	err := m.st.run(buildTxn)
+	return errors.Annotatef(err, "cannot lock machine %q for series upgrade", m.Id())
+}
+
+// RemoveUpgradeSeriesLock unlocks the machine, allowing further series
+// upgrades. It is not an error to remove a lock that does not exist.
+func (m *Machine) RemoveUpgradeSeriesLock() error {
+	err := m.st.runTransaction([]txn.Op{removeUpgradeSeriesLockOp(m.st, m.Id())})
+	return errors.Annotatef(err, "cannot remove upgrade series lock for machine %q", m.Id())
+}
+
+// IsLockedForSeriesUpgrade reports whether a series upgrade of the
+// machine is in progress.
+func (m *Machine) IsLockedForSeriesUpgrade() (bool, error) {
+	locks, closer := m.st.getCollection(upgradeSeriesLocksC)
+	defer closer()
+
+	count, err := locks.FindId(m.doc.DocID).Count()
+	if err != nil {
+		return false, errors.Annotatef(err, "cannot get upgrade series lock for machine %q", m.Id())
+	}
+	return count > 0, nil
+}
+
+// UpgradeSeriesTarget returns the series the machine is being upgraded
+// to. It returns a NotFound error if the machine is not locked for a
+// series upgrade.
+func (m *Machine) UpgradeSeriesTarget() (string, error) {
+	lock, err := m.getUpgradeSeriesLock()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return lock.ToSeries, nil
+}
+
+// UpgradeSeriesStatus returns the machine's progress through a series
+// upgrade, or UpgradeSeriesNotStarted if there is none in progress.
+func (m *Machine) UpgradeSeriesStatus() (UpgradeSeriesStatus, error) {
+	lock, err := m.getUpgradeSeriesLock()
+	if errors.IsNotFound(err) {
+		return UpgradeSeriesNotStarted, nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	return lock.MachineStatus, nil
+}
+
+// SetUpgradeSeriesStatus records the machine's progress through the
+// series upgrade in progress.
+func (m *Machine) SetUpgradeSeriesStatus(status UpgradeSeriesStatus) error {
+	if err := status.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      upgradeSeriesLocksC,
+		Id:     m.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"machine-status", status}}}},
+	}}
+	err := m.st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("upgrade series lock for machine %q", m.Id())
+	}
+	return errors.Annotatef(err, "cannot set upgrade series status for machine %q", m.Id())
+}
+
+// UpgradeSeriesUnitStatuses returns the progress through the series
+// upgrade in progress of each unit on the machine, keyed by unit name.
+func (m *Machine) UpgradeSeriesUnitStatuses() (map[string]UpgradeSeriesStatus, error) {
+	lock, err := m.getUpgradeSeriesLock()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return lock.UnitStatuses, nil
+}
+
+// SetUpgradeSeriesUnitStatus records the named unit's progress through
+// the series upgrade in progress. The unit must have been included when
+// the lock was created.
+func (m *Machine) SetUpgradeSeriesUnitStatus(unitName string, status UpgradeSeriesStatus) error {
+	if err := status.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	field := "unit-statuses." + unitName
+	ops := []txn.Op{{
+		C:      upgradeSeriesLocksC,
+		Id:     m.doc.DocID,
+		Assert: bson.D{{field, bson.D{{"$exists", true}}}},
+		Update: bson.D{{"$set", bson.D{{field, status}}}},
+	}}
+	err := m.st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("upgrade series lock for unit %q on machine %q", unitName, m.Id())
+	}
+	return errors.Annotatef(err, "cannot set upgrade series status for unit %q", unitName)
+}
+
+// UpdateMachineSeries records that the machine is now running the given
+// series. It may only be called while the machine is locked for a
+// series upgrade.
+func (m *Machine) UpdateMachineSeries(series string) error {
+	if series == "" {
+		return errors.New("missing series")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.Life() != Alive {
+			return nil, machineNotAliveErr
+		}
+		locked, err := m.IsLockedForSeriesUpgrade()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !locked {
+			return nil, errors.New("machine is not locked for series upgrade")
+		}
+		if m.Series() == series {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      upgradeSeriesLocksC,
+			Id:     m.doc.DocID,
+			Assert: txn.DocExists,
+		}, {
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: bson.D{{"life", Alive}, {"series", m.Series()}},
+			Update: bson.D{{"$set", bson.D{{"series", series}}}},
+		}}, nil
+	}
+	if err := m.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot update series of machine %q", m.Id())
+	}
+	m.doc.Series = series
+	return nil
+}
+
+// WatchUpgradeSeriesNotifications returns a watcher that fires whenever
+// the machine's upgrade series lock is created, changed or removed.
+func (m *Machine) WatchUpgradeSeriesNotifications() NotifyWatcher {
+	return newEntityWatcher(m.st, upgradeSeriesLocksC, m.doc.DocID)
+}
+
+func (u *Unit) assignedMachine() (*Machine, error) {
+	machineId, err := u.AssignedMachineId()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return u.st.Machine(machineId)
+}
+
+// UpgradeSeriesStatus returns the unit's progress through a series
+// upgrade of its machine, or UpgradeSeriesNotStarted if there is none
+// in progress that involves the unit.
+func (u *Unit) UpgradeSeriesStatus() (UpgradeSeriesStatus, error) {
+	m, err := u.assignedMachine()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	statuses, err := m.UpgradeSeriesUnitStatuses()
+	if errors.IsNotFound(err) {
+		return UpgradeSeriesNotStarted, nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	if status, ok := statuses[u.Name()]; ok {
+		return status, nil
+	}
+	return UpgradeSeriesNotStarted, nil
+}
+
+// SetUpgradeSeriesStatus records the unit's progress through the series
+// upgrade of its machine.
+func (u *Unit) SetUpgradeSeriesStatus(status UpgradeSeriesStatus) error {
+	m, err := u.assignedMachine()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return m.SetUpgradeSeriesUnitStatus(u.Name(), status)
+}
+
+// WatchUpgradeSeriesNotifications returns a watcher that fires whenever
+// the upgrade series lock of the unit's machine changes.
+func (u *Unit) WatchUpgradeSeriesNotifications() (NotifyWatcher, error) {
+	m, err := u.assignedMachine()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m.WatchUpgradeSeriesNotifications(), nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type UpgradeSeriesSuite struct {
+	ConnSuite
+
+	machine *state.Machine
+	unit    *state.Unit
+}
+
+var _ = gc.Suite(&UpgradeSeriesSuite{})
+
+func (s *UpgradeSeriesSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.machine = s.Factory.MakeMachine(c, nil)
+	s.unit = s.Factory.MakeUnit(c, &factory.UnitParams{Machine: s.machine})
+}
+
+func (s *UpgradeSeriesSuite) lock(c *gc.C) {
+	err := s.machine.CreateUpgradeSeriesLock([]string{s.unit.Name()}, "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UpgradeSeriesSuite) TestCreateUpgradeSeriesLock(c *gc.C) {
+	locked, err := s.machine.IsLockedForSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsFalse)
+
+	s.lock(c)
+
+	locked, err = s.machine.IsLockedForSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsTrue)
+
+	target, err := s.machine.UpgradeSeriesTarget()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(target, gc.Equals, "xenial")
+
+	status, err := s.machine.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, state.UpgradeSeriesPrepareStarted)
+
+	statuses, err := s.machine.UpgradeSeriesUnitStatuses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statuses, jc.DeepEquals, map[string]state.UpgradeSeriesStatus{
+		s.unit.Name(): state.UpgradeSeriesPrepareStarted,
+	})
+}
+
+func (s *UpgradeSeriesSuite) TestCreateUpgradeSeriesLockAlreadyLocked(c *gc.C) {
+	s.lock(c)
+	err := s.machine.CreateUpgradeSeriesLock(nil, "yakkety")
+	c.Assert(err, gc.ErrorMatches, `cannot lock machine "\d+" for series upgrade: upgrade series lock for machine "\d+" already exists`)
+}
+
+func (s *UpgradeSeriesSuite) TestCreateUpgradeSeriesLockSameSeries(c *gc.C) {
+	err := s.machine.CreateUpgradeSeriesLock(nil, "quantal")
+	c.Assert(err, gc.ErrorMatches, `cannot lock machine "\d+" for series upgrade: machine is already running series "quantal"`)
+}
+
+func (s *UpgradeSeriesSuite) TestCreateUpgradeSeriesLockDyingMachine(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	err := machine.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.CreateUpgradeSeriesLock(nil, "xenial")
+	c.Assert(err, gc.ErrorMatches, `cannot lock machine "\d+" for series upgrade: machine is not alive`)
+}
+
+func (s *UpgradeSeriesSuite) TestRemoveUpgradeSeriesLock(c *gc.C) {
+	s.lock(c)
+	err := s.machine.RemoveUpgradeSeriesLock()
+	c.Assert(err, jc.ErrorIsNil)
+
+	locked, err := s.machine.IsLockedForSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsFalse)
+
+	status, err := s.machine.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, state.UpgradeSeriesNotStarted)
+
+	// Removing a missing lock is not an error.
+	err = s.machine.RemoveUpgradeSeriesLock()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UpgradeSeriesSuite) TestSetUpgradeSeriesStatus(c *gc.C) {
+	s.lock(c)
+	err := s.machine.SetUpgradeSeriesStatus(state.UpgradeSeriesPrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := s.machine.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, state.UpgradeSeriesPrepareCompleted)
+}
+
+func (s *UpgradeSeriesSuite) TestSetUpgradeSeriesStatusNotLocked(c *gc.C) {
+	err := s.machine.SetUpgradeSeriesStatus(state.UpgradeSeriesPrepareCompleted)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *UpgradeSeriesSuite) TestSetUpgradeSeriesStatusInvalid(c *gc.C) {
+	s.lock(c)
+	err := s.machine.SetUpgradeSeriesStatus("bogus")
+	c.Assert(err, gc.ErrorMatches, `upgrade series status "bogus" not valid`)
+}
+
+func (s *UpgradeSeriesSuite) TestUnitUpgradeSeriesStatus(c *gc.C) {
+	status, err := s.unit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, state.UpgradeSeriesNotStarted)
+
+	s.lock(c)
+	err = s.unit.SetUpgradeSeriesStatus(state.UpgradeSeriesPrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err = s.unit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, state.UpgradeSeriesPrepareCompleted)
+}
+
+func (s *UpgradeSeriesSuite) TestSetUpgradeSeriesUnitStatusUnknownUnit(c *gc.C) {
+	s.lock(c)
+	err := s.machine.SetUpgradeSeriesUnitStatus("foo/0", state.UpgradeSeriesPrepareCompleted)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *UpgradeSeriesSuite) TestUpdateMachineSeries(c *gc.C) {
+	s.lock(c)
+	err := s.machine.UpdateMachineSeries("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.Series(), gc.Equals, "xenial")
+
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.Series(), gc.Equals, "xenial")
+}
+
+func (s *UpgradeSeriesSuite) TestUpdateMachineSeriesNotLocked(c *gc.C) {
+	err := s.machine.UpdateMachineSeries("xenial")
+	c.Assert(err, gc.ErrorMatches, `cannot update series of machine "\d+": machine is not locked for series upgrade`)
+}
+
+func (s *UpgradeSeriesSuite) TestWatchUpgradeSeriesNotifications(c *gc.C) {
+	w := s.machine.WatchUpgradeSeriesNotifications()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	s.lock(c)
+	wc.AssertOneChange()
+
+	err := s.unit.SetUpgradeSeriesStatus(state.UpgradeSeriesPrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.machine.RemoveUpgradeSeriesLock()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -1304,7 +1305,7 @@ func (st *State) WatchRestoreInfoChanges() NotifyWatcher {
 // WatchForModelConfigChanges returns a NotifyWatcher waiting for the Model
 // Config to change.
 func (st *State) WatchForModelConfigChanges() NotifyWatcher {
-	return newDocWatcher(st, []docKey{
+	keys := []docKey{
 		{
 			settingsC,
 			st.docID(modelGlobalKey),
@@ -1312,7 +1313,16 @@ func (st *State) WatchForModelConfigChanges() NotifyWatcher {
 			controllersC,
 			defaultModelSettingsGlobalKey,
 		},
-	})
+	}
+	// The model's cloud region never changes, so the defaults
+	// for that region are the only ones that can affect it.
+	if model, err := st.Model(); err == nil && model.CloudRegion() != "" {
+		keys = append(keys, docKey{
+			controllersC,
+			regionSettingsGlobalKey(model.CloudRegion()),
+		})
+	}
+	return newDocWatcher(st, keys)
 }
 
 // WatchForUnitAssignment watches for new services that request units to be
@@ -2572,6 +2582,251 @@ func (w *migrationActiveWatcher) loop() error {
 	}
 }
 
+// notifyCollWatcher sends a single value to indicate that one or
+// more documents in a collection have changed.
+type notifyCollWatcher struct {
+	commonWatcher
+	collName string
+	filter   func(interface{}) bool
+	sink     chan struct{}
+}
+
+// newNotifyCollWatcher returns a NotifyWatcher which triggers when
+// any document in the named collection which passes filter changes.
+func newNotifyCollWatcher(st *State, collName string, filter func(interface{}) bool) NotifyWatcher {
+	w := &notifyCollWatcher{
+		commonWatcher: newCommonWatcher(st),
+		collName:      collName,
+		filter:        filter,
+		sink:          make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.sink)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for this watcher.
+func (w *notifyCollWatcher) Changes() <-chan struct{} {
+	return w.sink
+}
+
+func (w *notifyCollWatcher) loop() error {
+	in := make(chan watcher.Change)
+
+	w.watcher.WatchCollectionWithFilter(w.collName, in, w.filter)
+	defer w.watcher.UnwatchCollection(w.collName, in)
+
+	out := w.sink // out set so that initial event is sent.
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case change := <-in:
+			if _, ok := collect(change, in, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			out = w.sink
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
+
+// machineCharmProfilesWatcher notifies of machines whose principal
+// units' charms may call for a different set of LXD profiles.
+type machineCharmProfilesWatcher struct {
+	commonWatcher
+	// charmURLs holds the charm URL of each application.
+	charmURLs map[string]string
+	// unitMachines holds the machine each assigned principal unit
+	// is assigned to.
+	unitMachines map[string]string
+	out          chan []string
+}
+
+var _ Watcher = (*machineCharmProfilesWatcher)(nil)
+
+// WatchMachineCharmProfiles returns a StringsWatcher which notifies of
+// the ids of machines whose LXD profiles, as declared by the charms of
+// the principal units assigned to them, may need to be updated. That
+// is the machines hosting units of an application whose charm URL has
+// changed, and the machines to which a principal unit was assigned or
+// from which one was removed. The initial event holds all machines
+// with principal units assigned.
+func (st *State) WatchMachineCharmProfiles() StringsWatcher {
+	return newMachineCharmProfilesWatcher(st)
+}
+
+func newMachineCharmProfilesWatcher(st *State) StringsWatcher {
+	w := &machineCharmProfilesWatcher{
+		commonWatcher: newCommonWatcher(st),
+		charmURLs:     make(map[string]string),
+		unitMachines:  make(map[string]string),
+		out:           make(chan []string),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for w.
+func (w *machineCharmProfilesWatcher) Changes() <-chan []string {
+	return w.out
+}
+
+func charmURLString(curl *charm.URL) string {
+	if curl == nil {
+		return ""
+	}
+	return curl.String()
+}
+
+func (w *machineCharmProfilesWatcher) initial() (set.Strings, error) {
+	applications, closer := w.st.getCollection(applicationsC)
+	defer closer()
+	var appDoc applicationDoc
+	iter := applications.Find(nil).Select(bson.D{{"name", 1}, {"charmurl", 1}}).Iter()
+	for iter.Next(&appDoc) {
+		w.charmURLs[appDoc.Name] = charmURLString(appDoc.CharmURL)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	units, closer := w.st.getCollection(unitsC)
+	defer closer()
+	machineIds := make(set.Strings)
+	var doc unitDoc
+	iter = units.Find(bson.D{{"principal", ""}}).Select(bson.D{{"name", 1}, {"machineid", 1}}).Iter()
+	for iter.Next(&doc) {
+		if doc.MachineId == "" {
+			continue
+		}
+		w.unitMachines[doc.Name] = doc.MachineId
+		machineIds.Add(doc.MachineId)
+	}
+	return machineIds, errors.Trace(iter.Close())
+}
+
+// mergeApplication adds to machineIds the machines hosting principal
+// units of the changed application if its charm URL has changed.
+func (w *machineCharmProfilesWatcher) mergeApplication(machineIds set.Strings, change watcher.Change) error {
+	name := w.st.localID(change.Id.(string))
+	if change.Revno == -1 {
+		delete(w.charmURLs, name)
+		return nil
+	}
+	applications, closer := w.st.getCollection(applicationsC)
+	defer closer()
+	var appDoc applicationDoc
+	err := applications.FindId(change.Id).Select(bson.D{{"charmurl", 1}}).One(&appDoc)
+	if err == mgo.ErrNotFound {
+		delete(w.charmURLs, name)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	charmURL := charmURLString(appDoc.CharmURL)
+	previous, known := w.charmURLs[name]
+	w.charmURLs[name] = charmURL
+	if !known || previous == charmURL {
+		return nil
+	}
+
+	units, closer := w.st.getCollection(unitsC)
+	defer closer()
+	var doc unitDoc
+	query := bson.D{{"application", name}, {"principal", ""}}
+	iter := units.Find(query).Select(bson.D{{"machineid", 1}}).Iter()
+	for iter.Next(&doc) {
+		if doc.MachineId != "" {
+			machineIds.Add(doc.MachineId)
+		}
+	}
+	return errors.Trace(iter.Close())
+}
+
+// mergeUnit adds to machineIds the machine the changed principal unit
+// has been assigned to, or has been removed from.
+func (w *machineCharmProfilesWatcher) mergeUnit(machineIds set.Strings, change watcher.Change) error {
+	name := w.st.localID(change.Id.(string))
+	previous, known := w.unitMachines[name]
+	removed := func() {
+		if known {
+			machineIds.Add(previous)
+			delete(w.unitMachines, name)
+		}
+	}
+	if change.Revno == -1 {
+		removed()
+		return nil
+	}
+	units, closer := w.st.getCollection(unitsC)
+	defer closer()
+	var doc unitDoc
+	err := units.FindId(change.Id).Select(bson.D{{"principal", 1}, {"machineid", 1}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		removed()
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if doc.Principal != "" || doc.MachineId == "" || (known && previous == doc.MachineId) {
+		return nil
+	}
+	w.unitMachines[name] = doc.MachineId
+	machineIds.Add(doc.MachineId)
+	return nil
+}
+
+func (w *machineCharmProfilesWatcher) loop() error {
+	applicationCh := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(applicationsC, applicationCh, isLocalID(w.st))
+	defer w.watcher.UnwatchCollection(applicationsC, applicationCh)
+	unitCh := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(unitsC, unitCh, isLocalID(w.st))
+	defer w.watcher.UnwatchCollection(unitsC, unitCh)
+
+	machineIds, err := w.initial()
+	if err != nil {
+		return err
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case change := <-applicationCh:
+			if err := w.mergeApplication(machineIds, change); err != nil {
+				return err
+			}
+			if !machineIds.IsEmpty() {
+				out = w.out
+			}
+		case change := <-unitCh:
+			if err := w.mergeUnit(machineIds, change); err != nil {
+				return err
+			}
+			if !machineIds.IsEmpty() {
+				out = w.out
+			}
+		case out <- machineIds.SortedValues():
+			out = nil
+			machineIds = make(set.Strings)
+		}
+	}
+}
+
 // WatchMigrationStatus returns a NotifyWatcher which triggers
 // whenever the status of latest migration for the State's model
 // changes. One instance can be used across migrations. The watcher
@@ -75,7 +75,11 @@ func mustParseCertAndKey(certPEM, keyPEM string) (*x509.Certificate, *rsa.Privat
 	if err != nil {
 		panic(err)
 	}
-	return cert, key
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		panic(fmt.Errorf("unexpected key type %T", key))
+	}
+	return cert, rsaKey
 }
 
 func serverCerts() *gitjujutesting.Certs {
@@ -4,8 +4,12 @@
 package factory
 
 import (
+	"crypto/sha512"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"math/rand"
+	"os"
 	"strconv"
 	"sync/atomic"
 	"time"
@@ -370,6 +374,43 @@ func (factory *Factory) MakeCharm(c *gc.C, params *CharmParams) *state.Charm {
 	return charm
 }
 
+// MakeMirroredCharm adds a charm store charm to the controller's charm
+// mirror with the specified parameters, substituting sane defaults for
+// missing values, and returns its URL.
+// If params is not specified, defaults are used.
+func (factory *Factory) MakeMirroredCharm(c *gc.C, params *CharmParams) *charm.URL {
+	if params == nil {
+		params = &CharmParams{}
+	}
+	if params.Name == "" {
+		params.Name = "mysql"
+	}
+	if params.Series == "" {
+		params.Series = "quantal"
+	}
+	if params.Revision == "" {
+		params.Revision = fmt.Sprintf("%d", uniqueInteger())
+	}
+	if params.URL == "" {
+		params.URL = fmt.Sprintf("cs:%s/%s-%s", params.Series, params.Name, params.Revision)
+	}
+
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), params.Name)
+	f, err := os.Open(ch.Path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	hash := sha512.New384()
+	size, err := io.Copy(hash, f)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = f.Seek(0, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	curl := charm.MustParseURL(params.URL)
+	err = factory.st.AddMirroredCharm(curl, hex.EncodeToString(hash.Sum(nil)), f, size)
+	c.Assert(err, jc.ErrorIsNil)
+	return curl
+}
+
 // MakeApplication creates an application with the specified parameters, substituting
 // sane defaults for missing values.
 // If params is not specified, defaults are used.
@@ -13,6 +13,7 @@ import (
 	"github.com/lxc/lxd/shared"
 
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/network"
 )
 
@@ -31,6 +32,7 @@ type rawInstanceClient interface {
 
 	WaitForSuccess(waitURL string) error
 	ContainerState(name string) (*shared.ContainerState, error)
+	ApplyProfile(container, profile string) (*lxd.Response, error)
 }
 
 type instanceClient struct {
@@ -124,6 +126,71 @@ func (client *instanceClient) Instance(name string) (*Instance, error) {
 	return inst, nil
 }
 
+// InstanceProfiles returns the names of the profiles applied to the
+// named instance, in the order in which they are applied.
+func (client *instanceClient) InstanceProfiles(name string) ([]string, error) {
+	info, err := client.raw.ContainerInfo(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return info.Profiles, nil
+}
+
+// SetInstanceProfiles replaces the profiles applied to the named
+// instance with the given ones, which are applied in order.
+func (client *instanceClient) SetInstanceProfiles(name string, profiles []string) error {
+	if len(profiles) == 0 {
+		return errors.New("at least one profile must be applied")
+	}
+	resp, err := client.raw.ApplyProfile(name, strings.Join(profiles, ","))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := client.raw.WaitForSuccess(resp.Operation); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// ReplaceCharmProfiles replaces any charm profiles applied to the
+// named instance with the given ones, which are applied after all of
+// the instance's other profiles. The instance is left untouched if it
+// already has exactly those profiles applied.
+func (client *instanceClient) ReplaceCharmProfiles(name string, charmProfiles []string) error {
+	current, err := client.InstanceProfiles(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var updated []string
+	for _, profile := range current {
+		if !lxdprofile.IsCharmProfile(profile) {
+			updated = append(updated, profile)
+		}
+	}
+	updated = append(updated, charmProfiles...)
+	if sameProfiles(current, updated) {
+		return nil
+	}
+	if len(updated) == 0 {
+		logger.Warningf("not removing charm profiles from instance %q: no other profiles applied", name)
+		return nil
+	}
+	logger.Infof("instance %q updated to profiles %v", name, updated)
+	return errors.Trace(client.SetInstanceProfiles(name, updated))
+}
+
+func sameProfiles(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func (client *instanceClient) Status(name string) (string, error) {
 	info, err := client.raw.ContainerInfo(name)
 	if err != nil {
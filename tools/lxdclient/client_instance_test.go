@@ -7,6 +7,7 @@ package lxdclient_test
 
 import (
 	jc "github.com/juju/testing/checkers"
+	"github.com/lxc/lxd"
 	lxdshared "github.com/lxc/lxd/shared"
 	gc "gopkg.in/check.v1"
 
@@ -177,3 +178,80 @@ func (s *addressesSuite) TestAddresses(c *gc.C) {
 		},
 	})
 }
+
+type profilesSuite struct {
+	jujutesting.BaseSuite
+}
+
+var _ = gc.Suite(&profilesSuite{})
+
+type profileTester struct {
+	lxdclient.RawInstanceClient
+
+	profiles []string
+	applied  string
+	waited   string
+}
+
+func (p *profileTester) ContainerInfo(name string) (*lxdshared.ContainerInfo, error) {
+	return &lxdshared.ContainerInfo{Name: name, Profiles: p.profiles}, nil
+}
+
+func (p *profileTester) ApplyProfile(container, profile string) (*lxd.Response, error) {
+	p.applied = profile
+	return &lxd.Response{Operation: "/1.0/operations/apply"}, nil
+}
+
+func (p *profileTester) WaitForSuccess(waitURL string) error {
+	p.waited = waitURL
+	return nil
+}
+
+func (s *profilesSuite) TestInstanceProfiles(c *gc.C) {
+	raw := &profileTester{profiles: []string{"default", "juju-charm-06f00d-mysql-3"}}
+	client := lxdclient.NewInstanceClient(raw)
+	profiles, err := client.InstanceProfiles("test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profiles, jc.DeepEquals, []string{"default", "juju-charm-06f00d-mysql-3"})
+}
+
+func (s *profilesSuite) TestSetInstanceProfiles(c *gc.C) {
+	raw := &profileTester{}
+	client := lxdclient.NewInstanceClient(raw)
+	err := client.SetInstanceProfiles("test", []string{"default", "juju-charm-06f00d-mysql-4"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(raw.applied, gc.Equals, "default,juju-charm-06f00d-mysql-4")
+	c.Assert(raw.waited, gc.Equals, "/1.0/operations/apply")
+}
+
+func (s *profilesSuite) TestSetInstanceProfilesNone(c *gc.C) {
+	raw := &profileTester{}
+	client := lxdclient.NewInstanceClient(raw)
+	err := client.SetInstanceProfiles("test", nil)
+	c.Assert(err, gc.ErrorMatches, "at least one profile must be applied")
+	c.Assert(raw.applied, gc.Equals, "")
+}
+
+func (s *profilesSuite) TestReplaceCharmProfiles(c *gc.C) {
+	raw := &profileTester{profiles: []string{"default", "juju-charm-06f00d-mysql-3", "custom"}}
+	client := lxdclient.NewInstanceClient(raw)
+	err := client.ReplaceCharmProfiles("test", []string{"juju-charm-06f00d-mysql-4"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(raw.applied, gc.Equals, "default,custom,juju-charm-06f00d-mysql-4")
+}
+
+func (s *profilesSuite) TestReplaceCharmProfilesRemoves(c *gc.C) {
+	raw := &profileTester{profiles: []string{"default", "juju-charm-06f00d-mysql-3"}}
+	client := lxdclient.NewInstanceClient(raw)
+	err := client.ReplaceCharmProfiles("test", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(raw.applied, gc.Equals, "default")
+}
+
+func (s *profilesSuite) TestReplaceCharmProfilesUnchanged(c *gc.C) {
+	raw := &profileTester{profiles: []string{"default", "juju-charm-06f00d-mysql-3"}}
+	client := lxdclient.NewInstanceClient(raw)
+	err := client.ReplaceCharmProfiles("test", []string{"juju-charm-06f00d-mysql-3"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(raw.applied, gc.Equals, "")
+}
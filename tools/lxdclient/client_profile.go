@@ -6,8 +6,12 @@
 package lxdclient
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/lxc/lxd"
+
+	"github.com/juju/juju/core/lxdprofile"
 )
 
 type rawProfileClient interface {
@@ -70,3 +74,54 @@ func (p profileClient) HasProfile(name string) (bool, error) {
 	}
 	return false, nil
 }
+
+// EnsureCharmProfiles creates any of the given charm profiles, keyed
+// by profile name, which do not already exist. Existing profiles are
+// left untouched, since a profile's name identifies the charm revision
+// it was created from. The profile names are returned in sorted order.
+func (p profileClient) EnsureCharmProfiles(profiles map[string]lxdprofile.Profile) ([]string, error) {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		hasProfile, err := p.HasProfile(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if hasProfile {
+			continue
+		}
+		if err := p.createCharmProfile(name, profiles[name]); err != nil {
+			return nil, errors.Annotatef(err, "cannot create profile %q", name)
+		}
+	}
+	return names, nil
+}
+
+func (p profileClient) createCharmProfile(name string, profile lxdprofile.Profile) error {
+	if err := p.CreateProfile(name, profile.Config); err != nil {
+		return errors.Trace(err)
+	}
+	deviceNames := make([]string, 0, len(profile.Devices))
+	for deviceName := range profile.Devices {
+		deviceNames = append(deviceNames, deviceName)
+	}
+	sort.Strings(deviceNames)
+	for _, deviceName := range deviceNames {
+		device := profile.Devices[deviceName]
+		var props []string
+		for key, value := range device {
+			if key == "type" {
+				continue
+			}
+			props = append(props, key+"="+value)
+		}
+		sort.Strings(props)
+		if _, err := p.ProfileDeviceAdd(name, deviceName, device["type"], props); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ package upgrades
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/version"
 )
@@ -73,16 +74,29 @@ func (u upgradeToVersion) TargetVersion() version.Number {
 	return u.targetVersion
 }
 
-// upgradeError records a description of the step being performed and the error.
+// upgradeError records a description of the step being performed and the error,
+// along with the descriptions of the steps that completed before it.
 type upgradeError struct {
 	description string
 	err         error
+	completed   []string
 }
 
 func (e *upgradeError) Error() string {
 	return fmt.Sprintf("%s: %v", e.description, e.err)
 }
 
+// CompletedSteps returns the descriptions of the upgrade steps that
+// completed successfully before the upgrade step that failed with the
+// given error, in the order they were run. It returns nil if the error
+// did not come from PerformUpgrade.
+func CompletedSteps(err error) []string {
+	if err, ok := errors.Cause(err).(*upgradeError); ok {
+		return err.completed
+	}
+	return nil
+}
+
 // AreUpgradesDefined returns true if there are upgrade operations
 // defined between the version supplied and the running software
 // version.
@@ -93,15 +107,16 @@ func AreUpgradesDefined(from version.Number) bool {
 // PerformUpgrade runs the business logic needed to upgrade the current "from" version to this
 // version of Juju on the "target" type of machine.
 func PerformUpgrade(from version.Number, targets []Target, context Context) error {
+	var completed []string
 	if hasStateTarget(targets) {
 		ops := newStateUpgradeOpsIterator(from)
-		if err := runUpgradeSteps(ops, targets, context.StateContext()); err != nil {
+		if err := runUpgradeSteps(ops, targets, context.StateContext(), &completed); err != nil {
 			return err
 		}
 	}
 
 	ops := newUpgradeOpsIterator(from)
-	if err := runUpgradeSteps(ops, targets, context.APIContext()); err != nil {
+	if err := runUpgradeSteps(ops, targets, context.APIContext(), &completed); err != nil {
 		return err
 	}
 
@@ -124,8 +139,9 @@ func hasStateTarget(targets []Target) bool {
 // As soon as any error is encountered, the operation is aborted since
 // subsequent steps may required successful completion of earlier
 // ones. The steps must be idempotent so that the entire upgrade
-// operation can be retried.
-func runUpgradeSteps(ops *opsIterator, targets []Target, context Context) error {
+// operation can be retried. The descriptions of the steps that complete
+// are appended to completed.
+func runUpgradeSteps(ops *opsIterator, targets []Target, context Context, completed *[]string) error {
 	for ops.Next() {
 		for _, step := range ops.Get().Steps() {
 			if targetsMatch(targets, step.Targets()) {
@@ -135,8 +151,10 @@ func runUpgradeSteps(ops *opsIterator, targets []Target, context Context) error
 					return &upgradeError{
 						description: step.Description(),
 						err:         err,
+						completed:   *completed,
 					}
 				}
+				*completed = append(*completed, step.Description())
 			}
 		}
 	}
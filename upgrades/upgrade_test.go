@@ -555,6 +555,7 @@ func (s *upgradeSuite) TestPerformUpgrade(c *gc.C) {
 			c.Check(err, jc.ErrorIsNil)
 		} else {
 			c.Check(err, gc.ErrorMatches, test.err)
+			c.Check(upgrades.CompletedSteps(err), jc.DeepEquals, test.expectedSteps)
 		}
 		c.Check(ctx.messages, jc.DeepEquals, test.expectedSteps)
 	}
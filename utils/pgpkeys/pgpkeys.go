@@ -0,0 +1,33 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package pgpkeys provides helpers for handling armored PGP keys.
+package pgpkeys
+
+import "strings"
+
+// armorBeginPrefix starts the first line of an armored PGP block.
+const armorBeginPrefix = "-----BEGIN PGP "
+
+// SplitArmored splits concatenated armored PGP key blocks into
+// individual blocks.
+func SplitArmored(armored string) []string {
+	var keys []string
+	var current []string
+	for _, line := range strings.SplitAfter(armored, "\n") {
+		if strings.HasPrefix(line, armorBeginPrefix) && len(current) > 0 {
+			keys = appendKey(keys, current)
+			current = nil
+		}
+		current = append(current, line)
+	}
+	return appendKey(keys, current)
+}
+
+func appendKey(keys, lines []string) []string {
+	key := strings.TrimSpace(strings.Join(lines, ""))
+	if key == "" {
+		return keys
+	}
+	return append(keys, key)
+}
@@ -0,0 +1,23 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package pgpkeys_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/utils/pgpkeys"
+)
+
+type PGPKeysSuite struct{}
+
+var _ = gc.Suite(&PGPKeysSuite{})
+
+func (s *PGPKeysSuite) TestSplitArmored(c *gc.C) {
+	first := "-----BEGIN PGP PUBLIC KEY BLOCK-----\nfirst\n-----END PGP PUBLIC KEY BLOCK-----"
+	second := "-----BEGIN PGP PUBLIC KEY BLOCK-----\nsecond\n-----END PGP PUBLIC KEY BLOCK-----"
+	c.Assert(pgpkeys.SplitArmored(""), gc.HasLen, 0)
+	c.Assert(pgpkeys.SplitArmored(first), jc.DeepEquals, []string{first})
+	c.Assert(pgpkeys.SplitArmored("\n"+first+"\n\n"+second+"\n"), jc.DeepEquals, []string{first, second})
+}
@@ -0,0 +1,85 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cacertupdater
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.cacertupdater")
+
+// CACertUpdater is responsible for propagating the CA certificates
+// trusted by the controller.
+//
+// In practice, CACertUpdater is used by an agent to watch the trusted
+// CA certificates in state and write the changes to the agent's config
+// file, so that the agent can still validate its API connection when
+// the controller CA is rotated.
+type CACertUpdater struct {
+	getter CACertGetter
+	setter CACertSetter
+	caCert string
+}
+
+// CACertGetter is an interface that is provided to NewCACertUpdater
+// which can be used to watch for changes to the trusted CA certificates.
+type CACertGetter interface {
+	TrustedCACert() (string, error)
+	WatchCACert() (watcher.NotifyWatcher, error)
+}
+
+// CACertSetter is an interface that is provided to NewCACertUpdater
+// whose SetCACert method will be invoked whenever the trusted CA
+// certificates change.
+type CACertSetter interface {
+	SetCACert(caCert string) error
+}
+
+// NewCACertUpdater returns a worker.Worker that watches for changes to
+// the trusted CA certificates and then sets them on the CACertSetter.
+func NewCACertUpdater(getter CACertGetter, setter CACertSetter) (worker.Worker, error) {
+	handler := &CACertUpdater{
+		getter: getter,
+		setter: setter,
+	}
+	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: handler,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// SetUp is part of the watcher.NotifyHandler interface.
+func (c *CACertUpdater) SetUp() (watcher.NotifyWatcher, error) {
+	return c.getter.WatchCACert()
+}
+
+// Handle is part of the watcher.NotifyHandler interface.
+func (c *CACertUpdater) Handle(_ <-chan struct{}) error {
+	caCert, err := c.getter.TrustedCACert()
+	if err != nil {
+		return errors.Annotate(err, "cannot get trusted CA certificates")
+	}
+	if caCert == c.caCert {
+		logger.Debugf("trusted CA certificates unchanged")
+		return nil
+	}
+	logger.Infof("updating trusted CA certificates")
+	if err := c.setter.SetCACert(caCert); err != nil {
+		return errors.Annotate(err, "cannot set trusted CA certificates")
+	}
+	c.caCert = caCert
+	return nil
+}
+
+// TearDown is part of the watcher.NotifyHandler interface.
+func (c *CACertUpdater) TearDown() error {
+	return nil
+}
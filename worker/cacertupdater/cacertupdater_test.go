@@ -0,0 +1,80 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cacertupdater_test
+
+import (
+	"strings"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apimachiner "github.com/juju/juju/api/machiner"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/cacertupdater"
+)
+
+type CACertUpdaterSuite struct {
+	jujutesting.JujuConnSuite
+}
+
+var _ = gc.Suite(&CACertUpdaterSuite{})
+
+type caCertSetter struct {
+	caCerts chan string
+	err     error
+}
+
+func (s *caCertSetter) SetCACert(caCert string) error {
+	s.caCerts <- caCert
+	return s.err
+}
+
+func (s *CACertUpdaterSuite) controllerCACert(c *gc.C) string {
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	caCert, _ := cfg.CACert()
+	return caCert
+}
+
+func (s *CACertUpdaterSuite) waitCACert(c *gc.C, setter *caCertSetter) string {
+	select {
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for SetCACert to be called")
+	case caCert := <-setter.caCerts:
+		return caCert
+	}
+	panic("unreachable")
+}
+
+func (s *CACertUpdaterSuite) TestStartStop(c *gc.C) {
+	st, _ := s.OpenAPIAsNewMachine(c, state.JobHostUnits)
+	worker, err := cacertupdater.NewCACertUpdater(apimachiner.NewState(st), &caCertSetter{})
+	c.Assert(err, jc.ErrorIsNil)
+	worker.Kill()
+	c.Assert(worker.Wait(), gc.IsNil)
+}
+
+func (s *CACertUpdaterSuite) TestCACertUpdates(c *gc.C) {
+	setter := &caCertSetter{caCerts: make(chan string, 1)}
+	st, _ := s.OpenAPIAsNewMachine(c, state.JobHostUnits)
+	worker, err := cacertupdater.NewCACertUpdater(apimachiner.NewState(st), setter)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// SetCACert should be called with the initial value.
+	controllerCACert := s.controllerCACert(c)
+	c.Assert(s.waitCACert(c, setter), gc.Equals, controllerCACert)
+
+	// Starting a CA rotation should add the new CA.
+	err = s.State.StartCARotation(coretesting.OtherCACert, coretesting.OtherCAKey)
+	c.Assert(err, jc.ErrorIsNil)
+	s.BackingState.StartSync()
+	caCert := s.waitCACert(c, setter)
+	c.Assert(strings.HasPrefix(caCert, controllerCACert), jc.IsTrue)
+	c.Assert(strings.HasSuffix(caCert, coretesting.OtherCACert), jc.IsTrue)
+}
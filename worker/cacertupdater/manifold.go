@@ -0,0 +1,49 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cacertupdater
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/machiner"
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/cmd/jujud/agent/engine"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/dependency"
+)
+
+// ManifoldConfig defines the names of the manifolds on which a Manifold will depend.
+type ManifoldConfig engine.AgentApiManifoldConfig
+
+// Manifold returns a dependency manifold that runs a CA certificate
+// updater worker, using the resource names defined in the supplied config.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	typedConfig := engine.AgentApiManifoldConfig(config)
+	return engine.AgentApiManifold(typedConfig, newWorker)
+}
+
+// newWorker trivially wraps NewCACertUpdater for use in a engine.AgentApiManifold.
+var newWorker = func(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
+	tag := a.CurrentConfig().Tag()
+
+	var getter CACertGetter
+	switch apiTag := tag.(type) {
+	case names.UnitTag:
+		getter = uniter.NewState(apiCaller, apiTag)
+	case names.MachineTag:
+		getter = machiner.NewState(apiCaller)
+	default:
+		return nil, errors.Errorf("expected a unit or machine tag; got %q", tag)
+	}
+
+	setter := agent.CACertSetter{a}
+	w, err := NewCACertUpdater(getter, setter)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/utils/set"
 
+	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/controller"
@@ -33,19 +34,27 @@ type CertificateUpdater struct {
 	configGetter    ControllerConfigGetter
 	hostPortsGetter APIHostPortsGetter
 	addresses       []network.Address
+	caCert          string
 }
 
 // AddressWatcher is an interface that is provided to NewCertificateUpdater
 // which can be used to watch for machine address changes.
 type AddressWatcher interface {
+	Id() string
 	WatchAddresses() state.NotifyWatcher
 	Addresses() (addresses []network.Address)
 }
 
 // ControllerConfigGetter is an interface that is provided to NewCertificateUpdater
-// which can be used to get the controller config.
+// which can be used to get the controller config, to watch for and get
+// the private key of a new controller CA when the CA is rotated, and to
+// record that the certificate has been reissued with the new CA.
 type ControllerConfigGetter interface {
 	ControllerConfig() (controller.Config, error)
+	WatchCACert() state.NotifyWatcher
+	StateServingInfo() (state.StateServingInfo, error)
+	CARotation() (*state.CARotation, error)
+	SetCAReissued(machineId string) error
 }
 
 // StateServingInfoGetter is an interface that is provided to NewCertificateUpdater
@@ -98,17 +107,26 @@ func (c *CertificateUpdater) SetUp() (state.NotifyWatcher, error) {
 	if err := c.updateCertificate(initialSANAddresses, make(chan struct{})); err != nil {
 		return nil, errors.Annotate(err, "setting initial cerificate SAN list")
 	}
-	// Return
-	return c.addressWatcher.WatchAddresses(), nil
+	// Watch for changes to both the machine addresses and the controller
+	// CA, either of which requires a new certificate.
+	return common.NewMultiNotifyWatcher(
+		c.addressWatcher.WatchAddresses(),
+		c.configGetter.WatchCACert(),
+	), nil
 }
 
 // Handle is defined on the NotifyWatchHandler interface.
 func (c *CertificateUpdater) Handle(done <-chan struct{}) error {
 	addresses := c.addressWatcher.Addresses()
-	if reflect.DeepEqual(addresses, c.addresses) {
+	cfg, err := c.configGetter.ControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "cannot read controller config")
+	}
+	caCert, _ := cfg.CACert()
+	if reflect.DeepEqual(addresses, c.addresses) && caCert == c.caCert {
 		// Sometimes the watcher will tell us things have changed, when they
 		// haven't as far as we can tell.
-		logger.Debugf("addresses haven't really changed since last updated cert")
+		logger.Debugf("addresses and CA haven't really changed since last updated cert")
 		return nil
 	}
 	return c.updateCertificate(addresses, done)
@@ -125,6 +143,15 @@ func (c *CertificateUpdater) updateCertificate(addresses []network.Address, done
 		return errors.New("no state serving info, cannot regenerate server certificate")
 	}
 	caPrivateKey := stateInfo.CAPrivateKey
+	// The CA private key in state replaces the agent's own once the
+	// controller CA has been rotated.
+	controllerInfo, err := c.configGetter.StateServingInfo()
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotate(err, "cannot read controller state serving info")
+	}
+	if controllerInfo.CAPrivateKey != "" {
+		caPrivateKey = controllerInfo.CAPrivateKey
+	}
 	if caPrivateKey == "" {
 		logger.Errorf("no CA cert private key, cannot regenerate server certificate")
 		return nil
@@ -134,6 +161,11 @@ func (c *CertificateUpdater) updateCertificate(addresses []network.Address, done
 	if err != nil {
 		return errors.Annotate(err, "cannot read controller config")
 	}
+	caCert, hasCACert := cfg.CACert()
+	if !hasCACert {
+		return errors.New("configuration has no ca-cert")
+	}
+	c.caCert = caCert
 
 	// For backwards compatibility, we must include "anything", "juju-apiserver"
 	// and "juju-mongodb" as hostnames as that is what clients specify
@@ -151,27 +183,49 @@ func (c *CertificateUpdater) updateCertificate(addresses []network.Address, done
 	if err != nil {
 		return errors.Annotate(err, "cannot determine if cert update needed")
 	}
-	if !update {
+	signed, err := signedBy(stateInfo.Cert, caCert)
+	if err != nil {
+		return errors.Annotate(err, "cannot determine if cert update needed")
+	}
+	if !update && signed {
 		logger.Debugf("no certificate update required")
-		return nil
+		return c.recordReissued()
 	}
 
 	// Generate a new controller certificate with the machine addresses in the SAN value.
-	caCert, hasCACert := cfg.CACert()
-	if !hasCACert {
-		return errors.New("configuration has no ca-cert")
-	}
 	newCert, newKey, err := controller.GenerateControllerCertAndKey(caCert, caPrivateKey, newServerAddrs)
 	if err != nil {
 		return errors.Annotate(err, "cannot generate controller certificate")
 	}
 	stateInfo.Cert = string(newCert)
 	stateInfo.PrivateKey = string(newKey)
+	stateInfo.CAPrivateKey = caPrivateKey
 	err = c.setter(stateInfo, done)
 	if err != nil {
 		return errors.Annotate(err, "cannot write agent config")
 	}
 	logger.Infof("controller cerificate addresses updated to %q", newServerAddrs)
+	return c.recordReissued()
+}
+
+// recordReissued records that the controller's certificate has been
+// reissued with the new CA, if a CA rotation is being finished. The
+// setter must have been called with the reissued certificate, which
+// makes the controller serve it, before this is called.
+func (c *CertificateUpdater) recordReissued() error {
+	rotation, err := c.configGetter.CARotation()
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "cannot read CA rotation")
+	}
+	if !rotation.Finishing {
+		return nil
+	}
+	if err := c.configGetter.SetCAReissued(c.addressWatcher.Id()); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("controller certificate reissued with the new CA")
 	return nil
 }
 
@@ -195,6 +249,21 @@ func updateRequired(serverCert string, newAddrs []string) ([]string, bool, error
 	return newAddrSet.SortedValues(), update, nil
 }
 
+// signedBy returns whether the server certificate was signed by the
+// given CA certificate. It is not when the controller CA has been
+// rotated since the server certificate was generated.
+func signedBy(serverCert, caCert string) (bool, error) {
+	x509Cert, err := cert.ParseCert(serverCert)
+	if err != nil {
+		return false, errors.Annotate(err, "cannot parse existing TLS certificate")
+	}
+	x509CACert, err := cert.ParseCert(caCert)
+	if err != nil {
+		return false, errors.Annotate(err, "cannot parse CA certificate")
+	}
+	return x509Cert.CheckSignatureFrom(x509CACert) == nil, nil
+}
+
 // TearDown is defined on the NotifyWatchHandler interface.
 func (c *CertificateUpdater) TearDown() error {
 	return nil
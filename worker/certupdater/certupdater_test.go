@@ -8,6 +8,7 @@ import (
 	stdtesting "testing"
 	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
@@ -66,14 +67,27 @@ func (*mockNotifyWatcher) Err() error {
 	return nil
 }
 
+// newMockNotifyWatcher returns a watcher that sends an initial event,
+// as state watchers do, followed by any sent on the given channel.
 func newMockNotifyWatcher(changes <-chan struct{}) state.NotifyWatcher {
-	return &mockNotifyWatcher{changes}
+	out := make(chan struct{}, 1)
+	out <- struct{}{}
+	go func() {
+		for range changes {
+			out <- struct{}{}
+		}
+	}()
+	return &mockNotifyWatcher{out}
 }
 
 type mockMachine struct {
 	changes chan struct{}
 }
 
+func (m *mockMachine) Id() string {
+	return "0"
+}
+
 func (m *mockMachine) WatchAddresses() state.NotifyWatcher {
 	return newMockNotifyWatcher(m.changes)
 }
@@ -88,15 +102,53 @@ func (s *CertUpdaterSuite) StateServingInfo() (params.StateServingInfo, bool) {
 	return s.stateServingInfo, true
 }
 
-type mockConfigGetter struct{}
+type mockConfigGetter struct {
+	// caCert and caKey, if set, hold a rotated controller CA.
+	caCert string
+	caKey  string
+
+	// rotation, if set, holds the CA rotation in progress, and
+	// reissued receives the ids of the machines recorded as having
+	// reissued their certificates.
+	rotation *state.CARotation
+	reissued chan string
+}
 
 func (g *mockConfigGetter) ControllerConfig() (jujucontroller.Config, error) {
+	if g.caCert != "" {
+		return map[string]interface{}{
+			jujucontroller.CACertKey: g.caCert,
+		}, nil
+	}
 	return map[string]interface{}{
 		jujucontroller.CACertKey:    coretesting.CACert,
 		jujucontroller.CAPrivateKey: coretesting.CAKey,
 	}, nil
 }
 
+func (g *mockConfigGetter) WatchCACert() state.NotifyWatcher {
+	return newMockNotifyWatcher(nil)
+}
+
+func (g *mockConfigGetter) StateServingInfo() (state.StateServingInfo, error) {
+	if g.caKey == "" {
+		return state.StateServingInfo{}, errors.NotFoundf("state serving info")
+	}
+	return state.StateServingInfo{CAPrivateKey: g.caKey}, nil
+}
+
+func (g *mockConfigGetter) CARotation() (*state.CARotation, error) {
+	if g.rotation == nil {
+		return nil, errors.NotFoundf("CA rotation")
+	}
+	return g.rotation, nil
+}
+
+func (g *mockConfigGetter) SetCAReissued(machineId string) error {
+	g.reissued <- machineId
+	return nil
+}
+
 type mockAPIHostGetter struct{}
 
 func (g *mockAPIHostGetter) APIHostPorts() ([][]network.HostPort, error) {
@@ -174,6 +226,48 @@ func (s *CertUpdaterSuite) TestAddressChange(c *gc.C) {
 		[]string{"localhost", "juju-apiserver", "juju-mongodb", "anything"})
 }
 
+func (s *CertUpdaterSuite) TestCAChange(c *gc.C) {
+	updated := make(chan params.StateServingInfo, 10)
+	setter := func(info params.StateServingInfo, dying <-chan struct{}) error {
+		updated <- info
+		return nil
+	}
+	configGetter := &mockConfigGetter{
+		caCert:   coretesting.OtherCACert,
+		caKey:    coretesting.OtherCAKey,
+		rotation: &state.CARotation{NewCACert: coretesting.OtherCACert, Finishing: true},
+		reissued: make(chan string, 10),
+	}
+	changes := make(chan struct{})
+	worker := certupdater.NewCertificateUpdater(
+		&mockMachine{changes}, s, configGetter, &mockAPIHostGetter{}, setter,
+	)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// The certificate should be reissued by the rotated CA, even
+	// though the addresses it covers have not changed.
+	select {
+	case info := <-updated:
+		srvCert, err := cert.ParseCert(info.Cert)
+		c.Assert(err, jc.ErrorIsNil)
+		caCert, err := cert.ParseCert(coretesting.OtherCACert)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(srvCert.CheckSignatureFrom(caCert), jc.ErrorIsNil)
+		c.Assert(info.CAPrivateKey, gc.Equals, coretesting.OtherCAKey)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for certificate to be updated")
+	}
+
+	// Once the certificate is reissued, the rotation is told.
+	select {
+	case id := <-configGetter.reissued:
+		c.Assert(id, gc.Equals, "0")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for reissued certificate to be recorded")
+	}
+}
+
 type mockStateServingGetterNoCAKey struct{}
 
 func (g *mockStateServingGetterNoCAKey) StateServingInfo() (params.StateServingInfo, bool) {
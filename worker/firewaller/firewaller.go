@@ -39,8 +39,11 @@ type Firewaller struct {
 	applicationids  map[names.ApplicationTag]*serviceData
 	exposedChange   chan *exposedChange
 	globalMode      bool
-	globalPortRef   map[network.PortRange]int
+	globalRuleRef   map[string]*ruleRef
 	machinePorts    map[names.MachineTag]machineRanges
+	// ingressCIDRs holds the model's default source CIDRs for exposed
+	// applications; if empty, ports are opened to anywhere.
+	ingressCIDRs []string
 }
 
 // NewFirewaller returns a new Firewaller or a new FirewallerV0,
@@ -89,7 +92,7 @@ func (fw *Firewaller) setUp() error {
 	case config.FwInstance:
 	case config.FwGlobal:
 		fw.globalMode = true
-		fw.globalPortRef = make(map[network.PortRange]int)
+		fw.globalRuleRef = make(map[string]*ruleRef)
 	case config.FwNone:
 		logger.Infof("stopping firewaller (not required)")
 		fw.Kill()
@@ -97,6 +100,7 @@ func (fw *Firewaller) setUp() error {
 	default:
 		return errors.Errorf("unknown firewall-mode %q", config.FwNone)
 	}
+	fw.ingressCIDRs = fw.environ.Config().IngressCIDRs()
 
 	fw.machinesWatcher, err = fw.st.WatchModelMachines()
 	if err != nil {
@@ -142,6 +146,9 @@ func (fw *Firewaller) loop() error {
 				// hopefully be replaced with EnvironObserver.
 				logger.Errorf("loaded invalid environment configuration: %v", err)
 			}
+			if err := fw.ingressCIDRsChanged(config.IngressCIDRs()); err != nil {
+				return errors.Annotate(err, "cannot change firewall ingress rules")
+			}
 		case change, ok := <-fw.machinesWatcher.Changes():
 			if !ok {
 				return errors.New("machines watcher closed")
@@ -182,6 +189,7 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.serviced.exposed = change.exposed
+			change.serviced.cidrs = change.cidrs
 			unitds := []*unitData{}
 			for _, unitd := range change.serviced.unitds {
 				unitds = append(unitds, unitd)
@@ -200,7 +208,7 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 		fw:           fw,
 		tag:          tag,
 		unitds:       make(map[names.UnitTag]*unitData),
-		openedPorts:  make([]network.PortRange, 0),
+		ingressRules: make([]network.IngressRule, 0),
 		definedPorts: make(map[network.PortRange]names.UnitTag),
 	}
 	m, err := machined.machine()
@@ -313,16 +321,21 @@ func (fw *Firewaller) startService(service *firewaller.Application) error {
 	if err != nil {
 		return err
 	}
+	cidrs, err := service.ExposedCIDRs()
+	if err != nil {
+		return err
+	}
 	serviced := &serviceData{
 		fw:          fw,
 		application: service,
 		exposed:     exposed,
+		cidrs:       cidrs,
 		unitds:      make(map[names.UnitTag]*unitData),
 	}
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &serviced.catacomb,
 		Work: func() error {
-			return serviced.watchLoop(exposed)
+			return serviced.watchLoop(exposed, cidrs)
 		},
 	})
 	if err != nil {
@@ -339,11 +352,11 @@ func (fw *Firewaller) startService(service *firewaller.Application) error {
 // units and services with the opened and closed ports globally and
 // opens and closes the appropriate ports for the whole environment.
 func (fw *Firewaller) reconcileGlobal() error {
-	initialPortRanges, err := fw.environ.Ports()
+	initialRules, err := fw.globalIngressRules()
 	if err != nil {
 		return err
 	}
-	collector := make(map[network.PortRange]bool)
+	var wantedRules []network.IngressRule
 	for _, machined := range fw.machineds {
 		for portRange, unitTag := range machined.definedPorts {
 			unitd, known := machined.unitds[unitTag]
@@ -352,30 +365,31 @@ func (fw *Firewaller) reconcileGlobal() error {
 				continue
 			}
 			if unitd.serviced.exposed {
-				collector[portRange] = true
+				rules, err := fw.ingressRules(unitd.serviced, portRange)
+				if err != nil {
+					return err
+				}
+				wantedRules = append(wantedRules, rules...)
 			}
 		}
 	}
-	wantedPorts := []network.PortRange{}
-	for port := range collector {
-		wantedPorts = append(wantedPorts, port)
-	}
-	// Check which ports to open or to close.
-	toOpen := diffRanges(wantedPorts, initialPortRanges)
-	toClose := diffRanges(initialPortRanges, wantedPorts)
+	wantedRules = network.SplitIngressRules(wantedRules)
+	// Check which rules to open or to close.
+	toOpen := diffRules(wantedRules, initialRules)
+	toClose := diffRules(initialRules, wantedRules)
 	if len(toOpen) > 0 {
-		logger.Infof("opening global ports %v", toOpen)
-		if err := fw.environ.OpenPorts(toOpen); err != nil {
+		logger.Infof("opening global ingress rules %v", toOpen)
+		if err := fw.openGlobalRules(toOpen); err != nil {
 			return err
 		}
-		network.SortPortRanges(toOpen)
+		network.SortIngressRules(toOpen)
 	}
 	if len(toClose) > 0 {
-		logger.Infof("closing global ports %v", toClose)
-		if err := fw.environ.ClosePorts(toClose); err != nil {
+		logger.Infof("closing global ingress rules %v", toClose)
+		if err := fw.closeGlobalRules(toClose, wantedRules); err != nil {
 			return err
 		}
-		network.SortPortRanges(toClose)
+		network.SortIngressRules(toClose)
 	}
 	return nil
 }
@@ -411,31 +425,31 @@ func (fw *Firewaller) reconcileInstances() error {
 			return err
 		}
 		machineId := machined.tag.Id()
-		initialPortRanges, err := instances[0].Ports(machineId)
+		initialRules, err := instanceIngressRules(instances[0], machineId)
 		if err != nil {
 			return err
 		}
 
-		// Check which ports to open or to close.
-		toOpen := diffRanges(machined.openedPorts, initialPortRanges)
-		toClose := diffRanges(initialPortRanges, machined.openedPorts)
+		// Check which rules to open or to close.
+		toOpen := diffRules(machined.ingressRules, initialRules)
+		toClose := diffRules(initialRules, machined.ingressRules)
 		if len(toOpen) > 0 {
-			logger.Infof("opening instance port ranges %v for %q",
+			logger.Infof("opening instance ingress rules %v for %q",
 				toOpen, machined.tag)
-			if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+			if err := openInstanceRules(instances[0], machineId, toOpen); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
-			network.SortPortRanges(toOpen)
+			network.SortIngressRules(toOpen)
 		}
 		if len(toClose) > 0 {
-			logger.Infof("closing instance port ranges %v for %q",
+			logger.Infof("closing instance ingress rules %v for %q",
 				toClose, machined.tag)
-			if err := instances[0].ClosePorts(machineId, toClose); err != nil {
+			if err := closeInstanceRules(instances[0], machineId, toClose, machined.ingressRules); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
-			network.SortPortRanges(toClose)
+			network.SortIngressRules(toClose)
 		}
 	}
 	return nil
@@ -556,8 +570,8 @@ func (fw *Firewaller) flushUnits(unitds []*unitData) error {
 
 // flushMachine opens and closes ports for the passed machine.
 func (fw *Firewaller) flushMachine(machined *machineData) error {
-	// Gather ports to open and close.
-	want := []network.PortRange{}
+	// Gather rules to open and close.
+	want := []network.IngressRule{}
 	for portRange, unitTag := range machined.definedPorts {
 		unitd, known := machined.unitds[unitTag]
 		if !known {
@@ -565,59 +579,116 @@ func (fw *Firewaller) flushMachine(machined *machineData) error {
 			continue
 		}
 		if unitd.serviced.exposed {
-			want = append(want, portRange)
+			rules, err := fw.ingressRules(unitd.serviced, portRange)
+			if err != nil {
+				return err
+			}
+			want = append(want, rules...)
 		}
 	}
-	toOpen := diffRanges(want, machined.openedPorts)
-	toClose := diffRanges(machined.openedPorts, want)
-	machined.openedPorts = want
+	want = network.SplitIngressRules(want)
+	toOpen := diffRules(want, machined.ingressRules)
+	toClose := diffRules(machined.ingressRules, want)
+	machined.ingressRules = want
 	if fw.globalMode {
-		return fw.flushGlobalPorts(toOpen, toClose)
+		return fw.flushGlobalRules(toOpen, toClose)
+	}
+	return fw.flushInstanceRules(machined, toOpen, toClose)
+}
+
+// ingressRules returns the rules opening the given port range of an
+// exposed application, one for each source CIDR: the application's own
+// source CIDRs if it has any, otherwise the model's default ingress
+// CIDRs.
+//
+// The firewaller always deals in rules with a single source CIDR, so
+// that rules are diffed and reference counted per port range and CIDR.
+// Providers may merge the CIDRs of a port range into one rule, and
+// applications may share some but not all of their CIDRs; comparing
+// whole rules would then churn, or close a CIDR still needed by
+// another application.
+func (fw *Firewaller) ingressRules(serviced *serviceData, portRange network.PortRange) ([]network.IngressRule, error) {
+	cidrs := serviced.cidrs
+	if len(cidrs) == 0 {
+		cidrs = fw.ingressCIDRs
+	}
+	rule, err := network.NewIngressRule(portRange, cidrs...)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot open %v for %q", portRange, serviced.application.Tag())
 	}
-	return fw.flushInstancePorts(machined, toOpen, toClose)
+	return network.SplitIngressRules([]network.IngressRule{rule}), nil
 }
 
-// flushGlobalPorts opens and closes global ports in the environment.
-// It keeps a reference count for ports so that only 0-to-1 and 1-to-0 events
-// modify the environment.
-func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.PortRange) error {
-	// Filter which ports are really to open or close.
-	var toOpen, toClose []network.PortRange
-	for _, portRange := range rawOpen {
-		if fw.globalPortRef[portRange] == 0 {
-			toOpen = append(toOpen, portRange)
+// ingressCIDRsChanged updates the model's default ingress CIDRs and,
+// if they changed, the rules of every machine.
+func (fw *Firewaller) ingressCIDRsChanged(cidrs []string) error {
+	if stringSlicesEqual(fw.ingressCIDRs, cidrs) {
+		return nil
+	}
+	logger.Infof("model ingress CIDRs changed to %v", cidrs)
+	fw.ingressCIDRs = cidrs
+	for _, machined := range fw.machineds {
+		if err := fw.flushMachine(machined); err != nil {
+			return err
 		}
-		fw.globalPortRef[portRange]++
 	}
-	for _, portRange := range rawClose {
-		fw.globalPortRef[portRange]--
-		if fw.globalPortRef[portRange] == 0 {
-			toClose = append(toClose, portRange)
-			delete(fw.globalPortRef, portRange)
+	return nil
+}
+
+// flushGlobalRules opens and closes global ingress rules in the environment.
+// It keeps a reference count for each port range and source CIDR so that
+// only 0-to-1 and 1-to-0 events modify the environment.
+func (fw *Firewaller) flushGlobalRules(rawOpen, rawClose []network.IngressRule) error {
+	// Filter which rules are really to open or close.
+	var toOpen, toClose []network.IngressRule
+	for _, rule := range rawOpen {
+		key := rule.String()
+		ref, ok := fw.globalRuleRef[key]
+		if !ok {
+			ref = &ruleRef{rule: rule}
+			fw.globalRuleRef[key] = ref
+			toOpen = append(toOpen, rule)
 		}
+		ref.count++
 	}
-	// Open and close the ports.
+	for _, rule := range rawClose {
+		key := rule.String()
+		ref, ok := fw.globalRuleRef[key]
+		if !ok {
+			continue
+		}
+		ref.count--
+		if ref.count == 0 {
+			toClose = append(toClose, rule)
+			delete(fw.globalRuleRef, key)
+		}
+	}
+	// Open and close the rules.
 	if len(toOpen) > 0 {
-		if err := fw.environ.OpenPorts(toOpen); err != nil {
+		if err := fw.openGlobalRules(toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
-		logger.Infof("opened port ranges %v in environment", toOpen)
+		network.SortIngressRules(toOpen)
+		logger.Infof("opened ingress rules %v in environment", toOpen)
 	}
 	if len(toClose) > 0 {
-		if err := fw.environ.ClosePorts(toClose); err != nil {
+		var keep []network.IngressRule
+		for _, ref := range fw.globalRuleRef {
+			keep = append(keep, ref.rule)
+		}
+		if err := fw.closeGlobalRules(toClose, keep); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toClose)
-		logger.Infof("closed port ranges %v in environment", toClose)
+		network.SortIngressRules(toClose)
+		logger.Infof("closed ingress rules %v in environment", toClose)
 	}
 	return nil
 }
 
-// flushInstancePorts opens and closes ports global on the machine.
-func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose []network.PortRange) error {
+// flushInstanceRules opens and closes ingress rules on the machine.
+func (fw *Firewaller) flushInstanceRules(machined *machineData, toOpen, toClose []network.IngressRule) error {
 	// If there's nothing to do, do nothing.
 	// This is important because when a machine is first created,
 	// it will have no instance id but also no open ports -
@@ -641,22 +712,22 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	if err != nil {
 		return err
 	}
-	// Open and close the ports.
+	// Open and close the rules.
 	if len(toOpen) > 0 {
-		if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+		if err := openInstanceRules(instances[0], machineId, toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
-		logger.Infof("opened port ranges %v on %q", toOpen, machined.tag)
+		network.SortIngressRules(toOpen)
+		logger.Infof("opened ingress rules %v on %q", toOpen, machined.tag)
 	}
 	if len(toClose) > 0 {
-		if err := instances[0].ClosePorts(machineId, toClose); err != nil {
+		if err := closeInstanceRules(instances[0], machineId, toClose, machined.ingressRules); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toClose)
-		logger.Infof("closed port ranges %v on %q", toClose, machined.tag)
+		network.SortIngressRules(toClose)
+		logger.Infof("closed ingress rules %v on %q", toClose, machined.tag)
 	}
 	return nil
 }
@@ -751,11 +822,12 @@ type unitsChange struct {
 
 // machineData holds machine details and watches units added or removed.
 type machineData struct {
-	catacomb    catacomb.Catacomb
-	fw          *Firewaller
-	tag         names.MachineTag
-	unitds      map[names.UnitTag]*unitData
-	openedPorts []network.PortRange
+	catacomb catacomb.Catacomb
+	fw       *Firewaller
+	tag      names.MachineTag
+	unitds   map[names.UnitTag]*unitData
+	// ingressRules holds the rules currently opened for the machine.
+	ingressRules []network.IngressRule
 	// ports defined by units on this machine
 	definedPorts map[network.PortRange]names.UnitTag
 }
@@ -805,10 +877,12 @@ type unitData struct {
 	machined *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific service.
+// exposedChange contains the changed exposed flag and source CIDRs for
+// one specific service.
 type exposedChange struct {
 	serviced *serviceData
 	exposed  bool
+	cidrs    []string
 }
 
 // serviceData holds service details and watches exposure changes.
@@ -817,11 +891,13 @@ type serviceData struct {
 	fw          *Firewaller
 	application *firewaller.Application
 	exposed     bool
+	cidrs       []string
 	unitds      map[names.UnitTag]*unitData
 }
 
-// watchLoop watches the service's exposed flag for changes.
-func (sd *serviceData) watchLoop(exposed bool) error {
+// watchLoop watches the service's exposed flag and source CIDRs for
+// changes.
+func (sd *serviceData) watchLoop(exposed bool, cidrs []string) error {
 	serviceWatcher, err := sd.application.Watch()
 	if err != nil {
 		return errors.Trace(err)
@@ -847,13 +923,17 @@ func (sd *serviceData) watchLoop(exposed bool) error {
 			if err != nil {
 				return errors.Trace(err)
 			}
-			if change == exposed {
+			changeCIDRs, err := sd.application.ExposedCIDRs()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if change == exposed && stringSlicesEqual(changeCIDRs, cidrs) {
 				continue
 			}
 
-			exposed = change
+			exposed, cidrs = change, changeCIDRs
 			select {
-			case sd.fw.exposedChange <- &exposedChange{sd, change}:
+			case sd.fw.exposedChange <- &exposedChange{sd, change, changeCIDRs}:
 			case <-sd.catacomb.Dying():
 				return sd.catacomb.ErrDying()
 			}
@@ -871,12 +951,18 @@ func (sd *serviceData) Wait() error {
 	return sd.catacomb.Wait()
 }
 
-// diffRanges returns all the port rangess that exist in A but not B.
-func diffRanges(A, B []network.PortRange) (missing []network.PortRange) {
+// ruleRef holds the number of machines wanting a global ingress rule.
+type ruleRef struct {
+	rule  network.IngressRule
+	count int
+}
+
+// diffRules returns all the ingress rules that exist in A but not B.
+func diffRules(A, B []network.IngressRule) (missing []network.IngressRule) {
 next:
 	for _, a := range A {
 		for _, b := range B {
-			if a == b {
+			if a.String() == b.String() {
 				continue next
 			}
 		}
@@ -885,6 +971,18 @@ next:
 	return
 }
 
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // parsePortsKey parses a ports document global key coming from the ports
 // watcher (e.g. "42:0.1.2.0/24") and returns the machine and subnet tags from
 // its components (in the last example "machine-42" and "subnet-0.1.2.0/24").
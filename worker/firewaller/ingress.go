@@ -0,0 +1,144 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewaller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+// The functions below apply ingress rules to the environment or to an
+// instance. Providers that cannot restrict the source addresses of
+// traffic, or that report ingress rules as not supported, only support
+// rules open to anywhere, applied as plain ports; restricted rules are
+// never opened on them, so that an application is not left exposed
+// more widely than its operator asked for.
+//
+// Rules read from the environment or an instance are split into one
+// rule per source CIDR, matching the rules the firewaller computes.
+// When closing rules as plain ports, a port is left open if any of the
+// rules to keep still opens it to anywhere, as a port may be open to
+// anywhere over both IPv4 and IPv6.
+
+func (fw *Firewaller) globalIngressRules() ([]network.IngressRule, error) {
+	if rf, ok := fw.environ.(environs.IngressRuleFirewaller); ok {
+		rules, err := rf.IngressRules()
+		if !errors.IsNotSupported(err) {
+			return network.SplitIngressRules(rules), err
+		}
+	}
+	ports, err := fw.environ.Ports()
+	if err != nil {
+		return nil, err
+	}
+	return network.IngressRulesFromPorts(ports), nil
+}
+
+func (fw *Firewaller) openGlobalRules(rules []network.IngressRule) error {
+	if rf, ok := fw.environ.(environs.IngressRuleFirewaller); ok {
+		if err := rf.OpenIngressRules(rules); !errors.IsNotSupported(err) {
+			return err
+		}
+	}
+	ports := unrestrictedPorts(rules)
+	if len(ports) == 0 {
+		return nil
+	}
+	return fw.environ.OpenPorts(ports)
+}
+
+func (fw *Firewaller) closeGlobalRules(rules, keep []network.IngressRule) error {
+	if rf, ok := fw.environ.(environs.IngressRuleFirewaller); ok {
+		if err := rf.CloseIngressRules(rules); !errors.IsNotSupported(err) {
+			return err
+		}
+	}
+	ports := closablePorts(rules, keep)
+	if len(ports) == 0 {
+		return nil
+	}
+	return fw.environ.ClosePorts(ports)
+}
+
+func instanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
+	if rf, ok := inst.(instance.InstanceIngressRuleFirewaller); ok {
+		rules, err := rf.IngressRules(machineId)
+		if !errors.IsNotSupported(err) {
+			return network.SplitIngressRules(rules), err
+		}
+	}
+	ports, err := inst.Ports(machineId)
+	if err != nil {
+		return nil, err
+	}
+	return network.IngressRulesFromPorts(ports), nil
+}
+
+func openInstanceRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	if rf, ok := inst.(instance.InstanceIngressRuleFirewaller); ok {
+		if err := rf.OpenIngressRules(machineId, rules); !errors.IsNotSupported(err) {
+			return err
+		}
+	}
+	ports := unrestrictedPorts(rules)
+	if len(ports) == 0 {
+		return nil
+	}
+	return inst.OpenPorts(machineId, ports)
+}
+
+func closeInstanceRules(inst instance.Instance, machineId string, rules, keep []network.IngressRule) error {
+	if rf, ok := inst.(instance.InstanceIngressRuleFirewaller); ok {
+		if err := rf.CloseIngressRules(machineId, rules); !errors.IsNotSupported(err) {
+			return err
+		}
+	}
+	ports := closablePorts(rules, keep)
+	if len(ports) == 0 {
+		return nil
+	}
+	return inst.ClosePorts(machineId, ports)
+}
+
+// unrestrictedPorts returns the port ranges of those rules that are
+// open to anywhere, logging the ones that are not. A port range open
+// to anywhere over both IPv4 and IPv6 is returned once.
+func unrestrictedPorts(rules []network.IngressRule) []network.PortRange {
+	var ports []network.PortRange
+	seen := make(map[network.PortRange]bool)
+	for _, rule := range rules {
+		if !rule.OpenToAll() {
+			logger.Warningf("provider cannot restrict ingress to source CIDRs, ignoring %v", rule)
+			continue
+		}
+		if seen[rule.PortRange] {
+			continue
+		}
+		seen[rule.PortRange] = true
+		ports = append(ports, rule.PortRange)
+	}
+	return ports
+}
+
+// closablePorts returns the port ranges of those rules to close that
+// are open to anywhere, leaving out any that a rule to keep still opens
+// to anywhere.
+func closablePorts(rules, keep []network.IngressRule) []network.PortRange {
+	kept := make(map[network.PortRange]bool)
+	for _, rule := range keep {
+		if rule.OpenToAll() {
+			kept[rule.PortRange] = true
+		}
+	}
+	var ports []network.PortRange
+	for _, port := range unrestrictedPorts(rules) {
+		if !kept[port] {
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
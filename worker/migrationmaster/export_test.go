@@ -3,5 +3,7 @@
 
 package migrationmaster
 
-var ApiOpen = &apiOpen
-var TempSuccessSleep = &tempSuccessSleep
+var (
+	ApiOpen        = &apiOpen
+	UploadBinaries = &uploadBinaries
+)
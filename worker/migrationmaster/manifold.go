@@ -5,6 +5,8 @@ package migrationmaster
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/dependency"
@@ -16,6 +18,7 @@ import (
 type ManifoldConfig struct {
 	APICallerName string
 	FortressName  string
+	ClockName     string
 
 	NewFacade func(base.APICaller) (Facade, error)
 	NewWorker func(Config) (worker.Worker, error)
@@ -29,6 +32,9 @@ func (config ManifoldConfig) validate() error {
 	if config.FortressName == "" {
 		return errors.NotValidf("empty FortressName")
 	}
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
 	if config.NewFacade == nil {
 		return errors.NotValidf("nil NewFacade")
 	}
@@ -51,6 +57,10 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	if err := context.Get(config.FortressName, &guard); err != nil {
 		return nil, errors.Trace(err)
 	}
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
 	facade, err := config.NewFacade(apiCaller)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -58,6 +68,7 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	worker, err := config.NewWorker(Config{
 		Facade: facade,
 		Guard:  guard,
+		Clock:  clock,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -68,7 +79,7 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 // Manifold packages a Worker for use in a dependency.Engine.
 func Manifold(config ManifoldConfig) dependency.Manifold {
 	return dependency.Manifold{
-		Inputs: []string{config.APICallerName, config.FortressName},
+		Inputs: []string{config.APICallerName, config.FortressName, config.ClockName},
 		Start:  config.start,
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/juju/worker/migrationmaster"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 )
 
@@ -35,10 +36,17 @@ func (*ValidateSuite) TestMissingFacade(c *gc.C) {
 	checkNotValid(c, config, "nil Facade not valid")
 }
 
+func (*ValidateSuite) TestMissingClock(c *gc.C) {
+	config := validConfig()
+	config.Clock = nil
+	checkNotValid(c, config, "nil Clock not valid")
+}
+
 func validConfig() migrationmaster.Config {
 	return migrationmaster.Config{
 		Guard:  struct{ fortress.Guard }{},
 		Facade: struct{ migrationmaster.Facade }{},
+		Clock:  struct{ clock.Clock }{},
 	}
 }
 
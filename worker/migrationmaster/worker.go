@@ -4,16 +4,25 @@
 package migrationmaster
 
 import (
+	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/migrationmaster"
 	"github.com/juju/juju/api/migrationtarget"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/migration"
+	modelmigration "github.com/juju/juju/migration"
+	"github.com/juju/juju/tools"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/catacomb"
 	"github.com/juju/juju/worker/dependency"
@@ -21,9 +30,9 @@ import (
 )
 
 var (
-	logger           = loggo.GetLogger("juju.worker.migrationmaster")
-	apiOpen          = api.Open
-	tempSuccessSleep = 10 * time.Second
+	logger         = loggo.GetLogger("juju.worker.migrationmaster")
+	apiOpen        = api.Open
+	uploadBinaries = modelmigration.UploadBinaries
 
 	// ErrDoneForNow indicates a temporary issue was encountered and
 	// that the worker should restart and retry.
@@ -46,14 +55,59 @@ type Facade interface {
 	SetPhase(migration.Phase) error
 
 	// Export returns a serialized representation of the model
-	// associated with the API connection.
-	Export() ([]byte, error)
+	// associated with the API connection, along with the charms and
+	// agent binaries it uses.
+	Export() (migration.SerializedModel, error)
+
+	// OpenCharm streams out the archive of the identified charm from
+	// the controller.
+	OpenCharm(*charm.URL) (io.ReadCloser, error)
+
+	// OpenTools streams out the identified agent binaries from the
+	// controller.
+	OpenTools(version.Binary) (io.ReadCloser, error)
+
+	// Prechecks checks that the model associated with the API
+	// connection is in a fit state to be migrated, returning every
+	// problem found.
+	Prechecks() ([]string, error)
+
+	// ModelInfo returns the details of the model associated with the
+	// API connection that the target controller needs to run its
+	// prechecks.
+	ModelInfo() (migration.ModelInfo, error)
+
+	// WatchMinionReports returns a watcher which reports when a
+	// migration minion has made a report for the current migration
+	// phase.
+	WatchMinionReports() (watcher.NotifyWatcher, error)
+
+	// MinionReports returns details of the reports made by migration
+	// minions to the controller for the current migration phase.
+	MinionReports() (migration.MinionReports, error)
+
+	// ModelLogs returns approximately limit of the model's log
+	// records which were logged strictly after the time given.
+	ModelLogs(after time.Time, limit int) ([]migration.LogRecord, error)
+
+	// Reap removes all documents for the model associated with the
+	// API connection once it has been migrated.
+	Reap() error
 }
 
+// minionWaitTimeout is how long the worker waits for every migration
+// minion to report back for a phase before giving up.
+const minionWaitTimeout = 15 * time.Minute
+
+// logTransferBatchSize is the approximate number of log records sent
+// to the target controller at a time during LOGTRANSFER.
+const logTransferBatchSize = 1000
+
 // Config defines the operation of a Worker.
 type Config struct {
 	Facade Facade
 	Guard  fortress.Guard
+	Clock  clock.Clock
 }
 
 // Validate returns an error if config cannot drive a Worker.
@@ -64,6 +118,9 @@ func (config Config) Validate() error {
 	if config.Guard == nil {
 		return errors.NotValidf("nil Guard")
 	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
 	return nil
 }
 
@@ -123,19 +180,19 @@ func (w *Worker) run() error {
 		var err error
 		switch phase {
 		case migration.QUIESCE:
-			phase, err = w.doQUIESCE()
+			phase, err = w.doQUIESCE(status)
 		case migration.READONLY:
 			phase, err = w.doREADONLY()
 		case migration.PRECHECK:
-			phase, err = w.doPRECHECK()
+			phase, err = w.doPRECHECK(status.TargetInfo)
 		case migration.IMPORT:
-			phase, err = w.doIMPORT(status.TargetInfo)
+			phase, err = w.doIMPORT(status.TargetInfo, status.ModelUUID)
 		case migration.VALIDATION:
-			phase, err = w.doVALIDATION(status.TargetInfo, status.ModelUUID)
+			phase, err = w.doVALIDATION(status)
 		case migration.SUCCESS:
-			phase, err = w.doSUCCESS()
+			phase, err = w.doSUCCESS(status)
 		case migration.LOGTRANSFER:
-			phase, err = w.doLOGTRANSFER()
+			phase, err = w.doLOGTRANSFER(status.TargetInfo, status.ModelUUID)
 		case migration.REAP:
 			phase, err = w.doREAP()
 		case migration.ABORT:
@@ -161,6 +218,7 @@ func (w *Worker) run() error {
 		if err := w.config.Facade.SetPhase(phase); err != nil {
 			return errors.Annotate(err, "failed to set phase")
 		}
+		status.Phase = phase
 
 		if modelHasMigrated(phase) {
 			// TODO(mjs) - use manifold Filter so that the dep engine
@@ -182,8 +240,16 @@ func (w *Worker) killed() bool {
 	}
 }
 
-func (w *Worker) doQUIESCE() (migration.Phase, error) {
-	// TODO(mjs) - Wait for all agents to report back.
+func (w *Worker) doQUIESCE(status migrationmaster.MigrationStatus) (migration.Phase, error) {
+	// Wait for all agents to report that they've stopped working
+	// against the source controller.
+	ok, err := w.waitForMinions(status, failFast, "quiescing")
+	if err != nil {
+		return migration.UNKNOWN, errors.Trace(err)
+	}
+	if !ok {
+		return migration.ABORT, nil
+	}
 	return migration.READONLY, nil
 }
 
@@ -192,14 +258,50 @@ func (w *Worker) doREADONLY() (migration.Phase, error) {
 	return migration.PRECHECK, nil
 }
 
-func (w *Worker) doPRECHECK() (migration.Phase, error) {
-	// TODO(mjs) - To be implemented.
+func (w *Worker) doPRECHECK(targetInfo migration.TargetInfo) (migration.Phase, error) {
+	problems, err := runPrechecks(w.config.Facade, targetInfo)
+	if err != nil {
+		logger.Errorf("prechecks failed: %v", err)
+		return migration.ABORT, nil
+	}
+	if len(problems) > 0 {
+		for _, problem := range problems {
+			logger.Errorf("precheck failed: %s", problem)
+		}
+		return migration.ABORT, nil
+	}
 	return migration.IMPORT, nil
 }
 
-func (w *Worker) doIMPORT(targetInfo migration.TargetInfo) (migration.Phase, error) {
+// runPrechecks returns every problem found by the source and target
+// controllers which would prevent the model from being migrated.
+func runPrechecks(facade Facade, targetInfo migration.TargetInfo) ([]string, error) {
+	logger.Infof("running source prechecks")
+	problems, err := facade.Prechecks()
+	if err != nil {
+		return nil, errors.Annotate(err, "source prechecks")
+	}
+	model, err := facade.ModelInfo()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving model info")
+	}
+
+	logger.Infof("running target prechecks")
+	conn, err := openAPIConn(targetInfo)
+	if err != nil {
+		return nil, errors.Annotate(err, "connecting to target controller")
+	}
+	defer conn.Close()
+	targetProblems, err := migrationtarget.NewClient(conn).Prechecks(model)
+	if err != nil {
+		return nil, errors.Annotate(err, "target prechecks")
+	}
+	return append(problems, targetProblems...), nil
+}
+
+func (w *Worker) doIMPORT(targetInfo migration.TargetInfo, modelUUID string) (migration.Phase, error) {
 	logger.Infof("exporting model")
-	bytes, err := w.config.Facade.Export()
+	serialized, err := w.config.Facade.Export()
 	if err != nil {
 		logger.Errorf("model export failed: %v", err)
 		return migration.ABORT, nil
@@ -215,21 +317,45 @@ func (w *Worker) doIMPORT(targetInfo migration.TargetInfo) (migration.Phase, err
 
 	logger.Infof("importing model into target controller")
 	targetClient := migrationtarget.NewClient(conn)
-	err = targetClient.Import(bytes)
+	err = targetClient.Import(serialized.Bytes)
 	if err != nil {
 		logger.Errorf("failed to import model into target controller: %v", err)
 		return migration.ABORT, nil
 	}
 
+	logger.Infof("uploading binaries into target controller")
+	uploader := &targetUploader{targetClient, modelUUID}
+	err = uploadBinaries(modelmigration.UploadBinariesConfig{
+		Charms:          serialized.Charms,
+		CharmDownloader: w.config.Facade,
+		CharmUploader:   uploader,
+		Tools:           serialized.Tools,
+		ToolsDownloader: w.config.Facade,
+		ToolsUploader:   uploader,
+	})
+	if err != nil {
+		logger.Errorf("failed to upload binaries to target controller: %v", err)
+		return migration.ABORT, nil
+	}
+
 	return migration.VALIDATION, nil
 }
 
-func (w *Worker) doVALIDATION(targetInfo migration.TargetInfo, modelUUID string) (migration.Phase, error) {
-	// TODO(mjs) - Wait for all agents to report back.
+func (w *Worker) doVALIDATION(status migrationmaster.MigrationStatus) (migration.Phase, error) {
+	// Wait for agents to report back that they can connect to the
+	// target controller.
+	ok, err := w.waitForMinions(status, failFast, "validating")
+	if err != nil {
+		return migration.UNKNOWN, errors.Trace(err)
+	}
+	if !ok {
+		return migration.ABORT, nil
+	}
 
 	// Once all agents have validated, activate the model.
-	err := activateModel(targetInfo, modelUUID)
+	err = activateModel(status.TargetInfo, status.ModelUUID)
 	if err != nil {
+		logger.Errorf("failed to activate model on target controller: %v", err)
 		return migration.ABORT, nil
 	}
 	return migration.SUCCESS, nil
@@ -247,21 +373,62 @@ func activateModel(targetInfo migration.TargetInfo, modelUUID string) error {
 	return errors.Trace(err)
 }
 
-func (w *Worker) doSUCCESS() (migration.Phase, error) {
-	// XXX(mjs) - this is a horrible hack, which helps to ensure that
-	// minions will see the SUCCESS state (due to watcher event
-	// coalescing). It will go away soon.
-	time.Sleep(tempSuccessSleep)
+func (w *Worker) doSUCCESS(status migrationmaster.MigrationStatus) (migration.Phase, error) {
+	// The model is already active on the target controller, so
+	// there's no going back. Wait for agents to switch over but
+	// don't worry about stragglers or failures.
+	_, err := w.waitForMinions(status, waitForAll, "successful")
+	if err != nil {
+		return migration.UNKNOWN, errors.Trace(err)
+	}
 	return migration.LOGTRANSFER, nil
 }
 
-func (w *Worker) doLOGTRANSFER() (migration.Phase, error) {
-	// TODO(mjs) - To be implemented.
+func (w *Worker) doLOGTRANSFER(targetInfo migration.TargetInfo, modelUUID string) (migration.Phase, error) {
+	// The model is already running on the target controller so
+	// there's no going back. Errors here cause the worker to exit;
+	// the transfer is resumed from the last record the target
+	// received when the worker restarts.
+	logger.Infof("transferring logs to target controller")
+	conn, err := openAPIConn(targetInfo)
+	if err != nil {
+		return migration.UNKNOWN, errors.Annotate(err, "connecting to target controller")
+	}
+	defer conn.Close()
+	targetClient := migrationtarget.NewClient(conn)
+
+	after, err := targetClient.LatestLogTime(modelUUID)
+	if err != nil {
+		return migration.UNKNOWN, errors.Annotate(err, "retrieving latest transferred log time")
+	}
+	var count int
+	for {
+		if w.killed() {
+			return migration.UNKNOWN, w.catacomb.ErrDying()
+		}
+		records, err := w.config.Facade.ModelLogs(after, logTransferBatchSize)
+		if err != nil {
+			return migration.UNKNOWN, errors.Annotate(err, "retrieving model logs")
+		}
+		if len(records) == 0 {
+			break
+		}
+		if err := targetClient.AddLogs(modelUUID, records); err != nil {
+			return migration.UNKNOWN, errors.Annotate(err, "sending logs to target controller")
+		}
+		after = records[len(records)-1].Time
+		count += len(records)
+	}
+	logger.Infof("transferred %d log records", count)
 	return migration.REAP, nil
 }
 
 func (w *Worker) doREAP() (migration.Phase, error) {
-	// TODO(mjs) - To be implemented.
+	logger.Infof("removing model from source controller")
+	if err := w.config.Facade.Reap(); err != nil {
+		logger.Errorf("failed to remove model from source controller: %v", err)
+		return migration.REAPFAILED, nil
+	}
 	return migration.DONE, nil
 }
 
@@ -323,6 +490,122 @@ func (w *Worker) waitForActiveMigration() (migrationmaster.MigrationStatus, erro
 	}
 }
 
+// minionWaitPolicy determines how waitForMinions reacts to failure
+// reports from migration minions.
+type minionWaitPolicy bool
+
+const (
+	failFast   minionWaitPolicy = false // Stop waiting at first minion failure report
+	waitForAll minionWaitPolicy = true  // Wait for all minion reports to arrive (or timeout)
+)
+
+// waitForMinions waits for every migration minion to report back for
+// the current phase. It returns true if they all reported success and
+// false if any failed or didn't report before the timeout. An error
+// is only returned if the worker should exit.
+func (w *Worker) waitForMinions(
+	status migrationmaster.MigrationStatus,
+	policy minionWaitPolicy,
+	infoPrefix string,
+) (success bool, err error) {
+	clk := w.config.Clock
+	timeout := clk.After(minionWaitTimeout)
+
+	watcher, err := w.config.Facade.WatchMinionReports()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if err := w.catacomb.Add(watcher); err != nil {
+		return false, errors.Trace(err)
+	}
+	defer watcher.Kill()
+
+	logger.Infof("waiting for agents to report back for %s", status.Phase)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return false, w.catacomb.ErrDying()
+
+		case <-timeout:
+			logger.Errorf("%s", formatMinionTimeout(status, infoPrefix))
+			return false, nil
+
+		case <-watcher.Changes():
+		}
+
+		reports, err := w.config.Facade.MinionReports()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if err := validateMinionReports(reports, status); err != nil {
+			return false, errors.Trace(err)
+		}
+
+		failures := len(reports.Failed)
+		if failures > 0 && policy == failFast {
+			logger.Errorf("%s", formatMinionFailure(reports, infoPrefix))
+			return false, nil
+		}
+		if reports.UnknownCount == 0 {
+			if failures > 0 {
+				logger.Warningf("%s", formatMinionFailure(reports, infoPrefix))
+				return false, nil
+			}
+			logger.Infof("all agents report success for %s", status.Phase)
+			return true, nil
+		}
+	}
+}
+
+// validateMinionReports checks that the reports are for the migration
+// and phase being waited on.
+func validateMinionReports(reports migration.MinionReports, status migrationmaster.MigrationStatus) error {
+	if reports.MigrationId != status.MigrationId {
+		return errors.Errorf("unexpected migration id in minion reports, got %v, expected %v",
+			reports.MigrationId, status.MigrationId)
+	}
+	if reports.Phase != status.Phase {
+		return errors.Errorf("minion reports phase (%s) does not match migration phase (%s)",
+			reports.Phase, status.Phase)
+	}
+	return nil
+}
+
+func formatMinionTimeout(status migrationmaster.MigrationStatus, infoPrefix string) string {
+	return fmt.Sprintf("%s, timed out waiting for agents to report back for %s",
+		infoPrefix, status.Phase)
+}
+
+func formatMinionFailure(reports migration.MinionReports, infoPrefix string) string {
+	return fmt.Sprintf("%s, some agents reported failure: %s",
+		infoPrefix, formatTags(reports.Failed))
+}
+
+func formatTags(tags []names.Tag) string {
+	out := make([]string, len(tags))
+	for i, tag := range tags {
+		out[i] = tag.Id()
+	}
+	return strings.Join(out, ", ")
+}
+
+// targetUploader sends the binaries used by the model being migrated
+// to the target controller.
+type targetUploader struct {
+	client    migrationtarget.Client
+	modelUUID string
+}
+
+// UploadCharm is part of modelmigration.CharmUploader.
+func (u *targetUploader) UploadCharm(curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	return u.client.UploadCharm(u.modelUUID, curl, content)
+}
+
+// UploadTools is part of modelmigration.ToolsUploader.
+func (u *targetUploader) UploadTools(content io.ReadSeeker, vers version.Binary) (tools.List, error) {
+	return u.client.UploadTools(u.modelUUID, content, vers)
+}
+
 func openAPIConn(targetInfo migration.TargetInfo) (api.Connection, error) {
 	apiInfo := &api.Info{
 		Addrs:    targetInfo.Addrs,
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
@@ -16,6 +17,7 @@ import (
 	masterapi "github.com/juju/juju/api/migrationmaster"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/migration"
+	modelmigration "github.com/juju/juju/migration"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
@@ -27,16 +29,25 @@ import (
 
 type Suite struct {
 	coretesting.BaseSuite
+	clock         *coretesting.Clock
 	stub          *jujutesting.Stub
 	connection    *stubConnection
 	connectionErr error
+	uploadConfig  modelmigration.UploadBinariesConfig
+	uploadErr     error
 }
 
 var _ = gc.Suite(&Suite{})
 
 var (
-	fakeSerializedModel = []byte("model")
-	modelTagString      = names.NewModelTag("model-uuid").String()
+	fakeSerializedModel = migration.SerializedModel{
+		Bytes:  []byte("model"),
+		Charms: []string{"charm0", "charm1"},
+		Tools: map[version.Binary]string{
+			version.MustParseBinary("2.1.0-trusty-amd64"): "sha",
+		},
+	}
+	modelTagString = names.NewModelTag("model-uuid").String()
 
 	// Define stub calls that commonly appear in tests here to allow reuse.
 	apiOpenCall = jujutesting.StubCall{
@@ -54,7 +65,14 @@ var (
 	importCall = jujutesting.StubCall{
 		"APICall:MigrationTarget.Import",
 		[]interface{}{
-			params.SerializedModel{Bytes: fakeSerializedModel},
+			params.SerializedModel{Bytes: fakeSerializedModel.Bytes},
+		},
+	}
+	uploadBinariesCall = jujutesting.StubCall{
+		"UploadBinaries",
+		[]interface{}{
+			fakeSerializedModel.Charms,
+			fakeSerializedModel.Tools,
 		},
 	}
 	activateCall = jujutesting.StubCall{
@@ -63,6 +81,16 @@ var (
 			params.ModelArgs{ModelTag: modelTagString},
 		},
 	}
+	prechecksCall = jujutesting.StubCall{
+		"APICall:MigrationTarget.Prechecks",
+		[]interface{}{
+			params.MigrationModelInfo{
+				UUID:     "model-uuid",
+				Name:     "model",
+				OwnerTag: names.NewUserTag("owner").String(),
+			},
+		},
+	}
 	connCloseCall = jujutesting.StubCall{"Connection.Close", nil}
 	abortCall     = jujutesting.StubCall{
 		"APICall:MigrationTarget.Abort",
@@ -70,16 +98,24 @@ var (
 			params.ModelArgs{ModelTag: modelTagString},
 		},
 	}
+	latestLogTimeCall = jujutesting.StubCall{
+		"APICall:MigrationTarget.LatestLogTime",
+		[]interface{}{
+			params.ModelArgs{ModelTag: modelTagString},
+		},
+	}
 )
 
 func (s *Suite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 
+	s.clock = coretesting.NewClock(time.Now())
 	s.stub = new(jujutesting.Stub)
 	s.connection = &stubConnection{stub: s.stub}
 	s.connectionErr = nil
+	s.uploadErr = nil
 	s.PatchValue(migrationmaster.ApiOpen, s.apiOpen)
-	s.PatchValue(migrationmaster.TempSuccessSleep, time.Millisecond)
+	s.PatchValue(migrationmaster.UploadBinaries, s.uploadBinaries)
 }
 
 func (s *Suite) apiOpen(info *api.Info, dialOpts api.DialOpts) (api.Connection, error) {
@@ -90,6 +126,12 @@ func (s *Suite) apiOpen(info *api.Info, dialOpts api.DialOpts) (api.Connection,
 	return s.connection, nil
 }
 
+func (s *Suite) uploadBinaries(config modelmigration.UploadBinariesConfig) error {
+	s.stub.AddCall("UploadBinaries", config.Charms, config.Tools)
+	s.uploadConfig = config
+	return s.uploadErr
+}
+
 func (s *Suite) triggerMigration(masterClient *stubMasterClient) {
 	masterClient.watcherChanges <- struct{}{}
 }
@@ -99,6 +141,7 @@ func (s *Suite) TestSuccessfulMigration(c *gc.C) {
 	worker, err := migrationmaster.New(migrationmaster.Config{
 		Facade: masterClient,
 		Guard:  newStubGuard(s.stub),
+		Clock:  s.clock,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.triggerMigration(masterClient)
@@ -113,22 +156,46 @@ func (s *Suite) TestSuccessfulMigration(c *gc.C) {
 		{"masterClient.Watch", nil},
 		{"masterClient.GetMigrationStatus", nil},
 		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
 		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
 		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		{"masterClient.Prechecks", nil},
+		{"masterClient.ModelInfo", nil},
+		apiOpenCall,
+		prechecksCall,
+		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
 		{"masterClient.Export", nil},
 		apiOpenCall,
 		importCall,
+		uploadBinariesCall,
 		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.VALIDATION}},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
 		apiOpenCall,
 		activateCall,
 		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.SUCCESS}},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
 		{"masterClient.SetPhase", []interface{}{migration.LOGTRANSFER}},
+		apiOpenCall,
+		latestLogTimeCall,
+		{"masterClient.ModelLogs", []interface{}{time.Time{}, 1000}},
+		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.REAP}},
+		{"masterClient.Reap", nil},
 		{"masterClient.SetPhase", []interface{}{migration.DONE}},
 	})
+
+	// The binaries are downloaded from the source controller and
+	// uploaded to the target.
+	c.Check(s.uploadConfig.CharmDownloader, gc.Equals, masterClient)
+	c.Check(s.uploadConfig.ToolsDownloader, gc.Equals, masterClient)
+	c.Check(s.uploadConfig.CharmUploader, gc.NotNil)
+	c.Check(s.uploadConfig.ToolsUploader, gc.NotNil)
 }
 
 func (s *Suite) TestMigrationResume(c *gc.C) {
@@ -138,6 +205,7 @@ func (s *Suite) TestMigrationResume(c *gc.C) {
 	worker, err := migrationmaster.New(migrationmaster.Config{
 		Facade: masterClient,
 		Guard:  newStubGuard(s.stub),
+		Clock:  s.clock,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	masterClient.status.Phase = migration.SUCCESS
@@ -150,8 +218,15 @@ func (s *Suite) TestMigrationResume(c *gc.C) {
 		{"masterClient.Watch", nil},
 		{"masterClient.GetMigrationStatus", nil},
 		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
 		{"masterClient.SetPhase", []interface{}{migration.LOGTRANSFER}},
+		apiOpenCall,
+		latestLogTimeCall,
+		{"masterClient.ModelLogs", []interface{}{time.Time{}, 1000}},
+		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.REAP}},
+		{"masterClient.Reap", nil},
 		{"masterClient.SetPhase", []interface{}{migration.DONE}},
 	})
 }
@@ -163,6 +238,7 @@ func (s *Suite) TestPreviouslyAbortedMigration(c *gc.C) {
 	worker, err := migrationmaster.New(migrationmaster.Config{
 		Facade: masterClient,
 		Guard:  newStubGuard(s.stub),
+		Clock:  s.clock,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	workertest.CheckAlive(c, worker)
@@ -178,6 +254,7 @@ func (s *Suite) TestPreviouslyCompletedMigration(c *gc.C) {
 	worker, err := migrationmaster.New(migrationmaster.Config{
 		Facade: masterClient,
 		Guard:  newStubGuard(s.stub),
+		Clock:  s.clock,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -196,6 +273,7 @@ func (s *Suite) TestWatchFailure(c *gc.C) {
 	worker, err := migrationmaster.New(migrationmaster.Config{
 		Facade: masterClient,
 		Guard:  newStubGuard(s.stub),
+		Clock:  s.clock,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	err = workertest.CheckKilled(c, worker)
@@ -208,6 +286,7 @@ func (s *Suite) TestStatusError(c *gc.C) {
 	worker, err := migrationmaster.New(migrationmaster.Config{
 		Facade: masterClient,
 		Guard:  newStubGuard(s.stub),
+		Clock:  s.clock,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.triggerMigration(masterClient)
@@ -226,6 +305,7 @@ func (s *Suite) TestStatusNotFound(c *gc.C) {
 	worker, err := migrationmaster.New(migrationmaster.Config{
 		Facade: masterClient,
 		Guard:  newStubGuard(s.stub),
+		Clock:  s.clock,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.triggerMigration(masterClient)
@@ -248,6 +328,7 @@ func (s *Suite) TestUnlockError(c *gc.C) {
 	worker, err := migrationmaster.New(migrationmaster.Config{
 		Facade: masterClient,
 		Guard:  guard,
+		Clock:  s.clock,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.triggerMigration(masterClient)
@@ -269,6 +350,7 @@ func (s *Suite) TestLockdownError(c *gc.C) {
 	worker, err := migrationmaster.New(migrationmaster.Config{
 		Facade: masterClient,
 		Guard:  guard,
+		Clock:  s.clock,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.triggerMigration(masterClient)
@@ -289,6 +371,7 @@ func (s *Suite) TestExportFailure(c *gc.C) {
 	worker, err := migrationmaster.New(migrationmaster.Config{
 		Facade: masterClient,
 		Guard:  newStubGuard(s.stub),
+		Clock:  s.clock,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.triggerMigration(masterClient)
@@ -300,8 +383,15 @@ func (s *Suite) TestExportFailure(c *gc.C) {
 		{"masterClient.Watch", nil},
 		{"masterClient.GetMigrationStatus", nil},
 		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
 		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
 		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		{"masterClient.Prechecks", nil},
+		{"masterClient.ModelInfo", nil},
+		apiOpenCall,
+		prechecksCall,
+		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
 		{"masterClient.Export", nil},
 		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
@@ -317,6 +407,7 @@ func (s *Suite) TestAPIOpenFailure(c *gc.C) {
 	worker, err := migrationmaster.New(migrationmaster.Config{
 		Facade: masterClient,
 		Guard:  newStubGuard(s.stub),
+		Clock:  s.clock,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.connectionErr = errors.New("boom")
@@ -329,13 +420,85 @@ func (s *Suite) TestAPIOpenFailure(c *gc.C) {
 		{"masterClient.Watch", nil},
 		{"masterClient.GetMigrationStatus", nil},
 		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
 		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
 		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
-		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
-		{"masterClient.Export", nil},
+		{"masterClient.Prechecks", nil},
+		{"masterClient.ModelInfo", nil},
+		apiOpenCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
+		apiOpenCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
+func (s *Suite) TestSourcePrecheckFailure(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.prechecksProblems = []string{"model is dying"}
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
+		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
+		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		{"masterClient.Prechecks", nil},
+		{"masterClient.ModelInfo", nil},
+		apiOpenCall,
+		prechecksCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
+		apiOpenCall,
+		abortCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
+func (s *Suite) TestTargetPrecheckFailure(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	s.connection.prechecksProblems = []string{"target controller upgrade is in progress"}
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
+		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
+		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		{"masterClient.Prechecks", nil},
+		{"masterClient.ModelInfo", nil},
 		apiOpenCall,
+		prechecksCall,
+		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
 		apiOpenCall,
+		abortCall,
+		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
 	})
 }
@@ -345,6 +508,7 @@ func (s *Suite) TestImportFailure(c *gc.C) {
 	worker, err := migrationmaster.New(migrationmaster.Config{
 		Facade: masterClient,
 		Guard:  newStubGuard(s.stub),
+		Clock:  s.clock,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.connection.importErr = errors.New("boom")
@@ -357,8 +521,15 @@ func (s *Suite) TestImportFailure(c *gc.C) {
 		{"masterClient.Watch", nil},
 		{"masterClient.GetMigrationStatus", nil},
 		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
 		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
 		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		{"masterClient.Prechecks", nil},
+		{"masterClient.ModelInfo", nil},
+		apiOpenCall,
+		prechecksCall,
+		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
 		{"masterClient.Export", nil},
 		apiOpenCall,
@@ -372,6 +543,260 @@ func (s *Suite) TestImportFailure(c *gc.C) {
 	})
 }
 
+func (s *Suite) TestUploadBinariesFailure(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.uploadErr = errors.New("boom")
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
+		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
+		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		{"masterClient.Prechecks", nil},
+		{"masterClient.ModelInfo", nil},
+		apiOpenCall,
+		prechecksCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
+		{"masterClient.Export", nil},
+		apiOpenCall,
+		importCall,
+		uploadBinariesCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
+		apiOpenCall,
+		abortCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
+func (s *Suite) TestQUIESCEMinionFailure(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.minionReportsFailed = []names.Tag{names.NewMachineTag("42")}
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
+		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
+		apiOpenCall,
+		abortCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
+func (s *Suite) TestQUIESCEMinionTimeout(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.minionReportsUnknown = 1
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for minion wait timeout to be set")
+	}
+	s.clock.Advance(15 * time.Minute)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+	s.stub.CheckCall(c, 5, "masterClient.SetPhase", migration.ABORT)
+}
+
+func (s *Suite) TestVALIDATIONMinionFailure(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.status.Phase = migration.VALIDATION
+	masterClient.minionReportsFailed = []names.Tag{names.NewUnitTag("foo/0")}
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	// The model must not be activated on the target.
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
+		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
+		apiOpenCall,
+		abortCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
+func (s *Suite) TestSUCCESSMinionFailureIgnored(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.status.Phase = migration.SUCCESS
+	masterClient.minionReportsFailed = []names.Tag{names.NewUnitTag("foo/0")}
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(errors.Cause(err), gc.Equals, dependency.ErrUninstall)
+	s.stub.CheckCall(c, 5, "masterClient.SetPhase", migration.LOGTRANSFER)
+}
+
+func (s *Suite) TestLOGTRANSFERResumes(c *gc.C) {
+	t0 := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Second)
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.status.Phase = migration.LOGTRANSFER
+	masterClient.logBatches = [][]migration.LogRecord{{{
+		Time:    t1,
+		Entity:  "machine-0",
+		Message: "hello",
+	}}}
+	s.connection.latestLogTime = t0
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(errors.Cause(err), gc.Equals, dependency.ErrUninstall)
+
+	// Log records are only requested from after the last one the
+	// target controller received.
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		apiOpenCall,
+		latestLogTimeCall,
+		{"masterClient.ModelLogs", []interface{}{t0, 1000}},
+		{"APICall:MigrationTarget.AddLogs", []interface{}{
+			params.MigrationLogs{
+				ModelTag: modelTagString,
+				Records: []params.MigrationLogRecord{{
+					Time:    t1,
+					Entity:  "machine-0",
+					Message: "hello",
+				}},
+			},
+		}},
+		{"masterClient.ModelLogs", []interface{}{t1, 1000}},
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.REAP}},
+		{"masterClient.Reap", nil},
+		{"masterClient.SetPhase", []interface{}{migration.DONE}},
+	})
+}
+
+func (s *Suite) TestLOGTRANSFERFailure(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.status.Phase = migration.LOGTRANSFER
+	masterClient.modelLogsErr = errors.New("boom")
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	// The worker exits without changing phase so that the transfer
+	// is retried when it restarts.
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.ErrorMatches, "retrieving model logs: boom")
+	s.stub.CheckCallNames(c,
+		"masterClient.Watch",
+		"masterClient.GetMigrationStatus",
+		"guard.Lockdown",
+		"apiOpen",
+		"APICall:MigrationTarget.LatestLogTime",
+		"masterClient.ModelLogs",
+		"Connection.Close",
+	)
+}
+
+func (s *Suite) TestREAPFailure(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.status.Phase = migration.REAP
+	masterClient.reapErr = errors.New("boom")
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(errors.Cause(err), gc.Equals, dependency.ErrUninstall)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.Reap", nil},
+		{"masterClient.SetPhase", []interface{}{migration.REAPFAILED}},
+	})
+}
+
+func (s *Suite) TestMinionReportsWrongPhase(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.minionReportsPhase = migration.VALIDATION
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.ErrorMatches,
+		`minion reports phase \(VALIDATION\) does not match migration phase \(QUIESCE\)`)
+}
+
 func newStubGuard(stub *jujutesting.Stub) *stubGuard {
 	return &stubGuard{stub: stub}
 }
@@ -397,9 +822,10 @@ func newStubMasterClient(stub *jujutesting.Stub) *stubMasterClient {
 		stub:           stub,
 		watcherChanges: make(chan struct{}, 1),
 		status: masterapi.MigrationStatus{
-			ModelUUID: "model-uuid",
-			Attempt:   2,
-			Phase:     migration.QUIESCE,
+			MigrationId: "model-uuid:2",
+			ModelUUID:   "model-uuid",
+			Attempt:     2,
+			Phase:       migration.QUIESCE,
 			TargetInfo: migration.TargetInfo{
 				ControllerTag: names.NewModelTag("controller-uuid"),
 				Addrs:         []string{"1.2.3.4:5"},
@@ -413,12 +839,21 @@ func newStubMasterClient(stub *jujutesting.Stub) *stubMasterClient {
 
 type stubMasterClient struct {
 	masterapi.Client
-	stub           *jujutesting.Stub
-	watcherChanges chan struct{}
-	watchErr       error
-	status         masterapi.MigrationStatus
-	statusErr      error
-	exportErr      error
+	stub              *jujutesting.Stub
+	watcherChanges    chan struct{}
+	watchErr          error
+	status            masterapi.MigrationStatus
+	statusErr         error
+	exportErr         error
+	prechecksProblems []string
+	phase             migration.Phase
+	logBatches        [][]migration.LogRecord
+	modelLogsErr      error
+	reapErr           error
+
+	minionReportsPhase   migration.Phase
+	minionReportsFailed  []names.Tag
+	minionReportsUnknown int
 }
 
 func (c *stubMasterClient) Watch() (watcher.NotifyWatcher, error) {
@@ -435,22 +870,79 @@ func (c *stubMasterClient) GetMigrationStatus() (masterapi.MigrationStatus, erro
 	if c.statusErr != nil {
 		return masterapi.MigrationStatus{}, c.statusErr
 	}
+	c.phase = c.status.Phase
 	return c.status, nil
 }
 
-func (c *stubMasterClient) Export() ([]byte, error) {
+func (c *stubMasterClient) Export() (migration.SerializedModel, error) {
 	c.stub.AddCall("masterClient.Export")
 	if c.exportErr != nil {
-		return nil, c.exportErr
+		return migration.SerializedModel{}, c.exportErr
 	}
 	return fakeSerializedModel, nil
 }
 
+func (c *stubMasterClient) Prechecks() ([]string, error) {
+	c.stub.AddCall("masterClient.Prechecks")
+	return c.prechecksProblems, nil
+}
+
+func (c *stubMasterClient) ModelInfo() (migration.ModelInfo, error) {
+	c.stub.AddCall("masterClient.ModelInfo")
+	return migration.ModelInfo{
+		UUID:  "model-uuid",
+		Name:  "model",
+		Owner: names.NewUserTag("owner"),
+	}, nil
+}
+
 func (c *stubMasterClient) SetPhase(phase migration.Phase) error {
 	c.stub.AddCall("masterClient.SetPhase", phase)
+	c.phase = phase
 	return nil
 }
 
+func (c *stubMasterClient) WatchMinionReports() (watcher.NotifyWatcher, error) {
+	c.stub.AddCall("masterClient.WatchMinionReports")
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	return newMockWatcher(changes), nil
+}
+
+func (c *stubMasterClient) MinionReports() (migration.MinionReports, error) {
+	c.stub.AddCall("masterClient.MinionReports")
+	phase := c.phase
+	if c.minionReportsPhase != migration.UNKNOWN {
+		phase = c.minionReportsPhase
+	}
+	reports := migration.MinionReports{
+		MigrationId:  c.status.MigrationId,
+		Phase:        phase,
+		SuccessCount: 2,
+		UnknownCount: c.minionReportsUnknown,
+		Failed:       c.minionReportsFailed,
+	}
+	return reports, nil
+}
+
+func (c *stubMasterClient) ModelLogs(after time.Time, limit int) ([]migration.LogRecord, error) {
+	c.stub.AddCall("masterClient.ModelLogs", after, limit)
+	if c.modelLogsErr != nil {
+		return nil, c.modelLogsErr
+	}
+	if len(c.logBatches) == 0 {
+		return nil, nil
+	}
+	records := c.logBatches[0]
+	c.logBatches = c.logBatches[1:]
+	return records, nil
+}
+
+func (c *stubMasterClient) Reap() error {
+	c.stub.AddCall("masterClient.Reap")
+	return c.reapErr
+}
+
 func newMockWatcher(changes chan struct{}) *mockWatcher {
 	return &mockWatcher{
 		Worker:  workertest.NewErrorWorker(nil),
@@ -469,22 +961,32 @@ func (w *mockWatcher) Changes() watcher.NotifyChannel {
 
 type stubConnection struct {
 	api.Connection
-	stub      *jujutesting.Stub
-	importErr error
+	stub              *jujutesting.Stub
+	importErr         error
+	prechecksProblems []string
+	latestLogTime     time.Time
 }
 
 func (c *stubConnection) BestFacadeVersion(string) int {
 	return 1
 }
 
-func (c *stubConnection) APICall(objType string, version int, id, request string, params, response interface{}) error {
-	c.stub.AddCall("APICall:"+objType+"."+request, params)
+func (c *stubConnection) APICall(objType string, version int, id, request string, args, response interface{}) error {
+	c.stub.AddCall("APICall:"+objType+"."+request, args)
 
 	if objType == "MigrationTarget" {
 		switch request {
+		case "Prechecks":
+			out := response.(*params.MigrationPrecheckResult)
+			out.Problems = c.prechecksProblems
+			return nil
 		case "Import":
 			return c.importErr
-		case "Activate":
+		case "Activate", "AddLogs":
+			return nil
+		case "LatestLogTime":
+			out := response.(*params.LatestLogTimeResult)
+			out.Time = c.latestLogTime
 			return nil
 		}
 	}
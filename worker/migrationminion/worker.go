@@ -8,6 +8,7 @@ import (
 	"github.com/juju/loggo"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/watcher"
@@ -25,13 +26,18 @@ type Facade interface {
 	// for the migration for the model associated with the API
 	// connection.
 	Watch() (watcher.MigrationStatusWatcher, error)
+
+	// Report allows a migration minion to report if it successfully
+	// completed its activities for a given migration phase.
+	Report(migrationId string, phase migration.Phase, success bool) error
 }
 
 // Config defines the operation of a Worker.
 type Config struct {
-	Agent  agent.Agent
-	Facade Facade
-	Guard  fortress.Guard
+	Agent   agent.Agent
+	Facade  Facade
+	Guard   fortress.Guard
+	APIOpen api.OpenFunc
 }
 
 // Validate returns an error if config cannot drive a Worker.
@@ -45,6 +51,9 @@ func (config Config) Validate() error {
 	if config.Guard == nil {
 		return errors.NotValidf("nil Guard")
 	}
+	if config.APIOpen == nil {
+		return errors.NotValidf("nil APIOpen")
+	}
 	return nil
 }
 
@@ -123,17 +132,13 @@ func (w *Worker) handle(status watcher.MigrationStatus) error {
 	case migration.QUIESCE:
 		// TODO(mjs) - once Will's stable mode work comes
 		// together this worker will only start up when a
-		// migration is active. Here the minion should report
-		// to the controller that it is running so that the
-		// migration can progress to READONLY.
+		// migration is active. For now, reporting back is
+		// enough to let the migration progress to READONLY.
+		err = w.report(status, true)
 	case migration.VALIDATION:
-		// TODO(mjs) - check connection to the target
-		// controller here and report success/failure.
+		err = w.doVALIDATION(status)
 	case migration.SUCCESS:
-		err := w.doSUCCESS(status.TargetAPIAddrs, status.TargetCACert)
-		if err != nil {
-			return errors.Trace(err)
-		}
+		err = w.doSUCCESS(status)
 	case migration.ABORT:
 		// TODO(mjs) - exit here once Will's stable mode work
 		// comes together. The minion is done if these phases
@@ -142,22 +147,66 @@ func (w *Worker) handle(status watcher.MigrationStatus) error {
 		// The minion doesn't need to do anything for other
 		// migration phases.
 	}
+	return errors.Trace(err)
+}
+
+func (w *Worker) doVALIDATION(status watcher.MigrationStatus) error {
+	err := w.validate(status)
+	if err != nil {
+		// Don't return this error just log it and report to the
+		// migrationmaster that things didn't work out.
+		logger.Errorf("validation failed: %v", err)
+	}
+	return w.report(status, err == nil)
+}
+
+// validate checks that the agent can log in to the target controller
+// using the credentials it already has.
+func (w *Worker) validate(status watcher.MigrationStatus) error {
+	info, ok := w.config.Agent.CurrentConfig().APIInfo()
+	if !ok {
+		return errors.New("no API connection details")
+	}
+	info.Addrs = status.TargetAPIAddrs
+	info.CACert = status.TargetCACert
+	// Use zero DialOpts (no retries) because the worker must stay
+	// responsive to Kill requests. We don't want it to be blocked by
+	// a long set of retry attempts.
+	conn, err := w.config.APIOpen(info, api.DialOpts{})
+	if err != nil {
+		return errors.Annotate(err, "failed to open API to target controller")
+	}
+	defer conn.Close()
 	return nil
 }
 
-func (w *Worker) doSUCCESS(targetAddrs []string, caCert string) error {
-	hps, err := apiAddrsToHostPorts(targetAddrs)
+func (w *Worker) doSUCCESS(status watcher.MigrationStatus) error {
+	hps, err := apiAddrsToHostPorts(status.TargetAPIAddrs)
 	if err != nil {
 		return errors.Annotate(err, "converting API addresses")
 	}
+
+	// Report first because the config update that's about to happen
+	// will cause the API connection to drop. The SUCCESS phase is the
+	// point of no return anyway.
+	if err := w.report(status, true); err != nil {
+		return errors.Trace(err)
+	}
+
 	err = w.config.Agent.ChangeConfig(func(conf agent.ConfigSetter) error {
 		conf.SetAPIHostPorts(hps)
-		conf.SetCACert(caCert)
+		conf.SetCACert(status.TargetCACert)
 		return nil
 	})
 	return errors.Annotate(err, "setting agent config")
 }
 
+func (w *Worker) report(status watcher.MigrationStatus, success bool) error {
+	logger.Debugf("reporting back for phase %s: %v", status.Phase, success)
+	err := w.config.Facade.Report(status.MigrationId, status.Phase, success)
+	return errors.Annotate(err, "failed to report phase progress")
+}
+
 func apiAddrsToHostPorts(addrs []string) ([][]network.HostPort, error) {
 	hps, err := network.ParseHostPorts(addrs...)
 	if err != nil {
@@ -11,8 +11,10 @@ import (
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/network"
 	coretesting "github.com/juju/juju/testing"
@@ -29,6 +31,8 @@ type Suite struct {
 	client *stubMinionClient
 	guard  *stubGuard
 	agent  *stubAgent
+
+	apiOpenErr error
 }
 
 var _ = gc.Suite(&Suite{})
@@ -39,13 +43,23 @@ func (s *Suite) SetUpTest(c *gc.C) {
 	s.client = newStubMinionClient(s.stub)
 	s.guard = newStubGuard(s.stub)
 	s.agent = newStubAgent()
+	s.apiOpenErr = nil
+}
+
+func (s *Suite) apiOpen(info *api.Info, dialOpts api.DialOpts) (api.Connection, error) {
+	s.stub.AddCall("API open", info, dialOpts)
+	if s.apiOpenErr != nil {
+		return nil, s.apiOpenErr
+	}
+	return &stubConnection{stub: s.stub}, nil
 }
 
 func (s *Suite) TestStartAndStop(c *gc.C) {
 	w, err := migrationminion.New(migrationminion.Config{
-		Facade: s.client,
-		Guard:  s.guard,
-		Agent:  s.agent,
+		Facade:  s.client,
+		Guard:   s.guard,
+		Agent:   s.agent,
+		APIOpen: s.apiOpen,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	workertest.CleanKill(c, w)
@@ -55,9 +69,10 @@ func (s *Suite) TestStartAndStop(c *gc.C) {
 func (s *Suite) TestWatchFailure(c *gc.C) {
 	s.client.watchErr = errors.New("boom")
 	w, err := migrationminion.New(migrationminion.Config{
-		Facade: s.client,
-		Guard:  s.guard,
-		Agent:  s.agent,
+		Facade:  s.client,
+		Guard:   s.guard,
+		Agent:   s.agent,
+		APIOpen: s.apiOpen,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	err = workertest.CheckKilled(c, w)
@@ -67,9 +82,10 @@ func (s *Suite) TestWatchFailure(c *gc.C) {
 func (s *Suite) TestClosedWatcherChannel(c *gc.C) {
 	close(s.client.watcher.changes)
 	w, err := migrationminion.New(migrationminion.Config{
-		Facade: s.client,
-		Guard:  s.guard,
-		Agent:  s.agent,
+		Facade:  s.client,
+		Guard:   s.guard,
+		Agent:   s.agent,
+		APIOpen: s.apiOpen,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	err = workertest.CheckKilled(c, w)
@@ -82,9 +98,10 @@ func (s *Suite) TestUnlockError(c *gc.C) {
 	}
 	s.guard.unlockErr = errors.New("squish")
 	w, err := migrationminion.New(migrationminion.Config{
-		Facade: s.client,
-		Guard:  s.guard,
-		Agent:  s.agent,
+		Facade:  s.client,
+		Guard:   s.guard,
+		Agent:   s.agent,
+		APIOpen: s.apiOpen,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -99,9 +116,10 @@ func (s *Suite) TestLockdownError(c *gc.C) {
 	}
 	s.guard.lockdownErr = errors.New("squash")
 	w, err := migrationminion.New(migrationminion.Config{
-		Facade: s.client,
-		Guard:  s.guard,
-		Agent:  s.agent,
+		Facade:  s.client,
+		Guard:   s.guard,
+		Agent:   s.agent,
+		APIOpen: s.apiOpen,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -115,9 +133,10 @@ func (s *Suite) TestNONE(c *gc.C) {
 		Phase: migration.NONE,
 	}
 	w, err := migrationminion.New(migrationminion.Config{
-		Facade: s.client,
-		Guard:  s.guard,
-		Agent:  s.agent,
+		Facade:  s.client,
+		Guard:   s.guard,
+		Agent:   s.agent,
+		APIOpen: s.apiOpen,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -129,14 +148,16 @@ func (s *Suite) TestNONE(c *gc.C) {
 func (s *Suite) TestSUCCESS(c *gc.C) {
 	addrs := []string{"1.1.1.1:1", "9.9.9.9:9"}
 	s.client.watcher.changes <- watcher.MigrationStatus{
+		MigrationId:    "id",
 		Phase:          migration.SUCCESS,
 		TargetAPIAddrs: addrs,
 		TargetCACert:   "top secret",
 	}
 	w, err := migrationminion.New(migrationminion.Config{
-		Facade: s.client,
-		Guard:  s.guard,
-		Agent:  s.agent,
+		Facade:  s.client,
+		Guard:   s.guard,
+		Agent:   s.agent,
+		APIOpen: s.apiOpen,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -148,7 +169,95 @@ func (s *Suite) TestSUCCESS(c *gc.C) {
 	workertest.CleanKill(c, w)
 	c.Assert(s.agent.conf.addrs, gc.DeepEquals, addrs)
 	c.Assert(s.agent.conf.caCert, gc.DeepEquals, "top secret")
-	s.stub.CheckCallNames(c, "Watch", "Lockdown")
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Watch", nil},
+		{"Lockdown", nil},
+		{"Report", []interface{}{"id", migration.SUCCESS, true}},
+	})
+}
+
+func (s *Suite) TestQUIESCE(c *gc.C) {
+	s.client.watcher.changes <- watcher.MigrationStatus{
+		MigrationId: "id",
+		Phase:       migration.QUIESCE,
+	}
+	w := s.mustStartWorker(c)
+	s.waitForReport(c)
+	workertest.CleanKill(c, w)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Watch", nil},
+		{"Lockdown", nil},
+		{"Report", []interface{}{"id", migration.QUIESCE, true}},
+	})
+}
+
+func (s *Suite) TestVALIDATION(c *gc.C) {
+	s.client.watcher.changes <- watcher.MigrationStatus{
+		MigrationId:    "id",
+		Phase:          migration.VALIDATION,
+		TargetAPIAddrs: []string{"1.1.1.1:1", "9.9.9.9:9"},
+		TargetCACert:   "trust me",
+	}
+	w := s.mustStartWorker(c)
+	s.waitForReport(c)
+	workertest.CleanKill(c, w)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Watch", nil},
+		{"Lockdown", nil},
+		{"API open", []interface{}{&api.Info{
+			Addrs:    []string{"1.1.1.1:1", "9.9.9.9:9"},
+			CACert:   "trust me",
+			Tag:      names.NewMachineTag("42"),
+			Password: "sekret",
+		}, api.DialOpts{}}},
+		{"API close", nil},
+		{"Report", []interface{}{"id", migration.VALIDATION, true}},
+	})
+}
+
+func (s *Suite) TestVALIDATIONCantConnect(c *gc.C) {
+	s.client.watcher.changes <- watcher.MigrationStatus{
+		MigrationId:    "id",
+		Phase:          migration.VALIDATION,
+		TargetAPIAddrs: []string{"1.1.1.1:1"},
+		TargetCACert:   "trust me",
+	}
+	s.apiOpenErr = errors.New("boom")
+	w := s.mustStartWorker(c)
+	s.waitForReport(c)
+	workertest.CleanKill(c, w)
+	s.stub.CheckCallNames(c, "Watch", "Lockdown", "API open", "Report")
+	s.stub.CheckCall(c, 3, "Report", "id", migration.VALIDATION, false)
+}
+
+func (s *Suite) TestReportFailure(c *gc.C) {
+	s.client.watcher.changes <- watcher.MigrationStatus{
+		MigrationId: "id",
+		Phase:       migration.QUIESCE,
+	}
+	s.client.reportErr = errors.New("boom")
+	w := s.mustStartWorker(c)
+	err := workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "failed to report phase progress: boom")
+}
+
+func (s *Suite) mustStartWorker(c *gc.C) worker.Worker {
+	w, err := migrationminion.New(migrationminion.Config{
+		Facade:  s.client,
+		Guard:   s.guard,
+		Agent:   s.agent,
+		APIOpen: s.apiOpen,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *Suite) waitForReport(c *gc.C) {
+	select {
+	case <-s.client.reported:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for report")
+	}
 }
 
 func newStubGuard(stub *jujutesting.Stub) *stubGuard {
@@ -173,15 +282,18 @@ func (g *stubGuard) Unlock() error {
 
 func newStubMinionClient(stub *jujutesting.Stub) *stubMinionClient {
 	return &stubMinionClient{
-		stub:    stub,
-		watcher: newStubWatcher(),
+		stub:     stub,
+		watcher:  newStubWatcher(),
+		reported: make(chan bool, 1),
 	}
 }
 
 type stubMinionClient struct {
-	stub     *jujutesting.Stub
-	watcher  *stubWatcher
-	watchErr error
+	stub      *jujutesting.Stub
+	watcher   *stubWatcher
+	watchErr  error
+	reportErr error
+	reported  chan bool
 }
 
 func (c *stubMinionClient) Watch() (watcher.MigrationStatusWatcher, error) {
@@ -192,6 +304,15 @@ func (c *stubMinionClient) Watch() (watcher.MigrationStatusWatcher, error) {
 	return c.watcher, nil
 }
 
+func (c *stubMinionClient) Report(id string, phase migration.Phase, success bool) error {
+	c.stub.MethodCall(c, "Report", id, phase, success)
+	select {
+	case c.reported <- success:
+	default:
+	}
+	return c.reportErr
+}
+
 func newStubWatcher() *stubWatcher {
 	return &stubWatcher{
 		Worker:  workertest.NewErrorWorker(nil),
@@ -229,6 +350,16 @@ func (ma *stubAgent) ChangeConfig(f agent.ConfigMutator) error {
 	return f(&ma.conf)
 }
 
+type stubConnection struct {
+	api.Connection
+	stub *jujutesting.Stub
+}
+
+func (c *stubConnection) Close() error {
+	c.stub.AddCall("API close")
+	return nil
+}
+
 type stubConfig struct {
 	agent.ConfigSetter
 
@@ -237,6 +368,15 @@ type stubConfig struct {
 	caCert string
 }
 
+func (mc *stubConfig) APIInfo() (*api.Info, bool) {
+	return &api.Info{
+		Addrs:    []string{"1.2.3.4:5"},
+		CACert:   "source cert",
+		Tag:      names.NewMachineTag("42"),
+		Password: "sekret",
+	}, true
+}
+
 func (mc *stubConfig) setAddresses(addrs ...string) {
 	mc.mu.Lock()
 	defer mc.mu.Unlock()
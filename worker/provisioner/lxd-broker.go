@@ -132,10 +132,18 @@ func (broker *lxdBroker) AllInstances() (result []instance.Instance, err error)
 
 // MaintainInstance ensures the container's host has the required iptables and
 // routing rules to make the container visible to both the host and other
-// machines on the same subnet.
+// machines on the same subnet, and that the container has the LXD profiles
+// declared by the charms deployed to it applied.
 func (broker *lxdBroker) MaintainInstance(args environs.StartInstanceParams) error {
 	machineID := args.InstanceConfig.MachineId
 
+	if profileManager, ok := broker.manager.(container.LXDProfileManager); ok {
+		err := profileManager.SetCharmLXDProfiles(machineID, args.InstanceConfig.CharmLXDProfiles)
+		if err != nil {
+			return errors.Annotate(err, "cannot update charm LXD profiles")
+		}
+	}
+
 	// Default to using the host network until we can configure.
 	bridgeDevice := broker.agentConfig.Value(agent.LxdBridge)
 	if bridgeDevice == "" {
@@ -6,6 +6,7 @@ package provisioner_test
 import (
 	"runtime"
 
+	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
@@ -17,6 +18,7 @@ import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
@@ -123,6 +125,29 @@ func (s *lxdBrokerSuite) TestStartInstanceNoHostArchTools(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `need tools for arch amd64, only found \[arm64\]`)
 }
 
+func (s *lxdBrokerSuite) TestMaintainInstanceSetsCharmProfiles(c *gc.C) {
+	instanceConfig := s.instanceConfig(c, "1/lxd/0")
+	instanceConfig.CharmLXDProfiles = map[string]lxdprofile.Profile{
+		"juju-charm-06f00d-app-1": {Config: map[string]string{"security.nesting": "true"}},
+	}
+	err := s.broker.MaintainInstance(environs.StartInstanceParams{
+		InstanceConfig: instanceConfig,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.manager.CheckCalls(c, []gitjujutesting.StubCall{{
+		FuncName: "SetCharmLXDProfiles",
+		Args:     []interface{}{"1/lxd/0", instanceConfig.CharmLXDProfiles},
+	}})
+}
+
+func (s *lxdBrokerSuite) TestMaintainInstanceCharmProfilesError(c *gc.C) {
+	s.manager.SetErrors(errors.New("boom"))
+	err := s.broker.MaintainInstance(environs.StartInstanceParams{
+		InstanceConfig: s.instanceConfig(c, "1/lxd/0"),
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot update charm LXD profiles: boom")
+}
+
 type fakeContainerManager struct {
 	gitjujutesting.Stub
 }
@@ -158,3 +183,8 @@ func (m *fakeContainerManager) IsInitialized() bool {
 	m.PopNoErr()
 	return true
 }
+
+func (m *fakeContainerManager) SetCharmLXDProfiles(machineId string, profiles map[string]lxdprofile.Profile) error {
+	m.MethodCall(m, "SetCharmLXDProfiles", machineId, profiles)
+	return m.NextErr()
+}
@@ -40,6 +40,7 @@ type Provisioner interface {
 	worker.Worker
 	getMachineWatcher() (watcher.StringsWatcher, error)
 	getRetryWatcher() (watcher.NotifyWatcher, error)
+	getProfileWatcher() (watcher.StringsWatcher, error)
 }
 
 // environProvisioner represents a running provisioning worker for machine nodes
@@ -133,6 +134,10 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 	if err != nil && !errors.IsNotImplemented(err) {
 		return nil, err
 	}
+	profileWatcher, err := p.getProfileWatcher()
+	if err != nil && !errors.IsNotImplemented(err) {
+		return nil, err
+	}
 	tag := p.agentConfig.Tag()
 	machineTag, ok := tag.(names.MachineTag)
 	if !ok {
@@ -161,6 +166,7 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 		p.toolsFinder,
 		machineWatcher,
 		retryWatcher,
+		profileWatcher,
 		p.broker,
 		auth,
 		modelCfg.ImageStream(),
@@ -256,6 +262,16 @@ func (p *environProvisioner) getRetryWatcher() (watcher.NotifyWatcher, error) {
 	return p.st.WatchMachineErrorRetry()
 }
 
+// getProfileWatcher returns a watcher that notifies of machines whose
+// LXD profiles, as declared by their units' charms, may need updating.
+// Only the LXD provider applies such profiles to its instances.
+func (p *environProvisioner) getProfileWatcher() (watcher.StringsWatcher, error) {
+	if p.environ.Config().Type() != "lxd" {
+		return nil, errors.NotImplementedf("getProfileWatcher")
+	}
+	return p.st.WatchMachineCharmProfiles()
+}
+
 // setConfig updates the environment configuration and notifies
 // the config observer.
 func (p *environProvisioner) setConfig(modelConfig *config.Config) error {
@@ -368,3 +384,14 @@ func (p *containerProvisioner) getMachineWatcher() (watcher.StringsWatcher, erro
 func (p *containerProvisioner) getRetryWatcher() (watcher.NotifyWatcher, error) {
 	return nil, errors.NotImplementedf("getRetryWatcher")
 }
+
+// getProfileWatcher returns a watcher that notifies of machines whose
+// LXD profiles, as declared by their units' charms, may need updating,
+// so that LXD containers are kept in step with upgrade-charm and with
+// units being placed on them.
+func (p *containerProvisioner) getProfileWatcher() (watcher.StringsWatcher, error) {
+	if p.containerType != instance.LXD {
+		return nil, errors.NotImplementedf("getProfileWatcher")
+	}
+	return p.st.WatchMachineCharmProfiles()
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/controller/authentication"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
@@ -69,6 +70,7 @@ func NewProvisionerTask(
 	toolsFinder ToolsFinder,
 	machineWatcher watcher.StringsWatcher,
 	retryWatcher watcher.NotifyWatcher,
+	profileWatcher watcher.StringsWatcher,
 	broker environs.InstanceBroker,
 	auth authentication.AuthenticationProvider,
 	imageStream string,
@@ -82,6 +84,11 @@ func NewProvisionerTask(
 		retryChanges = retryWatcher.Changes()
 		workers = append(workers, retryWatcher)
 	}
+	var profileChanges watcher.StringsChannel
+	if profileWatcher != nil {
+		profileChanges = profileWatcher.Changes()
+		workers = append(workers, profileWatcher)
+	}
 	task := &provisionerTask{
 		controllerUUID:             controllerUUID,
 		machineTag:                 machineTag,
@@ -89,6 +96,7 @@ func NewProvisionerTask(
 		toolsFinder:                toolsFinder,
 		machineChanges:             machineChanges,
 		retryChanges:               retryChanges,
+		profileChanges:             profileChanges,
 		broker:                     broker,
 		auth:                       auth,
 		harvestMode:                harvestMode,
@@ -116,6 +124,7 @@ type provisionerTask struct {
 	toolsFinder                ToolsFinder
 	machineChanges             watcher.StringsChannel
 	retryChanges               watcher.NotifyChannel
+	profileChanges             watcher.StringsChannel
 	broker                     environs.InstanceBroker
 	catacomb                   catacomb.Catacomb
 	auth                       authentication.AuthenticationProvider
@@ -148,6 +157,10 @@ func (task *provisionerTask) loop() error {
 	// as unknown.
 	var harvestModeChan chan config.HarvestMode
 
+	// Likewise, don't process charm profile changes until the machines
+	// they refer to are known.
+	var profileChanges watcher.StringsChannel
+
 	// When the watcher is started, it will have the initial changes be all
 	// the machines that are relevant. Also, since this is available straight
 	// away, we know there will be some changes right off the bat.
@@ -167,6 +180,7 @@ func (task *provisionerTask) loop() error {
 			// We've seen a set of changes. Enable modification of
 			// harvesting mode.
 			harvestModeChan = task.harvestModeChan
+			profileChanges = task.profileChanges
 		case harvestMode := <-harvestModeChan:
 			if harvestMode == task.harvestMode {
 				break
@@ -183,6 +197,13 @@ func (task *provisionerTask) loop() error {
 			if err := task.processMachinesWithTransientErrors(); err != nil {
 				return errors.Annotate(err, "failed to process machines with transient errors")
 			}
+		case ids, ok := <-profileChanges:
+			if !ok {
+				return errors.New("charm profile watcher closed channel")
+			}
+			if err := task.processProfileChanges(ids); err != nil {
+				return errors.Annotate(err, "failed to process charm profile changes")
+			}
 		}
 	}
 }
@@ -218,6 +239,29 @@ func (task *provisionerTask) processMachinesWithTransientErrors() error {
 	return task.startMachines(pending)
 }
 
+// processProfileChanges maintains those of the given machines that
+// are known to the task and have instances, so that the LXD profiles
+// declared by their units' charms are kept up to date when applications
+// are upgraded or units are assigned to them.
+func (task *provisionerTask) processProfileChanges(ids []string) error {
+	logger.Tracef("processProfileChanges(%v)", ids)
+	var maintain []*apiprovisioner.Machine
+	for _, id := range ids {
+		machine, found := task.machines[id]
+		if !found || machine.Life() != params.Alive {
+			continue
+		}
+		if _, err := machine.InstanceId(); params.IsCodeNotProvisioned(err) {
+			// The profiles will be applied when the instance is started.
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "failed to load machine id:%s, details:%v", machine.Id(), machine)
+		}
+		maintain = append(maintain, machine)
+	}
+	return task.maintainMachines(maintain)
+}
+
 func (task *provisionerTask) processMachines(ids []string) error {
 	logger.Tracef("processMachines(%v)", ids)
 
@@ -513,6 +557,7 @@ func (task *provisionerTask) constructInstanceConfig(
 	}
 
 	instanceConfig.Tags = pInfo.Tags
+	instanceConfig.CharmLXDProfiles = charmLXDProfiles(pInfo.CharmLXDProfiles)
 	if len(pInfo.Jobs) > 0 {
 		instanceConfig.Jobs = pInfo.Jobs
 	}
@@ -531,6 +576,23 @@ func (task *provisionerTask) constructInstanceConfig(
 	return instanceConfig, nil
 }
 
+// charmLXDProfiles converts the charm LXD profiles in a machine's
+// provisioning info into the form expected by the instance config.
+func charmLXDProfiles(in map[string]params.CharmLXDProfile) map[string]lxdprofile.Profile {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]lxdprofile.Profile, len(in))
+	for name, profile := range in {
+		out[name] = lxdprofile.Profile{
+			Description: profile.Description,
+			Config:      profile.Config,
+			Devices:     profile.Devices,
+		}
+	}
+	return out
+}
+
 func constructStartInstanceParams(
 	controllerUUID string,
 	machine *apiprovisioner.Machine,
@@ -622,9 +684,15 @@ func constructStartInstanceParams(
 func (task *provisionerTask) maintainMachines(machines []*apiprovisioner.Machine) error {
 	for _, m := range machines {
 		logger.Infof("maintainMachines: %v", m)
+		pInfo, err := m.ProvisioningInfo()
+		if err != nil {
+			logger.Warningf("cannot fetch provisioning info for machine %v: %v", m, err)
+			continue
+		}
 		startInstanceParams := environs.StartInstanceParams{}
 		startInstanceParams.InstanceConfig = &instancecfg.InstanceConfig{}
 		startInstanceParams.InstanceConfig.MachineId = m.Id()
+		startInstanceParams.InstanceConfig.CharmLXDProfiles = charmLXDProfiles(pInfo.CharmLXDProfiles)
 		if err := task.broker.MaintainInstance(startInstanceParams); err != nil {
 			return errors.Annotatef(err, "cannot maintain machine %v", m)
 		}
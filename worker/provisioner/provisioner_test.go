@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -48,6 +49,7 @@ import (
 	coretesting "github.com/juju/juju/testing"
 	coretools "github.com/juju/juju/tools"
 	jujuversion "github.com/juju/juju/version"
+	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
 	dt "github.com/juju/juju/worker/dependency/testing"
 	"github.com/juju/juju/worker/provisioner"
@@ -1220,6 +1222,17 @@ func (s *ProvisionerSuite) newProvisionerTask(
 	machineGetter provisioner.MachineGetter,
 	toolsFinder provisioner.ToolsFinder,
 ) provisioner.ProvisionerTask {
+	return s.newProvisionerTaskWithProfileWatcher(c, harvestingMethod, broker, machineGetter, toolsFinder, nil)
+}
+
+func (s *ProvisionerSuite) newProvisionerTaskWithProfileWatcher(
+	c *gc.C,
+	harvestingMethod config.HarvestMode,
+	broker environs.InstanceBroker,
+	machineGetter provisioner.MachineGetter,
+	toolsFinder provisioner.ToolsFinder,
+	profileWatcher watcher.StringsWatcher,
+) provisioner.ProvisionerTask {
 
 	machineWatcher, err := s.provisioner.WatchModelMachines()
 	c.Assert(err, jc.ErrorIsNil)
@@ -1238,6 +1251,7 @@ func (s *ProvisionerSuite) newProvisionerTask(
 		toolsFinder,
 		machineWatcher,
 		retryWatcher,
+		profileWatcher,
 		broker,
 		auth,
 		imagemetadata.ReleasedStream,
@@ -1396,6 +1410,34 @@ func (s *ProvisionerSuite) TestProvisionerRetriesTransientErrors(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
 }
 
+func (s *ProvisionerSuite) TestProvisionerMaintainsCharmProfiles(c *gc.C) {
+	broker := &mockMaintainBroker{Environ: s.Environ, maintained: make(chan string, 10)}
+	profileWatcher := newMockStringsWatcher()
+	task := s.newProvisionerTaskWithProfileWatcher(
+		c, config.HarvestDestroyed, broker, s.provisioner, mockToolsFinder{}, profileWatcher,
+	)
+	defer stop(c, task)
+
+	m0, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstance(c, m0)
+
+	// Only the known, provisioned machines reported by the
+	// watcher are maintained.
+	profileWatcher.changes <- []string{m0.Id(), "42"}
+	select {
+	case id := <-broker.maintained:
+		c.Assert(id, gc.Equals, m0.Id())
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for machine to be maintained")
+	}
+	select {
+	case id := <-broker.maintained:
+		c.Fatalf("unexpected maintenance of machine %q", id)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
 func (s *ProvisionerSuite) TestProvisionerObservesMachineJobs(c *gc.C) {
 	s.PatchValue(&apiserverprovisioner.ErrorRetryWaitDelay, 5*time.Millisecond)
 	broker := &mockBroker{Environ: s.Environ, retryCount: make(map[string]int)}
@@ -1435,6 +1477,42 @@ func (b *mockBroker) StartInstance(args environs.StartInstanceParams) (*environs
 	return nil, fmt.Errorf("error: some error")
 }
 
+type mockMaintainBroker struct {
+	environs.Environ
+	maintained chan string
+}
+
+func (b *mockMaintainBroker) MaintainInstance(args environs.StartInstanceParams) error {
+	b.maintained <- args.InstanceConfig.MachineId
+	return nil
+}
+
+type mockStringsWatcher struct {
+	changes chan []string
+	stopped chan struct{}
+	once    sync.Once
+}
+
+func newMockStringsWatcher() *mockStringsWatcher {
+	return &mockStringsWatcher{
+		changes: make(chan []string),
+		stopped: make(chan struct{}),
+	}
+}
+
+func (w *mockStringsWatcher) Changes() watcher.StringsChannel {
+	return w.changes
+}
+
+func (w *mockStringsWatcher) Kill() {
+	w.once.Do(func() { close(w.stopped) })
+}
+
+func (w *mockStringsWatcher) Wait() error {
+	<-w.stopped
+	return nil
+}
+
 type mockToolsFinder struct {
 }
 
@@ -0,0 +1,44 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/cmd/jujud/agent/engine"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/dependency"
+)
+
+// ManifoldConfig holds the information necessary to run a rolling
+// upgrader in a dependency.Engine.
+type ManifoldConfig struct {
+	APICallerName string
+	Period        time.Duration
+	NewFacade     func(base.APICaller) (Facade, error)
+	NewWorker     func(Config) (worker.Worker, error)
+}
+
+func (config ManifoldConfig) start(apiCaller base.APICaller) (worker.Worker, error) {
+	facade, err := config.NewFacade(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return config.NewWorker(Config{
+		Facade:   facade,
+		Period:   config.Period,
+		NewTimer: worker.NewTimer,
+	})
+}
+
+// Manifold returns a dependency.Manifold that runs a rolling upgrader.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return engine.ApiManifold(
+		engine.ApiManifoldConfig{config.APICallerName},
+		config.start,
+	)
+}
@@ -0,0 +1,67 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/worker"
+)
+
+// Facade defines the capabilities required by the worker.
+type Facade interface {
+
+	// RollingUpgrades returns the names of the applications with
+	// a rolling charm upgrade in progress.
+	RollingUpgrades() ([]string, error)
+
+	// Advance moves along the rolling charm upgrades of the named
+	// applications.
+	Advance(applications []string) error
+}
+
+// Config defines a worker's dependencies.
+type Config struct {
+	Facade Facade
+	Period time.Duration
+	// TODO(fwereade): 2016-03-17 lp:1558657
+	NewTimer worker.NewTimerFunc
+}
+
+// Validate returns an error if the config can't be expected
+// to run a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	if config.NewTimer == nil {
+		return errors.NotValidf("nil NewTimer")
+	}
+	return nil
+}
+
+// New returns a worker that periodically moves along any rolling
+// charm upgrades in the model, so that they progress without the
+// client that started them.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	advance := func(stop <-chan struct{}) error {
+		applications, err := config.Facade.RollingUpgrades()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(applications) == 0 {
+			return nil
+		}
+		return errors.Trace(config.Facade.Advance(applications))
+	}
+	return worker.NewPeriodicWorker(advance, config.Period, config.NewTimer), nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/rollingupgrader"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) TestValidateNilFacade(c *gc.C) {
+	config := validConfig(nil)
+	config.Facade = nil
+	s.checkNotValid(c, config, "nil Facade not valid")
+}
+
+func (s *WorkerSuite) TestValidateBadPeriod(c *gc.C) {
+	config := validConfig(&stubFacade{})
+	config.Period = 0
+	s.checkNotValid(c, config, "non-positive Period not valid")
+}
+
+func (s *WorkerSuite) TestValidateNilNewTimer(c *gc.C) {
+	config := validConfig(&stubFacade{})
+	config.NewTimer = nil
+	s.checkNotValid(c, config, "nil NewTimer not valid")
+}
+
+func (s *WorkerSuite) checkNotValid(c *gc.C, config rollingupgrader.Config, match string) {
+	w, err := rollingupgrader.New(config)
+	c.Check(w, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, match)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *WorkerSuite) TestAdvancesRollingUpgrades(c *gc.C) {
+	facade := &stubFacade{
+		applications: []string{"foo", "bar"},
+		advanced:     make(chan []string, 1),
+	}
+	w, err := rollingupgrader.New(validConfig(facade))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case applications := <-facade.advanced:
+		c.Check(applications, jc.DeepEquals, []string{"foo", "bar"})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for Advance")
+	}
+	facade.stub.CheckCallNames(c, "RollingUpgrades", "Advance")
+}
+
+func (s *WorkerSuite) TestRollingUpgradesError(c *gc.C) {
+	facade := &stubFacade{}
+	facade.stub.SetErrors(errors.New("splat"))
+	w, err := rollingupgrader.New(validConfig(facade))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "splat")
+	facade.stub.CheckCallNames(c, "RollingUpgrades")
+}
+
+func (s *WorkerSuite) TestAdvanceError(c *gc.C) {
+	facade := &stubFacade{
+		applications: []string{"foo"},
+		advanced:     make(chan []string, 1),
+	}
+	facade.stub.SetErrors(nil, errors.New("splat"))
+	w, err := rollingupgrader.New(validConfig(facade))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "splat")
+	facade.stub.CheckCallNames(c, "RollingUpgrades", "Advance")
+}
+
+func validConfig(facade rollingupgrader.Facade) rollingupgrader.Config {
+	return rollingupgrader.Config{
+		Facade:   facade,
+		Period:   coretesting.LongWait,
+		NewTimer: worker.NewTimer,
+	}
+}
+
+type stubFacade struct {
+	stub         testing.Stub
+	applications []string
+	advanced     chan []string
+}
+
+func (facade *stubFacade) RollingUpgrades() ([]string, error) {
+	facade.stub.AddCall("RollingUpgrades")
+	if err := facade.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return facade.applications, nil
+}
+
+func (facade *stubFacade) Advance(applications []string) error {
+	facade.stub.AddCall("Advance", applications)
+	facade.advanced <- applications
+	return facade.stub.NextErr()
+}
@@ -130,6 +130,27 @@ func (s *ManifestDeployerSuite) TestUpgradeOverwrite(c *gc.C) {
 	)
 }
 
+func (s *ManifestDeployerSuite) TestRollbackToPreviousRevision(c *gc.C) {
+	info := s.deployCharm(c, 1,
+		ft.File{"some-file", "hello", 0644},
+		ft.File{"old-file", "only in revision 1", 0644},
+	)
+	s.deployCharm(c, 2,
+		ft.File{"some-file", "goodbye", 0644},
+		ft.File{"new-file", "only in revision 2", 0644},
+	)
+
+	err := s.deployer.Stage(info, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.deployer.Deploy()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCharm(c, 1,
+		ft.File{"some-file", "hello", 0644},
+		ft.File{"old-file", "only in revision 1", 0644},
+		ft.Removed{"new-file"},
+	)
+}
+
 func (s *ManifestDeployerSuite) TestUpgradePreserveUserFiles(c *gc.C) {
 	//TODO(bogdanteleaga): Fix this on windows
 	if runtime.GOOS == "windows" {
@@ -16,6 +16,13 @@ const (
 	LeaderElected         hooks.Kind = "leader-elected"
 	LeaderDeposed         hooks.Kind = "leader-deposed"
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
+
+	// PreSeriesUpgrade is run on every unit of a machine before the
+	// machine's OS series is upgraded in place.
+	PreSeriesUpgrade hooks.Kind = "pre-series-upgrade"
+	// PostSeriesUpgrade is run on every unit of a machine after the
+	// machine's OS series has been upgraded in place.
+	PostSeriesUpgrade hooks.Kind = "post-series-upgrade"
 )
 
 // Info holds details required to execute a hook. Not all fields are
@@ -60,6 +67,8 @@ func (hi Info) Validate() error {
 	// TODO(fwereade): define these in charm/hooks...
 	case LeaderElected, LeaderDeposed, LeaderSettingsChanged:
 		return nil
+	case PreSeriesUpgrade, PostSeriesUpgrade:
+		return nil
 	}
 	return fmt.Errorf("unknown hook kind %q", hi.Kind)
 }
@@ -191,6 +191,8 @@ type mockUnit struct {
 	configSettingsWatcher *mockNotifyWatcher
 	storageWatcher        *mockStringsWatcher
 	actionWatcher         *mockStringsWatcher
+	upgradeSeriesWatcher  *mockNotifyWatcher
+	upgradeSeriesStatus   params.UpgradeSeriesStatus
 }
 
 func (u *mockUnit) Life() params.Life {
@@ -233,6 +235,14 @@ func (u *mockUnit) WatchActionNotifications() (watcher.StringsWatcher, error) {
 	return u.actionWatcher, nil
 }
 
+func (u *mockUnit) UpgradeSeriesStatus() (params.UpgradeSeriesStatus, error) {
+	return u.upgradeSeriesStatus, nil
+}
+
+func (u *mockUnit) WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error) {
+	return u.upgradeSeriesWatcher, nil
+}
+
 type mockService struct {
 	tag                   names.ApplicationTag
 	life                  params.Life
@@ -68,6 +68,10 @@ type Snapshot struct {
 	// Commands is the list of IDs of commands to be
 	// executed by this unit.
 	Commands []string
+
+	// UpgradeSeriesStatus is the unit's progress through
+	// a series upgrade of its machine.
+	UpgradeSeriesStatus params.UpgradeSeriesStatus
 }
 
 type RelationSnapshot struct {
@@ -32,6 +32,8 @@ type Unit interface {
 	WatchConfigSettings() (watcher.NotifyWatcher, error)
 	WatchStorage() (watcher.StringsWatcher, error)
 	WatchActionNotifications() (watcher.StringsWatcher, error)
+	UpgradeSeriesStatus() (params.UpgradeSeriesStatus, error)
+	WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error)
 }
 
 type Application interface {
@@ -256,6 +256,16 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 	}
 	requiredEvents++
 
+	var seenUpgradeSeriesChange bool
+	upgradeSeriesw, err := w.unit.WatchUpgradeSeriesNotifications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(upgradeSeriesw); err != nil {
+		return errors.Trace(err)
+	}
+	requiredEvents++
+
 	var seenLeadershipChange bool
 	// There's no watcher for this per se; we wait on a channel
 	// returned by the leadership tracker.
@@ -364,6 +374,16 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			}
 			observedEvent(&seenActionsChange)
 
+		case _, ok := <-upgradeSeriesw.Changes():
+			logger.Debugf("got upgrade series change: ok=%t", ok)
+			if !ok {
+				return errors.New("upgrade series watcher closed")
+			}
+			if err := w.upgradeSeriesStatusChanged(); err != nil {
+				return errors.Trace(err)
+			}
+			observedEvent(&seenUpgradeSeriesChange)
+
 		case keys, ok := <-relationsw.Changes():
 			logger.Debugf("got relations change: ok=%t", ok)
 			if !ok {
@@ -524,6 +544,19 @@ func (w *RemoteStateWatcher) leaderSettingsChanged() error {
 	return nil
 }
 
+// upgradeSeriesStatusChanged responds to changes in the series
+// upgrade lock of the unit's machine.
+func (w *RemoteStateWatcher) upgradeSeriesStatusChanged() error {
+	status, err := w.unit.UpgradeSeriesStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.mu.Lock()
+	w.current.UpgradeSeriesStatus = status
+	w.mu.Unlock()
+	return nil
+}
+
 func (w *RemoteStateWatcher) leadershipChanged(isLeader bool) error {
 	w.mu.Lock()
 	w.current.Leader = isLeader
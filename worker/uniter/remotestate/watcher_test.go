@@ -52,6 +52,8 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 			configSettingsWatcher: newMockNotifyWatcher(),
 			storageWatcher:        newMockStringsWatcher(),
 			actionWatcher:         newMockStringsWatcher(),
+			upgradeSeriesWatcher:  newMockNotifyWatcher(),
+			upgradeSeriesStatus:   params.UpgradeSeriesNotStarted,
 		},
 		relations:                 make(map[names.RelationTag]*mockRelation),
 		storageAttachment:         make(map[params.StorageAttachmentId]params.StorageAttachment),
@@ -106,6 +108,7 @@ func (s *WatcherSuite) TestInitialSignal(c *gc.C) {
 	s.st.unit.configSettingsWatcher.changes <- struct{}{}
 	s.st.unit.storageWatcher.changes <- []string{}
 	s.st.unit.actionWatcher.changes <- []string{}
+	s.st.unit.upgradeSeriesWatcher.changes <- struct{}{}
 	s.st.unit.service.serviceWatcher.changes <- struct{}{}
 	s.st.unit.service.leaderSettingsWatcher.changes <- struct{}{}
 	s.st.unit.service.relationsWatcher.changes <- []string{}
@@ -119,6 +122,7 @@ func signalAll(st *mockState, l *mockLeadershipTracker) {
 	st.unit.configSettingsWatcher.changes <- struct{}{}
 	st.unit.storageWatcher.changes <- []string{}
 	st.unit.actionWatcher.changes <- []string{}
+	st.unit.upgradeSeriesWatcher.changes <- struct{}{}
 	st.unit.service.serviceWatcher.changes <- struct{}{}
 	st.unit.service.leaderSettingsWatcher.changes <- struct{}{}
 	st.unit.service.relationsWatcher.changes <- []string{}
@@ -141,6 +145,7 @@ func (s *WatcherSuite) TestSnapshot(c *gc.C) {
 		ConfigVersion:         2, // config settings and addresses
 		LeaderSettingsVersion: 1,
 		Leader:                true,
+		UpgradeSeriesStatus:   params.UpgradeSeriesNotStarted,
 	})
 }
 
@@ -186,6 +191,17 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	assertOneChange()
 }
 
+func (s *WatcherSuite) TestUpgradeSeriesStatusChanged(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().UpgradeSeriesStatus, gc.Equals, params.UpgradeSeriesNotStarted)
+
+	s.st.unit.upgradeSeriesStatus = params.UpgradeSeriesPrepareStarted
+	s.st.unit.upgradeSeriesWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().UpgradeSeriesStatus, gc.Equals, params.UpgradeSeriesPrepareStarted)
+}
+
 func (s *WatcherSuite) TestActionsReceived(c *gc.C) {
 	signalAll(s.st, s.leadership)
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
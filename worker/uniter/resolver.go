@@ -27,6 +27,7 @@ type ResolverConfig struct {
 	Relations           resolver.Resolver
 	Storage             resolver.Resolver
 	Commands            resolver.Resolver
+	UpgradeSeries       resolver.Resolver
 }
 
 type uniterResolver struct {
@@ -100,6 +101,11 @@ func (s *uniterResolver) NextOp(
 		return op, err
 	}
 
+	op, err = s.config.UpgradeSeries.NextOp(localState, remoteState, opFactory)
+	if errors.Cause(err) != resolver.ErrNoOperation {
+		return op, err
+	}
+
 	switch localState.Kind {
 	case operation.RunHook:
 		switch localState.Step {
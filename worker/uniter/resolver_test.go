@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/worker/uniter/remotestate"
 	"github.com/juju/juju/worker/uniter/resolver"
 	"github.com/juju/juju/worker/uniter/storage"
+	"github.com/juju/juju/worker/uniter/upgradeseries"
 )
 
 type resolverSuite struct {
@@ -71,6 +72,7 @@ func (s *resolverSuite) SetUpTest(c *gc.C) {
 		Relations:           relation.NewRelationsResolver(&dummyRelations{}),
 		Storage:             storage.NewResolver(attachments),
 		Commands:            nopResolver{},
+		UpgradeSeries:       upgradeseries.NewResolver(),
 	}
 
 	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
@@ -475,6 +475,18 @@ func (ctx *HookContext) ConfigSettings() (charm.Settings, error) {
 	return result, nil
 }
 
+// CloudSpec returns the cloud, region and credential of the model, if
+// the unit's application is trusted to access them.
+func (ctx *HookContext) CloudSpec() (*params.CloudSpec, error) {
+	return ctx.state.CloudSpec()
+}
+
+// GoalState returns the units that are expected to exist for the
+// unit's application and for each application related to it.
+func (ctx *HookContext) GoalState() (*params.GoalState, error) {
+	return ctx.state.GoalState()
+}
+
 // ActionName returns the name of the action.
 func (ctx *HookContext) ActionName() (string, error) {
 	if ctx.actionData == nil {
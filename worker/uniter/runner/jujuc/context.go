@@ -105,6 +105,14 @@ type ContextUnit interface {
 
 	// Config returns the current service configuration of the executing unit.
 	ConfigSettings() (charm.Settings, error)
+
+	// CloudSpec returns the cloud, region and credential of the model,
+	// if the unit's application is trusted to access them.
+	CloudSpec() (*params.CloudSpec, error)
+
+	// GoalState returns the units that are expected to exist for the
+	// unit's application and for each application related to it.
+	GoalState() (*params.GoalState, error)
 }
 
 // ContextStatus is the part of a hook context related to the unit's status.
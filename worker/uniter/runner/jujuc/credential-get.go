@@ -0,0 +1,84 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// credentialGetCommand implements the credential-get command.
+type credentialGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+	out cmd.Output
+}
+
+// NewCredentialGetCommand returns a new credentialGetCommand with the given context.
+func NewCredentialGetCommand(ctx Context) (cmd.Command, error) {
+	return &credentialGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *credentialGetCommand) Info() *cmd.Info {
+	doc := `
+credential-get prints the type, region and endpoints of the model's cloud,
+together with the credential used to manage it. It is only available to
+units of applications that an operator has trusted with "juju trust".
+`
+	return &cmd.Info{
+		Name:    "credential-get",
+		Purpose: "print the model's cloud credential",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *credentialGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+}
+
+// Init is part of the cmd.Command interface.
+func (c *credentialGetCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *credentialGetCommand) Run(ctx *cmd.Context) error {
+	spec, err := c.ctx.CloudSpec()
+	if params.IsCodeUnauthorized(err) {
+		return errors.New(`application is not trusted to access the cloud credential; see "juju help trust"`)
+	} else if err != nil {
+		return errors.Annotate(err, "cannot get cloud credential")
+	}
+	out := cloudSpecOutput{
+		Type:            spec.Type,
+		Region:          spec.Region,
+		Endpoint:        spec.Endpoint,
+		StorageEndpoint: spec.StorageEndpoint,
+	}
+	if spec.Credential != nil {
+		out.Credential = &credentialOutput{
+			AuthType:   spec.Credential.AuthType,
+			Attributes: spec.Credential.Attributes,
+		}
+	}
+	return c.out.Write(ctx, out)
+}
+
+type cloudSpecOutput struct {
+	Type            string            `yaml:"type" json:"type"`
+	Region          string            `yaml:"region,omitempty" json:"region,omitempty"`
+	Endpoint        string            `yaml:"endpoint,omitempty" json:"endpoint,omitempty"`
+	StorageEndpoint string            `yaml:"storage-endpoint,omitempty" json:"storage-endpoint,omitempty"`
+	Credential      *credentialOutput `yaml:"credential,omitempty" json:"credential,omitempty"`
+}
+
+type credentialOutput struct {
+	AuthType   string            `yaml:"auth-type" json:"auth-type"`
+	Attributes map[string]string `yaml:"attrs,omitempty" json:"attrs,omitempty"`
+}
@@ -0,0 +1,71 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type CredentialGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&CredentialGetSuite{})
+
+func (s *CredentialGetSuite) TestInitError(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("credential-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = com.Init([]string{"blah"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["blah"\]`)
+}
+
+func (s *CredentialGetSuite) TestCredentialGet(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.Unit.CloudSpec = &params.CloudSpec{
+		Type:     "ec2",
+		Region:   "us-east-1",
+		Endpoint: "https://ec2.us-east-1.amazonaws.com",
+		Credential: &params.CloudCredential{
+			AuthType: "access-key",
+			Attributes: map[string]string{
+				"access-key": "key",
+				"secret-key": "secret",
+			},
+		},
+	}
+	com, err := jujuc.NewCommand(hctx, cmdString("credential-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, `
+type: ec2
+region: us-east-1
+endpoint: https://ec2.us-east-1.amazonaws.com
+credential:
+  auth-type: access-key
+  attrs:
+    access-key: key
+    secret-key: secret
+`[1:])
+}
+
+func (s *CredentialGetSuite) TestCredentialGetUntrusted(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(&params.Error{Message: "permission denied", Code: params.CodeUnauthorized})
+	com, err := jujuc.NewCommand(hctx, cmdString("credential-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Assert(code, gc.Equals, 1)
+	c.Assert(bufferString(ctx.Stderr), gc.Matches, "error: application is not trusted to access the cloud credential.*\n")
+}
@@ -0,0 +1,90 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// goalStateCommand implements the goal-state command.
+type goalStateCommand struct {
+	cmd.CommandBase
+	ctx Context
+	out cmd.Output
+}
+
+// NewGoalStateCommand returns a new goalStateCommand with the given context.
+func NewGoalStateCommand(ctx Context) (cmd.Command, error) {
+	return &goalStateCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *goalStateCommand) Info() *cmd.Info {
+	doc := `
+goal-state prints the units that are expected to exist for this unit's
+application, and for the applications related to it keyed by relation
+name, together with their current status. Units are listed as soon as
+they have been added to the model, before they join any relation, so a
+charm can tell whether more peers or related units are still to come.
+`
+	return &cmd.Info{
+		Name:    "goal-state",
+		Purpose: "print the units expected to exist for this application and its relations",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *goalStateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+}
+
+// Init is part of the cmd.Command interface.
+func (c *goalStateCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *goalStateCommand) Run(ctx *cmd.Context) error {
+	goalState, err := c.ctx.GoalState()
+	if err != nil {
+		return errors.Annotate(err, "cannot get goal state")
+	}
+	out := goalStateOutput{
+		Units:     formatUnitsGoalState(goalState.Units),
+		Relations: make(map[string]unitsGoalStateOutput),
+	}
+	for name, units := range goalState.Relations {
+		out.Relations[name] = formatUnitsGoalState(units)
+	}
+	return c.out.Write(ctx, out)
+}
+
+func formatUnitsGoalState(units params.UnitsGoalState) unitsGoalStateOutput {
+	out := make(unitsGoalStateOutput)
+	for name, unit := range units {
+		unitOut := goalStateStatusOutput{Status: unit.Status}
+		if unit.Since != nil {
+			unitOut.Since = unit.Since.UTC().Format("2006-01-02 15:04:05Z")
+		}
+		out[name] = unitOut
+	}
+	return out
+}
+
+type goalStateOutput struct {
+	Units     unitsGoalStateOutput            `yaml:"units" json:"units"`
+	Relations map[string]unitsGoalStateOutput `yaml:"relations" json:"relations"`
+}
+
+type unitsGoalStateOutput map[string]goalStateStatusOutput
+
+type goalStateStatusOutput struct {
+	Status string `yaml:"status" json:"status"`
+	Since  string `yaml:"since,omitempty" json:"since,omitempty"`
+}
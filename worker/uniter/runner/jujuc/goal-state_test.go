@@ -0,0 +1,76 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type GoalStateSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&GoalStateSuite{})
+
+func (s *GoalStateSuite) TestInitError(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("goal-state"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = com.Init([]string{"blah"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["blah"\]`)
+}
+
+func (s *GoalStateSuite) TestGoalState(c *gc.C) {
+	since := time.Date(2016, 8, 1, 10, 30, 0, 0, time.UTC)
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.Unit.GoalState = &params.GoalState{
+		Units: params.UnitsGoalState{
+			"u/0": {Status: "active", Since: &since},
+			"u/1": {Status: "waiting"},
+		},
+		Relations: map[string]params.UnitsGoalState{
+			"db": {
+				"mysql/0": {Status: "dying"},
+			},
+		},
+	}
+	com, err := jujuc.NewCommand(hctx, cmdString("goal-state"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, `
+units:
+  u/0:
+    status: active
+    since: 2016-08-01 10:30:00Z
+  u/1:
+    status: waiting
+relations:
+  db:
+    mysql/0:
+      status: dying
+`[1:])
+}
+
+func (s *GoalStateSuite) TestGoalStateError(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(errors.New("boom"))
+	com, err := jujuc.NewCommand(hctx, cmdString("goal-state"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Assert(code, gc.Equals, 1)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "error: cannot get goal state: boom\n")
+}
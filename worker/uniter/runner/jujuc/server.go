@@ -43,24 +43,26 @@ func RegisterCommand(name string, f creator) {
 
 // baseCommands maps Command names to creators.
 var baseCommands = map[string]creator{
-	"close-port" + cmdSuffix:    NewClosePortCommand,
-	"config-get" + cmdSuffix:    NewConfigGetCommand,
-	"juju-log" + cmdSuffix:      NewJujuLogCommand,
-	"open-port" + cmdSuffix:     NewOpenPortCommand,
-	"opened-ports" + cmdSuffix:  NewOpenedPortsCommand,
-	"relation-get" + cmdSuffix:  NewRelationGetCommand,
-	"action-get" + cmdSuffix:    NewActionGetCommand,
-	"action-set" + cmdSuffix:    NewActionSetCommand,
-	"action-fail" + cmdSuffix:   NewActionFailCommand,
-	"relation-ids" + cmdSuffix:  NewRelationIdsCommand,
-	"relation-list" + cmdSuffix: NewRelationListCommand,
-	"relation-set" + cmdSuffix:  NewRelationSetCommand,
-	"unit-get" + cmdSuffix:      NewUnitGetCommand,
-	"add-metric" + cmdSuffix:    NewAddMetricCommand,
-	"juju-reboot" + cmdSuffix:   NewJujuRebootCommand,
-	"status-get" + cmdSuffix:    NewStatusGetCommand,
-	"status-set" + cmdSuffix:    NewStatusSetCommand,
-	"network-get" + cmdSuffix:   NewNetworkGetCommand,
+	"close-port" + cmdSuffix:     NewClosePortCommand,
+	"config-get" + cmdSuffix:     NewConfigGetCommand,
+	"juju-log" + cmdSuffix:       NewJujuLogCommand,
+	"open-port" + cmdSuffix:      NewOpenPortCommand,
+	"opened-ports" + cmdSuffix:   NewOpenedPortsCommand,
+	"relation-get" + cmdSuffix:   NewRelationGetCommand,
+	"action-get" + cmdSuffix:     NewActionGetCommand,
+	"action-set" + cmdSuffix:     NewActionSetCommand,
+	"action-fail" + cmdSuffix:    NewActionFailCommand,
+	"relation-ids" + cmdSuffix:   NewRelationIdsCommand,
+	"relation-list" + cmdSuffix:  NewRelationListCommand,
+	"relation-set" + cmdSuffix:   NewRelationSetCommand,
+	"unit-get" + cmdSuffix:       NewUnitGetCommand,
+	"add-metric" + cmdSuffix:     NewAddMetricCommand,
+	"juju-reboot" + cmdSuffix:    NewJujuRebootCommand,
+	"status-get" + cmdSuffix:     NewStatusGetCommand,
+	"status-set" + cmdSuffix:     NewStatusSetCommand,
+	"network-get" + cmdSuffix:    NewNetworkGetCommand,
+	"credential-get" + cmdSuffix: NewCredentialGetCommand,
+	"goal-state" + cmdSuffix:     NewGoalStateCommand,
 }
 
 var storageCommands = map[string]creator{
@@ -253,6 +253,8 @@ var newCommandTests = []struct {
 }{
 	{"close-port", ""},
 	{"config-get", ""},
+	{"credential-get", ""},
+	{"goal-state", ""},
 	{"juju-log", ""},
 	{"open-port", ""},
 	{"opened-ports", ""},
@@ -39,6 +39,7 @@ import (
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 	"github.com/juju/juju/worker/uniter/storage"
+	"github.com/juju/juju/worker/uniter/upgradeseries"
 	jujuos "github.com/juju/utils/os"
 )
 
@@ -285,6 +286,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			Leadership:          uniterleadership.NewResolver(),
 			Relations:           relation.NewRelationsResolver(u.relations),
 			Storage:             storage.NewResolver(u.storage),
+			UpgradeSeries:       upgradeseries.NewResolver(),
 			Commands: runcommands.NewCommandsResolver(
 				u.commands, watcher.CommandCompleted,
 			),
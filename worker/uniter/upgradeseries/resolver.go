@@ -0,0 +1,52 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries
+
+import (
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+)
+
+var logger = loggo.GetLogger("juju.worker.uniter.upgradeseries")
+
+type upgradeSeriesResolver struct{}
+
+// NewResolver returns a new upgrade-series resolver, which runs the
+// pre-series-upgrade and post-series-upgrade hooks as the unit's
+// machine moves through a series upgrade.
+func NewResolver() resolver.Resolver {
+	return &upgradeSeriesResolver{}
+}
+
+// NextOp is defined on the Resolver interface.
+func (r *upgradeSeriesResolver) NextOp(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	if !localState.Installed || localState.Kind != operation.Continue {
+		return nil, resolver.ErrNoOperation
+	}
+
+	// The remote status only moves on once the hook has been committed,
+	// so the local status guards against running a hook twice while we
+	// wait for the remote state watcher to catch up.
+	logger.Tracef("checking upgrade series status")
+	switch remoteState.UpgradeSeriesStatus {
+	case params.UpgradeSeriesPrepareStarted:
+		if localState.UpgradeSeriesStatus != params.UpgradeSeriesPrepareCompleted {
+			return opFactory.NewRunHook(hook.Info{Kind: hook.PreSeriesUpgrade})
+		}
+	case params.UpgradeSeriesCompleteStarted:
+		if localState.UpgradeSeriesStatus != params.UpgradeSeriesCompleted {
+			return opFactory.NewRunHook(hook.Info{Kind: hook.PostSeriesUpgrade})
+		}
+	}
+	return nil, resolver.ErrNoOperation
+}
@@ -403,7 +403,9 @@ func (s *AllowedTargetVersionSuite) TestAllowedTargetVersionSuite(c *gc.C) {
 		{original: "1.2.3", current: "1.2.3", upgradeRunning: false, target: "1.2.2", allowed: true}, // downgrade between builds
 		{original: "1.2.3", current: "1.2.3", upgradeRunning: false, target: "0.2.3", allowed: false},
 		{original: "0.2.3", current: "1.2.3", upgradeRunning: false, target: "0.2.3", allowed: false},
-		{original: "0.2.3", current: "1.2.3", upgradeRunning: true, target: "0.2.3", allowed: true}, // downgrade during upgrade
+		{original: "0.2.3", current: "1.2.3", upgradeRunning: true, target: "0.2.3", allowed: true},   // downgrade during upgrade
+		{original: "1.2.3", current: "1.2.4", upgradeRunning: false, target: "1.2.3", allowed: true},  // aborted patch-level canary upgrade
+		{original: "1.2.3", current: "1.3.0", upgradeRunning: false, target: "1.2.3", allowed: false}, // minor-level canaries cannot be rolled back
 	}
 	for i, test := range cases {
 		c.Logf("test case %d, %#v", i, test)
@@ -0,0 +1,174 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries
+
+import (
+	"os"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+	"github.com/juju/utils/series"
+	"github.com/juju/utils/shell"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	agenttools "github.com/juju/juju/agent/tools"
+	"github.com/juju/juju/service"
+	"github.com/juju/juju/service/common"
+	"github.com/juju/juju/service/systemd"
+	jujuversion "github.com/juju/juju/version"
+)
+
+// NewAgentPreparer returns a function that prepares the agents on the
+// machine with the given config for an upgrade to a target series. It
+// makes the machine's agent tools available under the target series,
+// and, if the target series uses systemd where the current series does
+// not, writes systemd service files for the agents so that they start
+// when the upgraded machine boots.
+func NewAgentPreparer(agentConfig agent.Config) func(string, []string) error {
+	return func(targetSeries string, unitNames []string) error {
+		hostSeries := series.HostSeries()
+		if err := linkTools(agentConfig.DataDir(), hostSeries, targetSeries); err != nil {
+			return errors.Annotate(err, "cannot make agent tools available")
+		}
+
+		hostInit, err := service.VersionInitSystem(hostSeries)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		targetInit, err := service.VersionInitSystem(targetSeries)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if hostInit == targetInit {
+			return nil
+		}
+		if targetInit != service.InitSystemSystemd {
+			return errors.NotSupportedf("upgrading to init system %q", targetInit)
+		}
+		return errors.Annotate(
+			writeSystemdServices(agentConfig, unitNames),
+			"cannot write agent service files",
+		)
+	}
+}
+
+// NewAgentReverter returns a function that undoes the preparation made
+// by the function returned from NewAgentPreparer, for a series upgrade
+// that was abandoned before the machine was upgraded. It removes the
+// link to the agent tools for the target series and any systemd service
+// files written for the agents.
+func NewAgentReverter(agentConfig agent.Config) func(string, []string) error {
+	return func(targetSeries string, unitNames []string) error {
+		hostSeries := series.HostSeries()
+		if err := unlinkTools(agentConfig.DataDir(), targetSeries); err != nil {
+			return errors.Annotate(err, "cannot remove agent tools link")
+		}
+
+		hostInit, err := service.VersionInitSystem(hostSeries)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		targetInit, err := service.VersionInitSystem(targetSeries)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if hostInit == targetInit || targetInit != service.InitSystemSystemd {
+			return nil
+		}
+		return errors.Annotate(
+			removeSystemdServices(agentConfig, unitNames),
+			"cannot remove agent service files",
+		)
+	}
+}
+
+// linkTools makes the tools for the current series available as the
+// tools for the target series, so that the upgrader finds them on the
+// upgraded machine instead of downloading them again.
+func linkTools(dataDir, hostSeries, targetSeries string) error {
+	current := version.Binary{
+		Number: jujuversion.Current,
+		Arch:   arch.HostArch(),
+		Series: hostSeries,
+	}
+	target := current
+	target.Series = targetSeries
+
+	targetDir := agenttools.SharedToolsDir(dataDir, target)
+	if _, err := os.Lstat(targetDir); err == nil {
+		return nil
+	}
+	return os.Symlink(agenttools.SharedToolsDir(dataDir, current), targetDir)
+}
+
+// unlinkTools removes the link made by linkTools. The tools directory
+// for the target series is left alone if it is not a link, as it was
+// not created by linkTools.
+func unlinkTools(dataDir, targetSeries string) error {
+	target := version.Binary{
+		Number: jujuversion.Current,
+		Arch:   arch.HostArch(),
+		Series: targetSeries,
+	}
+	targetDir := agenttools.SharedToolsDir(dataDir, target)
+	info, err := os.Lstat(targetDir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if info.Mode()&os.ModeSymlink == 0 {
+		return nil
+	}
+	return os.Remove(targetDir)
+}
+
+func writeSystemdServices(agentConfig agent.Config, unitNames []string) error {
+	dataDir := agentConfig.DataDir()
+	for name, conf := range agentServiceConfs(agentConfig, unitNames) {
+		svc, err := systemd.NewService(name, conf, dataDir)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := svc.WriteService(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func removeSystemdServices(agentConfig agent.Config, unitNames []string) error {
+	dataDir := agentConfig.DataDir()
+	for name, conf := range agentServiceConfs(agentConfig, unitNames) {
+		svc, err := systemd.NewService(name, conf, dataDir)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := svc.RemoveService(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// agentServiceConfs returns the service confs, keyed by service name,
+// of the machine agent and the agents of the named units.
+func agentServiceConfs(agentConfig agent.Config, unitNames []string) map[string]common.Conf {
+	dataDir, logDir := agentConfig.DataDir(), agentConfig.LogDir()
+	renderer := &shell.BashRenderer{}
+
+	machineInfo := service.NewMachineAgentInfo(agentConfig.Tag().Id(), dataDir, logDir)
+	confs := map[string]common.Conf{
+		"jujud-" + agentConfig.Tag().String(): service.AgentConf(machineInfo, renderer),
+	}
+	containerType := agentConfig.Value(agent.ContainerType)
+	for _, unitName := range unitNames {
+		info := service.NewUnitAgentInfo(unitName, dataDir, logDir)
+		svcName := "jujud-" + names.NewUnitTag(unitName).String()
+		confs[svcName] = service.ContainerAgentConf(info, renderer, containerType)
+	}
+	return confs
+}
@@ -0,0 +1,65 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries_test
+
+import (
+	"os"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	agenttools "github.com/juju/juju/agent/tools"
+	jujuversion "github.com/juju/juju/version"
+	"github.com/juju/juju/worker/upgradeseries"
+)
+
+type AgentsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&AgentsSuite{})
+
+func toolsDir(dataDir, series string) string {
+	return agenttools.SharedToolsDir(dataDir, version.Binary{
+		Number: jujuversion.Current,
+		Arch:   arch.HostArch(),
+		Series: series,
+	})
+}
+
+func (s *AgentsSuite) TestUnlinkToolsRemovesLink(c *gc.C) {
+	dataDir := c.MkDir()
+	err := os.MkdirAll(toolsDir(dataDir, "trusty"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = upgradeseries.LinkTools(dataDir, "trusty", "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = os.Lstat(toolsDir(dataDir, "xenial"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = upgradeseries.UnlinkTools(dataDir, "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = os.Lstat(toolsDir(dataDir, "xenial"))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+	_, err = os.Stat(toolsDir(dataDir, "trusty"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AgentsSuite) TestUnlinkToolsLeavesDirectory(c *gc.C) {
+	dataDir := c.MkDir()
+	err := os.MkdirAll(toolsDir(dataDir, "xenial"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = upgradeseries.UnlinkTools(dataDir, "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = os.Stat(toolsDir(dataDir, "xenial"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AgentsSuite) TestUnlinkToolsMissing(c *gc.C) {
+	err := upgradeseries.UnlinkTools(c.MkDir(), "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,46 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/series"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/upgradeseries"
+	"github.com/juju/juju/cmd/jujud/agent/engine"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/dependency"
+)
+
+// ManifoldConfig defines the names of the manifolds on which a Manifold will depend.
+type ManifoldConfig engine.AgentApiManifoldConfig
+
+// Manifold returns a dependency manifold that runs an upgrade-series
+// worker, using the resource names defined in the supplied config.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	typedConfig := engine.AgentApiManifoldConfig(config)
+	return engine.AgentApiManifold(typedConfig, newWorker)
+}
+
+// newWorker wraps NewWorker for use in a engine.AgentApiManifold.
+func newWorker(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
+	agentConfig := a.CurrentConfig()
+	tag, ok := agentConfig.Tag().(names.MachineTag)
+	if !ok {
+		return nil, errors.Errorf("expected names.MachineTag, got %T", agentConfig.Tag())
+	}
+	w, err := NewWorker(Config{
+		Facade:        upgradeseries.NewClient(apiCaller, tag),
+		HostSeries:    series.HostSeries,
+		PrepareAgents: NewAgentPreparer(agentConfig),
+		RevertAgents:  NewAgentReverter(agentConfig),
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot start upgrade-series worker")
+	}
+	return w, nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.upgradeseries")
+
+// Facade exposes the controller functionality used by the worker.
+type Facade interface {
+	WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error)
+	MachineStatus() (params.UpgradeSeriesStatus, error)
+	SetMachineStatus(params.UpgradeSeriesStatus) error
+	TargetSeries() (string, error)
+	UnitsStatuses() (map[string]params.UpgradeSeriesStatus, error)
+	FinishUpgradeSeries(hostSeries string) error
+}
+
+// Config holds the dependencies of an upgrade-series worker.
+type Config struct {
+	// Facade is used to observe and record the machine's progress
+	// through a series upgrade.
+	Facade Facade
+
+	// HostSeries returns the series the machine is currently running.
+	HostSeries func() string
+
+	// PrepareAgents configures the agents running on the machine,
+	// including those of the named units, to run on the target series
+	// once the machine has been upgraded.
+	PrepareAgents func(targetSeries string, unitNames []string) error
+
+	// RevertAgents undoes the work of PrepareAgents, for an upgrade
+	// that is completed without the machine having changed series.
+	RevertAgents func(targetSeries string, unitNames []string) error
+}
+
+// Validate returns an error if the config cannot be used to start a
+// worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.HostSeries == nil {
+		return errors.NotValidf("nil HostSeries")
+	}
+	if config.PrepareAgents == nil {
+		return errors.NotValidf("nil PrepareAgents")
+	}
+	if config.RevertAgents == nil {
+		return errors.NotValidf("nil RevertAgents")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that drives the machine side of an
+// in-place series upgrade. Once every unit on the machine has run its
+// pre-series-upgrade hook, it prepares the machine's agents for the
+// new series; once every unit has run its post-series-upgrade hook,
+// it records the machine's new series and releases the upgrade lock.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: &upgradeSeriesHandler{config: config},
+	})
+	return w, errors.Trace(err)
+}
+
+type upgradeSeriesHandler struct {
+	config Config
+}
+
+// SetUp is part of the watcher.NotifyHandler interface.
+func (h *upgradeSeriesHandler) SetUp() (watcher.NotifyWatcher, error) {
+	w, err := h.config.Facade.WatchUpgradeSeriesNotifications()
+	return w, errors.Trace(err)
+}
+
+// Handle is part of the watcher.NotifyHandler interface.
+func (h *upgradeSeriesHandler) Handle(_ <-chan struct{}) error {
+	status, err := h.config.Facade.MachineStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Debugf("machine upgrade series status is %q", status)
+	switch status {
+	case params.UpgradeSeriesPrepareStarted:
+		return h.handlePrepareStarted()
+	case params.UpgradeSeriesCompleteStarted:
+		return h.handleCompleteStarted()
+	}
+	return nil
+}
+
+// TearDown is part of the watcher.NotifyHandler interface.
+func (h *upgradeSeriesHandler) TearDown() error {
+	return nil
+}
+
+func (h *upgradeSeriesHandler) handlePrepareStarted() error {
+	unitNames, ready, err := h.unitsReady(params.UpgradeSeriesPrepareCompleted)
+	if err != nil || !ready {
+		return errors.Trace(err)
+	}
+	target, err := h.config.Facade.TargetSeries()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("preparing agents for series upgrade to %q", target)
+	if err := h.config.PrepareAgents(target, unitNames); err != nil {
+		// Retrying is unlikely to help, so record the failure
+		// for the operator rather than restarting the worker.
+		logger.Errorf("cannot prepare agents for series %q: %v", target, err)
+		return errors.Trace(h.config.Facade.SetMachineStatus(params.UpgradeSeriesError))
+	}
+	return errors.Trace(h.config.Facade.SetMachineStatus(params.UpgradeSeriesPrepareCompleted))
+}
+
+func (h *upgradeSeriesHandler) handleCompleteStarted() error {
+	unitNames, ready, err := h.unitsReady(params.UpgradeSeriesCompleted)
+	if err != nil || !ready {
+		return errors.Trace(err)
+	}
+	hostSeries := h.config.HostSeries()
+	target, err := h.config.Facade.TargetSeries()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if hostSeries != target {
+		// The upgrade was aborted, or the OS release upgrade did
+		// not take; either way the machine keeps its series.
+		logger.Warningf("machine is running series %q, not the upgrade target %q", hostSeries, target)
+		if err := h.config.RevertAgents(target, unitNames); err != nil {
+			logger.Errorf("cannot revert agent preparation for series %q: %v", target, err)
+			return errors.Trace(h.config.Facade.SetMachineStatus(params.UpgradeSeriesError))
+		}
+	}
+	logger.Infof("completing series upgrade to %q", hostSeries)
+	return errors.Trace(h.config.Facade.FinishUpgradeSeries(hostSeries))
+}
+
+// unitsReady returns the names of the units on the machine, and
+// whether all of them have reached the given status.
+func (h *upgradeSeriesHandler) unitsReady(want params.UpgradeSeriesStatus) ([]string, bool, error) {
+	statuses, err := h.config.Facade.UnitsStatuses()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	var unitNames []string
+	ready := true
+	for unitName, status := range statuses {
+		unitNames = append(unitNames, unitName)
+		if status != want {
+			logger.Debugf("waiting for unit %q: status is %q", unitName, status)
+			ready = false
+		}
+	}
+	return unitNames, ready, nil
+}
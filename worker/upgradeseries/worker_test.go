@@ -0,0 +1,188 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/upgradeseries"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	stub   *testing.Stub
+	facade *mockFacade
+	config upgradeseries.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = &testing.Stub{}
+	s.facade = &mockFacade{
+		stub:   s.stub,
+		called: make(chan string, 10),
+		target: "xenial",
+	}
+	s.config = upgradeseries.Config{
+		Facade:     s.facade,
+		HostSeries: func() string { return "xenial" },
+		PrepareAgents: func(target string, unitNames []string) error {
+			s.stub.AddCall("PrepareAgents", target, unitNames)
+			return s.stub.NextErr()
+		},
+		RevertAgents: func(target string, unitNames []string) error {
+			s.stub.AddCall("RevertAgents", target, unitNames)
+			return s.stub.NextErr()
+		},
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.config.Facade = nil
+	_, err := upgradeseries.NewWorker(s.config)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "nil Facade not valid")
+}
+
+func (s *WorkerSuite) runUntil(c *gc.C, method string) {
+	w, err := upgradeseries.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	for {
+		select {
+		case called := <-s.facade.called:
+			if called == method {
+				return
+			}
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for %s", method)
+		}
+	}
+}
+
+func (s *WorkerSuite) TestPrepare(c *gc.C) {
+	s.facade.status = params.UpgradeSeriesPrepareStarted
+	s.facade.units = map[string]params.UpgradeSeriesStatus{
+		"mysql/0": params.UpgradeSeriesPrepareCompleted,
+	}
+	s.runUntil(c, "SetMachineStatus")
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{"WatchUpgradeSeriesNotifications", nil},
+		{"MachineStatus", nil},
+		{"UnitsStatuses", nil},
+		{"TargetSeries", nil},
+		{"PrepareAgents", []interface{}{"xenial", []string{"mysql/0"}}},
+		{"SetMachineStatus", []interface{}{params.UpgradeSeriesPrepareCompleted}},
+	})
+}
+
+func (s *WorkerSuite) TestPrepareWaitsForUnits(c *gc.C) {
+	s.facade.status = params.UpgradeSeriesPrepareStarted
+	s.facade.units = map[string]params.UpgradeSeriesStatus{
+		"mysql/0": params.UpgradeSeriesPrepareStarted,
+	}
+	s.runUntil(c, "UnitsStatuses")
+	s.stub.CheckCallNames(c, "WatchUpgradeSeriesNotifications", "MachineStatus", "UnitsStatuses")
+}
+
+func (s *WorkerSuite) TestPrepareAgentsError(c *gc.C) {
+	s.facade.status = params.UpgradeSeriesPrepareStarted
+	s.stub.SetErrors(nil, nil, nil, nil, errors.New("boom"))
+	s.runUntil(c, "SetMachineStatus")
+	s.stub.CheckCall(c, 5, "SetMachineStatus", params.UpgradeSeriesError)
+}
+
+func (s *WorkerSuite) TestComplete(c *gc.C) {
+	s.facade.status = params.UpgradeSeriesCompleteStarted
+	s.facade.units = map[string]params.UpgradeSeriesStatus{
+		"mysql/0": params.UpgradeSeriesCompleted,
+	}
+	s.runUntil(c, "FinishUpgradeSeries")
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{"WatchUpgradeSeriesNotifications", nil},
+		{"MachineStatus", nil},
+		{"UnitsStatuses", nil},
+		{"TargetSeries", nil},
+		{"FinishUpgradeSeries", []interface{}{"xenial"}},
+	})
+}
+
+func (s *WorkerSuite) TestCompleteAborted(c *gc.C) {
+	s.config.HostSeries = func() string { return "trusty" }
+	s.facade.status = params.UpgradeSeriesCompleteStarted
+	s.facade.units = map[string]params.UpgradeSeriesStatus{
+		"mysql/0": params.UpgradeSeriesCompleted,
+	}
+	s.runUntil(c, "FinishUpgradeSeries")
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{"WatchUpgradeSeriesNotifications", nil},
+		{"MachineStatus", nil},
+		{"UnitsStatuses", nil},
+		{"TargetSeries", nil},
+		{"RevertAgents", []interface{}{"xenial", []string{"mysql/0"}}},
+		{"FinishUpgradeSeries", []interface{}{"trusty"}},
+	})
+}
+
+func (s *WorkerSuite) TestRevertAgentsError(c *gc.C) {
+	s.config.HostSeries = func() string { return "trusty" }
+	s.facade.status = params.UpgradeSeriesCompleteStarted
+	s.stub.SetErrors(nil, nil, nil, nil, errors.New("boom"))
+	s.runUntil(c, "SetMachineStatus")
+	s.stub.CheckCall(c, 5, "SetMachineStatus", params.UpgradeSeriesError)
+}
+
+type mockFacade struct {
+	stub   *testing.Stub
+	called chan string
+	status params.UpgradeSeriesStatus
+	units  map[string]params.UpgradeSeriesStatus
+	target string
+}
+
+func (f *mockFacade) record(method string, args ...interface{}) error {
+	f.stub.AddCall(method, args...)
+	f.called <- method
+	return f.stub.NextErr()
+}
+
+func (f *mockFacade) WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error) {
+	if err := f.record("WatchUpgradeSeriesNotifications"); err != nil {
+		return nil, err
+	}
+	w := workertest.NewFakeWatcher(1, 1)
+	return &w, nil
+}
+
+func (f *mockFacade) MachineStatus() (params.UpgradeSeriesStatus, error) {
+	return f.status, f.record("MachineStatus")
+}
+
+func (f *mockFacade) SetMachineStatus(status params.UpgradeSeriesStatus) error {
+	return f.record("SetMachineStatus", status)
+}
+
+func (f *mockFacade) TargetSeries() (string, error) {
+	return f.target, f.record("TargetSeries")
+}
+
+func (f *mockFacade) UnitsStatuses() (map[string]params.UpgradeSeriesStatus, error) {
+	return f.units, f.record("UnitsStatuses")
+}
+
+func (f *mockFacade) FinishUpgradeSeries(hostSeries string) error {
+	return f.record("FinishUpgradeSeries", hostSeries)
+}
@@ -5,6 +5,7 @@ package upgradesteps
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -377,8 +378,15 @@ func (w *upgradesteps) reportUpgradeFailure(err error, willRetry bool) {
 	}
 	logger.Errorf("upgrade from %v to %v for %q failed (%s): %v",
 		w.fromVersion, w.toVersion, w.tag, retryText, err)
+	// Record the steps that did run, so that whoever resolves the
+	// failure knows what has and hasn't been applied.
+	var data map[string]interface{}
+	if completed := upgrades.CompletedSteps(err); len(completed) > 0 {
+		logger.Infof("completed upgrade steps: %s", strings.Join(completed, "; "))
+		data = map[string]interface{}{"completed-steps": completed}
+	}
 	w.machine.SetStatus(status.StatusError,
-		fmt.Sprintf("upgrade to %v failed (%s): %v", w.toVersion, retryText, err), nil)
+		fmt.Sprintf("upgrade to %v failed (%s): %v", w.toVersion, retryText, err), data)
 }
 
 func (w *upgradesteps) finaliseUpgrade(info *state.UpgradeInfo) error {